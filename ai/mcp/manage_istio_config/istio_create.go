@@ -8,8 +8,11 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kiali/kiali/ai/mcputil"
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
 )
 
 func IstioCreate(r *http.Request, args map[string]interface{}, businessLayer *business.Layer, conf *config.Config) (res interface{}, status int) {
@@ -35,6 +38,10 @@ func IstioCreate(r *http.Request, args map[string]interface{}, businessLayer *bu
 		return fmt.Sprintf("Object type not managed: %s", gvk.String()), http.StatusBadRequest
 	}
 
+	if msg, code := checkEditCapability(r, conf, namespace, cluster); code != 0 {
+		return msg, code
+	}
+
 	if msg, code := checkNamespaceExists(r.Context(), businessLayer, namespace, cluster); code != 0 {
 		return msg, code
 	}
@@ -63,10 +70,29 @@ func IstioCreate(r *http.Request, args map[string]interface{}, businessLayer *bu
 
 	defer recoverFromPanic(&res, &status, kind, object, namespace)
 
-	_, err = businessLayer.IstioConfig.CreateIstioConfigDetail(r.Context(), cluster, namespace, gvk, body)
+	if msg, code, ok := proposeGitOpsChange(r, businessLayer, conf, gitops.Change{
+		Operation: models.GitOpsOperationCreate,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      object,
+		GVK:       gvk,
+	}, body); ok {
+		return msg, code
+	}
+
+	created, err := businessLayer.IstioConfig.CreateIstioConfigDetail(r.Context(), cluster, namespace, gvk, body)
+	audit.Log(r, conf, models.AuditSourceMCP, audit.Entry{
+		Operation: "CREATE",
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      object,
+		GVK:       gvk,
+		After:     created.Object,
+		Err:       err,
+		Message:   "Object: " + data,
+	})
 	if err != nil {
 		return classifyError(err, kind, object, namespace)
 	}
-	audit(r, "CREATE", namespace, gvk.String(), "Object: "+data)
 	return fmt.Sprintf("Successfully created %s %q in namespace %q", kind, object, namespace), http.StatusOK
 }
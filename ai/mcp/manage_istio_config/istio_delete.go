@@ -7,8 +7,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/kiali/kiali/ai/mcputil"
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
 )
 
 func IstioDelete(r *http.Request, args map[string]interface{}, businessLayer *business.Layer, conf *config.Config) (res interface{}, status int) {
@@ -34,6 +37,10 @@ func IstioDelete(r *http.Request, args map[string]interface{}, businessLayer *bu
 		return fmt.Sprintf("Object type not managed: %s", gvk.String()), http.StatusBadRequest
 	}
 
+	if msg, code := checkEditCapability(r, conf, namespace, cluster); code != 0 {
+		return msg, code
+	}
+
 	if msg, code := checkNamespaceExists(ctx, businessLayer, namespace, cluster); code != 0 {
 		return msg, code
 	}
@@ -43,16 +50,35 @@ func IstioDelete(r *http.Request, args map[string]interface{}, businessLayer *bu
 	// Check if the resource exists before attempting delete.
 	// The business layer treats not-found deletes as idempotent no-ops (returns nil),
 	// but the chatbot needs to know the resource was never there.
-	_, err := businessLayer.IstioConfig.GetIstioConfigDetails(ctx, cluster, namespace, gvk, object)
+	before, err := businessLayer.IstioConfig.GetIstioConfigDetails(ctx, cluster, namespace, gvk, object)
 	if err != nil {
 		return classifyError(err, kind, object, namespace)
 	}
 
+	if msg, code, ok := proposeGitOpsChange(r, businessLayer, conf, gitops.Change{
+		Operation: models.GitOpsOperationDelete,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      object,
+		GVK:       gvk,
+	}, nil); ok {
+		return msg, code
+	}
+
 	err = businessLayer.IstioConfig.DeleteIstioConfigDetail(ctx, cluster, namespace, gvk, object)
+	audit.Log(r, conf, models.AuditSourceMCP, audit.Entry{
+		Operation: "DELETE",
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      object,
+		GVK:       gvk,
+		Before:    before.Object,
+		Err:       err,
+		Message:   "Name: [" + object + "]",
+	})
 	if err != nil {
 		return classifyError(err, kind, object, namespace)
 	}
 
-	audit(r, "DELETE", namespace, gvk.String(), "Name: ["+object+"]")
 	return fmt.Sprintf("Successfully deleted %s %q from namespace %q", gvk.Kind, object, namespace), http.StatusOK
 }
@@ -3,6 +3,7 @@ package manage_istio_config
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,10 +12,13 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kiali/kiali/ai/mcputil"
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/handlers/authentication"
 	"github.com/kiali/kiali/kubernetes"
-	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 )
 
 // isGatewayAPIEnabled checks whether Gateway API CRDs are installed on the
@@ -52,19 +56,6 @@ func getSAClient(ki *mcputil.KialiInterface, clusterName string) kubernetes.Clie
 	return ki.ClientFactory.GetSAClient(clusterName)
 }
 
-func audit(r *http.Request, operation, namespace, gvk, message string) {
-	if config.Get().Server.AuditLog {
-		user := r.Header.Get("Kiali-User")
-		log.FromRequest(r).
-			Info().
-			Str("operation", operation).
-			Str("namespace", namespace).
-			Str("gvk", gvk).
-			Str("user", user).
-			Msgf("AUDIT: %s", message)
-	}
-}
-
 // checkNamespaceExists verifies that the target namespace exists in the cluster.
 // Returns a user-friendly (message, status) tuple if the namespace is missing
 // or inaccessible, or ("", 0) when it exists and is accessible.
@@ -75,6 +66,17 @@ func checkNamespaceExists(ctx context.Context, businessLayer *business.Layer, na
 	return "", 0
 }
 
+// checkEditCapability verifies that the Kiali roles of the user grant the edit-istio-config capability in the
+// namespace of the cluster: the chat only requires use-ai-chat, the writes of the model are made for the user.
+// Returns a (message, status) tuple when the capability is not granted, or ("", 0).
+func checkEditCapability(r *http.Request, conf *config.Config, namespace, cluster string) (string, int) {
+	identity := authentication.GetUserIdentityContext(r.Context())
+	if !authentication.HasCapability(conf, identity, config.KialiCapabilityEditIstioConfig, cluster, namespace) {
+		return fmt.Sprintf("The Kiali roles do not grant the [%s] capability in namespace [%s] of cluster [%s]", config.KialiCapabilityEditIstioConfig, namespace, cluster), http.StatusForbidden
+	}
+	return "", 0
+}
+
 // resolveObjectName tries to determine the resource name from the args map.
 // It checks "object" first, and if not found, extracts metadata.name from
 // the "data" YAML/JSON payload. If a name is found from data, it is written
@@ -130,3 +132,44 @@ func classifyError(err error, kind, object, namespace string) (string, int) {
 		return err.Error(), http.StatusInternalServerError
 	}
 }
+
+// proposeGitOpsChange proposes the write operation to the GitOps repository when the GitOps mode is
+// enabled, instead of applying it to the cluster. ok is false when the mode is disabled.
+func proposeGitOpsChange(r *http.Request, businessLayer *business.Layer, conf *config.Config, change gitops.Change, body []byte) (res string, status int, ok bool) {
+	proposer := gitops.FromContext(r.Context())
+	if proposer == nil {
+		return "", 0, false
+	}
+
+	change.User = r.Header.Get("Kiali-User")
+	proposed, err := businessLayer.IstioConfig.ProposeIstioConfigChange(r.Context(), proposer, change, body)
+	entry := audit.Entry{
+		Operation: change.Operation,
+		Cluster:   change.Cluster,
+		Namespace: change.Namespace,
+		Name:      change.Name,
+		GVK:       change.GVK,
+		Patch:     change.Patch,
+		Err:       err,
+		Message:   "GitOps proposal failed: Name: [" + change.Name + "]",
+	}
+	if err == nil {
+		entry.Message = "GitOps proposal: Name: [" + proposed.Name + "], Branch: [" + proposed.Branch + "], URL: [" + proposed.URL + "]"
+	}
+	audit.Log(r, conf, models.AuditSourceMCP, entry)
+
+	if errors.Is(err, gitops.ErrNoChanges) {
+		return fmt.Sprintf("No change proposed: %s %q in namespace %q is already in that state in the GitOps repository", change.GVK.Kind, change.Name, change.Namespace), http.StatusConflict, true
+	}
+	if err != nil {
+		res, status = classifyError(err, change.GVK.Kind, change.Name, change.Namespace)
+		return res, status, true
+	}
+
+	link := proposed.URL
+	if link == "" {
+		link = "branch " + proposed.Branch
+	}
+	return fmt.Sprintf("The cluster is managed through GitOps: proposed %q for review at %s. The change is applied when it is merged into %s.",
+		proposed.Title, link, proposed.BaseBranch), http.StatusAccepted, true
+}
@@ -8,8 +8,11 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/kiali/kiali/ai/mcputil"
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
 )
 
 func IstioPatch(r *http.Request, args map[string]interface{}, businessLayer *business.Layer, conf *config.Config) (res interface{}, status int) {
@@ -35,6 +38,10 @@ func IstioPatch(r *http.Request, args map[string]interface{}, businessLayer *bus
 		return fmt.Sprintf("Object type not managed: %s", gvk.String()), http.StatusBadRequest
 	}
 
+	if msg, code := checkEditCapability(r, conf, namespace, cluster); code != 0 {
+		return msg, code
+	}
+
 	if msg, code := checkNamespaceExists(r.Context(), businessLayer, namespace, cluster); code != 0 {
 		return msg, code
 	}
@@ -45,18 +52,40 @@ func IstioPatch(r *http.Request, args map[string]interface{}, businessLayer *bus
 	}
 
 	// Pre-check: verify the resource exists before attempting the patch.
-	_, err = businessLayer.IstioConfig.GetIstioConfigDetails(r.Context(), cluster, namespace, gvk, object)
+	before, err := businessLayer.IstioConfig.GetIstioConfigDetails(r.Context(), cluster, namespace, gvk, object)
 	if err != nil {
 		return classifyError(err, kind, object, namespace)
 	}
 
 	defer recoverFromPanic(&res, &status, kind, object, namespace)
 
-	_, err = businessLayer.IstioConfig.UpdateIstioConfigDetail(r.Context(), cluster, namespace, gvk, object, string(patchBytes))
+	if msg, code, ok := proposeGitOpsChange(r, businessLayer, conf, gitops.Change{
+		Operation: models.GitOpsOperationUpdate,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      object,
+		GVK:       gvk,
+		Patch:     string(patchBytes),
+	}, patchBytes); ok {
+		return msg, code
+	}
+
+	after, err := businessLayer.IstioConfig.UpdateIstioConfigDetail(r.Context(), cluster, namespace, gvk, object, string(patchBytes))
+	audit.Log(r, conf, models.AuditSourceMCP, audit.Entry{
+		Operation: "UPDATE",
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      object,
+		GVK:       gvk,
+		Before:    before.Object,
+		After:     after.Object,
+		Patch:     string(patchBytes),
+		Err:       err,
+		Message:   "Name: [" + object + "], Patch: " + data,
+	})
 	if err != nil {
 		return classifyError(err, kind, object, namespace)
 	}
 
-	audit(r, "UPDATE", namespace, gvk.String(), "Name: ["+object+"], Patch: "+data)
 	return fmt.Sprintf("Successfully patched %s %q in namespace %q", kind, object, namespace), http.StatusOK
 }
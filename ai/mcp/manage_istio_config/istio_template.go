@@ -0,0 +1,163 @@
+package manage_istio_config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kiali/kiali/ai/mcp/get_action_ui"
+	"github.com/kiali/kiali/ai/mcputil"
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
+)
+
+// IstioTemplateGenerate generates the Istio config of a traffic template for a service, without applying it.
+func IstioTemplateGenerate(r *http.Request, args map[string]interface{}, businessLayer *business.Layer, conf *config.Config) (*models.TrafficTemplateResult, string, int) {
+	cluster := mcputil.GetStringOrDefault(args, conf.KubernetesConfig.ClusterName, "clusterName")
+	namespace := mcputil.GetStringArg(args, "namespace")
+	service := mcputil.GetStringArg(args, "service")
+	template := mcputil.GetStringArg(args, "template")
+	data := mcputil.GetStringArg(args, "data")
+
+	request := models.TrafficTemplateRequest{}
+	if data != "" {
+		body, err := yaml.YAMLToJSON([]byte(data))
+		if err != nil {
+			return nil, fmt.Sprintf("Invalid data (must be valid JSON or YAML): %s", err.Error()), http.StatusBadRequest
+		}
+		if err := json.Unmarshal(body, &request); err != nil {
+			return nil, fmt.Sprintf("Invalid traffic template parameters: %s", err.Error()), http.StatusBadRequest
+		}
+	}
+
+	result, err := businessLayer.IstioConfig.GenerateTrafficTemplate(r.Context(), cluster, namespace, service, template, request)
+	if api_errors.IsBadRequest(err) {
+		return nil, fmt.Sprintf("Invalid traffic template parameters: %s", err.Error()), http.StatusBadRequest
+	}
+	if api_errors.IsNotFound(err) {
+		return nil, fmt.Sprintf("Service not found: %q does not exist in namespace %q", service, namespace), http.StatusNotFound
+	}
+	if err != nil {
+		return nil, err.Error(), http.StatusInternalServerError
+	}
+	return result, "", http.StatusOK
+}
+
+// IstioTemplateApply applies the objects of a traffic template, or proposes them in GitOps mode.
+// Templates with validation errors are not applied.
+func IstioTemplateApply(r *http.Request, result *models.TrafficTemplateResult, businessLayer *business.Layer, conf *config.Config) (string, int) {
+	if msg, code := checkEditCapability(r, conf, result.Namespace, result.Cluster); code != 0 {
+		return msg, code
+	}
+	if !result.Valid {
+		return "The traffic template was not applied because the generated config has validation errors:\n" + trafficTemplateSummary(result), http.StatusUnprocessableEntity
+	}
+
+	if gitops.FromContext(r.Context()) != nil {
+		messages := make([]string, 0, len(result.Objects))
+		for _, obj := range result.Objects {
+			body := []byte(obj.Manifest)
+			change := gitops.Change{
+				Operation: obj.Operation,
+				Cluster:   result.Cluster,
+				Namespace: result.Namespace,
+				Name:      obj.Name,
+				GVK:       obj.ObjectGVK,
+			}
+			if obj.Operation == models.GitOpsOperationUpdate {
+				change.Patch = obj.Patch
+				body = []byte(obj.Patch)
+			}
+			msg, code, _ := proposeGitOpsChange(r, businessLayer, conf, change, body)
+			if code != http.StatusAccepted && code != http.StatusConflict {
+				return msg, code
+			}
+			messages = append(messages, msg)
+		}
+		return strings.Join(messages, "\n"), http.StatusAccepted
+	}
+
+	written, err := businessLayer.IstioConfig.ApplyTrafficTemplate(r.Context(), result)
+	for i, obj := range result.Objects {
+		entry := audit.Entry{
+			Operation: obj.Operation,
+			Cluster:   result.Cluster,
+			Namespace: result.Namespace,
+			Name:      obj.Name,
+			GVK:       obj.ObjectGVK,
+			Patch:     obj.Patch,
+			Message:   "Traffic template: [" + result.Template + "], Service: [" + result.Service + "]",
+		}
+		if i < len(written) {
+			entry.After = written[i].Object
+		} else {
+			entry.Err = err
+		}
+		audit.Log(r, conf, models.AuditSourceMCP, entry)
+		if entry.Err != nil {
+			break
+		}
+	}
+	if err != nil {
+		return err.Error(), http.StatusInternalServerError
+	}
+
+	names := make([]string, 0, len(result.Objects))
+	for _, obj := range result.Objects {
+		names = append(names, fmt.Sprintf("%s %q", obj.ObjectGVK.Kind, obj.Name))
+	}
+	return fmt.Sprintf("Successfully applied the %s template to service %q in namespace %q: %s", result.Template, result.Service, result.Namespace, strings.Join(names, ", ")), http.StatusOK
+}
+
+// trafficTemplateActions returns a file action per generated object, so the UI can preview and apply them.
+func trafficTemplateActions(result *models.TrafficTemplateResult) []get_action_ui.Action {
+	actions := make([]get_action_ui.Action, 0, len(result.Objects))
+	for _, obj := range result.Objects {
+		payload, err := yaml.JSONToYAML(obj.Manifest)
+		if err != nil {
+			payload = obj.Manifest
+		}
+		operation := "create"
+		if obj.Operation == models.GitOpsOperationUpdate {
+			operation = "patch"
+		}
+		actions = append(actions, get_action_ui.Action{
+			Title:     fmt.Sprintf("Preview of files to %s", operation),
+			FileName:  fmt.Sprintf("%s_%s.yaml", strings.ToLower(obj.ObjectGVK.Kind), obj.Name),
+			Kind:      get_action_ui.ActionKindFile,
+			Payload:   string(payload),
+			Operation: operation,
+			Cluster:   result.Cluster,
+			Namespace: result.Namespace,
+			Group:     obj.ObjectGVK.Group,
+			Version:   obj.ObjectGVK.Version,
+			KindName:  obj.ObjectGVK.Kind,
+			Object:    obj.Name,
+		})
+	}
+	return actions
+}
+
+// trafficTemplateSummary describes the generated objects and their validation errors and warnings.
+func trafficTemplateSummary(result *models.TrafficTemplateResult) string {
+	lines := []string{}
+	for _, obj := range result.Objects {
+		lines = append(lines, fmt.Sprintf("- %s %s %q", strings.ToLower(obj.Operation), obj.ObjectGVK.Kind, obj.Name))
+	}
+	checks := []string{}
+	for key, validation := range result.Validations {
+		for _, check := range validation.Checks {
+			checks = append(checks, fmt.Sprintf("- %s %q %s: %s (%s)", key.ObjectGVK.Kind, key.Name, check.Severity, check.Message, check.Path))
+		}
+	}
+	sort.Strings(checks)
+	return strings.Join(append(lines, checks...), "\n")
+}
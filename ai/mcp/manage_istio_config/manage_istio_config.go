@@ -76,6 +76,9 @@ func Execute(kialiInterface *mcputil.KialiInterface, args map[string]interface{}
 	if action == "list" || action == "get" {
 		return "for list and get actions use the manage_istio_config_read tool", http.StatusBadRequest
 	}
+	if action == "template" {
+		return executeTemplate(kialiInterface, args, clusterName, confirmed, mcpMode)
+	}
 	if err := validateIstioConfigInput(args, isGatewayAPIEnabled, isInferenceAPIEnabled); err != nil {
 		return err.Error(), http.StatusBadRequest
 	}
@@ -172,8 +175,73 @@ func Execute(kialiInterface *mcputil.KialiInterface, args map[string]interface{}
 		}
 		return res, http.StatusOK
 	default:
-		return fmt.Sprintf("invalid action %q: must be one of create, patch, delete", action), http.StatusBadRequest
+		return fmt.Sprintf("invalid action %q: must be one of create, patch, delete, template", action), http.StatusBadRequest
+	}
+}
+
+// executeTemplate runs the template action: it generates the Istio config of a traffic template for a service and
+// returns it as a preview, or applies it once confirmed.
+func executeTemplate(kialiInterface *mcputil.KialiInterface, args map[string]interface{}, clusterName string, confirmed, mcpMode bool) (interface{}, int) {
+	namespace := mcputil.GetStringArg(args, "namespace")
+	if namespace == "" {
+		return "namespace is required for action \"template\"", http.StatusBadRequest
+	}
+	if mcputil.GetStringArg(args, "service") == "" {
+		return "service is required for action \"template\"", http.StatusBadRequest
+	}
+	if mcputil.GetStringArg(args, "template") == "" {
+		return "template is required for action \"template\"", http.StatusBadRequest
+	}
+	if msg, code := checkNamespaceExists(kialiInterface.Request.Context(), kialiInterface.BusinessLayer, namespace, clusterName); code != 0 {
+		return msg, code
+	}
+
+	result, msg, status := IstioTemplateGenerate(kialiInterface.Request, args, kialiInterface.BusinessLayer, kialiInterface.Conf)
+	if status != http.StatusOK {
+		return fmt.Sprintf("ERROR: %s", msg), http.StatusOK
+	}
+	previewActions := trafficTemplateActions(result)
+
+	if !confirmed {
+		validity := "The generated config is valid."
+		if !result.Valid {
+			validity = "The generated config has validation errors and it cannot be applied until they are fixed."
+		}
+		preview := fmt.Sprintf(
+			"PREVIEW READY: the %s template generated these objects for service %q:\n%s\n%s "+
+				"Show the preview to the user and ask: 'Does this look correct, and do you want me to proceed with applying the template?' "+
+				"If they say yes, call this tool again with the exact same arguments and 'confirmed': true.",
+			result.Template, result.Service, trafficTemplateSummary(result), validity)
+		if mcpMode {
+			manifests := []string{}
+			for _, action := range previewActions {
+				manifests = append(manifests, action.Payload)
+			}
+			return preview + "\n\n" + strings.Join(manifests, "---\n"), http.StatusOK
+		}
+		return struct {
+			Actions []get_action_ui.Action `json:"actions"`
+			Result  string                 `json:"result"`
+		}{
+			Actions: previewActions,
+			Result:  preview,
+		}, http.StatusOK
+	}
+
+	res, status := IstioTemplateApply(kialiInterface.Request, result, kialiInterface.BusinessLayer, kialiInterface.Conf)
+	if status != http.StatusOK && status != http.StatusAccepted {
+		res = fmt.Sprintf("ERROR: %s", res)
+	}
+	if !mcpMode {
+		return struct {
+			Actions []get_action_ui.Action `json:"actions"`
+			Result  interface{}            `json:"result"`
+		}{
+			Actions: previewActions,
+			Result:  res,
+		}, http.StatusOK
 	}
+	return res, http.StatusOK
 }
 
 func createFileAction(ctx context.Context, args map[string]interface{}, businessLayer *business.Layer, conf *config.Config) []get_action_ui.Action {
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	istio_api_v1alpha3 "istio.io/api/networking/v1alpha3"
 	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
 	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -547,6 +548,124 @@ func TestExecute_DeletePreview(t *testing.T) {
 	assert.Contains(t, resp.Result, "PREVIEW READY")
 }
 
+func templateObjects() []runtime.Object {
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	objs := []runtime.Object{&reviews}
+	for _, version := range []string{"v1", "v2"} {
+		objs = append(objs, &core_v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "reviews-" + version,
+			Namespace: "bookinfo",
+			Labels:    map[string]string{"app": "reviews", "version": version},
+		}})
+	}
+	return objs
+}
+
+func templateArgs(confirmed bool) map[string]interface{} {
+	return map[string]interface{}{
+		"action":    "template",
+		"confirmed": confirmed,
+		"namespace": "bookinfo",
+		"service":   "reviews",
+		"template":  "traffic_shifting",
+		"data":      "routes:\n- version: v1\n  weight: 90\n- version: v2\n  weight: 10\n",
+	}
+}
+
+func TestExecute_TemplatePreview(t *testing.T) {
+	businessLayer, conf := setupTest(t, templateObjects()...)
+	r := reqWithAuth()
+
+	res, status := Execute(kialiIntf(r, businessLayer, conf), templateArgs(false))
+	require.Equal(t, http.StatusOK, status)
+
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	var resp previewResponse
+	require.NoError(t, json.Unmarshal(b, &resp))
+
+	require.Len(t, resp.Actions, 2)
+	assert.Equal(t, "DestinationRule", resp.Actions[0].KindName)
+	assert.Equal(t, "VirtualService", resp.Actions[1].KindName)
+	assert.Equal(t, "create", resp.Actions[1].Operation)
+	assert.Contains(t, resp.Actions[1].Payload, "weight: 90")
+	assert.Contains(t, resp.Result, "PREVIEW READY")
+	assert.Contains(t, resp.Result, "The generated config is valid.")
+
+	_, err = businessLayer.IstioConfig.GetIstioConfigDetails(r.Context(), "east", "bookinfo", kubernetes.VirtualServices, "reviews")
+	assert.True(t, api_errors.IsNotFound(err), "the preview must not apply the template")
+}
+
+func TestExecute_TemplateConfirmed(t *testing.T) {
+	businessLayer, conf := setupTest(t, templateObjects()...)
+	r := reqWithAuth()
+
+	res, status := Execute(kialiIntf(r, businessLayer, conf), templateArgs(true))
+	require.Equal(t, http.StatusOK, status)
+
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	var resp previewResponse
+	require.NoError(t, json.Unmarshal(b, &resp))
+	assert.Contains(t, resp.Result, "Successfully applied the traffic_shifting template")
+
+	vs, err := businessLayer.IstioConfig.GetIstioConfigDetails(r.Context(), "east", "bookinfo", kubernetes.VirtualServices, "reviews")
+	require.NoError(t, err)
+	assert.Equal(t, "traffic_shifting", vs.Object.GetLabels()["kiali_wizard"])
+}
+
+func TestExecute_WritesRequireEditCapability(t *testing.T) {
+	businessLayer, conf := setupTest(t, append(templateObjects(), existingVS())...)
+	conf.Auth.KialiRBAC = config.KialiRBACConfig{
+		DefaultRoles: []string{"chat"},
+		Enabled:      true,
+		Roles:        []config.KialiRole{{Name: "chat", Capabilities: []string{config.KialiCapabilityUseAIChat}}},
+	}
+	r := reqWithAuth()
+
+	create := baseWriteArgs("create")
+	create["object"] = "ratings"
+	create["data"] = `{"metadata":{"name":"ratings","namespace":"bookinfo"},"spec":{"hosts":["ratings"]}}`
+	patch := baseWriteArgs("patch")
+	patch["data"] = `{"spec":{"hosts":["reviews","ratings"]}}`
+	for _, args := range []map[string]interface{}{create, patch, baseWriteArgs("delete"), templateArgs(true)} {
+		res, status := Execute(kialiIntf(r, businessLayer, conf), args)
+		require.Equal(t, http.StatusOK, status)
+		b, err := json.Marshal(res)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "do not grant the [edit-istio-config] capability", "action %s", args["action"])
+	}
+
+	vs, err := businessLayer.IstioConfig.GetIstioConfigDetails(r.Context(), "east", "bookinfo", kubernetes.VirtualServices, "reviews")
+	require.NoError(t, err)
+	assert.Empty(t, vs.Object.GetLabels()["kiali_wizard"])
+	_, err = businessLayer.IstioConfig.GetIstioConfigDetails(r.Context(), "east", "bookinfo", kubernetes.VirtualServices, "ratings")
+	assert.True(t, api_errors.IsNotFound(err))
+}
+
+func TestExecute_TemplateInvalid(t *testing.T) {
+	businessLayer, conf := setupTest(t, templateObjects()...)
+	r := reqWithAuth()
+
+	// There are no v3 workloads
+	args := templateArgs(true)
+	args["data"] = `{"routes":[{"version":"v1","weight":50},{"version":"v3","weight":50}]}`
+	res, status := Execute(kialiIntf(r, businessLayer, conf), args)
+	require.Equal(t, http.StatusOK, status)
+	b, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "ERROR: The traffic template was not applied")
+
+	args["data"] = `{"routes":[{"version":"v1","weight":50}]}`
+	res, status = Execute(kialiIntf(r, businessLayer, conf), args)
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, res, "Invalid traffic template parameters")
+
+	delete(args, "service")
+	_, status = Execute(kialiIntf(r, businessLayer, conf), args)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
 // ---------------------------------------------------------------------------
 // 3. Execute – redirects list/get to manage_istio_config_read
 // ---------------------------------------------------------------------------
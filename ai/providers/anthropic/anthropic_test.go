@@ -36,6 +36,10 @@ func (s *anthropicTestStore) DeleteConversations(_ string, _ []string) error {
 	return nil
 }
 
+func (s *anthropicTestStore) DeleteSessionConversations(_ string) error {
+	return nil
+}
+
 func (s *anthropicTestStore) Enabled() bool {
 	return s.enabled
 }
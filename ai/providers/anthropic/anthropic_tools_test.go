@@ -515,17 +515,17 @@ func TestConvertToolToAnthropic_FromToolDefinition_ManageIstioConfig(t *testing.
 	expected := anthropic.ToolUnionParam{
 		OfTool: &anthropic.ToolParam{
 			Name:        "manage_istio_config",
-			Description: param.NewOpt("Create, patch, or delete Istio, Gateway API, and Inference API config. Supports Istio resources (networking.istio.io, security.istio.io), Gateway API resources (gateway.networking.k8s.io), and Inference API resources (inference.networking.k8s.io) when installed on the cluster. For list and get (read-only) use manage_istio_config_read."),
+			Description: param.NewOpt("Create, patch, or delete Istio, Gateway API, and Inference API config. Supports Istio resources (networking.istio.io, security.istio.io), Gateway API resources (gateway.networking.k8s.io), and Inference API resources (inference.networking.k8s.io) when installed on the cluster. Use the 'template' action to generate and apply the validated traffic management config of a service (traffic shifting, fault injection, request timeouts). For list and get (read-only) use manage_istio_config_read."),
 			InputSchema: anthropic.ToolInputSchemaParam{
 				Properties: map[string]interface{}{
 					"action": map[string]interface{}{
 						"type":        "string",
-						"description": "Action to perform (write)",
-						"enum":        []interface{}{"create", "patch", "delete"},
+						"description": "Action to perform (write). 'template' generates the VirtualService and DestinationRule (or HTTPRoute) of a traffic template for a service, and requires service and template instead of group, version, kind and object.",
+						"enum":        []interface{}{"create", "patch", "delete", "template"},
 					},
 					"confirmed": map[string]interface{}{
 						"type":        "boolean",
-						"description": "CRITICAL: If 'true', the destructive action (create/patch/delete) is executed. If 'false' (or omitted) for create/patch, the tool returns a YAML PREVIEW. Display it to the user and ask for confirmation before calling again with confirmed=true.",
+						"description": "CRITICAL: If 'true', the destructive action (create/patch/delete/template) is executed. If 'false' (or omitted) for create/patch/template, the tool returns a YAML PREVIEW. Display it to the user and ask for confirmation before calling again with confirmed=true.",
 					},
 					"clusterName": map[string]interface{}{
 						"type":        "string",
@@ -535,9 +535,18 @@ func TestConvertToolToAnthropic_FromToolDefinition_ManageIstioConfig(t *testing.
 						"type":        "string",
 						"description": "Namespace containing the Istio object.",
 					},
+					"service": map[string]interface{}{
+						"type":        "string",
+						"description": "Service to generate the traffic template for. Required for the template action.",
+					},
+					"template": map[string]interface{}{
+						"type":        "string",
+						"description": "Traffic template to generate. Required for the template action.",
+						"enum":        []interface{}{"traffic_shifting", "fault_injection", "request_timeouts"},
+					},
 					"group": map[string]interface{}{
 						"type":        "string",
-						"description": "API group of the Istio object. Use 'gateway.networking.k8s.io' for Gateway API resources. Use 'inference.networking.k8s.io' for Inference API resources.",
+						"description": "API group of the Istio object. Required for create, patch and delete. Use 'gateway.networking.k8s.io' for Gateway API resources. Use 'inference.networking.k8s.io' for Inference API resources.",
 						"enum":        []interface{}{"networking.istio.io", "security.istio.io", "gateway.networking.k8s.io", "inference.networking.k8s.io"},
 					},
 					"version": map[string]interface{}{
@@ -557,11 +566,11 @@ func TestConvertToolToAnthropic_FromToolDefinition_ManageIstioConfig(t *testing.
 					},
 					"object": map[string]interface{}{
 						"type":        "string",
-						"description": "Name of the Istio object.",
+						"description": "Name of the Istio object. Required for create, patch and delete.",
 					},
 					"data": map[string]interface{}{
 						"type":        "string",
-						"description": "JSON or YAML data for the resource. Required for create and patch actions. For create, you can provide partial content (e.g. only spec) and it will be merged onto a valid template with defaults. Arrays (like servers, http, etc.) are REPLACED entirely, so include ALL elements you want.",
+						"description": "JSON or YAML data for the resource. Required for create and patch actions. For create, you can provide partial content (e.g. only spec) and it will be merged onto a valid template with defaults. Arrays (like servers, http, etc.) are REPLACED entirely, so include ALL elements you want. For the template action, the JSON or YAML parameters of the template: routes ([{version, weight}], weights adding up to 100; use backend instead of version with gatewayAPI), mirror ({version, percentage}), fault ({delayPercentage, fixedDelay, abortPercentage, httpStatus}), timeout (e.g. '2s'), retries ({attempts, perTryTimeout, retryOn}), connectionPool ({maxConnections, http1MaxPendingRequests, maxRequestsPerConnection}), outlierDetection ({consecutive5xxErrors, interval, baseEjectionTime, maxEjectionPercent}) and gatewayAPI (true to generate an HTTPRoute).",
 					},
 					"dataFormat": map[string]interface{}{
 						"type":        "string",
@@ -569,7 +578,7 @@ func TestConvertToolToAnthropic_FromToolDefinition_ManageIstioConfig(t *testing.
 						"enum":        []interface{}{"auto", "json", "yaml"},
 					},
 				},
-				Required: []string{"action", "confirmed", "namespace", "group", "version", "kind", "object"},
+				Required: []string{"action", "confirmed", "namespace"},
 				ExtraFields: map[string]any{
 					"additionalProperties": false,
 				},
@@ -28,6 +28,7 @@ type googleTestStore struct {
 
 func (s *googleTestStore) GenerateConversationID() string                 { return "test-conv-id" }
 func (s *googleTestStore) DeleteConversations(_ string, _ []string) error { return nil }
+func (s *googleTestStore) DeleteSessionConversations(_ string) error      { return nil }
 func (s *googleTestStore) Enabled() bool                                  { return s.enabled }
 func (s *googleTestStore) ReduceWithAI() bool                             { return false }
 func (s *googleTestStore) ReduceThreshold() int                           { return 0 }
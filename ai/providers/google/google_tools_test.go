@@ -428,12 +428,12 @@ func TestConvertToolToGoogle_FromToolDefinition_ManageIstioConfig(t *testing.T)
 		Properties: map[string]*genai.Schema{
 			"action": {
 				Type:        genai.TypeString,
-				Description: "Action to perform (write)",
-				Enum:        []string{"create", "patch", "delete"},
+				Description: "Action to perform (write). 'template' generates the VirtualService and DestinationRule (or HTTPRoute) of a traffic template for a service, and requires service and template instead of group, version, kind and object.",
+				Enum:        []string{"create", "patch", "delete", "template"},
 			},
 			"confirmed": {
 				Type:        genai.TypeBoolean,
-				Description: "CRITICAL: If 'true', the destructive action (create/patch/delete) is executed. If 'false' (or omitted) for create/patch, the tool returns a YAML PREVIEW. Display it to the user and ask for confirmation before calling again with confirmed=true.",
+				Description: "CRITICAL: If 'true', the destructive action (create/patch/delete/template) is executed. If 'false' (or omitted) for create/patch/template, the tool returns a YAML PREVIEW. Display it to the user and ask for confirmation before calling again with confirmed=true.",
 			},
 			"clusterName": {
 				Type:        genai.TypeString,
@@ -443,9 +443,18 @@ func TestConvertToolToGoogle_FromToolDefinition_ManageIstioConfig(t *testing.T)
 				Type:        genai.TypeString,
 				Description: "Namespace containing the Istio object.",
 			},
+			"service": {
+				Type:        genai.TypeString,
+				Description: "Service to generate the traffic template for. Required for the template action.",
+			},
+			"template": {
+				Type:        genai.TypeString,
+				Description: "Traffic template to generate. Required for the template action.",
+				Enum:        []string{"traffic_shifting", "fault_injection", "request_timeouts"},
+			},
 			"group": {
 				Type:        genai.TypeString,
-				Description: "API group of the Istio object. Use 'gateway.networking.k8s.io' for Gateway API resources. Use 'inference.networking.k8s.io' for Inference API resources.",
+				Description: "API group of the Istio object. Required for create, patch and delete. Use 'gateway.networking.k8s.io' for Gateway API resources. Use 'inference.networking.k8s.io' for Inference API resources.",
 				Enum:        []string{"networking.istio.io", "security.istio.io", "gateway.networking.k8s.io", "inference.networking.k8s.io"},
 			},
 			"version": {
@@ -465,11 +474,11 @@ func TestConvertToolToGoogle_FromToolDefinition_ManageIstioConfig(t *testing.T)
 			},
 			"object": {
 				Type:        genai.TypeString,
-				Description: "Name of the Istio object.",
+				Description: "Name of the Istio object. Required for create, patch and delete.",
 			},
 			"data": {
 				Type:        genai.TypeString,
-				Description: "JSON or YAML data for the resource. Required for create and patch actions. For create, you can provide partial content (e.g. only spec) and it will be merged onto a valid template with defaults. Arrays (like servers, http, etc.) are REPLACED entirely, so include ALL elements you want.",
+				Description: "JSON or YAML data for the resource. Required for create and patch actions. For create, you can provide partial content (e.g. only spec) and it will be merged onto a valid template with defaults. Arrays (like servers, http, etc.) are REPLACED entirely, so include ALL elements you want. For the template action, the JSON or YAML parameters of the template: routes ([{version, weight}], weights adding up to 100; use backend instead of version with gatewayAPI), mirror ({version, percentage}), fault ({delayPercentage, fixedDelay, abortPercentage, httpStatus}), timeout (e.g. '2s'), retries ({attempts, perTryTimeout, retryOn}), connectionPool ({maxConnections, http1MaxPendingRequests, maxRequestsPerConnection}), outlierDetection ({consecutive5xxErrors, interval, baseEjectionTime, maxEjectionPercent}) and gatewayAPI (true to generate an HTTPRoute).",
 			},
 			"dataFormat": {
 				Type:        genai.TypeString,
@@ -477,7 +486,7 @@ func TestConvertToolToGoogle_FromToolDefinition_ManageIstioConfig(t *testing.T)
 				Enum:        []string{"auto", "json", "yaml"},
 			},
 		},
-		Required: []string{"action", "confirmed", "namespace", "group", "version", "kind", "object"},
+		Required: []string{"action", "confirmed", "namespace"},
 	}
 
 	assert.Equal(t, expected, converted)
@@ -31,6 +31,10 @@ func (f *fakeStore) DeleteConversations(_ string, _ []string) error {
 	return nil
 }
 
+func (f *fakeStore) DeleteSessionConversations(_ string) error {
+	return nil
+}
+
 func (f *fakeStore) Enabled() bool {
 	return f.enabled
 }
@@ -29,6 +29,7 @@ type openaiTestStore struct {
 
 func (s *openaiTestStore) GenerateConversationID() string                 { return "test-conv-id" }
 func (s *openaiTestStore) DeleteConversations(_ string, _ []string) error { return nil }
+func (s *openaiTestStore) DeleteSessionConversations(_ string) error      { return nil }
 func (s *openaiTestStore) Enabled() bool                                  { return s.enabled }
 func (s *openaiTestStore) ReduceWithAI() bool                             { return false }
 func (s *openaiTestStore) ReduceThreshold() int                           { return 0 }
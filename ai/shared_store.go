@@ -0,0 +1,230 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kiali/kiali/ai/types"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/sessionstore"
+)
+
+// storedConversation is a conversation as kept in the session store. The provider specific
+// parameters of the messages are not kept: providers rebuild their messages from the content and role.
+type storedConversation struct {
+	LastAccessed time.Time       `json:"lastAccessed"`
+	Messages     []storedMessage `json:"messages"`
+}
+
+type storedMessage struct {
+	Content string `json:"content"`
+	Name    string `json:"name,omitempty"`
+	Role    string `json:"role"`
+}
+
+// SharedAIStore keeps the AI conversations in a session store shared by the Kiali replicas, so that
+// a conversation can continue in any replica. Conversations and usage metrics expire after the
+// inactivity timeout. The memory limit only applies to single conversations: the store bounds its size.
+type SharedAIStore struct {
+	config *AiStoreConfig
+	ctx    context.Context
+	// usageMu serializes the usage updates of this replica. Updates of different replicas can still race,
+	// which may lose some usage of concurrent requests of the same session.
+	usageMu sync.Mutex
+	store   sessionstore.Store
+}
+
+// NewSharedAIStore creates an AI store keeping the conversations in the session store.
+func NewSharedAIStore(ctx context.Context, config *AiStoreConfig, store sessionstore.Store) types.AIStore {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &SharedAIStore{config: config, ctx: ctx, store: store}
+}
+
+func conversationKey(sessionID string, conversationID string) string {
+	return "ai:" + sessionID + ":conversation:" + conversationID
+}
+
+func usageKey(sessionID string) string {
+	return "ai:" + sessionID + ":usage"
+}
+
+// ReduceWithAI returns true if AI should be used to reduce conversations
+func (s *SharedAIStore) ReduceWithAI() bool {
+	return s.config.ReduceWithAI
+}
+
+// ReduceThreshold returns the threshold for reducing conversations
+func (s *SharedAIStore) ReduceThreshold() int {
+	return s.config.ReduceThreshold
+}
+
+// Enabled returns true if the AI store is enabled
+func (s *SharedAIStore) Enabled() bool {
+	return s.config.Enabled
+}
+
+// GenerateConversationID returns a new unique conversation ID
+func (s *SharedAIStore) GenerateConversationID() string {
+	return uuid.New().String()
+}
+
+// DeleteConversations removes the given conversations from a session but
+// preserves the session's usage metrics so token accounting is not lost.
+func (s *SharedAIStore) DeleteConversations(sessionID string, conversationIDs []string) error {
+	keys := make([]string, 0, len(conversationIDs))
+	for _, id := range conversationIDs {
+		keys = append(keys, conversationKey(sessionID, id))
+	}
+	return s.store.Delete(s.ctx, keys...)
+}
+
+// DeleteSessionConversations removes all the conversations of a session. The session's
+// usage metrics are preserved, like in DeleteConversations.
+func (s *SharedAIStore) DeleteSessionConversations(sessionID string) error {
+	keys, err := s.store.Keys(s.ctx, conversationKey(sessionID, ""))
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.store.Delete(s.ctx, keys...)
+}
+
+// GetConversation retrieves a conversation by sessionID. Reading a conversation extends its expiration.
+func (s *SharedAIStore) GetConversation(sessionID string, conversationID string) (*types.Conversation, bool) {
+	key := conversationKey(sessionID, conversationID)
+	value, err := s.store.Get(s.ctx, key)
+	if err != nil {
+		if !errors.Is(err, sessionstore.ErrNotFound) {
+			log.Errorf("Unable to read AI conversation [%s] from the session store: %v", conversationID, err)
+		}
+		return &types.Conversation{}, false
+	}
+	stored := storedConversation{}
+	if err := json.Unmarshal(value, &stored); err != nil {
+		log.Errorf("Unable to parse AI conversation [%s] from the session store: %v", conversationID, err)
+		return &types.Conversation{}, false
+	}
+
+	conversation := &types.Conversation{LastAccessed: time.Now()}
+	for _, m := range stored.Messages {
+		conversation.Conversation = append(conversation.Conversation, types.ConversationMessage{Content: m.Content, Name: m.Name, Role: m.Role})
+	}
+	conversation.EstimatedMB = EstimateConversationMemory(conversation.Conversation)
+	stored.LastAccessed = conversation.LastAccessed
+	if err := s.setStored(key, stored); err != nil {
+		log.Debugf("Unable to extend the expiration of AI conversation [%s]: %v", conversationID, err)
+	}
+	return conversation, true
+}
+
+// SetConversation stores a conversation by sessionID
+func (s *SharedAIStore) SetConversation(sessionID string, conversationID string, conversation *types.Conversation) error {
+	conversation.Mu.Lock()
+	conversation.EstimatedMB = EstimateConversationMemory(conversation.Conversation)
+	conversation.LastAccessed = time.Now()
+	stored := storedConversation{LastAccessed: conversation.LastAccessed}
+	for _, m := range conversation.Conversation {
+		stored.Messages = append(stored.Messages, storedMessage{Content: m.Content, Name: m.Name, Role: m.Role})
+	}
+	estimatedMB := conversation.EstimatedMB
+	conversation.Mu.Unlock()
+
+	if estimatedMB > float64(s.config.MaxCacheMemoryMB) {
+		return fmt.Errorf("conversation [%s] requires %.2f MB but max cache memory is %d MB", conversationID, estimatedMB, s.config.MaxCacheMemoryMB)
+	}
+	return s.setStored(conversationKey(sessionID, conversationID), stored)
+}
+
+func (s *SharedAIStore) setStored(key string, value any) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(s.ctx, key, content, s.config.InactivityTimeout)
+}
+
+func (s *SharedAIStore) readUsage(sessionID string) (map[string]*types.UsageMetric, error) {
+	metrics := map[string]*types.UsageMetric{}
+	value, err := s.store.Get(s.ctx, usageKey(sessionID))
+	if err != nil {
+		if errors.Is(err, sessionstore.ErrNotFound) {
+			return metrics, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(value, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (s *SharedAIStore) RecordUsage(sessionID string, provider string, model string, usage types.TokenUsage) error {
+	if !usage.HasTokens() {
+		return nil
+	}
+
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+
+	metrics, err := s.readUsage(sessionID)
+	if err != nil {
+		return fmt.Errorf("unable to read AI usage metrics: %w", err)
+	}
+
+	now := time.Now()
+	key := usageMetricKey(provider, model)
+	metric, found := metrics[key]
+	if !found || metric == nil {
+		metric = &types.UsageMetric{
+			UserID:   sessionID,
+			Provider: provider,
+			Model:    model,
+			Since:    now,
+		}
+		metrics[key] = metric
+	}
+	metric.RequestCount++
+	metric.PromptTokens += usage.PromptTokens
+	metric.CompletionTokens += usage.CompletionTokens
+	metric.TotalTokens += usage.TotalTokens
+	if metric.TotalTokens == 0 {
+		metric.TotalTokens = metric.PromptTokens + metric.CompletionTokens
+	}
+	metric.LastUpdated = now
+
+	return s.setStored(usageKey(sessionID), metrics)
+}
+
+func (s *SharedAIStore) GetUsageMetrics(sessionID string) []types.UsageMetric {
+	stored, err := s.readUsage(sessionID)
+	if err != nil {
+		log.Errorf("Unable to read AI usage metrics from the session store: %v", err)
+		return []types.UsageMetric{}
+	}
+
+	metrics := make([]types.UsageMetric, 0, len(stored))
+	for _, metric := range stored {
+		if metric == nil {
+			continue
+		}
+		metrics = append(metrics, *metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Provider != metrics[j].Provider {
+			return metrics[i].Provider < metrics[j].Provider
+		}
+		return metrics[i].Model < metrics[j].Model
+	})
+	return metrics
+}
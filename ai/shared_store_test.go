@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/ai/types"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/sessionstore/sessionstoretest"
+)
+
+func newSharedTestStores(t *testing.T) (types.AIStore, types.AIStore) {
+	conf := config.NewConfig()
+	conf.SessionStore.Type = config.SessionStoreTypeRedis
+	conf.SessionStore.Redis.Address = sessionstoretest.NewRedisServer(t, "").Addr()
+	storeConfig := &AiStoreConfig{Enabled: true, InactivityTimeout: time.Hour, MaxCacheMemoryMB: 1}
+	return NewSharedAIStore(t.Context(), storeConfig, sessionstore.NewRedisStore(conf)),
+		NewSharedAIStore(t.Context(), storeConfig, sessionstore.NewRedisStore(conf))
+}
+
+func TestSharedAIStore_ConversationContinuesInOtherReplica(t *testing.T) {
+	replicaA, replicaB := newSharedTestStores(t)
+
+	conversation := &types.Conversation{Conversation: []types.ConversationMessage{
+		{Content: "You are Kiali", Role: "system", Param: map[string]string{"ignored": "true"}},
+		{Content: "Why is my service failing?", Role: "user"},
+	}}
+	require.NoError(t, replicaA.SetConversation("session-1", "conv-1", conversation))
+
+	read, found := replicaB.GetConversation("session-1", "conv-1")
+	require.True(t, found)
+	require.Len(t, read.Conversation, 2)
+	assert.Equal(t, "Why is my service failing?", read.Conversation[1].Content)
+	assert.Nil(t, read.Conversation[0].Param)
+
+	_, found = replicaB.GetConversation("session-2", "conv-1")
+	assert.False(t, found)
+
+	require.NoError(t, replicaB.DeleteConversations("session-1", []string{"conv-1"}))
+	_, found = replicaA.GetConversation("session-1", "conv-1")
+	assert.False(t, found)
+}
+
+func TestSharedAIStore_UsageMetricsAreShared(t *testing.T) {
+	replicaA, replicaB := newSharedTestStores(t)
+
+	require.NoError(t, replicaA.RecordUsage("session-1", "openai", "gpt", types.TokenUsage{PromptTokens: 10, CompletionTokens: 5}))
+	require.NoError(t, replicaB.RecordUsage("session-1", "openai", "gpt", types.TokenUsage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2}))
+	require.NoError(t, replicaB.RecordUsage("session-1", "anthropic", "claude", types.TokenUsage{TotalTokens: 3}))
+
+	metrics := replicaA.GetUsageMetrics("session-1")
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "anthropic", metrics[0].Provider)
+	assert.Equal(t, int64(2), metrics[1].RequestCount)
+	assert.Equal(t, int64(11), metrics[1].PromptTokens)
+	assert.Empty(t, replicaA.GetUsageMetrics("session-2"))
+}
+
+func TestSharedAIStore_RejectsConversationOverMemoryLimit(t *testing.T) {
+	replicaA, _ := newSharedTestStores(t)
+
+	large := make([]types.ConversationMessage, 0, 2048)
+	for range 2048 {
+		large = append(large, types.ConversationMessage{Content: string(make([]byte, 1024)), Role: "user"})
+	}
+	err := replicaA.SetConversation("session-1", "conv-1", &types.Conversation{Conversation: large})
+	assert.ErrorContains(t, err, "max cache memory")
+}
+
+func TestSharedAIStore_DeleteSessionConversations(t *testing.T) {
+	replicaA, replicaB := newSharedTestStores(t)
+
+	for _, id := range []string{"conv-1", "conv-2"} {
+		require.NoError(t, replicaA.SetConversation("session-1", id, &types.Conversation{}))
+	}
+	require.NoError(t, replicaA.SetConversation("session-2", "conv-1", &types.Conversation{}))
+	require.NoError(t, replicaA.RecordUsage("session-1", "openai", "gpt", types.TokenUsage{TotalTokens: 3}))
+
+	require.NoError(t, replicaB.DeleteSessionConversations("session-1"))
+	for _, id := range []string{"conv-1", "conv-2"} {
+		_, found := replicaA.GetConversation("session-1", id)
+		assert.False(t, found)
+	}
+	_, found := replicaA.GetConversation("session-2", "conv-1")
+	assert.True(t, found)
+	assert.Len(t, replicaA.GetUsageMetrics("session-1"), 1, "usage metrics are preserved")
+}
@@ -101,6 +101,25 @@ func (s *AIStoreImpl) DeleteConversations(sessionID string, conversationIDs []st
 	return nil
 }
 
+// DeleteSessionConversations removes all the conversations of a session. The session's
+// UsageMetrics are preserved, like in DeleteConversations.
+func (s *AIStoreImpl) DeleteSessionConversations(sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessionConversation, exists := s.conversations[sessionID]
+	if !exists {
+		return nil
+	}
+
+	sessionConversation.mu.Lock()
+	sessionConversation.Conversation = make(map[string]*types.Conversation)
+	sessionConversation.mu.Unlock()
+
+	internalmetrics.SetAIStoreConversationsTotal(s.totalConversationsLocked())
+	return nil
+}
+
 // GetConversation retrieves a conversation by sessionID
 func (s *AIStoreImpl) GetConversation(sessionID string, conversationID string) (*types.Conversation, bool) {
 	s.mu.RLock()
@@ -70,6 +70,25 @@ func TestStore_SetAndGetConversation(t *testing.T) {
 	assert.Equal(t, "hello", got.Conversation[0].Content)
 }
 
+func TestStore_DeleteSessionConversations(t *testing.T) {
+	store := NewAIStore(context.Background(), nil)
+
+	require.NoError(t, store.SetConversation("session-1", "conv-1", &types.Conversation{}))
+	require.NoError(t, store.SetConversation("session-1", "conv-2", &types.Conversation{}))
+	require.NoError(t, store.SetConversation("session-2", "conv-1", &types.Conversation{}))
+	require.NoError(t, store.RecordUsage("session-1", "openai", "gpt", types.TokenUsage{TotalTokens: 3}))
+
+	require.NoError(t, store.DeleteSessionConversations("session-1"))
+	_, found := store.GetConversation("session-1", "conv-1")
+	assert.False(t, found)
+	_, found = store.GetConversation("session-1", "conv-2")
+	assert.False(t, found)
+	_, found = store.GetConversation("session-2", "conv-1")
+	assert.True(t, found)
+	assert.Len(t, store.GetUsageMetrics("session-1"), 1, "usage metrics are preserved")
+	require.NoError(t, store.DeleteSessionConversations("unknown"))
+}
+
 func TestStore_GetConversation_NotFound(t *testing.T) {
 	store := NewAIStore(context.Background(), nil)
 
@@ -3,6 +3,7 @@ package types
 // AIStore defines the interface for storing AI conversations
 type AIStore interface {
 	DeleteConversations(sessionID string, conversationIDs []string) error
+	DeleteSessionConversations(sessionID string) error
 	Enabled() bool
 	GenerateConversationID() string
 	GetConversation(sessionID string, conversationID string) (*Conversation, bool)
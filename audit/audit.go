@@ -0,0 +1,142 @@
+// Package audit records structured audit records for the write operations performed through Kiali
+// and dispatches them to the sinks enabled in the server.audit_trail configuration.
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// ErrSearchNotSupported is returned by Search when no enabled sink is able to query its records.
+var ErrSearchNotSupported = errors.New("audit search requires the file sink or in-memory audit records to be enabled")
+
+// Sink receives every audit record produced by Kiali.
+type Sink interface {
+	// Name identifies the sink in logs.
+	Name() string
+	// Write stores or forwards the record.
+	Write(ctx context.Context, record models.AuditRecord) error
+}
+
+// Searcher is implemented by the sinks that can query the records they stored.
+type Searcher interface {
+	// Search returns the records matching the query, newest first.
+	Search(ctx context.Context, query models.AuditQuery) ([]models.AuditRecord, error)
+}
+
+// Trail dispatches audit records to the configured sinks and serves searches.
+type Trail struct {
+	searcher Searcher
+	sinks    []Sink
+}
+
+// NewTrail creates a Trail with the sinks enabled in conf.Server.AuditTrail.
+// Searches are served by the file sink when it is enabled, otherwise by the in-memory records.
+// The background sinks stop when ctx is done.
+func NewTrail(ctx context.Context, conf *config.Config, clientFactory kubernetes.ClientFactory) (*Trail, error) {
+	trailConf := conf.Server.AuditTrail
+	trail := &Trail{}
+
+	if trailConf.File.Enabled {
+		fileSink := NewFileSink(trailConf.File.Path, trailConf.File.MaxSizeMB, trailConf.File.MaxBackups)
+		trail.sinks = append(trail.sinks, fileSink)
+		trail.searcher = fileSink
+	} else if trailConf.MaxMemoryRecords > 0 {
+		memorySink := NewMemorySink(trailConf.MaxMemoryRecords)
+		trail.sinks = append(trail.sinks, memorySink)
+		trail.searcher = memorySink
+	}
+
+	if trailConf.Webhook.Enabled {
+		webhookSink, err := NewWebhookSink(ctx, conf, trailConf.Webhook)
+		if err != nil {
+			return nil, err
+		}
+		trail.sinks = append(trail.sinks, webhookSink)
+	}
+
+	if trailConf.KubernetesEvents.Enabled {
+		trail.sinks = append(trail.sinks, NewEventsSink(conf, clientFactory))
+	}
+
+	return trail, nil
+}
+
+// NewTrailWithSinks creates a Trail writing to the given sinks. The first sink implementing Searcher serves searches.
+func NewTrailWithSinks(sinks ...Sink) *Trail {
+	trail := &Trail{sinks: sinks}
+	for _, sink := range sinks {
+		if searcher, ok := sink.(Searcher); ok {
+			trail.searcher = searcher
+			break
+		}
+	}
+	return trail
+}
+
+// Record completes the record identity and timestamp and writes it to every sink.
+// Sink failures are logged and never returned: auditing must not break the audited operation.
+func (t *Trail) Record(ctx context.Context, record models.AuditRecord) {
+	if t == nil {
+		return
+	}
+	if record.ID == "" {
+		record.ID = uuid.NewString()
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	for _, sink := range t.sinks {
+		if err := sink.Write(ctx, record); err != nil {
+			log.WithGroup(log.AuditLogName).Error().Msgf("Unable to write audit record [%s] to the %s sink: %v", record.ID, sink.Name(), err)
+		}
+	}
+}
+
+// Searchable returns true when one of the sinks can serve searches.
+func (t *Trail) Searchable() bool {
+	return t != nil && t.searcher != nil
+}
+
+// Search returns the records matching the query, newest first.
+func (t *Trail) Search(ctx context.Context, query models.AuditQuery) ([]models.AuditRecord, error) {
+	if !t.Searchable() {
+		return nil, ErrSearchNotSupported
+	}
+	return t.searcher.Search(ctx, query)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the trail.
+func NewContext(ctx context.Context, trail *Trail) context.Context {
+	return context.WithValue(ctx, contextKey{}, trail)
+}
+
+// FromContext returns the trail stored in ctx, or nil when there is none.
+func FromContext(ctx context.Context) *Trail {
+	if trail, ok := ctx.Value(contextKey{}).(*Trail); ok {
+		return trail
+	}
+	return nil
+}
+
+// limitRecords reverses the chronologically ordered records so the newest come first and applies the limit.
+func limitRecords(records []models.AuditRecord, limit int) []models.AuditRecord {
+	result := make([]models.AuditRecord, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		if limit > 0 && len(result) == limit {
+			break
+		}
+		result = append(result, records[i])
+	}
+	return result
+}
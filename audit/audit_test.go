@@ -0,0 +1,255 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	utilcontext "github.com/kiali/kiali/util/context"
+)
+
+func newRecord(user, namespace, name string, ts time.Time) models.AuditRecord {
+	return models.AuditRecord{
+		Timestamp: ts,
+		User:      user,
+		Operation: "UPDATE",
+		Cluster:   "east",
+		Namespace: namespace,
+		Name:      name,
+		ObjectGVK: kubernetes.VirtualServices,
+		Result:    models.AuditResultSuccess,
+	}
+}
+
+func TestMemorySinkSearch(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	now := time.Now()
+	sink := audit.NewMemorySink(3)
+	trail := audit.NewTrailWithSinks(sink)
+	trail.Record(context.TODO(), newRecord("alice", "bookinfo", "reviews", now.Add(-4*time.Hour)))
+	trail.Record(context.TODO(), newRecord("alice", "bookinfo", "reviews", now.Add(-3*time.Hour)))
+	trail.Record(context.TODO(), newRecord("bob", "bookinfo", "ratings", now.Add(-2*time.Hour)))
+	trail.Record(context.TODO(), newRecord("alice", "travels", "cars", now.Add(-1*time.Hour)))
+
+	// The oldest record has been evicted
+	records, err := trail.Search(context.TODO(), models.AuditQuery{})
+	require.NoError(err)
+	require.Len(records, 3)
+	assert.Equal("cars", records[0].Name)
+	assert.Equal("reviews", records[2].Name)
+	assert.NotEmpty(records[0].ID)
+
+	records, err = trail.Search(context.TODO(), models.AuditQuery{User: "alice"})
+	require.NoError(err)
+	assert.Len(records, 2)
+
+	records, err = trail.Search(context.TODO(), models.AuditQuery{Namespace: "bookinfo", Name: "ratings"})
+	require.NoError(err)
+	require.Len(records, 1)
+	assert.Equal("bob", records[0].User)
+
+	records, err = trail.Search(context.TODO(), models.AuditQuery{StartTime: now.Add(-150 * time.Minute), EndTime: now})
+	require.NoError(err)
+	assert.Len(records, 2)
+
+	records, err = trail.Search(context.TODO(), models.AuditQuery{Limit: 1})
+	require.NoError(err)
+	require.Len(records, 1)
+	assert.Equal("cars", records[0].Name)
+}
+
+func TestSearchNotSupported(t *testing.T) {
+	trail := audit.NewTrailWithSinks()
+	assert.False(t, trail.Searchable())
+
+	_, err := trail.Search(context.TODO(), models.AuditQuery{})
+	assert.True(t, errors.Is(err, audit.ErrSearchNotSupported))
+}
+
+func TestFileSinkSearch(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink := audit.NewFileSink(path, 0, 2)
+	defer sink.Close()
+	trail := audit.NewTrailWithSinks(sink)
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		trail.Record(context.TODO(), newRecord("alice", "bookinfo", "reviews", now.Add(time.Duration(i)*time.Minute)))
+	}
+	records, err := trail.Search(context.TODO(), models.AuditQuery{})
+	require.NoError(err)
+	require.Len(records, 3)
+	assert.True(records[0].Timestamp.After(records[2].Timestamp))
+
+	// Records written before a restart are still searchable
+	reopened := audit.NewFileSink(path, 0, 2)
+	defer reopened.Close()
+	records, err = reopened.Search(context.TODO(), models.AuditQuery{User: "alice"})
+	require.NoError(err)
+	assert.Len(records, 3)
+}
+
+func TestFileSinkRotationDropsOldFiles(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink := audit.NewFileSink(path, 1, 1)
+	defer sink.Close()
+
+	// Each record is bigger than half the maximum size: every write rotates the file
+	record := newRecord("alice", "bookinfo", "reviews", time.Now())
+	record.Patch = string(make([]byte, 600*1024))
+	for i := 0; i < 4; i++ {
+		record.ID = string(rune('a' + i))
+		require.NoError(sink.Write(context.TODO(), record))
+	}
+
+	records, err := sink.Search(context.TODO(), models.AuditQuery{})
+	require.NoError(err)
+	require.Len(records, 2)
+	assert.Equal(t, "d", records[0].ID)
+	assert.Equal(t, "c", records[1].ID)
+}
+
+func TestWebhookSink(t *testing.T) {
+	require := require.New(t)
+
+	received := make(chan models.AuditRecord, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-Audit-Key"))
+		record := models.AuditRecord{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+		received <- record
+	}))
+	defer server.Close()
+
+	conf := config.NewConfig()
+	sink, err := audit.NewWebhookSink(t.Context(), conf, config.AuditWebhookSink{
+		Headers: map[string]string{"X-Audit-Key": "secret"},
+		Timeout: 5 * time.Second,
+		URL:     server.URL,
+	})
+	require.NoError(err)
+
+	require.NoError(sink.Write(context.TODO(), newRecord("alice", "bookinfo", "reviews", time.Now())))
+	record := <-received
+	assert.Equal(t, "alice", record.User)
+	assert.Equal(t, "reviews", record.Name)
+}
+
+func TestWebhookSinkDoesNotWaitForTheEndpoint(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sink, err := audit.NewWebhookSink(t.Context(), config.NewConfig(), config.AuditWebhookSink{Timeout: time.Minute, URL: server.URL})
+	require.NoError(t, err)
+
+	// The records are queued while the endpoint hangs, and dropped once the queue is full
+	var dropped error
+	for i := 0; i < 2000 && dropped == nil; i++ {
+		dropped = sink.Write(context.TODO(), newRecord("alice", "bookinfo", "reviews", time.Now()))
+	}
+	assert.Error(t, dropped)
+}
+
+func TestEventsSink(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	k8s := kubetest.NewFakeK8sClient(&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "bookinfo"}})
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+
+	record := newRecord("alice", "bookinfo", "reviews", time.Now())
+	record.Result = models.AuditResultFailure
+	record.Error = "forbidden"
+	require.NoError(audit.NewEventsSink(conf, cf).Write(context.TODO(), record))
+
+	events, err := k8s.Kube().CoreV1().Events("bookinfo").List(context.TODO(), meta_v1.ListOptions{})
+	require.NoError(err)
+	require.Len(events.Items, 1)
+	event := events.Items[0]
+	assert.Equal(core_v1.EventTypeWarning, event.Type)
+	assert.Equal("VirtualService", event.InvolvedObject.Kind)
+	assert.Equal("reviews", event.InvolvedObject.Name)
+	assert.Contains(event.Message, "alice")
+	assert.Contains(event.Message, "forbidden")
+}
+
+func TestLogRecordsEntry(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyToken
+	trail := audit.NewTrailWithSinks(audit.NewMemorySink(10))
+
+	vs := &networking_v1.VirtualService{ObjectMeta: meta_v1.ObjectMeta{
+		Name:          "reviews",
+		Namespace:     "bookinfo",
+		ManagedFields: []meta_v1.ManagedFieldsEntry{{Manager: "kubectl"}},
+	}}
+	req := httptest.NewRequest(http.MethodPatch, "/api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService/reviews", nil)
+	req.Header.Set("Kiali-User", "alice")
+	req = req.WithContext(audit.NewContext(utilcontext.SetAPITokenIDContext(req.Context(), "token-1"), trail))
+
+	audit.Log(req, conf, models.AuditSourceAPI, audit.Entry{
+		Operation: "UPDATE",
+		Cluster:   "east",
+		Namespace: "bookinfo",
+		Name:      "reviews",
+		GVK:       kubernetes.VirtualServices,
+		Before:    vs,
+		After:     (*networking_v1.VirtualService)(nil),
+		Patch:     `{"spec":{}}`,
+		Err:       errors.New("conflict"),
+	})
+
+	records, err := trail.Search(context.TODO(), models.AuditQuery{})
+	require.NoError(err)
+	require.Len(records, 1)
+	record := records[0]
+	assert.Equal("alice", record.User)
+	assert.Equal(config.AuthStrategyToken, record.AuthStrategy)
+	assert.Equal("token-1", record.APITokenID)
+	assert.Equal(models.AuditSourceAPI, record.Source)
+	assert.Equal(models.AuditResultFailure, record.Result)
+	assert.Equal("conflict", record.Error)
+	assert.Nil(record.After)
+	assert.Contains(string(record.Before), `"name":"reviews"`)
+	assert.NotContains(string(record.Before), "kubectl")
+	// The original object is not modified
+	assert.Len(vs.ManagedFields, 1)
+
+	conf.Server.AuditLog = false
+	audit.Log(req, conf, models.AuditSourceAPI, audit.Entry{Operation: "DELETE"})
+	records, err = trail.Search(context.TODO(), models.AuditQuery{})
+	require.NoError(err)
+	assert.Len(records, 1)
+}
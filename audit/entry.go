@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	utilcontext "github.com/kiali/kiali/util/context"
+)
+
+// Entry describes a write operation performed on behalf of the user of a request.
+type Entry struct {
+	Operation string
+	Cluster   string
+	Namespace string
+	Name      string
+	GVK       schema.GroupVersionKind
+	// Object before the operation, nil when it is not known or did not exist
+	Before client.Object
+	// Object after the operation, nil when it is not known or was deleted
+	After client.Object
+	Patch string
+	// Error returned by the operation, nil when it succeeded
+	Err error
+	// Message written to the Kiali log
+	Message string
+}
+
+// Log writes the operation to the Kiali log and records it in the trail stored in the request context.
+// Nothing is audited when server.audit_log is disabled.
+func Log(r *http.Request, conf *config.Config, source string, entry Entry) {
+	if !conf.Server.AuditLog {
+		return
+	}
+
+	user := r.Header.Get("Kiali-User")
+	apiTokenID := utilcontext.GetAPITokenIDContext(r.Context())
+	event := log.FromRequest(r).Info()
+	if entry.Err != nil {
+		event = log.FromRequest(r).Warn().Str("error", entry.Err.Error())
+	}
+	if apiTokenID != "" {
+		event = event.Str("apiToken", apiTokenID)
+	}
+	event.
+		Str("operation", entry.Operation).
+		Str("namespace", entry.Namespace).
+		Str("gvk", entry.GVK.String()).
+		Str("user", user).
+		Msgf("AUDIT: %s", entry.Message)
+
+	trail := FromContext(r.Context())
+	if trail == nil {
+		return
+	}
+
+	record := models.AuditRecord{
+		User:         user,
+		AuthStrategy: conf.Auth.Strategy,
+		Source:       source,
+		Operation:    entry.Operation,
+		Cluster:      entry.Cluster,
+		Namespace:    entry.Namespace,
+		Name:         entry.Name,
+		ObjectGVK:    entry.GVK,
+		Before:       marshalObject(entry.Before),
+		After:        marshalObject(entry.After),
+		Patch:        entry.Patch,
+		Result:       models.AuditResultSuccess,
+		Message:      entry.Message,
+	}
+	if entry.Err != nil {
+		record.Result = models.AuditResultFailure
+		record.Error = entry.Err.Error()
+	}
+	if headers := utilcontext.GetRequestHeadersContext(r.Context()); headers != nil {
+		record.RequestID = headers.XRequestID
+	}
+	record.APITokenID = apiTokenID
+
+	trail.Record(r.Context(), record)
+}
+
+// marshalObject serializes the object without its managed fields, which are noise in an audit record.
+func marshalObject(obj client.Object) json.RawMessage {
+	if obj == nil {
+		return nil
+	}
+	copied, ok := obj.DeepCopyObject().(client.Object)
+	if !ok || copied == nil {
+		return nil
+	}
+	copied.SetManagedFields(nil)
+	raw, err := json.Marshal(copied)
+	if err != nil || string(raw) == "null" {
+		return nil
+	}
+	return raw
+}
@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	eventsComponent = "kiali"
+	eventsReason    = "KialiAudit"
+)
+
+// EventsSink records audit records as Kubernetes Events involving the modified object,
+// created with the Kiali service account in the cluster of the object.
+type EventsSink struct {
+	clientFactory kubernetes.ClientFactory
+	conf          *config.Config
+}
+
+// NewEventsSink creates an EventsSink.
+func NewEventsSink(conf *config.Config, clientFactory kubernetes.ClientFactory) *EventsSink {
+	return &EventsSink{clientFactory: clientFactory, conf: conf}
+}
+
+func (e *EventsSink) Name() string {
+	return "kubernetes-events"
+}
+
+func (e *EventsSink) Write(ctx context.Context, record models.AuditRecord) error {
+	client := e.clientFactory.GetSAClient(record.Cluster)
+	if client == nil {
+		return fmt.Errorf("no client found for cluster [%s]", record.Cluster)
+	}
+
+	namespace := record.Namespace
+	if namespace == "" {
+		namespace = e.conf.Deployment.Namespace
+	}
+
+	eventType := core_v1.EventTypeNormal
+	message := fmt.Sprintf("%s of %s [%s] by user [%s]", record.Operation, record.ObjectGVK.Kind, record.Name, record.User)
+	if record.Result == models.AuditResultFailure {
+		eventType = core_v1.EventTypeWarning
+		message = fmt.Sprintf("%s failed: %s", message, record.Error)
+	}
+
+	involvedObject := core_v1.ObjectReference{
+		APIVersion: record.ObjectGVK.GroupVersion().String(),
+		Kind:       record.ObjectGVK.Kind,
+		Name:       record.Name,
+		Namespace:  record.Namespace,
+	}
+	if record.ObjectGVK == kubernetes.Namespaces {
+		// Namespaces are cluster scoped
+		involvedObject.Namespace = ""
+	}
+
+	eventTime := meta_v1.NewTime(record.Timestamp)
+	event := &core_v1.Event{
+		ObjectMeta: meta_v1.ObjectMeta{
+			GenerateName: "kiali-audit-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name": eventsComponent,
+			},
+			Annotations: map[string]string{
+				"kiali.io/audit-id": record.ID,
+			},
+		},
+		InvolvedObject: involvedObject,
+		Reason:         eventsReason,
+		Message:        message,
+		Type:           eventType,
+		Source:         core_v1.EventSource{Component: eventsComponent},
+		FirstTimestamp: eventTime,
+		LastTimestamp:  eventTime,
+		Count:          1,
+	}
+
+	_, err := client.Kube().CoreV1().Events(namespace).Create(context.WithoutCancel(ctx), event, meta_v1.CreateOptions{})
+	return err
+}
@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/kiali/kiali/models"
+)
+
+// FileSink appends audit records as JSON lines to a file.
+// When the file grows beyond the maximum size it is renamed to <path>.1, older files are
+// shifted (<path>.1 to <path>.2, ...) and the oldest one beyond maxBackups is removed.
+type FileSink struct {
+	file       *os.File
+	lock       sync.Mutex
+	maxBackups int
+	maxSize    int64
+	path       string
+	size       int64
+}
+
+// NewFileSink creates a FileSink writing to path. The file is opened on the first write.
+func NewFileSink(path string, maxSizeMB, maxBackups int) *FileSink {
+	return &FileSink{
+		maxBackups: maxBackups,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		path:       path,
+	}
+}
+
+func (f *FileSink) Name() string {
+	return "file"
+}
+
+func (f *FileSink) Write(_ context.Context, record models.AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return err
+		}
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	return err
+}
+
+// Search scans the rotated files, oldest first, and then the current file.
+// Lines that cannot be parsed are skipped.
+func (f *FileSink) Search(ctx context.Context, query models.AuditQuery) ([]models.AuditRecord, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	matches := []models.AuditRecord{}
+	for i := f.maxBackups; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		records, err := readRecords(f.backupPath(i), query)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, records...)
+	}
+	return limitRecords(matches, query.Limit), nil
+}
+
+// Close closes the current file.
+func (f *FileSink) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *FileSink) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *FileSink) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+
+	if f.maxBackups <= 0 {
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return f.open()
+	}
+
+	if err := os.Remove(f.backupPath(f.maxBackups)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for i := f.maxBackups - 1; i >= 0; i-- {
+		if err := os.Rename(f.backupPath(i), f.backupPath(i+1)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return f.open()
+}
+
+// backupPath returns the path of the n-th rotated file, 0 being the current file.
+func (f *FileSink) backupPath(n int) string {
+	if n == 0 {
+		return f.path
+	}
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
+
+func readRecords(path string, query models.AuditQuery) ([]models.AuditRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	records := []models.AuditRecord{}
+	scanner := bufio.NewScanner(file)
+	// Records carrying full objects can be larger than the default token size
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		record := models.AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		if query.Matches(record) {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
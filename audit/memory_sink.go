@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kiali/kiali/models"
+)
+
+// MemorySink keeps the most recent audit records in memory. Records are lost when Kiali restarts.
+type MemorySink struct {
+	lock       sync.RWMutex
+	maxRecords int
+	records    []models.AuditRecord
+}
+
+// NewMemorySink creates a MemorySink keeping up to maxRecords records.
+func NewMemorySink(maxRecords int) *MemorySink {
+	return &MemorySink{maxRecords: maxRecords}
+}
+
+func (m *MemorySink) Name() string {
+	return "memory"
+}
+
+func (m *MemorySink) Write(_ context.Context, record models.AuditRecord) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.records = append(m.records, record)
+	if len(m.records) > m.maxRecords {
+		m.records = m.records[len(m.records)-m.maxRecords:]
+	}
+	return nil
+}
+
+func (m *MemorySink) Search(_ context.Context, query models.AuditQuery) ([]models.AuditRecord, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	matches := []models.AuditRecord{}
+	for _, record := range m.records {
+		if query.Matches(record) {
+			matches = append(matches, record)
+		}
+	}
+	return limitRecords(matches, query.Limit), nil
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util/httputil"
+)
+
+// webhookQueueSize is the number of records waiting to be posted to the webhook.
+const webhookQueueSize = 1024
+
+// WebhookSink posts every audit record as JSON to an external endpoint. The records are posted in the background,
+// so that a slow endpoint does not delay the audited requests: they are dropped when too many are waiting.
+type WebhookSink struct {
+	client http.Client
+	queue  chan models.AuditRecord
+	url    string
+}
+
+// NewWebhookSink creates a WebhookSink using the auth, headers and timeout of the webhook configuration.
+// The records are posted until ctx is done.
+func NewWebhookSink(ctx context.Context, conf *config.Config, webhookConf config.AuditWebhookSink) (*WebhookSink, error) {
+	var auth *config.Auth
+	if webhookConf.Auth.Type != "" && webhookConf.Auth.Type != config.AuthTypeNone {
+		auth = &webhookConf.Auth
+	}
+	transport, err := httputil.CreateTransport(conf, auth, &http.Transport{}, webhookConf.Timeout, webhookConf.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the audit webhook transport: %w", err)
+	}
+	sink := &WebhookSink{
+		client: http.Client{Transport: transport, Timeout: webhookConf.Timeout},
+		queue:  make(chan models.AuditRecord, webhookQueueSize),
+		url:    webhookConf.URL,
+	}
+	go sink.run(ctx)
+	return sink, nil
+}
+
+func (w *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Write queues the record to be posted. It fails when the queue is full and the record is dropped.
+func (w *WebhookSink) Write(_ context.Context, record models.AuditRecord) error {
+	select {
+	case w.queue <- record:
+		return nil
+	default:
+		return fmt.Errorf("the audit webhook queue is full: the record is dropped")
+	}
+}
+
+// run posts the queued records until ctx is done.
+func (w *WebhookSink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case record := <-w.queue:
+			if err := w.post(ctx, record); err != nil {
+				log.WithGroup(log.AuditLogName).Error().Msgf("Unable to write audit record [%s] to the %s sink: %v", record.ID, w.Name(), err)
+			}
+		}
+	}
+}
+
+func (w *WebhookSink) post(ctx context.Context, record models.AuditRecord) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
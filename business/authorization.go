@@ -0,0 +1,527 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	security_v1 "istio.io/client-go/pkg/apis/security/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+const defaultTrustDomain = "cluster.local"
+
+// AuthorizationService evaluates Istio AuthorizationPolicies against concrete requests.
+type AuthorizationService struct {
+	businessLayer *Layer
+	conf          *config.Config
+	discovery     istio.MeshDiscovery
+}
+
+// authorizationTarget holds the destination workload information needed to decide
+// which AuthorizationPolicies apply to a request and where they are enforced.
+type authorizationTarget struct {
+	Ambient       bool
+	Labels        map[string]string
+	Namespace     string
+	RootNamespace string
+	Services      []string
+	Waypoints     []models.WorkloadReferenceInfo
+}
+
+// SimulateRequest evaluates all the AuthorizationPolicies applicable to the destination workload
+// (mesh-wide, namespace, selector and waypoint attached) and returns whether the request would be allowed.
+func (in *AuthorizationService) SimulateRequest(ctx context.Context, cluster, namespace, workloadName string, req models.AuthorizationSimulationRequest) (*models.AuthorizationSimulationResult, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "SimulateRequest",
+		observability.Attribute("package", "business"),
+		observability.Attribute(observability.TracingClusterTag, cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workloadName),
+	)
+	defer end()
+
+	workload, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{
+		Cluster:          cluster,
+		IncludeServices:  true,
+		IncludeWaypoints: true,
+		Namespace:        namespace,
+		WorkloadName:     workloadName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := IstioConfigCriteria{IncludeAuthorizationPolicies: true}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, cluster, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	target := authorizationTarget{
+		Ambient:       !workload.HasIstioSidecar() && workload.HasIstioAmbient(),
+		Labels:        workload.Labels,
+		Namespace:     namespace,
+		RootNamespace: in.discovery.GetRootNamespace(ctx, cluster, namespace),
+		Waypoints:     workload.WaypointWorkloads,
+	}
+	if target.RootNamespace == "" {
+		target.RootNamespace = config.IstioNamespaceDefault
+	}
+	for _, svc := range workload.Services {
+		target.Services = append(target.Services, svc.Name)
+	}
+
+	if req.SourcePrincipal == "" && req.SourceNamespace != "" && req.SourceServiceAccount != "" {
+		req.SourcePrincipal = fmt.Sprintf("%s/ns/%s/sa/%s", in.trustDomain(ctx, cluster), req.SourceNamespace, req.SourceServiceAccount)
+	}
+
+	return evaluateAuthorization(istioConfigList.AuthorizationPolicies, target, req), nil
+}
+
+func (in *AuthorizationService) trustDomain(ctx context.Context, cluster string) string {
+	mesh, err := in.discovery.Mesh(ctx)
+	if err != nil || mesh == nil {
+		return defaultTrustDomain
+	}
+	for _, cp := range mesh.ControlPlanes {
+		if cp.Cluster != nil && cp.Cluster.Name == cluster && cp.MeshConfig != nil && cp.MeshConfig.TrustDomain != "" {
+			return cp.MeshConfig.TrustDomain
+		}
+	}
+	return defaultTrustDomain
+}
+
+// evaluateAuthorization applies the Istio authorization semantics: at every enforcement point CUSTOM
+// policies are evaluated first, then DENY and finally ALLOW. A request is allowed at an enforcement point
+// when no DENY policy matches and either there are no ALLOW policies or one of them matches.
+// AUDIT policies are reported but never change the decision.
+func evaluateAuthorization(policies []*security_v1.AuthorizationPolicy, target authorizationTarget, req models.AuthorizationSimulationRequest) *models.AuthorizationSimulationResult {
+	result := &models.AuthorizationSimulationResult{
+		Decision:            models.AuthorizationDecisionAllow,
+		SourcePrincipal:     req.SourcePrincipal,
+		DeterminingPolicies: []models.AuthorizationPolicyEvaluation{},
+		EvaluatedPolicies:   []models.AuthorizationPolicyEvaluation{},
+	}
+
+	perPoint := map[string][]models.AuthorizationPolicyEvaluation{}
+	points := []string{}
+	for _, ap := range policies {
+		scope, enforcedBy, applies := policyApplies(ap, target)
+		if !applies {
+			continue
+		}
+		eval := evaluatePolicy(ap, req, enforcedBy == models.AuthorizationEnforcementZtunnel)
+		eval.Scope = scope
+		eval.EnforcedBy = enforcedBy
+		if _, found := perPoint[enforcedBy]; !found {
+			points = append(points, enforcedBy)
+		}
+		perPoint[enforcedBy] = append(perPoint[enforcedBy], eval)
+	}
+
+	// Evaluation order inside every enforcement point
+	actionOrder := []string{
+		api_security_v1beta1.AuthorizationPolicy_CUSTOM.String(),
+		api_security_v1beta1.AuthorizationPolicy_DENY.String(),
+		api_security_v1beta1.AuthorizationPolicy_ALLOW.String(),
+		api_security_v1beta1.AuthorizationPolicy_AUDIT.String(),
+	}
+
+	reasons := []string{}
+	custom := false
+	for _, point := range points {
+		evals := perPoint[point]
+		for _, action := range actionOrder {
+			for _, eval := range evals {
+				if eval.Action == action {
+					result.EvaluatedPolicies = append(result.EvaluatedPolicies, eval)
+				}
+			}
+		}
+
+		decision, determining, reason := decideAtEnforcementPoint(evals)
+		switch decision {
+		case models.AuthorizationDecisionDeny:
+			if result.Decision != models.AuthorizationDecisionDeny {
+				result.DeterminingPolicies = []models.AuthorizationPolicyEvaluation{}
+				reasons = []string{}
+			}
+			result.Decision = models.AuthorizationDecisionDeny
+			result.DeterminingPolicies = append(result.DeterminingPolicies, determining...)
+			reasons = append(reasons, fmt.Sprintf("%s: %s", point, reason))
+		case models.AuthorizationDecisionCustom:
+			custom = true
+			if result.Decision != models.AuthorizationDecisionDeny {
+				result.DeterminingPolicies = append(result.DeterminingPolicies, determining...)
+				reasons = append(reasons, fmt.Sprintf("%s: %s", point, reason))
+			}
+		default:
+			if result.Decision != models.AuthorizationDecisionDeny {
+				result.DeterminingPolicies = append(result.DeterminingPolicies, determining...)
+				reasons = append(reasons, fmt.Sprintf("%s: %s", point, reason))
+			}
+		}
+	}
+
+	if result.Decision != models.AuthorizationDecisionDeny && custom {
+		result.Decision = models.AuthorizationDecisionCustom
+	}
+	if len(points) == 0 {
+		reasons = append(reasons, "no AuthorizationPolicy applies to the destination workload")
+	}
+	result.Reason = strings.Join(reasons, "; ")
+
+	return result
+}
+
+// decideAtEnforcementPoint returns the decision of a single proxy given the evaluation of its policies.
+func decideAtEnforcementPoint(evals []models.AuthorizationPolicyEvaluation) (string, []models.AuthorizationPolicyEvaluation, string) {
+	var customMatched, denyMatched, allowMatched, allowPolicies []models.AuthorizationPolicyEvaluation
+	for _, eval := range evals {
+		switch eval.Action {
+		case api_security_v1beta1.AuthorizationPolicy_CUSTOM.String():
+			if eval.Matched {
+				customMatched = append(customMatched, eval)
+			}
+		case api_security_v1beta1.AuthorizationPolicy_DENY.String():
+			if eval.Matched {
+				denyMatched = append(denyMatched, eval)
+			}
+		case api_security_v1beta1.AuthorizationPolicy_ALLOW.String():
+			allowPolicies = append(allowPolicies, eval)
+			if eval.Matched {
+				allowMatched = append(allowMatched, eval)
+			}
+		}
+	}
+
+	switch {
+	case len(denyMatched) > 0:
+		return models.AuthorizationDecisionDeny, denyMatched, "denied by a matching DENY policy"
+	case len(allowPolicies) > 0 && len(allowMatched) == 0:
+		return models.AuthorizationDecisionDeny, allowPolicies, "ALLOW policies apply but none of them matches the request"
+	case len(customMatched) > 0:
+		return models.AuthorizationDecisionCustom, customMatched, "delegated to the CUSTOM authorization provider"
+	case len(allowMatched) > 0:
+		return models.AuthorizationDecisionAllow, allowMatched, "allowed by a matching ALLOW policy"
+	default:
+		return models.AuthorizationDecisionAllow, nil, "no ALLOW policy applies, allowed by default"
+	}
+}
+
+// policyApplies returns the scope and the enforcement point of a policy for the given destination,
+// or false when the policy does not apply to it.
+func policyApplies(ap *security_v1.AuthorizationPolicy, target authorizationTarget) (string, string, bool) {
+	// Cloned to not append to the backing array of the cached policy
+	targetRefs := slices.Clone(ap.Spec.TargetRefs)
+	if ap.Spec.TargetRef != nil {
+		targetRefs = append(targetRefs, ap.Spec.TargetRef)
+	}
+
+	if len(targetRefs) > 0 {
+		for _, ref := range targetRefs {
+			if ref == nil {
+				continue
+			}
+			refNamespace := ap.Namespace
+			if ref.Namespace != "" {
+				refNamespace = ref.Namespace
+			}
+			switch ref.Kind {
+			case "Gateway":
+				for _, wp := range target.Waypoints {
+					if wp.Name == ref.Name && wp.Namespace == refNamespace {
+						return models.AuthorizationScopeTargetRef, models.AuthorizationEnforcementWaypoint, true
+					}
+				}
+			case "Service", "ServiceEntry":
+				if len(target.Waypoints) > 0 && refNamespace == target.Namespace {
+					for _, svc := range target.Services {
+						if svc == ref.Name {
+							return models.AuthorizationScopeTargetRef, models.AuthorizationEnforcementWaypoint, true
+						}
+					}
+				}
+			}
+		}
+		return "", "", false
+	}
+
+	enforcedBy := models.AuthorizationEnforcementSidecar
+	if target.Ambient {
+		enforcedBy = models.AuthorizationEnforcementZtunnel
+	}
+
+	if ap.Namespace != target.Namespace && ap.Namespace != target.RootNamespace {
+		return "", "", false
+	}
+
+	if ap.Spec.Selector == nil || len(ap.Spec.Selector.MatchLabels) == 0 {
+		if ap.Namespace == target.RootNamespace && ap.Namespace != target.Namespace {
+			return models.AuthorizationScopeMesh, enforcedBy, true
+		}
+		return models.AuthorizationScopeNamespace, enforcedBy, true
+	}
+
+	if labels.SelectorFromSet(ap.Spec.Selector.MatchLabels).Matches(labels.Set(target.Labels)) {
+		return models.AuthorizationScopeWorkload, enforcedBy, true
+	}
+	return "", "", false
+}
+
+// evaluatePolicy checks every rule of the policy against the request.
+// A policy without rules never matches: for ALLOW this means "allow nothing".
+func evaluatePolicy(ap *security_v1.AuthorizationPolicy, req models.AuthorizationSimulationRequest, l4Only bool) models.AuthorizationPolicyEvaluation {
+	eval := models.AuthorizationPolicyEvaluation{
+		Name:         ap.Name,
+		Namespace:    ap.Namespace,
+		Action:       ap.Spec.Action.String(),
+		MatchedRules: []int{},
+	}
+	if provider := ap.Spec.GetProvider(); provider != nil {
+		eval.Provider = provider.Name
+	}
+
+	for i, rule := range ap.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+		m := &ruleMatcher{allow: ap.Spec.Action == api_security_v1beta1.AuthorizationPolicy_ALLOW, req: req, l4Only: l4Only}
+		matched := m.matches(rule)
+		if m.hasL7 && l4Only {
+			// ztunnel cannot enforce L7 attributes: ALLOW rules using them never match,
+			// DENY rules are enforced using only their L4 attributes.
+			if ap.Spec.Action == api_security_v1beta1.AuthorizationPolicy_ALLOW {
+				matched = false
+				eval.Notes = append(eval.Notes, fmt.Sprintf("rules[%d] uses L7 attributes that ztunnel cannot enforce, the rule never matches", i))
+			} else {
+				eval.Notes = append(eval.Notes, fmt.Sprintf("rules[%d] uses L7 attributes that ztunnel cannot enforce, they were ignored", i))
+			}
+		}
+		eval.Notes = append(eval.Notes, prefixNotes(fmt.Sprintf("rules[%d]", i), m.notes)...)
+		if matched {
+			eval.Matched = true
+			eval.MatchedRules = append(eval.MatchedRules, i)
+		}
+	}
+
+	return eval
+}
+
+func prefixNotes(prefix string, notes []string) []string {
+	prefixed := make([]string, 0, len(notes))
+	for _, n := range notes {
+		prefixed = append(prefixed, prefix+": "+n)
+	}
+	return prefixed
+}
+
+// ruleMatcher matches a single rule against a request, tracking whether L7 attributes were used
+// and any condition that could not be evaluated. Such conditions only match for the rules of the
+// policies that are not ALLOW, so that the simulation never allows what Istio may deny.
+type ruleMatcher struct {
+	allow  bool
+	hasL7  bool
+	l4Only bool
+	notes  []string
+	req    models.AuthorizationSimulationRequest
+}
+
+// l7 records the usage of an L7 attribute and reports whether it must be ignored (ztunnel enforcement).
+func (m *ruleMatcher) l7(used bool) bool {
+	if used {
+		m.hasL7 = true
+	}
+	return used && m.l4Only
+}
+
+func (m *ruleMatcher) matches(rule *api_security_v1beta1.Rule) bool {
+	matched := true
+	if len(rule.From) > 0 {
+		anyFrom := false
+		for _, from := range rule.From {
+			if from != nil && m.matchesSource(from.Source) {
+				anyFrom = true
+			}
+		}
+		matched = matched && anyFrom
+	}
+	if len(rule.To) > 0 {
+		anyTo := false
+		for _, to := range rule.To {
+			if to != nil && m.matchesOperation(to.Operation) {
+				anyTo = true
+			}
+		}
+		matched = matched && anyTo
+	}
+	for _, cond := range rule.When {
+		if cond != nil && !m.matchesCondition(cond) {
+			matched = false
+		}
+	}
+	return matched
+}
+
+func (m *ruleMatcher) matchesSource(src *api_security_v1beta1.Source) bool {
+	if src == nil {
+		return true
+	}
+	principal := m.req.SourcePrincipal
+	namespace := m.req.SourceNamespace
+	serviceAccount := ""
+	trustDomain := ""
+	if parts := strings.Split(principal, "/"); len(parts) == 5 && parts[1] == "ns" && parts[3] == "sa" {
+		trustDomain = parts[0]
+		if namespace == "" {
+			namespace = parts[2]
+		}
+		serviceAccount = parts[2] + "/" + parts[4]
+	}
+
+	return matchField(src.Principals, src.NotPrincipals, principal, matchStringValue) &&
+		matchField(src.Namespaces, src.NotNamespaces, namespace, matchStringValue) &&
+		matchField(src.ServiceAccounts, src.NotServiceAccounts, serviceAccount, matchStringValue) &&
+		matchField(src.TrustDomains, src.NotTrustDomains, trustDomain, matchStringValue) &&
+		matchField(src.IpBlocks, src.NotIpBlocks, m.req.SourceIP, matchIPBlock) &&
+		matchField(src.RemoteIpBlocks, src.NotRemoteIpBlocks, m.req.SourceIP, matchIPBlock) &&
+		(m.l7(len(src.RequestPrincipals) > 0 || len(src.NotRequestPrincipals) > 0) ||
+			matchField(src.RequestPrincipals, src.NotRequestPrincipals, m.req.RequestPrincipal, matchStringValue))
+}
+
+func (m *ruleMatcher) matchesOperation(op *api_security_v1beta1.Operation) bool {
+	if op == nil {
+		return true
+	}
+	port := ""
+	if m.req.Port > 0 {
+		port = strconv.Itoa(m.req.Port)
+	}
+	return matchField(op.Ports, op.NotPorts, port, matchExact) &&
+		(m.l7(len(op.Hosts) > 0 || len(op.NotHosts) > 0) || matchField(op.Hosts, op.NotHosts, m.req.Host, matchHost)) &&
+		(m.l7(len(op.Methods) > 0 || len(op.NotMethods) > 0) || matchField(op.Methods, op.NotMethods, m.req.Method, matchExact)) &&
+		(m.l7(len(op.Paths) > 0 || len(op.NotPaths) > 0) || matchField(op.Paths, op.NotPaths, m.req.Path, matchStringValue))
+}
+
+func (m *ruleMatcher) matchesCondition(cond *api_security_v1beta1.Condition) bool {
+	key := cond.Key
+	var value string
+	switch {
+	case strings.HasPrefix(key, "request.headers[") && strings.HasSuffix(key, "]"):
+		if m.l7(true) {
+			return true
+		}
+		header := strings.TrimSuffix(strings.TrimPrefix(key, "request.headers["), "]")
+		for k, v := range m.req.Headers {
+			if strings.EqualFold(k, header) {
+				value = v
+			}
+		}
+		return matchField(cond.Values, cond.NotValues, value, matchStringValue)
+	case key == "request.auth.principal":
+		if m.l7(true) {
+			return true
+		}
+		value = m.req.RequestPrincipal
+		return matchField(cond.Values, cond.NotValues, value, matchStringValue)
+	case key == "source.ip" || key == "remote.ip":
+		return matchField(cond.Values, cond.NotValues, m.req.SourceIP, matchIPBlock)
+	case key == "source.namespace":
+		value = m.req.SourceNamespace
+	case key == "source.principal":
+		value = m.req.SourcePrincipal
+	case key == "destination.port":
+		if m.req.Port > 0 {
+			value = strconv.Itoa(m.req.Port)
+		}
+		return matchField(cond.Values, cond.NotValues, value, matchExact)
+	default:
+		// request.auth.claims, destination.ip, connection.sni, experimental keys...
+		if m.allow {
+			m.notes = append(m.notes, fmt.Sprintf("condition key [%s] cannot be simulated and was considered not matching", key))
+			return false
+		}
+		m.notes = append(m.notes, fmt.Sprintf("condition key [%s] cannot be simulated and was considered matching", key))
+		return true
+	}
+	return matchField(cond.Values, cond.NotValues, value, matchStringValue)
+}
+
+// matchField implements the Istio semantics of a field and its "not" counterpart:
+// the value must match one of values (when set) and none of notValues.
+func matchField(values, notValues []string, value string, match func(pattern, value string) bool) bool {
+	if len(values) > 0 {
+		found := false
+		for _, v := range values {
+			if match(v, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, v := range notValues {
+		if match(v, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchStringValue supports exact, prefix ("abc*"), suffix ("*abc") and presence ("*") matches.
+func matchStringValue(pattern, value string) bool {
+	switch {
+	case pattern == "*":
+		return value != ""
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(value, strings.TrimPrefix(pattern, "*"))
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	default:
+		return pattern == value
+	}
+}
+
+func matchExact(pattern, value string) bool {
+	return pattern == value
+}
+
+// matchHost is case-insensitive and ignores the port of the requested host when the pattern has none.
+func matchHost(pattern, value string) bool {
+	pattern = strings.ToLower(pattern)
+	value = strings.ToLower(value)
+	if matchStringValue(pattern, value) {
+		return true
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		return matchStringValue(pattern, host)
+	}
+	return false
+}
+
+func matchIPBlock(block, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	if prefix, err := netip.ParsePrefix(block); err == nil {
+		return prefix.Contains(addr)
+	}
+	if blockAddr, err := netip.ParseAddr(block); err == nil {
+		return blockAddr == addr
+	}
+	return false
+}
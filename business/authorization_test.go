@@ -0,0 +1,288 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_security_v1beta1 "istio.io/api/security/v1beta1"
+	api_type_v1beta1 "istio.io/api/type/v1beta1"
+	security_v1 "istio.io/client-go/pkg/apis/security/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func fakeAuthorizationPolicy(name, namespace string, action api_security_v1beta1.AuthorizationPolicy_Action, selector map[string]string, rules ...*api_security_v1beta1.Rule) *security_v1.AuthorizationPolicy {
+	ap := &security_v1.AuthorizationPolicy{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.AuthorizationPolicies.GroupVersion().String(), Kind: kubernetes.AuthorizationPolicies.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	ap.Spec.Action = action
+	ap.Spec.Rules = rules
+	if selector != nil {
+		ap.Spec.Selector = &api_type_v1beta1.WorkloadSelector{MatchLabels: selector}
+	}
+	return ap
+}
+
+func fromPrincipals(principals ...string) *api_security_v1beta1.Rule_From {
+	return &api_security_v1beta1.Rule_From{Source: &api_security_v1beta1.Source{Principals: principals}}
+}
+
+func toOperation(methods, paths []string) *api_security_v1beta1.Rule_To {
+	return &api_security_v1beta1.Rule_To{Operation: &api_security_v1beta1.Operation{Methods: methods, Paths: paths}}
+}
+
+func reviewsTarget() authorizationTarget {
+	return authorizationTarget{
+		Labels:        map[string]string{"app": "reviews", "version": "v1"},
+		Namespace:     "bookinfo",
+		RootNamespace: "istio-system",
+		Services:      []string{"reviews"},
+	}
+}
+
+func productpageRequest() models.AuthorizationSimulationRequest {
+	return models.AuthorizationSimulationRequest{
+		SourceNamespace: "bookinfo",
+		SourcePrincipal: "cluster.local/ns/bookinfo/sa/bookinfo-productpage",
+		Host:            "reviews.bookinfo.svc.cluster.local:9080",
+		Port:            9080,
+		Path:            "/reviews/1",
+		Method:          "GET",
+		Headers:         map[string]string{"X-Canary": "true"},
+	}
+}
+
+func TestAuthorizationNoPoliciesAllows(t *testing.T) {
+	result := evaluateAuthorization(nil, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionAllow, result.Decision)
+	assert.Empty(t, result.EvaluatedPolicies)
+	assert.Empty(t, result.DeterminingPolicies)
+}
+
+func TestAuthorizationAllowNothingDenies(t *testing.T) {
+	policies := []*security_v1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("allow-nothing", "istio-system", api_security_v1beta1.AuthorizationPolicy_ALLOW, nil),
+	}
+
+	result := evaluateAuthorization(policies, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	require.Len(t, result.DeterminingPolicies, 1)
+	assert.Equal(t, "allow-nothing", result.DeterminingPolicies[0].Name)
+	assert.Equal(t, models.AuthorizationScopeMesh, result.DeterminingPolicies[0].Scope)
+	assert.False(t, result.DeterminingPolicies[0].Matched)
+}
+
+func TestAuthorizationAllowMatchingRule(t *testing.T) {
+	policies := []*security_v1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("allow-nothing", "istio-system", api_security_v1beta1.AuthorizationPolicy_ALLOW, nil),
+		fakeAuthorizationPolicy("reviews-viewer", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "reviews"},
+			&api_security_v1beta1.Rule{
+				From: []*api_security_v1beta1.Rule_From{fromPrincipals("cluster.local/ns/bookinfo/sa/bookinfo-productpage")},
+				To:   []*api_security_v1beta1.Rule_To{toOperation([]string{"GET"}, []string{"/reviews/*"})},
+			}),
+		fakeAuthorizationPolicy("ratings-viewer", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "ratings"},
+			&api_security_v1beta1.Rule{}),
+	}
+
+	result := evaluateAuthorization(policies, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionAllow, result.Decision)
+	// ratings-viewer does not select reviews
+	assert.Len(t, result.EvaluatedPolicies, 2)
+	require.Len(t, result.DeterminingPolicies, 1)
+	assert.Equal(t, "reviews-viewer", result.DeterminingPolicies[0].Name)
+	assert.Equal(t, models.AuthorizationScopeWorkload, result.DeterminingPolicies[0].Scope)
+	assert.Equal(t, []int{0}, result.DeterminingPolicies[0].MatchedRules)
+
+	req := productpageRequest()
+	req.Method = "DELETE"
+	result = evaluateAuthorization(policies, reviewsTarget(), req)
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+}
+
+func TestAuthorizationDenyTakesPrecedence(t *testing.T) {
+	policies := []*security_v1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("allow-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, nil, &api_security_v1beta1.Rule{}),
+		fakeAuthorizationPolicy("deny-canary", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, nil,
+			&api_security_v1beta1.Rule{
+				When: []*api_security_v1beta1.Condition{{Key: "request.headers[x-canary]", Values: []string{"true"}}},
+			}),
+		fakeAuthorizationPolicy("audit-all", "bookinfo", api_security_v1beta1.AuthorizationPolicy_AUDIT, nil, &api_security_v1beta1.Rule{}),
+	}
+
+	result := evaluateAuthorization(policies, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	require.Len(t, result.DeterminingPolicies, 1)
+	assert.Equal(t, "deny-canary", result.DeterminingPolicies[0].Name)
+	require.Len(t, result.EvaluatedPolicies, 3)
+	// Evaluation order is DENY, ALLOW, AUDIT
+	assert.Equal(t, "deny-canary", result.EvaluatedPolicies[0].Name)
+	assert.Equal(t, "allow-all", result.EvaluatedPolicies[1].Name)
+	assert.Equal(t, "audit-all", result.EvaluatedPolicies[2].Name)
+	assert.True(t, result.EvaluatedPolicies[2].Matched)
+
+	req := productpageRequest()
+	req.Headers = nil
+	result = evaluateAuthorization(policies, reviewsTarget(), req)
+	assert.Equal(t, models.AuthorizationDecisionAllow, result.Decision)
+}
+
+func TestAuthorizationCustomIsDelegated(t *testing.T) {
+	custom := fakeAuthorizationPolicy("ext-authz", "bookinfo", api_security_v1beta1.AuthorizationPolicy_CUSTOM, nil,
+		&api_security_v1beta1.Rule{To: []*api_security_v1beta1.Rule_To{toOperation(nil, []string{"/reviews/*"})}})
+	custom.Spec.ActionDetail = &api_security_v1beta1.AuthorizationPolicy_Provider{
+		Provider: &api_security_v1beta1.AuthorizationPolicy_ExtensionProvider{Name: "my-ext-authz"},
+	}
+
+	result := evaluateAuthorization([]*security_v1.AuthorizationPolicy{custom}, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionCustom, result.Decision)
+	require.Len(t, result.DeterminingPolicies, 1)
+	assert.Equal(t, "my-ext-authz", result.DeterminingPolicies[0].Provider)
+}
+
+func TestAuthorizationSourceAndNotValues(t *testing.T) {
+	policies := []*security_v1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("deny-other-ns", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, nil,
+			&api_security_v1beta1.Rule{
+				From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{NotNamespaces: []string{"bookinfo"}}}},
+			}),
+		fakeAuthorizationPolicy("deny-ip", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, nil,
+			&api_security_v1beta1.Rule{
+				From: []*api_security_v1beta1.Rule_From{{Source: &api_security_v1beta1.Source{IpBlocks: []string{"10.10.0.0/16"}}}},
+			}),
+	}
+
+	req := productpageRequest()
+	req.SourceIP = "10.20.0.1"
+	assert.Equal(t, models.AuthorizationDecisionAllow, evaluateAuthorization(policies, reviewsTarget(), req).Decision)
+
+	req.SourceIP = "10.10.3.4"
+	result := evaluateAuthorization(policies, reviewsTarget(), req)
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	assert.Equal(t, "deny-ip", result.DeterminingPolicies[0].Name)
+
+	req = productpageRequest()
+	req.SourceNamespace = ""
+	req.SourcePrincipal = "cluster.local/ns/other/sa/default"
+	result = evaluateAuthorization(policies, reviewsTarget(), req)
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	assert.Equal(t, "deny-other-ns", result.DeterminingPolicies[0].Name)
+}
+
+func TestAuthorizationUnsupportedConditionIsReported(t *testing.T) {
+	policies := []*security_v1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("allow-claims", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, nil,
+			&api_security_v1beta1.Rule{
+				When: []*api_security_v1beta1.Condition{{Key: "request.auth.claims[groups]", Values: []string{"admin"}}},
+			}),
+	}
+
+	result := evaluateAuthorization(policies, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	require.Len(t, result.EvaluatedPolicies, 1)
+	require.Len(t, result.EvaluatedPolicies[0].Notes, 1)
+	assert.Contains(t, result.EvaluatedPolicies[0].Notes[0], "request.auth.claims[groups]")
+}
+
+func TestAuthorizationUnsupportedConditionMatchesDenyPolicies(t *testing.T) {
+	policies := []*security_v1.AuthorizationPolicy{
+		fakeAuthorizationPolicy("deny-claims", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, nil,
+			&api_security_v1beta1.Rule{
+				When: []*api_security_v1beta1.Condition{{Key: "request.auth.claims[groups]", Values: []string{"guests"}}},
+			}),
+	}
+
+	// Istio may deny the request: the simulation must not report it as allowed
+	result := evaluateAuthorization(policies, reviewsTarget(), productpageRequest())
+
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	require.Len(t, result.DeterminingPolicies, 1)
+	assert.Equal(t, "deny-claims", result.DeterminingPolicies[0].Name)
+	require.Len(t, result.EvaluatedPolicies[0].Notes, 1)
+	assert.Contains(t, result.EvaluatedPolicies[0].Notes[0], "considered matching")
+}
+
+func TestAuthorizationAmbientEnforcementPoints(t *testing.T) {
+	target := reviewsTarget()
+	target.Ambient = true
+	target.Waypoints = []models.WorkloadReferenceInfo{{Name: "waypoint", Namespace: "bookinfo"}}
+
+	waypointPolicy := fakeAuthorizationPolicy("waypoint-get-only", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, nil,
+		&api_security_v1beta1.Rule{To: []*api_security_v1beta1.Rule_To{toOperation([]string{"GET"}, nil)}})
+	waypointPolicy.Spec.TargetRefs = []*api_type_v1beta1.PolicyTargetReference{{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "waypoint"}}
+
+	otherWaypointPolicy := fakeAuthorizationPolicy("other-waypoint", "bookinfo", api_security_v1beta1.AuthorizationPolicy_DENY, nil, &api_security_v1beta1.Rule{})
+	otherWaypointPolicy.Spec.TargetRefs = []*api_type_v1beta1.PolicyTargetReference{{Group: "gateway.networking.k8s.io", Kind: "Gateway", Name: "other"}}
+
+	// Selector based policy with L7 attributes enforced by ztunnel: the ALLOW rule can never match
+	ztunnelPolicy := fakeAuthorizationPolicy("ztunnel-l7", "bookinfo", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "reviews"},
+		&api_security_v1beta1.Rule{To: []*api_security_v1beta1.Rule_To{toOperation([]string{"GET"}, nil)}})
+
+	result := evaluateAuthorization([]*security_v1.AuthorizationPolicy{waypointPolicy, otherWaypointPolicy}, target, productpageRequest())
+	assert.Equal(t, models.AuthorizationDecisionAllow, result.Decision)
+	require.Len(t, result.EvaluatedPolicies, 1)
+	assert.Equal(t, models.AuthorizationEnforcementWaypoint, result.EvaluatedPolicies[0].EnforcedBy)
+	assert.Equal(t, models.AuthorizationScopeTargetRef, result.EvaluatedPolicies[0].Scope)
+
+	result = evaluateAuthorization([]*security_v1.AuthorizationPolicy{waypointPolicy, ztunnelPolicy}, target, productpageRequest())
+	assert.Equal(t, models.AuthorizationDecisionDeny, result.Decision)
+	require.Len(t, result.DeterminingPolicies, 1)
+	assert.Equal(t, "ztunnel-l7", result.DeterminingPolicies[0].Name)
+	assert.Equal(t, models.AuthorizationEnforcementZtunnel, result.DeterminingPolicies[0].EnforcedBy)
+	assert.NotEmpty(t, result.DeterminingPolicies[0].Notes)
+}
+
+func TestSimulateRequest(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ClusterWideAccess = true
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.IstioLabels.AppLabelName = "app"
+	conf.IstioLabels.VersionLabelName = "version"
+	kubernetes.SetConfig(t, *conf)
+
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&FakeDepSyncedWithRS(conf)[0],
+		fakeAuthorizationPolicy("details-from-productpage", "Namespace", api_security_v1beta1.AuthorizationPolicy_ALLOW, map[string]string{"app": "details"},
+			&api_security_v1beta1.Rule{From: []*api_security_v1beta1.Rule_From{fromPrincipals("cluster.local/ns/Namespace/sa/productpage")}}),
+	}
+	for _, o := range FakeRSSyncedWithPods(conf) {
+		kubeObjs = append(kubeObjs, &o)
+	}
+	for _, o := range FakePodsSyncedWithDeployments(conf) {
+		kubeObjs = append(kubeObjs, &o)
+	}
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	layer := NewLayerBuilder(t, conf).WithClient(k8s).Build()
+
+	req := models.AuthorizationSimulationRequest{SourceNamespace: "Namespace", SourceServiceAccount: "productpage", Method: "GET", Path: "/details"}
+	result, err := layer.Authorization.SimulateRequest(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", req)
+	require.NoError(err)
+	assert.Equal(models.AuthorizationDecisionAllow, result.Decision)
+	assert.Equal("cluster.local/ns/Namespace/sa/productpage", result.SourcePrincipal)
+
+	req.SourceServiceAccount = "reviews"
+	result, err = layer.Authorization.SimulateRequest(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", req)
+	require.NoError(err)
+	assert.Equal(models.AuthorizationDecisionDeny, result.Decision)
+	require.Len(result.DeterminingPolicies, 1)
+	assert.Equal("details-from-productpage", result.DeterminingPolicies[0].Name)
+}
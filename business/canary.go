@@ -0,0 +1,500 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/common/model"
+	"github.com/rs/zerolog"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+)
+
+const (
+	defaultCanaryInterval   = time.Minute
+	defaultCanaryStepWeight = 10
+	// minCanaryInterval keeps the rate interval of the metrics checks meaningful for Prometheus
+	minCanaryInterval = 10 * time.Second
+	// maxFinishedCanaryRuns is the number of finished runs kept for their history
+	maxFinishedCanaryRuns = 100
+	canaryLatencyQuantile = "0.95"
+)
+
+// CanaryController runs the canary releases started through the API. A run shifts the traffic of a service
+// from a baseline to a canary version in weighted steps. Before each step, it checks the error rate of the
+// canary against the tolerances of the health config, and optionally its latency, and rolls the traffic back
+// to the baseline when they are breached.
+// The first step and the cancellations are applied with the clients of the user. The next steps are applied
+// with the Kiali service account. The runs are kept in memory: they stop when Kiali stops, leaving the last
+// weights applied.
+type CanaryController struct {
+	cache         cache.KialiCache
+	calculator    *HealthCalculator
+	clientFactory kubernetes.ClientFactory
+	conf          *config.Config
+	ctx           context.Context
+	discovery     istio.MeshDiscovery
+	logger        zerolog.Logger
+	mu            sync.RWMutex
+	now           func() time.Time
+	prom          prometheus.ClientInterface
+	runs          map[string]*canaryRun
+	// saLayer creates the business layer applying the automatic steps
+	saLayer func() (*Layer, error)
+}
+
+// canaryRun is the state of a run. stepMu serializes its steps and cancellation, the run itself is
+// guarded by the mutex of the controller.
+type canaryRun struct {
+	cancel            context.CancelFunc
+	ctx               context.Context
+	healthAnnotations map[string]string
+	interval          time.Duration
+	run               models.CanaryRun
+	stepMu            sync.Mutex
+}
+
+// NewCanaryController creates a CanaryController. The runs are stopped when ctx is cancelled.
+func NewCanaryController(
+	ctx context.Context,
+	cache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	conf *config.Config,
+	discovery istio.MeshDiscovery,
+	prom prometheus.ClientInterface,
+) *CanaryController {
+	c := &CanaryController{
+		cache:         cache,
+		calculator:    NewHealthCalculator(conf),
+		clientFactory: clientFactory,
+		conf:          conf,
+		ctx:           ctx,
+		discovery:     discovery,
+		logger:        log.Logger().With().Str("component", "canary-controller").Logger(),
+		now:           time.Now,
+		prom:          prom,
+		runs:          map[string]*canaryRun{},
+	}
+	c.saLayer = c.createLayer
+	return c
+}
+
+// createLayer creates a Layer with the SA clients, as the automatic steps do not run on behalf of a user.
+func (c *CanaryController) createLayer() (*Layer, error) {
+	discovery, ok := c.discovery.(*istio.Discovery)
+	if !ok {
+		return nil, fmt.Errorf("unsupported discovery type for canary controller: %T", c.discovery)
+	}
+	return NewLayerWithSAClients(
+		c.conf,
+		c.cache,
+		c.prom,
+		nil, // traceClient - not needed to shift traffic
+		&FakeControlPlaneMonitor{},
+		nil, // grafana - not needed to shift traffic
+		discovery,
+		c.clientFactory.GetSAClientsAsUserClientInterfaces(),
+	)
+}
+
+// Start starts a canary run for a service and applies its first step with the given layer, so that
+// the user starting the run needs the permissions to change the traffic of the service.
+func (c *CanaryController) Start(ctx context.Context, layer *Layer, cluster, namespace, service, user string, request models.CanaryRequest) (*models.CanaryRun, error) {
+	request, interval, err := validateCanaryRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := layer.Svc.GetService(ctx, cluster, namespace, service)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	for _, r := range c.runs {
+		if !r.run.Finished() && r.run.Cluster == cluster && r.run.Namespace == namespace && r.run.Service == service {
+			c.mu.Unlock()
+			return nil, api_errors.NewConflict(schema.GroupResource{Resource: "canaries"}, r.run.ID,
+				fmt.Errorf("a canary run is already shifting the traffic of service %s", service))
+		}
+	}
+	runCtx, cancel := context.WithCancel(c.ctx)
+	r := &canaryRun{
+		cancel:            cancel,
+		ctx:               runCtx,
+		healthAnnotations: svc.HealthAnnotations,
+		interval:          interval,
+		run: models.CanaryRun{
+			ID:        uuid.NewString(),
+			Cluster:   cluster,
+			Namespace: namespace,
+			Service:   service,
+			Request:   request,
+			Status:    models.CanaryStatusRunning,
+			User:      user,
+			StartTime: c.now(),
+			History:   []models.CanaryEvent{},
+		},
+	}
+	// The run is registered before its first step to reject concurrent runs of the same service
+	c.runs[r.run.ID] = r
+	c.mu.Unlock()
+
+	if err := c.shift(ctx, layer, r, request.StepWeight); err != nil {
+		cancel()
+		c.mu.Lock()
+		delete(c.runs, r.run.ID)
+		c.mu.Unlock()
+		return nil, err
+	}
+	run := c.record(r, models.CanaryEvent{Action: models.CanaryActionStart, Weight: request.StepWeight, ErrorRatio: -1}, "")
+
+	go c.loop(r)
+	return &run, nil
+}
+
+// Get returns a run, finished or not.
+func (c *CanaryController) Get(id string) (*models.CanaryRun, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	r, ok := c.runs[id]
+	if !ok {
+		return nil, false
+	}
+	run := copyCanaryRun(r.run)
+	return &run, true
+}
+
+// List returns the runs of a service, the most recent first.
+func (c *CanaryController) List(cluster, namespace, service string) []models.CanaryRun {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	runs := []models.CanaryRun{}
+	for _, r := range c.runs {
+		if r.run.Cluster == cluster && r.run.Namespace == namespace && r.run.Service == service {
+			runs = append(runs, copyCanaryRun(r.run))
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].StartTime.After(runs[j].StartTime) })
+	return runs
+}
+
+// Cancel stops a run and shifts all the traffic back to the baseline with the given layer.
+func (c *CanaryController) Cancel(ctx context.Context, layer *Layer, id, user string) (*models.CanaryRun, error) {
+	c.mu.RLock()
+	r, ok := c.runs[id]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, api_errors.NewNotFound(schema.GroupResource{Resource: "canaries"}, id)
+	}
+
+	r.stepMu.Lock()
+	defer r.stepMu.Unlock()
+	// The run may have finished, and been pruned, since it was looked up: check the run itself
+	c.mu.RLock()
+	finished, status := r.run.Finished(), r.run.Status
+	c.mu.RUnlock()
+	if finished {
+		return nil, api_errors.NewConflict(schema.GroupResource{Resource: "canaries"}, id,
+			fmt.Errorf("the canary run is already %s", status))
+	}
+	r.cancel()
+
+	message := "Cancelled"
+	if user != "" {
+		message = "Cancelled by " + user
+	}
+	if err := c.shift(ctx, layer, r, 0); err != nil {
+		// The run stays cancelled: the user can retry the rollback with the traffic templates
+		run := c.record(r, models.CanaryEvent{Action: models.CanaryActionFail, Weight: r.currentWeight(c), ErrorRatio: -1, Message: err.Error()}, models.CanaryStatusFailed)
+		return &run, err
+	}
+	run := c.record(r, models.CanaryEvent{Action: models.CanaryActionCancel, Weight: 0, ErrorRatio: -1, Message: message}, models.CanaryStatusCancelled)
+	return &run, nil
+}
+
+// loop runs the steps of a run at each interval, until it finishes or it is cancelled.
+func (c *CanaryController) loop(r *canaryRun) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			c.logger.Error().Msgf("Canary run [%s] panicked: %v\n%s", r.run.ID, rec, debug.Stack())
+		}
+	}()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			if finished := c.step(r); finished {
+				return
+			}
+		}
+	}
+}
+
+// step checks the canary metrics and promotes, completes or rolls back the run.
+// It returns true when the run is finished.
+func (c *CanaryController) step(r *canaryRun) bool {
+	r.stepMu.Lock()
+	defer r.stepMu.Unlock()
+	if r.ctx.Err() != nil {
+		return true
+	}
+
+	weight := r.currentWeight(c)
+	request := r.run.Request
+	event := c.analyze(r)
+
+	layer, err := c.saLayer()
+	if err != nil {
+		c.finish(r, models.CanaryEvent{Action: models.CanaryActionFail, Weight: weight, ErrorRatio: -1, Message: err.Error()}, models.CanaryStatusFailed)
+		return true
+	}
+
+	if event.Action == models.CanaryActionRollback {
+		if err := c.shift(r.ctx, layer, r, 0); err != nil {
+			event.Action = models.CanaryActionFail
+			event.Message += ". The rollback failed: " + err.Error()
+			c.finish(r, event, models.CanaryStatusFailed)
+			return true
+		}
+		event.Weight = 0
+		c.finish(r, event, models.CanaryStatusRolledBack)
+		return true
+	}
+
+	if weight >= request.MaxWeight {
+		event.Action = models.CanaryActionSucceed
+		event.Weight = weight
+		c.finish(r, event, models.CanaryStatusSucceeded)
+		return true
+	}
+
+	next := min(weight+request.StepWeight, request.MaxWeight)
+	if err := c.shift(r.ctx, layer, r, next); err != nil {
+		event.Action = models.CanaryActionFail
+		event.Weight = weight
+		event.Message = err.Error()
+		c.finish(r, event, models.CanaryStatusFailed)
+		return true
+	}
+	event.Action = models.CanaryActionPromote
+	event.Weight = next
+	c.record(r, event, "")
+	return false
+}
+
+// analyze evaluates the canary metrics over the last interval. The returned event has the rollback
+// action when a threshold is breached.
+func (c *CanaryController) analyze(r *canaryRun) models.CanaryEvent {
+	run := r.run
+	request := run.Request
+	rateInterval := model.Duration(r.interval).String()
+	queryTime := c.now()
+	event := models.CanaryEvent{ErrorRatio: -1}
+
+	// The canary requests are those to the backend service with the Gateway API, and those to the canary
+	// version of the service with the Istio config.
+	destination := run.Service
+	if request.Canary.Backend != "" {
+		destination = request.Canary.Backend
+	}
+	rates, err := c.prom.GetServiceRequestRates(r.ctx, run.Namespace, run.Cluster, destination, rateInterval, queryTime)
+	if err != nil {
+		event.Action = models.CanaryActionRollback
+		event.Message = "Canary metrics are not available: " + err.Error()
+		return event
+	}
+	requests := models.NewEmptyRequestHealth()
+	for _, sample := range rates {
+		if request.Canary.Version != "" && string(sample.Metric["destination_canonical_revision"]) != request.Canary.Version {
+			continue
+		}
+		requests.AggregateInbound(sample)
+	}
+	requests.CombineReporters()
+
+	health := c.calculator.CalculateServiceHealth(run.Namespace, destination, &models.ServiceHealth{Requests: requests}, r.healthAnnotations)
+	event.Health = health.Status
+	event.ErrorRatio = health.ErrorRatio
+
+	messages := []string{}
+	if health.Status == models.HealthStatusFailure {
+		messages = append(messages, fmt.Sprintf("The canary error ratio %.2f%% breaches the failure tolerance", health.ErrorRatio))
+	}
+
+	if request.MaxLatency > 0 {
+		labels := fmt.Sprintf(`{reporter="destination",destination_service_name="%s",destination_service_namespace="%s"`, destination, run.Namespace)
+		if request.Canary.Version != "" {
+			labels += fmt.Sprintf(`,destination_canonical_revision="%s"`, request.Canary.Version)
+		}
+		labels += "}"
+		histogram, err := c.prom.FetchHistogramValues(r.ctx, "istio_request_duration_milliseconds", labels, "", rateInterval, false, []string{canaryLatencyQuantile}, queryTime)
+		if err != nil {
+			messages = append(messages, "Canary latency is not available: "+err.Error())
+		} else {
+			for _, sample := range histogram[canaryLatencyQuantile] {
+				latency := float64(sample.Value)
+				if math.IsNaN(latency) {
+					continue
+				}
+				event.Latency = &latency
+				if latency > request.MaxLatency {
+					messages = append(messages, fmt.Sprintf("The canary latency %.0fms breaches the maximum of %.0fms", latency, request.MaxLatency))
+				}
+			}
+		}
+	}
+
+	if len(messages) > 0 {
+		event.Action = models.CanaryActionRollback
+		event.Message = strings.Join(messages, ". ")
+	}
+	return event
+}
+
+// shift applies the traffic_shifting template sending weight percent of the traffic to the canary.
+func (c *CanaryController) shift(ctx context.Context, layer *Layer, r *canaryRun, weight int32) error {
+	run := r.run
+	request := run.Request
+	template := models.TrafficTemplateRequest{
+		GatewayAPI: request.GatewayAPI,
+		Routes: []models.TrafficTemplateRoute{
+			{Version: request.Baseline.Version, Backend: request.Baseline.Backend, Weight: 100 - weight},
+			{Version: request.Canary.Version, Backend: request.Canary.Backend, Weight: weight},
+		},
+	}
+	result, err := layer.IstioConfig.GenerateTrafficTemplate(ctx, run.Cluster, run.Namespace, run.Service, models.TrafficTemplateTrafficShifting, template)
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		checks := []string{}
+		for key, validation := range result.Validations {
+			for _, check := range validation.Checks {
+				checks = append(checks, fmt.Sprintf("%s %s: %s", key.ObjectGVK.Kind, key.Name, check.Message))
+			}
+		}
+		sort.Strings(checks)
+		return api_errors.NewBadRequest("the traffic shift of the canary has validation errors: " + strings.Join(checks, "; "))
+	}
+	_, err = layer.IstioConfig.ApplyTrafficTemplate(ctx, result)
+	return err
+}
+
+// finish stops a run with its last event.
+func (c *CanaryController) finish(r *canaryRun, event models.CanaryEvent, status string) {
+	c.record(r, event, status)
+	r.cancel()
+	c.logger.Info().Msgf("Canary run [%s] of service [%s/%s] in cluster [%s] %s: %s", r.run.ID, r.run.Namespace, r.run.Service, r.run.Cluster, status, event.Message)
+}
+
+// record appends an event to the history of a run, updating its weight and, when set, its status.
+func (c *CanaryController) record(r *canaryRun, event models.CanaryEvent, status string) models.CanaryRun {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	event.Time = now
+	r.run.History = append(r.run.History, event)
+	r.run.Weight = event.Weight
+	r.run.Message = event.Message
+	if status != "" {
+		r.run.Status = status
+		r.run.EndTime = &now
+		c.pruneFinished()
+	}
+	return copyCanaryRun(r.run)
+}
+
+// pruneFinished forgets the oldest finished runs beyond maxFinishedCanaryRuns. The caller holds the lock.
+func (c *CanaryController) pruneFinished() {
+	finished := []*canaryRun{}
+	for _, r := range c.runs {
+		if r.run.Finished() {
+			finished = append(finished, r)
+		}
+	}
+	if len(finished) <= maxFinishedCanaryRuns {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].run.EndTime.Before(*finished[j].run.EndTime) })
+	for _, r := range finished[:len(finished)-maxFinishedCanaryRuns] {
+		delete(c.runs, r.run.ID)
+	}
+}
+
+func (r *canaryRun) currentWeight(c *CanaryController) int32 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return r.run.Weight
+}
+
+// copyCanaryRun copies a run so that its history can be returned while the run goes on.
+func copyCanaryRun(run models.CanaryRun) models.CanaryRun {
+	run.History = append([]models.CanaryEvent{}, run.History...)
+	return run
+}
+
+// validateCanaryRequest checks the request and sets its defaults. It returns the interval between the steps.
+func validateCanaryRequest(request models.CanaryRequest) (models.CanaryRequest, time.Duration, error) {
+	if request.StepWeight == 0 {
+		request.StepWeight = defaultCanaryStepWeight
+	}
+	if request.MaxWeight == 0 {
+		request.MaxWeight = 100
+	}
+	if request.StepWeight < 0 || request.StepWeight > 100 {
+		return request, 0, api_errors.NewBadRequest("the step weight must be between 1 and 100")
+	}
+	if request.MaxWeight < request.StepWeight || request.MaxWeight > 100 {
+		return request, 0, api_errors.NewBadRequest("the max weight must be between the step weight and 100")
+	}
+	if request.MaxLatency < 0 {
+		return request, 0, api_errors.NewBadRequest("the max latency must not be negative")
+	}
+
+	interval := defaultCanaryInterval
+	if request.Interval != "" {
+		parsed, err := time.ParseDuration(request.Interval)
+		if err != nil || parsed < minCanaryInterval {
+			return request, 0, api_errors.NewBadRequest(fmt.Sprintf("invalid interval [%s]: must be a duration of at least %s", request.Interval, minCanaryInterval))
+		}
+		interval = parsed
+	}
+
+	if request.GatewayAPI {
+		if request.Baseline.Version != "" || request.Canary.Version != "" {
+			return request, 0, api_errors.NewBadRequest("the versions of a canary run are backends with the Gateway API")
+		}
+		if request.Canary.Backend == "" || request.Canary.Backend == request.Baseline.Backend {
+			return request, 0, api_errors.NewBadRequest("the canary requires a backend different from the baseline")
+		}
+	} else {
+		if request.Baseline.Backend != "" || request.Canary.Backend != "" {
+			return request, 0, api_errors.NewBadRequest("backends are only supported with the Gateway API")
+		}
+		if request.Baseline.Version == "" || request.Canary.Version == "" || request.Baseline.Version == request.Canary.Version {
+			return request, 0, api_errors.NewBadRequest("the canary requires a baseline and a canary version")
+		}
+	}
+	return request, interval, nil
+}
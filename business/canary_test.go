@@ -0,0 +1,225 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func setupCanaryController(t *testing.T, prom *prometheustest.PromClientMock) (*CanaryController, *Layer, *kubetest.FakeK8sClient) {
+	t.Helper()
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	objects := []runtime.Object{kubetest.FakeNamespace("bookinfo"), &reviews}
+	for _, version := range []string{"v1", "v2"} {
+		objects = append(objects, &core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "reviews-" + version,
+			Namespace: "bookinfo",
+			Labels:    map[string]string{"app": "reviews", "version": version},
+		}})
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	layer := NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build()
+
+	controller := NewCanaryController(t.Context(), nil, nil, conf, nil, prom)
+	controller.saLayer = func() (*Layer, error) { return layer, nil }
+	return controller, layer, k8s
+}
+
+func canarySample(version, code string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{
+			"reporter":                       "destination",
+			"request_protocol":               "http",
+			"response_code":                  model.LabelValue(code),
+			"destination_canonical_revision": model.LabelValue(version),
+		},
+		Value: model.SampleValue(value),
+	}
+}
+
+func assertCanaryWeights(t *testing.T, k8s *kubetest.FakeK8sClient, baseline, canary int32) {
+	t.Helper()
+	vs, err := k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, vs.Spec.Http, 1)
+	require.Len(t, vs.Spec.Http[0].Route, 2)
+	assert.Equal(t, "v1", vs.Spec.Http[0].Route[0].Destination.Subset)
+	assert.Equal(t, baseline, vs.Spec.Http[0].Route[0].Weight)
+	assert.Equal(t, "v2", vs.Spec.Http[0].Route[1].Destination.Subset)
+	assert.Equal(t, canary, vs.Spec.Http[0].Route[1].Weight)
+}
+
+func TestCanaryPromotesUntilSuccess(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	prom := new(prometheustest.PromClientMock)
+	// The baseline errors are not taken into account
+	prom.MockServiceRequestRates(context.Background(), "bookinfo", "east", "reviews", model.Vector{
+		canarySample("v1", "500", 10),
+		canarySample("v2", "200", 10),
+		canarySample("v2", "404", 0.1),
+	})
+	prom.On("FetchHistogramValues", mock.Anything, "istio_request_duration_milliseconds",
+		`{reporter="destination",destination_service_name="reviews",destination_service_namespace="bookinfo",destination_canonical_revision="v2"}`,
+		"", "1h", false, []string{"0.95"}, mock.AnythingOfType("time.Time"),
+	).Return(map[string]model.Vector{"0.95": {&model.Sample{Value: 120}}}, nil)
+	controller, layer, k8s := setupCanaryController(t, prom)
+
+	run, err := controller.Start(context.Background(), layer, "east", "bookinfo", "reviews", "jdoe", models.CanaryRequest{
+		Baseline:   models.CanaryTarget{Version: "v1"},
+		Canary:     models.CanaryTarget{Version: "v2"},
+		StepWeight: 50,
+		Interval:   "1h",
+		MaxLatency: 500,
+	})
+	require.NoError(err)
+	assert.Equal(models.CanaryStatusRunning, run.Status)
+	assert.Equal(int32(50), run.Weight)
+	assert.Equal("jdoe", run.User)
+	assertCanaryWeights(t, k8s, 50, 50)
+
+	// A second run of the same service is rejected
+	_, err = controller.Start(context.Background(), layer, "east", "bookinfo", "reviews", "jdoe", run.Request)
+	assert.True(api_errors.IsConflict(err))
+
+	r := controller.runs[run.ID]
+	assert.False(controller.step(r))
+	assertCanaryWeights(t, k8s, 0, 100)
+	assert.True(controller.step(r))
+
+	run, found := controller.Get(run.ID)
+	require.True(found)
+	assert.Equal(models.CanaryStatusSucceeded, run.Status)
+	assert.Equal(int32(100), run.Weight)
+	assert.NotNil(run.EndTime)
+	require.Len(run.History, 3)
+	assert.Equal(models.CanaryActionStart, run.History[0].Action)
+	assert.Equal(models.CanaryActionPromote, run.History[1].Action)
+	assert.Equal(int32(100), run.History[1].Weight)
+	assert.Equal(models.HealthStatusHealthy, run.History[1].Health)
+	require.NotNil(run.History[1].Latency)
+	assert.Equal(float64(120), *run.History[1].Latency)
+	assert.Equal(models.CanaryActionSucceed, run.History[2].Action)
+	assert.Len(controller.List("east", "bookinfo", "reviews"), 1)
+	assert.Empty(controller.List("east", "bookinfo", "details"))
+}
+
+func TestCanaryRollsBackWhenTolerancesAreBreached(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	prom := new(prometheustest.PromClientMock)
+	prom.MockServiceRequestRates(context.Background(), "bookinfo", "east", "reviews", model.Vector{
+		canarySample("v1", "200", 10),
+		canarySample("v2", "200", 1),
+		canarySample("v2", "503", 1),
+	})
+	controller, layer, k8s := setupCanaryController(t, prom)
+
+	run, err := controller.Start(context.Background(), layer, "east", "bookinfo", "reviews", "", models.CanaryRequest{
+		Baseline: models.CanaryTarget{Version: "v1"},
+		Canary:   models.CanaryTarget{Version: "v2"},
+		Interval: "1h",
+	})
+	require.NoError(err)
+	assertCanaryWeights(t, k8s, 90, 10)
+
+	assert.True(controller.step(controller.runs[run.ID]))
+	assertCanaryWeights(t, k8s, 100, 0)
+
+	run, _ = controller.Get(run.ID)
+	assert.Equal(models.CanaryStatusRolledBack, run.Status)
+	assert.Equal(int32(0), run.Weight)
+	last := run.History[len(run.History)-1]
+	assert.Equal(models.CanaryActionRollback, last.Action)
+	assert.Equal(models.HealthStatusFailure, last.Health)
+	assert.Equal(float64(50), last.ErrorRatio)
+	assert.Contains(last.Message, "breaches the failure tolerance")
+}
+
+func TestCanaryCancel(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	controller, layer, k8s := setupCanaryController(t, new(prometheustest.PromClientMock))
+	request := models.CanaryRequest{
+		Baseline:   models.CanaryTarget{Version: "v1"},
+		Canary:     models.CanaryTarget{Version: "v2"},
+		StepWeight: 20,
+		Interval:   "1h",
+	}
+	run, err := controller.Start(context.Background(), layer, "east", "bookinfo", "reviews", "", request)
+	require.NoError(err)
+	assertCanaryWeights(t, k8s, 80, 20)
+
+	run, err = controller.Cancel(context.Background(), layer, run.ID, "jdoe")
+	require.NoError(err)
+	assert.Equal(models.CanaryStatusCancelled, run.Status)
+	assert.Equal("Cancelled by jdoe", run.Message)
+	assertCanaryWeights(t, k8s, 100, 0)
+	// The cancelled run no longer steps
+	assert.True(controller.step(controller.runs[run.ID]))
+	run, _ = controller.Get(run.ID)
+	assert.Len(run.History, 2)
+
+	_, err = controller.Cancel(context.Background(), layer, run.ID, "jdoe")
+	assert.True(api_errors.IsConflict(err))
+	_, err = controller.Cancel(context.Background(), layer, "unknown", "jdoe")
+	assert.True(api_errors.IsNotFound(err))
+
+	// A new run can start once the previous one is finished
+	_, err = controller.Start(context.Background(), layer, "east", "bookinfo", "reviews", "", request)
+	require.NoError(err)
+	assert.Len(controller.List("east", "bookinfo", "reviews"), 2)
+}
+
+func TestCanaryBadRequests(t *testing.T) {
+	controller, layer, _ := setupCanaryController(t, new(prometheustest.PromClientMock))
+
+	cases := map[string]models.CanaryRequest{
+		"no canary version":                {Baseline: models.CanaryTarget{Version: "v1"}},
+		"same versions":                    {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Version: "v1"}},
+		"backends without the Gateway API": {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Backend: "reviews-v2"}},
+		"versions with the Gateway API":    {GatewayAPI: true, Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Backend: "reviews-v2"}},
+		"step weight over 100":             {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Version: "v2"}, StepWeight: 120},
+		"max weight below step":            {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Version: "v2"}, StepWeight: 50, MaxWeight: 20},
+		"interval too short":               {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Version: "v2"}, Interval: "1s"},
+		"invalid interval":                 {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Version: "v2"}, Interval: "soon"},
+		"unknown canary subset":            {Baseline: models.CanaryTarget{Version: "v1"}, Canary: models.CanaryTarget{Version: "v3"}},
+	}
+	for name, request := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := controller.Start(context.Background(), layer, "east", "bookinfo", "reviews", "", request)
+			assert.Truef(t, api_errors.IsBadRequest(err), "unexpected error: %v", err)
+		})
+	}
+	assert.Empty(t, controller.List("east", "bookinfo", "reviews"))
+
+	_, err := controller.Start(context.Background(), layer, "east", "bookinfo", "details", "", models.CanaryRequest{
+		Baseline: models.CanaryTarget{Version: "v1"},
+		Canary:   models.CanaryTarget{Version: "v2"},
+	})
+	assert.True(t, api_errors.IsNotFound(err))
+}
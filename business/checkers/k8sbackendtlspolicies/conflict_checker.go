@@ -0,0 +1,67 @@
+package k8sbackendtlspolicies
+
+import (
+	"fmt"
+
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/models"
+)
+
+type ConflictChecker struct {
+	K8sBackendTLSPolicies []*k8s_networking_v1.BackendTLSPolicy
+	K8sBackendTLSPolicy   *k8s_networking_v1.BackendTLSPolicy
+}
+
+// Check validates that no other BackendTLSPolicy taking precedence targets the same Service port. Following the Gateway
+// API rules, the oldest policy takes precedence, using namespace/name as a tie-breaker.
+func (c ConflictChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for i, ref := range c.K8sBackendTLSPolicy.Spec.TargetRefs {
+		if !IsServiceTargetRef(ref) {
+			continue
+		}
+		for _, other := range c.K8sBackendTLSPolicies {
+			if other.Namespace != c.K8sBackendTLSPolicy.Namespace || !takesPrecedence(other, c.K8sBackendTLSPolicy) || !targetsSameSection(other, ref) {
+				continue
+			}
+			validation := models.Build("k8sbackendtlspolicies.targetref.conflict", fmt.Sprintf("spec/targetRefs[%d]/name", i))
+			validations = append(validations, &validation)
+			break
+		}
+	}
+
+	// Conflicts are reported as warnings, the policy still applies to its other targets
+	return validations, true
+}
+
+func targetsSameSection(policy *k8s_networking_v1.BackendTLSPolicy, ref k8s_networking_v1.LocalPolicyTargetReferenceWithSectionName) bool {
+	for _, r := range policy.Spec.TargetRefs {
+		if !IsServiceTargetRef(r) || r.Name != ref.Name {
+			continue
+		}
+		section, otherSection := "", ""
+		if ref.SectionName != nil {
+			section = string(*ref.SectionName)
+		}
+		if r.SectionName != nil {
+			otherSection = string(*r.SectionName)
+		}
+		if section == otherSection {
+			return true
+		}
+	}
+	return false
+}
+
+// takesPrecedence returns true when policy p1 is older than p2, using namespace/name as a tie-breaker
+func takesPrecedence(p1, p2 *k8s_networking_v1.BackendTLSPolicy) bool {
+	if p1.Namespace == p2.Namespace && p1.Name == p2.Name {
+		return false
+	}
+	if !p1.CreationTimestamp.Equal(&p2.CreationTimestamp) {
+		return p1.CreationTimestamp.Before(&p2.CreationTimestamp)
+	}
+	return fmt.Sprintf("%s/%s", p1.Namespace, p1.Name) < fmt.Sprintf("%s/%s", p2.Namespace, p2.Name)
+}
@@ -0,0 +1,43 @@
+package k8sbackendtlspolicies
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestBackendTLSPolicyConflict(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	older := data.CreateBackendTLSPolicy("older", "bookinfo", "reviews", "")
+	older.CreationTimestamp = meta_v1.NewTime(time.Now().Add(-time.Hour))
+	newer := data.CreateBackendTLSPolicy("newer", "bookinfo", "reviews", "")
+	newer.CreationTimestamp = meta_v1.NewTime(time.Now())
+	otherPort := data.CreateBackendTLSPolicy("other-port", "bookinfo", "reviews", "https")
+	policies := []*k8s_networking_v1.BackendTLSPolicy{older, newer, otherPort}
+
+	vals, valid := ConflictChecker{K8sBackendTLSPolicies: policies, K8sBackendTLSPolicy: older}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	vals, valid = ConflictChecker{K8sBackendTLSPolicies: policies, K8sBackendTLSPolicy: otherPort}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	vals, valid = ConflictChecker{K8sBackendTLSPolicies: policies, K8sBackendTLSPolicy: newer}.Check()
+	assert.True(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.WarningSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sbackendtlspolicies.targetref.conflict", vals[0]))
+	assert.Equal("spec/targetRefs[0]/name", vals[0].Path)
+}
@@ -0,0 +1,56 @@
+package k8sbackendtlspolicies
+
+import (
+	"fmt"
+
+	core_v1 "k8s.io/api/core/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type TargetRefChecker struct {
+	K8sBackendTLSPolicy *k8s_networking_v1.BackendTLSPolicy
+	Services            []core_v1.Service
+}
+
+// Check validates that the BackendTLSPolicy targetRefs point to existing Services in the policy namespace, and to
+// existing Service ports when a sectionName is set
+func (t TargetRefChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for i, ref := range t.K8sBackendTLSPolicy.Spec.TargetRefs {
+		if !IsServiceTargetRef(ref) {
+			continue
+		}
+		if !t.hasTarget(ref) {
+			validation := models.Build("k8sbackendtlspolicies.targetref.notfound", fmt.Sprintf("spec/targetRefs[%d]/name", i))
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, len(validations) == 0
+}
+
+func (t TargetRefChecker) hasTarget(ref k8s_networking_v1.LocalPolicyTargetReferenceWithSectionName) bool {
+	for _, svc := range t.Services {
+		if svc.Name != string(ref.Name) || svc.Namespace != t.K8sBackendTLSPolicy.Namespace {
+			continue
+		}
+		if ref.SectionName == nil || *ref.SectionName == "" {
+			return true
+		}
+		for _, port := range svc.Spec.Ports {
+			if port.Name == string(*ref.SectionName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsServiceTargetRef returns true when the policy targetRef points to a core Service
+func IsServiceTargetRef(ref k8s_networking_v1.LocalPolicyTargetReferenceWithSectionName) bool {
+	return string(ref.Kind) == kubernetes.ServiceType && (ref.Group == "" || ref.Group == "core")
+}
@@ -0,0 +1,62 @@
+package k8sbackendtlspolicies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func fakeServicesWithPort() []core_v1.Service {
+	services := data.CreateFakeMultiServices([]string{"reviews.bookinfo.svc.cluster.local"}, "bookinfo")
+	services[0].Spec.Ports = []core_v1.ServicePort{{Name: "https", Port: 443}}
+	return services
+}
+
+func TestBackendTLSPolicyValidTarget(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := TargetRefChecker{
+		K8sBackendTLSPolicy: data.CreateBackendTLSPolicy("policy", "bookinfo", "reviews", "https"),
+		Services:            fakeServicesWithPort(),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestBackendTLSPolicyTargetNotFound(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	cases := map[string]struct {
+		service     string
+		sectionName string
+	}{
+		"missing service": {service: "ratings"},
+		"missing port":    {service: "reviews", sectionName: "grpc"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			vals, valid := TargetRefChecker{
+				K8sBackendTLSPolicy: data.CreateBackendTLSPolicy("policy", "bookinfo", tc.service, tc.sectionName),
+				Services:            fakeServicesWithPort(),
+			}.Check()
+
+			assert.False(valid)
+			assert.Len(vals, 1)
+			assert.Equal(models.ErrorSeverity, vals[0].Severity)
+			assert.NoError(validations.ConfirmIstioCheckMessage("k8sbackendtlspolicies.targetref.notfound", vals[0]))
+			assert.Equal("spec/targetRefs[0]/name", vals[0].Path)
+		})
+	}
+}
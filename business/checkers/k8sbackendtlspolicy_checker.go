@@ -0,0 +1,50 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/business/checkers/k8sbackendtlspolicies"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sBackendTLSPolicyChecker struct {
+	Cluster               string
+	K8sBackendTLSPolicies []*k8s_networking_v1.BackendTLSPolicy
+	Services              []core_v1.Service
+}
+
+// Check runs checks for the all namespaces actions as well as for the single namespace validations
+func (in K8sBackendTLSPolicyChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	for _, policy := range in.K8sBackendTLSPolicies {
+		validations.MergeValidations(in.runChecks(policy))
+	}
+
+	return validations
+}
+
+func (in K8sBackendTLSPolicyChecker) runChecks(policy *k8s_networking_v1.BackendTLSPolicy) models.IstioValidations {
+	key, validations := EmptyValidValidation(policy.Name, policy.Namespace, kubernetes.K8sBackendTLSPolicies, in.Cluster)
+
+	enabledCheckers := []Checker{
+		k8sbackendtlspolicies.TargetRefChecker{
+			K8sBackendTLSPolicy: policy,
+			Services:            in.Services,
+		},
+		k8sbackendtlspolicies.ConflictChecker{
+			K8sBackendTLSPolicies: in.K8sBackendTLSPolicies,
+			K8sBackendTLSPolicy:   policy,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
@@ -2,6 +2,7 @@ package checkers
 
 import (
 	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/kiali/kiali/business/checkers/k8sgateways"
 	"github.com/kiali/kiali/config"
@@ -10,9 +11,10 @@ import (
 )
 
 type K8sGatewayChecker struct {
-	K8sGateways    []*k8s_networking_v1.Gateway
-	GatewayClasses []config.GatewayAPIClass
-	Cluster        string
+	K8sGateways        []*k8s_networking_v1.Gateway
+	GatewayClasses     []config.GatewayAPIClass
+	Cluster            string
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
 }
 
 // Check runs checks for the all namespaces actions as well as for the single namespace validations
@@ -41,6 +43,12 @@ func (g K8sGatewayChecker) runSingleChecks(gw *k8s_networking_v1.Gateway) models
 			K8sGateway:     gw,
 			GatewayClasses: g.GatewayClasses,
 		},
+		k8sgateways.CertificateRefChecker{
+			K8sReferenceGrants: g.K8sReferenceGrants,
+			Kind:               kubernetes.K8sGatewayType,
+			Listeners:          gw.Spec.Listeners,
+			Namespace:          gw.Namespace,
+		},
 	}
 
 	for _, checker := range enabledCheckers {
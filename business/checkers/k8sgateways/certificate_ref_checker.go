@@ -0,0 +1,45 @@
+package k8sgateways
+
+import (
+	"fmt"
+
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// CertificateRefChecker validates the certificateRefs of the listeners of a Gateway or a ListenerSet
+type CertificateRefChecker struct {
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
+	// Kind of the object owning the listeners: Gateway or ListenerSet
+	Kind      string
+	Listeners []k8s_networking_v1.Listener
+	Namespace string
+}
+
+// Check validates that the certificateRefs pointing to Secrets in another namespace are allowed by a ReferenceGrant
+func (c CertificateRefChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	for i, l := range c.Listeners {
+		if l.TLS == nil {
+			continue
+		}
+		for j, ref := range l.TLS.CertificateRefs {
+			if ref.Kind != nil && string(*ref.Kind) != kubernetes.SecretType {
+				continue
+			}
+			if ref.Namespace == nil || string(*ref.Namespace) == "" || string(*ref.Namespace) == c.Namespace {
+				continue
+			}
+			if !kubernetes.HasMatchingReferenceGrant(c.Namespace, string(*ref.Namespace), c.Kind, kubernetes.SecretType, c.K8sReferenceGrants) {
+				validation := models.Build("k8sgateways.certificateref.nogrant", fmt.Sprintf("spec/listeners[%d]/tls/certificateRefs[%d]/name", i, j))
+				validations = append(validations, &validation)
+			}
+		}
+	}
+
+	return validations, len(validations) == 0
+}
@@ -0,0 +1,78 @@
+package k8sgateways
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func httpsListener(certNamespace string) k8s_networking_v1.Listener {
+	listener := data.CreateListener("https", "bookinfo.example.com", 443, "HTTPS")
+	ref := k8s_networking_v1.SecretObjectReference{Name: "bookinfo-cert"}
+	if certNamespace != "" {
+		ns := k8s_networking_v1.Namespace(certNamespace)
+		ref.Namespace = &ns
+	}
+	listener.TLS = &k8s_networking_v1.ListenerTLSConfig{CertificateRefs: []k8s_networking_v1.SecretObjectReference{ref}}
+	return listener
+}
+
+func TestCertificateRefSameNamespace(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := CertificateRefChecker{
+		Kind:      kubernetes.K8sGatewayType,
+		Listeners: []k8s_networking_v1.Listener{httpsListener("")},
+		Namespace: "bookinfo",
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestCertificateRefMissingGrant(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := CertificateRefChecker{
+		Kind:      kubernetes.K8sGatewayType,
+		Listeners: []k8s_networking_v1.Listener{httpsListener("certs")},
+		Namespace: "bookinfo",
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sgateways.certificateref.nogrant", vals[0]))
+	assert.Equal("spec/listeners[0]/tls/certificateRefs[0]/name", vals[0].Path)
+}
+
+func TestCertificateRefWithGrant(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	grant := data.CreateReferenceGrantByKind("grant", "certs", "bookinfo", kubernetes.K8sGatewayType)
+	grant.Spec.To = []k8s_networking_v1beta1.ReferenceGrantTo{{Kind: kubernetes.SecretType}}
+
+	vals, valid := CertificateRefChecker{
+		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{grant},
+		Kind:               kubernetes.K8sGatewayType,
+		Listeners:          []k8s_networking_v1.Listener{httpsListener("certs")},
+		Namespace:          "bookinfo",
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
@@ -68,6 +68,53 @@ func CheckGateway(gwName, gwNs, routeNs, cluster string, gatewayNames map[string
 	return false
 }
 
+// CheckParentListeners validates the Gateway and ListenerSet parentRefs of a route: the parent must exist and be
+// accessible from the route namespace, and the sectionName and port, when set, must select at least one listener.
+func CheckParentListeners(parentRefs []k8s_networking_v1.ParentReference, routeNs, cluster string, gatewayNames map[string]k8s_networking_v1.Gateway, listenerSets []*k8s_networking_v1.ListenerSet, nss models.Namespaces, validations *[]*models.IstioCheck, identityDomain string) bool {
+	valid := true
+
+	for index, parentRef := range parentRefs {
+		if string(parentRef.Name) == "" || (parentRef.Group != nil && string(*parentRef.Group) != kubernetes.K8sGateways.Group) {
+			continue
+		}
+		isGateway := parentRef.Kind == nil || string(*parentRef.Kind) == kubernetes.K8sGateways.Kind
+		isListenerSet := parentRef.Kind != nil && string(*parentRef.Kind) == kubernetes.K8sListenerSets.Kind
+		if !isGateway && !isListenerSet {
+			continue
+		}
+		location := fmt.Sprintf("spec/parentRefs[%d]/name/%s", index, string(parentRef.Name))
+
+		if isGateway {
+			gwNs := routeNs
+			if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+				gwNs = string(*parentRef.Namespace)
+			}
+			if !CheckGateway(string(parentRef.Name), gwNs, routeNs, cluster, gatewayNames, nss, validations, location, identityDomain) {
+				valid = false
+				continue
+			}
+		}
+
+		listeners, found := kubernetes.K8sParentRefListeners(parentRef, routeNs, gatewayNames, listenerSets, identityDomain)
+		if !found {
+			validation := models.Build("k8sroutes.nok8slistener", location)
+			*validations = append(*validations, &validation)
+			valid = false
+			continue
+		}
+		if len(listeners) == 0 && ((parentRef.SectionName != nil && *parentRef.SectionName != "") || (parentRef.Port != nil && *parentRef.Port != 0)) {
+			path := fmt.Sprintf("spec/parentRefs[%d]/port", index)
+			if parentRef.SectionName != nil && *parentRef.SectionName != "" {
+				path = fmt.Sprintf("spec/parentRefs[%d]/sectionName", index)
+			}
+			validation := models.Build("k8sroutes.nok8slistener", path)
+			*validations = append(*validations, &validation)
+			valid = false
+		}
+	}
+	return valid
+}
+
 // IsGatewaySharedWithNS returns true if any listener on the Gateway allows
 // routes from the given namespace via allowedRoutes.namespaces. It supports
 // the full Kubernetes LabelSelector (both matchLabels and matchExpressions).
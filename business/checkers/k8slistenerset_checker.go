@@ -0,0 +1,72 @@
+package checkers
+
+import (
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/business/checkers/k8sgateways"
+	"github.com/kiali/kiali/business/checkers/k8slistenersets"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sListenerSetChecker struct {
+	Cluster            string
+	IdentityDomain     string
+	K8sGateways        []*k8s_networking_v1.Gateway
+	K8sListenerSets    []*k8s_networking_v1.ListenerSet
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
+	Namespaces         models.Namespaces
+}
+
+// Check runs checks for the all namespaces actions as well as for the single namespace validations
+func (in K8sListenerSetChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	gatewayNames := kubernetes.K8sGatewayNames(in.K8sGateways, in.IdentityDomain)
+
+	for _, ls := range in.K8sListenerSets {
+		validations.MergeValidations(in.runChecks(ls, gatewayNames))
+	}
+
+	return validations
+}
+
+func (in K8sListenerSetChecker) runChecks(ls *k8s_networking_v1.ListenerSet, gatewayNames map[string]k8s_networking_v1.Gateway) models.IstioValidations {
+	key, validations := EmptyValidValidation(ls.Name, ls.Namespace, kubernetes.K8sListenerSets, in.Cluster)
+
+	listeners := make([]k8s_networking_v1.Listener, 0, len(ls.Spec.Listeners))
+	for _, l := range ls.Spec.Listeners {
+		listeners = append(listeners, k8s_networking_v1.Listener(l))
+	}
+
+	enabledCheckers := []Checker{
+		k8slistenersets.NoK8sGatewayChecker{
+			Cluster:        in.Cluster,
+			GatewayNames:   gatewayNames,
+			IdentityDomain: in.IdentityDomain,
+			K8sListenerSet: ls,
+			Namespaces:     in.Namespaces,
+		},
+		k8slistenersets.ListenerConflictChecker{
+			GatewayNames:    gatewayNames,
+			IdentityDomain:  in.IdentityDomain,
+			K8sListenerSet:  ls,
+			K8sListenerSets: in.K8sListenerSets,
+		},
+		k8sgateways.CertificateRefChecker{
+			K8sReferenceGrants: in.K8sReferenceGrants,
+			Kind:               kubernetes.K8sListenerSetType,
+			Listeners:          listeners,
+			Namespace:          ls.Namespace,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
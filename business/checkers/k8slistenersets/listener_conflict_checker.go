@@ -0,0 +1,86 @@
+package k8slistenersets
+
+import (
+	"fmt"
+
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type ListenerConflictChecker struct {
+	GatewayNames    map[string]k8s_networking_v1.Gateway
+	IdentityDomain  string
+	K8sListenerSet  *k8s_networking_v1.ListenerSet
+	K8sListenerSets []*k8s_networking_v1.ListenerSet
+}
+
+// Check validates that the ListenerSet listeners don't conflict with listeners taking precedence over them. Following
+// the Gateway API rules, listeners of the parent Gateway take precedence, then listeners of the oldest ListenerSets,
+// then the listeners declared first in the same ListenerSet.
+func (c ListenerConflictChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	ls := c.K8sListenerSet
+	parentRef := ls.Spec.ParentRef
+	gwNs := ls.Namespace
+	if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+		gwNs = string(*parentRef.Namespace)
+	}
+
+	// Listeners taking precedence over the ones of this ListenerSet
+	var precedent []k8s_networking_v1.Listener
+	if gw, found := c.GatewayNames[kubernetes.ParseHost(string(parentRef.Name), gwNs, c.IdentityDomain).String()]; found {
+		precedent = append(precedent, gw.Spec.Listeners...)
+		for _, other := range c.K8sListenerSets {
+			if kubernetes.IsK8sListenerSetParent(other, gw.Name, gw.Namespace) && takesPrecedence(other, ls) {
+				for _, l := range other.Spec.Listeners {
+					precedent = append(precedent, k8s_networking_v1.Listener(l))
+				}
+			}
+		}
+	}
+
+	for i, l := range ls.Spec.Listeners {
+		conflict := false
+		for _, p := range precedent {
+			conflict = conflict || conflicts(k8s_networking_v1.Listener(l), p)
+		}
+		for _, p := range ls.Spec.Listeners[:i] {
+			conflict = conflict || conflicts(k8s_networking_v1.Listener(l), k8s_networking_v1.Listener(p))
+		}
+		if conflict {
+			validation := models.Build("k8slistenersets.listener.conflict", fmt.Sprintf("spec/listeners[%d]/name", i))
+			validations = append(validations, &validation)
+		}
+	}
+
+	return validations, len(validations) == 0
+}
+
+// conflicts returns true when both listeners use the same port, protocol and hostname
+func conflicts(l1, l2 k8s_networking_v1.Listener) bool {
+	if l1.Port != l2.Port || l1.Protocol != l2.Protocol {
+		return false
+	}
+	hostname1, hostname2 := "", ""
+	if l1.Hostname != nil {
+		hostname1 = string(*l1.Hostname)
+	}
+	if l2.Hostname != nil {
+		hostname2 = string(*l2.Hostname)
+	}
+	return hostname1 == hostname2
+}
+
+// takesPrecedence returns true when ListenerSet ls1 is older than ls2, using namespace/name as a tie-breaker
+func takesPrecedence(ls1, ls2 *k8s_networking_v1.ListenerSet) bool {
+	if ls1.Namespace == ls2.Namespace && ls1.Name == ls2.Name {
+		return false
+	}
+	if !ls1.CreationTimestamp.Equal(&ls2.CreationTimestamp) {
+		return ls1.CreationTimestamp.Before(&ls2.CreationTimestamp)
+	}
+	return fmt.Sprintf("%s/%s", ls1.Namespace, ls1.Name) < fmt.Sprintf("%s/%s", ls2.Namespace, ls2.Name)
+}
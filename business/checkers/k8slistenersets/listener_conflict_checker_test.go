@@ -0,0 +1,88 @@
+package k8slistenersets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestListenerSetNoConflicts(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.AddListenerToK8sGateway(data.CreateListener("http", "bookinfo.example.com", 80, "HTTP"), data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+	ls := data.AddListenerToListenerSet(data.CreateListener("http", "reviews.example.com", 80, "HTTP"), data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "bookinfo"))
+
+	vals, valid := ListenerConflictChecker{
+		GatewayNames:    kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local"),
+		IdentityDomain:  "svc.cluster.local",
+		K8sListenerSet:  ls,
+		K8sListenerSets: []*k8s_networking_v1.ListenerSet{ls},
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestListenerSetConflictWithGateway(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.AddListenerToK8sGateway(data.CreateListener("http", "bookinfo.example.com", 80, "HTTP"), data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+	ls := data.AddListenerToListenerSet(data.CreateListener("other", "bookinfo.example.com", 80, "HTTP"),
+		data.AddListenerToListenerSet(data.CreateListener("http", "reviews.example.com", 80, "HTTP"), data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "bookinfo")))
+
+	vals, valid := ListenerConflictChecker{
+		GatewayNames:    kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local"),
+		IdentityDomain:  "svc.cluster.local",
+		K8sListenerSet:  ls,
+		K8sListenerSets: []*k8s_networking_v1.ListenerSet{ls},
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8slistenersets.listener.conflict", vals[0]))
+	assert.Equal("spec/listeners[1]/name", vals[0].Path)
+}
+
+func TestListenerSetConflictWithOlderListenerSet(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.CreateEmptyK8sGateway("gatewayapi", "bookinfo")
+	older := data.AddListenerToListenerSet(data.CreateListener("http", "bookinfo.example.com", 80, "HTTP"), data.CreateListenerSet("older", "bookinfo", "gatewayapi", "bookinfo"))
+	older.CreationTimestamp = meta_v1.NewTime(time.Now().Add(-time.Hour))
+	newer := data.AddListenerToListenerSet(data.CreateListener("http", "bookinfo.example.com", 80, "HTTP"), data.CreateListenerSet("newer", "bookinfo", "gatewayapi", "bookinfo"))
+	newer.CreationTimestamp = meta_v1.NewTime(time.Now())
+
+	checker := ListenerConflictChecker{
+		GatewayNames:    kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local"),
+		IdentityDomain:  "svc.cluster.local",
+		K8sListenerSets: []*k8s_networking_v1.ListenerSet{older, newer},
+	}
+
+	checker.K8sListenerSet = older
+	vals, valid := checker.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	checker.K8sListenerSet = newer
+	vals, valid = checker.Check()
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8slistenersets.listener.conflict", vals[0]))
+	assert.Equal("spec/listeners[0]/name", vals[0].Path)
+}
@@ -0,0 +1,74 @@
+package k8slistenersets
+
+import (
+	"fmt"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+type NoK8sGatewayChecker struct {
+	Cluster        string
+	GatewayNames   map[string]k8s_networking_v1.Gateway
+	IdentityDomain string
+	K8sListenerSet *k8s_networking_v1.ListenerSet
+	Namespaces     models.Namespaces
+}
+
+// Check validates that the ListenerSet is pointing to an existing Gateway which allows ListenerSets from its namespace
+func (s NoK8sGatewayChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	parentRef := s.K8sListenerSet.Spec.ParentRef
+	location := fmt.Sprintf("spec/parentRef/name/%s", string(parentRef.Name))
+	gwNs := s.K8sListenerSet.Namespace
+	if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+		gwNs = string(*parentRef.Namespace)
+	}
+
+	gw, found := s.GatewayNames[kubernetes.ParseHost(string(parentRef.Name), gwNs, s.IdentityDomain).String()]
+	if !found || !kubernetes.IsK8sListenerSetParent(s.K8sListenerSet, gw.Name, gw.Namespace) {
+		validation := models.Build("k8slistenersets.nok8sgateway", location)
+		validations = append(validations, &validation)
+		return validations, false
+	}
+
+	if !s.isAllowedByGateway(gw) {
+		validation := models.Build("k8slistenersets.notallowed", location)
+		validations = append(validations, &validation)
+	}
+
+	return validations, len(validations) == 0
+}
+
+// isAllowedByGateway returns true when the Gateway allowedListeners accept ListenerSets from the ListenerSet namespace.
+// The Gateway API default is to allow no ListenerSets.
+func (s NoK8sGatewayChecker) isAllowedByGateway(gw k8s_networking_v1.Gateway) bool {
+	if gw.Spec.AllowedListeners == nil || gw.Spec.AllowedListeners.Namespaces == nil || gw.Spec.AllowedListeners.Namespaces.From == nil {
+		return false
+	}
+
+	switch *gw.Spec.AllowedListeners.Namespaces.From {
+	case k8s_networking_v1.NamespacesFromAll:
+		return true
+	case k8s_networking_v1.NamespacesFromSame:
+		return gw.Namespace == s.K8sListenerSet.Namespace
+	case k8s_networking_v1.NamespacesFromSelector:
+		ns := s.Namespaces.GetNamespace(s.K8sListenerSet.Namespace, s.Cluster)
+		if ns == nil || gw.Spec.AllowedListeners.Namespaces.Selector == nil {
+			return false
+		}
+		selector, err := meta_v1.LabelSelectorAsSelector(gw.Spec.AllowedListeners.Namespaces.Selector)
+		if err != nil {
+			log.Errorf("skipping invalid gateway allowedListeners selector: %v", err)
+			return false
+		}
+		return selector.Matches(labels.Set(ns.Labels))
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package k8slistenersets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestListenerSetValidGateway(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.AllowListenersToK8sGateway(k8s_networking_v1.NamespacesFromSame, data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+
+	vals, valid := NoK8sGatewayChecker{
+		GatewayNames:   kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local"),
+		IdentityDomain: "svc.cluster.local",
+		K8sListenerSet: data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "bookinfo"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestListenerSetMissingGateway(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := NoK8sGatewayChecker{
+		GatewayNames:   make(map[string]k8s_networking_v1.Gateway),
+		IdentityDomain: "svc.cluster.local",
+		K8sListenerSet: data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "bookinfo"),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8slistenersets.nok8sgateway", vals[0]))
+	assert.Equal("spec/parentRef/name/gatewayapi", vals[0].Path)
+}
+
+func TestListenerSetNotAllowed(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	cases := map[string]struct {
+		gateway  *k8s_networking_v1.Gateway
+		expected bool
+	}{
+		"no allowedListeners": {gateway: data.CreateEmptyK8sGateway("gatewayapi", "istio-system"), expected: false},
+		"same namespace only": {gateway: data.AllowListenersToK8sGateway(k8s_networking_v1.NamespacesFromSame, data.CreateEmptyK8sGateway("gatewayapi", "istio-system")), expected: false},
+		"all namespaces":      {gateway: data.AllowListenersToK8sGateway(k8s_networking_v1.NamespacesFromAll, data.CreateEmptyK8sGateway("gatewayapi", "istio-system")), expected: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			vals, valid := NoK8sGatewayChecker{
+				GatewayNames:   kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{tc.gateway}, "svc.cluster.local"),
+				IdentityDomain: "svc.cluster.local",
+				K8sListenerSet: data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "istio-system"),
+				Namespaces:     models.Namespaces{{Name: "bookinfo", Cluster: config.DefaultClusterID}},
+			}.Check()
+
+			assert.Equal(tc.expected, valid)
+			if !tc.expected {
+				assert.Len(vals, 1)
+				assert.NoError(validations.ConfirmIstioCheckMessage("k8slistenersets.notallowed", vals[0]))
+			}
+		})
+	}
+}
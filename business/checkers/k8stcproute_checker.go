@@ -0,0 +1,63 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/business/checkers/k8stcproutes"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sTCPRouteChecker struct {
+	Cluster            string
+	IdentityDomain     string
+	K8sGateways        []*k8s_networking_v1.Gateway
+	K8sListenerSets    []*k8s_networking_v1.ListenerSet
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
+	K8sTCPRoutes       []*k8s_networking_v1.TCPRoute
+	Namespaces         models.Namespaces
+	Services           []core_v1.Service
+}
+
+// Check runs checks for the all namespaces actions as well as for the single namespace validations
+func (in K8sTCPRouteChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	gatewayNames := kubernetes.K8sGatewayNames(in.K8sGateways, in.IdentityDomain)
+
+	for _, rt := range in.K8sTCPRoutes {
+		validations.MergeValidations(in.runChecks(rt, gatewayNames))
+	}
+
+	return validations
+}
+
+func (in K8sTCPRouteChecker) runChecks(rt *k8s_networking_v1.TCPRoute, gatewayNames map[string]k8s_networking_v1.Gateway) models.IstioValidations {
+	key, validations := EmptyValidValidation(rt.Name, rt.Namespace, kubernetes.K8sTCPRoutes, in.Cluster)
+
+	enabledCheckers := []Checker{
+		k8stcproutes.NoK8sGatewayChecker{
+			Cluster:         in.Cluster,
+			GatewayNames:    gatewayNames,
+			IdentityDomain:  in.IdentityDomain,
+			K8sListenerSets: in.K8sListenerSets,
+			K8sTCPRoute:     rt,
+			Namespaces:      in.Namespaces,
+		},
+		k8stcproutes.NoHostChecker{
+			K8sReferenceGrants: in.K8sReferenceGrants,
+			K8sTCPRoute:        rt,
+			Services:           in.Services,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
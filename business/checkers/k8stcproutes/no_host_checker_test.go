@@ -0,0 +1,70 @@
+package k8stcproutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTCPRouteValidBackend(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := NoHostChecker{
+		K8sTCPRoute: data.AddBackendRefToTCPRoute("mysql", "", data.CreateTCPRoute("route", "bookinfo", "gatewayapi")),
+		Services:    data.CreateFakeMultiServices([]string{"mysql.bookinfo.svc.cluster.local"}, "bookinfo"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestTCPRouteBackendNotFound(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := NoHostChecker{
+		K8sTCPRoute: data.AddBackendRefToTCPRoute("mongodb", "", data.CreateTCPRoute("route", "bookinfo", "gatewayapi")),
+		Services:    data.CreateFakeMultiServices([]string{"mysql.bookinfo.svc.cluster.local"}, "bookinfo"),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", vals[0]))
+	assert.Equal("spec/rules[0]/backendRefs[0]/name", vals[0].Path)
+}
+
+func TestTCPRouteCrossNamespaceBackend(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	rt := data.AddBackendRefToTCPRoute("mysql", "bookinfo", data.CreateTCPRoute("route", "bookinfo2", "gatewayapi"))
+	services := data.CreateFakeMultiServices([]string{"mysql.bookinfo.svc.cluster.local"}, "bookinfo")
+
+	vals, valid := NoHostChecker{
+		K8sTCPRoute: rt,
+		Services:    services,
+	}.Check()
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", vals[0]))
+
+	vals, valid = NoHostChecker{
+		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{data.CreateReferenceGrantByKind("grant", "bookinfo", "bookinfo2", kubernetes.K8sTCPRouteType)},
+		K8sTCPRoute:        rt,
+		Services:           services,
+	}.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+}
@@ -0,0 +1,26 @@
+package k8stcproutes
+
+import (
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/business/checkers/k8shttproutes"
+	"github.com/kiali/kiali/models"
+)
+
+type NoK8sGatewayChecker struct {
+	Cluster         string
+	GatewayNames    map[string]k8s_networking_v1.Gateway
+	IdentityDomain  string
+	K8sListenerSets []*k8s_networking_v1.ListenerSet
+	K8sTCPRoute     *k8s_networking_v1.TCPRoute
+	Namespaces      models.Namespaces
+}
+
+// Check validates that the TCPRoute is pointing to existing Gateways, ListenerSets and listeners
+func (s NoK8sGatewayChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	valid := k8shttproutes.CheckParentListeners(s.K8sTCPRoute.Spec.ParentRefs, s.K8sTCPRoute.Namespace, s.Cluster, s.GatewayNames, s.K8sListenerSets, s.Namespaces, &validations, s.IdentityDomain)
+
+	return validations, valid
+}
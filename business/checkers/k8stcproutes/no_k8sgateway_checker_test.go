@@ -0,0 +1,99 @@
+package k8stcproutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestTCPRouteValidGateway(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.AddListenerToK8sGateway(data.CreateListener("tcp", "", 9000, "TCP"), data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+
+	vals, valid := NoK8sGatewayChecker{
+		IdentityDomain: "svc.cluster.local",
+		K8sTCPRoute:    data.CreateTCPRoute("route", "bookinfo", "gatewayapi"),
+		GatewayNames:   kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local"),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestTCPRouteMissingGateway(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := NoK8sGatewayChecker{
+		IdentityDomain: "svc.cluster.local",
+		K8sTCPRoute:    data.CreateTCPRoute("route", "bookinfo", "gatewayapi"),
+		GatewayNames:   make(map[string]k8s_networking_v1.Gateway),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8sgateway", vals[0]))
+	assert.Equal("spec/parentRefs[0]/name/gatewayapi", vals[0].Path)
+}
+
+func TestTCPRouteMissingListener(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gw := data.AddListenerToK8sGateway(data.CreateListener("tcp", "", 9000, "TCP"), data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+	rt := data.CreateTCPRoute("route", "bookinfo", "gatewayapi")
+	sectionName := k8s_networking_v1.SectionName("mysql")
+	rt.Spec.ParentRefs[0].SectionName = &sectionName
+
+	vals, valid := NoK8sGatewayChecker{
+		IdentityDomain: "svc.cluster.local",
+		K8sTCPRoute:    rt,
+		GatewayNames:   kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local"),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8slistener", vals[0]))
+	assert.Equal("spec/parentRefs[0]/sectionName", vals[0].Path)
+}
+
+func TestTCPRouteListenerSetParent(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	ls := data.AddListenerToListenerSet(data.CreateListener("tcp", "", 9000, "TCP"), data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "bookinfo"))
+	rt := data.CreateTCPRoute("route", "bookinfo", "listeners")
+	kind := k8s_networking_v1.Kind(kubernetes.K8sListenerSetType)
+	rt.Spec.ParentRefs[0].Kind = &kind
+
+	checker := NoK8sGatewayChecker{
+		IdentityDomain:  "svc.cluster.local",
+		K8sListenerSets: []*k8s_networking_v1.ListenerSet{ls},
+		K8sTCPRoute:     rt,
+		GatewayNames:    make(map[string]k8s_networking_v1.Gateway),
+	}
+	vals, valid := checker.Check()
+	assert.True(valid)
+	assert.Empty(vals)
+
+	checker.K8sListenerSets = nil
+	vals, valid = checker.Check()
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nok8slistener", vals[0]))
+	assert.Equal("spec/parentRefs[0]/name/listeners", vals[0].Path)
+}
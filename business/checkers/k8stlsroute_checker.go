@@ -0,0 +1,69 @@
+package checkers
+
+import (
+	core_v1 "k8s.io/api/core/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/business/checkers/k8stlsroutes"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sTLSRouteChecker struct {
+	Cluster            string
+	IdentityDomain     string
+	K8sGateways        []*k8s_networking_v1.Gateway
+	K8sListenerSets    []*k8s_networking_v1.ListenerSet
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
+	K8sTLSRoutes       []*k8s_networking_v1.TLSRoute
+	Namespaces         models.Namespaces
+	Services           []core_v1.Service
+}
+
+// Check runs checks for the all namespaces actions as well as for the single namespace validations
+func (in K8sTLSRouteChecker) Check() models.IstioValidations {
+	validations := models.IstioValidations{}
+
+	gatewayNames := kubernetes.K8sGatewayNames(in.K8sGateways, in.IdentityDomain)
+
+	for _, rt := range in.K8sTLSRoutes {
+		validations.MergeValidations(in.runChecks(rt, gatewayNames))
+	}
+
+	return validations
+}
+
+func (in K8sTLSRouteChecker) runChecks(rt *k8s_networking_v1.TLSRoute, gatewayNames map[string]k8s_networking_v1.Gateway) models.IstioValidations {
+	key, validations := EmptyValidValidation(rt.Name, rt.Namespace, kubernetes.K8sTLSRoutes, in.Cluster)
+
+	enabledCheckers := []Checker{
+		k8stlsroutes.NoK8sGatewayChecker{
+			Cluster:         in.Cluster,
+			GatewayNames:    gatewayNames,
+			IdentityDomain:  in.IdentityDomain,
+			K8sListenerSets: in.K8sListenerSets,
+			K8sTLSRoute:     rt,
+			Namespaces:      in.Namespaces,
+		},
+		k8stlsroutes.NoHostChecker{
+			K8sReferenceGrants: in.K8sReferenceGrants,
+			K8sTLSRoute:        rt,
+			Services:           in.Services,
+		},
+		k8stlsroutes.HostnameChecker{
+			GatewayNames:    gatewayNames,
+			IdentityDomain:  in.IdentityDomain,
+			K8sListenerSets: in.K8sListenerSets,
+			K8sTLSRoute:     rt,
+		},
+	}
+
+	for _, checker := range enabledCheckers {
+		checks, validChecker := checker.Check()
+		validations.Checks = append(validations.Checks, checks...)
+		validations.Valid = validations.Valid && validChecker
+	}
+
+	return models.IstioValidations{key: validations}
+}
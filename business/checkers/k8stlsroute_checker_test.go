@@ -0,0 +1,42 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func TestK8sTLSRouteChecker(t *testing.T) {
+	conf := config.NewConfig()
+	config.Set(conf)
+	assert := assert.New(t)
+
+	gw := data.AddListenerToK8sGateway(data.CreateListener("tls", "*.example.com", 443, "TLS"), data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+
+	vals := K8sTLSRouteChecker{
+		IdentityDomain: "svc.cluster.local",
+		K8sGateways:    []*k8s_networking_v1.Gateway{gw},
+		K8sTLSRoutes: []*k8s_networking_v1.TLSRoute{
+			data.AddBackendRefToTLSRoute("reviews", "", data.CreateTLSRoute("valid", "bookinfo", "gatewayapi", []string{"reviews.example.com"})),
+			data.AddBackendRefToTLSRoute("ratings", "", data.CreateTLSRoute("invalid", "bookinfo", "gatewayapi", []string{"reviews.example.org"})),
+		},
+		Services: data.CreateFakeMultiServices([]string{"reviews.bookinfo.svc.cluster.local"}, "bookinfo"),
+	}.Check()
+
+	valid := vals[models.IstioValidationKey{ObjectGVK: kubernetes.K8sTLSRoutes, Namespace: "bookinfo", Name: "valid"}]
+	assert.True(valid.Valid)
+	assert.Empty(valid.Checks)
+
+	invalid := vals[models.IstioValidationKey{ObjectGVK: kubernetes.K8sTLSRoutes, Namespace: "bookinfo", Name: "invalid"}]
+	assert.False(invalid.Valid)
+	assert.Len(invalid.Checks, 2)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.nohost.namenotfound", invalid.Checks[0]))
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.hostname.nointersection", invalid.Checks[1]))
+}
@@ -0,0 +1,53 @@
+package k8stlsroutes
+
+import (
+	"fmt"
+
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type HostnameChecker struct {
+	GatewayNames    map[string]k8s_networking_v1.Gateway
+	IdentityDomain  string
+	K8sListenerSets []*k8s_networking_v1.ListenerSet
+	K8sTLSRoute     *k8s_networking_v1.TLSRoute
+}
+
+// Check validates that the TLSRoute hostnames intersect with the hostname of at least one listener of every parent.
+// Parents that can't be resolved are reported by the NoK8sGatewayChecker.
+func (h HostnameChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+
+	if len(h.K8sTLSRoute.Spec.Hostnames) == 0 {
+		return validations, true
+	}
+
+	for index, parentRef := range h.K8sTLSRoute.Spec.ParentRefs {
+		listeners, found := kubernetes.K8sParentRefListeners(parentRef, h.K8sTLSRoute.Namespace, h.GatewayNames, h.K8sListenerSets, h.IdentityDomain)
+		if !found || len(listeners) == 0 || h.intersects(listeners) {
+			continue
+		}
+		validation := models.Build("k8sroutes.hostname.nointersection", fmt.Sprintf("spec/parentRefs[%d]/name/%s", index, string(parentRef.Name)))
+		validations = append(validations, &validation)
+	}
+
+	return validations, len(validations) == 0
+}
+
+func (h HostnameChecker) intersects(listeners []k8s_networking_v1.Listener) bool {
+	for _, l := range listeners {
+		listenerHostname := ""
+		if l.Hostname != nil {
+			listenerHostname = string(*l.Hostname)
+		}
+		for _, hostname := range h.K8sTLSRoute.Spec.Hostnames {
+			if kubernetes.K8sHostnamesIntersect(listenerHostname, string(hostname)) {
+				return true
+			}
+		}
+	}
+	return false
+}
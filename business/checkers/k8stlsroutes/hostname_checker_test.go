@@ -0,0 +1,68 @@
+package k8stlsroutes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tests/testutils/validations"
+)
+
+func tlsGatewayNames() map[string]k8s_networking_v1.Gateway {
+	gw := data.AddListenerToK8sGateway(data.CreateListener("tls", "*.example.com", 443, "TLS"), data.CreateEmptyK8sGateway("gatewayapi", "bookinfo"))
+	return kubernetes.K8sGatewayNames([]*k8s_networking_v1.Gateway{gw}, "svc.cluster.local")
+}
+
+func TestTLSRouteHostnameIntersects(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := HostnameChecker{
+		GatewayNames:   tlsGatewayNames(),
+		IdentityDomain: "svc.cluster.local",
+		K8sTLSRoute:    data.CreateTLSRoute("route", "bookinfo", "gatewayapi", []string{"bookinfo.example.com"}),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
+
+func TestTLSRouteHostnameNoIntersection(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	vals, valid := HostnameChecker{
+		GatewayNames:   tlsGatewayNames(),
+		IdentityDomain: "svc.cluster.local",
+		K8sTLSRoute:    data.CreateTLSRoute("route", "bookinfo", "gatewayapi", []string{"bookinfo.example.org"}),
+	}.Check()
+
+	assert.False(valid)
+	assert.Len(vals, 1)
+	assert.Equal(models.ErrorSeverity, vals[0].Severity)
+	assert.NoError(validations.ConfirmIstioCheckMessage("k8sroutes.hostname.nointersection", vals[0]))
+	assert.Equal("spec/parentRefs[0]/name/gatewayapi", vals[0].Path)
+}
+
+func TestTLSRouteHostnameUnresolvedParent(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	// Unresolved parents are reported by the NoK8sGatewayChecker
+	vals, valid := HostnameChecker{
+		GatewayNames:   tlsGatewayNames(),
+		IdentityDomain: "svc.cluster.local",
+		K8sTLSRoute:    data.CreateTLSRoute("route", "bookinfo", "other", []string{"bookinfo.example.org"}),
+	}.Check()
+
+	assert.True(valid)
+	assert.Empty(vals)
+}
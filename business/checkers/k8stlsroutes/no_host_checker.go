@@ -0,0 +1,61 @@
+package k8stlsroutes
+
+import (
+	"fmt"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type NoHostChecker struct {
+	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant
+	K8sTLSRoute        *k8s_networking_v1.TLSRoute
+	Services           []core_v1.Service
+}
+
+// Check validates that the TLSRoute backendRefs point to existing Services, allowed by a ReferenceGrant when
+// they live in another namespace
+func (n NoHostChecker) Check() ([]*models.IstioCheck, bool) {
+	validations := make([]*models.IstioCheck, 0)
+	valid := true
+
+	for k, rule := range n.K8sTLSRoute.Spec.Rules {
+		for i, ref := range rule.BackendRefs {
+			if ref.Kind != nil && string(*ref.Kind) != kubernetes.ServiceType {
+				continue
+			}
+			valid = n.checkReference(ref.Namespace, ref.Name, &validations, fmt.Sprintf("spec/rules[%d]/backendRefs[%d]/name", k, i)) && valid
+		}
+	}
+
+	return validations, valid
+}
+
+func (n NoHostChecker) checkReference(refNamespace *k8s_networking_v1.Namespace, refName k8s_networking_v1.ObjectName, validations *[]*models.IstioCheck, location string) bool {
+	namespace := n.K8sTLSRoute.Namespace
+	if refNamespace != nil && string(*refNamespace) != "" {
+		namespace = string(*refNamespace)
+	}
+	// BackendRefs use simple names; dotted names are invalid for kind: Service
+	if strings.Contains(string(refName), ".") || !n.checkDestination(string(refName), namespace) ||
+		(namespace != n.K8sTLSRoute.Namespace && !kubernetes.HasMatchingReferenceGrant(n.K8sTLSRoute.Namespace, namespace, kubernetes.K8sTLSRouteType, kubernetes.ServiceType, n.K8sReferenceGrants)) {
+		validation := models.Build("k8sroutes.nohost.namenotfound", location)
+		*validations = append(*validations, &validation)
+		return false
+	}
+	return true
+}
+
+func (n NoHostChecker) checkDestination(svcName string, namespace string) bool {
+	for _, svc := range n.Services {
+		if svc.Name == svcName && svc.Namespace == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,1210 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"google.golang.org/protobuf/types/known/durationpb"
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	k8s_ingress_v1 "k8s.io/api/networking/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// defaultGatewayAPIClassName is the class of the converted gateways when no Gateway API class is configured.
+const defaultGatewayAPIClassName = "istio"
+
+var invalidSectionNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// gatewayAPIConvertedObject is a Gateway API object resulting from a conversion, with the objects converted into it.
+type gatewayAPIConvertedObject struct {
+	gvk     schema.GroupVersionKind
+	object  client.Object
+	sources []string
+}
+
+// gatewayAPIConverter translates Ingresses, Istio Gateways and VirtualServices of a namespace to the Gateway API,
+// collecting the constructs that cannot be translated.
+type gatewayAPIConverter struct {
+	ctx            context.Context
+	className      string
+	identityDomain string
+	namespaces     []string
+	kubeCache      client.Reader
+	// gateways are the Istio Gateways of the conversion, by namespace/name
+	gateways map[string]bool
+	objects  []gatewayAPIConvertedObject
+	names    map[string]bool
+	grants   map[string]*k8s_networking_v1beta1.ReferenceGrant
+	issues   []models.GatewayAPIConversionIssue
+}
+
+// ConvertToGatewayAPI converts the Ingresses, Istio Gateways and VirtualServices of a namespace to equivalent Gateway
+// API Gateways, HTTPRoutes, GRPCRoutes and ReferenceGrants. The Istio objects are read from the cache, the Ingresses,
+// that Kiali does not cache, from the cluster. Constructs that have no Gateway API equivalent are reported as issues.
+// The resulting objects are validated as if they were applied, but the cluster is not modified.
+func (in *IstioConfigService) ConvertToGatewayAPI(ctx context.Context, cluster, namespace string, request models.GatewayAPIConversionRequest) (*models.GatewayAPIConversion, error) {
+	userClient, ok := in.userClients[cluster]
+	if !ok || !userClient.IsGatewayAPI() {
+		return nil, api_errors.NewBadRequest("the Gateway API is not installed in cluster " + cluster)
+	}
+
+	converter := &gatewayAPIConverter{
+		ctx:            ctx,
+		className:      request.GatewayClassName,
+		identityDomain: in.businessLayer.Svc.ResolveIdentityDomain(ctx, cluster),
+		gateways:       map[string]bool{},
+		names:          map[string]bool{},
+		grants:         map[string]*k8s_networking_v1beta1.ReferenceGrant{},
+	}
+	if converter.className == "" {
+		converter.className = defaultGatewayAPIClassName
+		if classes := in.kialiCache.GatewayAPIClasses(cluster); len(classes) > 0 {
+			converter.className = classes[0].ClassName
+		}
+	}
+	var err error
+	if converter.kubeCache, err = in.kialiCache.GetKubeCache(cluster); err != nil {
+		return nil, err
+	}
+	namespaces, err := in.businessLayer.Namespace.GetClusterNamespaces(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range namespaces {
+		converter.namespaces = append(converter.namespaces, ns.Name)
+	}
+
+	all := len(request.Ingresses) == 0 && len(request.Gateways) == 0 && len(request.VirtualServices) == 0
+	list, err := in.GetIstioConfigListForNamespace(ctx, cluster, namespace, IstioConfigCriteria{IncludeGateways: true, IncludeVirtualServices: true})
+	if err != nil {
+		return nil, err
+	}
+	gateways, err := selectConversionObjects(list.Gateways, request.Gateways, all, kubernetes.Gateways)
+	if err != nil {
+		return nil, err
+	}
+	virtualServices, err := selectConversionObjects(list.VirtualServices, request.VirtualServices, all, kubernetes.VirtualServices)
+	if err != nil {
+		return nil, err
+	}
+	var ingresses []*k8s_ingress_v1.Ingress
+	if all || len(request.Ingresses) > 0 {
+		ingressList, err := userClient.Kube().NetworkingV1().Ingresses(namespace).List(ctx, meta_v1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items := make([]*k8s_ingress_v1.Ingress, 0, len(ingressList.Items))
+		for i := range ingressList.Items {
+			items = append(items, &ingressList.Items[i])
+		}
+		ingressGVK := k8s_ingress_v1.SchemeGroupVersion.WithKind("Ingress")
+		if ingresses, err = selectConversionObjects(items, request.Ingresses, all, ingressGVK); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, gw := range gateways {
+		converter.gateways[gw.Namespace+"/"+gw.Name] = true
+	}
+	for _, gw := range gateways {
+		converter.convertGateway(gw)
+	}
+	for _, vs := range virtualServices {
+		converter.convertVirtualService(vs)
+	}
+	for _, ingress := range ingresses {
+		converter.convertIngress(ingress)
+	}
+	converter.addReferenceGrants()
+
+	result := &models.GatewayAPIConversion{
+		Cluster:   cluster,
+		Namespace: namespace,
+		Objects:   make([]models.GatewayAPIConversionObject, 0, len(converter.objects)),
+		Issues:    converter.issues,
+	}
+	if result.Issues == nil {
+		result.Issues = []models.GatewayAPIConversionIssue{}
+	}
+	proposed := &models.IstioConfigList{}
+	for _, obj := range converter.objects {
+		converted, err := in.conversionObject(ctx, cluster, obj)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, *converted)
+		proposed.Add(obj.object)
+	}
+
+	result.Valid = true
+	if in.conf.IsValidationsEnabled() {
+		if result.Validations, err = in.businessLayer.Validations.ValidateProposedIstioObjects(ctx, cluster, namespace, proposed); err != nil {
+			return nil, err
+		}
+		for _, validation := range result.Validations {
+			result.Valid = result.Valid && validation.Valid
+		}
+	}
+
+	return result, nil
+}
+
+// ApplyGatewayAPIConversion writes the objects resulting from ConvertToGatewayAPI to the cluster, in order, creating or
+// updating each of them. The converted objects are not deleted. It stops at the first failure and returns the objects
+// written so far, so they can be audited.
+func (in *IstioConfigService) ApplyGatewayAPIConversion(ctx context.Context, result *models.GatewayAPIConversion) ([]models.IstioConfigDetails, error) {
+	written := make([]models.IstioConfigDetails, 0, len(result.Objects))
+	for _, obj := range result.Objects {
+		var details models.IstioConfigDetails
+		var err error
+		if obj.Operation == models.GitOpsOperationUpdate {
+			details, err = in.UpdateIstioConfigDetail(ctx, result.Cluster, obj.Namespace, obj.ObjectGVK, obj.Name, obj.Patch)
+		} else {
+			details, err = in.CreateIstioConfigDetail(ctx, result.Cluster, obj.Namespace, obj.ObjectGVK, obj.Manifest)
+		}
+		if err != nil {
+			return written, fmt.Errorf("unable to apply %s [%s/%s]: %w", obj.ObjectGVK.Kind, obj.Namespace, obj.Name, err)
+		}
+		written = append(written, details)
+	}
+	result.Applied = true
+	return written, nil
+}
+
+// conversionObject returns the manifest of a converted object, with the patch updating the existing object if any.
+func (in *IstioConfigService) conversionObject(ctx context.Context, cluster string, obj gatewayAPIConvertedObject) (*models.GatewayAPIConversionObject, error) {
+	namespace := obj.object.GetNamespace()
+	converted := &models.GatewayAPIConversionObject{
+		ObjectGVK: obj.gvk,
+		Namespace: namespace,
+		Name:      obj.object.GetName(),
+		Operation: models.GitOpsOperationCreate,
+		Sources:   obj.sources,
+	}
+
+	current, err := in.GetIstioConfigDetails(ctx, cluster, namespace, obj.gvk, obj.object.GetName())
+	if err != nil && !api_errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && current.Object != nil {
+		converted.Operation = models.GitOpsOperationUpdate
+		obj.object.SetLabels(mergeLabels(current.Object.GetLabels(), obj.object.GetLabels()))
+		obj.object.SetAnnotations(current.Object.GetAnnotations())
+	}
+
+	if converted.Manifest, err = models.RevisionObject(obj.gvk, obj.object); err != nil {
+		return nil, err
+	}
+	if converted.Operation == models.GitOpsOperationUpdate {
+		currentManifest, err := models.RevisionObject(obj.gvk, current.Object)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := jsonpatch.CreateMergePatch(currentManifest, converted.Manifest)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute the patch of %s [%s/%s]: %w", obj.gvk.Kind, namespace, obj.object.GetName(), err)
+		}
+		converted.Patch = string(patch)
+	}
+	return converted, nil
+}
+
+// selectConversionObjects returns the objects to convert sorted by name: all of them, or the named ones.
+func selectConversionObjects[T client.Object](objects []T, names []string, all bool, gvk schema.GroupVersionKind) ([]T, error) {
+	selected := []T{}
+	for _, obj := range objects {
+		if all || slices.Contains(names, obj.GetName()) {
+			selected = append(selected, obj)
+		}
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(selected, func(obj T) bool { return obj.GetName() == name }) {
+			return nil, api_errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind) + "s"}, name)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].GetName() < selected[j].GetName() })
+	return selected, nil
+}
+
+func (c *gatewayAPIConverter) issue(source, path, format string, args ...any) {
+	c.issues = append(c.issues, models.GatewayAPIConversionIssue{Source: source, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// add adds a converted object, renamed with the given suffix when an object of the same kind has its name already.
+func (c *gatewayAPIConverter) add(gvk schema.GroupVersionKind, obj client.Object, source, suffix string) {
+	key := gvk.Kind + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	if c.names[key] {
+		c.issue(source, "", "renamed the %s to %s, another object is converted to a %s with the same name", gvk.Kind, obj.GetName()+suffix, gvk.Kind)
+		obj.SetName(obj.GetName() + suffix)
+		key += suffix
+	}
+	c.names[key] = true
+	c.objects = append(c.objects, gatewayAPIConvertedObject{gvk: gvk, object: obj, sources: []string{source}})
+}
+
+func (c *gatewayAPIConverter) convertGateway(gw *networking_v1.Gateway) {
+	source := kubernetes.Gateways.Kind + "/" + gw.Name
+	k8sGateway := &k8s_networking_v1.Gateway{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sGateways.GroupVersion().String(), Kind: kubernetes.K8sGateways.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: gw.Name, Namespace: gw.Namespace},
+	}
+	k8sGateway.Spec.GatewayClassName = k8s_networking_v1.ObjectName(c.className)
+	if len(gw.Spec.Selector) > 0 {
+		c.issue(source, "spec/selector", "the gateway is deployed by the %s class, the selected gateway workloads are not used", c.className)
+	}
+
+	listenerNames := map[string]bool{}
+	for i, server := range gw.Spec.Servers {
+		path := fmt.Sprintf("spec/servers[%d]", i)
+		if server.Port == nil {
+			continue
+		}
+		if server.Bind != "" {
+			c.issue(source, path+"/bind", "binding a server to an address is not supported")
+		}
+		if server.DefaultEndpoint != "" {
+			c.issue(source, path+"/defaultEndpoint", "default endpoints are not supported")
+		}
+
+		protocol, tls, ok := c.listenerProtocol(source, path, server)
+		if !ok {
+			continue
+		}
+		for j, host := range server.Hosts {
+			hostNamespace, hostname := ".", host
+			if parts := strings.SplitN(host, "/", 2); len(parts) == 2 {
+				hostNamespace, hostname = parts[0], parts[1]
+			} else {
+				hostNamespace = "*"
+			}
+
+			name := listenerName(server, j, len(server.Hosts))
+			for listenerNames[name] {
+				name += "-" + fmt.Sprint(i)
+			}
+			listenerNames[name] = true
+			listener := k8s_networking_v1.Listener{
+				Name:          k8s_networking_v1.SectionName(name),
+				Port:          k8s_networking_v1.PortNumber(server.Port.Number),
+				Protocol:      protocol,
+				TLS:           tls,
+				AllowedRoutes: allowedRoutes(hostNamespace, gw.Namespace),
+			}
+			if hostname != "*" {
+				h := k8s_networking_v1.Hostname(hostname)
+				listener.Hostname = &h
+			}
+			k8sGateway.Spec.Listeners = append(k8sGateway.Spec.Listeners, listener)
+		}
+	}
+	c.add(kubernetes.K8sGateways, k8sGateway, source, "-gateway")
+}
+
+// listenerProtocol returns the protocol and TLS settings of the listeners of an Istio Gateway server, false when the
+// server cannot be translated.
+func (c *gatewayAPIConverter) listenerProtocol(source, path string, server *api_networking_v1.Server) (k8s_networking_v1.ProtocolType, *k8s_networking_v1.ListenerTLSConfig, bool) {
+	protocol := strings.ToUpper(server.Port.Protocol)
+	switch protocol {
+	case "HTTP", "HTTP2", "GRPC", "GRPC-WEB":
+		if server.Tls != nil && server.Tls.HttpsRedirect {
+			c.issue(source, path+"/tls/httpsRedirect", "add a RequestRedirect filter with the https scheme to the routes of the HTTP listeners")
+		}
+		return k8s_networking_v1.HTTPProtocolType, nil, true
+	case "TCP", "MONGO", "REDIS", "MYSQL":
+		return k8s_networking_v1.TCPProtocolType, nil, true
+	case "HTTPS", "TLS":
+	default:
+		c.issue(source, path+"/port/protocol", "the %s protocol is not supported", server.Port.Protocol)
+		return "", nil, false
+	}
+
+	if server.Tls == nil {
+		c.issue(source, path+"/tls", "the %s server has no TLS settings", protocol)
+		return "", nil, false
+	}
+	switch server.Tls.Mode {
+	case api_networking_v1.ServerTLSSettings_PASSTHROUGH:
+		mode := k8s_networking_v1.TLSModePassthrough
+		c.issue(source, path+"/tls/mode", "passthrough listeners require TLSRoutes, which are not converted")
+		return k8s_networking_v1.TLSProtocolType, &k8s_networking_v1.ListenerTLSConfig{Mode: &mode}, true
+	case api_networking_v1.ServerTLSSettings_SIMPLE, api_networking_v1.ServerTLSSettings_MUTUAL, api_networking_v1.ServerTLSSettings_OPTIONAL_MUTUAL:
+		if server.Tls.Mode != api_networking_v1.ServerTLSSettings_SIMPLE {
+			c.issue(source, path+"/tls/mode", "the validation of client certificates is not translated")
+		}
+		credentials := server.Tls.CredentialNames
+		if server.Tls.CredentialName != "" {
+			credentials = append([]string{server.Tls.CredentialName}, credentials...)
+		}
+		if len(credentials) == 0 {
+			c.issue(source, path+"/tls", "certificates mounted in the gateway are not supported, use a credential name")
+		}
+		mode := k8s_networking_v1.TLSModeTerminate
+		tls := &k8s_networking_v1.ListenerTLSConfig{Mode: &mode}
+		for _, credential := range credentials {
+			tls.CertificateRefs = append(tls.CertificateRefs, k8s_networking_v1.SecretObjectReference{Name: k8s_networking_v1.ObjectName(credential)})
+		}
+		if protocol == "HTTPS" {
+			return k8s_networking_v1.HTTPSProtocolType, tls, true
+		}
+		return k8s_networking_v1.TLSProtocolType, tls, true
+	default:
+		c.issue(source, path+"/tls/mode", "the %s TLS mode is not supported", server.Tls.Mode.String())
+		return "", nil, false
+	}
+}
+
+// listenerName returns the name of the listener for a host of an Istio Gateway server.
+func listenerName(server *api_networking_v1.Server, host, hosts int) string {
+	name := invalidSectionNameChars.ReplaceAllString(strings.ToLower(server.Port.Name), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		name = fmt.Sprintf("%s-%d", strings.ToLower(server.Port.Protocol), server.Port.Number)
+	}
+	if hosts > 1 {
+		name = fmt.Sprintf("%s-%d", name, host)
+	}
+	return name
+}
+
+// allowedRoutes returns the namespaces allowed to attach routes to a listener, from the namespace part of an Istio
+// Gateway host: any namespace when the host has no namespace part.
+func allowedRoutes(hostNamespace, gatewayNamespace string) *k8s_networking_v1.AllowedRoutes {
+	from := k8s_networking_v1.NamespacesFromAll
+	var selector *meta_v1.LabelSelector
+	switch hostNamespace {
+	case "*":
+	case ".", gatewayNamespace:
+		from = k8s_networking_v1.NamespacesFromSame
+	default:
+		from = k8s_networking_v1.NamespacesFromSelector
+		selector = &meta_v1.LabelSelector{MatchLabels: map[string]string{core_v1.LabelMetadataName: hostNamespace}}
+	}
+	return &k8s_networking_v1.AllowedRoutes{Namespaces: &k8s_networking_v1.RouteNamespaces{From: &from, Selector: selector}}
+}
+
+// backend returns the backend reference of an Istio destination: a service of the cluster, or an Istio Hostname.
+// Backends in another namespace are granted access to with a ReferenceGrant.
+func (c *gatewayAPIConverter) backend(source, path, routeKind, routeNamespace string, destination *api_networking_v1.Destination) k8s_networking_v1.BackendObjectReference {
+	if destination.Subset != "" {
+		c.issue(source, path+"/subset", "subsets are not supported, route to a service per version instead")
+	}
+	var portNumber uint32
+	if destination.Port != nil {
+		portNumber = destination.Port.Number
+	}
+
+	svc, found := c.service(destination.Host, routeNamespace)
+	if !found {
+		c.issue(source, path+"/host", "%s is not a service of the cluster, it is routed to with the Hostname backend specific to Istio", destination.Host)
+		group := k8s_networking_v1.Group(kubernetes.NetworkingGroupVersionV1.Group)
+		kind := k8s_networking_v1.Kind("Hostname")
+		ref := k8s_networking_v1.BackendObjectReference{Group: &group, Kind: &kind, Name: k8s_networking_v1.ObjectName(destination.Host)}
+		if portNumber != 0 {
+			port := k8s_networking_v1.PortNumber(portNumber)
+			ref.Port = &port
+		}
+		return ref
+	}
+
+	group := k8s_networking_v1.Group("")
+	kind := k8s_networking_v1.Kind(kubernetes.ServiceType)
+	ref := k8s_networking_v1.BackendObjectReference{Group: &group, Kind: &kind, Name: k8s_networking_v1.ObjectName(svc.Name)}
+	if svc.Namespace != routeNamespace {
+		ns := k8s_networking_v1.Namespace(svc.Namespace)
+		ref.Namespace = &ns
+		c.grant(routeKind, routeNamespace, svc.Namespace, svc.Name)
+	}
+
+	servicePort := destinationPort(svc, portNumber)
+	switch {
+	case servicePort == nil && portNumber != 0:
+		c.issue(source, path+"/port", "service %s has no port %d", svc.Name, portNumber)
+	case servicePort == nil:
+		c.issue(source, path+"/port", "service %s has no port", svc.Name)
+	default:
+		if portNumber == 0 && len(svc.Spec.Ports) > 1 {
+			c.issue(source, path+"/port", "service %s has several ports, the destination is set to port %d", svc.Name, servicePort.Port)
+		}
+		portNumber = uint32(servicePort.Port)
+	}
+	if portNumber != 0 {
+		port := k8s_networking_v1.PortNumber(portNumber)
+		ref.Port = &port
+	}
+	return ref
+}
+
+// service returns the service of the cluster an Istio host refers to, false when it is not a service of the cluster.
+func (c *gatewayAPIConverter) service(hostName, namespace string) (*core_v1.Service, bool) {
+	host := kubernetes.GetHost(hostName, namespace, c.namespaces, c.identityDomain)
+	if !host.CompleteInput {
+		return nil, false
+	}
+	svc := &core_v1.Service{}
+	if err := c.kubeCache.Get(c.ctx, client.ObjectKey{Namespace: host.Namespace, Name: host.Service}, svc); err != nil {
+		return nil, false
+	}
+	return svc, true
+}
+
+// destinationPort returns the port of a service a destination routes to: the given port, or the first one.
+func destinationPort(svc *core_v1.Service, portNumber uint32) *core_v1.ServicePort {
+	for i := range svc.Spec.Ports {
+		if portNumber == 0 || uint32(svc.Spec.Ports[i].Port) == portNumber {
+			return &svc.Spec.Ports[i]
+		}
+	}
+	return nil
+}
+
+func isGRPCPort(port core_v1.ServicePort) bool {
+	if port.AppProtocol != nil {
+		return strings.EqualFold(*port.AppProtocol, "grpc")
+	}
+	name := strings.ToLower(port.Name)
+	return name == "grpc" || strings.HasPrefix(name, "grpc-")
+}
+
+// grant allows the routes of a namespace to reference a service of another namespace.
+func (c *gatewayAPIConverter) grant(routeKind, routeNamespace, serviceNamespace, service string) {
+	grant, found := c.grants[serviceNamespace]
+	if !found {
+		grant = &k8s_networking_v1beta1.ReferenceGrant{
+			TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sReferenceGrants.GroupVersion().String(), Kind: kubernetes.K8sReferenceGrants.Kind},
+			ObjectMeta: meta_v1.ObjectMeta{Name: "allow-" + routeNamespace + "-routes", Namespace: serviceNamespace},
+		}
+		c.grants[serviceNamespace] = grant
+	}
+	from := k8s_networking_v1beta1.ReferenceGrantFrom{
+		Group:     k8s_networking_v1.GroupName,
+		Kind:      k8s_networking_v1.Kind(routeKind),
+		Namespace: k8s_networking_v1.Namespace(routeNamespace),
+	}
+	if !slices.Contains(grant.Spec.From, from) {
+		grant.Spec.From = append(grant.Spec.From, from)
+	}
+	name := k8s_networking_v1.ObjectName(service)
+	if !slices.ContainsFunc(grant.Spec.To, func(to k8s_networking_v1beta1.ReferenceGrantTo) bool { return *to.Name == name }) {
+		grant.Spec.To = append(grant.Spec.To, k8s_networking_v1beta1.ReferenceGrantTo{Group: "", Kind: kubernetes.ServiceType, Name: &name})
+	}
+}
+
+// addReferenceGrants adds the ReferenceGrants of the conversion, sorted by namespace.
+func (c *gatewayAPIConverter) addReferenceGrants() {
+	namespaces := make([]string, 0, len(c.grants))
+	for ns := range c.grants {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	for _, ns := range namespaces {
+		grant := c.grants[ns]
+		sort.Slice(grant.Spec.From, func(i, j int) bool { return grant.Spec.From[i].Kind < grant.Spec.From[j].Kind })
+		sort.Slice(grant.Spec.To, func(i, j int) bool { return *grant.Spec.To[i].Name < *grant.Spec.To[j].Name })
+
+		sources := []string{}
+		for _, obj := range c.objects {
+			for _, ref := range routeBackendNamespaces(obj.object) {
+				if ref == ns {
+					sources = append(sources, obj.sources...)
+					break
+				}
+			}
+		}
+		c.objects = append(c.objects, gatewayAPIConvertedObject{gvk: kubernetes.K8sReferenceGrants, object: grant, sources: slices.Compact(sources)})
+	}
+}
+
+// routeBackendNamespaces returns the namespaces of the backends of a converted route in another namespace.
+func routeBackendNamespaces(obj client.Object) []string {
+	namespaces := []string{}
+	addRef := func(ref k8s_networking_v1.BackendObjectReference) {
+		if ref.Namespace != nil {
+			namespaces = append(namespaces, string(*ref.Namespace))
+		}
+	}
+	switch route := obj.(type) {
+	case *k8s_networking_v1.HTTPRoute:
+		for _, rule := range route.Spec.Rules {
+			for _, backend := range rule.BackendRefs {
+				addRef(backend.BackendObjectReference)
+			}
+			for _, filter := range rule.Filters {
+				if filter.RequestMirror != nil {
+					addRef(filter.RequestMirror.BackendRef)
+				}
+			}
+		}
+	case *k8s_networking_v1.GRPCRoute:
+		for _, rule := range route.Spec.Rules {
+			for _, backend := range rule.BackendRefs {
+				addRef(backend.BackendObjectReference)
+			}
+			for _, filter := range rule.Filters {
+				if filter.RequestMirror != nil {
+					addRef(filter.RequestMirror.BackendRef)
+				}
+			}
+		}
+	}
+	return namespaces
+}
+
+// routeParents returns the parent references of the routes converted from a VirtualService: the Gateways converted
+// from its Istio gateways, and the services of its hosts for the mesh gateway (GAMMA).
+func (c *gatewayAPIConverter) routeParents(source string, vs *networking_v1.VirtualService) ([]k8s_networking_v1.ParentReference, bool) {
+	gateways := vs.Spec.Gateways
+	if len(gateways) == 0 {
+		gateways = []string{"mesh"}
+	}
+
+	parents := []k8s_networking_v1.ParentReference{}
+	hasGateway := false
+	for i, gateway := range gateways {
+		if gateway == "mesh" {
+			serviceGroup := k8s_networking_v1.Group("")
+			serviceKind := k8s_networking_v1.Kind(kubernetes.ServiceType)
+			for j, hostName := range vs.Spec.Hosts {
+				host := kubernetes.GetHost(hostName, vs.Namespace, c.namespaces, c.identityDomain)
+				if !host.CompleteInput || c.kubeCache.Get(c.ctx, client.ObjectKey{Namespace: host.Namespace, Name: host.Service}, &core_v1.Service{}) != nil {
+					c.issue(source, fmt.Sprintf("spec/hosts[%d]", j), "%s is not a service of the cluster, the mesh routes for it are not converted", hostName)
+					continue
+				}
+				parent := k8s_networking_v1.ParentReference{Group: &serviceGroup, Kind: &serviceKind, Name: k8s_networking_v1.ObjectName(host.Service)}
+				if host.Namespace != vs.Namespace {
+					ns := k8s_networking_v1.Namespace(host.Namespace)
+					parent.Namespace = &ns
+				}
+				parents = append(parents, parent)
+			}
+			continue
+		}
+
+		gw := kubernetes.ParseGatewayAsHost(gateway, vs.Namespace, c.identityDomain)
+		if !c.gateways[gw.Namespace+"/"+gw.Service] {
+			c.issue(source, fmt.Sprintf("spec/gateways[%d]", i), "gateway %s/%s is not part of the conversion, it must be converted as well", gw.Namespace, gw.Service)
+		}
+		parent := gatewayParentRef(gw.Service)
+		if gw.Namespace != vs.Namespace {
+			ns := k8s_networking_v1.Namespace(gw.Namespace)
+			parent.Namespace = &ns
+		}
+		parents = append(parents, parent)
+		hasGateway = true
+	}
+	return parents, hasGateway
+}
+
+// gatewayParentRef returns the reference to a Gateway of the namespace of the route, with the defaults set.
+func gatewayParentRef(name string) k8s_networking_v1.ParentReference {
+	group := k8s_networking_v1.Group(k8s_networking_v1.GroupName)
+	kind := k8s_networking_v1.Kind(kubernetes.K8sGateways.Kind)
+	return k8s_networking_v1.ParentReference{Group: &group, Kind: &kind, Name: k8s_networking_v1.ObjectName(name)}
+}
+
+func (c *gatewayAPIConverter) convertVirtualService(vs *networking_v1.VirtualService) {
+	source := kubernetes.VirtualServices.Kind + "/" + vs.Name
+	if len(vs.Spec.ExportTo) > 0 && !slices.Contains(vs.Spec.ExportTo, "*") {
+		c.issue(source, "spec/exportTo", "the visibility of routes cannot be restricted")
+	}
+	if len(vs.Spec.Tcp) > 0 {
+		c.issue(source, "spec/tcp", "TCP routes are not converted")
+	}
+	if len(vs.Spec.Tls) > 0 {
+		c.issue(source, "spec/tls", "TLS routes are not converted")
+	}
+	if len(vs.Spec.Http) == 0 {
+		return
+	}
+
+	parents, hasGateway := c.routeParents(source, vs)
+	if len(parents) == 0 {
+		return
+	}
+	var hostnames []k8s_networking_v1.Hostname
+	if hasGateway {
+		for _, host := range vs.Spec.Hosts {
+			if host != "*" {
+				hostnames = append(hostnames, k8s_networking_v1.Hostname(host))
+			}
+		}
+	}
+
+	if c.grpcRoutes(vs) {
+		route := &k8s_networking_v1.GRPCRoute{
+			TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sGRPCRoutes.GroupVersion().String(), Kind: kubernetes.K8sGRPCRoutes.Kind},
+			ObjectMeta: meta_v1.ObjectMeta{Name: vs.Name, Namespace: vs.Namespace},
+		}
+		route.Spec.ParentRefs = parents
+		route.Spec.Hostnames = hostnames
+		for i, httpRoute := range vs.Spec.Http {
+			if rule, ok := c.grpcRule(source, fmt.Sprintf("spec/http[%d]", i), vs.Namespace, httpRoute); ok {
+				route.Spec.Rules = append(route.Spec.Rules, rule)
+			}
+		}
+		c.add(kubernetes.K8sGRPCRoutes, route, source, "-grpc")
+		return
+	}
+
+	route := &k8s_networking_v1.HTTPRoute{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sHTTPRoutes.GroupVersion().String(), Kind: kubernetes.K8sHTTPRoutes.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: vs.Name, Namespace: vs.Namespace},
+	}
+	route.Spec.ParentRefs = parents
+	route.Spec.Hostnames = hostnames
+	for i, httpRoute := range vs.Spec.Http {
+		if rule, ok := c.httpRule(source, fmt.Sprintf("spec/http[%d]", i), vs.Namespace, httpRoute); ok {
+			route.Spec.Rules = append(route.Spec.Rules, rule)
+		}
+	}
+	c.add(kubernetes.K8sHTTPRoutes, route, source, "-http")
+}
+
+// grpcRoutes returns whether all the destinations of a VirtualService are gRPC ports of services.
+func (c *gatewayAPIConverter) grpcRoutes(vs *networking_v1.VirtualService) bool {
+	found := false
+	for _, httpRoute := range vs.Spec.Http {
+		for _, destination := range httpRoute.Route {
+			if destination.Destination == nil {
+				continue
+			}
+			svc, ok := c.service(destination.Destination.Host, vs.Namespace)
+			if !ok {
+				return false
+			}
+			var portNumber uint32
+			if destination.Destination.Port != nil {
+				portNumber = destination.Destination.Port.Number
+			}
+			if port := destinationPort(svc, portNumber); port == nil || !isGRPCPort(*port) {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+// unsupportedRouteFields reports the fields of an Istio HTTP route that have no Gateway API equivalent.
+// It returns false when the route cannot be converted at all.
+func (c *gatewayAPIConverter) unsupportedRouteFields(source, path string, httpRoute *api_networking_v1.HTTPRoute) bool {
+	if httpRoute.Retries != nil {
+		c.issue(source, path+"/retries", "retries are not supported")
+	}
+	if httpRoute.Fault != nil {
+		c.issue(source, path+"/fault", "fault injection is not supported")
+	}
+	if httpRoute.CorsPolicy != nil {
+		c.issue(source, path+"/corsPolicy", "CORS policies are not converted")
+	}
+	for i, destination := range httpRoute.Route {
+		if destination.Headers != nil {
+			c.issue(source, fmt.Sprintf("%s/route[%d]/headers", path, i), "header operations per destination are not converted")
+		}
+	}
+	if httpRoute.Delegate != nil {
+		c.issue(source, path+"/delegate", "delegation is not supported, the route is not converted")
+		return false
+	}
+	if httpRoute.DirectResponse != nil {
+		c.issue(source, path+"/directResponse", "direct responses are not supported, the route is not converted")
+		return false
+	}
+	return true
+}
+
+// unsupportedMatchFields reports the fields of an Istio match that have no Gateway API equivalent.
+func (c *gatewayAPIConverter) unsupportedMatchFields(source, path string, match *api_networking_v1.HTTPMatchRequest) {
+	if match.Scheme != nil {
+		c.issue(source, path+"/scheme", "matching the scheme is not supported")
+	}
+	if match.Authority != nil {
+		c.issue(source, path+"/authority", "matching the authority is not supported, use the hostnames of the route")
+	}
+	if match.Port != 0 {
+		c.issue(source, path+"/port", "matching the port is not supported, attach the route to a listener instead")
+	}
+	if len(match.SourceLabels) > 0 || match.SourceNamespace != "" {
+		c.issue(source, path, "matching the source workloads is not supported")
+	}
+	if len(match.Gateways) > 0 {
+		c.issue(source, path+"/gateways", "matching the gateways is not supported, the match applies to all the parents")
+	}
+	if len(match.WithoutHeaders) > 0 {
+		c.issue(source, path+"/withoutHeaders", "matching the absence of headers is not supported")
+	}
+	if match.IgnoreUriCase {
+		c.issue(source, path+"/ignoreUriCase", "case insensitive matching of the path is not supported")
+	}
+}
+
+func (c *gatewayAPIConverter) httpRule(source, path, namespace string, httpRoute *api_networking_v1.HTTPRoute) (k8s_networking_v1.HTTPRouteRule, bool) {
+	rule := k8s_networking_v1.HTTPRouteRule{}
+	if !c.unsupportedRouteFields(source, path, httpRoute) {
+		return rule, false
+	}
+
+	prefixMatches := len(httpRoute.Match) > 0
+	for i, match := range httpRoute.Match {
+		matchPath := fmt.Sprintf("%s/match[%d]", path, i)
+		c.unsupportedMatchFields(source, matchPath, match)
+		routeMatch := k8s_networking_v1.HTTPRouteMatch{}
+		if match.Uri != nil {
+			pathType, value := stringMatch(match.Uri)
+			switch pathType {
+			case "prefix":
+				matchType := k8s_networking_v1.PathMatchPathPrefix
+				routeMatch.Path = &k8s_networking_v1.HTTPPathMatch{Type: &matchType, Value: &value}
+				if value != "/" && !strings.HasSuffix(value, "/") {
+					c.issue(source, matchPath+"/uri", "path prefixes match whole path segments: %s no longer matches %s-suffixed paths", value, value)
+				}
+			case "exact":
+				matchType := k8s_networking_v1.PathMatchExact
+				routeMatch.Path = &k8s_networking_v1.HTTPPathMatch{Type: &matchType, Value: &value}
+				prefixMatches = false
+			default:
+				matchType := k8s_networking_v1.PathMatchRegularExpression
+				routeMatch.Path = &k8s_networking_v1.HTTPPathMatch{Type: &matchType, Value: &value}
+				prefixMatches = false
+			}
+		} else {
+			prefixMatches = false
+		}
+		for _, name := range sortedKeys(match.Headers) {
+			headerMatch := k8s_networking_v1.HTTPHeaderMatch{Name: k8s_networking_v1.HTTPHeaderName(name)}
+			matchType, value := stringMatchValue(match.Headers[name])
+			headerType := k8s_networking_v1.HeaderMatchType(matchType)
+			headerMatch.Type, headerMatch.Value = &headerType, value
+			routeMatch.Headers = append(routeMatch.Headers, headerMatch)
+		}
+		for _, name := range sortedKeys(match.QueryParams) {
+			paramMatch := k8s_networking_v1.HTTPQueryParamMatch{Name: k8s_networking_v1.HTTPHeaderName(name)}
+			matchType, value := stringMatchValue(match.QueryParams[name])
+			paramType := k8s_networking_v1.QueryParamMatchType(matchType)
+			paramMatch.Type, paramMatch.Value = &paramType, value
+			routeMatch.QueryParams = append(routeMatch.QueryParams, paramMatch)
+		}
+		if match.Method != nil {
+			if matchType, value := stringMatch(match.Method); matchType == "exact" {
+				method := k8s_networking_v1.HTTPMethod(strings.ToUpper(value))
+				routeMatch.Method = &method
+			} else {
+				c.issue(source, matchPath+"/method", "only exact method matches are supported")
+			}
+		}
+		rule.Matches = append(rule.Matches, routeMatch)
+	}
+
+	if redirect := httpRoute.Redirect; redirect != nil {
+		filter := &k8s_networking_v1.HTTPRequestRedirectFilter{}
+		if redirect.Uri != "" {
+			filter.Path = &k8s_networking_v1.HTTPPathModifier{Type: k8s_networking_v1.FullPathHTTPPathModifier, ReplaceFullPath: &redirect.Uri}
+		}
+		if redirect.Authority != "" {
+			hostname := k8s_networking_v1.PreciseHostname(redirect.Authority)
+			filter.Hostname = &hostname
+		}
+		if redirect.Scheme != "" {
+			filter.Scheme = &redirect.Scheme
+		}
+		switch port := redirect.RedirectPort.(type) {
+		case *api_networking_v1.HTTPRedirect_Port:
+			portNumber := k8s_networking_v1.PortNumber(port.Port)
+			filter.Port = &portNumber
+		case *api_networking_v1.HTTPRedirect_DerivePort:
+			c.issue(source, path+"/redirect/derivePort", "deriving the redirect port is not supported")
+		}
+		// The Istio redirects default to 301, the Gateway API ones to 302
+		statusCode := 301
+		if redirect.RedirectCode != 0 {
+			statusCode = int(redirect.RedirectCode)
+		}
+		filter.StatusCode = &statusCode
+		rule.Filters = append(rule.Filters, k8s_networking_v1.HTTPRouteFilter{Type: k8s_networking_v1.HTTPRouteFilterRequestRedirect, RequestRedirect: filter})
+	}
+
+	if rewrite := httpRoute.Rewrite; rewrite != nil {
+		filter := &k8s_networking_v1.HTTPURLRewriteFilter{}
+		if rewrite.Uri != "" {
+			if prefixMatches {
+				filter.Path = &k8s_networking_v1.HTTPPathModifier{Type: k8s_networking_v1.PrefixMatchHTTPPathModifier, ReplacePrefixMatch: &rewrite.Uri}
+			} else {
+				filter.Path = &k8s_networking_v1.HTTPPathModifier{Type: k8s_networking_v1.FullPathHTTPPathModifier, ReplaceFullPath: &rewrite.Uri}
+			}
+		}
+		if rewrite.UriRegexRewrite != nil {
+			c.issue(source, path+"/rewrite/uriRegexRewrite", "regular expression rewrites are not supported")
+		}
+		if rewrite.Authority != "" {
+			hostname := k8s_networking_v1.PreciseHostname(rewrite.Authority)
+			filter.Hostname = &hostname
+		}
+		if filter.Path != nil || filter.Hostname != nil {
+			rule.Filters = append(rule.Filters, k8s_networking_v1.HTTPRouteFilter{Type: k8s_networking_v1.HTTPRouteFilterURLRewrite, URLRewrite: filter})
+		}
+	}
+
+	if headers := httpRoute.Headers; headers != nil {
+		if headers.Request != nil {
+			rule.Filters = append(rule.Filters, k8s_networking_v1.HTTPRouteFilter{Type: k8s_networking_v1.HTTPRouteFilterRequestHeaderModifier, RequestHeaderModifier: headerFilter(headers.Request)})
+		}
+		if headers.Response != nil {
+			rule.Filters = append(rule.Filters, k8s_networking_v1.HTTPRouteFilter{Type: k8s_networking_v1.HTTPRouteFilterResponseHeaderModifier, ResponseHeaderModifier: headerFilter(headers.Response)})
+		}
+	}
+
+	for _, mirror := range c.mirrors(source, path, kubernetes.K8sHTTPRoutes.Kind, namespace, httpRoute) {
+		rule.Filters = append(rule.Filters, k8s_networking_v1.HTTPRouteFilter{Type: k8s_networking_v1.HTTPRouteFilterRequestMirror, RequestMirror: mirror})
+	}
+
+	if httpRoute.Timeout != nil {
+		timeout := gatewayAPIDuration(httpRoute.Timeout)
+		rule.Timeouts = &k8s_networking_v1.HTTPRouteTimeouts{Request: &timeout}
+	}
+
+	for i, destination := range httpRoute.Route {
+		if destination.Destination == nil {
+			continue
+		}
+		backend := c.backend(source, fmt.Sprintf("%s/route[%d]/destination", path, i), kubernetes.K8sHTTPRoutes.Kind, namespace, destination.Destination)
+		backendRef := k8s_networking_v1.HTTPBackendRef{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: backend}}
+		if len(httpRoute.Route) > 1 {
+			weight := destination.Weight
+			backendRef.Weight = &weight
+		}
+		rule.BackendRefs = append(rule.BackendRefs, backendRef)
+	}
+	return rule, true
+}
+
+func (c *gatewayAPIConverter) grpcRule(source, path, namespace string, httpRoute *api_networking_v1.HTTPRoute) (k8s_networking_v1.GRPCRouteRule, bool) {
+	rule := k8s_networking_v1.GRPCRouteRule{}
+	if !c.unsupportedRouteFields(source, path, httpRoute) {
+		return rule, false
+	}
+	if httpRoute.Redirect != nil {
+		c.issue(source, path+"/redirect", "redirects are not supported by gRPC routes, the route is not converted")
+		return rule, false
+	}
+	if httpRoute.Rewrite != nil {
+		c.issue(source, path+"/rewrite", "rewrites are not supported by gRPC routes")
+	}
+	if httpRoute.Timeout != nil {
+		c.issue(source, path+"/timeout", "timeouts are not supported by gRPC routes")
+	}
+
+	for i, match := range httpRoute.Match {
+		matchPath := fmt.Sprintf("%s/match[%d]", path, i)
+		c.unsupportedMatchFields(source, matchPath, match)
+		routeMatch := k8s_networking_v1.GRPCRouteMatch{}
+		if match.Uri != nil {
+			if methodMatch, ok := grpcMethodMatch(match.Uri); ok {
+				routeMatch.Method = methodMatch
+			} else {
+				c.issue(source, matchPath+"/uri", "the path match cannot be translated to a gRPC service and method")
+			}
+		}
+		for _, name := range sortedKeys(match.Headers) {
+			matchType, value := stringMatchValue(match.Headers[name])
+			headerType := k8s_networking_v1.GRPCHeaderMatchType(matchType)
+			routeMatch.Headers = append(routeMatch.Headers, k8s_networking_v1.GRPCHeaderMatch{Type: &headerType, Name: k8s_networking_v1.GRPCHeaderName(name), Value: value})
+		}
+		if match.Method != nil || len(match.QueryParams) > 0 {
+			c.issue(source, matchPath, "matching the method or the query parameters is not supported by gRPC routes")
+		}
+		rule.Matches = append(rule.Matches, routeMatch)
+	}
+
+	if headers := httpRoute.Headers; headers != nil {
+		if headers.Request != nil {
+			rule.Filters = append(rule.Filters, k8s_networking_v1.GRPCRouteFilter{Type: k8s_networking_v1.GRPCRouteFilterRequestHeaderModifier, RequestHeaderModifier: headerFilter(headers.Request)})
+		}
+		if headers.Response != nil {
+			rule.Filters = append(rule.Filters, k8s_networking_v1.GRPCRouteFilter{Type: k8s_networking_v1.GRPCRouteFilterResponseHeaderModifier, ResponseHeaderModifier: headerFilter(headers.Response)})
+		}
+	}
+	for _, mirror := range c.mirrors(source, path, kubernetes.K8sGRPCRoutes.Kind, namespace, httpRoute) {
+		rule.Filters = append(rule.Filters, k8s_networking_v1.GRPCRouteFilter{Type: k8s_networking_v1.GRPCRouteFilterRequestMirror, RequestMirror: mirror})
+	}
+
+	for i, destination := range httpRoute.Route {
+		if destination.Destination == nil {
+			continue
+		}
+		backend := c.backend(source, fmt.Sprintf("%s/route[%d]/destination", path, i), kubernetes.K8sGRPCRoutes.Kind, namespace, destination.Destination)
+		backendRef := k8s_networking_v1.GRPCBackendRef{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: backend}}
+		if len(httpRoute.Route) > 1 {
+			weight := destination.Weight
+			backendRef.Weight = &weight
+		}
+		rule.BackendRefs = append(rule.BackendRefs, backendRef)
+	}
+	return rule, true
+}
+
+// mirrors returns the mirror filters of an Istio HTTP route.
+func (c *gatewayAPIConverter) mirrors(source, path, routeKind, namespace string, httpRoute *api_networking_v1.HTTPRoute) []*k8s_networking_v1.HTTPRequestMirrorFilter {
+	type mirrorPolicy struct {
+		path   string
+		policy *api_networking_v1.HTTPMirrorPolicy
+	}
+	policies := []mirrorPolicy{}
+	if httpRoute.Mirror != nil {
+		policies = append(policies, mirrorPolicy{path: path + "/mirror", policy: &api_networking_v1.HTTPMirrorPolicy{Destination: httpRoute.Mirror, Percentage: httpRoute.MirrorPercentage}})
+	}
+	for i, policy := range httpRoute.Mirrors {
+		policies = append(policies, mirrorPolicy{path: fmt.Sprintf("%s/mirrors[%d]", path, i), policy: policy})
+	}
+
+	filters := []*k8s_networking_v1.HTTPRequestMirrorFilter{}
+	for _, mirror := range policies {
+		policy, mirrorPath := mirror.policy, mirror.path
+		if policy.Destination == nil {
+			continue
+		}
+		filter := &k8s_networking_v1.HTTPRequestMirrorFilter{BackendRef: c.backend(source, mirrorPath, routeKind, namespace, policy.Destination)}
+		if policy.Percentage != nil {
+			percent := int32(policy.Percentage.Value)
+			if float64(percent) != policy.Percentage.Value {
+				c.issue(source, mirrorPath, "the mirror percentage is rounded down to %d", percent)
+			}
+			filter.Percent = &percent
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// stringMatch returns the type and the value of an Istio string match: exact, prefix or regex.
+func stringMatch(match *api_networking_v1.StringMatch) (string, string) {
+	switch m := match.MatchType.(type) {
+	case *api_networking_v1.StringMatch_Exact:
+		return "exact", m.Exact
+	case *api_networking_v1.StringMatch_Prefix:
+		return "prefix", m.Prefix
+	case *api_networking_v1.StringMatch_Regex:
+		return "regex", m.Regex
+	}
+	return "", ""
+}
+
+// stringMatchValue returns the Gateway API match type and value of an Istio header or query parameter match.
+// Prefix matches are translated to regular expressions.
+func stringMatchValue(match *api_networking_v1.StringMatch) (string, string) {
+	matchType, value := stringMatch(match)
+	switch matchType {
+	case "exact":
+		return string(k8s_networking_v1.HeaderMatchExact), value
+	case "prefix":
+		return string(k8s_networking_v1.HeaderMatchRegularExpression), regexp.QuoteMeta(value) + ".*"
+	default:
+		return string(k8s_networking_v1.HeaderMatchRegularExpression), value
+	}
+}
+
+// grpcMethodMatch translates a path match to a gRPC method match: /service/method paths, or /service/ prefixes.
+func grpcMethodMatch(uri *api_networking_v1.StringMatch) (*k8s_networking_v1.GRPCMethodMatch, bool) {
+	matchType, value := stringMatch(uri)
+	parts := strings.Split(strings.TrimPrefix(value, "/"), "/")
+	exact := k8s_networking_v1.GRPCMethodMatchExact
+	switch {
+	case matchType == "prefix" && value == "/":
+		return nil, true
+	case matchType == "prefix" && len(parts) <= 2 && parts[0] != "" && (len(parts) == 1 || parts[1] == ""):
+		return &k8s_networking_v1.GRPCMethodMatch{Type: &exact, Service: &parts[0]}, true
+	case matchType == "exact" && len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return &k8s_networking_v1.GRPCMethodMatch{Type: &exact, Service: &parts[0], Method: &parts[1]}, true
+	}
+	return nil, false
+}
+
+func headerFilter(operations *api_networking_v1.Headers_HeaderOperations) *k8s_networking_v1.HTTPHeaderFilter {
+	filter := &k8s_networking_v1.HTTPHeaderFilter{Remove: operations.Remove}
+	for _, name := range sortedKeys(operations.Set) {
+		filter.Set = append(filter.Set, k8s_networking_v1.HTTPHeader{Name: k8s_networking_v1.HTTPHeaderName(name), Value: operations.Set[name]})
+	}
+	for _, name := range sortedKeys(operations.Add) {
+		filter.Add = append(filter.Add, k8s_networking_v1.HTTPHeader{Name: k8s_networking_v1.HTTPHeaderName(name), Value: operations.Add[name]})
+	}
+	return filter
+}
+
+// gatewayAPIDuration formats a duration as a Gateway API duration, in seconds or milliseconds.
+func gatewayAPIDuration(duration *durationpb.Duration) k8s_networking_v1.Duration {
+	ms := duration.AsDuration().Milliseconds()
+	if ms%1000 == 0 {
+		return k8s_networking_v1.Duration(fmt.Sprintf("%ds", ms/1000))
+	}
+	return k8s_networking_v1.Duration(fmt.Sprintf("%dms", ms))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// convertIngress converts an Ingress to a Gateway with HTTP and HTTPS listeners, and an HTTPRoute per host.
+func (c *gatewayAPIConverter) convertIngress(ingress *k8s_ingress_v1.Ingress) {
+	source := "Ingress/" + ingress.Name
+	for _, annotation := range sortedKeys(ingress.Annotations) {
+		if annotation != core_v1.LastAppliedConfigAnnotation {
+			c.issue(source, "metadata/annotations", "the %s annotation is not translated", annotation)
+		}
+	}
+
+	gateway := &k8s_networking_v1.Gateway{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sGateways.GroupVersion().String(), Kind: kubernetes.K8sGateways.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: ingress.Name, Namespace: ingress.Namespace},
+	}
+	gateway.Spec.GatewayClassName = k8s_networking_v1.ObjectName(c.className)
+
+	// The rules are grouped by host, each host gets its own route
+	type ingressPath struct {
+		path    string
+		ingress k8s_ingress_v1.HTTPIngressPath
+	}
+	rulesByHost := map[string][]ingressPath{}
+	for i, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			c.issue(source, fmt.Sprintf("spec/rules[%d]", i), "the rule has no HTTP paths")
+			continue
+		}
+		for j, httpPath := range rule.HTTP.Paths {
+			rulesByHost[rule.Host] = append(rulesByHost[rule.Host], ingressPath{path: fmt.Sprintf("spec/rules[%d]/http/paths[%d]", i, j), ingress: httpPath})
+		}
+	}
+	if ingress.Spec.DefaultBackend != nil {
+		if _, found := rulesByHost[""]; !found {
+			rulesByHost[""] = nil
+		}
+	}
+	hosts := sortedKeys(rulesByHost)
+
+	for i, host := range hosts {
+		listener := k8s_networking_v1.Listener{Name: "http", Port: 80, Protocol: k8s_networking_v1.HTTPProtocolType}
+		if len(hosts) > 1 {
+			listener.Name = k8s_networking_v1.SectionName(fmt.Sprintf("http-%d", i))
+		}
+		if host != "" {
+			hostname := k8s_networking_v1.Hostname(host)
+			listener.Hostname = &hostname
+		}
+		gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
+	}
+	for i, tls := range ingress.Spec.TLS {
+		mode := k8s_networking_v1.TLSModeTerminate
+		tlsConfig := &k8s_networking_v1.ListenerTLSConfig{Mode: &mode}
+		if tls.SecretName == "" {
+			c.issue(source, fmt.Sprintf("spec/tls[%d]/secretName", i), "TLS without a secret is not supported")
+		} else {
+			tlsConfig.CertificateRefs = []k8s_networking_v1.SecretObjectReference{{Name: k8s_networking_v1.ObjectName(tls.SecretName)}}
+		}
+		tlsHosts := tls.Hosts
+		if len(tlsHosts) == 0 {
+			tlsHosts = []string{""}
+		}
+		for j, host := range tlsHosts {
+			listener := k8s_networking_v1.Listener{
+				Name:     k8s_networking_v1.SectionName(fmt.Sprintf("https-%d-%d", i, j)),
+				Port:     443,
+				Protocol: k8s_networking_v1.HTTPSProtocolType,
+				TLS:      tlsConfig,
+			}
+			if host != "" {
+				hostname := k8s_networking_v1.Hostname(host)
+				listener.Hostname = &hostname
+			}
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
+		}
+	}
+	c.add(kubernetes.K8sGateways, gateway, source, "-ingress")
+
+	for i, host := range hosts {
+		route := &k8s_networking_v1.HTTPRoute{
+			TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sHTTPRoutes.GroupVersion().String(), Kind: kubernetes.K8sHTTPRoutes.Kind},
+			ObjectMeta: meta_v1.ObjectMeta{Name: ingress.Name, Namespace: ingress.Namespace},
+		}
+		if len(hosts) > 1 {
+			route.Name = fmt.Sprintf("%s-%d", ingress.Name, i)
+		}
+		route.Spec.ParentRefs = []k8s_networking_v1.ParentReference{gatewayParentRef(gateway.Name)}
+		if host != "" {
+			route.Spec.Hostnames = []k8s_networking_v1.Hostname{k8s_networking_v1.Hostname(host)}
+		}
+
+		for _, rulePath := range rulesByHost[host] {
+			path, ingressPath := rulePath.path, rulePath.ingress
+			value := ingressPath.Path
+			if value == "" {
+				value = "/"
+			}
+			matchType := k8s_networking_v1.PathMatchPathPrefix
+			switch {
+			case ingressPath.PathType != nil && *ingressPath.PathType == k8s_ingress_v1.PathTypeExact:
+				matchType = k8s_networking_v1.PathMatchExact
+			case ingressPath.PathType == nil || *ingressPath.PathType == k8s_ingress_v1.PathTypeImplementationSpecific:
+				c.issue(source, path+"/pathType", "the implementation specific path %s is translated to a path prefix", value)
+			}
+			rule := k8s_networking_v1.HTTPRouteRule{Matches: []k8s_networking_v1.HTTPRouteMatch{{Path: &k8s_networking_v1.HTTPPathMatch{Type: &matchType, Value: &value}}}}
+			if backendRef, ok := c.ingressBackend(source, path+"/backend", ingress.Namespace, ingressPath.Backend); ok {
+				rule.BackendRefs = []k8s_networking_v1.HTTPBackendRef{backendRef}
+			}
+			route.Spec.Rules = append(route.Spec.Rules, rule)
+		}
+		if host == "" && ingress.Spec.DefaultBackend != nil {
+			if backendRef, ok := c.ingressBackend(source, "spec/defaultBackend", ingress.Namespace, *ingress.Spec.DefaultBackend); ok {
+				route.Spec.Rules = append(route.Spec.Rules, k8s_networking_v1.HTTPRouteRule{BackendRefs: []k8s_networking_v1.HTTPBackendRef{backendRef}})
+			}
+		}
+		c.add(kubernetes.K8sHTTPRoutes, route, source, "-ingress")
+	}
+}
+
+// ingressBackend returns the backend reference of an Ingress backend, resolving the named service ports.
+func (c *gatewayAPIConverter) ingressBackend(source, path, namespace string, backend k8s_ingress_v1.IngressBackend) (k8s_networking_v1.HTTPBackendRef, bool) {
+	if backend.Service == nil {
+		c.issue(source, path+"/resource", "resource backends are not supported")
+		return k8s_networking_v1.HTTPBackendRef{}, false
+	}
+
+	group := k8s_networking_v1.Group("")
+	kind := k8s_networking_v1.Kind(kubernetes.ServiceType)
+	ref := k8s_networking_v1.BackendObjectReference{Group: &group, Kind: &kind, Name: k8s_networking_v1.ObjectName(backend.Service.Name)}
+	portNumber := backend.Service.Port.Number
+	if portNumber == 0 && backend.Service.Port.Name != "" {
+		svc := &core_v1.Service{}
+		if err := c.kubeCache.Get(c.ctx, client.ObjectKey{Namespace: namespace, Name: backend.Service.Name}, svc); err == nil {
+			for _, port := range svc.Spec.Ports {
+				if port.Name == backend.Service.Port.Name {
+					portNumber = port.Port
+				}
+			}
+		}
+		if portNumber == 0 {
+			c.issue(source, path+"/service/port", "the port %s of service %s is not found", backend.Service.Port.Name, backend.Service.Name)
+		}
+	}
+	if portNumber != 0 {
+		port := k8s_networking_v1.PortNumber(portNumber)
+		ref.Port = &port
+	}
+	return k8s_networking_v1.HTTPBackendRef{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: ref}}, true
+}
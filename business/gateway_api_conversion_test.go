@@ -0,0 +1,279 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	api_networking_v1 "istio.io/api/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	k8s_ingress_v1 "k8s.io/api/networking/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func conversionService(namespace, name, portName string, port int32) *core_v1.Service {
+	svc := kubetest.FakeService(namespace, name)
+	svc.Spec.Ports = []core_v1.ServicePort{{Name: portName, Protocol: "TCP", Port: port}}
+	return &svc
+}
+
+func conversionIssuePaths(issues []models.GatewayAPIConversionIssue) map[string]bool {
+	paths := map[string]bool{}
+	for _, issue := range issues {
+		paths[issue.Source+":"+issue.Path] = true
+	}
+	return paths
+}
+
+func TestConvertToGatewayAPI(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+
+	gateway := data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"})
+	gateway = data.AddServerToGateway(data.CreateServer([]string{"*"}, 80, "http", "HTTP"), gateway)
+	https := data.CreateServer([]string{"bookinfo.example.com"}, 443, "https", "HTTPS")
+	https.Tls = &api_networking_v1.ServerTLSSettings{Mode: api_networking_v1.ServerTLSSettings_SIMPLE, CredentialName: "bookinfo-cert"}
+	gateway = data.AddServerToGateway(https, gateway)
+
+	bookinfo := data.AddGatewaysToVirtualService([]string{"bookinfo-gateway"}, data.CreateEmptyVirtualService("bookinfo", "bookinfo", []string{"bookinfo.example.com"}))
+	bookinfo.Spec.Http = []*api_networking_v1.HTTPRoute{
+		{
+			Match: []*api_networking_v1.HTTPMatchRequest{
+				{Uri: &api_networking_v1.StringMatch{MatchType: &api_networking_v1.StringMatch_Exact{Exact: "/productpage"}}},
+				{Uri: &api_networking_v1.StringMatch{MatchType: &api_networking_v1.StringMatch_Prefix{Prefix: "/static/"}}},
+			},
+			Route: []*api_networking_v1.HTTPRouteDestination{{Destination: &api_networking_v1.Destination{Host: "productpage"}}},
+		},
+		{
+			Fault: &api_networking_v1.HTTPFaultInjection{},
+			Route: []*api_networking_v1.HTTPRouteDestination{
+				{Destination: &api_networking_v1.Destination{Host: "reviews"}, Weight: 90},
+				{Destination: &api_networking_v1.Destination{Host: "details.other.svc.cluster.local"}, Weight: 10},
+			},
+		},
+	}
+
+	ratings := data.CreateEmptyVirtualService("ratings", "bookinfo", []string{"ratings"})
+	ratings.Spec.Http = []*api_networking_v1.HTTPRoute{{
+		Timeout: durationpb.New(1500 * time.Millisecond),
+		Route:   []*api_networking_v1.HTTPRouteDestination{{Destination: &api_networking_v1.Destination{Host: "ratings", Subset: "v1"}}},
+	}}
+
+	catalog := data.CreateEmptyVirtualService("catalog", "bookinfo", []string{"catalog"})
+	catalog.Spec.Http = []*api_networking_v1.HTTPRoute{{
+		Match: []*api_networking_v1.HTTPMatchRequest{{Uri: &api_networking_v1.StringMatch{MatchType: &api_networking_v1.StringMatch_Exact{Exact: "/catalog.Catalog/List"}}}},
+		Route: []*api_networking_v1.HTTPRouteDestination{{Destination: &api_networking_v1.Destination{Host: "catalog"}}},
+	}}
+
+	prefix := k8s_ingress_v1.PathTypePrefix
+	ingress := &k8s_ingress_v1.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "shop", Namespace: "bookinfo", Annotations: map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/"}},
+		Spec: k8s_ingress_v1.IngressSpec{
+			TLS: []k8s_ingress_v1.IngressTLS{{Hosts: []string{"shop.example.com"}, SecretName: "shop-cert"}},
+			Rules: []k8s_ingress_v1.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: k8s_ingress_v1.IngressRuleValue{HTTP: &k8s_ingress_v1.HTTPIngressRuleValue{Paths: []k8s_ingress_v1.HTTPIngressPath{{
+					Path:     "/cart",
+					PathType: &prefix,
+					Backend:  k8s_ingress_v1.IngressBackend{Service: &k8s_ingress_v1.IngressServiceBackend{Name: "cart", Port: k8s_ingress_v1.ServiceBackendPort{Name: "http"}}},
+				}}}},
+			}},
+		},
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		kubetest.FakeNamespace("other"),
+		conversionService("bookinfo", "productpage", "http", 9080),
+		conversionService("bookinfo", "reviews", "http", 9080),
+		conversionService("bookinfo", "ratings", "http", 9080),
+		conversionService("bookinfo", "catalog", "grpc", 9090),
+		conversionService("bookinfo", "cart", "http", 8080),
+		conversionService("other", "details", "http", 9080),
+		gateway, bookinfo, ratings, catalog, ingress,
+	)
+	k8s.GatewayAPIEnabled = true
+	configService := NewLayerBuilder(t, conf).WithClient(k8s).Build().IstioConfig
+
+	ctx := context.Background()
+	result, err := configService.ConvertToGatewayAPI(ctx, "east", "bookinfo", models.GatewayAPIConversionRequest{GatewayClassName: "istio"})
+	require.NoError(err)
+	assert.False(result.Applied)
+
+	converted := []string{}
+	objects := map[string]models.GatewayAPIConversionObject{}
+	for _, obj := range result.Objects {
+		key := obj.ObjectGVK.Kind + "/" + obj.Namespace + "/" + obj.Name
+		converted = append(converted, key)
+		objects[key] = obj
+		assert.Equal(models.GitOpsOperationCreate, obj.Operation)
+	}
+	assert.Equal([]string{
+		"Gateway/bookinfo/bookinfo-gateway",
+		"HTTPRoute/bookinfo/bookinfo",
+		"GRPCRoute/bookinfo/catalog",
+		"HTTPRoute/bookinfo/ratings",
+		"Gateway/bookinfo/shop",
+		"HTTPRoute/bookinfo/shop",
+		"ReferenceGrant/other/allow-bookinfo-routes",
+	}, converted)
+
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind": "Gateway",
+		"metadata": {"name": "bookinfo-gateway", "namespace": "bookinfo"},
+		"spec": {
+			"gatewayClassName": "istio",
+			"listeners": [
+				{"name": "http", "port": 80, "protocol": "HTTP", "allowedRoutes": {"namespaces": {"from": "All"}}},
+				{
+					"name": "https", "hostname": "bookinfo.example.com", "port": 443, "protocol": "HTTPS",
+					"tls": {"mode": "Terminate", "certificateRefs": [{"name": "bookinfo-cert"}]},
+					"allowedRoutes": {"namespaces": {"from": "All"}}
+				}
+			]
+		}
+	}`, string(objects["Gateway/bookinfo/bookinfo-gateway"].Manifest))
+
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind": "HTTPRoute",
+		"metadata": {"name": "bookinfo", "namespace": "bookinfo"},
+		"spec": {
+			"parentRefs": [{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "bookinfo-gateway"}],
+			"hostnames": ["bookinfo.example.com"],
+			"rules": [
+				{
+					"matches": [
+						{"path": {"type": "Exact", "value": "/productpage"}},
+						{"path": {"type": "PathPrefix", "value": "/static/"}}
+					],
+					"backendRefs": [{"group": "", "kind": "Service", "name": "productpage", "port": 9080}]
+				},
+				{
+					"backendRefs": [
+						{"group": "", "kind": "Service", "name": "reviews", "port": 9080, "weight": 90},
+						{"group": "", "kind": "Service", "name": "details", "namespace": "other", "port": 9080, "weight": 10}
+					]
+				}
+			]
+		}
+	}`, string(objects["HTTPRoute/bookinfo/bookinfo"].Manifest))
+
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind": "HTTPRoute",
+		"metadata": {"name": "ratings", "namespace": "bookinfo"},
+		"spec": {
+			"parentRefs": [{"group": "", "kind": "Service", "name": "ratings"}],
+			"rules": [{
+				"backendRefs": [{"group": "", "kind": "Service", "name": "ratings", "port": 9080}],
+				"timeouts": {"request": "1500ms"}
+			}]
+		}
+	}`, string(objects["HTTPRoute/bookinfo/ratings"].Manifest))
+
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind": "GRPCRoute",
+		"metadata": {"name": "catalog", "namespace": "bookinfo"},
+		"spec": {
+			"parentRefs": [{"group": "", "kind": "Service", "name": "catalog"}],
+			"rules": [{
+				"matches": [{"method": {"type": "Exact", "service": "catalog.Catalog", "method": "List"}}],
+				"backendRefs": [{"group": "", "kind": "Service", "name": "catalog", "port": 9090}]
+			}]
+		}
+	}`, string(objects["GRPCRoute/bookinfo/catalog"].Manifest))
+
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind": "HTTPRoute",
+		"metadata": {"name": "shop", "namespace": "bookinfo"},
+		"spec": {
+			"parentRefs": [{"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "shop"}],
+			"hostnames": ["shop.example.com"],
+			"rules": [{
+				"matches": [{"path": {"type": "PathPrefix", "value": "/cart"}}],
+				"backendRefs": [{"group": "", "kind": "Service", "name": "cart", "port": 8080}]
+			}]
+		}
+	}`, string(objects["HTTPRoute/bookinfo/shop"].Manifest))
+
+	grant := objects["ReferenceGrant/other/allow-bookinfo-routes"]
+	assert.Equal([]string{"VirtualService/bookinfo"}, grant.Sources)
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1beta1",
+		"kind": "ReferenceGrant",
+		"metadata": {"name": "allow-bookinfo-routes", "namespace": "other"},
+		"spec": {
+			"from": [{"group": "gateway.networking.k8s.io", "kind": "HTTPRoute", "namespace": "bookinfo"}],
+			"to": [{"group": "", "kind": "Service", "name": "details"}]
+		}
+	}`, string(grant.Manifest))
+
+	issues := conversionIssuePaths(result.Issues)
+	assert.True(issues["Gateway/bookinfo-gateway:spec/selector"])
+	assert.True(issues["VirtualService/bookinfo:spec/http[1]/fault"])
+	assert.True(issues["VirtualService/ratings:spec/http[0]/route[0]/destination/subset"])
+	assert.True(issues["Ingress/shop:metadata/annotations"])
+	assert.Len(result.Issues, 4)
+
+	written, err := configService.ApplyGatewayAPIConversion(ctx, result)
+	require.NoError(err)
+	assert.True(result.Applied)
+	assert.Len(written, len(result.Objects))
+	_, err = k8s.GatewayAPI().GatewayV1().HTTPRoutes("bookinfo").Get(ctx, "bookinfo", meta_v1.GetOptions{})
+	assert.NoError(err)
+	_, err = k8s.GatewayAPI().GatewayV1beta1().ReferenceGrants("other").Get(ctx, "allow-bookinfo-routes", meta_v1.GetOptions{})
+	assert.NoError(err)
+}
+
+func TestConvertToGatewayAPISelectedObjects(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	reviews := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	reviews.Spec.Http = []*api_networking_v1.HTTPRoute{{
+		Route: []*api_networking_v1.HTTPRouteDestination{{Destination: &api_networking_v1.Destination{Host: "reviews"}}},
+	}}
+	details := data.CreateEmptyVirtualService("details", "bookinfo", []string{"details"})
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), conversionService("bookinfo", "reviews", "http", 9080), reviews, details)
+	configService := NewLayerBuilder(t, conf).WithClient(k8s).Build().IstioConfig
+	ctx := context.Background()
+
+	// The Gateway API must be installed
+	_, err := configService.ConvertToGatewayAPI(ctx, "east", "bookinfo", models.GatewayAPIConversionRequest{})
+	assert.True(api_errors.IsBadRequest(err))
+
+	k8s.GatewayAPIEnabled = true
+	_, err = configService.ConvertToGatewayAPI(ctx, "east", "bookinfo", models.GatewayAPIConversionRequest{VirtualServices: []string{"ratings"}})
+	assert.True(api_errors.IsNotFound(err))
+
+	result, err := configService.ConvertToGatewayAPI(ctx, "east", "bookinfo", models.GatewayAPIConversionRequest{VirtualServices: []string{"reviews"}})
+	require.NoError(err)
+	require.Len(result.Objects, 1)
+	assert.Equal(kubernetes.K8sHTTPRoutes, result.Objects[0].ObjectGVK)
+	assert.Equal([]string{"VirtualService/reviews"}, result.Objects[0].Sources)
+	assert.True(result.Valid)
+	assert.Empty(result.Issues)
+}
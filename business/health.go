@@ -49,7 +49,7 @@ type NamespaceHealthCriteria struct {
 }
 
 // Annotation Filter for Health
-var HealthAnnotation = []models.AnnotationKey{models.RateHealthAnnotation}
+var HealthAnnotation = []models.AnnotationKey{models.RateHealthAnnotation, models.SLOHealthAnnotation}
 
 // GetServiceHealth returns a service health (service request error rate)
 func (in *HealthService) GetServiceHealth(ctx context.Context, namespace, cluster, service, rateInterval string, queryTime time.Time, svc *models.Service) (models.ServiceHealth, error) {
@@ -64,7 +64,7 @@ func (in *HealthService) GetServiceHealth(ctx context.Context, namespace, cluste
 	defer end()
 
 	rqHealth, err := in.getServiceRequestsHealth(ctx, namespace, cluster, service, rateInterval, queryTime, svc)
-	health := models.ServiceHealth{Requests: rqHealth}
+	health := models.ServiceHealth{Requests: rqHealth, SLOs: in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeService)[service]}
 
 	// Calculate and set the health status
 	if err == nil {
@@ -95,6 +95,7 @@ func (in *HealthService) GetAppHealth(ctx context.Context, namespace, cluster, a
 	defer end()
 
 	health, err := in.getAppHealth(ctx, namespace, cluster, app, rateInterval, queryTime, appD.Workloads)
+	health.SLOs = in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeApp)[app]
 
 	// Calculate and set the health status
 	if err == nil {
@@ -132,7 +133,7 @@ func (in *HealthService) getAppHealth(ctx context.Context, namespace, cluster, a
 	}
 
 	// Deployment status
-	health.WorkloadStatuses = ws.CastWorkloadStatuses()
+	health.WorkloadStatuses = in.castWorkloadStatuses(ctx, cluster, namespace, ws)
 
 	return health, errRate
 }
@@ -154,8 +155,9 @@ func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, clust
 	// Perf: do not bother fetching request rate if workload has no HTTP/request traffic capability
 	if !w.HasHTTPTraffic() {
 		health = models.WorkloadHealth{
-			WorkloadStatus: w.CastWorkloadStatus(),
+			WorkloadStatus: in.castWorkloadStatus(ctx, cluster, namespace, w),
 			Requests:       models.NewEmptyRequestHealth(),
+			SLOs:           in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeWorkload)[workload],
 		}
 		// Calculate and set the health status
 		calculated := in.calculator.CalculateWorkloadHealth(namespace, workload, &health, w.HealthAnnotations)
@@ -168,8 +170,9 @@ func (in *HealthService) GetWorkloadHealth(ctx context.Context, namespace, clust
 	// Add Telemetry info
 	rate, err := in.getWorkloadRequestsHealth(ctx, namespace, cluster, workload, rateInterval, queryTime, w)
 	health = models.WorkloadHealth{
-		WorkloadStatus: w.CastWorkloadStatus(),
+		WorkloadStatus: in.castWorkloadStatus(ctx, cluster, namespace, w),
 		Requests:       rate,
+		SLOs:           in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeWorkload)[workload],
 	}
 
 	// Calculate and set the health status
@@ -349,7 +352,7 @@ func (in *HealthService) getNamespaceAppHealth(ctx context.Context, appEntities
 			h := models.EmptyAppHealth()
 			allHealth[app] = &h
 			if entities != nil {
-				h.WorkloadStatuses = entities.Workloads.CastWorkloadStatuses()
+				h.WorkloadStatuses = in.castWorkloadStatuses(ctx, cluster, namespace, entities.Workloads)
 				for _, w := range entities.Workloads {
 					if w.HasHTTPTraffic() {
 						hasHTTPTraffic = true
@@ -369,6 +372,28 @@ func (in *HealthService) getNamespaceAppHealth(ctx context.Context, appEntities
 		}
 		// Fill with collected request rates
 		fillAppRequestRates(allHealth, rates, appHTTPTraffic)
+		in.fillRequestLatencies(ctx, criteria, "canonical_service", "", func(app string) *models.RequestHealth {
+			if health, ok := allHealth[app]; ok && appHTTPTraffic[app] {
+				return &health.Requests
+			}
+			return nil
+		})
+	}
+
+	if criteria.IncludeMetrics {
+		entities := make(map[string]map[string]string, len(allHealth))
+		for app, health := range allHealth {
+			entities[app] = health.Requests.HealthAnnotations
+		}
+		for app, slos := range in.fillSLOs(ctx, criteria, "app", entities) {
+			allHealth[app].SLOs = slos
+		}
+	} else {
+		for app, slos := range in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeApp) {
+			if health, ok := allHealth[app]; ok {
+				health.SLOs = slos
+			}
+		}
 	}
 
 	// Calculate and set status for each app
@@ -496,6 +521,28 @@ func (in *HealthService) getNamespaceServiceHealth(ctx context.Context, services
 		for _, health := range allHealth {
 			health.Requests.CombineReporters()
 		}
+		in.fillRequestLatencies(ctx, criteria, "service_name", "", func(service string) *models.RequestHealth {
+			if health, ok := allHealth[service]; ok {
+				return &health.Requests
+			}
+			return nil
+		})
+	}
+
+	if criteria.IncludeMetrics {
+		entities := make(map[string]map[string]string, len(allHealth))
+		for svcName, health := range allHealth {
+			entities[svcName] = health.Requests.HealthAnnotations
+		}
+		for svcName, slos := range in.fillSLOs(ctx, criteria, "service", entities) {
+			allHealth[svcName].SLOs = slos
+		}
+	} else {
+		for svcName, slos := range in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeService) {
+			if health, ok := allHealth[svcName]; ok {
+				health.SLOs = slos
+			}
+		}
 	}
 
 	// Calculate and set status for each service
@@ -550,10 +597,11 @@ func (in *HealthService) getNamespaceWorkloadHealth(ctx context.Context, ws mode
 	wlHTTPTraffic := make(map[string]bool)
 
 	allHealth := make(models.NamespaceWorkloadHealth)
-	for _, w := range ws {
+	statuses := in.castWorkloadStatuses(ctx, cluster, namespace, ws)
+	for i, w := range ws {
 		allHealth[w.Name] = models.EmptyWorkloadHealth()
 		allHealth[w.Name].Requests.HealthAnnotations = models.GetHealthAnnotation(w.HealthAnnotations, HealthAnnotation)
-		allHealth[w.Name].WorkloadStatus = w.CastWorkloadStatus()
+		allHealth[w.Name].WorkloadStatus = statuses[i]
 		if w.HasHTTPTraffic() {
 			hasHTTPTraffic = true
 			wlHTTPTraffic[w.Name] = true
@@ -568,6 +616,28 @@ func (in *HealthService) getNamespaceWorkloadHealth(ctx context.Context, ws mode
 		}
 		// Fill with collected request rates
 		fillWorkloadRequestRates(allHealth, rates, wlHTTPTraffic)
+		in.fillRequestLatencies(ctx, criteria, "workload", "", func(workload string) *models.RequestHealth {
+			if health, ok := allHealth[workload]; ok && wlHTTPTraffic[workload] {
+				return &health.Requests
+			}
+			return nil
+		})
+	}
+
+	if criteria.IncludeMetrics {
+		entities := make(map[string]map[string]string, len(allHealth))
+		for wkName, health := range allHealth {
+			entities[wkName] = health.Requests.HealthAnnotations
+		}
+		for wkName, slos := range in.fillSLOs(ctx, criteria, "workload", entities) {
+			allHealth[wkName].SLOs = slos
+		}
+	} else {
+		for wkName, slos := range in.cachedSLOs(cluster, namespace, internalmetrics.HealthTypeWorkload) {
+			if health, ok := allHealth[wkName]; ok {
+				health.SLOs = slos
+			}
+		}
 	}
 
 	// Calculate and set status for each workload
@@ -580,6 +650,68 @@ func (in *HealthService) getNamespaceWorkloadHealth(ctx context.Context, ws mode
 	return allHealth, nil
 }
 
+// requestDurationMetric is the histogram of the request durations, used by the latency thresholds.
+const requestDurationMetric = "istio_request_duration_milliseconds"
+
+// fillRequestLatencies fetches the quantiles of the request duration used by the latency thresholds, for the
+// entities of the namespace identified by the destination_<itemLabelSuffix> and source_<itemLabelSuffix> labels,
+// and stores them in the request health returned by requests for each entity name. Only the entity with the given
+// name is fetched when it is not empty. Inbound latencies are those reported by the destination proxies, outbound
+// latencies those reported by the source proxies. Nothing is fetched when no latency threshold is configured.
+// Failures are logged: the health is then calculated without latency.
+func (in *HealthService) fillRequestLatencies(ctx context.Context, criteria NamespaceHealthCriteria, itemLabelSuffix, name string, requests func(name string) *models.RequestHealth) {
+	quantiles := in.calculator.matcher.LatencyQuantiles()
+	if len(quantiles) == 0 {
+		return
+	}
+
+	// Services are not labeled with the workload namespace
+	destNamespaceLabel := "destination_workload_namespace"
+	destClusterMatch := fmt.Sprintf(`destination_cluster="%s"`, criteria.Cluster)
+	if itemLabelSuffix == "service_name" {
+		destNamespaceLabel = "destination_service_namespace"
+		destClusterMatch = fmt.Sprintf(`destination_cluster=~"%s|unknown"`, criteria.Cluster)
+	}
+	type latencyQuery struct {
+		direction string
+		groupBy   string
+		labels    string
+	}
+	destNameMatch, sourceNameMatch := "", ""
+	if name != "" {
+		destNameMatch = fmt.Sprintf(`,destination_%s="%s"`, itemLabelSuffix, name)
+		sourceNameMatch = fmt.Sprintf(`,source_%s="%s"`, itemLabelSuffix, name)
+	}
+	queries := []latencyQuery{{
+		direction: "inbound",
+		groupBy:   "destination_" + itemLabelSuffix,
+		labels:    fmt.Sprintf(`{reporter="destination",%s="%s",%s%s}`, destNamespaceLabel, criteria.Namespace, destClusterMatch, destNameMatch),
+	}}
+	// Services have no outbound traffic
+	if itemLabelSuffix != "service_name" {
+		queries = append(queries, latencyQuery{
+			direction: "outbound",
+			groupBy:   "source_" + itemLabelSuffix,
+			labels:    fmt.Sprintf(`{reporter="source",source_workload_namespace="%s",source_cluster="%s"%s}`, criteria.Namespace, criteria.Cluster, sourceNameMatch),
+		})
+	}
+
+	for _, d := range queries {
+		latencies, err := in.prom.FetchHistogramValues(ctx, requestDurationMetric, d.labels, d.groupBy+",request_protocol", criteria.RateInterval, false, quantiles, criteria.QueryTime)
+		if err != nil {
+			log.FromContext(ctx).Warn().Err(err).Msgf("Unable to fetch the %s request latencies of namespace [%s]: health is calculated without them", d.direction, criteria.Namespace)
+			continue
+		}
+		for quantile, samples := range latencies {
+			for _, sample := range samples {
+				if rqHealth := requests(string(sample.Metric[model.LabelName(d.groupBy)])); rqHealth != nil {
+					rqHealth.SetLatency(d.direction, quantile, sample)
+				}
+			}
+		}
+	}
+}
+
 // fillAppRequestRates aggregates requests rates from metrics fetched from Prometheus, and stores the result in the health map.
 func fillAppRequestRates(allHealth models.NamespaceAppHealth, rates model.Vector, appHTTPTraffic map[string]bool) {
 	lblDest := model.LabelName("destination_canonical_service")
@@ -646,6 +778,7 @@ func (in *HealthService) getServiceRequestsHealth(ctx context.Context, namespace
 	}
 	rqHealth.HealthAnnotations = svc.HealthAnnotations
 	rqHealth.CombineReporters()
+	in.fillEntityRequestLatencies(ctx, namespace, cluster, "service_name", service, rateInterval, queryTime, &rqHealth)
 	return rqHealth, nil
 }
 
@@ -663,6 +796,7 @@ func (in *HealthService) getAppRequestsHealth(ctx context.Context, namespace, cl
 		rqHealth.AggregateOutbound(sample)
 	}
 	rqHealth.CombineReporters()
+	in.fillEntityRequestLatencies(ctx, namespace, cluster, "canonical_service", app, rateInterval, queryTime, &rqHealth)
 	return rqHealth, nil
 }
 
@@ -684,5 +818,17 @@ func (in *HealthService) getWorkloadRequestsHealth(ctx context.Context, namespac
 		rqHealth.HealthAnnotations = models.GetHealthAnnotation(w.HealthAnnotations, HealthAnnotation)
 	}
 	rqHealth.CombineReporters()
+	in.fillEntityRequestLatencies(ctx, namespace, cluster, "workload", workload, rateInterval, queryTime, &rqHealth)
 	return rqHealth, err
 }
+
+// fillEntityRequestLatencies fetches the request latencies of a single entity, see fillRequestLatencies.
+func (in *HealthService) fillEntityRequestLatencies(ctx context.Context, namespace, cluster, itemLabelSuffix, name, rateInterval string, queryTime time.Time, rqHealth *models.RequestHealth) {
+	criteria := NamespaceHealthCriteria{Cluster: cluster, Namespace: namespace, QueryTime: queryTime, RateInterval: rateInterval}
+	in.fillRequestLatencies(ctx, criteria, itemLabelSuffix, name, func(entity string) *models.RequestHealth {
+		if entity == name {
+			return rqHealth
+		}
+		return nil
+	})
+}
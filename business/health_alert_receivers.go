@@ -0,0 +1,155 @@
+package business
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util/httputil"
+)
+
+const defaultHealthAlertReceiverTimeout = 5 * time.Second
+
+// healthAlertReceiver posts health alerts to an endpoint.
+type healthAlertReceiver interface {
+	// repeatsFiring returns true when the firing alerts are posted by every health refresh, not only when notified
+	repeatsFiring() bool
+	send(ctx context.Context, alerts []models.HealthAlert) error
+}
+
+// httpHealthAlertReceiver posts health alerts to a webhook, a Slack-compatible incoming webhook or Alertmanager.
+type httpHealthAlertReceiver struct {
+	client       http.Client
+	receiverType string
+	url          string
+}
+
+// newHealthAlertReceiver creates a receiver using the auth, headers and timeout of the receiver configuration.
+func newHealthAlertReceiver(conf *config.Config, receiverConf config.HealthAlertReceiver) (*httpHealthAlertReceiver, error) {
+	timeout := receiverConf.Timeout
+	if timeout <= 0 {
+		timeout = defaultHealthAlertReceiverTimeout
+	}
+	var auth *config.Auth
+	if receiverConf.Auth.Type != "" && receiverConf.Auth.Type != config.AuthTypeNone {
+		auth = &receiverConf.Auth
+	}
+	transport, err := httputil.CreateTransport(conf, auth, &http.Transport{}, timeout, receiverConf.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the health alert receiver transport: %w", err)
+	}
+
+	url := receiverConf.URL
+	if receiverConf.Type == config.HealthAlertReceiverAlertmanager {
+		url = strings.TrimSuffix(url, "/") + "/api/v2/alerts"
+	}
+	return &httpHealthAlertReceiver{
+		client:       http.Client{Transport: transport, Timeout: timeout},
+		receiverType: receiverConf.Type,
+		url:          url,
+	}, nil
+}
+
+// repeatsFiring is true for Alertmanager, which resolves the alerts that are not posted again before its
+// resolve_timeout.
+func (r *httpHealthAlertReceiver) repeatsFiring() bool {
+	return r.receiverType == config.HealthAlertReceiverAlertmanager
+}
+
+func (r *httpHealthAlertReceiver) send(ctx context.Context, alerts []models.HealthAlert) error {
+	var payload any
+	switch r.receiverType {
+	case config.HealthAlertReceiverAlertmanager:
+		payload = alertmanagerAlerts(alerts)
+	case config.HealthAlertReceiverSlack:
+		payload = slackMessage(alerts)
+	default:
+		payload = models.HealthAlertNotification{Alerts: alerts}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health alert receiver returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// alertmanagerAlert is an alert of the Alertmanager v2 API.
+type alertmanagerAlert struct {
+	Annotations map[string]string `json:"annotations"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+	Labels      map[string]string `json:"labels"`
+	StartsAt    time.Time         `json:"startsAt"`
+}
+
+func alertmanagerAlerts(alerts []models.HealthAlert) []alertmanagerAlert {
+	amAlerts := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		annotations := map[string]string{"summary": healthAlertSummary(alert)}
+		if alert.Reason != "" {
+			annotations["description"] = alert.Reason
+		}
+		amAlerts = append(amAlerts, alertmanagerAlert{
+			Annotations: annotations,
+			EndsAt:      alert.EndsAt,
+			Labels: map[string]string{
+				"alertname": alert.Rule,
+				"cluster":   alert.Cluster,
+				"kind":      alert.Type,
+				"name":      alert.Name,
+				"namespace": alert.Namespace,
+				"severity":  alert.Severity,
+			},
+			StartsAt: alert.StartsAt,
+		})
+	}
+	return amAlerts
+}
+
+// slackMessage is the message of a Slack-compatible incoming webhook, with a line per alert.
+func slackMessage(alerts []models.HealthAlert) map[string]string {
+	lines := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		if alert.State == models.HealthAlertStateResolved {
+			lines = append(lines, "[RESOLVED] "+healthAlertSummary(alert))
+			continue
+		}
+		line := fmt.Sprintf("[FIRING:%s] %s", alert.Severity, healthAlertSummary(alert))
+		if alert.Reason != "" {
+			line += ": " + alert.Reason
+		}
+		lines = append(lines, line)
+	}
+	return map[string]string{"text": strings.Join(lines, "\n")}
+}
+
+// healthAlertSummary describes the entity and the status of a health alert.
+func healthAlertSummary(alert models.HealthAlert) string {
+	if alert.State == models.HealthAlertStateResolved {
+		return fmt.Sprintf("%s [%s] of namespace [%s] of cluster [%s] recovered (rule %s)", alert.Type, alert.Name, alert.Namespace, alert.Cluster, alert.Rule)
+	}
+	return fmt.Sprintf("%s [%s] of namespace [%s] of cluster [%s] is %s (rule %s)", alert.Type, alert.Name, alert.Namespace, alert.Cluster, alert.Status, alert.Rule)
+}
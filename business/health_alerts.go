@@ -0,0 +1,435 @@
+package business
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// healthAlertEntity is an app, service or workload whose health was computed by a health refresh.
+type healthAlertEntity struct {
+	healthType internalmetrics.HealthType
+	// labels are the label sets of the entity. An app has those of its workloads.
+	labels []map[string]string
+	name   string
+	status *models.CalculatedHealthStatus
+}
+
+// healthAlertEntities returns the entities of the health refreshed for a namespace along with their labels. The
+// workloads and services have their own labels, the apps those of their workloads.
+func healthAlertEntities(conf *config.Config, data *models.CachedHealthData, workloads models.Workloads, serviceLabels map[string]map[string]string) []healthAlertEntity {
+	workloadLabels := make(map[string]map[string]string, len(workloads))
+	appLabels := map[string][]map[string]string{}
+	for _, w := range workloads {
+		workloadLabels[w.Name] = w.Labels
+		if appLabelName, found := conf.GetAppLabelName(w.Labels); found && w.Labels[appLabelName] != "" {
+			app := w.Labels[appLabelName]
+			appLabels[app] = append(appLabels[app], w.Labels)
+		}
+	}
+
+	entities := make([]healthAlertEntity, 0, len(data.AppHealth)+len(data.ServiceHealth)+len(data.WorkloadHealth))
+	for name, health := range data.AppHealth {
+		if health != nil {
+			entities = append(entities, healthAlertEntity{healthType: internalmetrics.HealthTypeApp, labels: appLabels[name], name: name, status: health.Status})
+		}
+	}
+	for name, health := range data.ServiceHealth {
+		if health != nil {
+			entities = append(entities, healthAlertEntity{healthType: internalmetrics.HealthTypeService, labels: []map[string]string{serviceLabels[name]}, name: name, status: health.Status})
+		}
+	}
+	for name, health := range data.WorkloadHealth {
+		if health != nil {
+			entities = append(entities, healthAlertEntity{healthType: internalmetrics.HealthTypeWorkload, labels: []map[string]string{workloadLabels[name]}, name: name, status: health.Status})
+		}
+	}
+	return entities
+}
+
+// compiledHealthAlertSelector holds the pre-compiled regexes and label selectors of a HealthAlertSelector.
+type compiledHealthAlertSelector struct {
+	kind            *regexp.Regexp
+	labels          labels.Selector
+	name            *regexp.Regexp
+	namespace       *regexp.Regexp
+	namespaceLabels labels.Selector
+}
+
+func compileHealthAlertSelector(selector config.HealthAlertSelector) compiledHealthAlertSelector {
+	return compiledHealthAlertSelector{
+		kind:            compilePattern(selector.Kind, ".*"),
+		labels:          parseHealthAlertLabelSelector(selector.Labels),
+		name:            compilePattern(selector.Name, ".*"),
+		namespace:       compilePattern(selector.Namespace, ".*"),
+		namespaceLabels: parseHealthAlertLabelSelector(selector.NamespaceLabels),
+	}
+}
+
+// parseHealthAlertLabelSelector parses a label selector. An invalid selector matches nothing.
+func parseHealthAlertLabelSelector(selector string) labels.Selector {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		log.Warningf("Invalid health alert label selector '%s': %v. It matches nothing", selector, err)
+		return labels.Nothing()
+	}
+	return parsed
+}
+
+func (s compiledHealthAlertSelector) matches(namespace string, namespaceLabels map[string]string, entity healthAlertEntity) bool {
+	if !s.kind.MatchString(string(entity.healthType)) || !s.name.MatchString(entity.name) || !s.namespace.MatchString(namespace) {
+		return false
+	}
+	if !s.namespaceLabels.Matches(labels.Set(namespaceLabels)) {
+		return false
+	}
+	if s.labels.Empty() {
+		return true
+	}
+	for _, set := range entity.labels {
+		if s.labels.Matches(labels.Set(set)) {
+			return true
+		}
+	}
+	return false
+}
+
+// compiledHealthAlertRule is a HealthAlertRule with its defaults applied.
+type compiledHealthAlertRule struct {
+	forDuration time.Duration
+	// minStatus is the least severe status that fires the alert
+	minStatus models.HealthStatus
+	name      string
+	receivers []string
+	selector  compiledHealthAlertSelector
+}
+
+// compiledHealthAlertSilence is a HealthAlertSilence with its times parsed.
+type compiledHealthAlertSilence struct {
+	endsAt time.Time
+	// rules are the names of the silenced rules, all when empty
+	rules    map[string]bool
+	selector compiledHealthAlertSelector
+	startsAt time.Time
+}
+
+// healthAlertKey identifies the alert of a rule for an entity.
+type healthAlertKey struct {
+	entity entityKey
+	rule   string
+}
+
+// healthAlertState is the state of the alert of a rule for an entity that is unhealthy enough for the rule, or
+// that recovered while its recovery is not yet delivered to all the receivers notified of the firing alert.
+type healthAlertState struct {
+	// activeSince is when the entity became unhealthy enough for the rule
+	activeSince time.Time
+	// notified are the firing notifications delivered, by receiver name
+	notified map[string]healthAlertNotified
+	// resolved is the recovery to deliver, once the entity recovered or was removed
+	resolved *models.HealthAlert
+}
+
+// healthAlertNotified is a firing notification delivered to a receiver.
+type healthAlertNotified struct {
+	at     time.Time
+	status models.HealthStatus
+}
+
+// healthAlertBatch are the alerts of a health refresh of a namespace to post to a receiver.
+type healthAlertBatch struct {
+	alerts   []models.HealthAlert
+	at       time.Time
+	receiver string
+}
+
+// healthAlertQueueSize is the number of batches waiting to be posted. A refresh posts a batch per namespace
+// and receiver at most.
+const healthAlertQueueSize = 256
+
+// HealthAlerter notifies the alerts of the health_config.alerts rules from the health computed by the health
+// refreshes. It keeps the state of the alerts between the refreshes to de-duplicate the notifications. The
+// notifications are posted in the background: an alert is only notified once it was posted successfully, so the
+// alerts that could not be posted are posted again by the next refresh.
+type HealthAlerter struct {
+	logger         zerolog.Logger
+	queue          chan healthAlertBatch
+	receivers      map[string]healthAlertReceiver
+	repeatInterval time.Duration
+	rules          []compiledHealthAlertRule
+	silences       []compiledHealthAlertSilence
+	state          map[healthAlertKey]*healthAlertState
+	stateMutex     sync.Mutex
+}
+
+// NewHealthAlerter creates a HealthAlerter from health_config.alerts. The receivers that can't be created are
+// logged and skipped.
+func NewHealthAlerter(conf *config.Config) *HealthAlerter {
+	alertsConf := conf.HealthConfig.Alerts
+	alerter := &HealthAlerter{
+		logger:    log.Logger().With().Str("component", "health-alerter").Logger(),
+		queue:     make(chan healthAlertBatch, healthAlertQueueSize),
+		receivers: make(map[string]healthAlertReceiver, len(alertsConf.Receivers)),
+		state:     map[healthAlertKey]*healthAlertState{},
+	}
+	if !alertsConf.Enabled {
+		return alerter
+	}
+
+	receiverNames := make([]string, 0, len(alertsConf.Receivers))
+	for _, receiverConf := range alertsConf.Receivers {
+		receiver, err := newHealthAlertReceiver(conf, receiverConf)
+		if err != nil {
+			alerter.logger.Error().Err(err).Str("receiver", receiverConf.Name).Msg("Unable to create the health alert receiver")
+			continue
+		}
+		alerter.receivers[receiverConf.Name] = receiver
+		receiverNames = append(receiverNames, receiverConf.Name)
+	}
+	sort.Strings(receiverNames)
+
+	if alertsConf.RepeatInterval != "" {
+		if repeatInterval, err := alertsConf.RepeatInterval.ToDuration(); err == nil {
+			alerter.repeatInterval = repeatInterval
+		}
+	}
+
+	for _, rule := range alertsConf.Rules {
+		compiled := compiledHealthAlertRule{
+			minStatus: models.HealthStatusDegraded,
+			name:      rule.Name,
+			receivers: rule.Receivers,
+			selector:  compileHealthAlertSelector(rule.Selector),
+		}
+		if rule.For != "" {
+			compiled.forDuration, _ = rule.For.ToDuration()
+		}
+		if rule.Severity == config.HealthAlertSeverityCritical {
+			compiled.minStatus = models.HealthStatusFailure
+		}
+		if len(compiled.receivers) == 0 {
+			compiled.receivers = receiverNames
+		}
+		alerter.rules = append(alerter.rules, compiled)
+	}
+
+	for _, silence := range alertsConf.Silences {
+		compiled := compiledHealthAlertSilence{rules: map[string]bool{}, selector: compileHealthAlertSelector(silence.Selector)}
+		var err error
+		if compiled.endsAt, err = time.Parse(time.RFC3339, silence.EndsAt); err != nil {
+			alerter.logger.Warn().Err(err).Msg("Invalid health alert silence ends_at: the silence is ignored")
+			continue
+		}
+		if silence.StartsAt != "" {
+			if compiled.startsAt, err = time.Parse(time.RFC3339, silence.StartsAt); err != nil {
+				alerter.logger.Warn().Err(err).Msg("Invalid health alert silence starts_at: the silence is ignored")
+				continue
+			}
+		}
+		for _, rule := range silence.Rules {
+			compiled.rules[rule] = true
+		}
+		alerter.silences = append(alerter.silences, compiled)
+	}
+	return alerter
+}
+
+// Start posts the notifications queued by Observe until the context is done.
+func (a *HealthAlerter) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case batch := <-a.queue:
+				if err := a.receivers[batch.receiver].send(ctx, batch.alerts); err != nil {
+					a.logger.Warn().Err(err).Str("receiver", batch.receiver).Int("alerts", len(batch.alerts)).Msg("Unable to notify the health alerts: they are notified again by the next refresh")
+					continue
+				}
+				a.delivered(batch)
+			}
+		}
+	}()
+}
+
+// Observe evaluates the alert rules on the health of the entities of a namespace, computed by a health refresh at
+// now, and queues the resulting notifications. The alerts of the entities that are not seen anymore are resolved.
+// The notifications are dropped when the queue is full: they are evaluated again by the next refresh.
+func (a *HealthAlerter) Observe(cluster, namespace string, namespaceLabels map[string]string, entities []healthAlertEntity, now time.Time) {
+	for name, alerts := range a.evaluate(cluster, namespace, namespaceLabels, entities, now) {
+		select {
+		case a.queue <- healthAlertBatch{alerts: alerts, at: now, receiver: name}:
+		default:
+			a.logger.Warn().Str("receiver", name).Int("alerts", len(alerts)).Str("namespace", namespace).Str("cluster", cluster).Msg("The health alert queue is full: the notifications are dropped")
+		}
+	}
+}
+
+// evaluate updates the state of the alerts of the entities of a namespace and returns the alerts to post, by
+// receiver name. A firing alert is notified to a receiver once, then again when the status of the entity changes
+// or after the repeat interval. Silenced alerts are notified when the silence ends, if they still fire. Recoveries
+// are notified to the receivers that were notified of the firing alert, until they are delivered.
+func (a *HealthAlerter) evaluate(cluster, namespace string, namespaceLabels map[string]string, entities []healthAlertEntity, now time.Time) map[string][]models.HealthAlert {
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+
+	notifications := map[string][]models.HealthAlert{}
+	resolve := func(key healthAlertKey, state *healthAlertState, status models.HealthStatus) {
+		if len(state.notified) == 0 {
+			delete(a.state, key)
+			return
+		}
+		if state.resolved == nil {
+			notifiedStatus := models.HealthStatusNA
+			for _, notified := range state.notified {
+				if models.HealthStatusPriority(notified.status) > models.HealthStatusPriority(notifiedStatus) {
+					notifiedStatus = notified.status
+				}
+			}
+			endsAt := now
+			state.resolved = &models.HealthAlert{
+				Cluster:   key.entity.cluster,
+				EndsAt:    &endsAt,
+				Name:      key.entity.name,
+				Namespace: key.entity.namespace,
+				Rule:      key.rule,
+				Severity:  healthAlertSeverity(notifiedStatus),
+				StartsAt:  state.activeSince,
+				State:     models.HealthAlertStateResolved,
+				Status:    status,
+				Type:      string(key.entity.healthType),
+			}
+		}
+		for name := range state.notified {
+			notifications[name] = append(notifications[name], *state.resolved)
+		}
+	}
+
+	seen := map[healthAlertKey]bool{}
+	for _, rule := range a.rules {
+		for _, entity := range entities {
+			if !rule.selector.matches(namespace, namespaceLabels, entity) {
+				continue
+			}
+			key := healthAlertKey{entity: NewEntityKey(cluster, namespace, entity.healthType, entity.name), rule: rule.name}
+			seen[key] = true
+
+			status := models.HealthStatusNA
+			if entity.status != nil {
+				status = entity.status.Status
+			}
+			state := a.state[key]
+			if models.HealthStatusPriority(status) < models.HealthStatusPriority(rule.minStatus) {
+				if state != nil {
+					resolve(key, state, status)
+				}
+				continue
+			}
+
+			if state == nil || state.resolved != nil {
+				state = &healthAlertState{activeSince: now, notified: map[string]healthAlertNotified{}}
+				a.state[key] = state
+			}
+			if now.Sub(state.activeSince) < rule.forDuration || a.silenced(rule.name, namespace, namespaceLabels, entity, now) {
+				continue
+			}
+			alert := models.HealthAlert{
+				Cluster:   cluster,
+				Name:      entity.name,
+				Namespace: namespace,
+				Reason:    entity.status.Reason,
+				Rule:      rule.name,
+				Severity:  healthAlertSeverity(status),
+				Signal:    entity.status.Signal,
+				StartsAt:  state.activeSince,
+				State:     models.HealthAlertStateFiring,
+				Status:    status,
+				Type:      string(entity.healthType),
+			}
+			for _, name := range rule.receivers {
+				receiver, found := a.receivers[name]
+				if !found {
+					continue
+				}
+				notified, wasNotified := state.notified[name]
+				notify := !wasNotified || notified.status != status || (a.repeatInterval > 0 && now.Sub(notified.at) >= a.repeatInterval)
+				// Some receivers expect the firing alerts to be posted by every refresh
+				if notify || receiver.repeatsFiring() {
+					notifications[name] = append(notifications[name], alert)
+				}
+			}
+		}
+	}
+
+	// The entities of the namespace that are not seen anymore were removed
+	for key, state := range a.state {
+		if key.entity.cluster != cluster || key.entity.namespace != namespace || seen[key] {
+			continue
+		}
+		resolve(key, state, models.HealthStatusNA)
+	}
+	return notifications
+}
+
+// delivered records the notifications of a batch posted successfully to its receiver: the firing alerts are not
+// notified again to the receiver until their status changes or the repeat interval elapses, and the recoveries are
+// not notified again.
+func (a *HealthAlerter) delivered(batch healthAlertBatch) {
+	a.stateMutex.Lock()
+	defer a.stateMutex.Unlock()
+
+	for _, alert := range batch.alerts {
+		key := healthAlertKey{
+			entity: NewEntityKey(alert.Cluster, alert.Namespace, internalmetrics.HealthType(alert.Type), alert.Name),
+			rule:   alert.Rule,
+		}
+		state := a.state[key]
+		// The alert was replaced by a new one while it was posted
+		if state == nil || !state.activeSince.Equal(alert.StartsAt) {
+			continue
+		}
+		if alert.State == models.HealthAlertStateResolved {
+			delete(state.notified, batch.receiver)
+			if len(state.notified) == 0 {
+				delete(a.state, key)
+			}
+			continue
+		}
+		// A firing alert delivered after the entity recovered is resolved by the next refresh
+		state.notified[batch.receiver] = healthAlertNotified{at: batch.at, status: alert.Status}
+	}
+}
+
+// silenced returns true when a silence mutes the alert of the rule for the entity at now.
+func (a *HealthAlerter) silenced(rule, namespace string, namespaceLabels map[string]string, entity healthAlertEntity, now time.Time) bool {
+	for _, silence := range a.silences {
+		if len(silence.rules) > 0 && !silence.rules[rule] {
+			continue
+		}
+		if now.Before(silence.startsAt) || !now.Before(silence.endsAt) {
+			continue
+		}
+		if silence.selector.matches(namespace, namespaceLabels, entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// healthAlertSeverity returns the severity of the alert of an entity with a health status.
+func healthAlertSeverity(status models.HealthStatus) string {
+	if status == models.HealthStatusFailure {
+		return config.HealthAlertSeverityCritical
+	}
+	return config.HealthAlertSeverityWarning
+}
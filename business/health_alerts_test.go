@@ -0,0 +1,272 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+func newHealthAlertsTestConfig(rules ...config.HealthAlertRule) *config.Config {
+	conf := config.NewConfig()
+	conf.HealthConfig.Alerts = config.HealthAlerts{
+		Enabled: true,
+		Receivers: []config.HealthAlertReceiver{
+			{Name: "am", Type: config.HealthAlertReceiverAlertmanager, URL: "http://alertmanager:9093"},
+			{Name: "hook", Type: config.HealthAlertReceiverWebhook, URL: "http://hook"},
+		},
+		Rules: rules,
+	}
+	return conf
+}
+
+func workloadAlertEntity(name string, status models.HealthStatus, labels map[string]string) healthAlertEntity {
+	return healthAlertEntity{
+		healthType: internalmetrics.HealthTypeWorkload,
+		labels:     []map[string]string{labels},
+		name:       name,
+		status:     &models.CalculatedHealthStatus{Reason: "some reason", Signal: models.HealthSignalErrorRate, Status: status},
+	}
+}
+
+// evaluateAndDeliver evaluates the alerts like a health refresh and records them as posted successfully.
+func evaluateAndDeliver(alerter *HealthAlerter, cluster, namespace string, namespaceLabels map[string]string, entities []healthAlertEntity, now time.Time) map[string][]models.HealthAlert {
+	notifications := alerter.evaluate(cluster, namespace, namespaceLabels, entities, now)
+	for name, alerts := range notifications {
+		alerter.delivered(healthAlertBatch{alerts: alerts, at: now, receiver: name})
+	}
+	return notifications
+}
+
+func TestHealthAlerterTransitions(t *testing.T) {
+	require := require.New(t)
+	conf := newHealthAlertsTestConfig(config.HealthAlertRule{Name: "reviews", For: "5m", Selector: config.HealthAlertSelector{Name: "reviews-.*"}})
+	alerter := NewHealthAlerter(conf)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	observe := func(minutes int, entities ...healthAlertEntity) map[string][]models.HealthAlert {
+		return evaluateAndDeliver(alerter, "east", "bookinfo", nil, entities, start.Add(time.Duration(minutes)*time.Minute))
+	}
+
+	// Not unhealthy for long enough
+	require.Empty(observe(0, workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil)))
+	require.Empty(observe(3, workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil)))
+
+	notifications := observe(6, workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil), workloadAlertEntity("ratings-v1", models.HealthStatusFailure, nil))
+	require.Len(notifications["hook"], 1)
+	alert := notifications["hook"][0]
+	require.Equal(models.HealthAlertStateFiring, alert.State)
+	require.Equal("reviews-v1", alert.Name)
+	require.Equal(config.HealthAlertSeverityWarning, alert.Severity)
+	require.Equal(start, alert.StartsAt)
+	require.Equal("some reason", alert.Reason)
+	require.Len(notifications["am"], 1)
+
+	// De-duplicated, but Alertmanager is sent the firing alerts by every refresh
+	notifications = observe(9, workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil))
+	require.Empty(notifications["hook"])
+	require.Len(notifications["am"], 1)
+
+	// Notified again when the status changes
+	notifications = observe(12, workloadAlertEntity("reviews-v1", models.HealthStatusFailure, nil))
+	require.Len(notifications["hook"], 1)
+	require.Equal(config.HealthAlertSeverityCritical, notifications["hook"][0].Severity)
+
+	notifications = observe(15, workloadAlertEntity("reviews-v1", models.HealthStatusHealthy, nil))
+	require.Len(notifications["hook"], 1)
+	alert = notifications["hook"][0]
+	require.Equal(models.HealthAlertStateResolved, alert.State)
+	require.Equal(start.Add(15*time.Minute), *alert.EndsAt)
+	require.Equal(config.HealthAlertSeverityCritical, alert.Severity)
+
+	// Recovered before firing: nothing to resolve
+	require.Empty(observe(18, workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil)))
+	require.Empty(observe(21, workloadAlertEntity("reviews-v1", models.HealthStatusHealthy, nil)))
+}
+
+func TestHealthAlerterSelectorsAndSeverity(t *testing.T) {
+	require := require.New(t)
+	conf := newHealthAlertsTestConfig(config.HealthAlertRule{
+		Name:      "payments",
+		Receivers: []string{"hook"},
+		Selector:  config.HealthAlertSelector{Kind: "workload", Labels: "team=payments", NamespaceLabels: "env=prod"},
+		Severity:  config.HealthAlertSeverityCritical,
+	})
+	alerter := NewHealthAlerter(conf)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	entities := []healthAlertEntity{
+		workloadAlertEntity("checkout", models.HealthStatusFailure, map[string]string{"team": "payments"}),
+		workloadAlertEntity("cart", models.HealthStatusFailure, map[string]string{"team": "shop"}),
+		// Degraded is not critical
+		workloadAlertEntity("billing", models.HealthStatusDegraded, map[string]string{"team": "payments"}),
+	}
+	require.Empty(evaluateAndDeliver(alerter, "east", "shop", map[string]string{"env": "dev"}, entities, now))
+
+	notifications := evaluateAndDeliver(alerter, "east", "shop", map[string]string{"env": "prod"}, entities, now)
+	require.Len(notifications, 1)
+	require.Len(notifications["hook"], 1)
+	require.Equal("checkout", notifications["hook"][0].Name)
+
+	// Removed entities are resolved
+	notifications = evaluateAndDeliver(alerter, "east", "shop", map[string]string{"env": "prod"}, nil, now.Add(time.Minute))
+	require.Len(notifications["hook"], 1)
+	require.Equal(models.HealthAlertStateResolved, notifications["hook"][0].State)
+	require.Equal(models.HealthStatusNA, notifications["hook"][0].Status)
+}
+
+func TestHealthAlerterSilencesAndRepeat(t *testing.T) {
+	require := require.New(t)
+	conf := newHealthAlertsTestConfig(config.HealthAlertRule{Name: "all", Receivers: []string{"hook"}})
+	conf.HealthConfig.Alerts.RepeatInterval = "1h"
+	conf.HealthConfig.Alerts.Silences = []config.HealthAlertSilence{{
+		EndsAt:   "2026-01-01T00:30:00Z",
+		Selector: config.HealthAlertSelector{Name: "reviews-v1"},
+		StartsAt: "2026-01-01T00:00:00Z",
+	}}
+	alerter := NewHealthAlerter(conf)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	observe := func(minutes int) []models.HealthAlert {
+		entities := []healthAlertEntity{workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil)}
+		return evaluateAndDeliver(alerter, "east", "bookinfo", nil, entities, start.Add(time.Duration(minutes)*time.Minute))["hook"]
+	}
+
+	require.Empty(observe(0), "silenced")
+	require.Empty(observe(20), "silenced")
+	alerts := observe(30)
+	require.Len(alerts, 1, "notified when the silence ends")
+	require.Equal(start, alerts[0].StartsAt)
+	require.Empty(observe(60))
+	require.Len(observe(90), 1, "repeated")
+}
+
+func TestHealthAlerterRetriesFailedNotifications(t *testing.T) {
+	require := require.New(t)
+	conf := newHealthAlertsTestConfig(config.HealthAlertRule{Name: "all"})
+	alerter := NewHealthAlerter(conf)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	degraded := []healthAlertEntity{workloadAlertEntity("reviews-v1", models.HealthStatusDegraded, nil)}
+
+	// The post to the hook failed: only Alertmanager was notified
+	notifications := alerter.evaluate("east", "bookinfo", nil, degraded, start)
+	require.Len(notifications["hook"], 1)
+	alerter.delivered(healthAlertBatch{alerts: notifications["am"], at: start, receiver: "am"})
+
+	notifications = alerter.evaluate("east", "bookinfo", nil, degraded, start.Add(time.Minute))
+	require.Len(notifications["hook"], 1, "notified again by the next refresh")
+	alerter.delivered(healthAlertBatch{alerts: notifications["hook"], at: start.Add(time.Minute), receiver: "hook"})
+	require.Empty(alerter.evaluate("east", "bookinfo", nil, degraded, start.Add(2*time.Minute))["hook"])
+
+	// The recovery is notified until it is delivered
+	notifications = alerter.evaluate("east", "bookinfo", nil, nil, start.Add(3*time.Minute))
+	require.Len(notifications["hook"], 1)
+	require.Equal(models.HealthAlertStateResolved, notifications["hook"][0].State)
+	require.Len(notifications["am"], 1)
+	alerter.delivered(healthAlertBatch{alerts: notifications["am"], at: start.Add(3 * time.Minute), receiver: "am"})
+
+	notifications = alerter.evaluate("east", "bookinfo", nil, nil, start.Add(4*time.Minute))
+	require.Len(notifications["hook"], 1)
+	require.Empty(notifications["am"])
+	require.Equal(start.Add(3*time.Minute), *notifications["hook"][0].EndsAt)
+	alerter.delivered(healthAlertBatch{alerts: notifications["hook"], at: start.Add(4 * time.Minute), receiver: "hook"})
+	require.Empty(alerter.evaluate("east", "bookinfo", nil, nil, start.Add(5*time.Minute)))
+}
+
+func TestHealthAlerterPostsInBackground(t *testing.T) {
+	require := require.New(t)
+	posted := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	conf := config.NewConfig()
+	conf.HealthConfig.Alerts = config.HealthAlerts{
+		Enabled:   true,
+		Receivers: []config.HealthAlertReceiver{{Name: "hook", Type: config.HealthAlertReceiverWebhook, URL: server.URL}},
+		Rules:     []config.HealthAlertRule{{Name: "all"}},
+	}
+	alerter := NewHealthAlerter(conf)
+	alerter.Start(t.Context())
+
+	// Observe does not wait for the slow receiver
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	alerter.Observe("east", "bookinfo", nil, []healthAlertEntity{workloadAlertEntity("reviews-v1", models.HealthStatusFailure, nil)}, now)
+	<-posted
+	alerter.Observe("east", "bookinfo", nil, []healthAlertEntity{workloadAlertEntity("reviews-v1", models.HealthStatusFailure, nil)}, now.Add(time.Minute))
+	release <- struct{}{}
+	<-posted
+	release <- struct{}{}
+
+	require.Eventually(func() bool {
+		alerter.stateMutex.Lock()
+		defer alerter.stateMutex.Unlock()
+		for _, state := range alerter.state {
+			_, notified := state.notified["hook"]
+			return notified
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestHealthAlertReceivers(t *testing.T) {
+	require := require.New(t)
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(body)
+		requests <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	endsAt := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+	alerts := []models.HealthAlert{
+		{Cluster: "east", Name: "reviews-v1", Namespace: "bookinfo", Reason: "inbound error rate of 20.00% reached the failure threshold of 10%", Rule: "all", Severity: "critical", State: models.HealthAlertStateFiring, Status: models.HealthStatusFailure, Type: "workload"},
+		{Cluster: "east", EndsAt: &endsAt, Name: "ratings-v1", Namespace: "bookinfo", Rule: "all", Severity: "warning", State: models.HealthAlertStateResolved, Status: models.HealthStatusHealthy, Type: "workload"},
+	}
+	send := func(receiverType string) (*http.Request, []byte) {
+		receiver, err := newHealthAlertReceiver(config.NewConfig(), config.HealthAlertReceiver{
+			Headers: map[string]string{"X-Token": "secret"},
+			Name:    receiverType,
+			Type:    receiverType,
+			URL:     server.URL + "/",
+		})
+		require.NoError(err)
+		require.NoError(receiver.send(context.TODO(), alerts))
+		return <-requests, <-bodies
+	}
+
+	r, body := send(config.HealthAlertReceiverWebhook)
+	require.Equal("secret", r.Header.Get("X-Token"))
+	notification := models.HealthAlertNotification{}
+	require.NoError(json.Unmarshal(body, &notification))
+	require.Equal(alerts, notification.Alerts)
+
+	_, body = send(config.HealthAlertReceiverSlack)
+	message := map[string]string{}
+	require.NoError(json.Unmarshal(body, &message))
+	require.Equal("[FIRING:critical] workload [reviews-v1] of namespace [bookinfo] of cluster [east] is Failure (rule all): inbound error rate of 20.00% reached the failure threshold of 10%\n"+
+		"[RESOLVED] workload [ratings-v1] of namespace [bookinfo] of cluster [east] recovered (rule all)", message["text"])
+
+	r, body = send(config.HealthAlertReceiverAlertmanager)
+	require.Equal("/api/v2/alerts", r.URL.Path)
+	amAlerts := []alertmanagerAlert{}
+	require.NoError(json.Unmarshal(body, &amAlerts))
+	require.Len(amAlerts, 2)
+	require.Equal(map[string]string{"alertname": "all", "cluster": "east", "kind": "workload", "name": "reviews-v1", "namespace": "bookinfo", "severity": "critical"}, amAlerts[0].Labels)
+	require.Nil(amAlerts[0].EndsAt)
+	require.Equal(endsAt, *amAlerts[1].EndsAt)
+}
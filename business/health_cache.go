@@ -8,6 +8,8 @@ import (
 
 	prom "github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/zerolog"
+	core_v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -48,6 +50,7 @@ func NewHealthMonitor(
 		clientFactory:   clientFactory,
 		conf:            conf,
 		discovery:       discovery,
+		healthAlerter:   NewHealthAlerter(conf),
 		healthStatusExp: NewHealthStatusExporter(conf),
 		lastRun:         time.Time{},
 		logger:          log.Logger().With().Str("component", "health-monitor").Logger(),
@@ -61,6 +64,7 @@ type healthMonitor struct {
 	clientFactory   kubernetes.ClientFactory
 	conf            *config.Config
 	discovery       istio.MeshDiscovery
+	healthAlerter   *HealthAlerter
 	healthStatusExp *HealthStatusExporter
 	lastRun         time.Time
 	logger          zerolog.Logger
@@ -84,6 +88,9 @@ func (m *healthMonitor) Start(ctx context.Context) {
 		timeout = 10 * time.Minute
 	}
 	m.logger.Info().Msgf("Starting health monitor with refresh interval: %s, timeout: %s", m.conf.HealthConfig.Compute.RefreshInterval, m.conf.HealthConfig.Compute.Timeout)
+	if m.conf.HealthConfig.Alerts.Enabled && m.healthAlerter != nil {
+		m.healthAlerter.Start(ctx)
+	}
 
 	go func() {
 		// Prime the cache with an initial refresh (non-blocking to caller).
@@ -289,7 +296,7 @@ func (m *healthMonitor) refreshClusterHealth(ctx context.Context, layer *Layer,
 			return processedCount, errorCount
 		}
 
-		if err := m.refreshNamespaceHealth(ctx, layer, cluster, ns.Name, duration, workloadsByNamespace[ns.Name]); err != nil {
+		if err := m.refreshNamespaceHealth(ctx, layer, cluster, ns.Name, ns.Labels, duration, workloadsByNamespace[ns.Name]); err != nil {
 			log.Warn().Err(err).Str("namespace", ns.Name).Msg("Failed to refresh health for namespace")
 			errorCount++
 		} else {
@@ -333,7 +340,8 @@ func (m *healthMonitor) refreshClusterHealth(ctx context.Context, layer *Layer,
 
 // refreshNamespaceHealth computes and caches health for a single namespace.
 // workloads contains pre-fetched workloads for this namespace (may be nil if none exist).
-func (m *healthMonitor) refreshNamespaceHealth(ctx context.Context, layer *Layer, cluster, namespace, duration string, workloads models.Workloads) error {
+// namespaceLabels are used to select the namespaces of the health alert rules.
+func (m *healthMonitor) refreshNamespaceHealth(ctx context.Context, layer *Layer, cluster, namespace string, namespaceLabels map[string]string, duration string, workloads models.Workloads) error {
 	log := m.logger.With().
 		Str("cluster", cluster).
 		Str("namespace", namespace).
@@ -406,9 +414,34 @@ func (m *healthMonitor) refreshNamespaceHealth(ctx context.Context, layer *Layer
 		m.exportHealthStatusMetrics(cluster, namespace, appHealth, serviceHealth, workloadHealth)
 	}
 
+	if m.conf.HealthConfig.Alerts.Enabled && m.healthAlerter != nil {
+		entities := healthAlertEntities(m.conf, cachedData, workloads, m.serviceLabels(ctx, cluster, namespace))
+		m.healthAlerter.Observe(cluster, namespace, namespaceLabels, entities, queryTime)
+	}
+
 	return nil
 }
 
+// serviceLabels returns the labels of the services of a namespace, by service name, read from the kube cache.
+// Failures are logged: the health alert rules selecting labels then don't match the services.
+func (m *healthMonitor) serviceLabels(ctx context.Context, cluster, namespace string) map[string]map[string]string {
+	kubeCache, err := m.cache.GetKubeCache(cluster)
+	if err != nil {
+		m.logger.Debug().Err(err).Str("cluster", cluster).Msg("Unable to read the services for the health alerts")
+		return nil
+	}
+	list := &core_v1.ServiceList{}
+	if err := kubeCache.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		m.logger.Debug().Err(err).Str("cluster", cluster).Str("namespace", namespace).Msg("Unable to read the services for the health alerts")
+		return nil
+	}
+	serviceLabels := make(map[string]map[string]string, len(list.Items))
+	for _, svc := range list.Items {
+		serviceLabels[svc.Name] = svc.Labels
+	}
+	return serviceLabels
+}
+
 // exportHealthStatusMetrics exports health status for each individual item as Prometheus metrics.
 // Uses the pre-calculated Status field from the health data. Tracks seen entities for reconciliation.
 func (m *healthMonitor) exportHealthStatusMetrics(
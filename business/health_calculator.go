@@ -1,7 +1,10 @@
 package business
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/models"
@@ -35,24 +38,17 @@ func (c *HealthCalculator) CalculateAppHealth(
 		return CalculatedHealth{Status: models.HealthStatusNA}
 	}
 
-	// Get pre-compiled tolerances for this entity (with annotation override)
-	tolerances := c.matcher.GetCompiledTolerances(namespace, name, "app", annotations)
-
 	// Calculate request health status
-	reqStatus, errorRatio := c.calculateRequestStatus(health.Requests, tolerances)
+	calculated := c.calculateRequestHealth(namespace, name, "app", health.Requests, annotations)
+	mergeSLOStatuses(&calculated, health.SLOs)
 
 	// Merge with workload statuses (take the worst)
-	status := reqStatus
 	for _, ws := range health.WorkloadStatuses {
-		wsStatus := models.WorkloadStatusHealth(ws)
-		status = models.MergeHealthStatus(status, wsStatus)
+		mergeSignalStatus(&calculated, models.WorkloadStatusHealth(ws), models.HealthSignalWorkloadStatus, models.WorkloadStatusReason(ws))
+		mergeKubernetesStatus(&calculated, ws, c.conf.HealthConfig.Kubernetes)
 	}
 
-	return CalculatedHealth{
-		ErrorRatio:       errorRatio,
-		Status:           status,
-		TotalRequestRate: health.Requests.GetTotalRequestRate(),
-	}
+	return calculated
 }
 
 // CalculateServiceHealth calculates the overall health status for a service
@@ -65,17 +61,11 @@ func (c *HealthCalculator) CalculateServiceHealth(
 		return CalculatedHealth{Status: models.HealthStatusNA}
 	}
 
-	// Get pre-compiled tolerances for this entity (with annotation override)
-	tolerances := c.matcher.GetCompiledTolerances(namespace, name, "service", annotations)
-
 	// Calculate request health status
-	status, errorRatio := c.calculateRequestStatus(health.Requests, tolerances)
+	calculated := c.calculateRequestHealth(namespace, name, "service", health.Requests, annotations)
+	mergeSLOStatuses(&calculated, health.SLOs)
 
-	return CalculatedHealth{
-		ErrorRatio:       errorRatio,
-		Status:           status,
-		TotalRequestRate: health.Requests.GetTotalRequestRate(),
-	}
+	return calculated
 }
 
 // CalculateWorkloadHealth calculates the overall health status for a workload
@@ -88,29 +78,70 @@ func (c *HealthCalculator) CalculateWorkloadHealth(
 		return CalculatedHealth{Status: models.HealthStatusNA}
 	}
 
-	// Get pre-compiled tolerances for this entity (with annotation override)
-	tolerances := c.matcher.GetCompiledTolerances(namespace, name, "workload", annotations)
-
 	// Calculate request health status
-	reqStatus, errorRatio := c.calculateRequestStatus(health.Requests, tolerances)
+	calculated := c.calculateRequestHealth(namespace, name, "workload", health.Requests, annotations)
+	mergeSLOStatuses(&calculated, health.SLOs)
 
-	// Get workload status health
-	wsStatus := models.WorkloadStatusHealth(health.WorkloadStatus)
+	// Merge with workload status health (take the worst)
+	mergeSignalStatus(&calculated, models.WorkloadStatusHealth(health.WorkloadStatus), models.HealthSignalWorkloadStatus, models.WorkloadStatusReason(health.WorkloadStatus))
+	mergeKubernetesStatus(&calculated, health.WorkloadStatus, c.conf.HealthConfig.Kubernetes)
 
-	// Return the worse of the two
-	return CalculatedHealth{
+	return calculated
+}
+
+// calculateRequestHealth calculates the health of the requests of an entity, from their error rate using the
+// tolerances and from their latency using the latency thresholds.
+func (c *HealthCalculator) calculateRequestHealth(
+	namespace, name, kind string,
+	requests models.RequestHealth,
+	annotations map[string]string,
+) CalculatedHealth {
+	// Get pre-compiled tolerances for this entity (with annotation override)
+	tolerances := c.matcher.GetCompiledTolerances(namespace, name, kind, annotations)
+	errStatus, errorRatio, errReason := c.calculateRequestStatus(requests, tolerances)
+
+	calculated := CalculatedHealth{
 		ErrorRatio:       errorRatio,
-		Status:           models.MergeHealthStatus(wsStatus, reqStatus),
-		TotalRequestRate: health.Requests.GetTotalRequestRate(),
+		Status:           models.HealthStatusNA,
+		TotalRequestRate: requests.GetTotalRequestRate(),
+	}
+	mergeSignalStatus(&calculated, errStatus, models.HealthSignalErrorRate, errReason)
+
+	latencyStatus, latencyReason := c.calculateLatencyStatus(requests, c.matcher.GetCompiledLatencyThresholds(namespace, name, kind))
+	mergeSignalStatus(&calculated, latencyStatus, models.HealthSignalLatency, latencyReason)
+
+	return calculated
+}
+
+// mergeSLOStatuses merges the statuses of the SLOs of an entity, computed by the health refresh.
+func mergeSLOStatuses(calculated *CalculatedHealth, slos []models.SLOStatus) {
+	for _, slo := range slos {
+		mergeSignalStatus(calculated, slo.Status, models.HealthSignalSLO, slo.Reason)
 	}
 }
 
+// mergeSignalStatus sets the status calculated from a signal when it is worse than the current one, along with
+// the signal and the reason that explain it. Healthy statuses are not explained.
+func mergeSignalStatus(calculated *CalculatedHealth, status models.HealthStatus, signal models.HealthSignal, reason string) {
+	if models.HealthStatusPriority(status) <= models.HealthStatusPriority(calculated.Status) {
+		return
+	}
+	calculated.Status = status
+	if status == models.HealthStatusHealthy {
+		calculated.Reason = ""
+		calculated.Signal = ""
+		return
+	}
+	calculated.Reason = reason
+	calculated.Signal = signal
+}
+
 // calculateRequestStatus calculates health status from request data using pre-compiled tolerances.
-// Returns the worst status across all matching tolerances and the error ratio that caused it.
+// Returns the worst status across all matching tolerances, the error ratio that caused it and the explanation.
 func (c *HealthCalculator) calculateRequestStatus(
 	requests models.RequestHealth,
 	tolerances []CompiledTolerance,
-) (models.HealthStatus, float64) {
+) (models.HealthStatus, float64, string) {
 	if len(tolerances) == 0 {
 		// No tolerances configured, use simple error detection
 		return c.calculateSimpleRequestStatus(requests)
@@ -118,25 +149,28 @@ func (c *HealthCalculator) calculateRequestStatus(
 
 	worstStatus := models.HealthStatusNA
 	worstErrorRatio := float64(-1)
+	worstReason := ""
 	hasTraffic := false
 
 	// Process inbound traffic
-	inboundStatus, inboundRatio, inboundHasTraffic := c.processDirectionalTraffic(requests.Inbound, tolerances, "inbound")
+	inboundStatus, inboundRatio, inboundReason, inboundHasTraffic := c.processDirectionalTraffic(requests.Inbound, tolerances, "inbound")
 	if inboundHasTraffic {
 		hasTraffic = true
 		if models.HealthStatusPriority(inboundStatus) > models.HealthStatusPriority(worstStatus) {
 			worstStatus = inboundStatus
 			worstErrorRatio = inboundRatio
+			worstReason = inboundReason
 		}
 	}
 
 	// Process outbound traffic
-	outboundStatus, outboundRatio, outboundHasTraffic := c.processDirectionalTraffic(requests.Outbound, tolerances, "outbound")
+	outboundStatus, outboundRatio, outboundReason, outboundHasTraffic := c.processDirectionalTraffic(requests.Outbound, tolerances, "outbound")
 	if outboundHasTraffic {
 		hasTraffic = true
 		if models.HealthStatusPriority(outboundStatus) > models.HealthStatusPriority(worstStatus) {
 			worstStatus = outboundStatus
 			worstErrorRatio = outboundRatio
+			worstReason = outboundReason
 		}
 	}
 
@@ -146,7 +180,7 @@ func (c *HealthCalculator) calculateRequestStatus(
 		worstErrorRatio = 0
 	}
 
-	return worstStatus, worstErrorRatio
+	return worstStatus, worstErrorRatio, worstReason
 }
 
 // processDirectionalTraffic processes traffic for a specific direction (inbound/outbound)
@@ -154,9 +188,10 @@ func (c *HealthCalculator) processDirectionalTraffic(
 	traffic map[string]map[string]float64,
 	tolerances []CompiledTolerance,
 	direction string,
-) (models.HealthStatus, float64, bool) {
+) (models.HealthStatus, float64, string, bool) {
 	worstStatus := models.HealthStatusNA
 	worstErrorRatio := float64(-1)
+	worstReason := ""
 	hasTraffic := false
 
 	// For each protocol in traffic
@@ -189,11 +224,85 @@ func (c *HealthCalculator) processDirectionalTraffic(
 			if models.HealthStatusPriority(status) > models.HealthStatusPriority(worstStatus) {
 				worstStatus = status
 				worstErrorRatio = errorRatio
+				worstReason = errorRateReason(direction, protocol, errorRatio, status, tol.Degraded, tol.Failure)
+			}
+		}
+	}
+
+	return worstStatus, worstErrorRatio, worstReason, hasTraffic
+}
+
+// errorRateReason explains the status calculated from the error rate of the traffic of a direction and protocol.
+func errorRateReason(direction, protocol string, errorRatio float64, status models.HealthStatus, degraded, failure float32) string {
+	prefix := strings.TrimSpace(direction + " " + protocol)
+	switch status {
+	case models.HealthStatusFailure:
+		return fmt.Sprintf("%s error rate of %.2f%% reached the failure threshold of %v%%", prefix, errorRatio, failure)
+	case models.HealthStatusDegraded:
+		return fmt.Sprintf("%s error rate of %.2f%% reached the degraded threshold of %v%%", prefix, errorRatio, degraded)
+	}
+	return ""
+}
+
+// calculateLatencyStatus calculates health status from the request latencies using pre-compiled latency
+// thresholds. Returns the worst status across all matching thresholds and the explanation.
+func (c *HealthCalculator) calculateLatencyStatus(
+	requests models.RequestHealth,
+	thresholds []CompiledLatencyThreshold,
+) (models.HealthStatus, string) {
+	worstStatus := models.HealthStatusNA
+	worstReason := ""
+	for _, direction := range []string{"inbound", "outbound"} {
+		latencies := requests.InboundLatency
+		if direction == "outbound" {
+			latencies = requests.OutboundLatency
+		}
+		for protocol, quantiles := range latencies {
+			for _, threshold := range thresholds {
+				if !threshold.Direction.MatchString(direction) || !threshold.Protocol.MatchString(protocol) {
+					continue
+				}
+				latency, ok := quantiles[threshold.Quantile]
+				if !ok {
+					continue
+				}
+				status := applyLatencyThresholds(latency, threshold)
+				if models.HealthStatusPriority(status) > models.HealthStatusPriority(worstStatus) {
+					worstStatus = status
+					worstReason = latencyReason(direction, protocol, latency, status, threshold)
+				}
 			}
 		}
 	}
+	return worstStatus, worstReason
+}
+
+// applyLatencyThresholds determines the health status based on a request latency and its thresholds.
+// Unlike the error tolerances, a threshold of 0 is not set.
+func applyLatencyThresholds(latency float64, threshold CompiledLatencyThreshold) models.HealthStatus {
+	if threshold.Failure > 0 && latency >= float64(threshold.Failure) {
+		return models.HealthStatusFailure
+	}
+	if threshold.Degraded > 0 && latency >= float64(threshold.Degraded) {
+		return models.HealthStatusDegraded
+	}
+	return models.HealthStatusHealthy
+}
 
-	return worstStatus, worstErrorRatio, hasTraffic
+// latencyReason explains the status calculated from the latency of the traffic of a direction and protocol.
+func latencyReason(direction, protocol string, latency float64, status models.HealthStatus, threshold CompiledLatencyThreshold) string {
+	percentile := threshold.Quantile
+	if q, err := strconv.ParseFloat(threshold.Quantile, 64); err == nil {
+		percentile = strconv.FormatFloat(q*100, 'f', -1, 64)
+	}
+	prefix := strings.TrimSpace(fmt.Sprintf("%s %s p%s", direction, protocol, percentile))
+	switch status {
+	case models.HealthStatusFailure:
+		return fmt.Sprintf("%s latency of %.0fms reached the failure threshold of %vms", prefix, latency, threshold.Failure)
+	case models.HealthStatusDegraded:
+		return fmt.Sprintf("%s latency of %.0fms reached the degraded threshold of %vms", prefix, latency, threshold.Degraded)
+	}
+	return ""
 }
 
 // aggregateMatchingCodes sums up request counts, identifying which match the code pattern as errors.
@@ -232,22 +341,22 @@ func (c *HealthCalculator) applyThresholds(errorRatio, degraded, failure float64
 }
 
 // calculateSimpleRequestStatus calculates a simple status when no tolerances are configured
-func (c *HealthCalculator) calculateSimpleRequestStatus(requests models.RequestHealth) (models.HealthStatus, float64) {
+func (c *HealthCalculator) calculateSimpleRequestStatus(requests models.RequestHealth) (models.HealthStatus, float64, string) {
 	errorRatio := requests.GetErrorRatio()
 
 	if errorRatio < 0 {
-		return models.HealthStatusNA, -1
+		return models.HealthStatusNA, -1, ""
 	}
 
 	// Use default thresholds (matching AddHealthDefault in config.go)
 	errorPct := errorRatio * 100
 	if errorPct >= 10 {
-		return models.HealthStatusFailure, errorPct
+		return models.HealthStatusFailure, errorPct, errorRateReason("", "", errorPct, models.HealthStatusFailure, 0.1, 10)
 	}
 	if errorPct >= 0.1 {
-		return models.HealthStatusDegraded, errorPct
+		return models.HealthStatusDegraded, errorPct, errorRateReason("", "", errorPct, models.HealthStatusDegraded, 0.1, 10)
 	}
-	return models.HealthStatusHealthy, errorPct
+	return models.HealthStatusHealthy, errorPct, ""
 }
 
 // GetCompiledTolerancesForDirection returns pre-compiled tolerances for an entity filtered by direction.
@@ -655,3 +655,87 @@ func TestCalculateRequestFailureTakesPrecedenceOverDegradedWorkload(t *testing.T
 	// Request failure should take precedence over workload degraded
 	assert.Equal(t, models.HealthStatusFailure, result.Status)
 }
+
+func TestCalculateLatencyThresholds(t *testing.T) {
+	conf := setupTestConfig()
+	conf.HealthConfig.Rate[1].Latency = []config.LatencyThreshold{
+		{Protocol: "http", Direction: "inbound", Degraded: 500, Failure: 2000},
+		{Protocol: "http", Direction: "outbound", Failure: 5000, Quantile: 0.99},
+	}
+	calc := NewHealthCalculator(conf)
+	assert.Equal(t, []string{"0.95", "0.99"}, calc.matcher.LatencyQuantiles())
+
+	// Requests answered without errors, but slowly
+	health := &models.ServiceHealth{
+		Requests: models.RequestHealth{
+			Inbound:        map[string]map[string]float64{"http": {"200": 100}},
+			InboundLatency: map[string]map[string]float64{"http": {"0.95": 8000}},
+		},
+	}
+	result := calc.CalculateServiceHealth("test", "my-service", health, nil)
+	assert.Equal(t, models.HealthStatusFailure, result.Status)
+	assert.Equal(t, models.HealthSignalLatency, result.Signal)
+	assert.Equal(t, "inbound http p95 latency of 8000ms reached the failure threshold of 2000ms", result.Reason)
+	assert.Equal(t, float64(0), result.ErrorRatio)
+
+	health.Requests.InboundLatency["http"]["0.95"] = 800
+	result = calc.CalculateServiceHealth("test", "my-service", health, nil)
+	assert.Equal(t, models.HealthStatusDegraded, result.Status)
+	assert.Equal(t, models.HealthSignalLatency, result.Signal)
+
+	health.Requests.InboundLatency["http"]["0.95"] = 100
+	result = calc.CalculateServiceHealth("test", "my-service", health, nil)
+	assert.Equal(t, models.HealthStatusHealthy, result.Status)
+	assert.Empty(t, result.Signal)
+	assert.Empty(t, result.Reason)
+
+	// The outbound threshold has no degraded threshold, and applies to its quantile only
+	wkHealth := &models.WorkloadHealth{
+		Requests: models.RequestHealth{
+			Outbound:        map[string]map[string]float64{"http": {"200": 100}},
+			OutboundLatency: map[string]map[string]float64{"http": {"0.95": 9000, "0.99": 3000}},
+		},
+		WorkloadStatus: &models.WorkloadStatus{Name: "wk", DesiredReplicas: 1, CurrentReplicas: 1, AvailableReplicas: 1, SyncedProxies: 1},
+	}
+	result = calc.CalculateWorkloadHealth("test", "wk", wkHealth, nil)
+	assert.Equal(t, models.HealthStatusHealthy, result.Status)
+
+	wkHealth.Requests.OutboundLatency["http"]["0.99"] = 6000
+	result = calc.CalculateWorkloadHealth("test", "wk", wkHealth, nil)
+	assert.Equal(t, models.HealthStatusFailure, result.Status)
+	assert.Equal(t, "outbound http p99 latency of 6000ms reached the failure threshold of 5000ms", result.Reason)
+}
+
+func TestCalculateExplainsWorstSignal(t *testing.T) {
+	conf := setupTestConfig()
+	conf.HealthConfig.Rate[1].Latency = []config.LatencyThreshold{{Degraded: 500, Failure: 2000}}
+	calc := NewHealthCalculator(conf)
+
+	// Errors are failing while latency is degraded
+	health := &models.AppHealth{
+		Requests: models.RequestHealth{
+			Inbound:        map[string]map[string]float64{"http": {"200": 70, "500": 30}},
+			InboundLatency: map[string]map[string]float64{"http": {"0.95": 600}},
+		},
+		WorkloadStatuses: []*models.WorkloadStatus{
+			{Name: "app-v1", DesiredReplicas: 2, CurrentReplicas: 2, AvailableReplicas: 1, SyncedProxies: 2},
+		},
+	}
+	result := calc.CalculateAppHealth("test", "app", health, nil)
+	assert.Equal(t, models.HealthStatusFailure, result.Status)
+	assert.Equal(t, models.HealthSignalErrorRate, result.Signal)
+	assert.Equal(t, "inbound http error rate of 30.00% reached the failure threshold of 20%", result.Reason)
+
+	// Without errors, the degraded latency is reported first
+	health.Requests.Inbound["http"] = map[string]float64{"200": 100}
+	result = calc.CalculateAppHealth("test", "app", health, nil)
+	assert.Equal(t, models.HealthStatusDegraded, result.Status)
+	assert.Equal(t, models.HealthSignalLatency, result.Signal)
+
+	// Then the workloads
+	health.Requests.InboundLatency = nil
+	result = calc.CalculateAppHealth("test", "app", health, nil)
+	assert.Equal(t, models.HealthStatusDegraded, result.Status)
+	assert.Equal(t, models.HealthSignalWorkloadStatus, result.Signal)
+	assert.Equal(t, "workload [app-v1] has 2 desired, 2 current and 1 available replicas, 2 synced proxies", result.Reason)
+}
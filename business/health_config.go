@@ -2,12 +2,14 @@ package business
 
 import (
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 )
 
 // HealthRateMatcher provides methods to match health rate configuration to entities.
@@ -16,11 +18,23 @@ type HealthRateMatcher struct {
 	cache map[int]*compiledRate // keyed by index in conf.HealthConfig.Rate
 	conf  *config.Config
 	mu    sync.RWMutex
+	// latencyQuantiles are the distinct quantiles of all the latency thresholds, sorted
+	latencyQuantiles []string
+	slos             []compiledSLO
+}
+
+// compiledSLO holds pre-compiled regex patterns for the target of an SLO config
+type compiledSLO struct {
+	kind      *regexp.Regexp
+	name      *regexp.Regexp
+	namespace *regexp.Regexp
+	slo       config.SLO
 }
 
 // compiledRate holds pre-compiled regex patterns for a Rate config
 type compiledRate struct {
 	kind      *regexp.Regexp
+	latency   []CompiledLatencyThreshold
 	name      *regexp.Regexp
 	namespace *regexp.Regexp
 	tolerance []CompiledTolerance
@@ -36,6 +50,16 @@ type CompiledTolerance struct {
 	Protocol  *regexp.Regexp
 }
 
+// CompiledLatencyThreshold holds pre-compiled regex patterns for a LatencyThreshold config.
+type CompiledLatencyThreshold struct {
+	Degraded  float32
+	Direction *regexp.Regexp
+	Failure   float32
+	Protocol  *regexp.Regexp
+	// Quantile is formatted as in the Prometheus queries and the request health, e.g. "0.95"
+	Quantile string
+}
+
 // NewHealthRateMatcher creates a new HealthRateMatcher with the given config.
 // It pre-compiles all regex patterns from the health configuration.
 func NewHealthRateMatcher(conf *config.Config) *HealthRateMatcher {
@@ -53,11 +77,38 @@ func (m *HealthRateMatcher) compileAllPatterns() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	quantiles := map[string]bool{}
 	for i := range m.conf.HealthConfig.Rate {
 		m.cache[i] = m.compileRate(&m.conf.HealthConfig.Rate[i])
+		for _, latency := range m.cache[i].latency {
+			quantiles[latency.Quantile] = true
+		}
+	}
+	m.latencyQuantiles = make([]string, 0, len(quantiles))
+	for quantile := range quantiles {
+		m.latencyQuantiles = append(m.latencyQuantiles, quantile)
+	}
+	sort.Strings(m.latencyQuantiles)
+
+	m.slos = make([]compiledSLO, 0, len(m.conf.HealthConfig.SLO))
+	for _, slo := range m.conf.HealthConfig.SLO {
+		m.slos = append(m.slos, compiledSLO{
+			kind:      compilePattern(slo.Target.Kind, ".*"),
+			name:      compilePattern(slo.Target.Name, ".*"),
+			namespace: compilePattern(slo.Target.Namespace, ".*"),
+			slo:       withSLODefaults(slo),
+		})
 	}
 }
 
+// withSLODefaults returns the SLO with the default values of its unset fields.
+func withSLODefaults(slo config.SLO) config.SLO {
+	if slo.Window == "" {
+		slo.Window = config.DefaultSLOWindow
+	}
+	return slo
+}
+
 // compileRate compiles regex patterns for a single Rate config
 func (m *HealthRateMatcher) compileRate(rate *config.Rate) *compiledRate {
 	compiled := &compiledRate{
@@ -65,11 +116,15 @@ func (m *HealthRateMatcher) compileRate(rate *config.Rate) *compiledRate {
 		kind:      compilePattern(rate.Kind, ".*"),
 		name:      compilePattern(rate.Name, ".*"),
 		tolerance: make([]CompiledTolerance, len(rate.Tolerance)),
+		latency:   make([]CompiledLatencyThreshold, len(rate.Latency)),
 	}
 
 	for i, tol := range rate.Tolerance {
 		compiled.tolerance[i] = CompileTolerance(tol)
 	}
+	for i, latency := range rate.Latency {
+		compiled.latency[i] = CompileLatencyThreshold(latency)
+	}
 
 	return compiled
 }
@@ -86,6 +141,21 @@ func CompileTolerance(tol config.Tolerance) CompiledTolerance {
 	}
 }
 
+// CompileLatencyThreshold compiles a config.LatencyThreshold into a CompiledLatencyThreshold.
+func CompileLatencyThreshold(latency config.LatencyThreshold) CompiledLatencyThreshold {
+	quantile := latency.Quantile
+	if quantile == 0 {
+		quantile = config.DefaultLatencyQuantile
+	}
+	return CompiledLatencyThreshold{
+		Degraded:  latency.Degraded,
+		Direction: compilePattern(latency.Direction, ".*"),
+		Failure:   latency.Failure,
+		Protocol:  compilePattern(latency.Protocol, ".*"),
+		Quantile:  strconv.FormatFloat(float64(quantile), 'f', -1, 32),
+	}
+}
+
 // compilePattern compiles a regex pattern, using defaultPattern if empty
 func compilePattern(pattern, defaultPattern string) *regexp.Regexp {
 	if pattern == "" {
@@ -216,6 +286,72 @@ func parseFloat32(s string, result *float32) (bool, error) {
 // HealthAnnotationKey is the annotation key for health rate configuration
 const HealthAnnotationKey = "health.kiali.io/rate"
 
+// ParseSLOAnnotation parses an SLO annotation value and returns the SLOs.
+// Annotation format: "name,type,objective[,window[,latency_threshold]]" (semicolon-separated for multiple)
+// Example: "checkout-availability,availability,99.9;checkout-latency,latency,99,7d,500"
+// Invalid SLOs are logged and skipped.
+func ParseSLOAnnotation(annotation string) []config.SLO {
+	var slos []config.SLO
+	for _, part := range strings.Split(annotation, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		fields := strings.Split(part, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if len(fields) < 3 || len(fields) > 5 {
+			log.Warningf("Invalid SLO annotation format '%s': expected name,type,objective[,window[,latency_threshold]]", part)
+			continue
+		}
+
+		slo := config.SLO{Name: fields[0], Type: fields[1]}
+		objective, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			log.Warningf("Invalid SLO annotation objective '%s': %v", fields[2], err)
+			continue
+		}
+		slo.Objective = objective
+		if len(fields) > 3 {
+			slo.Window = fields[3]
+		}
+		if len(fields) > 4 {
+			if _, err := parseFloat32(fields[4], &slo.LatencyThreshold); err != nil {
+				log.Warningf("Invalid SLO annotation latency threshold '%s': %v", fields[4], err)
+				continue
+			}
+		}
+		if err := config.ValidateSLO(slo); err != nil {
+			log.Warningf("Invalid SLO annotation '%s': %v", part, err)
+			continue
+		}
+		slos = append(slos, withSLODefaults(slo))
+	}
+	return slos
+}
+
+// GetSLOs returns the SLOs of an entity: all the configured SLOs targeting it, unless the entity has a valid SLO
+// annotation, whose SLOs replace them.
+func (m *HealthRateMatcher) GetSLOs(namespace, name, kind string, annotations map[string]string) []config.SLO {
+	if annotationValue := annotations[string(models.SLOHealthAnnotation)]; annotationValue != "" {
+		if slos := ParseSLOAnnotation(annotationValue); len(slos) > 0 {
+			return slos
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var slos []config.SLO
+	for _, compiled := range m.slos {
+		if compiled.namespace.MatchString(namespace) && compiled.name.MatchString(name) && compiled.kind.MatchString(kind) {
+			slos = append(slos, compiled.slo)
+		}
+	}
+	return slos
+}
+
 // GetCompiledTolerances returns pre-compiled tolerances for an entity, with annotation overrides.
 // This is the preferred method for health calculation as it avoids regex recompilation.
 func (m *HealthRateMatcher) GetCompiledTolerances(namespace, name, kind string, annotations map[string]string) []CompiledTolerance {
@@ -237,6 +373,30 @@ func (m *HealthRateMatcher) GetCompiledTolerances(namespace, name, kind string,
 
 // getCompiledTolerancesForEntity returns the pre-compiled tolerances for an entity from the cache.
 func (m *HealthRateMatcher) getCompiledTolerancesForEntity(namespace, name, kind string) []CompiledTolerance {
+	if compiled := m.getCompiledRateForEntity(namespace, name, kind); compiled != nil {
+		return compiled.tolerance
+	}
+	return nil
+}
+
+// GetCompiledLatencyThresholds returns the pre-compiled latency thresholds for an entity. Health annotations
+// only override the tolerances: the latency thresholds always come from the configuration.
+func (m *HealthRateMatcher) GetCompiledLatencyThresholds(namespace, name, kind string) []CompiledLatencyThreshold {
+	if compiled := m.getCompiledRateForEntity(namespace, name, kind); compiled != nil {
+		return compiled.latency
+	}
+	return nil
+}
+
+// LatencyQuantiles returns the quantiles of the request duration used by the latency thresholds, if any.
+func (m *HealthRateMatcher) LatencyQuantiles() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.latencyQuantiles
+}
+
+// getCompiledRateForEntity returns the pre-compiled rate matching an entity from the cache.
+func (m *HealthRateMatcher) getCompiledRateForEntity(namespace, name, kind string) *compiledRate {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -247,16 +407,13 @@ func (m *HealthRateMatcher) getCompiledTolerancesForEntity(namespace, name, kind
 			compiled.namespace.MatchString(namespace) &&
 			compiled.name.MatchString(name) &&
 			compiled.kind.MatchString(kind) {
-			return compiled.tolerance
+			return compiled
 		}
 	}
 
-	// Fall back to the last rate's tolerances
+	// Fall back to the last rate
 	if len(m.conf.HealthConfig.Rate) > 0 {
-		lastIdx := len(m.conf.HealthConfig.Rate) - 1
-		if compiled := m.cache[lastIdx]; compiled != nil {
-			return compiled.tolerance
-		}
+		return m.cache[len(m.conf.HealthConfig.Rate)-1]
 	}
 
 	return nil
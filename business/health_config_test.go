@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kiali/kiali/config"
 )
@@ -128,3 +129,46 @@ func TestCompilePatternWithInvalidRegex(t *testing.T) {
 	assert.NotNil(t, pattern)
 	assert.True(t, pattern.MatchString("anything"))
 }
+
+func TestGetSLOs(t *testing.T) {
+	conf := config.NewConfig()
+	conf.HealthConfig.SLO = []config.SLO{
+		{Name: "all-availability", Objective: 99, Type: config.SLOTypeAvailability},
+		{Name: "reviews-latency", Objective: 95, Type: config.SLOTypeLatency, LatencyThreshold: 250, Window: "7d", Target: config.SLOTarget{Kind: "service", Name: "reviews"}},
+	}
+	matcher := NewHealthRateMatcher(conf)
+
+	slos := matcher.GetSLOs("bookinfo", "reviews", "service", nil)
+	require.Len(t, slos, 2)
+	assert.Equal(t, config.DefaultSLOWindow, slos[0].Window)
+	assert.Equal(t, "7d", slos[1].Window)
+
+	slos = matcher.GetSLOs("bookinfo", "reviews", "workload", nil)
+	require.Len(t, slos, 1)
+	assert.Equal(t, "all-availability", slos[0].Name)
+
+	// The SLOs of the annotation replace the configured ones
+	slos = matcher.GetSLOs("bookinfo", "reviews", "service", map[string]string{"health.kiali.io/slo": "checkout,availability,99.9;fast,latency,99,1d,500"})
+	require.Len(t, slos, 2)
+	assert.Equal(t, config.SLO{Name: "checkout", Objective: 99.9, Type: config.SLOTypeAvailability, Window: config.DefaultSLOWindow}, slos[0])
+	assert.Equal(t, config.SLO{Name: "fast", Objective: 99, Type: config.SLOTypeLatency, Window: "1d", LatencyThreshold: 500}, slos[1])
+
+	// Invalid annotations are ignored
+	slos = matcher.GetSLOs("bookinfo", "reviews", "workload", map[string]string{"health.kiali.io/slo": "slow,latency,99"})
+	require.Len(t, slos, 1)
+	assert.Equal(t, "all-availability", slos[0].Name)
+}
+
+func TestParseSLOAnnotation(t *testing.T) {
+	assert.Len(t, ParseSLOAnnotation("a,availability,99.9;b,latency,99,7d,250"), 2)
+	// Missing fields
+	assert.Len(t, ParseSLOAnnotation("a,availability"), 0)
+	// Objective out of range
+	assert.Len(t, ParseSLOAnnotation("a,availability,100"), 0)
+	// Unknown type
+	assert.Len(t, ParseSLOAnnotation("a,throughput,99"), 0)
+	// Invalid window
+	assert.Len(t, ParseSLOAnnotation("a,availability,99,month"), 0)
+	// Mixed valid and invalid
+	assert.Len(t, ParseSLOAnnotation("a,availability,99;invalid;b,availability,95"), 2)
+}
@@ -0,0 +1,184 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+const (
+	crashLoopBackOffReason = "CrashLoopBackOff"
+	oomKilledReason        = "OOMKilled"
+	// unhealthyEventReason is the reason of the Events of the kubelet reporting failed probes
+	unhealthyEventReason = "Unhealthy"
+)
+
+// castWorkloadStatuses returns the statuses of the workloads of a namespace along with their Kubernetes signals,
+// when health_config.kubernetes is enabled. The Warning Events of the namespace are read once from the cache.
+func (in *HealthService) castWorkloadStatuses(ctx context.Context, cluster, namespace string, ws models.Workloads) []*models.WorkloadStatus {
+	statuses := ws.CastWorkloadStatuses()
+	k8sHealth := in.conf.HealthConfig.Kubernetes
+	if !k8sHealth.Enabled || len(ws) == 0 {
+		return statuses
+	}
+	window, err := k8sHealth.RecentWindow.ToDuration()
+	if err != nil {
+		log.FromContext(ctx).Debug().Msgf("Invalid health_config.kubernetes.recent_window [%s]: %v", k8sHealth.RecentWindow, err)
+		return statuses
+	}
+
+	since := time.Now().Add(-window)
+	var events []core_v1.Event
+	if k8sHealth.FailedProbes.Degraded > 0 || k8sHealth.FailedProbes.Failure > 0 || k8sHealth.WarningEvents.Degraded > 0 || k8sHealth.WarningEvents.Failure > 0 {
+		events = in.recentWarningEvents(ctx, cluster, namespace, since)
+	}
+	for i, w := range ws {
+		statuses[i].Kubernetes = kubernetesSignals(w, events, since)
+	}
+	return statuses
+}
+
+// castWorkloadStatus returns the status of a workload along with its Kubernetes signals.
+func (in *HealthService) castWorkloadStatus(ctx context.Context, cluster, namespace string, w *models.Workload) *models.WorkloadStatus {
+	return in.castWorkloadStatuses(ctx, cluster, namespace, models.Workloads{w})[0]
+}
+
+// recentWarningEvents returns the Warning Events of a namespace last seen after since. Failures are logged: the
+// health is then calculated without the Events.
+func (in *HealthService) recentWarningEvents(ctx context.Context, cluster, namespace string, since time.Time) []core_v1.Event {
+	kubeCache, err := in.kialiCache.GetKubeCache(cluster)
+	if err != nil {
+		log.FromContext(ctx).Debug().Msgf("Unable to read the Events of namespace [%s] of cluster [%s]: %v", namespace, cluster, err)
+		return nil
+	}
+	list := &core_v1.EventList{}
+	if err := kubeCache.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Debug().Msgf("Unable to read the Events of namespace [%s] of cluster [%s]: %v", namespace, cluster, err)
+		return nil
+	}
+
+	var events []core_v1.Event
+	for _, event := range list.Items {
+		if event.Type == core_v1.EventTypeWarning && eventLastSeen(event).After(since) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// eventLastSeen returns when an Event was last observed, whether it is recorded with the core or the events API.
+func eventLastSeen(event core_v1.Event) time.Time {
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	return event.EventTime.Time
+}
+
+// eventCount returns how many times an Event was observed.
+func eventCount(event core_v1.Event) int32 {
+	if event.Series != nil && event.Series.Count > 0 {
+		return event.Series.Count
+	}
+	if event.Count > 0 {
+		return event.Count
+	}
+	return 1
+}
+
+// kubernetesSignals returns the Kubernetes signals of a workload from the state of the containers of its pods and
+// from the recent Warning Events involving its pods or the workload itself.
+func kubernetesSignals(w *models.Workload, events []core_v1.Event, since time.Time) *models.KubernetesSignals {
+	signals := &models.KubernetesSignals{}
+	podNames := make(map[string]bool, len(w.Pods))
+	for _, pod := range w.Pods {
+		podNames[pod.Name] = true
+		for _, containers := range [][]*models.ContainerInfo{pod.Containers, pod.IstioContainers} {
+			for _, container := range containers {
+				containerName := pod.Name + "/" + container.Name
+				if container.WaitingReason == crashLoopBackOffReason {
+					signals.CrashLoopContainers = append(signals.CrashLoopContainers, containerName)
+				}
+				terminatedAt, err := time.Parse(time.RFC3339, container.LastTerminatedAt)
+				if err != nil || !terminatedAt.After(since) {
+					continue
+				}
+				signals.RecentRestarts += container.RestartCount
+				if container.LastTerminationReason == oomKilledReason {
+					signals.OOMKilledContainers = append(signals.OOMKilledContainers, containerName)
+				}
+			}
+		}
+	}
+
+	var lastWarning time.Time
+	for _, event := range events {
+		involved := event.InvolvedObject
+		if !(involved.Kind == kubernetes.Pods.Kind && podNames[involved.Name]) && !(involved.Kind == w.WorkloadGVK.Kind && involved.Name == w.Name) {
+			continue
+		}
+		if event.Reason == unhealthyEventReason && strings.HasPrefix(event.Message, "Readiness probe") {
+			signals.FailedProbes += eventCount(event)
+			continue
+		}
+		signals.WarningEvents += eventCount(event)
+		if lastSeen := eventLastSeen(event); lastSeen.After(lastWarning) {
+			lastWarning = lastSeen
+			signals.LastWarningEvent = fmt.Sprintf("%s: %s", event.Reason, event.Message)
+		}
+	}
+	return signals
+}
+
+// mergeKubernetesStatus merges the statuses calculated from the Kubernetes signals of a workload, using the
+// health_config.kubernetes thresholds.
+func mergeKubernetesStatus(calculated *CalculatedHealth, ws *models.WorkloadStatus, k8sHealth config.HealthKubernetes) {
+	if ws == nil || ws.Kubernetes == nil {
+		return
+	}
+	signals := ws.Kubernetes
+	window := k8sHealth.RecentWindow
+
+	if len(signals.CrashLoopContainers) > 0 {
+		reason := fmt.Sprintf("container [%s] of workload [%s] is in CrashLoopBackOff", signals.CrashLoopContainers[0], ws.Name)
+		mergeSignalStatus(calculated, models.HealthStatusFailure, models.HealthSignalKubernetes, reason)
+	}
+	if status := countStatus(signals.RecentRestarts, k8sHealth.Restarts); status != models.HealthStatusNA {
+		reason := fmt.Sprintf("containers of workload [%s] that restarted in the last %s restarted %d times", ws.Name, window, signals.RecentRestarts)
+		mergeSignalStatus(calculated, status, models.HealthSignalKubernetes, reason)
+	}
+	if len(signals.OOMKilledContainers) > 0 {
+		reason := fmt.Sprintf("container [%s] of workload [%s] was OOMKilled in the last %s", signals.OOMKilledContainers[0], ws.Name, window)
+		mergeSignalStatus(calculated, models.HealthStatusDegraded, models.HealthSignalKubernetes, reason)
+	}
+	if status := countStatus(signals.FailedProbes, k8sHealth.FailedProbes); status != models.HealthStatusNA {
+		reason := fmt.Sprintf("readiness probes of workload [%s] failed %d times in the last %s", ws.Name, signals.FailedProbes, window)
+		mergeSignalStatus(calculated, status, models.HealthSignalKubernetes, reason)
+	}
+	if status := countStatus(signals.WarningEvents, k8sHealth.WarningEvents); status != models.HealthStatusNA {
+		reason := fmt.Sprintf("%d Warning Events involved workload [%s] in the last %s, the last one: %s", signals.WarningEvents, ws.Name, window, signals.LastWarningEvent)
+		mergeSignalStatus(calculated, status, models.HealthSignalKubernetes, reason)
+	}
+}
+
+// countStatus returns the status of a count given its thresholds. A threshold of 0 is disabled.
+func countStatus(count int32, thresholds config.HealthCountThresholds) models.HealthStatus {
+	if thresholds.Failure > 0 && count >= thresholds.Failure {
+		return models.HealthStatusFailure
+	}
+	if thresholds.Degraded > 0 && count >= thresholds.Degraded {
+		return models.HealthStatusDegraded
+	}
+	return models.HealthStatusNA
+}
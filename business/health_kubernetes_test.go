@@ -0,0 +1,140 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestGetNamespaceWorkloadHealthWithKubernetesSignals(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.Kubernetes.FailedProbes = config.HealthCountThresholds{Degraded: 3}
+	conf.HealthConfig.Kubernetes.WarningEvents = config.HealthCountThresholds{Degraded: 10}
+	config.Set(conf)
+
+	now := time.Now()
+	warningEvent := func(name, involvedPod, reason, message string, count int32, lastSeen time.Time) *core_v1.Event {
+		return &core_v1.Event{
+			ObjectMeta:     meta_v1.ObjectMeta{Name: name, Namespace: "ns"},
+			Count:          count,
+			InvolvedObject: core_v1.ObjectReference{Kind: "Pod", Name: involvedPod, Namespace: "ns"},
+			LastTimestamp:  meta_v1.NewTime(lastSeen),
+			Message:        message,
+			Reason:         reason,
+			Type:           core_v1.EventTypeWarning,
+		}
+	}
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+		warningEvent("probes", "ratings-v1-abc", "Unhealthy", "Readiness probe failed: HTTP probe failed with statuscode: 503", 4, now.Add(-time.Minute)),
+		warningEvent("old-probes", "details-v1-abc", "Unhealthy", "Readiness probe failed", 50, now.Add(-time.Hour)),
+		warningEvent("mount", "details-v1-abc", "FailedMount", "MountVolume.SetUp failed", 2, now.Add(-2*time.Minute)),
+	)
+	prom := new(prometheustest.PromClientMock)
+	hs := NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build().Health
+
+	newWorkload := func(name string, containers ...*models.ContainerInfo) *models.Workload {
+		return &models.Workload{
+			WorkloadListItem: models.WorkloadListItem{
+				Name:        name,
+				Namespace:   "ns",
+				WorkloadGVK: kubernetes.Deployments,
+			},
+			AvailableReplicas: 1,
+			CurrentReplicas:   1,
+			DesiredReplicas:   1,
+			Pods:              models.Pods{{Name: name + "-abc", Containers: containers}},
+		}
+	}
+	recently := now.Add(-5 * time.Minute).UTC().Format(time.RFC3339)
+	workloads := models.Workloads{
+		newWorkload("reviews-v1", &models.ContainerInfo{Name: "reviews", RestartCount: 12, WaitingReason: "CrashLoopBackOff", LastTerminatedAt: recently}),
+		newWorkload("reviews-v2", &models.ContainerInfo{Name: "reviews", RestartCount: 4, LastTerminatedAt: recently}),
+		newWorkload("reviews-v3", &models.ContainerInfo{Name: "reviews", RestartCount: 1, LastTerminationReason: "OOMKilled", LastTerminatedAt: recently}),
+		// Restarted long ago
+		newWorkload("productpage-v1", &models.ContainerInfo{Name: "productpage", RestartCount: 40, LastTerminatedAt: now.Add(-48 * time.Hour).UTC().Format(time.RFC3339)}),
+		newWorkload("ratings-v1", &models.ContainerInfo{Name: "ratings"}),
+		newWorkload("details-v1", &models.ContainerInfo{Name: "details"}),
+	}
+
+	criteria := NamespaceHealthCriteria{
+		Cluster:   conf.KubernetesConfig.ClusterName,
+		Namespace: "ns",
+	}
+	health, err := hs.GetNamespaceWorkloadHealthFromWorkloads(context.TODO(), criteria, workloads)
+	require.NoError(err)
+
+	status := health["reviews-v1"].Status
+	require.Equal(models.HealthStatusFailure, status.Status)
+	require.Equal(models.HealthSignalKubernetes, status.Signal)
+	require.Equal("container [reviews-v1-abc/reviews] of workload [reviews-v1] is in CrashLoopBackOff", status.Reason)
+
+	status = health["reviews-v2"].Status
+	require.Equal(models.HealthStatusDegraded, status.Status)
+	require.Equal("containers of workload [reviews-v2] that restarted in the last 15m restarted 4 times", status.Reason)
+
+	status = health["reviews-v3"].Status
+	require.Equal(models.HealthStatusDegraded, status.Status)
+	require.Equal([]string{"reviews-v3-abc/reviews"}, health["reviews-v3"].WorkloadStatus.Kubernetes.OOMKilledContainers)
+
+	require.Equal(models.HealthStatusHealthy, health["productpage-v1"].Status.Status)
+	require.Zero(health["productpage-v1"].WorkloadStatus.Kubernetes.RecentRestarts)
+
+	status = health["ratings-v1"].Status
+	require.Equal(models.HealthStatusDegraded, status.Status)
+	require.Equal(models.HealthSignalKubernetes, status.Signal)
+	require.Equal(int32(4), health["ratings-v1"].WorkloadStatus.Kubernetes.FailedProbes)
+
+	// The old probe failures are ignored and the other warnings are under the threshold
+	signals := health["details-v1"].WorkloadStatus.Kubernetes
+	require.Equal(models.HealthStatusHealthy, health["details-v1"].Status.Status)
+	require.Zero(signals.FailedProbes)
+	require.Equal(int32(2), signals.WarningEvents)
+	require.Equal("FailedMount: MountVolume.SetUp failed", signals.LastWarningEvent)
+
+	// Disabled
+	conf.HealthConfig.Kubernetes.Enabled = false
+	hs = NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build().Health
+	health, err = hs.GetNamespaceWorkloadHealthFromWorkloads(context.TODO(), criteria, workloads)
+	require.NoError(err)
+	require.Nil(health["reviews-v1"].WorkloadStatus.Kubernetes)
+	require.Equal(models.HealthStatusHealthy, health["reviews-v1"].Status.Status)
+}
+
+func TestMergeKubernetesStatus(t *testing.T) {
+	k8sHealth := config.NewConfig().HealthConfig.Kubernetes
+	k8sHealth.WarningEvents = config.HealthCountThresholds{Degraded: 2, Failure: 5}
+
+	cases := map[string]struct {
+		signals        *models.KubernetesSignals
+		expectedStatus models.HealthStatus
+	}{
+		"no signals":            {signals: nil, expectedStatus: models.HealthStatusHealthy},
+		"quiet":                 {signals: &models.KubernetesSignals{RecentRestarts: 2, FailedProbes: 2, WarningEvents: 1}, expectedStatus: models.HealthStatusHealthy},
+		"restarts failure":      {signals: &models.KubernetesSignals{RecentRestarts: 10}, expectedStatus: models.HealthStatusFailure},
+		"warning events failed": {signals: &models.KubernetesSignals{WarningEvents: 5}, expectedStatus: models.HealthStatusFailure},
+		"warning events":        {signals: &models.KubernetesSignals{WarningEvents: 3}, expectedStatus: models.HealthStatusDegraded},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calculated := CalculatedHealth{Status: models.HealthStatusHealthy}
+			mergeKubernetesStatus(&calculated, &models.WorkloadStatus{Name: "reviews-v1", Kubernetes: tc.signals}, k8sHealth)
+			require.Equal(t, tc.expectedStatus, calculated.Status)
+			if tc.expectedStatus != models.HealthStatusHealthy {
+				require.Equal(t, models.HealthSignalKubernetes, calculated.Signal)
+				require.NotEmpty(t, calculated.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// meshHealthLatencyQuantile is the quantile of the xDS push time and of the proxy convergence time
+const meshHealthLatencyQuantile = 0.99
+
+// MeshHealthCriteria is the criteria of the health of the mesh infrastructure components
+type MeshHealthCriteria struct {
+	QueryTime    time.Time
+	RateInterval string
+}
+
+// GetMeshHealth returns the health of the mesh infrastructure components the user can access: istiod, the ingress
+// and egress gateways, the waypoints and ztunnel. It returns nothing when health_config.mesh is disabled.
+func (in *HealthService) GetMeshHealth(ctx context.Context, criteria MeshHealthCriteria) ([]models.MeshComponentHealth, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "GetMeshHealth",
+		observability.Attribute("package", "business"),
+		observability.Attribute("rateInterval", criteria.RateInterval),
+		observability.Attribute("queryTime", criteria.QueryTime),
+	)
+	defer end()
+
+	components := []models.MeshComponentHealth{}
+	if !in.conf.HealthConfig.Mesh.Enabled {
+		return components, nil
+	}
+
+	mesh, err := in.businessLayer.Mesh.GetMesh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	access := map[string]bool{}
+	accessible := func(cluster, namespace string) bool {
+		key := makeNamespaceKey(cluster, namespace)
+		if _, found := access[key]; !found {
+			_, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster)
+			access[key] = err == nil
+		}
+		return access[key]
+	}
+
+	for _, cp := range mesh.ControlPlanes {
+		if cp.Cluster == nil || !accessible(cp.Cluster.Name, cp.IstiodNamespace) {
+			continue
+		}
+		if _, ok := in.userClients[cp.Cluster.Name]; !ok {
+			continue
+		}
+		istiods, err := in.businessLayer.Workload.GetAllWorkloads(ctx, cp.Cluster.Name, fmt.Sprintf("%s=istiod", config.IstioAppLabel))
+		if err != nil {
+			return nil, err
+		}
+		var istiod *models.Workload
+		for _, w := range istiods {
+			if w.Namespace == cp.IstiodNamespace && w.Name == cp.IstiodName {
+				istiod = w
+				break
+			}
+		}
+		components = append(components, in.istiodHealth(ctx, cp, istiod, criteria))
+	}
+
+	clusters := make([]string, 0, len(in.userClients))
+	for cluster := range in.userClients {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	for _, cluster := range clusters {
+		if !in.kialiCache.IsAmbientEnabled(cluster) {
+			continue
+		}
+		ztunnels, err := in.businessLayer.Workload.GetAllWorkloads(ctx, cluster, fmt.Sprintf("%s=%s", config.IstioAppLabel, config.Ztunnel))
+		if err != nil {
+			return nil, err
+		}
+		for _, ztunnel := range ztunnels {
+			if accessible(ztunnel.Cluster, ztunnel.Namespace) {
+				components = append(components, in.meshComponentHealth(ctx, models.MeshComponentZtunnel, ztunnel))
+			}
+		}
+	}
+
+	gateways, err := in.businessLayer.Workload.GetGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, gateway := range gateways {
+		// Waypoints are gateways too, they are reported as waypoints
+		if !gateway.IsWaypoint() && accessible(gateway.Cluster, gateway.Namespace) {
+			components = append(components, in.meshComponentHealth(ctx, models.MeshComponentGateway, gateway))
+		}
+	}
+	for _, waypoint := range in.businessLayer.Workload.GetWaypoints(ctx) {
+		if accessible(waypoint.Cluster, waypoint.Namespace) {
+			components = append(components, in.meshComponentHealth(ctx, models.MeshComponentWaypoint, waypoint))
+		}
+	}
+
+	sort.SliceStable(components, func(i, j int) bool {
+		a, b := components[i], components[j]
+		if a.Cluster != b.Cluster {
+			return a.Cluster < b.Cluster
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return components, nil
+}
+
+// meshComponentHealth calculates the health of a mesh infrastructure component from the status of its pods and the
+// Kubernetes signals.
+func (in *HealthService) meshComponentHealth(ctx context.Context, componentType string, w *models.Workload) models.MeshComponentHealth {
+	ws := in.castWorkloadStatus(ctx, w.Cluster, w.Namespace, w)
+	calculated := CalculatedHealth{Status: models.HealthStatusHealthy}
+	mergeSignalStatus(&calculated, models.WorkloadStatusHealth(ws), models.HealthSignalWorkloadStatus, models.WorkloadStatusReason(ws))
+	mergeKubernetesStatus(&calculated, ws, in.conf.HealthConfig.Kubernetes)
+	return models.MeshComponentHealth{
+		Cluster:        w.Cluster,
+		Name:           w.Name,
+		Namespace:      w.Namespace,
+		Status:         calculated,
+		Type:           componentType,
+		WorkloadStatus: ws,
+	}
+}
+
+// istiodHealth calculates the health of the istiod of a control plane, adding the control plane signals to the
+// health of its pods. When the istiod workload is not found (e.g. an external control plane) only the certificates
+// are considered: the status of the control plane already reports an unreachable istiod.
+func (in *HealthService) istiodHealth(ctx context.Context, cp models.ControlPlane, istiod *models.Workload, criteria MeshHealthCriteria) models.MeshComponentHealth {
+	health := models.MeshComponentHealth{
+		Cluster:   cp.Cluster.Name,
+		Name:      cp.IstiodName,
+		Namespace: cp.IstiodNamespace,
+		Status:    models.CalculatedHealthStatus{Status: models.HealthStatusNA},
+		Type:      models.MeshComponentIstiod,
+	}
+	if istiod != nil {
+		health = in.meshComponentHealth(ctx, models.MeshComponentIstiod, istiod)
+	}
+
+	signals := &models.ControlPlaneSignals{}
+	for _, cert := range cp.Config.Certificates {
+		if cert.Error == "" && !cert.NotAfter.IsZero() && (signals.CertExpiry == nil || cert.NotAfter.Before(*signals.CertExpiry)) {
+			notAfter := cert.NotAfter
+			signals.CertExpiry = &notAfter
+		}
+	}
+	if istiod != nil && len(istiod.Pods) > 0 && in.prom != nil {
+		in.fetchControlPlaneSignals(ctx, signals, istiod, criteria)
+	}
+	health.Signals = signals
+	mergeControlPlaneStatus(&health.Status, health.Name, signals, in.conf.HealthConfig.Mesh, criteria)
+	return health
+}
+
+// fetchControlPlaneSignals sets the signals of the pilot_* metrics of the istiod pods. Failures are logged: the
+// health is then calculated without the signal.
+func (in *HealthService) fetchControlPlaneSignals(ctx context.Context, signals *models.ControlPlaneSignals, istiod *models.Workload, criteria MeshHealthCriteria) {
+	pods := make([]string, 0, len(istiod.Pods))
+	for _, pod := range istiod.Pods {
+		pods = append(pods, pod.Name)
+	}
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s"`, istiod.Namespace, strings.Join(pods, "|"))
+	window := criteria.RateInterval
+
+	queries := []struct {
+		query  string
+		signal **float64
+	}{
+		{
+			query:  fmt.Sprintf(`sum(increase(pilot_total_xds_rejects{%s}[%s]))`, selector, window),
+			signal: &signals.ConfigRejections,
+		},
+		{
+			query:  fmt.Sprintf(`histogram_quantile(%g, sum(rate(pilot_proxy_convergence_time_bucket{%s}[%s])) by (le))`, meshHealthLatencyQuantile, selector, window),
+			signal: &signals.ProxyConvergence,
+		},
+		{
+			query:  fmt.Sprintf(`sum(increase({__name__=~"pilot_xds_push_context_errors|pilot_total_xds_internal_errors|pilot_xds_write_timeout",%s}[%s]))`, selector, window),
+			signal: &signals.XdsPushErrors,
+		},
+		{
+			query:  fmt.Sprintf(`histogram_quantile(%g, sum(rate(pilot_xds_push_time_bucket{%s}[%s])) by (le))`, meshHealthLatencyQuantile, selector, window),
+			signal: &signals.XdsPushLatency,
+		},
+	}
+	for _, q := range queries {
+		result, _, err := in.prom.API().Query(ctx, q.query, criteria.QueryTime)
+		if err != nil {
+			log.FromContext(ctx).Warn().Err(err).Msgf("Unable to fetch the control plane signals of istiod [%s]: %s", istiod.Name, q.query)
+			continue
+		}
+		vector, ok := result.(model.Vector)
+		if !ok || len(vector) == 0 {
+			continue
+		}
+		value := float64(vector[0].Value)
+		// NaN: no pushes over the window
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			*q.signal = &value
+		}
+	}
+}
+
+// mergeControlPlaneStatus merges the status of the control plane signals of istiod into its health.
+func mergeControlPlaneStatus(calculated *CalculatedHealth, istiod string, signals *models.ControlPlaneSignals, meshHealth config.HealthMesh, criteria MeshHealthCriteria) {
+	window := criteria.RateInterval
+
+	if signals.CertExpiry != nil {
+		remaining := signals.CertExpiry.Sub(criteria.QueryTime)
+		if status := remainingDurationStatus(remaining, meshHealth.CertExpiry); status != models.HealthStatusNA {
+			reason := fmt.Sprintf("a certificate of the control plane of istiod [%s] expires on %s", istiod, signals.CertExpiry.UTC().Format(time.RFC3339))
+			if remaining <= 0 {
+				reason = fmt.Sprintf("a certificate of the control plane of istiod [%s] expired on %s", istiod, signals.CertExpiry.UTC().Format(time.RFC3339))
+			}
+			mergeSignalStatus(calculated, status, models.HealthSignalControlPlane, reason)
+		}
+	}
+	if signals.ConfigRejections != nil {
+		rejections := int32(math.Round(*signals.ConfigRejections))
+		if status := countStatus(rejections, meshHealth.ConfigRejections); status != models.HealthStatusNA {
+			reason := fmt.Sprintf("proxies rejected %d xDS configs of istiod [%s] in the last %s", rejections, istiod, window)
+			mergeSignalStatus(calculated, status, models.HealthSignalControlPlane, reason)
+		}
+	}
+	if signals.XdsPushErrors != nil {
+		pushErrors := int32(math.Round(*signals.XdsPushErrors))
+		if status := countStatus(pushErrors, meshHealth.XdsPushErrors); status != models.HealthStatusNA {
+			reason := fmt.Sprintf("istiod [%s] had %d xDS push errors in the last %s", istiod, pushErrors, window)
+			mergeSignalStatus(calculated, status, models.HealthSignalControlPlane, reason)
+		}
+	}
+	if signals.XdsPushLatency != nil {
+		if status := durationStatus(*signals.XdsPushLatency, meshHealth.XdsPushLatency); status != models.HealthStatusNA {
+			reason := fmt.Sprintf("the p99 of the xDS push time of istiod [%s] is %.2fs", istiod, *signals.XdsPushLatency)
+			mergeSignalStatus(calculated, status, models.HealthSignalControlPlane, reason)
+		}
+	}
+	if signals.ProxyConvergence != nil {
+		if status := durationStatus(*signals.ProxyConvergence, meshHealth.ProxyConvergence); status != models.HealthStatusNA {
+			reason := fmt.Sprintf("the p99 of the proxy convergence time of istiod [%s] is %.2fs", istiod, *signals.ProxyConvergence)
+			mergeSignalStatus(calculated, status, models.HealthSignalControlPlane, reason)
+		}
+	}
+}
+
+// durationStatus returns the status of a duration, in seconds, given its thresholds. An empty threshold is disabled.
+func durationStatus(seconds float64, thresholds config.HealthDurationThresholds) models.HealthStatus {
+	if failure, err := thresholds.Failure.ToDuration(); err == nil && failure > 0 && seconds >= failure.Seconds() {
+		return models.HealthStatusFailure
+	}
+	if degraded, err := thresholds.Degraded.ToDuration(); err == nil && degraded > 0 && seconds >= degraded.Seconds() {
+		return models.HealthStatusDegraded
+	}
+	return models.HealthStatusNA
+}
+
+// remainingDurationStatus returns the status of a remaining duration given its thresholds: the shorter, the worse.
+// An empty threshold is disabled.
+func remainingDurationStatus(remaining time.Duration, thresholds config.HealthDurationThresholds) models.HealthStatus {
+	if failure, err := thresholds.Failure.ToDuration(); err == nil && remaining <= failure {
+		return models.HealthStatusFailure
+	}
+	if degraded, err := thresholds.Degraded.ToDuration(); err == nil && remaining <= degraded {
+		return models.HealthStatusDegraded
+	}
+	return models.HealthStatusNA
+}
@@ -0,0 +1,171 @@
+package business
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apps_v1 "k8s.io/api/apps/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/istio/istiotest"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func fakeMeshDeployment(name string, labels map[string]string, replicas, available int32) *apps_v1.Deployment {
+	return &apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "istio-system", Labels: labels},
+		Spec: apps_v1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &meta_v1.LabelSelector{MatchLabels: labels},
+			Template: core_v1.PodTemplateSpec{ObjectMeta: meta_v1.ObjectMeta{Labels: labels}},
+		},
+		Status: apps_v1.DeploymentStatus{AvailableReplicas: available, Replicas: replicas},
+	}
+}
+
+func TestGetMeshHealth(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		fakeMeshDeployment("istiod", map[string]string{"app": "istiod"}, 1, 1),
+		fakeMeshDeployment("istio-ingressgateway", map[string]string{"app": "istio-ingressgateway", "istio": "ingressgateway"}, 2, 0),
+	)
+	now := time.Now()
+	discovery := &istiotest.FakeDiscovery{
+		MeshReturn: models.Mesh{
+			ControlPlanes: []models.ControlPlane{{
+				Cluster: &models.KubeCluster{Name: conf.KubernetesConfig.ClusterName},
+				Config: models.ControlPlaneConfiguration{
+					Certificates: []models.Certificate{
+						{Issuer: "O=cluster.local", NotAfter: now.Add(365 * 24 * time.Hour)},
+						{Issuer: "O=cluster.local", NotAfter: now.Add(20 * 24 * time.Hour)},
+						{Error: "unable to parse certificate"},
+					},
+				},
+				IstiodName:      "istiod",
+				IstiodNamespace: "istio-system",
+				Status:          kubernetes.ComponentHealthy,
+			}, {
+				// The namespace is not accessible: the control plane is left out
+				Cluster:         &models.KubeCluster{Name: conf.KubernetesConfig.ClusterName},
+				IstiodName:      "istiod",
+				IstiodNamespace: "istio-hidden",
+				Status:          kubernetes.ComponentUnhealthy,
+			}},
+		},
+	}
+	hs := NewLayerBuilder(t, conf).WithClient(k8s).WithDiscovery(discovery).Build().Health
+
+	criteria := MeshHealthCriteria{QueryTime: now, RateInterval: "5m"}
+	health, err := hs.GetMeshHealth(context.TODO(), criteria)
+	require.NoError(err)
+	require.Len(health, 2)
+
+	gateway := health[0]
+	require.Equal(models.MeshComponentGateway, gateway.Type)
+	require.Equal("istio-ingressgateway", gateway.Name)
+	require.Equal(models.HealthStatusFailure, gateway.Status.Status)
+	require.Equal(models.HealthSignalWorkloadStatus, gateway.Status.Signal)
+
+	istiod := health[1]
+	require.Equal(models.MeshComponentIstiod, istiod.Type)
+	require.Equal(int32(1), istiod.WorkloadStatus.AvailableReplicas)
+	require.Equal(models.HealthStatusDegraded, istiod.Status.Status)
+	require.Equal(models.HealthSignalControlPlane, istiod.Status.Signal)
+	require.Contains(istiod.Status.Reason, "a certificate of the control plane of istiod [istiod] expires on")
+	require.WithinDuration(now.Add(20*24*time.Hour), *istiod.Signals.CertExpiry, time.Second)
+
+	// Disabled
+	conf.HealthConfig.Mesh.Enabled = false
+	health, err = hs.GetMeshHealth(context.TODO(), criteria)
+	require.NoError(err)
+	require.Empty(health)
+}
+
+func TestFetchControlPlaneSignals(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	queryTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	prom := new(prometheustest.PromClientMock)
+	promAPI := new(prometheustest.PromAPIMock)
+	prom.On("API").Return(promAPI)
+	values := map[string]float64{
+		"pilot_total_xds_rejects":       3,
+		"pilot_proxy_convergence_time":  12,
+		"pilot_xds_push_context_errors": 2,
+		"pilot_xds_push_time_bucket":    0.5,
+	}
+	for metric, value := range values {
+		promAPI.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+			return strings.Contains(query, metric) && strings.Contains(query, `namespace="istio-system",pod=~"istiod-a|istiod-b"`) && strings.Contains(query, "[5m]")
+		}), queryTime).Return(model.Vector{&model.Sample{Value: model.SampleValue(value)}})
+	}
+	hs := HealthService{conf: conf, prom: prom}
+
+	istiod := &models.Workload{
+		WorkloadListItem: models.WorkloadListItem{Name: "istiod", Namespace: "istio-system"},
+		Pods:             models.Pods{{Name: "istiod-a"}, {Name: "istiod-b"}},
+	}
+	criteria := MeshHealthCriteria{QueryTime: queryTime, RateInterval: "5m"}
+	signals := &models.ControlPlaneSignals{}
+	hs.fetchControlPlaneSignals(context.TODO(), signals, istiod, criteria)
+	require.Equal(3.0, *signals.ConfigRejections)
+	require.Equal(12.0, *signals.ProxyConvergence)
+	require.Equal(2.0, *signals.XdsPushErrors)
+	require.Equal(0.5, *signals.XdsPushLatency)
+
+	calculated := CalculatedHealth{Status: models.HealthStatusHealthy}
+	mergeControlPlaneStatus(&calculated, "istiod", signals, conf.HealthConfig.Mesh, criteria)
+	require.Equal(models.HealthStatusDegraded, calculated.Status)
+	require.Equal(models.HealthSignalControlPlane, calculated.Signal)
+	require.Equal("proxies rejected 3 xDS configs of istiod [istiod] in the last 5m", calculated.Reason)
+}
+
+func TestMergeControlPlaneStatus(t *testing.T) {
+	meshHealth := config.NewConfig().HealthConfig.Mesh
+	queryTime := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	value := func(v float64) *float64 { return &v }
+	expiry := func(d time.Duration) *time.Time {
+		t := queryTime.Add(d)
+		return &t
+	}
+
+	cases := map[string]struct {
+		signals        models.ControlPlaneSignals
+		expectedStatus models.HealthStatus
+		expectedReason string
+	}{
+		"no signals":            {expectedStatus: models.HealthStatusHealthy},
+		"quiet":                 {signals: models.ControlPlaneSignals{CertExpiry: expiry(60 * 24 * time.Hour), ConfigRejections: value(0), ProxyConvergence: value(0.1), XdsPushErrors: value(4), XdsPushLatency: value(0.2)}, expectedStatus: models.HealthStatusHealthy},
+		"cert expired":          {signals: models.ControlPlaneSignals{CertExpiry: expiry(-time.Hour)}, expectedStatus: models.HealthStatusFailure, expectedReason: "a certificate of the control plane of istiod [istiod] expired on 2025-12-31T23:00:00Z"},
+		"cert expires soon":     {signals: models.ControlPlaneSignals{CertExpiry: expiry(24 * time.Hour)}, expectedStatus: models.HealthStatusFailure},
+		"push errors":           {signals: models.ControlPlaneSignals{XdsPushErrors: value(50)}, expectedStatus: models.HealthStatusFailure, expectedReason: "istiod [istiod] had 50 xDS push errors in the last 5m"},
+		"slow pushes":           {signals: models.ControlPlaneSignals{XdsPushLatency: value(1.5)}, expectedStatus: models.HealthStatusDegraded, expectedReason: "the p99 of the xDS push time of istiod [istiod] is 1.50s"},
+		"slow convergence":      {signals: models.ControlPlaneSignals{ProxyConvergence: value(30)}, expectedStatus: models.HealthStatusFailure},
+		"worst signal reported": {signals: models.ControlPlaneSignals{ConfigRejections: value(1), ProxyConvergence: value(45)}, expectedStatus: models.HealthStatusFailure, expectedReason: "the p99 of the proxy convergence time of istiod [istiod] is 45.00s"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calculated := CalculatedHealth{Status: models.HealthStatusHealthy}
+			mergeControlPlaneStatus(&calculated, "istiod", &tc.signals, meshHealth, MeshHealthCriteria{QueryTime: queryTime, RateInterval: "5m"})
+			require.Equal(t, tc.expectedStatus, calculated.Status)
+			if tc.expectedReason != "" {
+				require.Equal(t, tc.expectedReason, calculated.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// sloBurnRateAlert is a multi-window burn rate condition, as in the Google SRE workbook: the error budget burns
+// too fast when the burn rate exceeds the factor over both windows. The long window detects the burn, the short
+// one checks that it is still ongoing.
+type sloBurnRateAlert struct {
+	factor   float64
+	long     string
+	severity string
+	short    string
+	status   models.HealthStatus
+}
+
+// sloBurnRateAlerts are the burn rate conditions, worst first. Over a 30d window, a burn rate of 14.4 consumes 2%
+// of the error budget in 1h, and a burn rate of 6 consumes 5% of it in 6h.
+var sloBurnRateAlerts = []sloBurnRateAlert{
+	{factor: 14.4, long: "1h", severity: "critical", short: "5m", status: models.HealthStatusFailure},
+	{factor: 6, long: "6h", severity: "warning", short: "30m", status: models.HealthStatusDegraded},
+}
+
+// sloBurnRateWindows are the windows of the burn rates reported for each SLO.
+var sloBurnRateWindows = []string{"5m", "30m", "1h", "6h"}
+
+// sloEntityLabels returns the labels of the inbound Istio metrics that hold the namespace and the name of the
+// entities of a kind (app, service or workload).
+func sloEntityLabels(kind string) (namespaceLabel, nameLabel string) {
+	switch kind {
+	case "app":
+		return "destination_workload_namespace", "destination_canonical_service"
+	case "workload":
+		return "destination_workload_namespace", "destination_workload"
+	default:
+		return "destination_service_namespace", "destination_service_name"
+	}
+}
+
+// sloErrorRatioQuery returns the query of the ratio of the bad requests of an SLO, over the window, among the
+// inbound requests matching the selector, grouped by the groupBy labels.
+func sloErrorRatioQuery(slo config.SLO, selector, groupBy, window string) string {
+	if slo.Type == config.SLOTypeLatency {
+		le := strconv.FormatFloat(float64(slo.LatencyThreshold), 'f', -1, 32)
+		return fmt.Sprintf(`1 - sum(rate(%s_bucket{%s,le="%s"}[%s])) by (%s) / sum(rate(%s_count{%s}[%s])) by (%s)`,
+			requestDurationMetric, selector, le, window, groupBy, requestDurationMetric, selector, window, groupBy)
+	}
+	// Entities without errors have no 5xx series: their error ratio is 0
+	total := fmt.Sprintf(`sum(rate(istio_requests_total{%s}[%s])) by (%s)`, selector, window, groupBy)
+	return fmt.Sprintf(`(sum(rate(istio_requests_total{%s,response_code=~"5.."}[%s])) by (%s) or %s * 0) / %s`,
+		selector, window, groupBy, total, total)
+}
+
+// sloWindows returns the windows the error ratio of an SLO is needed for: its compliance window and the windows
+// of the burn rates.
+func sloWindows(slo config.SLO) []string {
+	return append([]string{slo.Window}, sloBurnRateWindows...)
+}
+
+// sloKey identifies the SLOs that share the same error ratio queries.
+func sloKey(slo config.SLO) string {
+	return fmt.Sprintf("%s/%g", slo.Type, slo.LatencyThreshold)
+}
+
+// fillSLOs computes the state of the SLOs of the entities of a kind in the namespace, from the error ratios of
+// their inbound requests, and returns them by entity name. The entities are given with their health annotations,
+// that may override the configured SLOs. Only the metrics reported by the destination proxies are used. Failures
+// are logged: the SLOs are then computed without the failed windows.
+func (in *HealthService) fillSLOs(ctx context.Context, criteria NamespaceHealthCriteria, kind string, entities map[string]map[string]string) map[string][]models.SLOStatus {
+	slos := map[string][]config.SLO{}
+	for name, annotations := range entities {
+		if entitySLOs := in.calculator.matcher.GetSLOs(criteria.Namespace, name, kind, annotations); len(entitySLOs) > 0 {
+			slos[name] = entitySLOs
+		}
+	}
+	if len(slos) == 0 {
+		return nil
+	}
+
+	namespaceLabel, nameLabel := sloEntityLabels(kind)
+	clusterMatch := fmt.Sprintf(`destination_cluster="%s"`, criteria.Cluster)
+	if kind == "service" {
+		clusterMatch = fmt.Sprintf(`destination_cluster=~"%s|unknown"`, criteria.Cluster)
+	}
+	selector := fmt.Sprintf(`reporter="destination",%s="%s",%s`, namespaceLabel, criteria.Namespace, clusterMatch)
+
+	// Error ratios by SLO key, window and entity name. The queries are shared by the entities of the namespace.
+	ratios := map[string]map[string]map[string]float64{}
+	for _, entitySLOs := range slos {
+		for _, slo := range entitySLOs {
+			key := sloKey(slo)
+			if ratios[key] == nil {
+				ratios[key] = map[string]map[string]float64{}
+			}
+			for _, window := range sloWindows(slo) {
+				if _, done := ratios[key][window]; done {
+					continue
+				}
+				ratios[key][window] = map[string]float64{}
+				query := sloErrorRatioQuery(slo, selector, nameLabel, window)
+				result, _, err := in.prom.API().Query(ctx, query, criteria.QueryTime)
+				if err != nil {
+					log.FromContext(ctx).Warn().Err(err).Msgf("Unable to fetch the SLO error ratios of namespace [%s]: %s", criteria.Namespace, query)
+					continue
+				}
+				vector, ok := result.(model.Vector)
+				if !ok {
+					continue
+				}
+				for _, sample := range vector {
+					value := float64(sample.Value)
+					// NaN: no requests over the window
+					if !math.IsNaN(value) && !math.IsInf(value, 0) {
+						ratios[key][window][string(sample.Metric[model.LabelName(nameLabel)])] = value
+					}
+				}
+			}
+		}
+	}
+
+	statuses := make(map[string][]models.SLOStatus, len(slos))
+	for name, entitySLOs := range slos {
+		for _, slo := range entitySLOs {
+			windowRatios := map[string]float64{}
+			for window, byName := range ratios[sloKey(slo)] {
+				if ratio, found := byName[name]; found {
+					windowRatios[window] = ratio
+				}
+			}
+			statuses[name] = append(statuses[name], calculateSLOStatus(slo, windowRatios))
+		}
+	}
+	return statuses
+}
+
+// cachedSLOs returns the SLO states computed by the last health refresh of the namespace, by entity name. The
+// health computed without metrics, or for a single entity, does not query the SLOs: it keeps these.
+func (in *HealthService) cachedSLOs(cluster, namespace string, healthType internalmetrics.HealthType) map[string][]models.SLOStatus {
+	cached, found := in.kialiCache.GetHealth(cluster, namespace, healthType)
+	if !found {
+		return nil
+	}
+	slos := map[string][]models.SLOStatus{}
+	switch healthType {
+	case internalmetrics.HealthTypeApp:
+		for name, health := range cached.AppHealth {
+			if health != nil && len(health.SLOs) > 0 {
+				slos[name] = health.SLOs
+			}
+		}
+	case internalmetrics.HealthTypeService:
+		for name, health := range cached.ServiceHealth {
+			if health != nil && len(health.SLOs) > 0 {
+				slos[name] = health.SLOs
+			}
+		}
+	case internalmetrics.HealthTypeWorkload:
+		for name, health := range cached.WorkloadHealth {
+			if health != nil && len(health.SLOs) > 0 {
+				slos[name] = health.SLOs
+			}
+		}
+	}
+	return slos
+}
+
+// calculateSLOStatus returns the state of an SLO from the error ratios of the entity by window. Windows without
+// requests have no error ratio. The SLO fails when the error budget burns too fast over both windows of the 1h
+// burn rate alert, and is degraded when it does over the windows of the 6h alert or when the budget is exhausted.
+func calculateSLOStatus(slo config.SLO, ratios map[string]float64) models.SLOStatus {
+	status := models.SLOStatus{
+		BurnRates:        map[string]float64{},
+		LatencyThreshold: slo.LatencyThreshold,
+		Name:             slo.Name,
+		Objective:        slo.Objective,
+		Status:           models.HealthStatusNA,
+		Type:             slo.Type,
+		Window:           slo.Window,
+	}
+	ratio, hasTraffic := ratios[slo.Window]
+	if !hasTraffic {
+		return status
+	}
+
+	budget := 1 - slo.Objective/100
+	status.SLI = (1 - ratio) * 100
+	status.ErrorBudgetRemaining = (1 - ratio/budget) * 100
+	for _, window := range sloBurnRateWindows {
+		if windowRatio, found := ratios[window]; found {
+			status.BurnRates[window] = windowRatio / budget
+		}
+	}
+
+	status.Status = models.HealthStatusHealthy
+	for _, alert := range sloBurnRateAlerts {
+		// Windows without requests do not burn the budget
+		if status.BurnRates[alert.long] >= alert.factor && status.BurnRates[alert.short] >= alert.factor {
+			status.Status = alert.status
+			status.Reason = fmt.Sprintf("error budget of SLO %s is burning %.1fx faster than sustainable over %s and %s",
+				slo.Name, status.BurnRates[alert.long], alert.long, alert.short)
+			return status
+		}
+	}
+	if status.ErrorBudgetRemaining <= 0 {
+		status.Status = models.HealthStatusDegraded
+		status.Reason = fmt.Sprintf("error budget of SLO %s is exhausted over %s (SLI of %.3f%% for an objective of %g%%)",
+			slo.Name, slo.Window, status.SLI, slo.Objective)
+	}
+	return status
+}
+
+// sloRecordName returns the name of the recording rule of the SLO error ratios over a window.
+func sloRecordName(window string) string {
+	return "kiali:slo_errors:ratio_rate" + window
+}
+
+// sloTargetMatcher returns the PromQL matcher of a label for a target regex of the health config. The regexes of
+// the health config and of PromQL are both fully anchored. Empty regexes match everything: no matcher is returned.
+func sloTargetMatcher(label, pattern string) string {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	if pattern == "" || pattern == ".*" {
+		return ""
+	}
+	return fmt.Sprintf(",%s=~%s", label, strconv.Quote(pattern))
+}
+
+// GenerateSLORules returns the Prometheus recording and alerting rules of the configured SLOs, as a rules file.
+// The error ratios of each SLO are recorded over its compliance window and the burn rate windows, by namespace
+// and name of the targeted entities, and the alerts fire on the same multi-window burn rates as the health. The
+// entities are services, unless the SLO targets apps or workloads only. SLOs defined in annotations are not
+// included: their targets are not known from the config.
+func GenerateSLORules(conf *config.Config) ([]byte, error) {
+	rules := models.PrometheusRuleGroups{Groups: []models.PrometheusRuleGroup{}}
+	for _, slo := range conf.HealthConfig.SLO {
+		slo = withSLODefaults(slo)
+		kind := slo.Target.Kind
+		if kind != "app" && kind != "workload" {
+			kind = "service"
+		}
+		namespaceLabel, nameLabel := sloEntityLabels(kind)
+		selector := `reporter="destination"` +
+			sloTargetMatcher(namespaceLabel, slo.Target.Namespace) +
+			sloTargetMatcher(nameLabel, slo.Target.Name)
+		sloLabels := map[string]string{"slo": slo.Name, "slo_type": slo.Type}
+
+		group := models.PrometheusRuleGroup{Name: "kiali-slo-" + slo.Name}
+		for _, window := range sloWindows(slo) {
+			group.Rules = append(group.Rules, models.PrometheusRule{
+				Expr:   sloErrorRatioQuery(slo, selector, namespaceLabel+","+nameLabel, window),
+				Labels: sloLabels,
+				Record: sloRecordName(window),
+			})
+		}
+
+		// Rounded to hide the floating point errors of the subtraction
+		budget := strconv.FormatFloat(1-slo.Objective/100, 'g', 12, 64)
+		for _, alert := range sloBurnRateAlerts {
+			threshold := fmt.Sprintf("(%g * %s)", alert.factor, budget)
+			group.Rules = append(group.Rules, models.PrometheusRule{
+				Alert: "KialiSLOErrorBudgetBurn",
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("Error budget of SLO %s is burning %gx faster than sustainable over %s and %s on {{ $labels.%s }}.{{ $labels.%s }}",
+						slo.Name, alert.factor, alert.long, alert.short, nameLabel, namespaceLabel),
+				},
+				Expr: fmt.Sprintf(`%s{slo=%q} > %s and %s{slo=%q} > %s`,
+					sloRecordName(alert.long), slo.Name, threshold, sloRecordName(alert.short), slo.Name, threshold),
+				Labels: map[string]string{"severity": alert.severity, "slo": slo.Name},
+			})
+		}
+		rules.Groups = append(rules.Groups, group)
+	}
+	sort.Slice(rules.Groups, func(i, j int) bool {
+		return rules.Groups[i].Name < rules.Groups[j].Name
+	})
+	return yaml.Marshal(rules)
+}
@@ -0,0 +1,160 @@
+package business
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+)
+
+func TestCalculateSLOStatus(t *testing.T) {
+	slo := config.SLO{Name: "availability", Objective: 99, Type: config.SLOTypeAvailability, Window: "30d"}
+
+	cases := map[string]struct {
+		ratios         map[string]float64
+		expectedStatus models.HealthStatus
+		expectedBudget float64
+	}{
+		"no traffic": {
+			ratios:         map[string]float64{},
+			expectedStatus: models.HealthStatusNA,
+		},
+		"within budget": {
+			ratios:         map[string]float64{"30d": 0.005, "5m": 0.01, "30m": 0.01, "1h": 0.01, "6h": 0.01},
+			expectedStatus: models.HealthStatusHealthy,
+			expectedBudget: 50,
+		},
+		"fast burn": {
+			ratios:         map[string]float64{"30d": 0.005, "5m": 0.2, "30m": 0.2, "1h": 0.15, "6h": 0.03},
+			expectedStatus: models.HealthStatusFailure,
+			expectedBudget: 50,
+		},
+		"fast burn over": {
+			// The burn is not ongoing over the short window
+			ratios:         map[string]float64{"30d": 0.005, "1h": 0.15, "6h": 0.03},
+			expectedStatus: models.HealthStatusHealthy,
+			expectedBudget: 50,
+		},
+		"slow burn": {
+			ratios:         map[string]float64{"30d": 0.005, "5m": 0.07, "30m": 0.07, "1h": 0.07, "6h": 0.07},
+			expectedStatus: models.HealthStatusDegraded,
+			expectedBudget: 50,
+		},
+		"budget exhausted": {
+			ratios:         map[string]float64{"30d": 0.02, "5m": 0.01, "30m": 0.01, "1h": 0.01, "6h": 0.01},
+			expectedStatus: models.HealthStatusDegraded,
+			expectedBudget: -100,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := calculateSLOStatus(slo, tc.ratios)
+			assert.Equal(t, tc.expectedStatus, status.Status)
+			assert.InDelta(t, tc.expectedBudget, status.ErrorBudgetRemaining, 0.0001)
+			if tc.expectedStatus == models.HealthStatusHealthy || tc.expectedStatus == models.HealthStatusNA {
+				assert.Empty(t, status.Reason)
+			} else {
+				assert.Contains(t, status.Reason, "availability")
+			}
+		})
+	}
+}
+
+func TestGetNamespaceServiceHealthWithSLOs(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.SLO = []config.SLO{{Name: "availability", Objective: 99, Type: config.SLOTypeAvailability, Window: "7d"}}
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+	)
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom := new(prometheustest.PromClientMock)
+	prom.MockNamespaceServicesRequestRates(context.Background(), "ns", cluster, "1m", queryTime, model.Vector{})
+	promAPI := new(prometheustest.PromAPIMock)
+	prom.On("API").Return(promAPI)
+	ratios := map[string]model.SampleValue{"7d": 0.002, "5m": 0.5, "30m": 0.3, "1h": 0.2, "6h": 0.05}
+	for window, ratio := range ratios {
+		query := `(sum(rate(istio_requests_total{reporter="destination",destination_service_namespace="ns",destination_cluster=~"` + cluster + `|unknown",response_code=~"5.."}[` + window + `])) by (destination_service_name) or ` +
+			`sum(rate(istio_requests_total{reporter="destination",destination_service_namespace="ns",destination_cluster=~"` + cluster + `|unknown"}[` + window + `])) by (destination_service_name) * 0) / ` +
+			`sum(rate(istio_requests_total{reporter="destination",destination_service_namespace="ns",destination_cluster=~"` + cluster + `|unknown"}[` + window + `])) by (destination_service_name)`
+		promAPI.On("Query", mock.Anything, query, queryTime).Return(model.Vector{
+			{Metric: model.Metric{"destination_service_name": "reviews"}, Value: ratio},
+			// No traffic over the window
+			{Metric: model.Metric{"destination_service_name": "ratings"}, Value: model.SampleValue(math.NaN())},
+		})
+	}
+
+	hs := NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build().Health
+	services := &models.ServiceList{Services: []models.ServiceOverview{{Name: "reviews"}, {Name: "ratings"}}}
+	criteria := NamespaceHealthCriteria{
+		Cluster:        cluster,
+		Namespace:      "ns",
+		RateInterval:   "1m",
+		QueryTime:      queryTime,
+		IncludeMetrics: true,
+	}
+	health := hs.getNamespaceServiceHealth(context.TODO(), services, criteria)
+
+	require.Len(health["reviews"].SLOs, 1)
+	slo := health["reviews"].SLOs[0]
+	require.Equal(models.HealthStatusFailure, slo.Status)
+	require.InDelta(99.8, slo.SLI, 0.0001)
+	require.InDelta(80, slo.ErrorBudgetRemaining, 0.0001)
+	require.InDelta(20, slo.BurnRates["1h"], 0.0001)
+	require.Equal(models.HealthStatusFailure, health["reviews"].Status.Status)
+	require.Equal(models.HealthSignalSLO, health["reviews"].Status.Signal)
+
+	require.Len(health["ratings"].SLOs, 1)
+	require.Equal(models.HealthStatusNA, health["ratings"].SLOs[0].Status)
+	// The queries are shared by the services of the namespace
+	promAPI.AssertNumberOfCalls(t, "Query", len(ratios))
+}
+
+func TestGenerateSLORules(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.SLO = []config.SLO{
+		{Name: "reviews-latency", Objective: 99, Type: config.SLOTypeLatency, LatencyThreshold: 250, Target: config.SLOTarget{Kind: "workload", Name: "reviews-.*", Namespace: "^bookinfo$"}},
+		{Name: "availability", Objective: 99.9, Type: config.SLOTypeAvailability, Window: "7d"},
+	}
+
+	content, err := GenerateSLORules(conf)
+	require.NoError(err)
+	rules := models.PrometheusRuleGroups{}
+	require.NoError(yaml.Unmarshal(content, &rules))
+	require.Len(rules.Groups, 2)
+
+	availability := rules.Groups[0]
+	require.Equal("kiali-slo-availability", availability.Name)
+	// The error ratios over the compliance window and the burn rate windows, then the alerts
+	require.Len(availability.Rules, 7)
+	require.Equal("kiali:slo_errors:ratio_rate7d", availability.Rules[0].Record)
+	require.Contains(availability.Rules[0].Expr, `istio_requests_total{reporter="destination",response_code=~"5.."}[7d]`)
+	require.Contains(availability.Rules[0].Expr, "by (destination_service_namespace,destination_service_name)")
+	require.Equal("availability", availability.Rules[0].Labels["slo"])
+	require.Equal("KialiSLOErrorBudgetBurn", availability.Rules[5].Alert)
+	require.Equal(`kiali:slo_errors:ratio_rate1h{slo="availability"} > (14.4 * 0.001) and kiali:slo_errors:ratio_rate5m{slo="availability"} > (14.4 * 0.001)`,
+		availability.Rules[5].Expr)
+	require.Equal("critical", availability.Rules[5].Labels["severity"])
+	require.Equal("warning", availability.Rules[6].Labels["severity"])
+
+	latency := rules.Groups[1]
+	require.Equal("kiali:slo_errors:ratio_rate30d", latency.Rules[0].Record)
+	require.Contains(latency.Rules[0].Expr, `istio_request_duration_milliseconds_bucket{reporter="destination",destination_workload_namespace=~"bookinfo",destination_workload=~"reviews-.*",le="250"}[30d]`)
+}
@@ -2,6 +2,7 @@ package business
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
@@ -1220,6 +1221,155 @@ func TestGetNamespaceWorkloadHealthFromWorkloads(t *testing.T) {
 	prom.AssertNumberOfCalls(t, "GetAllRequestRates", 1)
 }
 
+func TestGetNamespaceWorkloadHealthWithLatencyThresholds(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.Rate = []config.Rate{{
+		Latency:   []config.LatencyThreshold{{Protocol: "http", Degraded: 500, Failure: 2000}},
+		Tolerance: []config.Tolerance{{Code: "5XX", Protocol: "http", Failure: 10}},
+	}}
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+	)
+	prom := new(prometheustest.PromClientMock)
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom.MockAllRequestRates(context.Background(), "ns", cluster, "1m", queryTime, otherRatesIn)
+	prom.On("FetchHistogramValues", mock.Anything, "istio_request_duration_milliseconds",
+		`{reporter="destination",destination_workload_namespace="ns",destination_cluster="`+cluster+`"}`,
+		"destination_workload,request_protocol", "1m", false, []string{"0.95"}, queryTime).
+		Return(map[string]model.Vector{"0.95": {
+			{Metric: model.Metric{"destination_workload": "reviews-v1", "request_protocol": "http"}, Value: 8000},
+			// No traffic for the quantile
+			{Metric: model.Metric{"destination_workload": "reviews-v2", "request_protocol": "http"}, Value: model.SampleValue(math.NaN())},
+		}}, nil)
+	prom.On("FetchHistogramValues", mock.Anything, "istio_request_duration_milliseconds",
+		`{reporter="source",source_workload_namespace="ns",source_cluster="`+cluster+`"}`,
+		"source_workload,request_protocol", "1m", false, []string{"0.95"}, queryTime).
+		Return(map[string]model.Vector{}, nil)
+
+	hs := NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build().Health
+
+	newWorkload := func(name string) *models.Workload {
+		return &models.Workload{
+			WorkloadListItem: models.WorkloadListItem{
+				Name:         name,
+				Namespace:    "ns",
+				IstioSidecar: true,
+				Labels:       map[string]string{"app": "reviews"},
+			},
+			AvailableReplicas: 1,
+			CurrentReplicas:   1,
+			DesiredReplicas:   1,
+		}
+	}
+	criteria := NamespaceHealthCriteria{
+		Cluster:        cluster,
+		Namespace:      "ns",
+		RateInterval:   "1m",
+		QueryTime:      queryTime,
+		IncludeMetrics: true,
+	}
+	health, err := hs.GetNamespaceWorkloadHealthFromWorkloads(context.TODO(), criteria, models.Workloads{newWorkload("reviews-v1"), newWorkload("reviews-v2")})
+	require.NoError(err)
+
+	require.Equal(map[string]map[string]float64{"http": {"0.95": 8000}}, health["reviews-v1"].Requests.InboundLatency)
+	require.Equal(models.HealthStatusFailure, health["reviews-v1"].Status.Status)
+	require.Equal(models.HealthSignalLatency, health["reviews-v1"].Status.Signal)
+	require.Nil(health["reviews-v2"].Requests.InboundLatency)
+	prom.AssertNumberOfCalls(t, "FetchHistogramValues", 2)
+}
+
+func TestGetServiceHealthWithLatencyThresholds(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.Rate = []config.Rate{{
+		Latency:   []config.LatencyThreshold{{Protocol: "http", Degraded: 500, Failure: 2000}},
+		Tolerance: []config.Tolerance{{Code: "5XX", Protocol: "http", Failure: 10}},
+	}}
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+		&core_v1.Service{ObjectMeta: meta_v1.ObjectMeta{Name: "httpbin", Namespace: "ns"}},
+	)
+	prom := new(prometheustest.PromClientMock)
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom.MockServiceRequestRates(context.Background(), "ns", cluster, "httpbin", serviceRates)
+	// Only the latency of the service is fetched
+	prom.On("FetchHistogramValues", mock.Anything, "istio_request_duration_milliseconds",
+		`{reporter="destination",destination_service_namespace="ns",destination_cluster=~"`+cluster+`|unknown",destination_service_name="httpbin"}`,
+		"destination_service_name,request_protocol", "1m", false, []string{"0.95"}, queryTime).
+		Return(map[string]model.Vector{"0.95": {
+			{Metric: model.Metric{"destination_service_name": "httpbin", "request_protocol": "http"}, Value: 1000},
+		}}, nil)
+
+	hs := NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build().Health
+
+	svc := models.Service{}
+	svc.Name = "httpbin"
+	health, err := hs.GetServiceHealth(context.TODO(), "ns", cluster, "httpbin", "1m", queryTime, &svc)
+	require.NoError(err)
+
+	require.Equal(map[string]map[string]float64{"http": {"0.95": 1000}}, health.Requests.InboundLatency)
+	require.Equal(models.HealthStatusDegraded, health.Status.Status)
+	require.Equal(models.HealthSignalLatency, health.Status.Signal)
+	prom.AssertNumberOfCalls(t, "FetchHistogramValues", 1)
+}
+
+func TestGetWorkloadHealthWithLatencyThresholds(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.Rate = []config.Rate{{
+		Latency:   []config.LatencyThreshold{{Protocol: "http", Degraded: 500, Failure: 2000}},
+		Tolerance: []config.Tolerance{{Code: "5XX", Protocol: "http", Failure: 10}},
+	}}
+	config.Set(conf)
+	cluster := conf.KubernetesConfig.ClusterName
+
+	k8s := kubetest.NewFakeK8sClient(
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns"}},
+	)
+	prom := new(prometheustest.PromClientMock)
+	queryTime := time.Date(2017, 1, 15, 0, 0, 0, 0, time.UTC)
+	prom.MockWorkloadRequestRates(context.Background(), "ns", cluster, "reviews-v1", model.Vector{}, model.Vector{})
+	// Only the latencies of the workload are fetched
+	prom.On("FetchHistogramValues", mock.Anything, "istio_request_duration_milliseconds",
+		`{reporter="destination",destination_workload_namespace="ns",destination_cluster="`+cluster+`",destination_workload="reviews-v1"}`,
+		"destination_workload,request_protocol", "1m", false, []string{"0.95"}, queryTime).
+		Return(map[string]model.Vector{"0.95": {
+			{Metric: model.Metric{"destination_workload": "reviews-v1", "request_protocol": "http"}, Value: 8000},
+		}}, nil)
+	prom.On("FetchHistogramValues", mock.Anything, "istio_request_duration_milliseconds",
+		`{reporter="source",source_workload_namespace="ns",source_cluster="`+cluster+`",source_workload="reviews-v1"}`,
+		"source_workload,request_protocol", "1m", false, []string{"0.95"}, queryTime).
+		Return(map[string]model.Vector{}, nil)
+
+	hs := NewLayerBuilder(t, conf).WithClient(k8s).WithProm(prom).Build().Health
+
+	workload := &models.Workload{
+		WorkloadListItem: models.WorkloadListItem{
+			Name:         "reviews-v1",
+			Namespace:    "ns",
+			IstioSidecar: true,
+			Labels:       map[string]string{"app": "reviews"},
+		},
+		AvailableReplicas: 1,
+		CurrentReplicas:   1,
+		DesiredReplicas:   1,
+	}
+	health, err := hs.GetWorkloadHealth(context.TODO(), "ns", cluster, "reviews-v1", "1m", queryTime, workload)
+	require.NoError(err)
+
+	require.Equal(map[string]map[string]float64{"http": {"0.95": 8000}}, health.Requests.InboundLatency)
+	require.Equal(models.HealthStatusFailure, health.Status.Status)
+	require.Equal(models.HealthSignalLatency, health.Status.Signal)
+	prom.AssertNumberOfCalls(t, "FetchHistogramValues", 2)
+}
+
 func TestGetNamespaceWorkloadHealthFromWorkloadsWithoutIstio(t *testing.T) {
 	require := require.New(t)
 	conf := config.NewConfig()
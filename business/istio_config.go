@@ -48,10 +48,12 @@ type IstioConfigCriteria struct {
 	IncludeDestinationRules       bool
 	IncludeEnvoyFilters           bool
 	IncludeGateways               bool
+	IncludeK8sBackendTLSPolicies  bool
 	IncludeK8sGateways            bool
 	IncludeK8sGRPCRoutes          bool
 	IncludeK8sHTTPRoutes          bool
 	IncludeK8sInferencePools      bool
+	IncludeK8sListenerSets        bool
 	IncludeK8sReferenceGrants     bool
 	IncludeK8sTCPRoutes           bool
 	IncludeK8sTLSRoutes           bool
@@ -82,6 +84,8 @@ func (icc IstioConfigCriteria) Include(resource schema.GroupVersionKind) bool {
 		return icc.IncludeEnvoyFilters
 	case kubernetes.Gateways:
 		return icc.IncludeGateways
+	case kubernetes.K8sBackendTLSPolicies:
+		return icc.IncludeK8sBackendTLSPolicies
 	case kubernetes.K8sGateways:
 		return icc.IncludeK8sGateways
 	case kubernetes.K8sGRPCRoutes:
@@ -90,6 +94,8 @@ func (icc IstioConfigCriteria) Include(resource schema.GroupVersionKind) bool {
 		return icc.IncludeK8sHTTPRoutes
 	case kubernetes.K8sInferencePools:
 		return icc.IncludeK8sInferencePools
+	case kubernetes.K8sListenerSets:
+		return icc.IncludeK8sListenerSets
 	case kubernetes.K8sReferenceGrants:
 		return icc.IncludeK8sReferenceGrants
 	case kubernetes.K8sTCPRoutes:
@@ -222,14 +228,16 @@ func (in *IstioConfigService) getIstioConfigList(ctx context.Context, cluster st
 		WasmPlugins:       []*extentions_v1alpha1.WasmPlugin{},
 		Telemetries:       []*telemetry_v1.Telemetry{},
 
-		K8sGateways:        []*k8s_networking_v1.Gateway{},
-		K8sGRPCRoutes:      []*k8s_networking_v1.GRPCRoute{},
-		K8sHTTPRoutes:      []*k8s_networking_v1.HTTPRoute{},
-		K8sInferencePools:  []*k8s_inference_v1.InferencePool{},
-		K8sReferenceGrants: []*k8s_networking_v1beta1.ReferenceGrant{},
-		K8sTCPRoutes:       []*k8s_networking_v1.TCPRoute{},
-		K8sTLSRoutes:       []*k8s_networking_v1.TLSRoute{},
-		K8sUDPRoutes:       []*k8s_networking_v1.UDPRoute{},
+		K8sBackendTLSPolicies: []*k8s_networking_v1.BackendTLSPolicy{},
+		K8sGateways:           []*k8s_networking_v1.Gateway{},
+		K8sGRPCRoutes:         []*k8s_networking_v1.GRPCRoute{},
+		K8sHTTPRoutes:         []*k8s_networking_v1.HTTPRoute{},
+		K8sInferencePools:     []*k8s_inference_v1.InferencePool{},
+		K8sListenerSets:       []*k8s_networking_v1.ListenerSet{},
+		K8sReferenceGrants:    []*k8s_networking_v1beta1.ReferenceGrant{},
+		K8sTCPRoutes:          []*k8s_networking_v1.TCPRoute{},
+		K8sTLSRoutes:          []*k8s_networking_v1.TLSRoute{},
+		K8sUDPRoutes:          []*k8s_networking_v1.UDPRoute{},
 
 		AuthorizationPolicies:  []*security_v1.AuthorizationPolicy{},
 		PeerAuthentications:    []*security_v1.PeerAuthentication{},
@@ -322,6 +330,17 @@ func (in *IstioConfigService) getIstioConfigList(ctx context.Context, cluster st
 		}
 	}
 
+	if userClient.IsGatewayAPI() && userClient.HasBackendTLSPolicyInV1() && criteria.Include(kubernetes.K8sBackendTLSPolicies) {
+		list := &k8s_networking_v1.BackendTLSPolicyList{}
+		if err := kubeCache.List(ctx, list, listOpts...); err != nil {
+			return nil, err
+		}
+		if err := kubernetes.EnsureTypeMeta(list); err != nil {
+			return nil, err
+		}
+		istioConfigList.K8sBackendTLSPolicies = ToPtrs(list.Items)
+	}
+
 	if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sGateways) {
 		list := &k8s_networking_v1.GatewayList{}
 		if err := kubeCache.List(ctx, list, listOpts...); err != nil {
@@ -369,6 +388,17 @@ func (in *IstioConfigService) getIstioConfigList(ctx context.Context, cluster st
 		}
 	}
 
+	if userClient.IsGatewayAPI() && userClient.HasListenerSetInV1() && criteria.Include(kubernetes.K8sListenerSets) {
+		list := &k8s_networking_v1.ListenerSetList{}
+		if err := kubeCache.List(ctx, list, listOpts...); err != nil {
+			return nil, err
+		}
+		if err := kubernetes.EnsureTypeMeta(list); err != nil {
+			return nil, err
+		}
+		istioConfigList.K8sListenerSets = ToPtrs(list.Items)
+	}
+
 	if userClient.IsGatewayAPI() && criteria.Include(kubernetes.K8sReferenceGrants) {
 		list := &k8s_networking_v1beta1.ReferenceGrantList{}
 		if err := kubeCache.List(ctx, list, listOpts...); err != nil {
@@ -592,10 +622,12 @@ func (in *IstioConfigService) GetIstioConfigList(ctx context.Context, cluster st
 		DestinationRules:       kubernetes.FilterByNamespaceNames(istioConfigs.DestinationRules, namespaceNames),
 		EnvoyFilters:           kubernetes.FilterByNamespaceNames(istioConfigs.EnvoyFilters, namespaceNames),
 		Gateways:               kubernetes.FilterByNamespaceNames(istioConfigs.Gateways, namespaceNames),
+		K8sBackendTLSPolicies:  kubernetes.FilterByNamespaceNames(istioConfigs.K8sBackendTLSPolicies, namespaceNames),
 		K8sGateways:            kubernetes.FilterByNamespaceNames(istioConfigs.K8sGateways, namespaceNames),
 		K8sGRPCRoutes:          kubernetes.FilterByNamespaceNames(istioConfigs.K8sGRPCRoutes, namespaceNames),
 		K8sHTTPRoutes:          kubernetes.FilterByNamespaceNames(istioConfigs.K8sHTTPRoutes, namespaceNames),
 		K8sInferencePools:      kubernetes.FilterByNamespaceNames(istioConfigs.K8sInferencePools, namespaceNames),
+		K8sListenerSets:        kubernetes.FilterByNamespaceNames(istioConfigs.K8sListenerSets, namespaceNames),
 		K8sReferenceGrants:     kubernetes.FilterByNamespaceNames(istioConfigs.K8sReferenceGrants, namespaceNames),
 		K8sTCPRoutes:           kubernetes.FilterByNamespaceNames(istioConfigs.K8sTCPRoutes, namespaceNames),
 		K8sTLSRoutes:           kubernetes.FilterByNamespaceNames(istioConfigs.K8sTLSRoutes, namespaceNames),
@@ -681,6 +713,13 @@ func (in *IstioConfigService) GetIstioConfigDetails(ctx context.Context, cluster
 			istioConfigDetail.Gateway.APIVersion = kubernetes.Gateways.GroupVersion().String()
 			istioConfigDetail.Object = istioConfigDetail.Gateway
 		}
+	case kubernetes.K8sBackendTLSPolicies:
+		istioConfigDetail.K8sBackendTLSPolicy, err = in.userClients[cluster].GatewayAPI().GatewayV1().BackendTLSPolicies(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sBackendTLSPolicy.Kind = kubernetes.K8sBackendTLSPolicies.Kind
+			istioConfigDetail.K8sBackendTLSPolicy.APIVersion = kubernetes.K8sBackendTLSPolicies.GroupVersion().String()
+			istioConfigDetail.Object = istioConfigDetail.K8sBackendTLSPolicy
+		}
 	case kubernetes.K8sGateways:
 		istioConfigDetail.K8sGateway, err = in.userClients[cluster].GatewayAPI().GatewayV1().Gateways(namespace).Get(ctx, object, getOpts)
 		if err == nil {
@@ -709,6 +748,13 @@ func (in *IstioConfigService) GetIstioConfigDetails(ctx context.Context, cluster
 			istioConfigDetail.K8sInferencePool.APIVersion = kubernetes.K8sInferencePools.GroupVersion().String()
 			istioConfigDetail.Object = istioConfigDetail.K8sInferencePool
 		}
+	case kubernetes.K8sListenerSets:
+		istioConfigDetail.K8sListenerSet, err = in.userClients[cluster].GatewayAPI().GatewayV1().ListenerSets(namespace).Get(ctx, object, getOpts)
+		if err == nil {
+			istioConfigDetail.K8sListenerSet.Kind = kubernetes.K8sListenerSets.Kind
+			istioConfigDetail.K8sListenerSet.APIVersion = kubernetes.K8sListenerSets.GroupVersion().String()
+			istioConfigDetail.Object = istioConfigDetail.K8sListenerSet
+		}
 	case kubernetes.K8sReferenceGrants:
 		istioConfigDetail.K8sReferenceGrant, err = in.userClients[cluster].GatewayAPI().GatewayV1beta1().ReferenceGrants(namespace).Get(ctx, object, getOpts)
 		if err == nil {
@@ -857,6 +903,8 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(ctx context.Context, clust
 		err = userClient.Istio().NetworkingV1alpha3().EnvoyFilters(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.Gateways:
 		err = userClient.Istio().NetworkingV1().Gateways(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sBackendTLSPolicies:
+		err = userClient.GatewayAPI().GatewayV1().BackendTLSPolicies(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sGateways:
 		err = userClient.GatewayAPI().GatewayV1().Gateways(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sGRPCRoutes:
@@ -865,6 +913,8 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(ctx context.Context, clust
 		err = userClient.GatewayAPI().GatewayV1().HTTPRoutes(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sInferencePools:
 		err = userClient.InferenceAPI().InferenceV1().InferencePools(namespace).Delete(ctx, name, delOpts)
+	case kubernetes.K8sListenerSets:
+		err = userClient.GatewayAPI().GatewayV1().ListenerSets(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sReferenceGrants:
 		err = userClient.GatewayAPI().GatewayV1beta1().ReferenceGrants(namespace).Delete(ctx, name, delOpts)
 	case kubernetes.K8sTCPRoutes:
@@ -902,6 +952,8 @@ func (in *IstioConfigService) DeleteIstioConfigDetail(ctx context.Context, clust
 		return err
 	}
 
+	in.recordConfigRevision(cluster, resourceType, details.Object, models.ConfigRevisionOperationDelete)
+
 	in.waitForObjectDeletion(ctx, cluster, details.Object)
 
 	// Remove validations for the object to refresh the validation cache.
@@ -955,12 +1007,44 @@ func (in *IstioConfigService) waitForCacheCreate(ctx context.Context, cluster st
 	}
 }
 
+// UpdateIstioConfigDetail applies the JSON merge patch to the given Istio resource.
+// The previous version of the object is recorded in the config history when it is enabled.
 func (in *IstioConfigService) UpdateIstioConfigDetail(ctx context.Context, cluster, namespace string, resourceType schema.GroupVersionKind, name, jsonPatch string) (models.IstioConfigDetails, error) {
+	var before client.Object
+	if in.conf.KialiFeatureFlags.ConfigHistory.Enabled {
+		// The snapshot is best effort: failing to read the object must not prevent the update.
+		if details, err := in.GetIstioConfigDetails(ctx, cluster, namespace, resourceType, name); err == nil {
+			before = details.Object
+		}
+	}
+
+	istioConfigDetail, err := in.patchIstioConfigDetail(ctx, cluster, namespace, resourceType, name, jsonPatch, meta_v1.PatchOptions{})
+	if err != nil {
+		return istioConfigDetail, err
+	}
+
+	in.recordConfigRevision(cluster, resourceType, before, models.ConfigRevisionOperationUpdate)
+
+	in.waitForCacheUpdate(ctx, cluster, istioConfigDetail.Object)
+
+	// Re-run validations (if enabled) for that object to refresh the validation cache.
+	if in.conf.IsValidationsEnabled() {
+		if _, _, err := in.businessLayer.Validations.ValidateIstioObject(ctx, cluster, namespace, resourceType, name); err != nil {
+			// Logging the error and swallowing it since the object was updated successfully.
+			log.FromContext(ctx).Error().Msgf("Error while validating Istio object: %s", err)
+		}
+	}
+
+	return istioConfigDetail, nil
+}
+
+// patchIstioConfigDetail applies the JSON merge patch with the given options, without any of the post-update steps.
+// Passing DryRun in patchOpts validates the patch on the API server without persisting it.
+func (in *IstioConfigService) patchIstioConfigDetail(ctx context.Context, cluster, namespace string, resourceType schema.GroupVersionKind, name, jsonPatch string, patchOpts meta_v1.PatchOptions) (models.IstioConfigDetails, error) {
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Cluster: cluster, Name: namespace}
 	istioConfigDetail.ObjectGVK = resourceType
 
-	patchOpts := meta_v1.PatchOptions{}
 	patchType := api_types.MergePatchType
 	bytePatch := []byte(jsonPatch)
 
@@ -984,6 +1068,10 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(ctx context.Context, clust
 		istioConfigDetail.Gateway = &networking_v1.Gateway{}
 		istioConfigDetail.Gateway, err = userClient.Istio().NetworkingV1().Gateways(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
 		istioConfigDetail.Object = istioConfigDetail.Gateway
+	case kubernetes.K8sBackendTLSPolicies.String():
+		istioConfigDetail.K8sBackendTLSPolicy = &k8s_networking_v1.BackendTLSPolicy{}
+		istioConfigDetail.K8sBackendTLSPolicy, err = userClient.GatewayAPI().GatewayV1().BackendTLSPolicies(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+		istioConfigDetail.Object = istioConfigDetail.K8sBackendTLSPolicy
 	case kubernetes.K8sGateways.String():
 		istioConfigDetail.K8sGateway = &k8s_networking_v1.Gateway{}
 		istioConfigDetail.K8sGateway, err = userClient.GatewayAPI().GatewayV1().Gateways(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
@@ -1000,6 +1088,10 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(ctx context.Context, clust
 		istioConfigDetail.K8sInferencePool = &k8s_inference_v1.InferencePool{}
 		istioConfigDetail.K8sInferencePool, err = userClient.InferenceAPI().InferenceV1().InferencePools(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
 		istioConfigDetail.Object = istioConfigDetail.K8sInferencePool
+	case kubernetes.K8sListenerSets.String():
+		istioConfigDetail.K8sListenerSet = &k8s_networking_v1.ListenerSet{}
+		istioConfigDetail.K8sListenerSet, err = userClient.GatewayAPI().GatewayV1().ListenerSets(namespace).Patch(ctx, name, patchType, bytePatch, patchOpts)
+		istioConfigDetail.Object = istioConfigDetail.K8sListenerSet
 	case kubernetes.K8sReferenceGrants.String():
 		istioConfigDetail.K8sReferenceGrant = &k8s_networking_v1beta1.ReferenceGrant{}
 		fixedPatch := strings.ReplaceAll(jsonPatch, "\"group\":null", "\"group\":\"\"")
@@ -1064,30 +1156,34 @@ func (in *IstioConfigService) UpdateIstioConfigDetail(ctx context.Context, clust
 	default:
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
+
+	return istioConfigDetail, err
+}
+
+func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, cluster, namespace string, resourceType schema.GroupVersionKind, body []byte) (models.IstioConfigDetails, error) {
+	istioConfigDetail, err := in.createIstioConfigDetail(ctx, cluster, namespace, resourceType, body, meta_v1.CreateOptions{})
 	if err != nil {
 		return istioConfigDetail, err
 	}
 
-	in.waitForCacheUpdate(ctx, cluster, istioConfigDetail.Object)
+	in.waitForCacheCreate(ctx, cluster, istioConfigDetail.Object)
 
-	// Re-run validations (if enabled) for that object to refresh the validation cache.
-	if in.conf.IsValidationsEnabled() {
-		if _, _, err := in.businessLayer.Validations.ValidateIstioObject(ctx, cluster, namespace, resourceType, name); err != nil {
-			// Logging the error and swallowing it since the object was updated successfully.
-			log.FromContext(ctx).Error().Msgf("Error while validating Istio object: %s", err)
-		}
+	// Re-run validations for that object to refresh the validation cache.
+	if _, _, err := in.businessLayer.Validations.ValidateIstioObject(ctx, cluster, namespace, resourceType, istioConfigDetail.Object.GetName()); err != nil {
+		// Logging the error and swallowing it since the object was created successfully.
+		log.FromContext(ctx).Error().Msgf("Error while validating Istio object: %s", err)
 	}
 
 	return istioConfigDetail, nil
 }
 
-func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, cluster, namespace string, resourceType schema.GroupVersionKind, body []byte) (models.IstioConfigDetails, error) {
+// createIstioConfigDetail creates the object with the given options, without any of the post-create steps.
+// Passing DryRun in createOpts validates the object on the API server without persisting it.
+func (in *IstioConfigService) createIstioConfigDetail(ctx context.Context, cluster, namespace string, resourceType schema.GroupVersionKind, body []byte, createOpts meta_v1.CreateOptions) (models.IstioConfigDetails, error) {
 	istioConfigDetail := models.IstioConfigDetails{}
 	istioConfigDetail.Namespace = models.Namespace{Cluster: cluster, Name: namespace}
 	istioConfigDetail.ObjectGVK = resourceType
 
-	createOpts := meta_v1.CreateOptions{}
-
 	userClient := in.userClients[cluster]
 	if userClient == nil {
 		return istioConfigDetail, fmt.Errorf("K8s Client [%s] is not found or is not accessible for Kiali", cluster)
@@ -1095,7 +1191,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 
 	var err error
 
-	var name string
 	switch resourceType.String() {
 	case kubernetes.DestinationRules.String():
 		istioConfigDetail.DestinationRule = &networking_v1.DestinationRule{}
@@ -1105,7 +1200,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.DestinationRule, err = userClient.Istio().NetworkingV1().DestinationRules(namespace).Create(ctx, istioConfigDetail.DestinationRule, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.DestinationRule
-		name = istioConfigDetail.DestinationRule.Name
 	case kubernetes.EnvoyFilters.String():
 		istioConfigDetail.EnvoyFilter = &networking_v1alpha3.EnvoyFilter{}
 		err = json.Unmarshal(body, istioConfigDetail.EnvoyFilter)
@@ -1114,7 +1208,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.EnvoyFilter, err = userClient.Istio().NetworkingV1alpha3().EnvoyFilters(namespace).Create(ctx, istioConfigDetail.EnvoyFilter, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.EnvoyFilter
-		name = istioConfigDetail.EnvoyFilter.Name
 	case kubernetes.Gateways.String():
 		istioConfigDetail.Gateway = &networking_v1.Gateway{}
 		err = json.Unmarshal(body, istioConfigDetail.Gateway)
@@ -1123,7 +1216,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.Gateway, err = userClient.Istio().NetworkingV1().Gateways(namespace).Create(ctx, istioConfigDetail.Gateway, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.Gateway
-		name = istioConfigDetail.Gateway.Name
 	case kubernetes.K8sGateways.String():
 		istioConfigDetail.K8sGateway = &k8s_networking_v1.Gateway{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sGateway)
@@ -1132,7 +1224,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.K8sGateway, err = userClient.GatewayAPI().GatewayV1().Gateways(namespace).Create(ctx, istioConfigDetail.K8sGateway, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.K8sGateway
-		name = istioConfigDetail.K8sGateway.Name
 	case kubernetes.K8sHTTPRoutes.String():
 		istioConfigDetail.K8sHTTPRoute = &k8s_networking_v1.HTTPRoute{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sHTTPRoute)
@@ -1141,7 +1232,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.K8sHTTPRoute, err = userClient.GatewayAPI().GatewayV1().HTTPRoutes(namespace).Create(ctx, istioConfigDetail.K8sHTTPRoute, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.K8sHTTPRoute
-		name = istioConfigDetail.K8sHTTPRoute.Name
 	case kubernetes.K8sInferencePools.String():
 		istioConfigDetail.K8sInferencePool = &k8s_inference_v1.InferencePool{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sInferencePool)
@@ -1150,7 +1240,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.K8sInferencePool, err = userClient.InferenceAPI().InferenceV1().InferencePools(namespace).Create(ctx, istioConfigDetail.K8sInferencePool, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.K8sInferencePool
-		name = istioConfigDetail.K8sInferencePool.Name
 	case kubernetes.K8sGRPCRoutes.String():
 		istioConfigDetail.K8sGRPCRoute = &k8s_networking_v1.GRPCRoute{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sGRPCRoute)
@@ -1159,7 +1248,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.K8sGRPCRoute, err = userClient.GatewayAPI().GatewayV1().GRPCRoutes(namespace).Create(ctx, istioConfigDetail.K8sGRPCRoute, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.K8sGRPCRoute
-		name = istioConfigDetail.K8sGRPCRoute.Name
 	case kubernetes.K8sReferenceGrants.String():
 		istioConfigDetail.K8sReferenceGrant = &k8s_networking_v1beta1.ReferenceGrant{}
 		err = json.Unmarshal(body, istioConfigDetail.K8sReferenceGrant)
@@ -1168,7 +1256,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.K8sReferenceGrant, err = userClient.GatewayAPI().GatewayV1beta1().ReferenceGrants(namespace).Create(ctx, istioConfigDetail.K8sReferenceGrant, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.K8sReferenceGrant
-		name = istioConfigDetail.K8sReferenceGrant.Name
 	case kubernetes.ServiceEntries.String():
 		istioConfigDetail.ServiceEntry = &networking_v1.ServiceEntry{}
 		err = json.Unmarshal(body, istioConfigDetail.ServiceEntry)
@@ -1177,7 +1264,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.ServiceEntry, err = userClient.Istio().NetworkingV1().ServiceEntries(namespace).Create(ctx, istioConfigDetail.ServiceEntry, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.ServiceEntry
-		name = istioConfigDetail.ServiceEntry.Name
 	case kubernetes.Sidecars.String():
 		istioConfigDetail.Sidecar = &networking_v1.Sidecar{}
 		err = json.Unmarshal(body, istioConfigDetail.Sidecar)
@@ -1186,7 +1272,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.Sidecar, err = userClient.Istio().NetworkingV1().Sidecars(namespace).Create(ctx, istioConfigDetail.Sidecar, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.Sidecar
-		name = istioConfigDetail.Sidecar.Name
 	case kubernetes.VirtualServices.String():
 		istioConfigDetail.VirtualService = &networking_v1.VirtualService{}
 		err = json.Unmarshal(body, istioConfigDetail.VirtualService)
@@ -1195,7 +1280,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.VirtualService, err = userClient.Istio().NetworkingV1().VirtualServices(namespace).Create(ctx, istioConfigDetail.VirtualService, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.VirtualService
-		name = istioConfigDetail.VirtualService.Name
 	case kubernetes.WorkloadEntries.String():
 		istioConfigDetail.WorkloadEntry = &networking_v1.WorkloadEntry{}
 		err = json.Unmarshal(body, istioConfigDetail.WorkloadEntry)
@@ -1204,7 +1288,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.WorkloadEntry, err = userClient.Istio().NetworkingV1().WorkloadEntries(namespace).Create(ctx, istioConfigDetail.WorkloadEntry, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.WorkloadEntry
-		name = istioConfigDetail.WorkloadEntry.Name
 	case kubernetes.WorkloadGroups.String():
 		istioConfigDetail.WorkloadGroup = &networking_v1.WorkloadGroup{}
 		err = json.Unmarshal(body, istioConfigDetail.WorkloadGroup)
@@ -1213,7 +1296,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.WorkloadGroup, err = userClient.Istio().NetworkingV1().WorkloadGroups(namespace).Create(ctx, istioConfigDetail.WorkloadGroup, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.WorkloadGroup
-		name = istioConfigDetail.WorkloadGroup.Name
 	case kubernetes.TrafficExtensions.String():
 		istioConfigDetail.TrafficExtension = &extentions_v1alpha1.TrafficExtension{}
 		err = json.Unmarshal(body, istioConfigDetail.TrafficExtension)
@@ -1222,7 +1304,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.TrafficExtension, err = userClient.Istio().ExtensionsV1alpha1().TrafficExtensions(namespace).Create(ctx, istioConfigDetail.TrafficExtension, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.TrafficExtension
-		name = istioConfigDetail.TrafficExtension.Name
 	case kubernetes.WasmPlugins.String():
 		istioConfigDetail.WasmPlugin = &extentions_v1alpha1.WasmPlugin{}
 		err = json.Unmarshal(body, istioConfigDetail.WasmPlugin)
@@ -1231,7 +1312,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.WasmPlugin, err = userClient.Istio().ExtensionsV1alpha1().WasmPlugins(namespace).Create(ctx, istioConfigDetail.WasmPlugin, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.WasmPlugin
-		name = istioConfigDetail.WasmPlugin.Name
 	case kubernetes.Telemetries.String():
 		istioConfigDetail.Telemetry = &telemetry_v1.Telemetry{}
 		err = json.Unmarshal(body, istioConfigDetail.Telemetry)
@@ -1240,7 +1320,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.Telemetry, err = userClient.Istio().TelemetryV1().Telemetries(namespace).Create(ctx, istioConfigDetail.Telemetry, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.Telemetry
-		name = istioConfigDetail.Telemetry.Name
 	case kubernetes.AuthorizationPolicies.String():
 		istioConfigDetail.AuthorizationPolicy = &security_v1.AuthorizationPolicy{}
 		err = json.Unmarshal(body, istioConfigDetail.AuthorizationPolicy)
@@ -1249,7 +1328,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.AuthorizationPolicy, err = userClient.Istio().SecurityV1().AuthorizationPolicies(namespace).Create(ctx, istioConfigDetail.AuthorizationPolicy, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.AuthorizationPolicy
-		name = istioConfigDetail.AuthorizationPolicy.Name
 	case kubernetes.PeerAuthentications.String():
 		istioConfigDetail.PeerAuthentication = &security_v1.PeerAuthentication{}
 		err = json.Unmarshal(body, istioConfigDetail.PeerAuthentication)
@@ -1258,7 +1336,6 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.PeerAuthentication, err = userClient.Istio().SecurityV1().PeerAuthentications(namespace).Create(ctx, istioConfigDetail.PeerAuthentication, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.PeerAuthentication
-		name = istioConfigDetail.PeerAuthentication.Name
 	case kubernetes.RequestAuthentications.String():
 		istioConfigDetail.RequestAuthentication = &security_v1.RequestAuthentication{}
 		err = json.Unmarshal(body, istioConfigDetail.RequestAuthentication)
@@ -1267,23 +1344,11 @@ func (in *IstioConfigService) CreateIstioConfigDetail(ctx context.Context, clust
 		}
 		istioConfigDetail.RequestAuthentication, err = userClient.Istio().SecurityV1().RequestAuthentications(namespace).Create(ctx, istioConfigDetail.RequestAuthentication, createOpts)
 		istioConfigDetail.Object = istioConfigDetail.RequestAuthentication
-		name = istioConfigDetail.RequestAuthentication.Name
 	default:
 		err = fmt.Errorf("object type not found: %v", resourceType)
 	}
-	if err != nil {
-		return istioConfigDetail, err
-	}
-
-	in.waitForCacheCreate(ctx, cluster, istioConfigDetail.Object)
-
-	// Re-run validations for that object to refresh the validation cache.
-	if _, _, err := in.businessLayer.Validations.ValidateIstioObject(ctx, cluster, namespace, resourceType, name); err != nil {
-		// Logging the error and swallowing it since the object was created successfully.
-		log.FromContext(ctx).Error().Msgf("Error while validating Istio object: %s", err)
-	}
 
-	return istioConfigDetail, nil
+	return istioConfigDetail, err
 }
 
 func (in *IstioConfigService) GetIstioConfigPermissions(ctx context.Context, namespaces []string, cluster string) models.IstioConfigPermissions {
@@ -1442,10 +1507,12 @@ func ParseIstioConfigCriteria(objects, labelSelector, workloadSelector string) I
 	defaultInclude := objects == ""
 	criteria := IstioConfigCriteria{}
 	criteria.IncludeGateways = defaultInclude
+	criteria.IncludeK8sBackendTLSPolicies = defaultInclude
 	criteria.IncludeK8sGateways = defaultInclude
 	criteria.IncludeK8sGRPCRoutes = defaultInclude
 	criteria.IncludeK8sHTTPRoutes = defaultInclude
 	criteria.IncludeK8sInferencePools = defaultInclude
+	criteria.IncludeK8sListenerSets = defaultInclude
 	criteria.IncludeK8sReferenceGrants = defaultInclude
 	criteria.IncludeK8sTCPRoutes = defaultInclude
 	criteria.IncludeK8sTLSRoutes = defaultInclude
@@ -1474,6 +1541,9 @@ func ParseIstioConfigCriteria(objects, labelSelector, workloadSelector string) I
 	if checkType(types, kubernetes.Gateways.String()) {
 		criteria.IncludeGateways = true
 	}
+	if checkType(types, kubernetes.K8sBackendTLSPolicies.String()) {
+		criteria.IncludeK8sBackendTLSPolicies = true
+	}
 	if checkType(types, kubernetes.K8sGateways.String()) {
 		criteria.IncludeK8sGateways = true
 	}
@@ -1486,6 +1556,9 @@ func ParseIstioConfigCriteria(objects, labelSelector, workloadSelector string) I
 	if checkType(types, kubernetes.K8sInferencePools.String()) {
 		criteria.IncludeK8sInferencePools = true
 	}
+	if checkType(types, kubernetes.K8sListenerSets.String()) {
+		criteria.IncludeK8sListenerSets = true
+	}
 	if checkType(types, kubernetes.K8sReferenceGrants.String()) {
 		criteria.IncludeK8sReferenceGrants = true
 	}
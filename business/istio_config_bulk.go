@@ -0,0 +1,271 @@
+package business
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util"
+)
+
+// bulkObject is an object matching the criteria of a bulk operation.
+type bulkObject struct {
+	cluster string
+	gvk     schema.GroupVersionKind
+	object  client.Object
+}
+
+// BulkIstioConfig applies the operation of the request to all the Istio config objects matching its criteria.
+// Objects are changed one by one with the user clients; the operation failing for an object does not stop the others.
+// With dryRun, no object is changed: the result lists the matching objects, the errors the operation would hit
+// and the validations that would change.
+func (in *IstioConfigService) BulkIstioConfig(ctx context.Context, req models.IstioConfigBulkRequest, dryRun bool) (models.IstioConfigBulkResult, error) {
+	result := models.IstioConfigBulkResult{Operation: req.Operation, DryRun: dryRun, Objects: []models.IstioConfigBulkObject{}}
+
+	patch, err := bulkPatch(req)
+	if err != nil {
+		return result, err
+	}
+	objects, err := in.selectBulkObjects(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	// Changes are grouped by cluster and namespace to preview the validations of each namespace once
+	type namespaceKey struct{ cluster, namespace string }
+	changed := map[namespaceKey]*models.IstioConfigList{}
+	deleted := map[namespaceKey][]models.IstioValidationKey{}
+	var namespaces []namespaceKey
+
+	for _, obj := range objects {
+		name, namespace := obj.object.GetName(), obj.object.GetNamespace()
+		entry := models.IstioConfigBulkObject{Cluster: obj.cluster, Namespace: namespace, Name: name, ObjectGVK: obj.gvk, Before: obj.object}
+		key := namespaceKey{cluster: obj.cluster, namespace: namespace}
+		if _, found := changed[key]; !found {
+			changed[key] = &models.IstioConfigList{}
+			namespaces = append(namespaces, key)
+		}
+
+		switch {
+		case dryRun && req.Operation == models.IstioConfigBulkDelete:
+			deleted[key] = append(deleted[key], models.BuildKey(obj.gvk, name, namespace, obj.cluster))
+		case dryRun:
+			patched, err := patchObject(obj.object, patch)
+			if err != nil {
+				entry.Error = err.Error()
+			} else {
+				changed[key].Add(patched)
+			}
+		case req.Operation == models.IstioConfigBulkDelete:
+			err = in.DeleteIstioConfigDetail(ctx, obj.cluster, namespace, obj.gvk, name)
+		default:
+			var details models.IstioConfigDetails
+			details, err = in.UpdateIstioConfigDetail(ctx, obj.cluster, namespace, obj.gvk, name, string(patch))
+			entry.After = details.Object
+		}
+		if !dryRun && err != nil {
+			entry.Error = err.Error()
+		}
+		result.Objects = append(result.Objects, entry)
+	}
+
+	if !dryRun || !in.conf.IsValidationsEnabled() {
+		return result, nil
+	}
+	for _, key := range namespaces {
+		changes, err := in.previewValidationChanges(ctx, key.cluster, key.namespace, changed[key], deleted[key])
+		if err != nil {
+			return result, err
+		}
+		result.ValidationChanges = append(result.ValidationChanges, changes...)
+	}
+	return result, nil
+}
+
+// bulkPatch validates the request and returns the JSON merge patch applied to each object, nil for a delete.
+func bulkPatch(req models.IstioConfigBulkRequest) ([]byte, error) {
+	badRequest := func(format string, args ...any) error {
+		return api_errors.NewBadRequest(fmt.Sprintf(format, args...))
+	}
+
+	if len(req.Namespaces) == 0 && req.LabelSelector == "" {
+		return nil, badRequest("namespaces or a label selector are required to select the objects")
+	}
+	for _, selector := range []string{req.LabelSelector, req.WorkloadSelector} {
+		if _, err := labels.ConvertSelectorToLabelsMap(selector); err != nil {
+			return nil, badRequest("invalid selector [%s]: %s", selector, err)
+		}
+	}
+	supported := (&models.IstioConfigList{}).Objects()
+	for _, object := range req.Objects {
+		gvk, err := util.StringToGVK(object)
+		if _, found := supported[gvk]; err != nil || !found {
+			return nil, badRequest("object type [%s] is not supported", object)
+		}
+	}
+
+	switch req.Operation {
+	case models.IstioConfigBulkDelete:
+		return nil, nil
+	case models.IstioConfigBulkPatch:
+		patch := map[string]any{}
+		if err := json.Unmarshal(req.Patch, &patch); err != nil {
+			return nil, badRequest("the patch must be a JSON merge patch object: %s", err)
+		}
+		if metadata, ok := patch["metadata"].(map[string]any); ok {
+			if _, found := metadata["name"]; found {
+				return nil, badRequest("the patch cannot change the name of the objects")
+			}
+			if _, found := metadata["namespace"]; found {
+				return nil, badRequest("the patch cannot change the namespace of the objects")
+			}
+		}
+		return req.Patch, nil
+	case models.IstioConfigBulkMetadata:
+		metadata := map[string]any{}
+		for field, values := range map[string]struct {
+			set    map[string]string
+			remove []string
+		}{
+			"labels":      {set: req.Labels, remove: req.RemoveLabels},
+			"annotations": {set: req.Annotations, remove: req.RemoveAnnotations},
+		} {
+			changes := map[string]any{}
+			for k, v := range values.set {
+				changes[k] = v
+			}
+			for _, k := range values.remove {
+				if _, found := values.set[k]; found {
+					return nil, badRequest("%s [%s] cannot be both set and removed", strings.TrimSuffix(field, "s"), k)
+				}
+				// A null value removes the key in a JSON merge patch
+				changes[k] = nil
+			}
+			if len(changes) > 0 {
+				metadata[field] = changes
+			}
+		}
+		if len(metadata) == 0 {
+			return nil, badRequest("the metadata operation requires labels or annotations to set or remove")
+		}
+		return json.Marshal(map[string]any{"metadata": metadata})
+	default:
+		return nil, badRequest("unknown operation [%s], supported operations are %s, %s and %s",
+			req.Operation, models.IstioConfigBulkPatch, models.IstioConfigBulkMetadata, models.IstioConfigBulkDelete)
+	}
+}
+
+// selectBulkObjects returns the objects matching the criteria of the request, in all its clusters.
+func (in *IstioConfigService) selectBulkObjects(ctx context.Context, req models.IstioConfigBulkRequest) ([]bulkObject, error) {
+	criteria := ParseIstioConfigCriteria(strings.Join(req.Objects, ";"), req.LabelSelector, req.WorkloadSelector)
+	clusters := req.Clusters
+	if len(clusters) == 0 {
+		clusters = []string{in.conf.KubernetesConfig.ClusterName}
+	}
+
+	var objects []bulkObject
+	add := func(cluster string, list *models.IstioConfigList) {
+		for gvk, typeObjects := range list.Objects() {
+			for _, obj := range typeObjects {
+				objects = append(objects, bulkObject{cluster: cluster, gvk: gvk, object: obj})
+			}
+		}
+	}
+	for _, cluster := range clusters {
+		if _, found := in.userClients[cluster]; !found {
+			return nil, api_errors.NewNotFound(schema.GroupResource{Resource: "clusters"}, cluster)
+		}
+		if len(req.Namespaces) == 0 {
+			list, err := in.GetIstioConfigList(ctx, cluster, criteria)
+			if err != nil {
+				return nil, err
+			}
+			add(cluster, list)
+			continue
+		}
+		for _, namespace := range req.Namespaces {
+			list, err := in.GetIstioConfigListForNamespace(ctx, cluster, namespace, criteria)
+			if err != nil {
+				return nil, err
+			}
+			add(cluster, list)
+		}
+	}
+
+	// Objects() is a map: sort for a stable result
+	slices.SortFunc(objects, func(a, b bulkObject) int {
+		return cmp.Or(
+			cmp.Compare(a.cluster, b.cluster),
+			cmp.Compare(a.object.GetNamespace(), b.object.GetNamespace()),
+			cmp.Compare(a.gvk.Kind, b.gvk.Kind),
+			cmp.Compare(a.gvk.Group, b.gvk.Group),
+			cmp.Compare(a.object.GetName(), b.object.GetName()),
+		)
+	})
+	return objects, nil
+}
+
+// patchObject returns a copy of the object with the JSON merge patch applied.
+func patchObject(obj client.Object, patch []byte) (client.Object, error) {
+	original, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := jsonpatch.MergePatch(original, patch)
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply the patch: %w", err)
+	}
+	result := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(client.Object)
+	if err := json.Unmarshal(patched, result); err != nil {
+		return nil, fmt.Errorf("the patched object is not valid: %w", err)
+	}
+	return result, nil
+}
+
+// previewValidationChanges validates the namespace with and without the changes, and returns the validations that differ.
+func (in *IstioConfigService) previewValidationChanges(ctx context.Context, cluster, namespace string, changed *models.IstioConfigList, deleted []models.IstioValidationKey) ([]models.IstioConfigValidationChange, error) {
+	before, err := in.businessLayer.Validations.ValidateNamespaceChanges(ctx, cluster, namespace, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	after, err := in.businessLayer.Validations.ValidateNamespaceChanges(ctx, cluster, namespace, changed, deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.IstioConfigValidationChange{}
+	for _, key := range deleted {
+		if validation, found := before[key]; found {
+			changes = append(changes, models.IstioConfigValidationChange{Before: validation})
+		}
+	}
+	for key, validation := range after {
+		previous := before[key]
+		if previous == nil || previous.Valid != validation.Valid || len(previous.Checks) != len(validation.Checks) {
+			changes = append(changes, models.IstioConfigValidationChange{Before: previous, After: validation})
+		}
+	}
+	slices.SortFunc(changes, func(a, b models.IstioConfigValidationChange) int {
+		return cmp.Compare(validationChangeName(a), validationChangeName(b))
+	})
+	return changes, nil
+}
+
+func validationChangeName(change models.IstioConfigValidationChange) string {
+	validation := change.After
+	if validation == nil {
+		validation = change.Before
+	}
+	return validation.ObjectGVK.Kind + "/" + validation.Name
+}
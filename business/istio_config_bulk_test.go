@@ -0,0 +1,171 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1 "istio.io/api/networking/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func setupBulkIstioConfig(t *testing.T) (*Layer, *kubetest.FakeK8sClient) {
+	t.Helper()
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	migrated := map[string]string{"migrated": "true"}
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs.Labels = migrated
+	vs.Spec.Http = []*api_networking_v1.HTTPRoute{{Route: []*api_networking_v1.HTTPRouteDestination{
+		{Destination: &api_networking_v1.Destination{Host: "reviews", Subset: "v1"}},
+	}}}
+	dr := data.AddSubsetToDestinationRule(data.CreateSubset("v1", "v1"), data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews"))
+	dr.Labels = migrated
+	details := data.CreateEmptyDestinationRule("bookinfo", "details", "details")
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), &reviews, vs, dr, details)
+	return NewLayerBuilder(t, conf).WithClient(k8s).Build(), k8s
+}
+
+func TestBulkIstioConfigMetadata(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	layer, k8s := setupBulkIstioConfig(t)
+
+	ctx := context.Background()
+	result, err := layer.IstioConfig.BulkIstioConfig(ctx, models.IstioConfigBulkRequest{
+		LabelSelector:     "migrated=true",
+		Operation:         models.IstioConfigBulkMetadata,
+		Labels:            map[string]string{"team": "reviewers"},
+		RemoveLabels:      []string{"migrated"},
+		Annotations:       map[string]string{"owner": "jdoe"},
+		RemoveAnnotations: []string{"unknown"},
+	}, false)
+	require.NoError(err)
+	assert.False(result.DryRun)
+	assert.Zero(result.Failed())
+	require.Len(result.Objects, 2)
+	assert.Equal(kubernetes.DestinationRules, result.Objects[0].ObjectGVK)
+	assert.Equal(kubernetes.VirtualServices, result.Objects[1].ObjectGVK)
+	assert.NotNil(result.Objects[1].After)
+
+	vs, err := k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal(map[string]string{"team": "reviewers"}, vs.Labels)
+	assert.Equal(map[string]string{"owner": "jdoe"}, vs.Annotations)
+	details, err := k8s.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "details", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Empty(details.Labels)
+}
+
+func TestBulkIstioConfigPatchDryRun(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	layer, k8s := setupBulkIstioConfig(t)
+
+	ctx := context.Background()
+	result, err := layer.IstioConfig.BulkIstioConfig(ctx, models.IstioConfigBulkRequest{
+		Objects:    []string{kubernetes.DestinationRules.String()},
+		Namespaces: []string{"bookinfo"},
+		Operation:  models.IstioConfigBulkPatch,
+		Patch:      json.RawMessage(`{"spec":{"subsets":null}}`),
+	}, true)
+	require.NoError(err)
+	assert.True(result.DryRun)
+	require.Len(result.Objects, 2)
+	assert.Equal("details", result.Objects[0].Name)
+	assert.Equal("reviews", result.Objects[1].Name)
+
+	// The VirtualService routes to a subset that no longer exists
+	var vsChange *models.IstioConfigValidationChange
+	for i, change := range result.ValidationChanges {
+		if change.After != nil && change.After.ObjectGVK == kubernetes.VirtualServices {
+			vsChange = &result.ValidationChanges[i]
+		}
+	}
+	require.NotNil(vsChange, "validation changes: %+v", result.ValidationChanges)
+	require.NotNil(vsChange.Before)
+	assert.Empty(vsChange.Before.Checks)
+	require.Len(vsChange.After.Checks, 1)
+	assert.Equal(models.WarningSeverity, vsChange.After.Checks[0].Severity)
+	assert.Equal("spec/http[0]/route[0]/destination", vsChange.After.Checks[0].Path)
+
+	// Nothing is changed
+	dr, err := k8s.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Len(dr.Spec.Subsets, 1)
+}
+
+func TestBulkIstioConfigDelete(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	layer, k8s := setupBulkIstioConfig(t)
+
+	ctx := context.Background()
+	request := models.IstioConfigBulkRequest{LabelSelector: "migrated=true", Operation: models.IstioConfigBulkDelete}
+	result, err := layer.IstioConfig.BulkIstioConfig(ctx, request, true)
+	require.NoError(err)
+	require.Len(result.Objects, 2)
+	deleted := 0
+	for _, change := range result.ValidationChanges {
+		if change.After == nil {
+			deleted++
+		}
+	}
+	assert.Equal(2, deleted)
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+
+	result, err = layer.IstioConfig.BulkIstioConfig(ctx, request, false)
+	require.NoError(err)
+	assert.Zero(result.Failed())
+	assert.Empty(result.ValidationChanges)
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+	_, err = k8s.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+	_, err = k8s.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "details", meta_v1.GetOptions{})
+	assert.NoError(err)
+}
+
+func TestBulkIstioConfigBadRequests(t *testing.T) {
+	layer, _ := setupBulkIstioConfig(t)
+
+	cases := map[string]models.IstioConfigBulkRequest{
+		"no namespaces nor label selector": {Operation: models.IstioConfigBulkDelete},
+		"invalid label selector":           {LabelSelector: "app in (reviews)", Operation: models.IstioConfigBulkDelete},
+		"unknown object type":              {Namespaces: []string{"bookinfo"}, Objects: []string{"apps/v1, Kind=Deployment"}, Operation: models.IstioConfigBulkDelete},
+		"unknown operation":                {Namespaces: []string{"bookinfo"}, Operation: "rename"},
+		"patch not an object":              {Namespaces: []string{"bookinfo"}, Operation: models.IstioConfigBulkPatch, Patch: json.RawMessage(`[]`)},
+		"patch renaming the objects":       {Namespaces: []string{"bookinfo"}, Operation: models.IstioConfigBulkPatch, Patch: json.RawMessage(`{"metadata":{"name":"other"}}`)},
+		"no metadata changes":              {Namespaces: []string{"bookinfo"}, Operation: models.IstioConfigBulkMetadata},
+		"label set and removed":            {Namespaces: []string{"bookinfo"}, Operation: models.IstioConfigBulkMetadata, Labels: map[string]string{"a": "b"}, RemoveLabels: []string{"a"}},
+	}
+	for name, request := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := layer.IstioConfig.BulkIstioConfig(context.Background(), request, true)
+			assert.Truef(t, api_errors.IsBadRequest(err), "unexpected error: %v", err)
+		})
+	}
+
+	_, err := layer.IstioConfig.BulkIstioConfig(context.Background(), models.IstioConfigBulkRequest{
+		Namespaces: []string{"bookinfo"},
+		Clusters:   []string{"west"},
+		Operation:  models.IstioConfigBulkDelete,
+	}, true)
+	assert.True(t, api_errors.IsNotFound(err))
+}
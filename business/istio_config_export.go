@@ -0,0 +1,324 @@
+package business
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kiali/kiali/models"
+)
+
+// lastAppliedAnnotation is set by kubectl apply with the whole previous object, it must not be exported.
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// hostFields are the fields holding host names in the Istio and Gateway API objects.
+var hostFields = map[string]bool{"host": true, "hosts": true, "hostname": true, "hostnames": true}
+
+var invalidParameterChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportedObject is an object of an export, with the locations of its parameterised host names.
+type exportedObject struct {
+	gvk      schema.GroupVersionKind
+	name     string
+	manifest map[string]any
+	hosts    []exportedHost
+}
+
+// exportedHost is a parameterised host name at a location of an object.
+type exportedHost struct {
+	// path of the field as JSON pointer tokens, unescaped
+	path      []string
+	parameter string
+}
+
+// ExportIstioConfig exports the Istio config of a namespace matching the criteria as a Helm chart, or as a Kustomize
+// base with an overlay per target cluster. Only the desired state of the objects is exported: the metadata managed by
+// the API server and the status are stripped. The external host names are parameters: Helm values, or patches of the
+// Kustomize overlays, set to the exported values so that they are edited for each environment.
+func (in *IstioConfigService) ExportIstioConfig(ctx context.Context, cluster, namespace string, criteria IstioConfigCriteria, opts models.IstioConfigExportOptions) (*models.IstioConfigExport, error) {
+	if opts.Format != models.IstioConfigExportHelm && opts.Format != models.IstioConfigExportKustomize {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("unknown export format [%s], supported formats are %s and %s",
+			opts.Format, models.IstioConfigExportHelm, models.IstioConfigExportKustomize))
+	}
+	if opts.ChartName == "" {
+		opts.ChartName = namespace
+	}
+	if errs := validation.IsDNS1123Label(opts.ChartName); len(errs) > 0 {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("invalid chart name [%s]: %s", opts.ChartName, strings.Join(errs, ", ")))
+	}
+	if len(opts.Clusters) == 0 {
+		opts.Clusters = []string{cluster}
+	}
+	for _, target := range opts.Clusters {
+		if _, found := in.userClients[target]; !found {
+			return nil, api_errors.NewNotFound(schema.GroupResource{Resource: "clusters"}, target)
+		}
+	}
+
+	list, err := in.GetIstioConfigListForNamespace(ctx, cluster, namespace, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.IstioConfigExport{Format: opts.Format, Cluster: cluster, Namespace: namespace, Hosts: map[string]string{}}
+	parameters := map[string]string{}
+	var objects []exportedObject
+	for gvk, typeObjects := range list.Objects() {
+		for _, obj := range typeObjects {
+			raw, err := models.RevisionObject(gvk, obj)
+			if err != nil {
+				return nil, err
+			}
+			manifest := map[string]any{}
+			if err := json.Unmarshal(raw, &manifest); err != nil {
+				return nil, err
+			}
+			if metadata, ok := manifest["metadata"].(map[string]any); ok {
+				if annotations, ok := metadata["annotations"].(map[string]any); ok {
+					delete(annotations, lastAppliedAnnotation)
+					if len(annotations) == 0 {
+						delete(metadata, "annotations")
+					}
+				}
+			}
+
+			exported := exportedObject{gvk: gvk, name: obj.GetName(), manifest: manifest}
+			walkHosts(manifest["spec"], []string{"spec"}, false, func(path []string, host string) {
+				if !isExternalHost(host, namespace) {
+					return
+				}
+				parameter, found := parameters[host]
+				if !found {
+					parameter = hostParameter(host, export.Hosts)
+					parameters[host] = parameter
+					export.Hosts[parameter] = host
+				}
+				exported.hosts = append(exported.hosts, exportedHost{path: path, parameter: parameter})
+			})
+			objects = append(objects, exported)
+		}
+	}
+	slices.SortFunc(objects, func(a, b exportedObject) int {
+		return cmp.Or(cmp.Compare(a.gvk.Kind, b.gvk.Kind), cmp.Compare(a.gvk.Group, b.gvk.Group), cmp.Compare(a.name, b.name))
+	})
+
+	if opts.Format == models.IstioConfigExportHelm {
+		err = exportHelmChart(export, opts, objects)
+	} else {
+		err = exportKustomize(export, opts, objects)
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(export.Files, func(a, b models.IstioConfigExportFile) int { return cmp.Compare(a.Path, b.Path) })
+	return export, nil
+}
+
+// exportHelmChart adds a chart with a template per object, the host names being values of the chart.
+// Every target cluster gets a values file to install the chart with.
+func exportHelmChart(export *models.IstioConfigExport, opts models.IstioConfigExportOptions, objects []exportedObject) error {
+	chart, err := yaml.Marshal(map[string]any{
+		"apiVersion":  "v2",
+		"name":        opts.ChartName,
+		"description": fmt.Sprintf("Istio config of namespace %s exported by Kiali from cluster %s", export.Namespace, export.Cluster),
+		"type":        "application",
+		"version":     "0.1.0",
+	})
+	if err != nil {
+		return err
+	}
+	values, err := yaml.Marshal(map[string]any{"hosts": export.Hosts})
+	if err != nil {
+		return err
+	}
+	export.Files = append(export.Files,
+		models.IstioConfigExportFile{Path: "Chart.yaml", Content: string(chart)},
+		models.IstioConfigExportFile{Path: "values.yaml", Content: string(values)},
+	)
+	for _, target := range opts.Clusters {
+		export.Files = append(export.Files, models.IstioConfigExportFile{Path: "values-" + target + ".yaml", Content: string(values)})
+	}
+
+	for _, obj := range objects {
+		// The parameterised fields are set to placeholders, replaced by template actions once the YAML is rendered.
+		// Any action delimiter already in the object is escaped first, so that it is rendered as is.
+		placeholders := map[string]string{"__kialiexport_namespace__": "{{ .Release.Namespace | quote }}"}
+		obj.manifest["metadata"].(map[string]any)["namespace"] = "__kialiexport_namespace__"
+		for i, host := range obj.hosts {
+			placeholder := "__kialiexport_host_" + strconv.Itoa(i) + "__"
+			placeholders[placeholder] = "{{ .Values.hosts." + host.parameter + " | quote }}"
+			setPath(obj.manifest, host.path, placeholder)
+		}
+
+		manifest, err := yaml.Marshal(obj.manifest)
+		if err != nil {
+			return fmt.Errorf("unable to render the manifest of %s [%s/%s]: %w", obj.gvk.Kind, export.Namespace, obj.name, err)
+		}
+		template := strings.ReplaceAll(string(manifest), "{{", `{{ "{{" }}`)
+		for placeholder, action := range placeholders {
+			template = strings.ReplaceAll(template, placeholder, action)
+		}
+		export.Files = append(export.Files, models.IstioConfigExportFile{Path: "templates/" + objectFileName(obj), Content: template})
+	}
+	return nil
+}
+
+// exportKustomize adds a base with the objects as exported, and an overlay per target cluster patching the host names.
+func exportKustomize(export *models.IstioConfigExport, opts models.IstioConfigExportOptions, objects []exportedObject) error {
+	resources := make([]string, 0, len(objects))
+	var patches []map[string]any
+	for _, obj := range objects {
+		manifest, err := yaml.Marshal(obj.manifest)
+		if err != nil {
+			return fmt.Errorf("unable to render the manifest of %s [%s/%s]: %w", obj.gvk.Kind, export.Namespace, obj.name, err)
+		}
+		resources = append(resources, objectFileName(obj))
+		export.Files = append(export.Files, models.IstioConfigExportFile{Path: "base/" + objectFileName(obj), Content: string(manifest)})
+
+		if len(obj.hosts) == 0 {
+			continue
+		}
+		operations := make([]map[string]any, 0, len(obj.hosts))
+		for _, host := range obj.hosts {
+			operations = append(operations, map[string]any{"op": "replace", "path": jsonPointer(host.path), "value": export.Hosts[host.parameter]})
+		}
+		patch, err := yaml.Marshal(operations)
+		if err != nil {
+			return err
+		}
+		patches = append(patches, map[string]any{
+			"target": map[string]any{"group": obj.gvk.Group, "version": obj.gvk.Version, "kind": obj.gvk.Kind, "name": obj.name},
+			"patch":  string(patch),
+		})
+	}
+
+	base, err := yaml.Marshal(map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"namespace":  export.Namespace,
+		"resources":  resources,
+	})
+	if err != nil {
+		return err
+	}
+	export.Files = append(export.Files, models.IstioConfigExportFile{Path: "base/kustomization.yaml", Content: string(base)})
+
+	overlay := map[string]any{
+		"apiVersion": "kustomize.config.k8s.io/v1beta1",
+		"kind":       "Kustomization",
+		"resources":  []string{"../../base"},
+	}
+	if len(patches) > 0 {
+		overlay["patches"] = patches
+	}
+	content, err := yaml.Marshal(overlay)
+	if err != nil {
+		return err
+	}
+	for _, target := range opts.Clusters {
+		export.Files = append(export.Files, models.IstioConfigExportFile{Path: "overlays/" + target + "/kustomization.yaml", Content: string(content)})
+	}
+	return nil
+}
+
+// walkHosts calls found with the path of every host name of the value, in the fields named after host names.
+func walkHosts(value any, path []string, inHostField bool, found func(path []string, host string)) {
+	switch v := value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			walkHosts(v[key], append(slices.Clone(path), key), hostFields[key], found)
+		}
+	case []any:
+		for i, item := range v {
+			walkHosts(item, append(slices.Clone(path), strconv.Itoa(i)), inHostField, found)
+		}
+	case string:
+		if inHostField {
+			found(path, v)
+		}
+	}
+}
+
+// isExternalHost returns true for the host names that usually change from an environment to another: the fully
+// qualified names that are not services of the cluster. Sidecar hosts (namespace/host) and wildcards are not.
+func isExternalHost(host, namespace string) bool {
+	name := strings.TrimPrefix(host, "*.")
+	if host == "*" || strings.Contains(host, "/") || !strings.Contains(name, ".") {
+		return false
+	}
+	if strings.Contains(name+".", ".svc.") || strings.HasSuffix(name, ".local") {
+		return false
+	}
+	// Service short names qualified with the namespace
+	return !strings.HasSuffix(name, "."+namespace) || strings.Count(name, ".") > 1
+}
+
+// hostParameter returns a name for the host parameter, unique among the used ones.
+func hostParameter(host string, used map[string]string) string {
+	name := strings.ToLower(host)
+	if strings.HasPrefix(name, "*.") {
+		name = "wildcard." + strings.TrimPrefix(name, "*.")
+	}
+	name = strings.Trim(invalidParameterChars.ReplaceAllString(name, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "host_" + name
+	}
+	parameter := name
+	for i := 2; ; i++ {
+		if _, found := used[parameter]; !found {
+			return parameter
+		}
+		parameter = name + "_" + strconv.Itoa(i)
+	}
+}
+
+// setPath sets the value at the path of the JSON object, the path being valid.
+func setPath(object map[string]any, path []string, value string) {
+	var current any = object
+	for i, token := range path {
+		last := i == len(path)-1
+		switch v := current.(type) {
+		case map[string]any:
+			if last {
+				v[token] = value
+			}
+			current = v[token]
+		case []any:
+			index, _ := strconv.Atoi(token)
+			if last {
+				v[index] = value
+			}
+			current = v[index]
+		}
+	}
+}
+
+func jsonPointer(path []string) string {
+	escaper := strings.NewReplacer("~", "~0", "/", "~1")
+	var sb strings.Builder
+	for _, token := range path {
+		sb.WriteString("/" + escaper.Replace(token))
+	}
+	return sb.String()
+}
+
+// objectFileName returns the name of the manifest file of the object. The Gateway API kinds are prefixed with k8s
+// since some of them have the same kind as Istio ones.
+func objectFileName(obj exportedObject) string {
+	kind := strings.ToLower(obj.gvk.Kind)
+	if strings.HasSuffix(obj.gvk.Group, ".k8s.io") {
+		kind = "k8s" + kind
+	}
+	return kind + "-" + obj.name + ".yaml"
+}
@@ -0,0 +1,157 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1 "istio.io/api/networking/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func setupIstioConfigExport(t *testing.T) *Layer {
+	t.Helper()
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+
+	gw := data.AddServerToGateway(data.CreateServer([]string{"bookinfo.example.com", "*.example.com"}, 80, "http", "HTTP"),
+		data.CreateEmptyGateway("bookinfo-gateway", "bookinfo", map[string]string{"istio": "ingressgateway"}))
+	gw.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"}
+	gw.ResourceVersion = "42"
+	gw.UID = "0a1b2c"
+	vs := data.AddGatewaysToVirtualService([]string{"bookinfo-gateway"},
+		data.CreateEmptyVirtualService("bookinfo", "bookinfo", []string{"bookinfo.example.com", "reviews"}))
+	vs.Spec.Http = []*api_networking_v1.HTTPRoute{{Route: []*api_networking_v1.HTTPRouteDestination{
+		{Destination: &api_networking_v1.Destination{Host: "reviews.bookinfo.svc.cluster.local"}},
+	}}}
+	dr := data.CreateEmptyDestinationRule("bookinfo", "reviews", "reviews.bookinfo")
+	dr.Labels = map[string]string{"app": "reviews"}
+
+	east := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), gw, vs, dr)
+	west := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"))
+	return NewLayerBuilder(t, conf).WithClients(map[string]kubernetes.UserClientInterface{"east": east, "west": west}).Build()
+}
+
+func exportFiles(export *models.IstioConfigExport) map[string]string {
+	files := map[string]string{}
+	for _, file := range export.Files {
+		files[file.Path] = file.Content
+	}
+	return files
+}
+
+func TestExportIstioConfigAsHelmChart(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	layer := setupIstioConfigExport(t)
+
+	export, err := layer.IstioConfig.ExportIstioConfig(context.Background(), "east", "bookinfo", ParseIstioConfigCriteria("", "", ""),
+		models.IstioConfigExportOptions{Format: models.IstioConfigExportHelm, Clusters: []string{"west"}})
+	require.NoError(err)
+	assert.Equal(map[string]string{"bookinfo_example_com": "bookinfo.example.com", "wildcard_example_com": "*.example.com"}, export.Hosts)
+
+	files := exportFiles(export)
+	assert.Len(files, 6)
+	assert.Contains(files["Chart.yaml"], "name: bookinfo\n")
+	assert.Equal(files["values.yaml"], files["values-west.yaml"])
+	assert.Equal("hosts:\n  bookinfo_example_com: bookinfo.example.com\n  wildcard_example_com: '*.example.com'\n", files["values.yaml"])
+
+	assert.Equal(`apiVersion: networking.istio.io/v1
+kind: Gateway
+metadata:
+  name: bookinfo-gateway
+  namespace: {{ .Release.Namespace | quote }}
+spec:
+  selector:
+    istio: ingressgateway
+  servers:
+  - hosts:
+    - {{ .Values.hosts.bookinfo_example_com | quote }}
+    - {{ .Values.hosts.wildcard_example_com | quote }}
+    port:
+      name: http
+      number: 80
+      protocol: HTTP
+`, files["templates/gateway-bookinfo-gateway.yaml"])
+
+	// The services of the mesh are not parameterised
+	vs := files["templates/virtualservice-bookinfo.yaml"]
+	assert.Contains(vs, "  - {{ .Values.hosts.bookinfo_example_com | quote }}\n  - reviews\n")
+	assert.Contains(vs, "host: reviews.bookinfo.svc.cluster.local")
+	assert.Contains(files["templates/destinationrule-reviews.yaml"], "host: reviews.bookinfo\n")
+}
+
+func TestExportIstioConfigAsKustomize(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	layer := setupIstioConfigExport(t)
+
+	export, err := layer.IstioConfig.ExportIstioConfig(context.Background(), "east", "bookinfo", ParseIstioConfigCriteria(kubernetes.Gateways.String(), "", ""),
+		models.IstioConfigExportOptions{Format: models.IstioConfigExportKustomize, Clusters: []string{"east", "west"}})
+	require.NoError(err)
+
+	files := exportFiles(export)
+	paths := make([]string, 0, len(export.Files))
+	for _, file := range export.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.Equal([]string{
+		"base/gateway-bookinfo-gateway.yaml",
+		"base/kustomization.yaml",
+		"overlays/east/kustomization.yaml",
+		"overlays/west/kustomization.yaml",
+	}, paths)
+
+	gw := files["base/gateway-bookinfo-gateway.yaml"]
+	assert.Contains(gw, "namespace: bookinfo\n")
+	assert.Contains(gw, "- bookinfo.example.com\n")
+	assert.NotContains(gw, "last-applied-configuration")
+	assert.NotContains(gw, "resourceVersion")
+	assert.NotContains(gw, "uid")
+	assert.Equal(`apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+namespace: bookinfo
+resources:
+- gateway-bookinfo-gateway.yaml
+`, files["base/kustomization.yaml"])
+	assert.Equal(`apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+patches:
+- patch: |
+    - op: replace
+      path: /spec/servers/0/hosts/0
+      value: bookinfo.example.com
+    - op: replace
+      path: /spec/servers/0/hosts/1
+      value: '*.example.com'
+  target:
+    group: networking.istio.io
+    kind: Gateway
+    name: bookinfo-gateway
+    version: v1
+resources:
+- ../../base
+`, files["overlays/west/kustomization.yaml"])
+}
+
+func TestExportIstioConfigBadRequests(t *testing.T) {
+	layer := setupIstioConfigExport(t)
+	criteria := ParseIstioConfigCriteria("", "", "")
+
+	_, err := layer.IstioConfig.ExportIstioConfig(context.Background(), "east", "bookinfo", criteria, models.IstioConfigExportOptions{Format: "tar"})
+	assert.True(t, api_errors.IsBadRequest(err))
+	_, err = layer.IstioConfig.ExportIstioConfig(context.Background(), "east", "bookinfo", criteria,
+		models.IstioConfigExportOptions{Format: models.IstioConfigExportHelm, ChartName: "Book Info"})
+	assert.True(t, api_errors.IsBadRequest(err))
+	_, err = layer.IstioConfig.ExportIstioConfig(context.Background(), "east", "bookinfo", criteria,
+		models.IstioConfigExportOptions{Format: models.IstioConfigExportHelm, Clusters: []string{"north"}})
+	assert.True(t, api_errors.IsNotFound(err))
+}
@@ -0,0 +1,53 @@
+package business
+
+import (
+	"context"
+	"fmt"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
+)
+
+// ProposeIstioConfigChange proposes a write operation on an Istio object to the GitOps repository instead of
+// applying it: the object resulting from the operation is rendered with a server side dry run, so it is validated
+// as a regular write would be, and its manifest is committed to a new branch. The cluster objects are never modified.
+// body is the object to create for models.GitOpsOperationCreate, where change.Name is taken from it;
+// change.Patch is the JSON merge patch of models.GitOpsOperationUpdate.
+func (in *IstioConfigService) ProposeIstioConfigChange(ctx context.Context, proposer *gitops.Proposer, change gitops.Change, body []byte) (*models.GitOpsChange, error) {
+	dryRunAll := []string{meta_v1.DryRunAll}
+
+	var details models.IstioConfigDetails
+	var err error
+	switch change.Operation {
+	case models.GitOpsOperationCreate:
+		details, err = in.createIstioConfigDetail(ctx, change.Cluster, change.Namespace, change.GVK, body, meta_v1.CreateOptions{DryRun: dryRunAll})
+		if err == nil {
+			change.Name = details.Object.GetName()
+		}
+	case models.GitOpsOperationUpdate:
+		details, err = in.patchIstioConfigDetail(ctx, change.Cluster, change.Namespace, change.GVK, change.Name, change.Patch, meta_v1.PatchOptions{DryRun: dryRunAll})
+	case models.GitOpsOperationDelete:
+		// Only propose the deletion of objects the user can see.
+		_, err = in.GetIstioConfigDetails(ctx, change.Cluster, change.Namespace, change.GVK, change.Name)
+	default:
+		return nil, fmt.Errorf("unsupported GitOps operation [%s]", change.Operation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Operation != models.GitOpsOperationDelete {
+		object, err := models.RevisionObject(change.GVK, details.Object)
+		if err != nil {
+			return nil, err
+		}
+		if change.Manifest, err = yaml.JSONToYAML(object); err != nil {
+			return nil, fmt.Errorf("unable to render the manifest of %s [%s/%s]: %w", change.GVK.Kind, change.Namespace, change.Name, err)
+		}
+	}
+
+	return proposer.Propose(ctx, change)
+}
@@ -0,0 +1,115 @@
+package business
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeclienttesting "k8s.io/client-go/testing"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapifake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestProposeIstioConfigChange(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	for _, args := range [][]string{{"init", "-q", "-b", "main"}, {"commit", "-q", "--allow-empty", "-m", "init"}} {
+		cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+		cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(err, string(out))
+	}
+
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("test"),
+		data.CreateEmptyHTTPRoute("reviews", "test", []string{"reviews.example.com"}),
+	)
+	k8s.GatewayAPIEnabled = true
+	// The fake clientset applies the dry runs: answer them with the object the API server would return
+	dryRuns := 0
+	k8s.GatewayAPI().(*gatewayapifake.Clientset).PrependReactor("patch", "httproutes", func(action kubeclienttesting.Action) (bool, runtime.Object, error) {
+		if len(action.(kubeclienttesting.PatchActionImpl).PatchOptions.DryRun) == 0 {
+			return false, nil, nil
+		}
+		dryRuns++
+		return true, &k8s_networking_v1.HTTPRoute{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "test", ResourceVersion: "7", UID: "1234"},
+			Spec:       k8s_networking_v1.HTTPRouteSpec{Hostnames: []k8s_networking_v1.Hostname{"ratings.example.com"}},
+		}, nil
+	})
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.GitOps.Enabled = true
+	conf.ExternalServices.GitOps.Local.RepositoryPath = repo
+	config.Set(conf)
+	configService := NewLayerBuilder(t, conf).WithClient(k8s).Build().IstioConfig
+	proposer, err := gitops.NewProposer(conf)
+	require.NoError(err)
+
+	ctx := context.Background()
+	cluster := conf.KubernetesConfig.ClusterName
+	change, err := configService.ProposeIstioConfigChange(ctx, proposer, gitops.Change{
+		Operation: models.GitOpsOperationUpdate,
+		Cluster:   cluster,
+		Namespace: "test",
+		Name:      "reviews",
+		GVK:       kubernetes.K8sHTTPRoutes,
+		Patch:     `{"spec":{"hostnames":["ratings.example.com"]}}`,
+	}, nil)
+	require.NoError(err)
+	assert.Equal(1, dryRuns)
+	assert.Equal("east/test/HTTPRoute/reviews.yaml", change.Path)
+	assert.True(strings.HasPrefix(change.Branch, "kiali/update-httproute-east-test-reviews-"), change.Branch)
+
+	show := exec.Command("git", "-C", repo, "show", change.Branch+":"+change.Path)
+	manifest, err := show.Output()
+	require.NoError(err)
+	assert.Contains(string(manifest), "kind: HTTPRoute")
+	assert.Contains(string(manifest), "- ratings.example.com")
+	assert.NotContains(string(manifest), "resourceVersion")
+	assert.NotContains(string(manifest), "uid")
+
+	// The cluster object is untouched
+	route, err := k8s.GatewayAPI().GatewayV1().HTTPRoutes("test").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal([]k8s_networking_v1.Hostname{"reviews.example.com"}, route.Spec.Hostnames)
+
+	// Deleting an unknown object is not proposed
+	_, err = configService.ProposeIstioConfigChange(ctx, proposer, gitops.Change{
+		Operation: models.GitOpsOperationDelete,
+		Cluster:   cluster,
+		Namespace: "test",
+		Name:      "details",
+		GVK:       kubernetes.K8sHTTPRoutes,
+	}, nil)
+	require.Error(err)
+
+	deleted, err := configService.ProposeIstioConfigChange(ctx, proposer, gitops.Change{
+		Operation: models.GitOpsOperationDelete,
+		Cluster:   cluster,
+		Namespace: "test",
+		Name:      "reviews",
+		GVK:       kubernetes.K8sHTTPRoutes,
+	}, nil)
+	// The manifest is not in the base branch yet
+	assert.Nil(deleted)
+	assert.ErrorIs(err, gitops.ErrNoChanges)
+}
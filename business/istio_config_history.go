@@ -0,0 +1,141 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// recordConfigRevision records obj, the version of an Istio object replaced or deleted through Kiali,
+// in the config history.
+func (in *IstioConfigService) recordConfigRevision(cluster string, gvk schema.GroupVersionKind, obj client.Object, operation string) {
+	if !in.conf.KialiFeatureFlags.ConfigHistory.Enabled || obj == nil {
+		return
+	}
+
+	revision, err := models.NewIstioConfigRevision(gvk, obj, operation, models.ConfigRevisionSourceKiali)
+	if err != nil {
+		log.Errorf("Unable to record the revision of %s [%s/%s]: %s", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+		return
+	}
+	in.kialiCache.AddConfigRevision(models.IstioConfigRevisionKey{
+		Cluster:   cluster,
+		Namespace: obj.GetNamespace(),
+		ObjectGVK: gvk,
+		Name:      obj.GetName(),
+	}, revision)
+}
+
+// GetIstioConfigRevisions returns the revision history of an Istio object, oldest first. Every revision
+// includes the diff against the next one; the latest revision is compared with the current object.
+func (in *IstioConfigService) GetIstioConfigRevisions(ctx context.Context, cluster, namespace string, gvk schema.GroupVersionKind, name string) (models.IstioConfigRevisions, error) {
+	key := models.IstioConfigRevisionKey{Cluster: cluster, Namespace: namespace, ObjectGVK: gvk, Name: name}
+	result := models.IstioConfigRevisions{IstioConfigRevisionKey: key, Revisions: []models.IstioConfigRevision{}}
+
+	// The history of a deleted object must not be visible to users who cannot access its namespace.
+	if _, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster); err != nil {
+		return result, err
+	}
+
+	revisions := in.kialiCache.GetConfigRevisions(key)
+	current, err := in.GetIstioConfigDetails(ctx, cluster, namespace, gvk, name)
+	if err != nil {
+		// A deleted object still has a history.
+		if !api_errors.IsNotFound(err) || len(revisions) == 0 {
+			return result, err
+		}
+	} else {
+		if result.Current, err = models.RevisionObject(gvk, current.Object); err != nil {
+			return result, err
+		}
+	}
+
+	result.Revisions = make([]models.IstioConfigRevision, len(revisions))
+	next := result.Current
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		if next == nil {
+			revision.Diff = json.RawMessage("null")
+		} else if revision.Diff, err = jsonpatch.CreateMergePatch(revision.Object, next); err != nil {
+			return result, fmt.Errorf("unable to compute the diff of revision [%d]: %w", revision.Revision, err)
+		}
+		result.Revisions[i] = revision
+		next = revision.Object
+	}
+
+	return result, nil
+}
+
+// RollbackIstioConfig restores a revision of an Istio object. An existing object is restored through
+// UpdateIstioConfigDetail, so the replaced version is recorded as a new revision, and a deleted object is
+// re-created. The change is first validated with a server side dry run; when dryRun is true nothing
+// else is done and the object resulting from the dry run is returned.
+func (in *IstioConfigService) RollbackIstioConfig(ctx context.Context, cluster, namespace string, gvk schema.GroupVersionKind, name string, revisionNumber int, dryRun bool) (models.IstioConfigDetails, error) {
+	operation, body, err := in.RollbackIstioConfigChange(ctx, cluster, namespace, gvk, name, revisionNumber)
+	if err != nil {
+		return models.IstioConfigDetails{}, err
+	}
+
+	dryRunAll := []string{meta_v1.DryRunAll}
+	if operation == models.GitOpsOperationCreate {
+		details, err := in.createIstioConfigDetail(ctx, cluster, namespace, gvk, body, meta_v1.CreateOptions{DryRun: dryRunAll})
+		if err != nil || dryRun {
+			return details, err
+		}
+		return in.CreateIstioConfigDetail(ctx, cluster, namespace, gvk, body)
+	}
+
+	details, err := in.patchIstioConfigDetail(ctx, cluster, namespace, gvk, name, string(body), meta_v1.PatchOptions{DryRun: dryRunAll})
+	if err != nil || dryRun {
+		return details, err
+	}
+	return in.UpdateIstioConfigDetail(ctx, cluster, namespace, gvk, name, string(body))
+}
+
+// RollbackIstioConfigChange returns the write operation restoring a revision of an Istio object, without
+// applying it: models.GitOpsOperationCreate with the object of the revision when the object was deleted,
+// or models.GitOpsOperationUpdate with the JSON merge patch turning the current object into the revision.
+func (in *IstioConfigService) RollbackIstioConfigChange(ctx context.Context, cluster, namespace string, gvk schema.GroupVersionKind, name string, revisionNumber int) (string, []byte, error) {
+	key := models.IstioConfigRevisionKey{Cluster: cluster, Namespace: namespace, ObjectGVK: gvk, Name: name}
+	if _, err := in.businessLayer.Namespace.GetClusterNamespace(ctx, namespace, cluster); err != nil {
+		return "", nil, err
+	}
+
+	var target *models.IstioConfigRevision
+	for _, revision := range in.kialiCache.GetConfigRevisions(key) {
+		if revision.Revision == revisionNumber {
+			target = &revision
+			break
+		}
+	}
+	if target == nil {
+		return "", nil, api_errors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind + " revision"}, fmt.Sprintf("%s/%d", name, revisionNumber))
+	}
+
+	current, err := in.GetIstioConfigDetails(ctx, cluster, namespace, gvk, name)
+	if err != nil {
+		if !api_errors.IsNotFound(err) {
+			return "", nil, err
+		}
+		return models.GitOpsOperationCreate, target.Object, nil
+	}
+
+	currentObject, err := models.RevisionObject(gvk, current.Object)
+	if err != nil {
+		return "", nil, err
+	}
+	patch, err := jsonpatch.CreateMergePatch(currentObject, target.Object)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to compute the rollback patch of revision [%d]: %w", revisionNumber, err)
+	}
+	return models.GitOpsOperationUpdate, patch, nil
+}
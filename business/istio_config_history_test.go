@@ -0,0 +1,177 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubeclienttesting "k8s.io/client-go/testing"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapifake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+// dryRunReactor emulates the API server dry runs, which the fake clientset applies as regular requests.
+// When reject is true the dry runs fail as if the object was invalid.
+func dryRunReactor(dryRuns *int, reject bool) kubeclienttesting.ReactionFunc {
+	return func(action kubeclienttesting.Action) (bool, runtime.Object, error) {
+		var dryRun []string
+		switch a := action.(type) {
+		case kubeclienttesting.PatchActionImpl:
+			dryRun = a.PatchOptions.DryRun
+		case kubeclienttesting.CreateActionImpl:
+			dryRun = a.CreateOptions.DryRun
+		}
+		if len(dryRun) == 0 {
+			return false, nil, nil
+		}
+		*dryRuns++
+		if reject {
+			return true, nil, api_errors.NewInvalid(kubernetes.K8sHTTPRoutes.GroupKind(), "reviews", field.ErrorList{field.Invalid(field.NewPath("spec"), nil, "rejected by webhook")})
+		}
+		return true, &k8s_networking_v1.HTTPRoute{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "test"}}, nil
+	}
+}
+
+func setupConfigHistoryService(t *testing.T, dryRuns *int, reject bool) (IstioConfigService, *kubetest.FakeK8sClient, *config.Config) {
+	t.Helper()
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("test"),
+		data.CreateEmptyHTTPRoute("reviews", "test", []string{"reviews.example.com"}),
+	)
+	k8s.GatewayAPIEnabled = true
+	gatewayAPIClient := k8s.GatewayAPI().(*gatewayapifake.Clientset)
+	gatewayAPIClient.PrependReactor("patch", "httproutes", dryRunReactor(dryRuns, reject))
+	gatewayAPIClient.PrependReactor("create", "httproutes", dryRunReactor(dryRuns, reject))
+
+	conf := config.NewConfig()
+	config.Set(conf)
+	return NewLayerBuilder(t, conf).WithClient(k8s).Build().IstioConfig, k8s, conf
+}
+
+func TestUpdateRecordsRevisionAndRollback(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	dryRuns := 0
+	configService, k8s, conf := setupConfigHistoryService(t, &dryRuns, false)
+	cluster := conf.KubernetesConfig.ClusterName
+	ctx := context.Background()
+
+	_, err := configService.UpdateIstioConfigDetail(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", `{"spec":{"hostnames":["ratings.example.com"]}}`)
+	require.NoError(err)
+
+	revisions, err := configService.GetIstioConfigRevisions(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews")
+	require.NoError(err)
+	require.Len(revisions.Revisions, 1)
+	revision := revisions.Revisions[0]
+	assert.Equal(1, revision.Revision)
+	assert.Equal(models.ConfigRevisionOperationUpdate, revision.Operation)
+	assert.Equal(models.ConfigRevisionSourceKiali, revision.Source)
+	assert.Contains(string(revision.Object), `"hostnames":["reviews.example.com"]`)
+	assert.NotContains(string(revision.Object), "resourceVersion")
+	assert.Contains(string(revision.Diff), `"hostnames":["ratings.example.com"]`)
+	assert.Contains(string(revisions.Current), `"hostnames":["ratings.example.com"]`)
+
+	// A dry run does not change the object
+	_, err = configService.RollbackIstioConfig(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", 1, true)
+	require.NoError(err)
+	assert.Equal(1, dryRuns)
+	route, err := k8s.GatewayAPI().GatewayV1().HTTPRoutes("test").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal([]k8s_networking_v1.Hostname{"ratings.example.com"}, route.Spec.Hostnames)
+
+	restored, err := configService.RollbackIstioConfig(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", 1, false)
+	require.NoError(err)
+	assert.Equal(2, dryRuns)
+	assert.Equal([]k8s_networking_v1.Hostname{"reviews.example.com"}, restored.K8sHTTPRoute.Spec.Hostnames)
+
+	// The rollback goes through the update path so it can be rolled back too
+	revisions, err = configService.GetIstioConfigRevisions(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews")
+	require.NoError(err)
+	require.Len(revisions.Revisions, 2)
+	assert.Contains(string(revisions.Revisions[1].Object), `"hostnames":["ratings.example.com"]`)
+}
+
+func TestRollbackRejectedByDryRun(t *testing.T) {
+	require := require.New(t)
+
+	dryRuns := 0
+	configService, k8s, conf := setupConfigHistoryService(t, &dryRuns, true)
+	cluster := conf.KubernetesConfig.ClusterName
+	ctx := context.Background()
+
+	_, err := configService.UpdateIstioConfigDetail(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", `{"spec":{"hostnames":["ratings.example.com"]}}`)
+	require.NoError(err)
+
+	_, err = configService.RollbackIstioConfig(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", 1, false)
+	require.Error(err)
+	require.True(api_errors.IsInvalid(err))
+
+	route, err := k8s.GatewayAPI().GatewayV1().HTTPRoutes("test").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	require.Equal([]k8s_networking_v1.Hostname{"ratings.example.com"}, route.Spec.Hostnames)
+}
+
+func TestDeleteRecordsRevisionAndRollbackRecreates(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	dryRuns := 0
+	configService, k8s, conf := setupConfigHistoryService(t, &dryRuns, false)
+	cluster := conf.KubernetesConfig.ClusterName
+	ctx := context.Background()
+
+	require.NoError(configService.DeleteIstioConfigDetail(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews"))
+
+	revisions, err := configService.GetIstioConfigRevisions(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews")
+	require.NoError(err)
+	require.Len(revisions.Revisions, 1)
+	assert.Nil(revisions.Current)
+	assert.Equal(models.ConfigRevisionOperationDelete, revisions.Revisions[0].Operation)
+	assert.Equal("null", string(revisions.Revisions[0].Diff))
+
+	_, err = configService.RollbackIstioConfig(ctx, cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", 1, false)
+	require.NoError(err)
+	assert.Equal(1, dryRuns)
+
+	route, err := k8s.GatewayAPI().GatewayV1().HTTPRoutes("test").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal([]k8s_networking_v1.Hostname{"reviews.example.com"}, route.Spec.Hostnames)
+}
+
+func TestRollbackUnknownRevision(t *testing.T) {
+	dryRuns := 0
+	configService, _, conf := setupConfigHistoryService(t, &dryRuns, false)
+
+	_, err := configService.RollbackIstioConfig(context.Background(), conf.KubernetesConfig.ClusterName, "test", kubernetes.K8sHTTPRoutes, "reviews", 3, false)
+	require.Error(t, err)
+	assert.True(t, api_errors.IsNotFound(err))
+	assert.Zero(t, dryRuns)
+}
+
+func TestConfigHistoryDisabled(t *testing.T) {
+	dryRuns := 0
+	configService, _, conf := setupConfigHistoryService(t, &dryRuns, false)
+	conf.KialiFeatureFlags.ConfigHistory.Enabled = false
+	cluster := conf.KubernetesConfig.ClusterName
+
+	_, err := configService.UpdateIstioConfigDetail(context.Background(), cluster, "test", kubernetes.K8sHTTPRoutes, "reviews", `{"spec":{"hostnames":["ratings.example.com"]}}`)
+	require.NoError(t, err)
+	assert.Empty(t, configService.kialiCache.GetConfigRevisions(models.IstioConfigRevisionKey{
+		Cluster:   cluster,
+		Namespace: "test",
+		ObjectGVK: kubernetes.K8sHTTPRoutes,
+		Name:      "reviews",
+	}))
+}
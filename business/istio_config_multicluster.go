@@ -0,0 +1,82 @@
+package business
+
+import (
+	"context"
+	"sync"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/models"
+)
+
+// IstioConfigClusterWrite is the outcome of an Istio config write operation in one cluster.
+type IstioConfigClusterWrite struct {
+	Cluster string
+	// Details of the object resulting from a create or update
+	Details models.IstioConfigDetails
+	// Validation of the resulting object, nil when it is not known
+	Validation *models.IstioValidation
+	// Err is the error of the operation in this cluster, nil when it succeeded
+	Err error
+}
+
+// CreateIstioConfigDetailInClusters creates the object in every cluster with the user clients of each cluster.
+// The results are in the order of clusters; the operation failing in a cluster does not stop the others.
+func (in *IstioConfigService) CreateIstioConfigDetailInClusters(ctx context.Context, clusters []string, namespace string, resourceType schema.GroupVersionKind, body []byte) []IstioConfigClusterWrite {
+	return in.writeInClusters(clusters, namespace, resourceType, func(cluster string) (models.IstioConfigDetails, error) {
+		return in.CreateIstioConfigDetail(ctx, cluster, namespace, resourceType, body)
+	})
+}
+
+// UpdateIstioConfigDetailInClusters applies the JSON merge patch to the object in every cluster with the user clients of each cluster.
+// The results are in the order of clusters; the operation failing in a cluster does not stop the others.
+func (in *IstioConfigService) UpdateIstioConfigDetailInClusters(ctx context.Context, clusters []string, namespace string, resourceType schema.GroupVersionKind, name, jsonPatch string) []IstioConfigClusterWrite {
+	return in.writeInClusters(clusters, namespace, resourceType, func(cluster string) (models.IstioConfigDetails, error) {
+		return in.UpdateIstioConfigDetail(ctx, cluster, namespace, resourceType, name, jsonPatch)
+	})
+}
+
+// DeleteIstioConfigDetailInClusters deletes the object in every cluster with the user clients of each cluster.
+// The results are in the order of clusters; the operation failing in a cluster does not stop the others.
+func (in *IstioConfigService) DeleteIstioConfigDetailInClusters(ctx context.Context, clusters []string, namespace string, resourceType schema.GroupVersionKind, name string) []IstioConfigClusterWrite {
+	return in.writeInClusters(clusters, namespace, resourceType, func(cluster string) (models.IstioConfigDetails, error) {
+		return models.IstioConfigDetails{}, in.DeleteIstioConfigDetail(ctx, cluster, namespace, resourceType, name)
+	})
+}
+
+// writeInClusters runs the write operation in every cluster in parallel. Create and update refresh the
+// validation of the object, which is then read back from the validations cache.
+func (in *IstioConfigService) writeInClusters(clusters []string, namespace string, resourceType schema.GroupVersionKind, write func(cluster string) (models.IstioConfigDetails, error)) []IstioConfigClusterWrite {
+	results := make([]IstioConfigClusterWrite, len(clusters))
+
+	wg := sync.WaitGroup{}
+	for i, cluster := range clusters {
+		results[i].Cluster = cluster
+		if _, ok := in.userClients[cluster]; !ok {
+			results[i].Err = api_errors.NewNotFound(schema.GroupResource{Resource: "clusters"}, cluster)
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *IstioConfigClusterWrite) {
+			defer wg.Done()
+			result.Details, result.Err = write(result.Cluster)
+			if result.Err != nil || result.Details.Object == nil {
+				return
+			}
+			key := models.IstioValidationKey{
+				ObjectGVK: resourceType,
+				Name:      result.Details.Object.GetName(),
+				Namespace: namespace,
+				Cluster:   result.Cluster,
+			}
+			if validation, found := in.kialiCache.Validations().Get(key); found {
+				result.Validation = validation
+			}
+		}(&results[i])
+	}
+	wg.Wait()
+
+	return results
+}
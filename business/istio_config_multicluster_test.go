@@ -0,0 +1,58 @@
+package business
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+)
+
+func TestCreateAndDeleteIstioConfigInClusters(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	east := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"))
+	west := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"))
+	configService := NewLayerBuilder(t, conf).WithClients(map[string]kubernetes.UserClientInterface{"east": east, "west": west}).Build().IstioConfig
+
+	ctx := context.Background()
+	body := []byte(`{"metadata":{"name":"reviews","namespace":"bookinfo"},"spec":{"host":"reviews"}}`)
+	results := configService.CreateIstioConfigDetailInClusters(ctx, []string{"west", "north", "east"}, "bookinfo", kubernetes.DestinationRules, body)
+	require.Len(results, 3)
+	assert.Equal("west", results[0].Cluster)
+	assert.NoError(results[0].Err)
+	assert.Equal("reviews", results[0].Details.Object.GetName())
+	assert.Equal("north", results[1].Cluster)
+	assert.True(api_errors.IsNotFound(results[1].Err))
+	assert.Equal("east", results[2].Cluster)
+	assert.NoError(results[2].Err)
+
+	for _, client := range []kubernetes.UserClientInterface{east, west} {
+		_, err := client.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+		require.NoError(err)
+	}
+
+	// Creating it again fails in every cluster
+	results = configService.CreateIstioConfigDetailInClusters(ctx, []string{"east", "west"}, "bookinfo", kubernetes.DestinationRules, body)
+	for _, result := range results {
+		assert.Truef(api_errors.IsAlreadyExists(result.Err), "cluster %s: %v", result.Cluster, result.Err)
+	}
+
+	results = configService.DeleteIstioConfigDetailInClusters(ctx, []string{"east"}, "bookinfo", kubernetes.DestinationRules, "reviews")
+	require.Len(results, 1)
+	assert.NoError(results[0].Err)
+	_, err := east.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+	_, err = west.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	assert.NoError(err)
+}
@@ -342,11 +342,15 @@ func (in *IstioValidationsService) Validate(ctx context.Context, cluster string,
 		IncludeAuthorizationPolicies:  true,
 		IncludeDestinationRules:       true,
 		IncludeGateways:               true,
+		IncludeK8sBackendTLSPolicies:  true,
 		IncludeK8sGateways:            true,
 		IncludeK8sGRPCRoutes:          true,
 		IncludeK8sHTTPRoutes:          true,
 		IncludeK8sInferencePools:      true,
+		IncludeK8sListenerSets:        true,
 		IncludeK8sReferenceGrants:     true,
+		IncludeK8sTCPRoutes:           true,
+		IncludeK8sTLSRoutes:           true,
 		IncludePeerAuthentications:    true,
 		IncludeRequestAuthentications: true,
 		IncludeServiceEntries:         true,
@@ -500,6 +504,9 @@ func detectClusterConfigChange(vInfo *validationInfo) bool {
 	for _, c := range config.Gateways {
 		change = vInfo.update("GW", cluster, c.Namespace, c.Name, c.ResourceVersion) || change
 	}
+	for _, c := range config.K8sBackendTLSPolicies {
+		change = vInfo.update("KBTLS", cluster, c.Namespace, c.Name, c.ResourceVersion) || change
+	}
 	for _, c := range config.K8sGateways {
 		change = vInfo.update("KG", cluster, c.Namespace, c.Name, c.ResourceVersion) || change
 	}
@@ -509,6 +516,9 @@ func detectClusterConfigChange(vInfo *validationInfo) bool {
 	for _, c := range config.K8sHTTPRoutes {
 		change = vInfo.update("KHTTP", cluster, c.Namespace, c.Name, c.ResourceVersion) || change
 	}
+	for _, c := range config.K8sListenerSets {
+		change = vInfo.update("KLS", cluster, c.Namespace, c.Name, c.ResourceVersion) || change
+	}
 	for _, c := range config.K8sReferenceGrants {
 		change = vInfo.update("KRG", cluster, c.Namespace, c.Name, c.ResourceVersion) || change
 	}
@@ -556,9 +566,11 @@ func detectClusterConfigChange(vInfo *validationInfo) bool {
 		len(config.DestinationRules) +
 		len(config.EnvoyFilters) +
 		len(config.Gateways) +
+		len(config.K8sBackendTLSPolicies) +
 		len(config.K8sGateways) +
 		len(config.K8sGRPCRoutes) +
 		len(config.K8sHTTPRoutes) +
+		len(config.K8sListenerSets) +
 		len(config.K8sReferenceGrants) +
 		len(config.K8sTCPRoutes) +
 		len(config.K8sTLSRoutes) +
@@ -609,10 +621,14 @@ func (in *IstioValidationsService) getAllObjectCheckers(vInfo *validationInfo) (
 		checkers.AuthorizationPolicyChecker{AuthorizationPolicies: rbacDetails.AuthorizationPolicies, Cluster: cluster, Conf: conf, IdentityDomain: identityDomain, KnownTrustDomains: vInfo.knownTrustDomains, KubeServiceHosts: kubeServiceHosts, MtlsDetails: *mtlsDetails, Namespaces: nsNames, PolicyAllowAny: policyAllowAny, ServiceAccounts: vInfo.saMap, ServiceEntries: istioConfigList.ServiceEntries, Services: services, VirtualServices: istioConfigList.VirtualServices, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.DestinationRulesChecker{Cluster: cluster, Conf: conf, DestinationRules: istioConfigList.DestinationRules, IdentityDomain: identityDomain, ImportScope: importScope, MTLSDetails: *mtlsDetails, Namespaces: namespaces},
 		checkers.GatewayChecker{Cluster: cluster, Conf: conf, Gateways: istioConfigList.Gateways, IsGatewayToNamespace: gatewayToNamespace, WorkloadsPerNamespace: workloadsPerNamespace},
-		checkers.K8sGatewayChecker{Cluster: cluster, GatewayClasses: in.kialiCache.GatewayAPIClasses(cluster), K8sGateways: istioConfigList.K8sGateways},
+		checkers.K8sBackendTLSPolicyChecker{Cluster: cluster, K8sBackendTLSPolicies: istioConfigList.K8sBackendTLSPolicies, Services: services},
+		checkers.K8sGatewayChecker{Cluster: cluster, GatewayClasses: in.kialiCache.GatewayAPIClasses(cluster), K8sGateways: istioConfigList.K8sGateways, K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
 		checkers.K8sGRPCRouteChecker{Cluster: cluster, Conf: conf, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, Services: services},
 		checkers.K8sHTTPRouteChecker{Cluster: cluster, Conf: conf, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, Services: services},
+		checkers.K8sListenerSetChecker{Cluster: cluster, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sListenerSets: istioConfigList.K8sListenerSets, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
 		checkers.K8sReferenceGrantChecker{Cluster: cluster, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
+		checkers.K8sTCPRouteChecker{Cluster: cluster, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sListenerSets: istioConfigList.K8sListenerSets, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, Namespaces: namespaces, Services: services},
+		checkers.K8sTLSRouteChecker{Cluster: cluster, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sListenerSets: istioConfigList.K8sListenerSets, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTLSRoutes: istioConfigList.K8sTLSRoutes, Namespaces: namespaces, Services: services},
 		checkers.NoServiceChecker{AuthorizationDetails: rbacDetails, Cluster: cluster, Conf: conf, IdentityDomain: identityDomain, IstioConfigList: istioConfigList, KubeServiceHosts: kubeServiceHosts, Namespaces: namespaces, PolicyAllowAny: policyAllowAny, Services: services, WorkloadsPerNamespace: workloadsPerNamespace},
 		checkers.NewPeerAuthenticationChecker(cluster, conf, identityDomain, vInfo.clusterInfo.rootNamespaces, *mtlsDetails, mtlsDetails.PeerAuthentications, workloadsPerNamespace),
 		checkers.RequestAuthenticationChecker{Cluster: cluster, RequestAuthentications: istioConfigList.RequestAuthentications, WorkloadsPerNamespace: workloadsPerNamespace},
@@ -631,6 +647,98 @@ func (in *IstioValidationsService) getAllObjectCheckers(vInfo *validationInfo) (
 // ValidateIstioObject validates a single Istio object of the given type with the given name found in the given namespace. Note that
 // even validating a single object requires a fair amount of information, as it may interact with many other configs.
 func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, cluster, namespace string, objectGVK schema.GroupVersionKind, object string) (models.IstioValidations, models.IstioReferencesMap, error) {
+	return in.validateIstioObject(ctx, cluster, namespace, objectGVK, object, nil)
+}
+
+// ValidateProposedIstioObjects validates Istio objects that are not applied yet, as if they were in the cluster: they are
+// added to the cluster config, replacing the objects with the same namespace and name. Objects without a namespace are
+// in the given namespace. The validations are not stored in the validations cache.
+func (in *IstioValidationsService) ValidateProposedIstioObjects(ctx context.Context, cluster, namespace string, proposed *models.IstioConfigList) (models.IstioValidations, error) {
+	validations := models.IstioValidations{}
+	changes := &proposedChanges{objects: proposed}
+	for gvk, objects := range proposed.Objects() {
+		for _, obj := range objects {
+			objectNamespace := obj.GetNamespace()
+			if objectNamespace == "" {
+				objectNamespace = namespace
+			}
+			objectValidations, _, err := in.validateIstioObject(ctx, cluster, objectNamespace, gvk, obj.GetName(), changes)
+			if err != nil {
+				return nil, err
+			}
+			validations.MergeValidations(objectValidations)
+		}
+	}
+	return validations, nil
+}
+
+// ValidateNamespaceChanges validates all the Istio objects of a namespace as if the changed objects replaced the ones
+// with the same name and the deleted objects were removed from the cluster. The deleted objects are not validated.
+// The validations are not stored in the validations cache.
+func (in *IstioValidationsService) ValidateNamespaceChanges(ctx context.Context, cluster, namespace string, changed *models.IstioConfigList, deleted []models.IstioValidationKey) (models.IstioValidations, error) {
+	list, err := in.istioConfig.GetIstioConfigListForNamespace(ctx, cluster, namespace, ParseIstioConfigCriteria("", "", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &proposedChanges{objects: changed, deleted: deleted}
+	validations := models.IstioValidations{}
+	for gvk, objects := range list.Objects() {
+		for _, obj := range objects {
+			if slices.Contains(deleted, models.BuildKey(gvk, obj.GetName(), namespace, cluster)) {
+				continue
+			}
+			objectValidations, _, err := in.validateIstioObject(ctx, cluster, namespace, gvk, obj.GetName(), changes)
+			if err != nil {
+				return nil, err
+			}
+			validations.MergeValidations(objectValidations)
+		}
+	}
+	return validations, nil
+}
+
+// proposedChanges are Istio config changes that are not applied yet, validated as if they were.
+type proposedChanges struct {
+	// objects replace the cluster objects with the same namespace and name, or are added to them
+	objects *models.IstioConfigList
+	// deleted objects are removed from the cluster objects
+	deleted []models.IstioValidationKey
+}
+
+// withProposedObjects returns a copy of the config list with the proposed changes.
+func withProposedObjects(list *models.IstioConfigList, cluster string, proposed *proposedChanges) *models.IstioConfigList {
+	merged := &models.IstioConfigList{IstioValidations: list.IstioValidations}
+	replaced := map[models.IstioValidationKey]bool{}
+	for _, key := range proposed.deleted {
+		replaced[key] = true
+	}
+	if proposed.objects != nil {
+		for gvk, objects := range proposed.objects.Objects() {
+			for _, obj := range objects {
+				replaced[models.BuildKey(gvk, obj.GetName(), obj.GetNamespace(), cluster)] = true
+			}
+		}
+	}
+
+	for gvk, objects := range list.Objects() {
+		for _, obj := range objects {
+			if !replaced[models.BuildKey(gvk, obj.GetName(), obj.GetNamespace(), cluster)] {
+				merged.Add(obj)
+			}
+		}
+	}
+	if proposed.objects != nil {
+		for _, objects := range proposed.objects.Objects() {
+			for _, obj := range objects {
+				merged.Add(obj)
+			}
+		}
+	}
+	return merged
+}
+
+func (in *IstioValidationsService) validateIstioObject(ctx context.Context, cluster, namespace string, objectGVK schema.GroupVersionKind, object string, proposed *proposedChanges) (models.IstioValidations, models.IstioReferencesMap, error) {
 	var end observability.EndFunc
 	ctx, end = observability.StartSpan(ctx, "GetIstioObjectValidations",
 		observability.Attribute("package", "business"),
@@ -693,11 +801,15 @@ func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, clus
 		IncludeAuthorizationPolicies:  true,
 		IncludeDestinationRules:       true,
 		IncludeGateways:               true,
+		IncludeK8sBackendTLSPolicies:  true,
 		IncludeK8sGateways:            true,
 		IncludeK8sGRPCRoutes:          true,
 		IncludeK8sHTTPRoutes:          true,
 		IncludeK8sInferencePools:      true,
+		IncludeK8sListenerSets:        true,
 		IncludeK8sReferenceGrants:     true,
+		IncludeK8sTCPRoutes:           true,
+		IncludeK8sTLSRoutes:           true,
 		IncludePeerAuthentications:    true,
 		IncludeRequestAuthentications: true,
 		IncludeServiceEntries:         true,
@@ -712,6 +824,9 @@ func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, clus
 	if err != nil {
 		return nil, istioReferences, err
 	}
+	if proposed != nil {
+		clusterIstioConfigList = withProposedObjects(clusterIstioConfigList, cluster, proposed)
+	}
 	filterIstioConfigByManagedNamespaces(clusterIstioConfigList, vInfo.mesh, cluster, getNsNames(vInfo.nsMap[cluster]))
 	vInfo.clusterInfo.istioConfig = clusterIstioConfigList
 
@@ -835,11 +950,16 @@ func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, clus
 			checkers.TelemetryChecker{Namespaces: namespaces, Telemetries: istioConfigList.Telemetries},
 			newAmbientPolicyChecker(cluster, namespaces, workloadsPerNamespace, rbacDetails.AuthorizationPolicies, istioConfigList, services, identityDomain),
 		}
+	case kubernetes.K8sBackendTLSPolicies:
+		objectCheckers = []checkers.ObjectChecker{
+			checkers.K8sBackendTLSPolicyChecker{Cluster: cluster, K8sBackendTLSPolicies: istioConfigList.K8sBackendTLSPolicies, Services: services},
+		}
+		referenceChecker = references.K8sBackendTLSPolicyReferences{K8sBackendTLSPolicies: istioConfigList.K8sBackendTLSPolicies, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sTLSRoutes: istioConfigList.K8sTLSRoutes}
 	case kubernetes.K8sGateways:
 		objectCheckers = []checkers.ObjectChecker{
-			checkers.K8sGatewayChecker{Cluster: cluster, K8sGateways: istioConfigList.K8sGateways, GatewayClasses: in.kialiCache.GatewayAPIClasses(cluster)},
+			checkers.K8sGatewayChecker{Cluster: cluster, K8sGateways: istioConfigList.K8sGateways, GatewayClasses: in.kialiCache.GatewayAPIClasses(cluster), K8sReferenceGrants: istioConfigList.K8sReferenceGrants},
 		}
-		referenceChecker = references.K8sGatewayReferences{Conf: conf, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, WorkloadsPerNamespace: workloadsPerNamespace}
+		referenceChecker = references.K8sGatewayReferences{Conf: conf, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sListenerSets: istioConfigList.K8sListenerSets, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sTLSRoutes: istioConfigList.K8sTLSRoutes, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.K8sGRPCRoutes:
 		grpcRouteChecker := checkers.K8sGRPCRouteChecker{Cluster: cluster, Conf: conf, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, Services: services}
 		objectCheckers = []checkers.ObjectChecker{noServiceChecker, grpcRouteChecker}
@@ -848,6 +968,11 @@ func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, clus
 		httpRouteChecker := checkers.K8sHTTPRouteChecker{Cluster: cluster, Conf: conf, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces, Services: services}
 		objectCheckers = []checkers.ObjectChecker{noServiceChecker, httpRouteChecker}
 		referenceChecker = references.K8sHTTPRouteReferences{Conf: conf, IdentityDomain: identityDomain, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sInferencePools: istioConfigList.K8sInferencePools, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: nsNames}
+	case kubernetes.K8sListenerSets:
+		objectCheckers = []checkers.ObjectChecker{
+			checkers.K8sListenerSetChecker{Cluster: cluster, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sListenerSets: istioConfigList.K8sListenerSets, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
+		}
+		referenceChecker = references.K8sListenerSetReferences{IdentityDomain: identityDomain, K8sGRPCRoutes: istioConfigList.K8sGRPCRoutes, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sListenerSets: istioConfigList.K8sListenerSets, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, K8sTLSRoutes: istioConfigList.K8sTLSRoutes}
 	case kubernetes.K8sInferencePools:
 		referenceChecker = references.K8sInferencePoolReferences{Conf: conf, IdentityDomain: identityDomain, K8sHTTPRoutes: istioConfigList.K8sHTTPRoutes, K8sInferencePools: istioConfigList.K8sInferencePools, KubeServiceHosts: kubeServiceHosts, Namespaces: nsNames, WorkloadsPerNamespace: workloadsPerNamespace}
 	case kubernetes.K8sReferenceGrants:
@@ -855,9 +980,15 @@ func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, clus
 			checkers.K8sReferenceGrantChecker{Cluster: cluster, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, Namespaces: namespaces},
 		}
 	case kubernetes.K8sTCPRoutes:
-		// Validation on K8sTCPRoutes is not expected
+		objectCheckers = []checkers.ObjectChecker{
+			checkers.K8sTCPRouteChecker{Cluster: cluster, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sListenerSets: istioConfigList.K8sListenerSets, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, Namespaces: namespaces, Services: services},
+		}
+		referenceChecker = references.K8sTCPRouteReferences{IdentityDomain: identityDomain, K8sBackendTLSPolicies: istioConfigList.K8sBackendTLSPolicies, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTCPRoutes: istioConfigList.K8sTCPRoutes, Namespaces: nsNames}
 	case kubernetes.K8sTLSRoutes:
-		// Validation on K8sTLSRoutes is not expected
+		objectCheckers = []checkers.ObjectChecker{
+			checkers.K8sTLSRouteChecker{Cluster: cluster, IdentityDomain: identityDomain, K8sGateways: istioConfigList.K8sGateways, K8sListenerSets: istioConfigList.K8sListenerSets, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTLSRoutes: istioConfigList.K8sTLSRoutes, Namespaces: namespaces, Services: services},
+		}
+		referenceChecker = references.K8sTLSRouteReferences{IdentityDomain: identityDomain, K8sBackendTLSPolicies: istioConfigList.K8sBackendTLSPolicies, K8sReferenceGrants: istioConfigList.K8sReferenceGrants, K8sTLSRoutes: istioConfigList.K8sTLSRoutes, Namespaces: nsNames}
 	case kubernetes.K8sUDPRoutes:
 		// Validation on K8sUDPRoutes is not expected
 	default:
@@ -873,8 +1004,10 @@ func (in *IstioValidationsService) ValidateIstioObject(ctx context.Context, clus
 	}
 
 	validations := runObjectCheckers(ctx, objectCheckers, conf, buildObjectIgnoreValidations(vInfo, cluster)).FilterByKey(objectGVK, object)
-	for k, v := range validations {
-		in.kialiCache.Validations().Set(k, v)
+	if proposed == nil {
+		for k, v := range validations {
+			in.kialiCache.Validations().Set(k, v)
+		}
 	}
 
 	return validations, istioReferences, nil
@@ -1063,6 +1196,18 @@ func (in *IstioValidationsService) setNamespaceIstioConfig(
 	// All K8sInferencePools
 	namespaceIstioConfigList.K8sInferencePools = append(namespaceIstioConfigList.K8sInferencePools, clusterIstioConfig.K8sInferencePools...)
 
+	// All K8sTCPRoutes
+	namespaceIstioConfigList.K8sTCPRoutes = append(namespaceIstioConfigList.K8sTCPRoutes, clusterIstioConfig.K8sTCPRoutes...)
+
+	// All K8sTLSRoutes
+	namespaceIstioConfigList.K8sTLSRoutes = append(namespaceIstioConfigList.K8sTLSRoutes, clusterIstioConfig.K8sTLSRoutes...)
+
+	// All K8sListenerSets
+	namespaceIstioConfigList.K8sListenerSets = append(namespaceIstioConfigList.K8sListenerSets, clusterIstioConfig.K8sListenerSets...)
+
+	// All K8sBackendTLSPolicies
+	namespaceIstioConfigList.K8sBackendTLSPolicies = append(namespaceIstioConfigList.K8sBackendTLSPolicies, clusterIstioConfig.K8sBackendTLSPolicies...)
+
 	// All K8sReferenceGrants
 	namespaceIstioConfigList.K8sReferenceGrants = append(namespaceIstioConfigList.K8sReferenceGrants, clusterIstioConfig.K8sReferenceGrants...)
 
@@ -1262,11 +1407,15 @@ func filterIstioConfigByManagedNamespaces(config *models.IstioConfigList, mesh *
 	config.AuthorizationPolicies = kubernetes.FilterByNamespaceNames(config.AuthorizationPolicies, allowedNames)
 	config.DestinationRules = kubernetes.FilterByNamespaceNames(config.DestinationRules, allowedNames)
 	config.Gateways = kubernetes.FilterByNamespaceNames(config.Gateways, allowedNames)
+	config.K8sBackendTLSPolicies = kubernetes.FilterByNamespaceNames(config.K8sBackendTLSPolicies, allowedNames)
 	config.K8sGateways = kubernetes.FilterByNamespaceNames(config.K8sGateways, allowedNames)
 	config.K8sGRPCRoutes = kubernetes.FilterByNamespaceNames(config.K8sGRPCRoutes, allowedNames)
 	config.K8sHTTPRoutes = kubernetes.FilterByNamespaceNames(config.K8sHTTPRoutes, allowedNames)
 	config.K8sInferencePools = kubernetes.FilterByNamespaceNames(config.K8sInferencePools, allowedNames)
+	config.K8sListenerSets = kubernetes.FilterByNamespaceNames(config.K8sListenerSets, allowedNames)
 	config.K8sReferenceGrants = kubernetes.FilterByNamespaceNames(config.K8sReferenceGrants, allowedNames)
+	config.K8sTCPRoutes = kubernetes.FilterByNamespaceNames(config.K8sTCPRoutes, allowedNames)
+	config.K8sTLSRoutes = kubernetes.FilterByNamespaceNames(config.K8sTLSRoutes, allowedNames)
 	config.PeerAuthentications = kubernetes.FilterByNamespaceNames(config.PeerAuthentications, allowedNames)
 	config.RequestAuthentications = kubernetes.FilterByNamespaceNames(config.RequestAuthentications, allowedNames)
 	config.ServiceEntries = kubernetes.FilterByNamespaceNames(config.ServiceEntries, allowedNames)
@@ -16,19 +16,21 @@ import (
 // A business layer is created per token/user. Any data that
 // needs to be saved across layers is saved in the Kiali Cache.
 type Layer struct {
-	App          AppService
-	Health       HealthService
-	IstioConfig  IstioConfigService
-	IstioStatus  IstioStatusService
-	Tracing      TracingService
-	Mesh         MeshService
-	Namespace    NamespaceService
-	ProxyLogging ProxyLoggingService
-	ProxyStatus  ProxyStatusService
-	Svc          SvcService
-	TLS          TLSService
-	Validations  IstioValidationsService
-	Workload     WorkloadService
+	App           AppService
+	Authorization AuthorizationService
+	Health        HealthService
+	IstioConfig   IstioConfigService
+	IstioStatus   IstioStatusService
+	Tracing       TracingService
+	Mesh          MeshService
+	Namespace     NamespaceService
+	ProxyLogging  ProxyLoggingService
+	ProxyStatus   ProxyStatusService
+	Routing       RouteResolutionService
+	Svc           SvcService
+	TLS           TLSService
+	Validations   IstioValidationsService
+	Workload      WorkloadService
 }
 
 func newLayer(
@@ -48,6 +50,7 @@ func newLayer(
 
 	// TODO: Modify the k8s argument to other services to pass the whole k8s map if needed
 	temporaryLayer.App = NewAppService(temporaryLayer, conf, cache, prom, grafana, userClients)
+	temporaryLayer.Authorization = AuthorizationService{businessLayer: temporaryLayer, conf: conf, discovery: discovery}
 	temporaryLayer.Health = NewHealthService(temporaryLayer, conf, cache, prom, userClients)
 	temporaryLayer.IstioConfig = IstioConfigService{conf: conf, userClients: userClients, saClients: kialiSAClients, kialiCache: cache, businessLayer: temporaryLayer, controlPlaneMonitor: cpm}
 	temporaryLayer.IstioStatus = NewIstioStatusService(cache, conf, discovery, kialiSAClients[homeClusterName], &temporaryLayer.Tracing, userClients, &temporaryLayer.Workload, &temporaryLayer.Health)
@@ -56,6 +59,7 @@ func newLayer(
 	temporaryLayer.ProxyStatus = NewProxyStatusService(conf, cache, discovery, kialiSAClients, &temporaryLayer.Namespace)
 	// Out of order because it relies on ProxyStatus
 	temporaryLayer.ProxyLogging = ProxyLoggingService{conf: conf, userClients: userClients, proxyStatus: &temporaryLayer.ProxyStatus}
+	temporaryLayer.Routing = RouteResolutionService{businessLayer: temporaryLayer, conf: conf, discovery: discovery}
 	temporaryLayer.Svc = SvcService{conf: conf, kialiCache: cache, businessLayer: temporaryLayer, prom: prom, userClients: userClients}
 	temporaryLayer.TLS = TLSService{conf: conf, discovery: discovery, userClients: userClients, kialiCache: cache, businessLayer: temporaryLayer}
 	temporaryLayer.Validations = NewValidationsService(conf, &temporaryLayer.IstioConfig, cache, &temporaryLayer.Mesh, &temporaryLayer.Namespace, &temporaryLayer.Svc, userClients, &temporaryLayer.Workload)
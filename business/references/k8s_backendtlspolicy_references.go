@@ -0,0 +1,116 @@
+package references
+
+import (
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sBackendTLSPolicyReferences struct {
+	K8sBackendTLSPolicies []*k8s_networking_v1.BackendTLSPolicy
+	K8sGRPCRoutes         []*k8s_networking_v1.GRPCRoute
+	K8sHTTPRoutes         []*k8s_networking_v1.HTTPRoute
+	K8sTCPRoutes          []*k8s_networking_v1.TCPRoute
+	K8sTLSRoutes          []*k8s_networking_v1.TLSRoute
+}
+
+func (n K8sBackendTLSPolicyReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, policy := range n.K8sBackendTLSPolicies {
+		key := models.IstioReferenceKey{Namespace: policy.Namespace, Name: policy.Name, ObjectGVK: kubernetes.K8sBackendTLSPolicies}
+		references := &models.IstioReferences{}
+		references.ServiceReferences = n.getServiceReferences(policy)
+		references.ObjectReferences = n.getConfigReferences(policy)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+func (n K8sBackendTLSPolicyReferences) getServiceReferences(policy *k8s_networking_v1.BackendTLSPolicy) []models.ServiceReference {
+	keys := make(map[string]bool)
+	result := make([]models.ServiceReference, 0)
+
+	for _, targetRef := range policy.Spec.TargetRefs {
+		if string(targetRef.Kind) != kubernetes.ServiceType || keys[string(targetRef.Name)] {
+			continue
+		}
+		result = append(result, models.ServiceReference{Name: string(targetRef.Name), Namespace: policy.Namespace})
+		keys[string(targetRef.Name)] = true
+	}
+	return result
+}
+
+// getConfigReferences returns the routes sending traffic to the Services targeted by the policy
+func (n K8sBackendTLSPolicyReferences) getConfigReferences(policy *k8s_networking_v1.BackendTLSPolicy) []models.IstioReference {
+	result := make([]models.IstioReference, 0)
+
+	for _, rt := range n.K8sHTTPRoutes {
+		for _, rule := range rt.Spec.Rules {
+			if n.targetsBackend(policy, rt.Namespace, httpBackendRefs(rule.BackendRefs)) {
+				result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sHTTPRoutes})
+				break
+			}
+		}
+	}
+	for _, rt := range n.K8sGRPCRoutes {
+		for _, rule := range rt.Spec.Rules {
+			if n.targetsBackend(policy, rt.Namespace, grpcBackendRefs(rule.BackendRefs)) {
+				result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sGRPCRoutes})
+				break
+			}
+		}
+	}
+	for _, rt := range n.K8sTCPRoutes {
+		for _, rule := range rt.Spec.Rules {
+			if n.targetsBackend(policy, rt.Namespace, rule.BackendRefs) {
+				result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sTCPRoutes})
+				break
+			}
+		}
+	}
+	for _, rt := range n.K8sTLSRoutes {
+		for _, rule := range rt.Spec.Rules {
+			if n.targetsBackend(policy, rt.Namespace, rule.BackendRefs) {
+				result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sTLSRoutes})
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func (n K8sBackendTLSPolicyReferences) targetsBackend(policy *k8s_networking_v1.BackendTLSPolicy, routeNs string, backendRefs []k8s_networking_v1.BackendRef) bool {
+	for _, ref := range backendRefs {
+		if ref.Kind != nil && string(*ref.Kind) != kubernetes.ServiceType {
+			continue
+		}
+		namespace := routeNs
+		if ref.Namespace != nil && string(*ref.Namespace) != "" {
+			namespace = string(*ref.Namespace)
+		}
+		if namespace == policy.Namespace && policyTargetsService(policy, string(ref.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func httpBackendRefs(refs []k8s_networking_v1.HTTPBackendRef) []k8s_networking_v1.BackendRef {
+	result := make([]k8s_networking_v1.BackendRef, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, ref.BackendRef)
+	}
+	return result
+}
+
+func grpcBackendRefs(refs []k8s_networking_v1.GRPCBackendRef) []k8s_networking_v1.BackendRef {
+	result := make([]k8s_networking_v1.BackendRef, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, ref.BackendRef)
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestK8sBackendTLSPolicyReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	policyReferences := K8sBackendTLSPolicyReferences{
+		K8sBackendTLSPolicies: []*k8s_networking_v1.BackendTLSPolicy{data.CreateBackendTLSPolicy("policy", "bookinfo", "reviews", "")},
+		K8sHTTPRoutes: []*k8s_networking_v1.HTTPRoute{
+			data.AddBackendRefToHTTPRoute("reviews", "", data.CreateHTTPRoute("reviews-route", "bookinfo", "gatewayapi", []string{})),
+			data.AddBackendRefToHTTPRoute("ratings", "", data.CreateHTTPRoute("ratings-route", "bookinfo", "gatewayapi", []string{})),
+		},
+		K8sTLSRoutes: []*k8s_networking_v1.TLSRoute{
+			data.AddBackendRefToTLSRoute("reviews", "bookinfo", data.CreateTLSRoute("tls-route", "bookinfo2", "gatewayapi", []string{})),
+		},
+	}
+	references := policyReferences.References()[models.IstioReferenceKey{ObjectGVK: kubernetes.K8sBackendTLSPolicies, Namespace: "bookinfo", Name: "policy"}]
+
+	assert.Len(references.ServiceReferences, 1)
+	assert.Equal(models.ServiceReference{Name: "reviews", Namespace: "bookinfo"}, references.ServiceReferences[0])
+
+	assert.Len(references.ObjectReferences, 2)
+	assert.Equal(models.IstioReference{Name: "reviews-route", Namespace: "bookinfo", ObjectGVK: kubernetes.K8sHTTPRoutes}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "tls-route", Namespace: "bookinfo2", ObjectGVK: kubernetes.K8sTLSRoutes}, references.ObjectReferences[1])
+}
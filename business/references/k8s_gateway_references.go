@@ -14,6 +14,9 @@ type K8sGatewayReferences struct {
 	K8sGateways           []*k8s_networking_v1.Gateway
 	K8sHTTPRoutes         []*k8s_networking_v1.HTTPRoute
 	K8sGRPCRoutes         []*k8s_networking_v1.GRPCRoute
+	K8sListenerSets       []*k8s_networking_v1.ListenerSet
+	K8sTCPRoutes          []*k8s_networking_v1.TCPRoute
+	K8sTLSRoutes          []*k8s_networking_v1.TLSRoute
 	WorkloadsPerNamespace map[string]models.Workloads
 }
 
@@ -70,5 +73,23 @@ func (g K8sGatewayReferences) getConfigReferences(gw *k8s_networking_v1.Gateway)
 		}
 	}
 
+	for _, rt := range g.K8sTCPRoutes {
+		if hasParentRef(rt.Spec.ParentRefs, rt.Namespace, gvk, gw.Name, gw.Namespace) {
+			result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sTCPRoutes})
+		}
+	}
+
+	for _, rt := range g.K8sTLSRoutes {
+		if hasParentRef(rt.Spec.ParentRefs, rt.Namespace, gvk, gw.Name, gw.Namespace) {
+			result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sTLSRoutes})
+		}
+	}
+
+	for _, ls := range g.K8sListenerSets {
+		if kubernetes.IsK8sListenerSetParent(ls, gw.Name, gw.Namespace) {
+			result = append(result, models.IstioReference{Name: ls.Name, Namespace: ls.Namespace, ObjectGVK: kubernetes.K8sListenerSets})
+		}
+	}
+
 	return result
 }
@@ -0,0 +1,88 @@
+package references
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sListenerSetReferences struct {
+	IdentityDomain  string
+	K8sGRPCRoutes   []*k8s_networking_v1.GRPCRoute
+	K8sHTTPRoutes   []*k8s_networking_v1.HTTPRoute
+	K8sListenerSets []*k8s_networking_v1.ListenerSet
+	K8sTCPRoutes    []*k8s_networking_v1.TCPRoute
+	K8sTLSRoutes    []*k8s_networking_v1.TLSRoute
+}
+
+func (n K8sListenerSetReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, ls := range n.K8sListenerSets {
+		key := models.IstioReferenceKey{Namespace: ls.Namespace, Name: ls.Name, ObjectGVK: kubernetes.K8sListenerSets}
+		references := &models.IstioReferences{}
+		references.ObjectReferences = n.getConfigReferences(ls)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+func (n K8sListenerSetReferences) getConfigReferences(ls *k8s_networking_v1.ListenerSet) []models.IstioReference {
+	result := make([]models.IstioReference, 0)
+
+	parentRef := ls.Spec.ParentRef
+	if (parentRef.Kind == nil || string(*parentRef.Kind) == kubernetes.K8sGateways.Kind) && string(parentRef.Name) != "" {
+		namespace := ls.Namespace
+		if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+		result = append(result, getK8sGatewayReference(string(parentRef.Name), namespace, n.IdentityDomain))
+	}
+
+	for _, rt := range n.K8sHTTPRoutes {
+		if hasParentRef(rt.Spec.ParentRefs, rt.Namespace, kubernetes.K8sListenerSets, ls.Name, ls.Namespace) {
+			result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sHTTPRoutes})
+		}
+	}
+	for _, rt := range n.K8sGRPCRoutes {
+		if hasParentRef(rt.Spec.ParentRefs, rt.Namespace, kubernetes.K8sListenerSets, ls.Name, ls.Namespace) {
+			result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sGRPCRoutes})
+		}
+	}
+	for _, rt := range n.K8sTCPRoutes {
+		if hasParentRef(rt.Spec.ParentRefs, rt.Namespace, kubernetes.K8sListenerSets, ls.Name, ls.Namespace) {
+			result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sTCPRoutes})
+		}
+	}
+	for _, rt := range n.K8sTLSRoutes {
+		if hasParentRef(rt.Spec.ParentRefs, rt.Namespace, kubernetes.K8sListenerSets, ls.Name, ls.Namespace) {
+			result = append(result, models.IstioReference{Name: rt.Name, Namespace: rt.Namespace, ObjectGVK: kubernetes.K8sTLSRoutes})
+		}
+	}
+
+	return result
+}
+
+// hasParentRef returns true when any of the route parentRefs points to the object of the given kind, name and namespace
+func hasParentRef(prs []k8s_networking_v1.ParentReference, routeNs string, gvk schema.GroupVersionKind, name, namespace string) bool {
+	for _, pr := range prs {
+		kind := kubernetes.K8sGateways.Kind
+		if pr.Kind != nil {
+			kind = string(*pr.Kind)
+		}
+		if pr.Group != nil && string(*pr.Group) != gvk.Group {
+			continue
+		}
+		prNs := routeNs
+		if pr.Namespace != nil && string(*pr.Namespace) != "" {
+			prNs = string(*pr.Namespace)
+		}
+		if kind == gvk.Kind && string(pr.Name) == name && prNs == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestK8sListenerSetReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	attached := data.CreateTCPRoute("attached", "bookinfo", "listeners")
+	kind := k8s_networking_v1.Kind(kubernetes.K8sListenerSetType)
+	attached.Spec.ParentRefs[0].Kind = &kind
+	notAttached := data.CreateTCPRoute("not-attached", "bookinfo", "listeners")
+
+	listenerSetReferences := K8sListenerSetReferences{
+		IdentityDomain:  config.ResolveIdentityDomain(config.Get().ExternalServices.Istio.IstioIdentityDomain, ""),
+		K8sListenerSets: []*k8s_networking_v1.ListenerSet{data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "istio-system")},
+		K8sTCPRoutes:    []*k8s_networking_v1.TCPRoute{attached, notAttached},
+	}
+	references := listenerSetReferences.References()[models.IstioReferenceKey{ObjectGVK: kubernetes.K8sListenerSets, Namespace: "bookinfo", Name: "listeners"}]
+
+	assert.Len(references.ObjectReferences, 2)
+	assert.Equal(models.IstioReference{Name: "gatewayapi", Namespace: "istio-system", ObjectGVK: kubernetes.K8sGateways}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "attached", Namespace: "bookinfo", ObjectGVK: kubernetes.K8sTCPRoutes}, references.ObjectReferences[1])
+}
+
+func TestK8sGatewayListenerSetReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	gatewayReferences := K8sGatewayReferences{
+		Conf:            config.Get(),
+		IdentityDomain:  config.ResolveIdentityDomain(config.Get().ExternalServices.Istio.IstioIdentityDomain, ""),
+		K8sGateways:     []*k8s_networking_v1.Gateway{data.CreateEmptyK8sGateway("gatewayapi", "istio-system")},
+		K8sListenerSets: []*k8s_networking_v1.ListenerSet{data.CreateListenerSet("listeners", "bookinfo", "gatewayapi", "istio-system"), data.CreateListenerSet("other", "bookinfo", "other", "istio-system")},
+		K8sTLSRoutes:    []*k8s_networking_v1.TLSRoute{data.CreateTLSRoute("route", "istio-system", "gatewayapi", []string{})},
+	}
+	references := gatewayReferences.References()[models.IstioReferenceKey{ObjectGVK: kubernetes.K8sGateways, Namespace: "istio-system", Name: "gatewayapi"}]
+
+	assert.Len(references.ObjectReferences, 2)
+	assert.Equal(models.IstioReference{Name: "route", Namespace: "istio-system", ObjectGVK: kubernetes.K8sTLSRoutes}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "listeners", Namespace: "bookinfo", ObjectGVK: kubernetes.K8sListenerSets}, references.ObjectReferences[1])
+}
@@ -0,0 +1,127 @@
+package references
+
+import (
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util"
+)
+
+type K8sTCPRouteReferences struct {
+	IdentityDomain        string
+	K8sBackendTLSPolicies []*k8s_networking_v1.BackendTLSPolicy
+	K8sReferenceGrants    []*k8s_networking_v1beta1.ReferenceGrant
+	K8sTCPRoutes          []*k8s_networking_v1.TCPRoute
+	Namespaces            []string
+}
+
+func (n K8sTCPRouteReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, rt := range n.K8sTCPRoutes {
+		key := models.IstioReferenceKey{Namespace: rt.Namespace, Name: rt.Name, ObjectGVK: kubernetes.K8sTCPRoutes}
+		backendRefs := make([]k8s_networking_v1.BackendRef, 0)
+		for _, rule := range rt.Spec.Rules {
+			backendRefs = append(backendRefs, rule.BackendRefs...)
+		}
+		references := &models.IstioReferences{}
+		references.ServiceReferences = getBackendServiceReferences(backendRefs, rt.Namespace, n.Namespaces, n.IdentityDomain)
+		references.ObjectReferences = getRouteConfigReferences(rt.Spec.ParentRefs, backendRefs, rt.Namespace, kubernetes.K8sTCPRouteType, n.K8sReferenceGrants, n.K8sBackendTLSPolicies, n.IdentityDomain)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
+
+// getBackendServiceReferences returns the unique Services referenced by the given route backendRefs
+func getBackendServiceReferences(backendRefs []k8s_networking_v1.BackendRef, ns string, namespaces []string, identityDomain string) []models.ServiceReference {
+	keys := make(map[string]bool)
+	result := make([]models.ServiceReference, 0)
+
+	for _, ref := range backendRefs {
+		if ref.Kind != nil && string(*ref.Kind) != kubernetes.ServiceType {
+			continue
+		}
+		namespace := ns
+		if ref.Namespace != nil && string(*ref.Namespace) != "" {
+			namespace = string(*ref.Namespace)
+		}
+		fqdn := kubernetes.GetHost(string(ref.Name), namespace, namespaces, identityDomain)
+		if fqdn.IsWildcard() {
+			continue
+		}
+		key := util.BuildNameNSKey(fqdn.Service, fqdn.Namespace)
+		if !keys[key] {
+			result = append(result, models.ServiceReference{Name: fqdn.Service, Namespace: fqdn.Namespace})
+			keys[key] = true
+		}
+	}
+	return result
+}
+
+// getRouteConfigReferences returns the unique parent Gateways and ListenerSets of a route, the ReferenceGrants
+// allowing its cross namespace backends and the BackendTLSPolicies attached to its backends
+func getRouteConfigReferences(parentRefs []k8s_networking_v1.ParentReference, backendRefs []k8s_networking_v1.BackendRef, ns string, kind string, grants []*k8s_networking_v1beta1.ReferenceGrant, policies []*k8s_networking_v1.BackendTLSPolicy, identityDomain string) []models.IstioReference {
+	keys := make(map[string]bool)
+	result := make([]models.IstioReference, 0)
+
+	addUnique := func(ref models.IstioReference) {
+		key := util.BuildNameNSTypeKey(ref.Name, ref.Namespace, ref.ObjectGVK)
+		if !keys[key] {
+			result = append(result, ref)
+			keys[key] = true
+		}
+	}
+
+	for _, parentRef := range parentRefs {
+		if string(parentRef.Name) == "" || (parentRef.Group != nil && string(*parentRef.Group) != kubernetes.K8sGateways.Group) {
+			continue
+		}
+		namespace := ns
+		if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+			namespace = string(*parentRef.Namespace)
+		}
+		switch {
+		case parentRef.Kind == nil || string(*parentRef.Kind) == kubernetes.K8sGateways.Kind:
+			addUnique(getK8sGatewayReference(string(parentRef.Name), namespace, identityDomain))
+		case string(*parentRef.Kind) == kubernetes.K8sListenerSets.Kind:
+			addUnique(models.IstioReference{Name: string(parentRef.Name), Namespace: namespace, ObjectGVK: kubernetes.K8sListenerSets})
+		}
+	}
+
+	for _, rGrant := range grants {
+		for _, from := range rGrant.Spec.From {
+			if string(from.Namespace) == ns && string(from.Kind) == kind {
+				addUnique(getK8sGrantReference(rGrant.Name, rGrant.Namespace))
+			}
+		}
+	}
+
+	for _, ref := range backendRefs {
+		if ref.Kind != nil && string(*ref.Kind) != kubernetes.ServiceType {
+			continue
+		}
+		namespace := ns
+		if ref.Namespace != nil && string(*ref.Namespace) != "" {
+			namespace = string(*ref.Namespace)
+		}
+		for _, policy := range policies {
+			if policy.Namespace == namespace && policyTargetsService(policy, string(ref.Name)) {
+				addUnique(models.IstioReference{Name: policy.Name, Namespace: policy.Namespace, ObjectGVK: kubernetes.K8sBackendTLSPolicies})
+			}
+		}
+	}
+
+	return result
+}
+
+func policyTargetsService(policy *k8s_networking_v1.BackendTLSPolicy, service string) bool {
+	for _, targetRef := range policy.Spec.TargetRefs {
+		if string(targetRef.Kind) == kubernetes.ServiceType && string(targetRef.Name) == service {
+			return true
+		}
+	}
+	return false
+}
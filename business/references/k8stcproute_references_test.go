@@ -0,0 +1,61 @@
+package references
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestK8sTCPRouteReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	route := data.AddBackendRefToTCPRoute("mysql", "bookinfo2", data.AddBackendRefToTCPRoute("mysql", "", data.CreateTCPRoute("route", "bookinfo", "gatewayapi")))
+
+	routeReferences := K8sTCPRouteReferences{
+		IdentityDomain:        config.ResolveIdentityDomain(config.Get().ExternalServices.Istio.IstioIdentityDomain, ""),
+		K8sBackendTLSPolicies: []*k8s_networking_v1.BackendTLSPolicy{data.CreateBackendTLSPolicy("mysql-tls", "bookinfo", "mysql", ""), data.CreateBackendTLSPolicy("other-tls", "bookinfo", "other", "")},
+		K8sReferenceGrants:    []*k8s_networking_v1beta1.ReferenceGrant{data.CreateReferenceGrantByKind("rg", "bookinfo2", "bookinfo", kubernetes.K8sTCPRouteType)},
+		K8sTCPRoutes:          []*k8s_networking_v1.TCPRoute{route},
+		Namespaces:            []string{"bookinfo", "bookinfo2"},
+	}
+	references := routeReferences.References()[models.IstioReferenceKey{ObjectGVK: kubernetes.K8sTCPRoutes, Namespace: "bookinfo", Name: "route"}]
+
+	assert.Len(references.ServiceReferences, 2)
+	assert.Equal(models.ServiceReference{Name: "mysql", Namespace: "bookinfo"}, references.ServiceReferences[0])
+	assert.Equal(models.ServiceReference{Name: "mysql", Namespace: "bookinfo2"}, references.ServiceReferences[1])
+
+	assert.Len(references.ObjectReferences, 3)
+	assert.Equal(models.IstioReference{Name: "gatewayapi", Namespace: "bookinfo", ObjectGVK: kubernetes.K8sGateways}, references.ObjectReferences[0])
+	assert.Equal(models.IstioReference{Name: "rg", Namespace: "bookinfo2", ObjectGVK: kubernetes.K8sReferenceGrants}, references.ObjectReferences[1])
+	assert.Equal(models.IstioReference{Name: "mysql-tls", Namespace: "bookinfo", ObjectGVK: kubernetes.K8sBackendTLSPolicies}, references.ObjectReferences[2])
+}
+
+func TestK8sTLSRouteListenerSetReferences(t *testing.T) {
+	assert := assert.New(t)
+	conf := config.NewConfig()
+	config.Set(conf)
+
+	route := data.CreateTLSRoute("route", "bookinfo", "listeners", []string{"bookinfo.example.com"})
+	kind := k8s_networking_v1.Kind(kubernetes.K8sListenerSetType)
+	route.Spec.ParentRefs[0].Kind = &kind
+
+	routeReferences := K8sTLSRouteReferences{
+		IdentityDomain: config.ResolveIdentityDomain(config.Get().ExternalServices.Istio.IstioIdentityDomain, ""),
+		K8sTLSRoutes:   []*k8s_networking_v1.TLSRoute{route},
+		Namespaces:     []string{"bookinfo"},
+	}
+	references := routeReferences.References()[models.IstioReferenceKey{ObjectGVK: kubernetes.K8sTLSRoutes, Namespace: "bookinfo", Name: "route"}]
+
+	assert.Empty(references.ServiceReferences)
+	assert.Len(references.ObjectReferences, 1)
+	assert.Equal(models.IstioReference{Name: "listeners", Namespace: "bookinfo", ObjectGVK: kubernetes.K8sListenerSets}, references.ObjectReferences[0])
+}
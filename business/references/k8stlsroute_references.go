@@ -0,0 +1,35 @@
+package references
+
+import (
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+	k8s_networking_v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+type K8sTLSRouteReferences struct {
+	IdentityDomain        string
+	K8sBackendTLSPolicies []*k8s_networking_v1.BackendTLSPolicy
+	K8sReferenceGrants    []*k8s_networking_v1beta1.ReferenceGrant
+	K8sTLSRoutes          []*k8s_networking_v1.TLSRoute
+	Namespaces            []string
+}
+
+func (n K8sTLSRouteReferences) References() models.IstioReferencesMap {
+	result := models.IstioReferencesMap{}
+
+	for _, rt := range n.K8sTLSRoutes {
+		key := models.IstioReferenceKey{Namespace: rt.Namespace, Name: rt.Name, ObjectGVK: kubernetes.K8sTLSRoutes}
+		backendRefs := make([]k8s_networking_v1.BackendRef, 0)
+		for _, rule := range rt.Spec.Rules {
+			backendRefs = append(backendRefs, rule.BackendRefs...)
+		}
+		references := &models.IstioReferences{}
+		references.ServiceReferences = getBackendServiceReferences(backendRefs, rt.Namespace, n.Namespaces, n.IdentityDomain)
+		references.ObjectReferences = getRouteConfigReferences(rt.Spec.ParentRefs, backendRefs, rt.Namespace, kubernetes.K8sTLSRouteType, n.K8sReferenceGrants, n.K8sBackendTLSPolicies, n.IdentityDomain)
+		result.MergeReferencesMap(models.IstioReferencesMap{key: references})
+	}
+
+	return result
+}
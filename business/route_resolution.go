@@ -0,0 +1,1110 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/observability"
+)
+
+// RouteResolutionService computes the effective routing decision of a request from the point of view
+// of a source workload, using the Istio and Gateway API configuration in the cache.
+type RouteResolutionService struct {
+	businessLayer *Layer
+	conf          *config.Config
+	discovery     istio.MeshDiscovery
+}
+
+// routeSource holds the source workload information that affects route selection.
+type routeSource struct {
+	Labels        map[string]string
+	Namespace     string
+	RootNamespace string
+}
+
+// ResolveRoute returns the effective route that the proxy of the source workload applies to the request,
+// explaining which objects contributed to it. When the source workload has a sidecar, the result is
+// cross-checked against the config_dump of one of its pods.
+func (in *RouteResolutionService) ResolveRoute(ctx context.Context, cluster, namespace, workloadName string, req models.RouteResolutionRequest) (*models.RouteResolutionResult, error) {
+	var end observability.EndFunc
+	ctx, end = observability.StartSpan(ctx, "ResolveRoute",
+		observability.Attribute("package", "business"),
+		observability.Attribute(observability.TracingClusterTag, cluster),
+		observability.Attribute("namespace", namespace),
+		observability.Attribute("workload", workloadName),
+	)
+	defer end()
+
+	workload, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{
+		Cluster:      cluster,
+		Namespace:    namespace,
+		WorkloadName: workloadName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	criteria := IstioConfigCriteria{
+		IncludeDestinationRules: true,
+		IncludeK8sGRPCRoutes:    true,
+		IncludeK8sHTTPRoutes:    true,
+		IncludeSidecars:         true,
+		IncludeVirtualServices:  true,
+	}
+	istioConfigList, err := in.businessLayer.IstioConfig.GetIstioConfigList(ctx, cluster, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	source := routeSource{
+		Labels:        workload.Labels,
+		Namespace:     namespace,
+		RootNamespace: in.discovery.GetRootNamespace(ctx, cluster, namespace),
+	}
+	if source.RootNamespace == "" {
+		source.RootNamespace = config.IstioNamespaceDefault
+	}
+	identityDomain := resolveIdentityDomainWithDiscovery(ctx, in.discovery, cluster, in.conf.ExternalServices.Istio.IstioIdentityDomain)
+
+	result := resolveRoute(istioConfigList, source, req, identityDomain)
+
+	for _, pod := range workload.Pods {
+		if !pod.HasIstioSidecar() {
+			continue
+		}
+		dump, err := in.businessLayer.ProxyStatus.GetConfigDump(cluster, namespace, pod.Name)
+		if err != nil || dump.ConfigDump == nil {
+			result.Notes = append(result.Notes, fmt.Sprintf("config_dump of pod [%s] is not available: %v", pod.Name, err))
+			break
+		}
+		result.ProxyCheck = checkProxyRoute(dump.ConfigDump, pod.Name, result)
+		break
+	}
+
+	return result, nil
+}
+
+// resolveRoute applies the routing precedence of the source proxy: Sidecar egress visibility first, then
+// Gateway API routes attached to the destination service (GAMMA) and finally VirtualServices. The
+// DestinationRule of every resulting destination is resolved afterwards.
+func resolveRoute(istioConfig *models.IstioConfigList, source routeSource, req models.RouteResolutionRequest, identityDomain string) *models.RouteResolutionResult {
+	result := &models.RouteResolutionResult{
+		Host:         requestHostFQDN(req.Host, source.Namespace, identityDomain),
+		Port:         req.Port,
+		Visible:      true,
+		Destinations: []models.RouteDestination{},
+		Contributors: []models.RouteContributor{},
+		Notes:        []string{},
+	}
+
+	if sidecar := applicableSidecar(istioConfig.Sidecars, source); sidecar != nil {
+		visible := sidecarEgressAllows(sidecar, result.Host, req.Port, identityDomain)
+		reason := "Host is in the egress scope of the Sidecar"
+		if !visible {
+			reason = "Host is not in the egress scope of the Sidecar"
+		}
+		result.Contributors = append(result.Contributors, routeContributor(kubernetes.Sidecars, sidecar.ObjectMeta, models.RouteContributionApplied, reason))
+		if !visible {
+			result.Visible = false
+			result.RouteSource = models.RouteSourceDefault
+			result.Notes = append(result.Notes, "Traffic to this host is handled by the mesh outboundTrafficPolicy (PassthroughCluster or BlackHoleCluster)")
+			return result
+		}
+	}
+
+	if !resolveGatewayAPIRoute(istioConfig, source, req, identityDomain, result) && !resolveVirtualServiceRoute(istioConfig.VirtualServices, source, req, identityDomain, result) {
+		result.RouteSource = models.RouteSourceDefault
+		result.Destinations = append(result.Destinations, models.RouteDestination{Host: result.Host, Port: req.Port, Weight: 100})
+		result.Notes = append(result.Notes, "No route applies to the host; traffic is sent to the host unchanged")
+	}
+
+	for i := range result.Destinations {
+		applyDestinationRule(istioConfig.DestinationRules, source, identityDomain, &result.Destinations[i], result)
+	}
+
+	return result
+}
+
+// requestHostFQDN expands the host used by the client to its FQDN. As for the Kubernetes DNS search
+// list, short names are relative to the source namespace and two part names are <service>.<namespace>.
+func requestHostFQDN(host, namespace, identityDomain string) string {
+	parts := strings.Split(host, ".")
+	switch {
+	case len(parts) == 1:
+		return fmt.Sprintf("%s.%s.%s", host, namespace, identityDomain)
+	case len(parts) == 2:
+		return fmt.Sprintf("%s.%s", host, identityDomain)
+	case len(parts) == 3 && parts[2] == "svc":
+		return fmt.Sprintf("%s.%s.%s", parts[0], parts[1], identityDomain)
+	}
+	return host
+}
+
+// objectHostFQDN expands the host of an Istio object the way Istio does: only short names are
+// resolved, relative to the namespace of the object.
+func objectHostFQDN(host, namespace, identityDomain string) string {
+	if host == "" || strings.Contains(host, ".") || strings.HasPrefix(host, "*") {
+		return host
+	}
+	return fmt.Sprintf("%s.%s.%s", host, namespace, identityDomain)
+}
+
+// hostSpecificity returns how specifically pattern matches host: 0 for no match,
+// the pattern length for wildcards and a higher value for exact matches.
+func hostSpecificity(pattern, host string) int {
+	if pattern == host {
+		return len(host) + 1
+	}
+	if strings.HasPrefix(pattern, "*") && kubernetes.HostWithinWildcardHost(host, pattern) {
+		return len(pattern)
+	}
+	return 0
+}
+
+// serviceHostParts returns the service name and namespace of a Kubernetes service FQDN.
+func serviceHostParts(host, identityDomain string) (string, string, bool) {
+	if !strings.HasSuffix(host, "."+identityDomain) {
+		return "", "", false
+	}
+	parts := strings.Split(strings.TrimSuffix(host, "."+identityDomain), ".")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func routeContributor(gvk schema.GroupVersionKind, meta metav1.ObjectMeta, role, reason string) models.RouteContributor {
+	return models.RouteContributor{
+		IstioReference: models.IstioReference{ObjectGVK: gvk, Name: meta.Name, Namespace: meta.Namespace},
+		Role:           role,
+		Reason:         reason,
+	}
+}
+
+// olderObject orders objects the way Istio and Gateway API break ties: oldest first, then by namespace/name.
+func olderObject(a, b metav1.ObjectMeta) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// applicableSidecar returns the Sidecar configuring the source proxy: a selector based one in the
+// source namespace, else the namespace default one, else the mesh default one in the root namespace.
+func applicableSidecar(sidecars []*networking_v1.Sidecar, source routeSource) *networking_v1.Sidecar {
+	var selected, namespaceDefault, meshDefault *networking_v1.Sidecar
+	for _, sc := range sidecars {
+		switch {
+		case sc.Namespace == source.Namespace && sc.Spec.WorkloadSelector != nil:
+			if labels.SelectorFromSet(sc.Spec.WorkloadSelector.Labels).Matches(labels.Set(source.Labels)) &&
+				(selected == nil || olderObject(sc.ObjectMeta, selected.ObjectMeta)) {
+				selected = sc
+			}
+		case sc.Namespace == source.Namespace:
+			if namespaceDefault == nil || olderObject(sc.ObjectMeta, namespaceDefault.ObjectMeta) {
+				namespaceDefault = sc
+			}
+		case sc.Namespace == source.RootNamespace && sc.Spec.WorkloadSelector == nil:
+			if meshDefault == nil || olderObject(sc.ObjectMeta, meshDefault.ObjectMeta) {
+				meshDefault = sc
+			}
+		}
+	}
+	if selected != nil {
+		return selected
+	}
+	if namespaceDefault != nil {
+		return namespaceDefault
+	}
+	return meshDefault
+}
+
+// sidecarEgressAllows checks the "namespace/dnsName" egress hosts of the listeners applying to the port.
+func sidecarEgressAllows(sidecar *networking_v1.Sidecar, host string, port int, identityDomain string) bool {
+	_, hostNamespace, isService := serviceHostParts(host, identityDomain)
+	for _, egress := range sidecar.Spec.Egress {
+		if egress.Port != nil && egress.Port.Number != 0 && int(egress.Port.Number) != port {
+			continue
+		}
+		for _, egressHost := range egress.Hosts {
+			ns, dnsName, found := strings.Cut(egressHost, "/")
+			if !found {
+				continue
+			}
+			switch ns {
+			case "*":
+			case "~":
+				continue
+			case ".":
+				if !isService || hostNamespace != sidecar.Namespace {
+					continue
+				}
+			default:
+				if !isService || hostNamespace != ns {
+					continue
+				}
+			}
+			if dnsName == "*" || hostSpecificity(dnsName, host) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gatewayRouteCandidate is a matching rule of a Gateway API route attached to the destination service.
+type gatewayRouteCandidate struct {
+	gvk        schema.GroupVersionKind
+	meta       metav1.ObjectMeta
+	consumer   bool
+	ruleIndex  int
+	ruleName   string
+	precedence []int
+	result     models.RouteResolutionResult
+}
+
+// resolveGatewayAPIRoute selects the matching rule of the HTTPRoutes and GRPCRoutes whose parentRefs
+// point to the destination service. Routes in the source namespace (consumer routes) take precedence
+// over routes in the service namespace (producer routes), then the Gateway API match precedence applies.
+func resolveGatewayAPIRoute(istioConfig *models.IstioConfigList, source routeSource, req models.RouteResolutionRequest, identityDomain string, result *models.RouteResolutionResult) bool {
+	svcName, svcNamespace, isService := serviceHostParts(result.Host, identityDomain)
+	if !isService {
+		return false
+	}
+
+	path, query := splitRequestPath(req.Path)
+	attached := map[string]metav1.ObjectMeta{}
+	attachedGVK := map[string]schema.GroupVersionKind{}
+	candidates := []gatewayRouteCandidate{}
+
+	for _, route := range istioConfig.K8sHTTPRoutes {
+		if !gatewayRouteAttached(route.Spec.ParentRefs, route.Namespace, svcName, svcNamespace, source.Namespace, req.Port) {
+			continue
+		}
+		key := kubernetes.K8sHTTPRouteType + "/" + route.Namespace + "/" + route.Name
+		attached[key] = route.ObjectMeta
+		attachedGVK[key] = kubernetes.K8sHTTPRoutes
+		for i, rule := range route.Spec.Rules {
+			matches := rule.Matches
+			if len(matches) == 0 {
+				matches = []k8s_networking_v1.HTTPRouteMatch{{}}
+			}
+			for _, match := range matches {
+				precedence, ok := matchGatewayHTTPRoute(match, path, query, req)
+				if !ok {
+					continue
+				}
+				candidate := gatewayRouteCandidate{
+					gvk:        kubernetes.K8sHTTPRoutes,
+					meta:       route.ObjectMeta,
+					consumer:   route.Namespace != svcNamespace,
+					ruleIndex:  i,
+					precedence: precedence,
+				}
+				if rule.Name != nil {
+					candidate.ruleName = string(*rule.Name)
+				}
+				for _, backend := range rule.BackendRefs {
+					candidate.result.Destinations = append(candidate.result.Destinations, gatewayBackendDestination(backend.BackendRef, route.Namespace, req.Port, identityDomain))
+				}
+				if rule.Timeouts != nil && rule.Timeouts.Request != nil {
+					candidate.result.Timeout = string(*rule.Timeouts.Request)
+				}
+				if rule.Retry != nil && rule.Retry.Attempts != nil {
+					candidate.result.Retries = &models.RouteRetries{Attempts: int32(*rule.Retry.Attempts)}
+					if rule.Retry.Backoff != nil {
+						candidate.result.Retries.PerTryTimeout = string(*rule.Retry.Backoff)
+					}
+				}
+				for _, filter := range rule.Filters {
+					switch filter.Type {
+					case k8s_networking_v1.HTTPRouteFilterRequestRedirect:
+						candidate.result.Redirect = true
+					case k8s_networking_v1.HTTPRouteFilterURLRewrite:
+						candidate.result.Rewrite = true
+					case k8s_networking_v1.HTTPRouteFilterRequestMirror:
+						candidate.result.Mirror = true
+					}
+				}
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	grpcService, grpcMethod := "", ""
+	if parts := strings.Split(strings.TrimPrefix(path, "/"), "/"); len(parts) == 2 {
+		grpcService, grpcMethod = parts[0], parts[1]
+	}
+	for _, route := range istioConfig.K8sGRPCRoutes {
+		if !gatewayRouteAttached(route.Spec.ParentRefs, route.Namespace, svcName, svcNamespace, source.Namespace, req.Port) {
+			continue
+		}
+		key := kubernetes.K8sGRPCRouteType + "/" + route.Namespace + "/" + route.Name
+		attached[key] = route.ObjectMeta
+		attachedGVK[key] = kubernetes.K8sGRPCRoutes
+		for i, rule := range route.Spec.Rules {
+			matches := rule.Matches
+			if len(matches) == 0 {
+				matches = []k8s_networking_v1.GRPCRouteMatch{{}}
+			}
+			for _, match := range matches {
+				precedence, ok := matchGatewayGRPCRoute(match, grpcService, grpcMethod, req)
+				if !ok {
+					continue
+				}
+				candidate := gatewayRouteCandidate{
+					gvk:        kubernetes.K8sGRPCRoutes,
+					meta:       route.ObjectMeta,
+					consumer:   route.Namespace != svcNamespace,
+					ruleIndex:  i,
+					precedence: precedence,
+				}
+				if rule.Name != nil {
+					candidate.ruleName = string(*rule.Name)
+				}
+				for _, backend := range rule.BackendRefs {
+					candidate.result.Destinations = append(candidate.result.Destinations, gatewayBackendDestination(backend.BackendRef, route.Namespace, req.Port, identityDomain))
+				}
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	if len(attached) == 0 {
+		return false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.consumer != b.consumer {
+			return a.consumer
+		}
+		for k := range a.precedence {
+			if k < len(b.precedence) && a.precedence[k] != b.precedence[k] {
+				return a.precedence[k] > b.precedence[k]
+			}
+		}
+		if a.gvk != b.gvk || a.meta.Name != b.meta.Name || a.meta.Namespace != b.meta.Namespace {
+			return olderObject(a.meta, b.meta)
+		}
+		return a.ruleIndex < b.ruleIndex
+	})
+
+	keys := make([]string, 0, len(attached))
+	for key := range attached {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if len(candidates) == 0 {
+		for _, key := range keys {
+			result.Contributors = append(result.Contributors, routeContributor(attachedGVK[key], attached[key], models.RouteContributionNoMatch, "Route is attached to the service but none of its rules match the request"))
+		}
+		result.RouteSource = attachedGVK[keys[0]].Kind
+		result.Notes = append(result.Notes, "Gateway API routes are attached to the service but none matches the request; the proxy responds with 404")
+		shadowVirtualServices(istioConfig.VirtualServices, identityDomain, result)
+		return true
+	}
+
+	selected := candidates[0]
+	result.RouteSource = selected.gvk.Kind
+	result.RouteRule = selected.ruleName
+	if result.RouteRule == "" {
+		result.RouteRule = strconv.Itoa(selected.ruleIndex)
+	}
+	result.Destinations = append(result.Destinations, normalizeWeights(selected.result.Destinations)...)
+	result.Timeout = selected.result.Timeout
+	result.Retries = selected.result.Retries
+	result.Redirect = selected.result.Redirect
+	result.Rewrite = selected.result.Rewrite
+	result.Mirror = selected.result.Mirror
+
+	for _, key := range keys {
+		meta := attached[key]
+		switch {
+		case meta.Namespace == selected.meta.Namespace && meta.Name == selected.meta.Name && attachedGVK[key] == selected.gvk:
+			result.Contributors = append(result.Contributors, routeContributor(attachedGVK[key], meta, models.RouteContributionSelected, fmt.Sprintf("Rule [%s] matches the request with the highest precedence", result.RouteRule)))
+		default:
+			result.Contributors = append(result.Contributors, routeContributor(attachedGVK[key], meta, models.RouteContributionShadowed, "Route is attached to the service but another rule has higher precedence"))
+		}
+	}
+
+	shadowVirtualServices(istioConfig.VirtualServices, identityDomain, result)
+	return true
+}
+
+// shadowVirtualServices reports the VirtualServices for the host, ignored because of Gateway API routes.
+func shadowVirtualServices(virtualServices []*networking_v1.VirtualService, identityDomain string, result *models.RouteResolutionResult) {
+	for _, vs := range virtualServices {
+		for _, host := range vs.Spec.Hosts {
+			if hostSpecificity(objectHostFQDN(host, vs.Namespace, identityDomain), result.Host) > 0 {
+				result.Contributors = append(result.Contributors, routeContributor(kubernetes.VirtualServices, vs.ObjectMeta, models.RouteContributionShadowed, "Gateway API routes attached to the service take precedence over VirtualServices"))
+				break
+			}
+		}
+	}
+}
+
+// gatewayRouteAttached checks whether the parentRefs of a route reference the destination Service
+// and whether the route is visible to the source: producer routes apply to every client,
+// consumer routes only to clients in the route namespace.
+func gatewayRouteAttached(parentRefs []k8s_networking_v1.ParentReference, routeNamespace, svcName, svcNamespace, sourceNamespace string, port int) bool {
+	if routeNamespace != svcNamespace && routeNamespace != sourceNamespace {
+		return false
+	}
+	for _, ref := range parentRefs {
+		if ref.Kind == nil || string(*ref.Kind) != kubernetes.ServiceType {
+			continue
+		}
+		if ref.Group != nil && string(*ref.Group) != "" && string(*ref.Group) != "core" {
+			continue
+		}
+		ns := routeNamespace
+		if ref.Namespace != nil {
+			ns = string(*ref.Namespace)
+		}
+		if string(ref.Name) != svcName || ns != svcNamespace {
+			continue
+		}
+		if ref.Port != nil && port != 0 && int(*ref.Port) != port {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchGatewayHTTPRoute returns the precedence of a matching HTTPRouteMatch following the Gateway API
+// ordering: exact path, longest prefix, method, number of headers and number of query params.
+func matchGatewayHTTPRoute(match k8s_networking_v1.HTTPRouteMatch, path string, query url.Values, req models.RouteResolutionRequest) ([]int, bool) {
+	exact, prefixLength := 0, 1
+	if match.Path != nil {
+		value := "/"
+		if match.Path.Value != nil {
+			value = *match.Path.Value
+		}
+		matchType := k8s_networking_v1.PathMatchPathPrefix
+		if match.Path.Type != nil {
+			matchType = *match.Path.Type
+		}
+		switch matchType {
+		case k8s_networking_v1.PathMatchExact:
+			if path != value {
+				return nil, false
+			}
+			exact = 1
+		case k8s_networking_v1.PathMatchRegularExpression:
+			if !matchFullRegex(value, path) {
+				return nil, false
+			}
+		default:
+			trimmed := strings.TrimSuffix(value, "/")
+			if path != trimmed && !strings.HasPrefix(path, trimmed+"/") && value != "/" {
+				return nil, false
+			}
+			prefixLength = len(value)
+		}
+	}
+
+	method := 0
+	if match.Method != nil {
+		if !strings.EqualFold(string(*match.Method), req.Method) {
+			return nil, false
+		}
+		method = 1
+	}
+
+	for _, header := range match.Headers {
+		value, found := requestHeader(req.Headers, string(header.Name))
+		if !found {
+			return nil, false
+		}
+		if header.Type != nil && *header.Type == k8s_networking_v1.HeaderMatchRegularExpression {
+			if !matchFullRegex(header.Value, value) {
+				return nil, false
+			}
+		} else if value != header.Value {
+			return nil, false
+		}
+	}
+
+	for _, param := range match.QueryParams {
+		value := query.Get(string(param.Name))
+		if !query.Has(string(param.Name)) {
+			return nil, false
+		}
+		if param.Type != nil && *param.Type == k8s_networking_v1.QueryParamMatchRegularExpression {
+			if !matchFullRegex(param.Value, value) {
+				return nil, false
+			}
+		} else if value != param.Value {
+			return nil, false
+		}
+	}
+
+	return []int{exact, prefixLength, method, len(match.Headers), len(match.QueryParams)}, true
+}
+
+// matchGatewayGRPCRoute returns the precedence of a matching GRPCRouteMatch: the number of characters of
+// the service and method matched, then the number of headers.
+func matchGatewayGRPCRoute(match k8s_networking_v1.GRPCRouteMatch, service, method string, req models.RouteResolutionRequest) ([]int, bool) {
+	serviceLength, methodLength := 0, 0
+	if match.Method != nil {
+		regex := match.Method.Type != nil && *match.Method.Type == k8s_networking_v1.GRPCMethodMatchRegularExpression
+		if match.Method.Service != nil {
+			if (regex && !matchFullRegex(*match.Method.Service, service)) || (!regex && *match.Method.Service != service) {
+				return nil, false
+			}
+			serviceLength = len(*match.Method.Service)
+		}
+		if match.Method.Method != nil {
+			if (regex && !matchFullRegex(*match.Method.Method, method)) || (!regex && *match.Method.Method != method) {
+				return nil, false
+			}
+			methodLength = len(*match.Method.Method)
+		}
+	}
+
+	for _, header := range match.Headers {
+		value, found := requestHeader(req.Headers, string(header.Name))
+		if !found {
+			return nil, false
+		}
+		if header.Type != nil && *header.Type == k8s_networking_v1.GRPCHeaderMatchRegularExpression {
+			if !matchFullRegex(header.Value, value) {
+				return nil, false
+			}
+		} else if value != header.Value {
+			return nil, false
+		}
+	}
+
+	return []int{serviceLength, methodLength, len(match.Headers)}, true
+}
+
+func gatewayBackendDestination(backend k8s_networking_v1.BackendRef, routeNamespace string, port int, identityDomain string) models.RouteDestination {
+	ns := routeNamespace
+	if backend.Namespace != nil {
+		ns = string(*backend.Namespace)
+	}
+	dest := models.RouteDestination{
+		Host:   fmt.Sprintf("%s.%s.%s", backend.Name, ns, identityDomain),
+		Port:   port,
+		Weight: 1,
+	}
+	if backend.Port != nil {
+		dest.Port = int(*backend.Port)
+	}
+	if backend.Weight != nil {
+		dest.Weight = *backend.Weight
+	}
+	return dest
+}
+
+// normalizeWeights converts relative weights into percentages.
+func normalizeWeights(destinations []models.RouteDestination) []models.RouteDestination {
+	total := int32(0)
+	for _, dest := range destinations {
+		total += dest.Weight
+	}
+	if len(destinations) == 1 && total == 0 {
+		destinations[0].Weight = 100
+		return destinations
+	}
+	if total == 0 {
+		return destinations
+	}
+	for i := range destinations {
+		destinations[i].Weight = destinations[i].Weight * 100 / total
+	}
+	return destinations
+}
+
+// virtualServiceCandidate is a VirtualService whose hosts include the destination host.
+type virtualServiceCandidate struct {
+	vs          *networking_v1.VirtualService
+	specificity int
+}
+
+// resolveVirtualServiceRoute selects the VirtualService applying to the host in the source proxy and its
+// first matching route. Sidecars use a single VirtualService per host: the most specific host wins,
+// then the one in the source namespace, then the oldest one.
+func resolveVirtualServiceRoute(virtualServices []*networking_v1.VirtualService, source routeSource, req models.RouteResolutionRequest, identityDomain string, result *models.RouteResolutionResult) bool {
+	candidates := []virtualServiceCandidate{}
+	for _, vs := range virtualServices {
+		if !appliesToMesh(vs.Spec.Gateways) || !kubernetes.IsExportedTo(vs.Spec.ExportTo, vs.Namespace, source.Namespace) {
+			continue
+		}
+		specificity := 0
+		for _, host := range vs.Spec.Hosts {
+			if s := hostSpecificity(objectHostFQDN(host, vs.Namespace, identityDomain), result.Host); s > specificity {
+				specificity = s
+			}
+		}
+		if specificity > 0 {
+			candidates = append(candidates, virtualServiceCandidate{vs: vs, specificity: specificity})
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.specificity != b.specificity {
+			return a.specificity > b.specificity
+		}
+		if (a.vs.Namespace == source.Namespace) != (b.vs.Namespace == source.Namespace) {
+			return a.vs.Namespace == source.Namespace
+		}
+		return olderObject(a.vs.ObjectMeta, b.vs.ObjectMeta)
+	})
+
+	selected := candidates[0].vs
+	for _, candidate := range candidates[1:] {
+		result.Contributors = append(result.Contributors, routeContributor(kubernetes.VirtualServices, candidate.vs.ObjectMeta, models.RouteContributionShadowed,
+			fmt.Sprintf("VirtualService [%s/%s] takes precedence for this host", selected.Namespace, selected.Name)))
+	}
+
+	result.RouteSource = models.RouteSourceVirtualService
+	path, query := splitRequestPath(req.Path)
+
+	switch {
+	case len(selected.Spec.Http) > 0:
+		for i, route := range selected.Spec.Http {
+			if !matchVirtualServiceHTTPRoute(route.Match, path, query, source, req, result) {
+				continue
+			}
+			result.RouteRule = route.Name
+			if result.RouteRule == "" {
+				result.RouteRule = strconv.Itoa(i)
+			}
+			destinations := []models.RouteDestination{}
+			for _, dest := range route.Route {
+				destinations = append(destinations, virtualServiceDestination(dest.Destination, dest.Weight, selected.Namespace, req.Port, identityDomain))
+			}
+			result.Destinations = append(result.Destinations, normalizeWeights(destinations)...)
+			if route.Timeout != nil {
+				result.Timeout = route.Timeout.AsDuration().String()
+			}
+			if route.Retries != nil {
+				result.Retries = &models.RouteRetries{Attempts: route.Retries.Attempts, RetryOn: route.Retries.RetryOn}
+				if route.Retries.PerTryTimeout != nil {
+					result.Retries.PerTryTimeout = route.Retries.PerTryTimeout.AsDuration().String()
+				}
+			}
+			result.Redirect = route.Redirect != nil || route.DirectResponse != nil
+			result.Rewrite = route.Rewrite != nil
+			result.Mirror = route.Mirror != nil || len(route.Mirrors) > 0
+			result.Fault = route.Fault != nil
+			result.Contributors = append(result.Contributors, routeContributor(kubernetes.VirtualServices, selected.ObjectMeta, models.RouteContributionSelected,
+				fmt.Sprintf("HTTP route [%s] is the first route matching the request", result.RouteRule)))
+			return true
+		}
+	case len(selected.Spec.Tcp) > 0:
+		for i, route := range selected.Spec.Tcp {
+			if !matchVirtualServiceTCPRoute(route.Match, source, req) {
+				continue
+			}
+			result.RouteRule = strconv.Itoa(i)
+			destinations := []models.RouteDestination{}
+			for _, dest := range route.Route {
+				destinations = append(destinations, virtualServiceDestination(dest.Destination, dest.Weight, selected.Namespace, req.Port, identityDomain))
+			}
+			result.Destinations = append(result.Destinations, normalizeWeights(destinations)...)
+			result.Contributors = append(result.Contributors, routeContributor(kubernetes.VirtualServices, selected.ObjectMeta, models.RouteContributionSelected,
+				fmt.Sprintf("TCP route [%s] is the first route matching the connection", result.RouteRule)))
+			return true
+		}
+	default:
+		result.Notes = append(result.Notes, "Only HTTP and TCP routes of VirtualServices are resolved")
+	}
+
+	result.Contributors = append(result.Contributors, routeContributor(kubernetes.VirtualServices, selected.ObjectMeta, models.RouteContributionNoMatch, "None of the routes match the request"))
+	result.Notes = append(result.Notes, "The VirtualService has no route matching the request; the proxy responds with 404")
+	return true
+}
+
+func appliesToMesh(gateways []string) bool {
+	if len(gateways) == 0 {
+		return true
+	}
+	for _, gw := range gateways {
+		if gw == "mesh" {
+			return true
+		}
+	}
+	return false
+}
+
+func virtualServiceDestination(dest *api_networking_v1.Destination, weight int32, namespace string, port int, identityDomain string) models.RouteDestination {
+	if dest == nil {
+		return models.RouteDestination{Weight: weight}
+	}
+	result := models.RouteDestination{
+		Host:   objectHostFQDN(dest.Host, namespace, identityDomain),
+		Port:   port,
+		Subset: dest.Subset,
+		Weight: weight,
+	}
+	if dest.Port != nil && dest.Port.Number != 0 {
+		result.Port = int(dest.Port.Number)
+	}
+	return result
+}
+
+// matchVirtualServiceHTTPRoute returns true when any of the match requests matches (ORed), or when
+// there are none. Conditions inside a match request are ANDed.
+func matchVirtualServiceHTTPRoute(matches []*api_networking_v1.HTTPMatchRequest, path string, query url.Values, source routeSource, req models.RouteResolutionRequest, result *models.RouteResolutionResult) bool {
+	if len(matches) == 0 {
+		return true
+	}
+	for _, match := range matches {
+		if match.Port != 0 && int(match.Port) != req.Port {
+			continue
+		}
+		if match.SourceNamespace != "" && match.SourceNamespace != source.Namespace {
+			continue
+		}
+		if len(match.SourceLabels) > 0 && !labels.SelectorFromSet(match.SourceLabels).Matches(labels.Set(source.Labels)) {
+			continue
+		}
+		if !appliesToMesh(match.Gateways) {
+			continue
+		}
+		if !matchStringMatch(match.Uri, path, match.IgnoreUriCase) || !matchStringMatch(match.Method, req.Method, false) || !matchStringMatch(match.Authority, req.Host, false) {
+			continue
+		}
+		if match.Scheme != nil {
+			result.Notes = append(result.Notes, "Scheme conditions are not evaluated")
+		}
+		if !matchStringMatches(match.Headers, req.Headers) {
+			continue
+		}
+		if withoutHeadersMatch(match.WithoutHeaders, req.Headers) {
+			continue
+		}
+		queryMatches := true
+		for name, qm := range match.QueryParams {
+			if !query.Has(name) || !matchStringMatch(qm, query.Get(name), false) {
+				queryMatches = false
+				break
+			}
+		}
+		if queryMatches {
+			return true
+		}
+	}
+	return false
+}
+
+func matchVirtualServiceTCPRoute(matches []*api_networking_v1.L4MatchAttributes, source routeSource, req models.RouteResolutionRequest) bool {
+	if len(matches) == 0 {
+		return true
+	}
+	for _, match := range matches {
+		if match.Port != 0 && int(match.Port) != req.Port {
+			continue
+		}
+		if match.SourceNamespace != "" && match.SourceNamespace != source.Namespace {
+			continue
+		}
+		if len(match.SourceLabels) > 0 && !labels.SelectorFromSet(match.SourceLabels).Matches(labels.Set(source.Labels)) {
+			continue
+		}
+		if !appliesToMesh(match.Gateways) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func matchStringMatches(matches map[string]*api_networking_v1.StringMatch, headers map[string]string) bool {
+	for name, sm := range matches {
+		value, found := requestHeader(headers, name)
+		if !found || !matchStringMatch(sm, value, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutHeadersMatch returns true when any of the withoutHeaders conditions matches the request.
+func withoutHeadersMatch(matches map[string]*api_networking_v1.StringMatch, headers map[string]string) bool {
+	for name, sm := range matches {
+		if value, found := requestHeader(headers, name); found && matchStringMatch(sm, value, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchStringMatch evaluates an Istio StringMatch. A nil match matches everything and an empty one
+// only checks the presence of the value.
+func matchStringMatch(match *api_networking_v1.StringMatch, value string, ignoreCase bool) bool {
+	if match == nil {
+		return true
+	}
+	if ignoreCase {
+		value = strings.ToLower(value)
+	}
+	switch {
+	case match.GetExact() != "":
+		expected := match.GetExact()
+		if ignoreCase {
+			expected = strings.ToLower(expected)
+		}
+		return value == expected
+	case match.GetPrefix() != "":
+		prefix := match.GetPrefix()
+		if ignoreCase {
+			prefix = strings.ToLower(prefix)
+		}
+		return strings.HasPrefix(value, prefix)
+	case match.GetRegex() != "":
+		return matchFullRegex(match.GetRegex(), value)
+	}
+	return true
+}
+
+// matchFullRegex evaluates a RE2 expression against the whole value, as Envoy does.
+func matchFullRegex(expr, value string) bool {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return false
+	}
+	return re.MatchString(value)
+}
+
+func requestHeader(headers map[string]string, name string) (string, bool) {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func splitRequestPath(requestPath string) (string, url.Values) {
+	path, rawQuery, _ := strings.Cut(requestPath, "?")
+	if path == "" {
+		path = "/"
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		query = url.Values{}
+	}
+	return path, query
+}
+
+// applyDestinationRule looks up the DestinationRule of a destination the way Istio does: the one in
+// the source namespace first, then the one in the destination namespace and finally the one in the
+// root namespace. The most specific host wins inside a namespace.
+func applyDestinationRule(destinationRules []*networking_v1.DestinationRule, source routeSource, identityDomain string, dest *models.RouteDestination, result *models.RouteResolutionResult) {
+	if dest.Port != 0 {
+		dest.Cluster = fmt.Sprintf("outbound|%d|%s|%s", dest.Port, dest.Subset, dest.Host)
+	}
+
+	_, destNamespace, _ := serviceHostParts(dest.Host, identityDomain)
+	rank := func(dr *networking_v1.DestinationRule) int {
+		switch dr.Namespace {
+		case source.Namespace:
+			return 0
+		case destNamespace:
+			return 1
+		case source.RootNamespace:
+			return 2
+		}
+		return 3
+	}
+
+	var selected *networking_v1.DestinationRule
+	selectedSpecificity := 0
+	for _, dr := range destinationRules {
+		if !kubernetes.IsExportedTo(dr.Spec.ExportTo, dr.Namespace, source.Namespace) {
+			continue
+		}
+		if dr.Spec.WorkloadSelector != nil && (dr.Namespace != source.Namespace ||
+			!labels.SelectorFromSet(dr.Spec.WorkloadSelector.MatchLabels).Matches(labels.Set(source.Labels))) {
+			continue
+		}
+		specificity := hostSpecificity(objectHostFQDN(dr.Spec.Host, dr.Namespace, identityDomain), dest.Host)
+		if specificity == 0 {
+			continue
+		}
+		if selected == nil || rank(dr) < rank(selected) ||
+			(rank(dr) == rank(selected) && (specificity > selectedSpecificity || (specificity == selectedSpecificity && olderObject(dr.ObjectMeta, selected.ObjectMeta)))) {
+			selected = dr
+			selectedSpecificity = specificity
+		}
+	}
+
+	if selected == nil {
+		if dest.Subset != "" {
+			result.Notes = append(result.Notes, fmt.Sprintf("Subset [%s] of host [%s] is not defined by any DestinationRule; the proxy responds with 503", dest.Subset, dest.Host))
+		}
+		return
+	}
+
+	dest.DestinationRule = &models.IstioReference{ObjectGVK: kubernetes.DestinationRules, Name: selected.Name, Namespace: selected.Namespace}
+	reason := fmt.Sprintf("Traffic policy applies to host [%s]", dest.Host)
+	if dest.Subset != "" {
+		found := false
+		for _, subset := range selected.Spec.Subsets {
+			if subset.Name == dest.Subset {
+				dest.SubsetLabels = subset.Labels
+				found = true
+				break
+			}
+		}
+		if found {
+			reason = fmt.Sprintf("Defines subset [%s] of host [%s]", dest.Subset, dest.Host)
+		} else {
+			result.Notes = append(result.Notes, fmt.Sprintf("Subset [%s] is not defined in DestinationRule [%s/%s]; the proxy responds with 503", dest.Subset, selected.Namespace, selected.Name))
+		}
+	}
+
+	for _, c := range result.Contributors {
+		if c.ObjectGVK == kubernetes.DestinationRules && c.Namespace == selected.Namespace && c.Name == selected.Name && c.Reason == reason {
+			return
+		}
+	}
+	result.Contributors = append(result.Contributors, routeContributor(kubernetes.DestinationRules, selected.ObjectMeta, models.RouteContributionApplied, reason))
+}
+
+// checkProxyRoute compares the resolved route with the outbound route configuration of the source proxy.
+func checkProxyRoute(dump *kubernetes.ConfigDump, pod string, result *models.RouteResolutionResult) *models.RouteProxyCheck {
+	check := &models.RouteProxyCheck{
+		Pod:        pod,
+		Consistent: true,
+		Clusters:   []string{},
+		Notes:      []string{},
+	}
+
+	routes, err := dump.GetRoutes()
+	if err != nil {
+		check.Consistent = false
+		check.Notes = append(check.Notes, fmt.Sprintf("Routes could not be read from the config_dump: %v", err))
+		return check
+	}
+
+	routeConfigName := strconv.Itoa(result.Port)
+	hostPort := fmt.Sprintf("%s:%d", result.Host, result.Port)
+	var virtualHost *kubernetes.VirtualHostFilter
+	for _, routeSet := range [][]kubernetes.EnvoyRouteConfig{routes.DynamicRouteConfigs, routes.StaticRouteConfigs} {
+		for _, rc := range routeSet {
+			if rc.RouteConfig == nil || rc.RouteConfig.Name != routeConfigName {
+				continue
+			}
+			for i, vh := range rc.RouteConfig.VirtualHosts {
+				for _, domain := range vh.Domains {
+					if domain == result.Host || domain == hostPort {
+						virtualHost = &rc.RouteConfig.VirtualHosts[i]
+					}
+				}
+			}
+		}
+	}
+
+	if virtualHost == nil {
+		if result.Visible && result.RouteSource != models.RouteSourceDefault {
+			check.Consistent = false
+		}
+		check.Notes = append(check.Notes, fmt.Sprintf("No virtual host for [%s] in route configuration [%s]", hostPort, routeConfigName))
+		return check
+	}
+
+	check.VirtualHost = virtualHost.Name
+	for _, route := range virtualHost.Routes {
+		if vs := virtualServiceFromMetadata(route.Metadata); vs != "" && check.VirtualService == "" {
+			check.VirtualService = vs
+		}
+		if route.Route == nil {
+			continue
+		}
+		if route.Route.Cluster != "" {
+			check.Clusters = appendUnique(check.Clusters, route.Route.Cluster)
+		}
+		if route.Route.WeightedClusters != nil {
+			for _, wc := range route.Route.WeightedClusters.Clusters {
+				check.Clusters = appendUnique(check.Clusters, wc.Name)
+			}
+		}
+	}
+
+	if !result.Visible {
+		check.Consistent = false
+		check.Notes = append(check.Notes, "The proxy has a virtual host for a host expected to be outside of the Sidecar egress scope")
+	}
+	if result.RouteSource == models.RouteSourceVirtualService {
+		for _, c := range result.Contributors {
+			if c.ObjectGVK == kubernetes.VirtualServices && (c.Role == models.RouteContributionSelected || c.Role == models.RouteContributionNoMatch) {
+				expected := fmt.Sprintf("%s.%s", c.Name, c.Namespace)
+				if check.VirtualService != expected {
+					check.Consistent = false
+					check.Notes = append(check.Notes, fmt.Sprintf("The proxy routes are generated from VirtualService [%s], expected [%s]", check.VirtualService, expected))
+				}
+			}
+		}
+	}
+	for _, dest := range result.Destinations {
+		if dest.Cluster == "" {
+			continue
+		}
+		found := false
+		for _, cluster := range check.Clusters {
+			if cluster == dest.Cluster {
+				found = true
+				break
+			}
+		}
+		if !found {
+			check.Consistent = false
+			check.Notes = append(check.Notes, fmt.Sprintf("Cluster [%s] is not targeted by the proxy routes", dest.Cluster))
+		}
+	}
+
+	return check
+}
+
+// virtualServiceFromMetadata returns the name.namespace of the VirtualService an Envoy route was generated from.
+func virtualServiceFromMetadata(metadata *kubernetes.EnvoyMetadata) string {
+	if metadata == nil || metadata.FilterMetadata == nil || metadata.FilterMetadata.Istio == nil {
+		return ""
+	}
+	parts := strings.Split(metadata.FilterMetadata.Istio.Config, "/")
+	// /apis/networking.istio.io/v1/namespaces/<namespace>/virtual-service/<name>
+	if len(parts) != 8 || parts[6] != "virtual-service" {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s", parts[7], parts[5])
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
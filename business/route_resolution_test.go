@@ -0,0 +1,299 @@
+package business
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+const routeIdentityDomain = "svc.cluster.local"
+
+func productpageSource() routeSource {
+	return routeSource{Labels: map[string]string{"app": "productpage"}, Namespace: "bookinfo", RootNamespace: "istio-system"}
+}
+
+func fakeRouteVirtualService(name, namespace string, created time.Time, hosts []string, routes ...*api_networking_v1.HTTPRoute) *networking_v1.VirtualService {
+	vs := &networking_v1.VirtualService{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: meta_v1.NewTime(created)},
+	}
+	vs.Spec.Hosts = hosts
+	vs.Spec.Http = routes
+	return vs
+}
+
+func fakeRouteDestinationRule(name, namespace, host string, subsets ...string) *networking_v1.DestinationRule {
+	dr := &networking_v1.DestinationRule{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+	}
+	dr.Spec.Host = host
+	for _, subset := range subsets {
+		dr.Spec.Subsets = append(dr.Spec.Subsets, &api_networking_v1.Subset{Name: subset, Labels: map[string]string{"version": subset}})
+	}
+	return dr
+}
+
+func routeTo(host, subset string, weight int32) *api_networking_v1.HTTPRouteDestination {
+	return &api_networking_v1.HTTPRouteDestination{Destination: &api_networking_v1.Destination{Host: host, Subset: subset}, Weight: weight}
+}
+
+func reviewsRequest(path string) models.RouteResolutionRequest {
+	return models.RouteResolutionRequest{Host: "reviews", Port: 9080, Path: path, Method: "GET"}
+}
+
+func TestResolveRouteDefault(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	result := resolveRoute(&models.IstioConfigList{}, productpageSource(), reviewsRequest("/"), routeIdentityDomain)
+
+	assert.Equal("reviews.bookinfo.svc.cluster.local", result.Host)
+	assert.True(result.Visible)
+	assert.Equal(models.RouteSourceDefault, result.RouteSource)
+	require.Len(result.Destinations, 1)
+	assert.Equal(int32(100), result.Destinations[0].Weight)
+	assert.Equal("outbound|9080||reviews.bookinfo.svc.cluster.local", result.Destinations[0].Cluster)
+}
+
+func TestResolveRouteVirtualServiceFirstMatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vs := fakeRouteVirtualService("reviews", "bookinfo", time.Now(), []string{"reviews"},
+		&api_networking_v1.HTTPRoute{
+			Name: "jason",
+			Match: []*api_networking_v1.HTTPMatchRequest{{
+				Headers: map[string]*api_networking_v1.StringMatch{"end-user": {MatchType: &api_networking_v1.StringMatch_Exact{Exact: "jason"}}},
+			}},
+			Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v2", 0)},
+		},
+		&api_networking_v1.HTTPRoute{
+			Match:   []*api_networking_v1.HTTPMatchRequest{{Uri: &api_networking_v1.StringMatch{MatchType: &api_networking_v1.StringMatch_Prefix{Prefix: "/reviews"}}}},
+			Route:   []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v1", 80), routeTo("reviews", "v3", 20)},
+			Timeout: durationpb.New(2 * time.Second),
+			Retries: &api_networking_v1.HTTPRetry{Attempts: 3, RetryOn: "5xx"},
+		},
+	)
+	dr := fakeRouteDestinationRule("reviews", "bookinfo", "reviews", "v1", "v2", "v3")
+	istioConfig := &models.IstioConfigList{VirtualServices: []*networking_v1.VirtualService{vs}, DestinationRules: []*networking_v1.DestinationRule{dr}}
+
+	result := resolveRoute(istioConfig, productpageSource(), reviewsRequest("/reviews/1"), routeIdentityDomain)
+	assert.Equal(models.RouteSourceVirtualService, result.RouteSource)
+	assert.Equal("1", result.RouteRule)
+	require.Len(result.Destinations, 2)
+	assert.Equal("v1", result.Destinations[0].Subset)
+	assert.Equal(int32(80), result.Destinations[0].Weight)
+	assert.Equal(map[string]string{"version": "v1"}, result.Destinations[0].SubsetLabels)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("reviews", result.Destinations[0].DestinationRule.Name)
+	assert.Equal("outbound|9080|v3|reviews.bookinfo.svc.cluster.local", result.Destinations[1].Cluster)
+	assert.Equal("2s", result.Timeout)
+	require.NotNil(result.Retries)
+	assert.Equal(int32(3), result.Retries.Attempts)
+
+	req := reviewsRequest("/reviews/1")
+	req.Headers = map[string]string{"End-User": "jason"}
+	result = resolveRoute(istioConfig, productpageSource(), req, routeIdentityDomain)
+	assert.Equal("jason", result.RouteRule)
+	require.Len(result.Destinations, 1)
+	assert.Equal("v2", result.Destinations[0].Subset)
+	assert.Equal(int32(100), result.Destinations[0].Weight)
+
+	result = resolveRoute(istioConfig, productpageSource(), reviewsRequest("/ratings"), routeIdentityDomain)
+	assert.Equal(models.RouteSourceVirtualService, result.RouteSource)
+	assert.Empty(result.Destinations)
+	require.NotEmpty(result.Contributors)
+	assert.Equal(models.RouteContributionNoMatch, result.Contributors[0].Role)
+}
+
+func TestResolveRouteVirtualServicePrecedence(t *testing.T) {
+	assert := assert.New(t)
+
+	older := fakeRouteVirtualService("reviews-old", "bookinfo", time.Now().Add(-time.Hour), []string{"reviews"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v1", 0)}})
+	newer := fakeRouteVirtualService("reviews-new", "bookinfo", time.Now(), []string{"reviews.bookinfo.svc.cluster.local"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v2", 0)}})
+	wildcard := fakeRouteVirtualService("all", "bookinfo", time.Now().Add(-2*time.Hour), []string{"*.bookinfo.svc.cluster.local"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v3", 0)}})
+	hidden := fakeRouteVirtualService("hidden", "other", time.Now().Add(-3*time.Hour), []string{"reviews.bookinfo.svc.cluster.local"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v4", 0)}})
+	hidden.Spec.ExportTo = []string{"."}
+
+	istioConfig := &models.IstioConfigList{VirtualServices: []*networking_v1.VirtualService{wildcard, newer, older, hidden}}
+	result := resolveRoute(istioConfig, productpageSource(), reviewsRequest("/"), routeIdentityDomain)
+
+	assert.Equal("v1", result.Destinations[0].Subset)
+	roles := map[string]string{}
+	for _, c := range result.Contributors {
+		roles[c.Name] = c.Role
+	}
+	assert.Equal(map[string]string{
+		"reviews-old": models.RouteContributionSelected,
+		"reviews-new": models.RouteContributionShadowed,
+		"all":         models.RouteContributionShadowed,
+	}, roles)
+	assert.Contains(result.Notes, "Subset [v1] of host [reviews.bookinfo.svc.cluster.local] is not defined by any DestinationRule; the proxy responds with 503")
+}
+
+func TestResolveRouteGatewayAPIPrecedence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	serviceKind := k8s_networking_v1.Kind("Service")
+	exact := k8s_networking_v1.PathMatchExact
+	prefix := k8s_networking_v1.PathMatchPathPrefix
+	weight80, weight20 := int32(80), int32(20)
+	reviewsPrefix, reviewsExact := "/reviews", "/reviews/1"
+	route := &k8s_networking_v1.HTTPRoute{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews", Namespace: "bookinfo"}}
+	route.Spec.ParentRefs = []k8s_networking_v1.ParentReference{{Kind: &serviceKind, Name: "reviews"}}
+	route.Spec.Rules = []k8s_networking_v1.HTTPRouteRule{
+		{
+			Matches: []k8s_networking_v1.HTTPRouteMatch{{Path: &k8s_networking_v1.HTTPPathMatch{Type: &prefix, Value: &reviewsPrefix}}},
+			BackendRefs: []k8s_networking_v1.HTTPBackendRef{
+				{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: k8s_networking_v1.BackendObjectReference{Name: "reviews-v1"}, Weight: &weight80}},
+				{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: k8s_networking_v1.BackendObjectReference{Name: "reviews-v2"}, Weight: &weight20}},
+			},
+		},
+		{
+			Matches:     []k8s_networking_v1.HTTPRouteMatch{{Path: &k8s_networking_v1.HTTPPathMatch{Type: &exact, Value: &reviewsExact}}},
+			BackendRefs: []k8s_networking_v1.HTTPBackendRef{{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: k8s_networking_v1.BackendObjectReference{Name: "reviews-v3"}}}},
+		},
+	}
+	vs := fakeRouteVirtualService("reviews", "bookinfo", time.Now(), []string{"reviews"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v1", 0)}})
+	istioConfig := &models.IstioConfigList{K8sHTTPRoutes: []*k8s_networking_v1.HTTPRoute{route}, VirtualServices: []*networking_v1.VirtualService{vs}}
+
+	result := resolveRoute(istioConfig, productpageSource(), reviewsRequest("/reviews/1"), routeIdentityDomain)
+	assert.Equal(models.RouteSourceHTTPRoute, result.RouteSource)
+	assert.Equal("1", result.RouteRule)
+	require.Len(result.Destinations, 1)
+	assert.Equal("reviews-v3.bookinfo.svc.cluster.local", result.Destinations[0].Host)
+
+	result = resolveRoute(istioConfig, productpageSource(), reviewsRequest("/reviews/2"), routeIdentityDomain)
+	assert.Equal("0", result.RouteRule)
+	require.Len(result.Destinations, 2)
+	assert.Equal(int32(80), result.Destinations[0].Weight)
+	assert.Equal(int32(20), result.Destinations[1].Weight)
+
+	// Prefix matches are done by path element
+	result = resolveRoute(istioConfig, productpageSource(), reviewsRequest("/reviewsX"), routeIdentityDomain)
+	assert.Empty(result.Destinations)
+
+	shadowed := false
+	for _, c := range result.Contributors {
+		if c.ObjectGVK == kubernetes.VirtualServices && c.Role == models.RouteContributionShadowed {
+			shadowed = true
+		}
+	}
+	assert.True(shadowed)
+}
+
+func TestResolveRouteSidecarEgress(t *testing.T) {
+	assert := assert.New(t)
+
+	sc := &networking_v1.Sidecar{ObjectMeta: meta_v1.ObjectMeta{Name: "default", Namespace: "bookinfo"}}
+	sc.Spec.Egress = []*api_networking_v1.IstioEgressListener{{Hosts: []string{"./*", "istio-system/*"}}}
+	istioConfig := &models.IstioConfigList{Sidecars: []*networking_v1.Sidecar{sc}}
+
+	result := resolveRoute(istioConfig, productpageSource(), reviewsRequest("/"), routeIdentityDomain)
+	assert.True(result.Visible)
+
+	req := reviewsRequest("/")
+	req.Host = "reviews.other"
+	result = resolveRoute(istioConfig, productpageSource(), req, routeIdentityDomain)
+	assert.False(result.Visible)
+	assert.Equal("reviews.other.svc.cluster.local", result.Host)
+	assert.Empty(result.Destinations)
+}
+
+func TestCheckProxyRoute(t *testing.T) {
+	assert := assert.New(t)
+
+	dump := &kubernetes.ConfigDump{Configs: []interface{}{
+		map[string]interface{}{
+			"@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+			"dynamic_route_configs": []interface{}{
+				map[string]interface{}{
+					"route_config": map[string]interface{}{
+						"name": "9080",
+						"virtual_hosts": []interface{}{
+							map[string]interface{}{
+								"name":    "reviews.bookinfo.svc.cluster.local:9080",
+								"domains": []interface{}{"reviews.bookinfo.svc.cluster.local", "reviews", "reviews:9080"},
+								"routes": []interface{}{
+									map[string]interface{}{
+										"match":    map[string]interface{}{"prefix": "/"},
+										"metadata": map[string]interface{}{"filter_metadata": map[string]interface{}{"istio": map[string]interface{}{"config": "/apis/networking.istio.io/v1/namespaces/bookinfo/virtual-service/reviews"}}},
+										"route":    map[string]interface{}{"cluster": "outbound|9080|v1|reviews.bookinfo.svc.cluster.local"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	vs := fakeRouteVirtualService("reviews", "bookinfo", time.Now(), []string{"reviews"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("reviews", "v1", 0)}})
+	result := resolveRoute(&models.IstioConfigList{VirtualServices: []*networking_v1.VirtualService{vs}}, productpageSource(), reviewsRequest("/"), routeIdentityDomain)
+
+	check := checkProxyRoute(dump, "productpage-v1-123", result)
+	assert.True(check.Consistent, check.Notes)
+	assert.Equal("reviews.bookinfo.svc.cluster.local:9080", check.VirtualHost)
+	assert.Equal("reviews.bookinfo", check.VirtualService)
+	assert.Equal([]string{"outbound|9080|v1|reviews.bookinfo.svc.cluster.local"}, check.Clusters)
+
+	result.Destinations[0].Cluster = "outbound|9080|v2|reviews.bookinfo.svc.cluster.local"
+	check = checkProxyRoute(dump, "productpage-v1-123", result)
+	assert.False(check.Consistent)
+}
+
+func TestResolveRouteFromWorkload(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.Deployment.ClusterWideAccess = true
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.IstioLabels.AppLabelName = "app"
+	conf.IstioLabels.VersionLabelName = "version"
+	kubernetes.SetConfig(t, *conf)
+
+	vs := fakeRouteVirtualService("details", "Namespace", time.Now(), []string{"details"},
+		&api_networking_v1.HTTPRoute{Route: []*api_networking_v1.HTTPRouteDestination{routeTo("details", "v1", 0)}})
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&FakeDepSyncedWithRS(conf)[0],
+		vs,
+		fakeRouteDestinationRule("details", "Namespace", "details", "v1"),
+	}
+	k8s := kubetest.NewFakeK8sClient(kubeObjs...)
+	layer := NewLayerBuilder(t, conf).WithClient(k8s).Build()
+
+	req := models.RouteResolutionRequest{Host: "details", Port: 9080, Path: "/details/0", Method: "GET"}
+	result, err := layer.Routing.ResolveRoute(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", "details-v1", req)
+	require.NoError(err)
+	assert.Equal("details.Namespace.svc.cluster.local", result.Host)
+	assert.Equal(models.RouteSourceVirtualService, result.RouteSource)
+	require.Len(result.Destinations, 1)
+	assert.Equal("v1", result.Destinations[0].Subset)
+	require.NotNil(result.Destinations[0].DestinationRule)
+	assert.Equal("details", result.Destinations[0].DestinationRule.Name)
+}
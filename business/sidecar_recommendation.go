@@ -0,0 +1,312 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// sidecarNoEgressHost is the egress host of a Sidecar whose workloads called no destination: it matches no host,
+// whereas a Sidecar without egress hosts keeps the default visibility.
+const sidecarNoEgressHost = "~/*"
+
+// SidecarRecommendationCriteria are the observed traffic and the options of a Sidecar recommendation.
+type SidecarRecommendationCriteria struct {
+	// Duration is the window of the observed traffic
+	Duration string
+	// Scope is models.SidecarRecommendationScopeNamespace or models.SidecarRecommendationScopeWorkload
+	Scope string
+	// Traffic are the destinations called by the workloads of the namespace, by source workload name.
+	// The host of a service destination is resolved from its service name and namespace when it is empty.
+	Traffic map[string][]models.SidecarDestination
+	// Workload restricts a workload scoped recommendation to one workload
+	Workload string
+}
+
+// RecommendSidecars generates the Sidecars that restrict the egress of the workloads of a namespace to the destinations
+// they called in the observed traffic: one Sidecar for the whole namespace, or one per workload. The Sidecars are
+// validated as if they were applied, and the configuration size they save is estimated from the config_dump of a pod.
+func (in *IstioConfigService) RecommendSidecars(ctx context.Context, cluster, namespace string, criteria SidecarRecommendationCriteria) (*models.SidecarRecommendation, error) {
+	if criteria.Scope != models.SidecarRecommendationScopeNamespace && criteria.Scope != models.SidecarRecommendationScopeWorkload {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("scope [%s] is not valid, use namespace or workload", criteria.Scope))
+	}
+	if criteria.Workload != "" && criteria.Scope != models.SidecarRecommendationScopeWorkload {
+		return nil, api_errors.NewBadRequest("a workload can only be selected with the workload scope")
+	}
+
+	workloadList, err := in.businessLayer.Workload.GetWorkloadList(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	result := &models.SidecarRecommendation{
+		Cluster:   cluster,
+		Namespace: namespace,
+		Scope:     criteria.Scope,
+		Duration:  criteria.Duration,
+		Proposals: []models.SidecarProposal{},
+		Notes:     []string{fmt.Sprintf("Destinations not called during the last %s are not reachable with the recommended Sidecars", criteria.Duration)},
+	}
+
+	// Sidecars only apply to sidecar proxies: gateways, waypoints and workloads without a proxy are left out
+	workloads := []models.WorkloadListItem{}
+	for _, wk := range workloadList.Workloads {
+		if wk.IstioSidecar && !wk.IsGateway && !wk.IsWaypoint {
+			workloads = append(workloads, wk)
+		} else if len(criteria.Traffic[wk.Name]) > 0 {
+			result.Notes = append(result.Notes, fmt.Sprintf("Workload [%s] has no sidecar proxy, its traffic is not considered", wk.Name))
+		}
+	}
+	sort.Slice(workloads, func(i, j int) bool { return workloads[i].Name < workloads[j].Name })
+	if criteria.Workload != "" {
+		i := slices.IndexFunc(workloadList.Workloads, func(wk models.WorkloadListItem) bool { return wk.Name == criteria.Workload })
+		if i < 0 {
+			return nil, api_errors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "workloads"}, criteria.Workload)
+		}
+		j := slices.IndexFunc(workloads, func(wk models.WorkloadListItem) bool { return wk.Name == criteria.Workload })
+		if j < 0 {
+			return nil, api_errors.NewBadRequest(fmt.Sprintf("workload [%s] has no sidecar proxy", criteria.Workload))
+		}
+		workloads = workloads[j : j+1]
+	}
+
+	identityDomain := in.businessLayer.Svc.ResolveIdentityDomain(ctx, cluster)
+	proposed := &models.IstioConfigList{}
+	addProposal := func(sidecar *networking_v1.Sidecar, workload string, sources []models.WorkloadListItem) error {
+		destinations := sidecarDestinations(criteria.Traffic, sources, identityDomain)
+		sidecar.Spec.Egress = []*api_networking_v1.IstioEgressListener{{Hosts: sidecarEgressHosts(destinations)}}
+		obj, err := in.conversionObject(ctx, cluster, gatewayAPIConvertedObject{gvk: kubernetes.Sidecars, object: sidecar})
+		if err != nil {
+			return err
+		}
+		proposal := models.SidecarProposal{
+			Workload:     workload,
+			ObjectGVK:    obj.ObjectGVK,
+			Namespace:    obj.Namespace,
+			Name:         obj.Name,
+			Operation:    obj.Operation,
+			Manifest:     obj.Manifest,
+			Patch:        obj.Patch,
+			Hosts:        sidecar.Spec.Egress[0].Hosts,
+			Destinations: destinations,
+		}
+		in.estimateProposalConfigSize(ctx, cluster, namespace, sources, &proposal)
+		result.Proposals = append(result.Proposals, proposal)
+		proposed.Add(sidecar)
+		return nil
+	}
+
+	if criteria.Scope == models.SidecarRecommendationScopeNamespace {
+		if len(workloads) > 0 {
+			sidecar := &networking_v1.Sidecar{ObjectMeta: meta_v1.ObjectMeta{Name: "default", Namespace: namespace}}
+			if err := addProposal(sidecar, "", workloads); err != nil {
+				return nil, err
+			}
+		}
+		current, err := in.GetIstioConfigListForNamespace(ctx, cluster, namespace, IstioConfigCriteria{IncludeSidecars: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, sc := range current.Sidecars {
+			if sc.Spec.WorkloadSelector != nil {
+				result.Notes = append(result.Notes, fmt.Sprintf("Sidecar [%s] selects workloads by labels and takes precedence over the namespace Sidecar for them", sc.Name))
+			}
+		}
+	} else {
+		for _, wk := range workloads {
+			sidecar := &networking_v1.Sidecar{
+				ObjectMeta: meta_v1.ObjectMeta{Name: wk.Name, Namespace: namespace},
+				Spec: api_networking_v1.Sidecar{
+					WorkloadSelector: &api_networking_v1.WorkloadSelector{Labels: wk.Labels},
+				},
+			}
+			if err := addProposal(sidecar, wk.Name, []models.WorkloadListItem{wk}); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result.Valid = true
+	if in.conf.IsValidationsEnabled() && len(result.Proposals) > 0 {
+		if result.Validations, err = in.businessLayer.Validations.ValidateProposedIstioObjects(ctx, cluster, namespace, proposed); err != nil {
+			return nil, err
+		}
+		for _, validation := range result.Validations {
+			result.Valid = result.Valid && validation.Valid
+		}
+	}
+
+	return result, nil
+}
+
+// sidecarDestinations merges the destinations called by the source workloads, sorted by namespace and host. All of
+// them are kept: the ones in the service registry by an egress host, the others by the outbound traffic policy.
+func sidecarDestinations(traffic map[string][]models.SidecarDestination, sources []models.WorkloadListItem, identityDomain string) []models.SidecarDestination {
+	merged := map[string]*models.SidecarDestination{}
+	for _, source := range sources {
+		for _, dest := range traffic[source.Name] {
+			if dest.Host == "" && dest.Service != "" {
+				dest.Host = fmt.Sprintf("%s.%s.%s", dest.Service, dest.Namespace, identityDomain)
+			}
+			key := dest.Namespace + "/" + dest.Host
+			if existing, ok := merged[key]; ok {
+				if !slices.Contains(existing.Workloads, source.Name) {
+					existing.Workloads = append(existing.Workloads, source.Name)
+				}
+				continue
+			}
+			dest.Workloads = []string{source.Name}
+			dest.Kept = true
+			if dest.Host == "" {
+				dest.Reason = "The host is not in the service registry: it is reachable only if the outbound traffic policy is ALLOW_ANY"
+			}
+			merged[key] = &dest
+		}
+	}
+
+	destinations := make([]models.SidecarDestination, 0, len(merged))
+	for _, key := range sortedKeys(merged) {
+		dest := merged[key]
+		sort.Strings(dest.Workloads)
+		destinations = append(destinations, *dest)
+	}
+	return destinations
+}
+
+// sidecarEgressHosts returns the "namespace/host" egress hosts keeping the destinations in the service registry.
+func sidecarEgressHosts(destinations []models.SidecarDestination) []string {
+	hosts := []string{}
+	for _, dest := range destinations {
+		if dest.Host != "" {
+			hosts = append(hosts, dest.Namespace+"/"+dest.Host)
+		}
+	}
+	if len(hosts) == 0 {
+		return []string{sidecarNoEgressHost}
+	}
+	return hosts
+}
+
+// estimateProposalConfigSize estimates the configuration saved by the proposal from the config_dump of the first pod
+// of the source workloads that has a sidecar.
+func (in *IstioConfigService) estimateProposalConfigSize(ctx context.Context, cluster, namespace string, sources []models.WorkloadListItem, proposal *models.SidecarProposal) {
+	kept := map[string]bool{}
+	for _, dest := range proposal.Destinations {
+		if dest.Host != "" {
+			kept[dest.Host] = true
+		}
+	}
+
+	for _, source := range sources {
+		workload, err := in.businessLayer.Workload.GetWorkload(ctx, WorkloadCriteria{Cluster: cluster, Namespace: namespace, WorkloadName: source.Name})
+		if err != nil {
+			proposal.ConfigSizeError = err.Error()
+			return
+		}
+		for _, pod := range workload.Pods {
+			if !pod.HasIstioSidecar() {
+				continue
+			}
+			dump, err := in.businessLayer.ProxyStatus.GetConfigDump(cluster, namespace, pod.Name)
+			if err == nil && dump.ConfigDump == nil {
+				err = fmt.Errorf("empty config_dump")
+			}
+			if err != nil {
+				proposal.ConfigSizeError = fmt.Sprintf("config_dump of pod [%s] is not available: %v", pod.Name, err)
+				return
+			}
+			if proposal.ConfigSize, err = estimateSidecarConfigSize(dump.ConfigDump, pod.Name, kept); err != nil {
+				proposal.ConfigSizeError = err.Error()
+			}
+			return
+		}
+	}
+	proposal.ConfigSizeError = "no running pod with a sidecar proxy"
+}
+
+// estimateSidecarConfigSize estimates the size of a config_dump once the proxy only receives the configuration of the
+// kept hosts: the outbound clusters and the route virtual hosts of the other hosts are removed. Listeners are counted
+// as kept, so the estimate is conservative.
+func estimateSidecarConfigSize(dump *kubernetes.ConfigDump, pod string, kept map[string]bool) (*models.SidecarConfigSize, error) {
+	raw, err := json.Marshal(dump)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the config_dump of pod [%s]: %w", pod, err)
+	}
+	size := &models.SidecarConfigSize{Pod: pod, CurrentBytes: len(raw)}
+	removed := 0
+
+	for _, configRaw := range dump.Configs {
+		config, ok := configRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch config["@type"] {
+		case "type.googleapis.com/envoy.admin.v3.ClustersConfigDump":
+			clusters, _ := config["dynamic_active_clusters"].([]interface{})
+			for _, entry := range clusters {
+				name, _ := nestedValue(entry, "cluster", "name").(string)
+				// outbound|<port>|<subset>|<host>
+				parts := strings.Split(name, "|")
+				if len(parts) != 4 || parts[0] != "outbound" {
+					continue
+				}
+				size.CurrentClusters++
+				if kept[parts[3]] {
+					size.EstimatedClusters++
+				} else {
+					removed += jsonSize(entry)
+				}
+			}
+		case "type.googleapis.com/envoy.admin.v3.RoutesConfigDump":
+			routeConfigs, _ := config["dynamic_route_configs"].([]interface{})
+			for _, routeConfig := range routeConfigs {
+				virtualHosts, _ := nestedValue(routeConfig, "route_config", "virtual_hosts").([]interface{})
+				for _, virtualHost := range virtualHosts {
+					// <host>:<port>, or allow_any and block_all for the outbound traffic policy
+					name, _ := nestedValue(virtualHost, "name").(string)
+					host, _, found := strings.Cut(name, ":")
+					if found && !kept[host] {
+						removed += jsonSize(virtualHost)
+					}
+				}
+			}
+		}
+	}
+
+	size.EstimatedBytes = size.CurrentBytes - removed
+	if size.CurrentBytes > 0 {
+		size.ReductionPercent = math.Round(float64(removed)*1000/float64(size.CurrentBytes)) / 10
+	}
+	return size, nil
+}
+
+func nestedValue(value interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[key]
+	}
+	return value
+}
+
+func jsonSize(value interface{}) int {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
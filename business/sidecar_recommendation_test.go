@@ -0,0 +1,187 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+// configDumpClient serves a fixed config_dump for every pod.
+type configDumpClient struct {
+	*kubetest.FakeK8sClient
+	dump *kubernetes.ConfigDump
+}
+
+func (c *configDumpClient) GetConfigDump(namespace, podName string) (*kubernetes.ConfigDump, error) {
+	return c.dump, nil
+}
+
+func fakeSidecarConfigDump() *kubernetes.ConfigDump {
+	cluster := func(name string) interface{} {
+		return map[string]interface{}{"cluster": map[string]interface{}{"name": name, "type": "EDS"}}
+	}
+	virtualHost := func(name string) interface{} {
+		return map[string]interface{}{"name": name, "domains": []interface{}{name}}
+	}
+	return &kubernetes.ConfigDump{Configs: []interface{}{
+		map[string]interface{}{
+			"@type": "type.googleapis.com/envoy.admin.v3.ClustersConfigDump",
+			"static_clusters": []interface{}{
+				cluster("prometheus_stats"),
+			},
+			"dynamic_active_clusters": []interface{}{
+				cluster("inbound|9080||"),
+				cluster("outbound|9080||reviews.Namespace.svc.cluster.local"),
+				cluster("outbound|9080||ratings.Namespace.svc.cluster.local"),
+				cluster("outbound|443||api.example.com"),
+			},
+		},
+		map[string]interface{}{
+			"@type": "type.googleapis.com/envoy.admin.v3.RoutesConfigDump",
+			"dynamic_route_configs": []interface{}{
+				map[string]interface{}{
+					"route_config": map[string]interface{}{
+						"name": "9080",
+						"virtual_hosts": []interface{}{
+							virtualHost("reviews.Namespace.svc.cluster.local:9080"),
+							virtualHost("ratings.Namespace.svc.cluster.local:9080"),
+							virtualHost("allow_any"),
+						},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestRecommendSidecars(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.IstioLabels.AppLabelName = "app"
+	conf.IstioLabels.VersionLabelName = "version"
+	kubernetes.SetConfig(t, *conf)
+
+	// The selector Sidecar takes precedence over the namespace Sidecar for the workloads it selects
+	current := &networking_v1.Sidecar{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ratings", Namespace: "Namespace"},
+		Spec: api_networking_v1.Sidecar{
+			WorkloadSelector: &api_networking_v1.WorkloadSelector{Labels: map[string]string{"app": "ratings"}},
+			Egress:           []*api_networking_v1.IstioEgressListener{{Hosts: []string{"./*"}}},
+		},
+	}
+	reviews := kubetest.FakeService("Namespace", "reviews")
+	pod := FakePodsSyncedWithDeployments(conf)[0]
+	pod.Labels = map[string]string{"app": "details", "version": "v1"}
+	kubeObjs := []runtime.Object{
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "Namespace"}},
+		&core_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "istio-system"}},
+		&FakeDepSyncedWithRS(conf)[0],
+		&FakeRSSyncedWithPods(conf)[0],
+		&pod,
+		&reviews,
+		current,
+	}
+	k8s := &configDumpClient{FakeK8sClient: kubetest.NewFakeK8sClient(kubeObjs...), dump: fakeSidecarConfigDump()}
+	layer := NewLayerBuilder(t, conf).WithClient(k8s).Build()
+
+	criteria := SidecarRecommendationCriteria{
+		Duration: "1h",
+		Scope:    models.SidecarRecommendationScopeNamespace,
+		Traffic: map[string][]models.SidecarDestination{
+			"details-v1": {
+				{Namespace: "Namespace", Service: "reviews"},
+				{Namespace: "Namespace", Service: "reviews"},
+				{Namespace: "external", Host: "api.example.com", ServiceEntry: "api"},
+				{},
+			},
+		},
+	}
+	result, err := layer.IstioConfig.RecommendSidecars(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", criteria)
+	require.NoError(err)
+	require.Len(result.Proposals, 1)
+	proposal := result.Proposals[0]
+	assert.Equal("default", proposal.Name)
+	assert.Empty(proposal.Workload)
+	assert.Equal(models.GitOpsOperationCreate, proposal.Operation)
+	assert.Equal([]string{"Namespace/reviews.Namespace.svc.cluster.local", "external/api.example.com"}, proposal.Hosts)
+	require.Len(proposal.Destinations, 3)
+	assert.Empty(proposal.Destinations[0].Host)
+	assert.NotEmpty(proposal.Destinations[0].Reason)
+	assert.Equal("reviews.Namespace.svc.cluster.local", proposal.Destinations[1].Host)
+	assert.Equal([]string{"details-v1"}, proposal.Destinations[1].Workloads)
+	for _, dest := range proposal.Destinations {
+		assert.True(dest.Kept)
+	}
+	require.NotNil(proposal.ConfigSize, proposal.ConfigSizeError)
+	assert.Equal("details-v1-3618568057-dnkjp", proposal.ConfigSize.Pod)
+	assert.Equal(3, proposal.ConfigSize.CurrentClusters)
+	assert.Equal(2, proposal.ConfigSize.EstimatedClusters)
+	assert.Less(proposal.ConfigSize.EstimatedBytes, proposal.ConfigSize.CurrentBytes)
+	assert.Greater(proposal.ConfigSize.ReductionPercent, 0.0)
+	assert.Contains(result.Notes, "Sidecar [ratings] selects workloads by labels and takes precedence over the namespace Sidecar for them")
+
+	sidecar := &networking_v1.Sidecar{}
+	require.NoError(json.Unmarshal(proposal.Manifest, sidecar))
+	assert.Nil(sidecar.Spec.WorkloadSelector)
+	assert.Equal(proposal.Hosts, sidecar.Spec.Egress[0].Hosts)
+
+	criteria.Scope = models.SidecarRecommendationScopeWorkload
+	criteria.Traffic = nil
+	result, err = layer.IstioConfig.RecommendSidecars(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", criteria)
+	require.NoError(err)
+	require.Len(result.Proposals, 1)
+	proposal = result.Proposals[0]
+	assert.Equal("details-v1", proposal.Name)
+	assert.Equal("details-v1", proposal.Workload)
+	// No destination was called: the Sidecar keeps no egress host
+	assert.Equal([]string{sidecarNoEgressHost}, proposal.Hosts)
+	assert.Empty(proposal.Destinations)
+	require.NoError(json.Unmarshal(proposal.Manifest, sidecar))
+	assert.Equal(map[string]string{"app": "details", "version": "v1"}, sidecar.Spec.WorkloadSelector.Labels)
+
+	criteria.Workload = "reviews-v1"
+	_, err = layer.IstioConfig.RecommendSidecars(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", criteria)
+	assert.True(api_errors.IsNotFound(err))
+
+	criteria.Scope = "mesh"
+	_, err = layer.IstioConfig.RecommendSidecars(context.TODO(), conf.KubernetesConfig.ClusterName, "Namespace", criteria)
+	assert.True(api_errors.IsBadRequest(err))
+}
+
+func TestEstimateSidecarConfigSize(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	size, err := estimateSidecarConfigSize(fakeSidecarConfigDump(), "productpage-v1-123", map[string]bool{})
+	require.NoError(err)
+	assert.Equal(3, size.CurrentClusters)
+	assert.Zero(size.EstimatedClusters)
+
+	kept := map[string]bool{
+		"reviews.Namespace.svc.cluster.local": true,
+		"ratings.Namespace.svc.cluster.local": true,
+		"api.example.com":                     true,
+	}
+	size, err = estimateSidecarConfigSize(fakeSidecarConfigDump(), "productpage-v1-123", kept)
+	require.NoError(err)
+	assert.Equal(3, size.EstimatedClusters)
+	assert.Equal(size.CurrentBytes, size.EstimatedBytes)
+	assert.Zero(size.ReductionPercent)
+}
@@ -0,0 +1,482 @@
+package business
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8s_networking_v1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// kialiWizardLabel labels the objects generated by the wizards with the wizard type.
+const kialiWizardLabel = "kiali_wizard"
+
+// trafficTemplateObject is an object generated by a traffic template, with its type.
+type trafficTemplateObject struct {
+	gvk    schema.GroupVersionKind
+	object client.Object
+}
+
+// GenerateTrafficTemplate generates the Istio config of a traffic template for a service, the same config the
+// traffic management wizards of the UI generate: a VirtualService and a DestinationRule named after the service,
+// or a Gateway API HTTPRoute. The objects are validated as if they were applied, but the cluster is not modified.
+// Objects that already exist are updated with a JSON merge patch preserving their labels and annotations.
+func (in *IstioConfigService) GenerateTrafficTemplate(ctx context.Context, cluster, namespace, service, template string, request models.TrafficTemplateRequest) (*models.TrafficTemplateResult, error) {
+	objects, err := in.buildTrafficTemplate(ctx, cluster, namespace, service, template, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TrafficTemplateResult{
+		Template:  template,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Service:   service,
+		Objects:   make([]models.TrafficTemplateObject, 0, len(objects)),
+	}
+	proposed := &models.IstioConfigList{}
+	for _, obj := range objects {
+		templateObject := models.TrafficTemplateObject{
+			ObjectGVK: obj.gvk,
+			Name:      obj.object.GetName(),
+			Operation: models.GitOpsOperationCreate,
+		}
+
+		current, err := in.GetIstioConfigDetails(ctx, cluster, namespace, obj.gvk, obj.object.GetName())
+		if err != nil && !api_errors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil && current.Object != nil {
+			templateObject.Operation = models.GitOpsOperationUpdate
+			obj.object.SetLabels(mergeLabels(current.Object.GetLabels(), obj.object.GetLabels()))
+			obj.object.SetAnnotations(current.Object.GetAnnotations())
+		}
+
+		if templateObject.Manifest, err = models.RevisionObject(obj.gvk, obj.object); err != nil {
+			return nil, err
+		}
+		if templateObject.Operation == models.GitOpsOperationUpdate {
+			currentManifest, err := models.RevisionObject(obj.gvk, current.Object)
+			if err != nil {
+				return nil, err
+			}
+			patch, err := jsonpatch.CreateMergePatch(currentManifest, templateObject.Manifest)
+			if err != nil {
+				return nil, fmt.Errorf("unable to compute the patch of %s [%s/%s]: %w", obj.gvk.Kind, namespace, obj.object.GetName(), err)
+			}
+			templateObject.Patch = string(patch)
+		}
+		result.Objects = append(result.Objects, templateObject)
+
+		switch typed := obj.object.(type) {
+		case *networking_v1.VirtualService:
+			proposed.VirtualServices = append(proposed.VirtualServices, typed)
+		case *networking_v1.DestinationRule:
+			proposed.DestinationRules = append(proposed.DestinationRules, typed)
+		case *k8s_networking_v1.HTTPRoute:
+			proposed.K8sHTTPRoutes = append(proposed.K8sHTTPRoutes, typed)
+		}
+	}
+
+	result.Valid = true
+	if in.conf.IsValidationsEnabled() {
+		if result.Validations, err = in.businessLayer.Validations.ValidateProposedIstioObjects(ctx, cluster, namespace, proposed); err != nil {
+			return nil, err
+		}
+		for _, validation := range result.Validations {
+			result.Valid = result.Valid && validation.Valid
+		}
+	}
+
+	return result, nil
+}
+
+// ApplyTrafficTemplate writes the objects generated by GenerateTrafficTemplate to the cluster, in order, creating or
+// updating each of them. It stops at the first failure and returns the objects written so far, so they can be audited.
+func (in *IstioConfigService) ApplyTrafficTemplate(ctx context.Context, result *models.TrafficTemplateResult) ([]models.IstioConfigDetails, error) {
+	written := make([]models.IstioConfigDetails, 0, len(result.Objects))
+	for _, obj := range result.Objects {
+		var details models.IstioConfigDetails
+		var err error
+		if obj.Operation == models.GitOpsOperationUpdate {
+			details, err = in.UpdateIstioConfigDetail(ctx, result.Cluster, result.Namespace, obj.ObjectGVK, obj.Name, obj.Patch)
+		} else {
+			details, err = in.CreateIstioConfigDetail(ctx, result.Cluster, result.Namespace, obj.ObjectGVK, obj.Manifest)
+		}
+		if err != nil {
+			return written, fmt.Errorf("unable to apply %s [%s/%s]: %w", obj.ObjectGVK.Kind, result.Namespace, obj.Name, err)
+		}
+		written = append(written, details)
+	}
+	result.Applied = true
+	return written, nil
+}
+
+func (in *IstioConfigService) buildTrafficTemplate(ctx context.Context, cluster, namespace, service, template string, request models.TrafficTemplateRequest) ([]trafficTemplateObject, error) {
+	if err := validateTrafficTemplateRequest(template, request); err != nil {
+		return nil, err
+	}
+
+	svc, err := in.businessLayer.Svc.GetService(ctx, cluster, namespace, service)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{kialiWizardLabel: template}
+	if request.GatewayAPI {
+		if userClient, ok := in.userClients[cluster]; !ok || !userClient.IsGatewayAPI() {
+			return nil, api_errors.NewBadRequest("the Gateway API is not installed in cluster " + cluster)
+		}
+		route, err := buildTemplateHTTPRoute(namespace, svc, labels, request)
+		if err != nil {
+			return nil, err
+		}
+		return []trafficTemplateObject{{gvk: kubernetes.K8sHTTPRoutes, object: route}}, nil
+	}
+
+	host := fmt.Sprintf("%s.%s.%s", service, namespace, in.businessLayer.Svc.ResolveIdentityDomain(ctx, cluster))
+	dr, err := buildTemplateDestinationRule(namespace, service, host, in.templateVersionLabel(ctx, cluster, namespace, svc), labels, request)
+	if err != nil {
+		return nil, err
+	}
+	vs, err := buildTemplateVirtualService(namespace, service, host, labels, request)
+	if err != nil {
+		return nil, err
+	}
+	// The DestinationRule goes first: the VirtualService routes to its subsets.
+	return []trafficTemplateObject{
+		{gvk: kubernetes.DestinationRules, object: dr},
+		{gvk: kubernetes.VirtualServices, object: vs},
+	}, nil
+}
+
+func validateTrafficTemplateRequest(template string, request models.TrafficTemplateRequest) error {
+	switch template {
+	case models.TrafficTemplateTrafficShifting:
+		if len(request.Routes) == 0 {
+			return api_errors.NewBadRequest("the traffic_shifting template requires routes")
+		}
+	case models.TrafficTemplateFaultInjection:
+		if request.Fault == nil || (request.Fault.DelayPercentage == 0 && request.Fault.AbortPercentage == 0) {
+			return api_errors.NewBadRequest("the fault_injection template requires a delay or an abort fault")
+		}
+	case models.TrafficTemplateRequestTimeouts:
+		if request.Timeout == "" && request.Retries == nil {
+			return api_errors.NewBadRequest("the request_timeouts template requires a timeout or retries")
+		}
+	default:
+		return api_errors.NewBadRequest(fmt.Sprintf("unknown traffic template [%s], must be one of %s, %s, %s", template,
+			models.TrafficTemplateTrafficShifting, models.TrafficTemplateFaultInjection, models.TrafficTemplateRequestTimeouts))
+	}
+
+	if request.GatewayAPI {
+		switch {
+		case request.Fault != nil:
+			return api_errors.NewBadRequest("fault injection is not supported with the Gateway API")
+		case request.Retries != nil:
+			return api_errors.NewBadRequest("retries are not supported with the Gateway API")
+		case request.ConnectionPool != nil || request.OutlierDetection != nil:
+			return api_errors.NewBadRequest("connection pools and outlier detection are not supported with the Gateway API")
+		}
+	}
+
+	if len(request.Routes) > 0 {
+		total := int32(0)
+		for _, route := range request.Routes {
+			if route.Weight < 0 {
+				return api_errors.NewBadRequest("route weights must not be negative")
+			}
+			if !request.GatewayAPI && route.Version == "" {
+				return api_errors.NewBadRequest("routes require a version")
+			}
+			total += route.Weight
+		}
+		if total != 100 {
+			return api_errors.NewBadRequest(fmt.Sprintf("route weights must add up to 100, not %d", total))
+		}
+	}
+	if request.Mirror != nil {
+		if !request.GatewayAPI && request.Mirror.Version == "" {
+			return api_errors.NewBadRequest("the mirror requires a version")
+		}
+		if !validPercentage(request.Mirror.Percentage) {
+			return api_errors.NewBadRequest("the mirror percentage must be between 0 and 100")
+		}
+	}
+	if request.Fault != nil {
+		if !validPercentage(request.Fault.DelayPercentage) || !validPercentage(request.Fault.AbortPercentage) {
+			return api_errors.NewBadRequest("fault percentages must be between 0 and 100")
+		}
+		if request.Fault.AbortPercentage > 0 && (request.Fault.HTTPStatus < 200 || request.Fault.HTTPStatus > 599) {
+			return api_errors.NewBadRequest("the abort fault requires an HTTP status")
+		}
+		if request.Fault.DelayPercentage > 0 && request.Fault.FixedDelay == "" {
+			return api_errors.NewBadRequest("the delay fault requires a fixed delay")
+		}
+	}
+	return nil
+}
+
+func validPercentage(percentage float64) bool {
+	return percentage >= 0 && percentage <= 100
+}
+
+// parseTemplateDuration parses an optional duration of a template parameter, nil when it is not set.
+func parseTemplateDuration(field, value string) (*durationpb.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return nil, api_errors.NewBadRequest(fmt.Sprintf("invalid %s [%s]: must be a positive duration (e.g. 5s)", field, value))
+	}
+	return durationpb.New(duration), nil
+}
+
+// templateVersionLabel returns the version label of the workloads of the service, used to select the subsets.
+func (in *IstioConfigService) templateVersionLabel(ctx context.Context, cluster, namespace string, svc models.Service) string {
+	if len(svc.Selectors) > 0 {
+		if kubeCache, err := in.kialiCache.GetKubeCache(cluster); err == nil {
+			podList := &core_v1.PodList{}
+			if err := kubeCache.List(ctx, podList, client.MatchingLabels(svc.Selectors), client.InNamespace(namespace)); err == nil {
+				for _, pod := range podList.Items {
+					if name, found := in.conf.GetVersionLabelName(pod.Labels); found {
+						return name
+					}
+				}
+			}
+		}
+	}
+	if in.conf.IstioLabels.VersionLabelName != "" {
+		return in.conf.IstioLabels.VersionLabelName
+	}
+	return "version"
+}
+
+func buildTemplateDestinationRule(namespace, service, host, versionLabel string, labels map[string]string, request models.TrafficTemplateRequest) (*networking_v1.DestinationRule, error) {
+	dr := &networking_v1.DestinationRule{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.DestinationRules.GroupVersion().String(), Kind: kubernetes.DestinationRules.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: service, Namespace: namespace, Labels: maps.Clone(labels)},
+	}
+	dr.Spec.Host = host
+
+	versions := []string{}
+	for _, route := range request.Routes {
+		versions = append(versions, route.Version)
+	}
+	if request.Mirror != nil {
+		versions = append(versions, request.Mirror.Version)
+	}
+	for _, version := range versions {
+		if version == "" || templateSubset(dr.Spec.Subsets, version) {
+			continue
+		}
+		dr.Spec.Subsets = append(dr.Spec.Subsets, &api_networking_v1.Subset{
+			Name:   version,
+			Labels: map[string]string{versionLabel: version},
+		})
+	}
+
+	if request.ConnectionPool != nil || request.OutlierDetection != nil {
+		dr.Spec.TrafficPolicy = &api_networking_v1.TrafficPolicy{}
+	}
+	if pool := request.ConnectionPool; pool != nil {
+		dr.Spec.TrafficPolicy.ConnectionPool = &api_networking_v1.ConnectionPoolSettings{}
+		if pool.MaxConnections > 0 {
+			dr.Spec.TrafficPolicy.ConnectionPool.Tcp = &api_networking_v1.ConnectionPoolSettings_TCPSettings{MaxConnections: pool.MaxConnections}
+		}
+		if pool.HTTP1MaxPendingRequests > 0 || pool.MaxRequestsPerConnection > 0 {
+			dr.Spec.TrafficPolicy.ConnectionPool.Http = &api_networking_v1.ConnectionPoolSettings_HTTPSettings{
+				Http1MaxPendingRequests:  pool.HTTP1MaxPendingRequests,
+				MaxRequestsPerConnection: pool.MaxRequestsPerConnection,
+			}
+		}
+	}
+	if outlier := request.OutlierDetection; outlier != nil {
+		interval, err := parseTemplateDuration("outlier detection interval", outlier.Interval)
+		if err != nil {
+			return nil, err
+		}
+		baseEjectionTime, err := parseTemplateDuration("outlier detection base ejection time", outlier.BaseEjectionTime)
+		if err != nil {
+			return nil, err
+		}
+		if outlier.MaxEjectionPercent < 0 || outlier.MaxEjectionPercent > 100 {
+			return nil, api_errors.NewBadRequest("the max ejection percent must be between 0 and 100")
+		}
+		dr.Spec.TrafficPolicy.OutlierDetection = &api_networking_v1.OutlierDetection{
+			Interval:           interval,
+			BaseEjectionTime:   baseEjectionTime,
+			MaxEjectionPercent: outlier.MaxEjectionPercent,
+		}
+		if outlier.Consecutive5xxErrors > 0 {
+			dr.Spec.TrafficPolicy.OutlierDetection.Consecutive_5XxErrors = wrapperspb.UInt32(outlier.Consecutive5xxErrors)
+		}
+	}
+	return dr, nil
+}
+
+func templateSubset(subsets []*api_networking_v1.Subset, name string) bool {
+	for _, subset := range subsets {
+		if subset.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func buildTemplateVirtualService(namespace, service, host string, labels map[string]string, request models.TrafficTemplateRequest) (*networking_v1.VirtualService, error) {
+	vs := &networking_v1.VirtualService{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.VirtualServices.GroupVersion().String(), Kind: kubernetes.VirtualServices.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: service, Namespace: namespace, Labels: maps.Clone(labels)},
+	}
+	vs.Spec.Hosts = []string{service}
+
+	httpRoute := &api_networking_v1.HTTPRoute{}
+	if len(request.Routes) == 0 {
+		httpRoute.Route = []*api_networking_v1.HTTPRouteDestination{{Destination: &api_networking_v1.Destination{Host: host}}}
+	}
+	for _, route := range request.Routes {
+		httpRoute.Route = append(httpRoute.Route, &api_networking_v1.HTTPRouteDestination{
+			Destination: &api_networking_v1.Destination{Host: host, Subset: route.Version},
+			Weight:      route.Weight,
+		})
+	}
+
+	if mirror := request.Mirror; mirror != nil {
+		httpRoute.Mirror = &api_networking_v1.Destination{Host: host, Subset: mirror.Version}
+		if mirror.Percentage > 0 {
+			httpRoute.MirrorPercentage = &api_networking_v1.Percent{Value: mirror.Percentage}
+		}
+	}
+
+	if fault := request.Fault; fault != nil {
+		httpRoute.Fault = &api_networking_v1.HTTPFaultInjection{}
+		if fault.DelayPercentage > 0 {
+			delay, err := parseTemplateDuration("fixed delay", fault.FixedDelay)
+			if err != nil {
+				return nil, err
+			}
+			httpRoute.Fault.Delay = &api_networking_v1.HTTPFaultInjection_Delay{
+				HttpDelayType: &api_networking_v1.HTTPFaultInjection_Delay_FixedDelay{FixedDelay: delay},
+				Percentage:    &api_networking_v1.Percent{Value: fault.DelayPercentage},
+			}
+		}
+		if fault.AbortPercentage > 0 {
+			httpRoute.Fault.Abort = &api_networking_v1.HTTPFaultInjection_Abort{
+				ErrorType:  &api_networking_v1.HTTPFaultInjection_Abort_HttpStatus{HttpStatus: fault.HTTPStatus},
+				Percentage: &api_networking_v1.Percent{Value: fault.AbortPercentage},
+			}
+		}
+	}
+
+	timeout, err := parseTemplateDuration("timeout", request.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	httpRoute.Timeout = timeout
+
+	if retries := request.Retries; retries != nil {
+		perTryTimeout, err := parseTemplateDuration("per try timeout", retries.PerTryTimeout)
+		if err != nil {
+			return nil, err
+		}
+		httpRoute.Retries = &api_networking_v1.HTTPRetry{
+			Attempts:      retries.Attempts,
+			PerTryTimeout: perTryTimeout,
+			RetryOn:       retries.RetryOn,
+		}
+	}
+
+	vs.Spec.Http = []*api_networking_v1.HTTPRoute{httpRoute}
+	return vs, nil
+}
+
+// buildTemplateHTTPRoute builds an HTTPRoute attached to the service (GAMMA), routing to the backends of the request.
+func buildTemplateHTTPRoute(namespace string, svc models.Service, labels map[string]string, request models.TrafficTemplateRequest) (*k8s_networking_v1.HTTPRoute, error) {
+	serviceGroup := k8s_networking_v1.Group("")
+	serviceKind := k8s_networking_v1.Kind(kubernetes.ServiceType)
+	backendRef := func(name string) k8s_networking_v1.BackendObjectReference {
+		if name == "" {
+			name = svc.Name
+		}
+		ref := k8s_networking_v1.BackendObjectReference{
+			Group: &serviceGroup,
+			Kind:  &serviceKind,
+			Name:  k8s_networking_v1.ObjectName(name),
+		}
+		if len(svc.Ports) > 0 {
+			port := k8s_networking_v1.PortNumber(svc.Ports[0].Port)
+			ref.Port = &port
+		}
+		return ref
+	}
+
+	route := &k8s_networking_v1.HTTPRoute{
+		TypeMeta:   meta_v1.TypeMeta{APIVersion: kubernetes.K8sHTTPRoutes.GroupVersion().String(), Kind: kubernetes.K8sHTTPRoutes.Kind},
+		ObjectMeta: meta_v1.ObjectMeta{Name: svc.Name, Namespace: namespace, Labels: maps.Clone(labels)},
+	}
+	route.Spec.ParentRefs = []k8s_networking_v1.ParentReference{{
+		Group: &serviceGroup,
+		Kind:  &serviceKind,
+		Name:  k8s_networking_v1.ObjectName(svc.Name),
+	}}
+
+	rule := k8s_networking_v1.HTTPRouteRule{}
+	if len(request.Routes) == 0 {
+		rule.BackendRefs = []k8s_networking_v1.HTTPBackendRef{{BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: backendRef("")}}}
+	}
+	for _, r := range request.Routes {
+		weight := r.Weight
+		rule.BackendRefs = append(rule.BackendRefs, k8s_networking_v1.HTTPBackendRef{
+			BackendRef: k8s_networking_v1.BackendRef{BackendObjectReference: backendRef(r.Backend), Weight: &weight},
+		})
+	}
+
+	if mirror := request.Mirror; mirror != nil {
+		filter := k8s_networking_v1.HTTPRouteFilter{
+			Type:          k8s_networking_v1.HTTPRouteFilterRequestMirror,
+			RequestMirror: &k8s_networking_v1.HTTPRequestMirrorFilter{BackendRef: backendRef(mirror.Backend)},
+		}
+		if mirror.Percentage > 0 {
+			percent := int32(mirror.Percentage)
+			filter.RequestMirror.Percent = &percent
+		}
+		rule.Filters = append(rule.Filters, filter)
+	}
+
+	if request.Timeout != "" {
+		if _, err := parseTemplateDuration("timeout", request.Timeout); err != nil {
+			return nil, err
+		}
+		timeout := k8s_networking_v1.Duration(request.Timeout)
+		rule.Timeouts = &k8s_networking_v1.HTTPRouteTimeouts{Request: &timeout}
+	}
+
+	route.Spec.Rules = []k8s_networking_v1.HTTPRouteRule{rule}
+	return route, nil
+}
+
+// mergeLabels returns the current labels of an object updated with the given labels.
+func mergeLabels(current, labels map[string]string) map[string]string {
+	merged := maps.Clone(current)
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	maps.Copy(merged, labels)
+	return merged
+}
@@ -0,0 +1,210 @@
+package business
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/tests/data"
+)
+
+func TestGenerateAndApplyTrafficShiftingTemplate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	vs := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	vs.Labels = map[string]string{"team": "reviewers"}
+	objects := []runtime.Object{kubetest.FakeNamespace("bookinfo"), &reviews, vs}
+	for _, version := range []string{"v1", "v2", "v3"} {
+		objects = append(objects, &core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "reviews-" + version,
+			Namespace: "bookinfo",
+			Labels:    map[string]string{"app": "reviews", "version": version},
+		}})
+	}
+	k8s := kubetest.NewFakeK8sClient(objects...)
+	layer := NewLayerBuilder(t, conf).WithClient(k8s).Build()
+
+	ctx := context.Background()
+	request := models.TrafficTemplateRequest{
+		Routes:           []models.TrafficTemplateRoute{{Version: "v1", Weight: 80}, {Version: "v2", Weight: 20}},
+		Mirror:           &models.TrafficTemplateMirror{Version: "v3", Percentage: 10},
+		OutlierDetection: &models.TrafficTemplateOutlierDetection{Consecutive5xxErrors: 5, Interval: "10s"},
+	}
+	result, err := layer.IstioConfig.GenerateTrafficTemplate(ctx, "east", "bookinfo", "reviews", models.TrafficTemplateTrafficShifting, request)
+	require.NoError(err)
+	assert.False(result.Applied)
+	require.Len(result.Objects, 2)
+
+	dr := result.Objects[0]
+	assert.Equal(kubernetes.DestinationRules, dr.ObjectGVK)
+	assert.Equal(models.GitOpsOperationCreate, dr.Operation)
+	assert.Empty(dr.Patch)
+	assert.JSONEq(`{
+		"apiVersion": "networking.istio.io/v1",
+		"kind": "DestinationRule",
+		"metadata": {"name": "reviews", "namespace": "bookinfo", "labels": {"kiali_wizard": "traffic_shifting"}},
+		"spec": {
+			"host": "reviews.bookinfo.svc.cluster.local",
+			"subsets": [
+				{"name": "v1", "labels": {"version": "v1"}},
+				{"name": "v2", "labels": {"version": "v2"}},
+				{"name": "v3", "labels": {"version": "v3"}}
+			],
+			"trafficPolicy": {"outlierDetection": {"consecutive5xxErrors": 5, "interval": "10s"}}
+		}
+	}`, string(dr.Manifest))
+
+	updated := result.Objects[1]
+	assert.Equal(kubernetes.VirtualServices, updated.ObjectGVK)
+	assert.Equal(models.GitOpsOperationUpdate, updated.Operation)
+	manifest := map[string]any{}
+	require.NoError(json.Unmarshal(updated.Manifest, &manifest))
+	// The existing labels are kept
+	assert.Equal(map[string]any{"team": "reviewers", "kiali_wizard": "traffic_shifting"}, manifest["metadata"].(map[string]any)["labels"])
+	assert.JSONEq(`{
+		"hosts": ["reviews"],
+		"http": [{
+			"route": [
+				{"destination": {"host": "reviews.bookinfo.svc.cluster.local", "subset": "v1"}, "weight": 80},
+				{"destination": {"host": "reviews.bookinfo.svc.cluster.local", "subset": "v2"}, "weight": 20}
+			],
+			"mirror": {"host": "reviews.bookinfo.svc.cluster.local", "subset": "v3"},
+			"mirrorPercentage": {"value": 10}
+		}]
+	}`, mustMarshal(t, manifest["spec"]))
+	assert.Contains(updated.Patch, `"kiali_wizard":"traffic_shifting"`)
+
+	assert.True(result.Valid)
+	assert.Contains(result.Validations, models.BuildKey(kubernetes.VirtualServices, "reviews", "bookinfo", "east"))
+	assert.Contains(result.Validations, models.BuildKey(kubernetes.DestinationRules, "reviews", "bookinfo", "east"))
+	// The proposed objects are not cached as the validations of the cluster objects
+	_, found := layer.Validations.kialiCache.Validations().Get(models.BuildKey(kubernetes.VirtualServices, "reviews", "bookinfo", "east"))
+	assert.False(found)
+
+	written, err := layer.IstioConfig.ApplyTrafficTemplate(ctx, result)
+	require.NoError(err)
+	assert.True(result.Applied)
+	assert.Len(written, 2)
+
+	createdDR, err := k8s.Istio().NetworkingV1().DestinationRules("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Len(createdDR.Spec.Subsets, 3)
+	updatedVS, err := k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(ctx, "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	require.Len(updatedVS.Spec.Http, 1)
+	assert.Len(updatedVS.Spec.Http[0].Route, 2)
+	assert.Equal("traffic_shifting", updatedVS.Labels["kiali_wizard"])
+	assert.Equal("reviewers", updatedVS.Labels["team"])
+}
+
+func TestGenerateTrafficTemplateHTTPRoute(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	reviewsV2 := kubetest.FakeService("bookinfo", "reviews-v2")
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), &reviews, &reviewsV2)
+	k8s.GatewayAPIEnabled = true
+	configService := NewLayerBuilder(t, conf).WithClient(k8s).Build().IstioConfig
+
+	result, err := configService.GenerateTrafficTemplate(context.Background(), "east", "bookinfo", "reviews", models.TrafficTemplateRequestTimeouts, models.TrafficTemplateRequest{
+		GatewayAPI: true,
+		Routes:     []models.TrafficTemplateRoute{{Weight: 90}, {Backend: "reviews-v2", Weight: 10}},
+		Timeout:    "2s",
+	})
+	require.NoError(err)
+	require.Len(result.Objects, 1)
+	assert.Equal(kubernetes.K8sHTTPRoutes, result.Objects[0].ObjectGVK)
+	assert.Equal(models.GitOpsOperationCreate, result.Objects[0].Operation)
+	assert.JSONEq(`{
+		"apiVersion": "gateway.networking.k8s.io/v1",
+		"kind": "HTTPRoute",
+		"metadata": {"name": "reviews", "namespace": "bookinfo", "labels": {"kiali_wizard": "request_timeouts"}},
+		"spec": {
+			"parentRefs": [{"group": "", "kind": "Service", "name": "reviews"}],
+			"rules": [{
+				"backendRefs": [
+					{"group": "", "kind": "Service", "name": "reviews", "port": 3001, "weight": 90},
+					{"group": "", "kind": "Service", "name": "reviews-v2", "port": 3001, "weight": 10}
+				],
+				"timeouts": {"request": "2s"}
+			}]
+		}
+	}`, string(result.Objects[0].Manifest))
+	assert.True(result.Valid)
+}
+
+func TestGenerateTrafficTemplateBadRequests(t *testing.T) {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	config.Set(conf)
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), &reviews)
+	configService := NewLayerBuilder(t, conf).WithClient(k8s).Build().IstioConfig
+
+	cases := map[string]struct {
+		template string
+		request  models.TrafficTemplateRequest
+	}{
+		"unknown template": {template: "canary"},
+		"weights not adding up to 100": {
+			template: models.TrafficTemplateTrafficShifting,
+			request:  models.TrafficTemplateRequest{Routes: []models.TrafficTemplateRoute{{Version: "v1", Weight: 50}, {Version: "v2", Weight: 40}}},
+		},
+		"fault without percentages": {
+			template: models.TrafficTemplateFaultInjection,
+			request:  models.TrafficTemplateRequest{Fault: &models.TrafficTemplateFault{HTTPStatus: 503}},
+		},
+		"abort without status": {
+			template: models.TrafficTemplateFaultInjection,
+			request:  models.TrafficTemplateRequest{Fault: &models.TrafficTemplateFault{AbortPercentage: 10}},
+		},
+		"invalid timeout": {
+			template: models.TrafficTemplateRequestTimeouts,
+			request:  models.TrafficTemplateRequest{Timeout: "soon"},
+		},
+		"fault with the Gateway API": {
+			template: models.TrafficTemplateFaultInjection,
+			request:  models.TrafficTemplateRequest{GatewayAPI: true, Fault: &models.TrafficTemplateFault{AbortPercentage: 10, HTTPStatus: 503}},
+		},
+		"Gateway API not installed": {
+			template: models.TrafficTemplateRequestTimeouts,
+			request:  models.TrafficTemplateRequest{GatewayAPI: true, Timeout: "2s"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := configService.GenerateTrafficTemplate(context.Background(), "east", "bookinfo", "reviews", tc.template, tc.request)
+			assert.Truef(t, api_errors.IsBadRequest(err), "unexpected error: %v", err)
+		})
+	}
+
+	_, err := configService.GenerateTrafficTemplate(context.Background(), "east", "bookinfo", "details", models.TrafficTemplateRequestTimeouts, models.TrafficTemplateRequest{Timeout: "2s"})
+	assert.True(t, api_errors.IsNotFound(err))
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(raw)
+}
@@ -61,10 +61,10 @@ type KialiCache interface {
 	// HealthKeys returns all keys currently in the health cache.
 	HealthKeys() []string
 
-	// RemoveHealth removes the cached health entry for the given cluster/namespace.
+	// RemoveHealth removes the cached health entry, and the health history, for the given cluster/namespace.
 	RemoveHealth(cluster, namespace string)
 
-	// SetHealth stores health data in cache.
+	// SetHealth stores health data in cache, and records the health transitions in the health history.
 	// Can be called by background job OR by individual handlers
 	// to update specific entries independently.
 	SetHealth(cluster, namespace string, data *models.CachedHealthData)
@@ -124,6 +124,8 @@ type KialiCache interface {
 	// RefreshTokenNamespaces clears the in memory cache of namespaces.
 	RefreshTokenNamespaces(cluster string)
 
+	ConfigRevisionCache
+	HealthHistoryCache
 	ProxyStatusCache
 	ZtunnelDumpCache
 
@@ -152,6 +154,11 @@ type kialiCacheImpl struct {
 	clients                 map[string]kubernetes.ClientInterface
 	conf                    config.Config
 
+	// Revision history of the Istio objects, key'd by object.
+	// The lock serializes the read-modify-write of the revision lists.
+	configRevisionStore store.Store[models.IstioConfigRevisionKey, []models.IstioConfigRevision]
+	configRevisionsLock sync.Mutex
+
 	// Info about the kube clusters that the cache knows about.
 	clusters    []models.KubeCluster
 	clusterLock sync.RWMutex
@@ -168,6 +175,8 @@ type kialiCacheImpl struct {
 	// partially-mutated map (which would be a fatal runtime.throw).
 	healthStore       store.Store[string, *models.CachedHealthData]
 	healthUpdateMutex sync.Mutex
+	// The health transitions recorded by SetHealth, per cluster:namespace. Written under healthUpdateMutex.
+	healthHistoryStore store.Store[string, models.NamespaceHealthHistory]
 
 	// There's only ever one IstioStatus but we want to reuse the store machinery
 	// so using a store here but the only key should be kialiCacheIstioStatusKey.
@@ -215,8 +224,10 @@ func NewKialiCache(ctx context.Context, kialiSAClients map[string]kubernetes.Cli
 		canReadWebhookByCluster: make(map[string]bool),
 		clients:                 kialiSAClients,
 		conf:                    conf,
+		configRevisionStore:     store.New[models.IstioConfigRevisionKey, []models.IstioConfigRevision](),
 		zl:                      zl,
 		gatewayStore:            store.NewExpirationStore(ctx, store.New[string, models.Workloads](), util.AsPtr(conf.KialiInternal.CacheExpiration.Gateway), nil),
+		healthHistoryStore:      store.New[string, models.NamespaceHealthHistory](),
 		healthStore:             store.New[string, *models.CachedHealthData](),
 		istioStatusStore:        store.NewExpirationStore(ctx, store.New[string, kubernetes.IstioComponentStatus](), util.AsPtr(conf.KialiInternal.CacheExpiration.IstioStatus), nil),
 		kubeCache:               kubeCache,
@@ -489,7 +500,11 @@ func (c *kialiCacheImpl) HealthKeys() []string {
 
 // RemoveHealth removes the cached health entry for the given cluster/namespace.
 func (c *kialiCacheImpl) RemoveHealth(cluster, namespace string) {
+	c.healthUpdateMutex.Lock()
+	defer c.healthUpdateMutex.Unlock()
+
 	c.healthStore.Remove(models.HealthCacheKey(cluster, namespace))
+	c.healthHistoryStore.Remove(models.HealthCacheKey(cluster, namespace))
 }
 
 // SetHealth stores health data in cache.
@@ -507,6 +522,7 @@ func (c *kialiCacheImpl) SetHealth(cluster, namespace string, data *models.Cache
 		Str("duration", data.Duration).
 		Msg("health cache updated")
 	c.healthStore.Set(key, data)
+	c.recordHealthHistory(cluster, namespace, data)
 }
 
 // UpdateAppHealth updates a single app's health in the cached namespace data.
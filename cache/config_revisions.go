@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"github.com/kiali/kiali/models"
+)
+
+// ConfigRevisionCache keeps the revision history of the Istio objects modified or deleted
+// through Kiali and, optionally, by other tools.
+type ConfigRevisionCache interface {
+	// AddConfigRevision records the revision of an object. The revision number is assigned by the cache.
+	// A revision observed by the informers with the resourceVersion of an already recorded one is the same
+	// revision: it is not added again and, when the one recorded first came from the informers, the
+	// operation and source of the Kiali one are kept.
+	// Only the latest kiali_feature_flags.config_history.max_revisions revisions are kept.
+	AddConfigRevision(key models.IstioConfigRevisionKey, revision models.IstioConfigRevision)
+
+	// GetConfigRevisions returns the recorded revisions of an object, oldest first.
+	GetConfigRevisions(key models.IstioConfigRevisionKey) []models.IstioConfigRevision
+}
+
+func (c *kialiCacheImpl) AddConfigRevision(key models.IstioConfigRevisionKey, revision models.IstioConfigRevision) {
+	c.configRevisionsLock.Lock()
+	defer c.configRevisionsLock.Unlock()
+
+	revisions, _ := c.configRevisionStore.Get(key)
+	for i := range revisions {
+		// The informers and Kiali both record the changes made through Kiali.
+		if revision.ResourceVersion != "" && revisions[i].ResourceVersion == revision.ResourceVersion &&
+			(revision.Source == models.ConfigRevisionSourceExternal || revisions[i].Source == models.ConfigRevisionSourceExternal) {
+			if revision.Source == models.ConfigRevisionSourceKiali {
+				updated := append([]models.IstioConfigRevision{}, revisions...)
+				updated[i].Operation = revision.Operation
+				updated[i].Source = revision.Source
+				c.configRevisionStore.Set(key, updated)
+			}
+			return
+		}
+	}
+
+	revision.Revision = 1
+	if len(revisions) > 0 {
+		revision.Revision = revisions[len(revisions)-1].Revision + 1
+	}
+
+	// Copy the slice so that the revisions returned before are never modified.
+	maxRevisions := c.conf.KialiFeatureFlags.ConfigHistory.MaxRevisions
+	start := 0
+	if maxRevisions > 0 && len(revisions)+1 > maxRevisions {
+		start = len(revisions) + 1 - maxRevisions
+	}
+	updated := make([]models.IstioConfigRevision, 0, len(revisions)+1-start)
+	updated = append(updated, revisions[start:]...)
+	updated = append(updated, revision)
+	c.configRevisionStore.Set(key, updated)
+}
+
+func (c *kialiCacheImpl) GetConfigRevisions(key models.IstioConfigRevisionKey) []models.IstioConfigRevision {
+	revisions, _ := c.configRevisionStore.Get(key)
+	return revisions
+}
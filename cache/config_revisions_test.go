@@ -0,0 +1,55 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestConfigRevisionsAreBounded(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.KialiFeatureFlags.ConfigHistory.MaxRevisions = 2
+	kialiCache := cache.NewTestingCache(t, kubetest.NewFakeK8sClient(), *conf)
+
+	key := models.IstioConfigRevisionKey{Cluster: "east", Namespace: "bookinfo", ObjectGVK: kubernetes.VirtualServices, Name: "reviews"}
+	for _, rv := range []string{"1", "2", "3"} {
+		kialiCache.AddConfigRevision(key, models.IstioConfigRevision{ResourceVersion: rv, Source: models.ConfigRevisionSourceKiali})
+	}
+
+	revisions := kialiCache.GetConfigRevisions(key)
+	require.Len(revisions, 2)
+	require.Equal(2, revisions[0].Revision)
+	require.Equal("2", revisions[0].ResourceVersion)
+	require.Equal(3, revisions[1].Revision)
+
+	other := key
+	other.Name = "ratings"
+	require.Empty(kialiCache.GetConfigRevisions(other))
+}
+
+func TestConfigRevisionsObservedTwice(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	kialiCache := cache.NewTestingCache(t, kubetest.NewFakeK8sClient(), *conf)
+	key := models.IstioConfigRevisionKey{Cluster: "east", Namespace: "bookinfo", ObjectGVK: kubernetes.VirtualServices, Name: "reviews"}
+
+	// The informer observes the change made through Kiali before Kiali records it
+	kialiCache.AddConfigRevision(key, models.IstioConfigRevision{ResourceVersion: "1", Operation: models.ConfigRevisionOperationUpdate, Source: models.ConfigRevisionSourceExternal})
+	kialiCache.AddConfigRevision(key, models.IstioConfigRevision{ResourceVersion: "1", Operation: models.ConfigRevisionOperationDelete, Source: models.ConfigRevisionSourceKiali})
+	// And after Kiali recorded it
+	kialiCache.AddConfigRevision(key, models.IstioConfigRevision{ResourceVersion: "2", Operation: models.ConfigRevisionOperationUpdate, Source: models.ConfigRevisionSourceKiali})
+	kialiCache.AddConfigRevision(key, models.IstioConfigRevision{ResourceVersion: "2", Operation: models.ConfigRevisionOperationUpdate, Source: models.ConfigRevisionSourceExternal})
+
+	revisions := kialiCache.GetConfigRevisions(key)
+	require.Len(revisions, 2)
+	require.Equal(models.ConfigRevisionSourceKiali, revisions[0].Source)
+	require.Equal(models.ConfigRevisionOperationDelete, revisions[0].Operation)
+	require.Equal(models.ConfigRevisionSourceKiali, revisions[1].Source)
+}
@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/internalmetrics"
+)
+
+// HealthHistoryCache keeps the history of the health transitions of the apps, services and workloads, as
+// computed by the health refreshes stored with SetHealth.
+type HealthHistoryCache interface {
+	// GetHealthHistory returns the health timelines of the entities of a namespace, sorted by type and name.
+	// The entities are filtered by type and name when they are set. The durations of the transitions are
+	// computed up to the next transition or, for the current status, to the last refresh that saw the entity.
+	GetHealthHistory(cluster, namespace, healthType, name string) []models.HealthEntityTimeline
+}
+
+// healthObservation is the health status of an entity computed by a health refresh.
+type healthObservation struct {
+	healthType string
+	name       string
+	status     *models.CalculatedHealthStatus
+}
+
+// healthObservations returns the health statuses of the entities of the cached namespace health.
+func healthObservations(data *models.CachedHealthData) []healthObservation {
+	observations := make([]healthObservation, 0, len(data.AppHealth)+len(data.ServiceHealth)+len(data.WorkloadHealth))
+	for name, health := range data.AppHealth {
+		if health != nil {
+			observations = append(observations, healthObservation{healthType: string(internalmetrics.HealthTypeApp), name: name, status: health.Status})
+		}
+	}
+	for name, health := range data.ServiceHealth {
+		if health != nil {
+			observations = append(observations, healthObservation{healthType: string(internalmetrics.HealthTypeService), name: name, status: health.Status})
+		}
+	}
+	for name, health := range data.WorkloadHealth {
+		if health != nil {
+			observations = append(observations, healthObservation{healthType: string(internalmetrics.HealthTypeWorkload), name: name, status: health.Status})
+		}
+	}
+	return observations
+}
+
+// recordHealthHistory records the transitions of the health refreshed for a namespace. A transition is recorded
+// when the status of an entity, or the signal that caused it, changes. The timelines are copied on write: the
+// ones returned before are never modified. Must be called with healthUpdateMutex held.
+func (c *kialiCacheImpl) recordHealthHistory(cluster, namespace string, data *models.CachedHealthData) {
+	historyConf := c.conf.HealthConfig.History
+	if !historyConf.Enabled {
+		return
+	}
+	maxAge, err := historyConf.MaxAge.ToDuration()
+	if err != nil {
+		c.zl.Warn().Err(err).Msg("Invalid health_config.history.max_age: health history is not recorded")
+		return
+	}
+
+	key := models.HealthCacheKey(cluster, namespace)
+	previous, _ := c.healthHistoryStore.Get(key)
+	now := data.ComputedAt
+	history := make(models.NamespaceHealthHistory, len(previous))
+	for entityKey, timeline := range previous {
+		// Forget the entities that are not seen anymore
+		if now.Sub(timeline.LastSeen) <= maxAge {
+			history[entityKey] = timeline
+		}
+	}
+
+	for _, observation := range healthObservations(data) {
+		transition := models.HealthTransition{Status: models.HealthStatusNA, Timestamp: now}
+		if observation.status != nil {
+			transition.Reason = observation.status.Reason
+			transition.Signal = observation.status.Signal
+			transition.Status = observation.status.Status
+		}
+
+		entityKey := models.HealthHistoryEntityKey(observation.healthType, observation.name)
+		timeline := &models.HealthEntityTimeline{Name: observation.name, Type: observation.healthType}
+		if current, found := history[entityKey]; found {
+			*timeline = *current
+		}
+		timeline.LastSeen = now
+
+		var transitions []models.HealthTransition
+		for i, t := range timeline.Transitions {
+			// Drop the transitions that ended before the max age. The current one never ends.
+			if i+1 < len(timeline.Transitions) && now.Sub(timeline.Transitions[i+1].Timestamp) > maxAge {
+				continue
+			}
+			transitions = append(transitions, t)
+		}
+		if len(transitions) == 0 || transitions[len(transitions)-1].Status != transition.Status || transitions[len(transitions)-1].Signal != transition.Signal {
+			transitions = append(transitions, transition)
+		}
+		if len(transitions) > historyConf.MaxTransitions {
+			transitions = transitions[len(transitions)-historyConf.MaxTransitions:]
+		}
+		// Copy so that the transitions returned before are never modified
+		timeline.Transitions = append([]models.HealthTransition{}, transitions...)
+		history[entityKey] = timeline
+	}
+
+	c.healthHistoryStore.Set(key, history)
+}
+
+func (c *kialiCacheImpl) GetHealthHistory(cluster, namespace, healthType, name string) []models.HealthEntityTimeline {
+	history, _ := c.healthHistoryStore.Get(models.HealthCacheKey(cluster, namespace))
+	timelines := []models.HealthEntityTimeline{}
+	for _, timeline := range history {
+		if (healthType != "" && timeline.Type != healthType) || (name != "" && timeline.Name != name) {
+			continue
+		}
+		result := *timeline
+		result.Transitions = make([]models.HealthTransition, len(timeline.Transitions))
+		for i, transition := range timeline.Transitions {
+			end := timeline.LastSeen
+			if i+1 < len(timeline.Transitions) {
+				end = timeline.Transitions[i+1].Timestamp
+			}
+			transition.DurationSeconds = end.Sub(transition.Timestamp).Round(time.Second).Seconds()
+			result.Transitions[i] = transition
+		}
+		timelines = append(timelines, result)
+	}
+	sort.Slice(timelines, func(i, j int) bool {
+		if timelines[i].Type != timelines[j].Type {
+			return timelines[i].Type < timelines[j].Type
+		}
+		return timelines[i].Name < timelines[j].Name
+	})
+	return timelines
+}
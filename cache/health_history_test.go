@@ -0,0 +1,96 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func workloadHealthWithStatus(status models.HealthStatus, signal models.HealthSignal) *models.WorkloadHealth {
+	return &models.WorkloadHealth{Status: &models.CalculatedHealthStatus{Status: status, Signal: signal}}
+}
+
+func TestHealthHistoryRecordsTransitions(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.History.MaxTransitions = 3
+	kialiCache := cache.NewTestingCache(t, kubetest.NewFakeK8sClient(), *conf)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	refresh := func(minutes int, health models.NamespaceWorkloadHealth) {
+		kialiCache.SetHealth("east", "bookinfo", &models.CachedHealthData{
+			Cluster:        "east",
+			ComputedAt:     start.Add(time.Duration(minutes) * time.Minute),
+			Namespace:      "bookinfo",
+			ServiceHealth:  models.NamespaceServiceHealth{"reviews": {}},
+			WorkloadHealth: health,
+		})
+	}
+	refresh(0, models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusHealthy, "")})
+	// No transition when the status is the same
+	refresh(3, models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusHealthy, "")})
+	refresh(6, models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusDegraded, models.HealthSignalErrorRate)})
+	// The cause changed
+	refresh(9, models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusDegraded, models.HealthSignalLatency)})
+	refresh(15, models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusDegraded, models.HealthSignalLatency)})
+
+	timelines := kialiCache.GetHealthHistory("east", "bookinfo", "workload", "reviews-v1")
+	require.Len(timelines, 1)
+	require.Equal(start.Add(15*time.Minute), timelines[0].LastSeen)
+	transitions := timelines[0].Transitions
+	require.Len(transitions, 3)
+	require.Equal(models.HealthStatusHealthy, transitions[0].Status)
+	require.Equal(float64(360), transitions[0].DurationSeconds)
+	require.Equal(models.HealthSignalErrorRate, transitions[1].Signal)
+	require.Equal(float64(180), transitions[1].DurationSeconds)
+	require.Equal(models.HealthSignalLatency, transitions[2].Signal)
+	require.Equal(float64(360), transitions[2].DurationSeconds, "the current status lasts until the last refresh")
+
+	// The oldest transitions are dropped first
+	refresh(18, models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusHealthy, "")})
+	transitions = kialiCache.GetHealthHistory("east", "bookinfo", "workload", "reviews-v1")[0].Transitions
+	require.Len(transitions, 3)
+	require.Equal(models.HealthSignalErrorRate, transitions[0].Signal)
+
+	// The namespace timeline holds all the entities, sorted by type and name
+	timelines = kialiCache.GetHealthHistory("east", "bookinfo", "", "")
+	require.Len(timelines, 2)
+	require.Equal("service", timelines[0].Type)
+	require.Equal(models.HealthStatusNA, timelines[0].Transitions[0].Status)
+	require.Equal("workload", timelines[1].Type)
+
+	kialiCache.RemoveHealth("east", "bookinfo")
+	require.Empty(kialiCache.GetHealthHistory("east", "bookinfo", "", ""))
+}
+
+func TestHealthHistoryForgetsOldEntities(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	conf.HealthConfig.History.MaxAge = "1h"
+	kialiCache := cache.NewTestingCache(t, kubetest.NewFakeK8sClient(), *conf)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	kialiCache.SetHealth("east", "bookinfo", &models.CachedHealthData{
+		ComputedAt:     start,
+		WorkloadHealth: models.NamespaceWorkloadHealth{"reviews-v1": workloadHealthWithStatus(models.HealthStatusHealthy, "")},
+	})
+	kialiCache.SetHealth("east", "bookinfo", &models.CachedHealthData{
+		ComputedAt:     start.Add(30 * time.Minute),
+		WorkloadHealth: models.NamespaceWorkloadHealth{"ratings-v1": workloadHealthWithStatus(models.HealthStatusHealthy, "")},
+	})
+	require.Len(kialiCache.GetHealthHistory("east", "bookinfo", "", ""), 2)
+
+	kialiCache.SetHealth("east", "bookinfo", &models.CachedHealthData{
+		ComputedAt:     start.Add(2 * time.Hour),
+		WorkloadHealth: models.NamespaceWorkloadHealth{"ratings-v1": workloadHealthWithStatus(models.HealthStatusHealthy, "")},
+	})
+	timelines := kialiCache.GetHealthHistory("east", "bookinfo", "", "")
+	require.Len(timelines, 1)
+	require.Equal("ratings-v1", timelines[0].Name)
+}
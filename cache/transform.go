@@ -69,3 +69,28 @@ func TransformService(svc any) (any, error) {
 	}
 	return trimmedService, nil
 }
+
+func TransformEvent(event any) (any, error) {
+	obj, ok := event.(*corev1.Event)
+	if !ok {
+		return nil, fmt.Errorf("%T is not of type 'Event'", obj)
+	}
+
+	trimmedEvent := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            obj.Name,
+			Namespace:       obj.Namespace,
+			ResourceVersion: obj.ResourceVersion,
+		},
+		Count:          obj.Count,
+		EventTime:      obj.EventTime,
+		FirstTimestamp: obj.FirstTimestamp,
+		InvolvedObject: obj.InvolvedObject,
+		LastTimestamp:  obj.LastTimestamp,
+		Message:        obj.Message,
+		Reason:         obj.Reason,
+		Series:         obj.Series,
+		Type:           obj.Type,
+	}
+	return trimmedEvent, nil
+}
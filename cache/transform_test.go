@@ -176,3 +176,46 @@ func TestGetServiceTrimmed(t *testing.T) {
 		t.Fatal(diff)
 	}
 }
+
+func TestGetEventTrimmed(t *testing.T) {
+	require := require.New(t)
+	ts := metav1.NewTime(time.Date(2026, 4, 21, 16, 55, 25, 0, time.UTC))
+	obj := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations:     map[string]string{"c": "d"},
+			Labels:          map[string]string{"a": "b"},
+			Name:            "foo.1234",
+			Namespace:       "test",
+			ResourceVersion: "v1",
+		},
+		Count:          3,
+		FirstTimestamp: ts,
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "foo"},
+		LastTimestamp:  ts,
+		Message:        "Readiness probe failed",
+		Reason:         "Unhealthy",
+		Source:         corev1.EventSource{Component: "kubelet"},
+		Type:           corev1.EventTypeWarning,
+	}
+	want := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "foo.1234",
+			Namespace:       "test",
+			ResourceVersion: "v1",
+		},
+		Count:          3,
+		FirstTimestamp: ts,
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "foo"},
+		LastTimestamp:  ts,
+		Message:        "Readiness probe failed",
+		Reason:         "Unhealthy",
+		Type:           corev1.EventTypeWarning,
+	}
+
+	got, err := cache.TransformEvent(obj)
+	require.NoError(err)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
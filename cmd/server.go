@@ -21,6 +21,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
 	toolscache "k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
@@ -151,6 +152,12 @@ func run(ctx context.Context, conf *config.Config, staticAssetFS fs.FS, clientFa
 		log.Info("Validation reconcile interval is 0 or less; skipping periodic validations.")
 	}
 
+	if configHistory := conf.KialiFeatureFlags.ConfigHistory; configHistory.Enabled && configHistory.WatchExternalChanges {
+		if err := controller.WatchConfigHistory(ctx, kubeCaches, cache); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	controllerStopped := make(chan struct{})
 	go func() {
 		defer close(controllerStopped)
@@ -256,6 +263,8 @@ func asReaders(caches map[string]ctrlcache.Cache) map[string]client.Reader {
 // memory consumption in large meshes.
 func cacheTransforms() map[client.Object]ctrlcache.ByObject {
 	return map[client.Object]ctrlcache.ByObject{
+		// Only the Warning Events are used, by the workload health
+		&corev1.Event{}:   {Field: fields.OneTermEqualSelector("type", corev1.EventTypeWarning), Transform: cache.TransformEvent},
 		&corev1.Pod{}:     {Transform: cache.TransformPod},
 		&corev1.Service{}: {Transform: cache.TransformService},
 	}
@@ -270,6 +279,7 @@ func makeWatchErrorHandler(getCache func() ctrlcache.Cache, k8sClient kubernetes
 		}
 		log.Infof("A namespace appears to have been deleted or Kiali is forbidden from seeing it [err=%v]. Shutting down cache.", watchErr)
 		objectsToRemove := []client.Object{
+			&corev1.Event{},
 			&corev1.Pod{},
 			&corev1.Service{},
 			&appsv1.StatefulSet{},
@@ -302,6 +312,12 @@ func makeWatchErrorHandler(getCache func() ctrlcache.Cache, k8sClient kubernetes
 			objectsToRemove = append(objectsToRemove, &k8snetworkingv1beta1.ReferenceGrant{})
 			objectsToRemove = append(objectsToRemove, &k8snetworkingv1.TLSRoute{})
 		}
+		if k8sClient.HasBackendTLSPolicyInV1() {
+			objectsToRemove = append(objectsToRemove, &k8snetworkingv1.BackendTLSPolicy{})
+		}
+		if k8sClient.HasListenerSetInV1() {
+			objectsToRemove = append(objectsToRemove, &k8snetworkingv1.ListenerSet{})
+		}
 		if k8sClient.HasTCPRouteInV1() {
 			objectsToRemove = append(objectsToRemove, &k8snetworkingv1.TCPRoute{})
 		}
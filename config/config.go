@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -65,6 +66,12 @@ const (
 	// Login Token signing key used to prepare the token for user login
 	SecretFileLoginTokenSigningKey = "login-token-signing-key"
 
+	// Password of the Redis session store
+	SecretFileSessionStoreRedisPassword = "session-store-redis-password"
+
+	// OpenID client secret of a remote cluster prefix (used to build dynamic volume names)
+	secretFileOpenIdClusterPrefix = "openid-cluster"
+
 	// Chat AI credential secret prefixes (used to build dynamic volume names)
 	secretFileChatAIProviderPrefix = "chat-ai-provider"
 	secretFileChatAIModelPrefix    = "chat-ai-model"
@@ -86,6 +93,10 @@ func chatAIProviderSecretFileName(providerName string) string {
 	return fmt.Sprintf("%s-%s", secretFileChatAIProviderPrefix, sanitizeSecretName(providerName))
 }
 
+func openIdClusterSecretFileName(clusterName string) string {
+	return fmt.Sprintf("%s-%s-client-secret", secretFileOpenIdClusterPrefix, sanitizeSecretName(clusterName))
+}
+
 func chatAIModelSecretFileName(providerName, modelName string) string {
 	return fmt.Sprintf("%s-%s-%s", secretFileChatAIModelPrefix, sanitizeSecretName(providerName), sanitizeSecretName(modelName))
 }
@@ -97,6 +108,7 @@ const (
 	AuthStrategyToken     = "token"
 	AuthStrategyOpenId    = "openid"
 	AuthStrategyHeader    = "header"
+	AuthStrategyX509      = "x509"
 
 	// These constants are used for external services auth (Prometheus, Grafana ...) ; not for Kiali auth
 	AuthTypeBasic  = "basic"
@@ -105,6 +117,38 @@ const (
 	AuthTypeOAuth2 = "oauth2"
 )
 
+// The client certificate fields the x509 auth strategy maps to the username and groups
+const (
+	X509FieldCommonName         = "cn"
+	X509FieldDNS                = "dns"
+	X509FieldEmail              = "email"
+	X509FieldNone               = "none"
+	X509FieldOrganization       = "o"
+	X509FieldOrganizationalUnit = "ou"
+	X509FieldURI                = "uri"
+)
+
+// The capabilities the Kiali roles grant on top of Kubernetes RBAC
+const (
+	KialiCapabilityAll                 = "*"
+	KialiCapabilityChangeProxyLogLevel = "change-proxy-log-level"
+	KialiCapabilityEditIstioConfig     = "edit-istio-config"
+	KialiCapabilityManageSessions      = "manage-sessions"
+	KialiCapabilityUseAIChat           = "use-ai-chat"
+	KialiCapabilityViewGraph           = "view-graph"
+	KialiCapabilityViewSecrets         = "view-secrets"
+)
+
+// KialiCapabilities lists the capabilities that can be granted by a Kiali role.
+var KialiCapabilities = []string{
+	KialiCapabilityChangeProxyLogLevel,
+	KialiCapabilityEditIstioConfig,
+	KialiCapabilityManageSessions,
+	KialiCapabilityUseAIChat,
+	KialiCapabilityViewGraph,
+	KialiCapabilityViewSecrets,
+}
+
 const (
 	IstioMultiClusterHostSuffix = "global"
 	IstioNamespaceDefault       = "istio-system"
@@ -290,10 +334,44 @@ type Observability struct {
 	Tracing Tracing `yaml:"tracing,omitempty"`
 }
 
+// AuditFileSink writes audit records as JSON lines into a local file, rotated by size.
+type AuditFileSink struct {
+	Enabled    bool   `yaml:"enabled,omitempty"`
+	MaxBackups int    `yaml:"max_backups,omitempty"` // Number of rotated files kept next to the current one
+	MaxSizeMB  int    `yaml:"max_size_mb,omitempty"` // The file is rotated when it grows beyond this size
+	Path       string `yaml:"path,omitempty"`
+}
+
+// AuditKubernetesEventsSink records audit records as Kubernetes Events involving the modified object.
+// The Kiali service account needs permissions to create Events.
+type AuditKubernetesEventsSink struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// AuditWebhookSink posts every audit record as JSON to an external endpoint. The records are posted in the
+// background and dropped, with an error logged, when too many are waiting for a slow endpoint.
+type AuditWebhookSink struct {
+	Auth    Auth              `yaml:"auth,omitempty"`
+	Enabled bool              `yaml:"enabled,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Timeout time.Duration     `yaml:"timeout,omitempty"`
+	URL     string            `yaml:"url,omitempty"`
+}
+
+// AuditTrail configures the structured audit records produced for write operations when audit_log is enabled.
+type AuditTrail struct {
+	File             AuditFileSink             `yaml:"file,omitempty"`
+	KubernetesEvents AuditKubernetesEventsSink `yaml:"kubernetes_events,omitempty"`
+	// Number of records kept in memory to serve searches when no file sink is enabled. 0 disables it.
+	MaxMemoryRecords int              `yaml:"max_memory_records,omitempty"`
+	Webhook          AuditWebhookSink `yaml:"webhook,omitempty"`
+}
+
 // Server configuration
 type Server struct {
 	Address        string        `yaml:",omitempty"`
 	AuditLog       bool          `yaml:"audit_log,omitempty"` // When true, allows additional audit logging on Write operations
+	AuditTrail     AuditTrail    `yaml:"audit_trail,omitempty"`
 	CORSAllowAll   bool          `yaml:"cors_allow_all,omitempty"`
 	GzipEnabled    bool          `yaml:"gzip_enabled,omitempty"`
 	Observability  Observability `yaml:"observability,omitempty"`
@@ -375,6 +453,47 @@ type PrometheusConfig struct {
 	URL             string            `yaml:"url,omitempty" json:"url,omitempty"`
 }
 
+const (
+	GitOpsProviderAPI   = "api"
+	GitOpsProviderLocal = "local"
+)
+
+// GitOpsAPIConfig describes the Git hosting API endpoint that receives the proposed changes.
+// Kiali posts the branch, commit message and files of every change as JSON and expects the link to the change in the response.
+type GitOpsAPIConfig struct {
+	Auth    Auth              `yaml:"auth,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Timeout time.Duration     `yaml:"timeout,omitempty"`
+	URL     string            `yaml:"url,omitempty"`
+}
+
+// GitOpsLocalConfig describes a git repository on the local filesystem where the proposed changes are committed.
+type GitOpsLocalConfig struct {
+	// Template of the link returned for a change. Fields: .Branch, .BaseBranch and .Commit.
+	// e.g. https://github.com/org/mesh-config/compare/{{.BaseBranch}}...{{.Branch}}
+	ChangeURLTemplate string `yaml:"change_url_template,omitempty"`
+	// Remote the new branches are pushed to. The branches are only created locally when empty.
+	Remote         string `yaml:"remote,omitempty"`
+	RepositoryPath string `yaml:"repository_path,omitempty"`
+}
+
+// GitOpsConfig describes the GitOps mode: when enabled, the Istio config write operations are proposed
+// as a commit on a new branch of a git repository and the cluster objects are left untouched.
+type GitOpsConfig struct {
+	API         GitOpsAPIConfig `yaml:"api,omitempty"`
+	AuthorEmail string          `yaml:"author_email,omitempty"`
+	AuthorName  string          `yaml:"author_name,omitempty"`
+	BaseBranch  string          `yaml:"base_branch,omitempty"`
+	// Prefix of the branches created for the changes
+	BranchPrefix string            `yaml:"branch_prefix,omitempty"`
+	Enabled      bool              `yaml:"enabled,omitempty"`
+	Local        GitOpsLocalConfig `yaml:"local,omitempty"`
+	// Template of the manifest path in the repository. Fields: .Cluster, .Namespace, .Group, .Version, .Kind and .Name.
+	PathTemplate string `yaml:"path_template,omitempty"`
+	// "local" or "api"
+	Provider string `yaml:"provider,omitempty"`
+}
+
 // CustomDashboardsConfig describes configuration specific to Custom Dashboards
 type CustomDashboardsConfig struct {
 	DiscoveryEnabled       string           `yaml:"discovery_enabled,omitempty" json:"discoveryEnabled,omitempty"`
@@ -514,6 +633,7 @@ type ExternalServices struct {
 	Perses           PersesConfig           `yaml:"perses,omitempty"`
 	Prometheus       PrometheusConfig       `yaml:"prometheus,omitempty"`
 	CustomDashboards CustomDashboardsConfig `yaml:"custom_dashboards,omitempty"`
+	GitOps           GitOpsConfig           `yaml:"gitops,omitempty"`
 	Tracing          TracingConfig          `yaml:"tracing,omitempty"`
 }
 
@@ -527,6 +647,43 @@ func (lt *LoginToken) Obfuscate() {
 	lt.SigningKey = "xxx"
 }
 
+const (
+	SessionStoreTypeFile   = "file"
+	SessionStoreTypeMemory = "memory"
+	SessionStoreTypeRedis  = "redis"
+)
+
+// SessionStoreConfig configures where the session-scoped state (login sessions, AI conversations) is kept.
+// The memory store keeps it in each replica, which is fine for a single replica. The file and redis stores
+// keep it out of the replicas so it can be shared by all of them: login sessions are then stored server-side
+// and the session cookie only holds an encrypted reference to them.
+type SessionStoreConfig struct {
+	File  SessionStoreFileConfig  `yaml:"file,omitempty"`
+	Redis SessionStoreRedisConfig `yaml:"redis,omitempty"`
+	Type  string                  `yaml:"type,omitempty"`
+}
+
+// SessionStoreFileConfig configures the file session store. The directory can be a volume shared by the replicas.
+type SessionStoreFileConfig struct {
+	Path string `yaml:"path,omitempty"`
+}
+
+// SessionStoreRedisConfig configures the redis session store. Any server speaking the Redis protocol can be used.
+type SessionStoreRedisConfig struct {
+	Address   string        `yaml:"address,omitempty"`
+	Database  int           `yaml:"database,omitempty"`
+	KeyPrefix string        `yaml:"key_prefix,omitempty"`
+	Password  Credential    `yaml:"password,omitempty"`
+	Timeout   time.Duration `yaml:"timeout,omitempty"`
+	UseTLS    bool          `yaml:"use_tls,omitempty"`
+	Username  string        `yaml:"username,omitempty"`
+}
+
+// IsShared returns true when the session-scoped state is kept out of the replicas.
+func (s SessionStoreConfig) IsShared() bool {
+	return s.Type == SessionStoreTypeFile || s.Type == SessionStoreTypeRedis
+}
+
 // IstioLabels holds configuration about the labels required by Istio
 type IstioLabels struct {
 	AppLabelName     string `yaml:"app_label_name,omitempty" json:"appLabelName"`
@@ -585,9 +742,78 @@ type KialiInternalConfig struct {
 
 // AuthConfig provides details on how users are to authenticate
 type AuthConfig struct {
-	OpenId    OpenIdConfig    `yaml:"openid,omitempty"`
-	OpenShift OpenShiftConfig `yaml:"openshift,omitempty"`
-	Strategy  string          `yaml:"strategy,omitempty"`
+	APITokens         APITokensConfig         `yaml:"api_tokens,omitempty"`
+	KialiRBAC         KialiRBACConfig         `yaml:"kiali_rbac,omitempty"`
+	OpenId            OpenIdConfig            `yaml:"openid,omitempty"`
+	OpenShift         OpenShiftConfig         `yaml:"openshift,omitempty"`
+	SessionManagement SessionManagementConfig `yaml:"session_management,omitempty"`
+	Strategy          string                  `yaml:"strategy,omitempty"`
+	X509              X509Config              `yaml:"x509,omitempty"`
+}
+
+// ImpersonatesWithSA returns true when the auth strategy impersonates the users with the Kiali service account:
+// openshift with impersonation enabled, and x509, whose users have no token.
+func (a AuthConfig) ImpersonatesWithSA() bool {
+	switch a.Strategy {
+	case AuthStrategyOpenshift:
+		return a.OpenShift.Impersonation.Enabled
+	case AuthStrategyX509:
+		return true
+	default:
+		return false
+	}
+}
+
+// APITokensConfig configures the API tokens: Kiali-issued tokens for scripts and CI jobs calling the Kiali API.
+// A token acts on behalf of the user that created it, by impersonating that user with the Kiali service account.
+// So the tokens are only supported with the auth strategies already impersonating the users with the Kiali
+// service account, which holds the impersonate RBAC permissions: openshift with impersonation enabled, and x509.
+// A token stays valid until it expires or is revoked, independently of the credentials of its owner: a user
+// losing access to Kiali must have their tokens revoked. The tokens are kept in the session store: use a shared
+// session store (see session_store) so that they survive restarts and are accepted by all the replicas.
+type APITokensConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// MaxExpirationSeconds is the longest lifetime of a token. It is also the lifetime of the tokens created
+	// without an expiration.
+	MaxExpirationSeconds int64 `yaml:"max_expiration_seconds,omitempty"`
+}
+
+// SessionManagementConfig configures the session management API, listing and revoking the active login sessions.
+// It is reserved to the users granted the manage-sessions capability, so it needs the Kiali roles (see kiali_rbac).
+// The sessions are tracked in the session store: use a shared session store (see session_store) to see and revoke
+// the sessions of all the replicas.
+type SessionManagementConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// KialiRBACConfig configures the Kiali roles: an overlay on top of Kubernetes RBAC restricting what users
+// can do in Kiali. Kubernetes RBAC still decides what users can read and write in the clusters; the Kiali
+// roles can only take capabilities away. When disabled, all the capabilities are granted to all the users.
+type KialiRBACConfig struct {
+	// DefaultRoles are the roles granted to every user, in every cluster and namespace.
+	DefaultRoles []string `yaml:"default_roles,omitempty"`
+	Enabled      bool     `yaml:"enabled,omitempty"`
+	// RoleBindings grant roles to users and groups.
+	RoleBindings []KialiRoleBinding `yaml:"role_bindings,omitempty"`
+	Roles        []KialiRole        `yaml:"roles,omitempty"`
+}
+
+// KialiRole is a named set of capabilities. The "*" capability grants all of them.
+type KialiRole struct {
+	Capabilities []string `yaml:"capabilities,omitempty"`
+	Name         string   `yaml:"name,omitempty"`
+}
+
+// KialiRoleBinding grants a role to users and groups. The groups come from the OpenID groups claim,
+// the OpenShift user groups or the impersonated groups of the header and x509 strategies.
+type KialiRoleBinding struct {
+	// Clusters restricts the binding to some clusters. It applies to all the clusters when empty.
+	Clusters []string `yaml:"clusters,omitempty"`
+	Groups   []string `yaml:"groups,omitempty"`
+	// Namespaces restricts the binding to some namespaces. It applies to all the namespaces when empty.
+	Namespaces []string `yaml:"namespaces,omitempty"`
+	Role       string   `yaml:"role,omitempty"`
+	Users      []string `yaml:"users,omitempty"`
 }
 
 // OpenShiftConfig contains specific configuration for authentication when on OpenShift
@@ -606,6 +832,29 @@ type ImpersonationConfig struct {
 	Enabled       bool     `yaml:"enabled,omitempty"`
 }
 
+// X509Config contains specific configuration for authentication with client certificates. The Kiali listener
+// verifies the client certificates that are given; the requests without a verified one are not authenticated, while
+// the liveness and readiness probes and the metrics scrapes still connect without a certificate.
+// The identity read from the certificate is impersonated on every cluster with the Kiali service account.
+type X509Config struct {
+	// AllowedGroups restricts the impersonated groups. All the groups of the certificate are kept when empty.
+	AllowedGroups []string `yaml:"allowed_groups,omitempty"`
+	// AllowedUsers restricts the users that can log in. Any user with a verified certificate can log in when empty.
+	AllowedUsers []string `yaml:"allowed_users,omitempty"`
+	// ClientCAFile is the CA bundle verifying the client certificates.
+	ClientCAFile string `yaml:"client_ca_file,omitempty"`
+	// GroupsField is the certificate field holding the groups: o (subject organizations), ou (subject
+	// organizational units) or none.
+	GroupsField string `yaml:"groups_field,omitempty"`
+	// GroupsPrefix is prepended to the groups read from the certificate.
+	GroupsPrefix string `yaml:"groups_prefix,omitempty"`
+	// UsernameField is the certificate field holding the username: cn (subject common name), email, uri or dns
+	// (first subject alternative name of that type).
+	UsernameField string `yaml:"username_field,omitempty"`
+	// UsernamePrefix is prepended to the username read from the certificate.
+	UsernamePrefix string `yaml:"username_prefix,omitempty"`
+}
+
 // DiscoveryOverrideConfig contains explicit OIDC endpoints to override auto-discovery
 type DiscoveryOverrideConfig struct {
 	AuthorizationEndpoint string `yaml:"authorization_endpoint,omitempty"`
@@ -615,6 +864,35 @@ type DiscoveryOverrideConfig struct {
 	UserinfoEndpoint      string `yaml:"userinfo_endpoint,omitempty"`
 }
 
+// The ways the openid strategy gets the credential of a remote cluster
+const (
+	// OpenIdClusterModeLogin logs the user in the OpenID provider of the cluster, after the Kiali login.
+	OpenIdClusterModeLogin = "login"
+	// OpenIdClusterModeTokenExchange exchanges the token of the Kiali login for a token of the cluster (RFC 8693).
+	OpenIdClusterModeTokenExchange = "token_exchange"
+)
+
+// OpenIdClusterConfig configures the credential of a remote cluster trusting another OpenID issuer than
+// the one of the Kiali login.
+type OpenIdClusterConfig struct {
+	// ApiToken is the token used against the cluster API: id_token (default) or access_token.
+	ApiToken string `yaml:"api_token,omitempty"`
+	// Audience is requested in token exchanges, when the issuer of the cluster requires it.
+	Audience string `yaml:"audience,omitempty"`
+	// AuthorizationEndpoint overrides the discovered endpoint used by the login mode.
+	AuthorizationEndpoint string     `yaml:"authorization_endpoint,omitempty"`
+	ClientId              string     `yaml:"client_id,omitempty"`
+	ClientSecret          Credential `yaml:"client_secret,omitempty"`
+	IssuerUri             string     `yaml:"issuer_uri,omitempty"`
+	// Mode is login or token_exchange.
+	Mode   string   `yaml:"mode,omitempty"`
+	Scopes []string `yaml:"scopes,omitempty"`
+	// TokenEndpoint overrides the discovered token endpoint.
+	TokenEndpoint string `yaml:"token_endpoint,omitempty"`
+	// UsernameClaim is the claim of the id_token of the login mode holding the name of the user in the cluster.
+	UsernameClaim string `yaml:"username_claim,omitempty"`
+}
+
 // OpenIdConfig contains specific configuration for authentication using an OpenID provider
 type OpenIdConfig struct {
 	AdditionalRequestParams map[string]string `yaml:"additional_request_params,omitempty"`
@@ -624,18 +902,22 @@ type OpenIdConfig struct {
 	ApiToken                string            `yaml:"api_token,omitempty"`
 	AuthenticationTimeout   int               `yaml:"authentication_timeout,omitempty"`
 	// Deprecated: use DiscoveryOverride.AuthorizationEndpoint
-	AuthorizationEndpoint string                  `yaml:"authorization_endpoint,omitempty"`
-	ClientId              string                  `yaml:"client_id,omitempty"`
-	ClientSecret          Credential              `yaml:"-"` // Runtime only - set from mounted file at /kiali-secret/oidc-secret, never from ConfigMap
-	DisableRBAC           bool                    `yaml:"disable_rbac,omitempty"`
-	DiscoveryOverride     DiscoveryOverrideConfig `yaml:"discovery_override,omitempty"`
-	HTTPProxy             string                  `yaml:"http_proxy,omitempty"`
-	HTTPSProxy            string                  `yaml:"https_proxy,omitempty"`
-	InsecureSkipVerifyTLS bool                    `yaml:"insecure_skip_verify_tls,omitempty"`
-	IssuerUri             string                  `yaml:"issuer_uri,omitempty"`
-	PostLogoutRedirectURI string                  `yaml:"post_logout_redirect_uri,omitempty"`
-	Scopes                []string                `yaml:"scopes,omitempty"`
-	UsernameClaim         string                  `yaml:"username_claim,omitempty"`
+	AuthorizationEndpoint string     `yaml:"authorization_endpoint,omitempty"`
+	ClientId              string     `yaml:"client_id,omitempty"`
+	ClientSecret          Credential `yaml:"-"` // Runtime only - set from mounted file at /kiali-secret/oidc-secret, never from ConfigMap
+	// Clusters configures how the credentials of the remote clusters that do not trust IssuerUri are obtained,
+	// by cluster name. The token of the Kiali login is used in the other clusters.
+	Clusters              map[string]OpenIdClusterConfig `yaml:"clusters,omitempty"`
+	DisableRBAC           bool                           `yaml:"disable_rbac,omitempty"`
+	DiscoveryOverride     DiscoveryOverrideConfig        `yaml:"discovery_override,omitempty"`
+	GroupsClaim           string                         `yaml:"groups_claim,omitempty"`
+	HTTPProxy             string                         `yaml:"http_proxy,omitempty"`
+	HTTPSProxy            string                         `yaml:"https_proxy,omitempty"`
+	InsecureSkipVerifyTLS bool                           `yaml:"insecure_skip_verify_tls,omitempty"`
+	IssuerUri             string                         `yaml:"issuer_uri,omitempty"`
+	PostLogoutRedirectURI string                         `yaml:"post_logout_redirect_uri,omitempty"`
+	Scopes                []string                       `yaml:"scopes,omitempty"`
+	UsernameClaim         string                         `yaml:"username_claim,omitempty"`
 }
 
 // DeploymentConfig provides details on how Kiali was deployed.
@@ -888,6 +1170,15 @@ func (c Clustering) IsZero() bool {
 	return c.Clusters == nil && c.KialiURLs == nil
 }
 
+// FeatureFlagConfigHistory configures the revision history that Kiali keeps for the Istio config it modifies or deletes
+type FeatureFlagConfigHistory struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// Maximum number of revisions kept per object. The oldest revisions are dropped first.
+	MaxRevisions int `yaml:"max_revisions,omitempty" json:"maxRevisions"`
+	// When true the revisions of changes made by other tools are also recorded, as observed by the cache informers.
+	WatchExternalChanges bool `yaml:"watch_external_changes,omitempty" json:"watchExternalChanges"`
+}
+
 type FeatureFlagClustering struct {
 	EnableExecProvider bool `yaml:"enable_exec_provider,omitempty" json:"enable_exec_provider"`
 }
@@ -922,6 +1213,7 @@ type FeatureFlagAuthz struct {
 type KialiFeatureFlags struct {
 	Authz                 FeatureFlagAuthz          `yaml:"authz,omitempty" json:"authz,omitempty"`
 	Clustering            FeatureFlagClustering     `yaml:"clustering,omitempty" json:"clustering,omitempty"`
+	ConfigHistory         FeatureFlagConfigHistory  `yaml:"config_history,omitempty" json:"configHistory,omitempty"`
 	CustomWorkloadTypes   []metav1.GroupVersionKind `yaml:"custom_workload_types,omitempty" json:"customWorkloadTypes,omitempty"`
 	DisabledFeatures      []string                  `yaml:"disabled_features,omitempty" json:"disabledFeatures,omitempty"`
 	IstioAnnotationAction bool                      `yaml:"istio_annotation_action,omitempty" json:"istioAnnotationAction"`
@@ -940,12 +1232,27 @@ type Tolerance struct {
 	Direction string  `yaml:"direction,omitempty" json:"direction"`
 }
 
+// LatencyThreshold config: the health is degraded, or failing, when a quantile of the duration of the matching
+// requests reaches the thresholds, in milliseconds.
+type LatencyThreshold struct {
+	Degraded  float32 `yaml:"degraded,omitempty" json:"degraded"`
+	Direction string  `yaml:"direction,omitempty" json:"direction"`
+	Failure   float32 `yaml:"failure,omitempty" json:"failure"`
+	Protocol  string  `yaml:"protocol,omitempty" json:"protocol"`
+	// Quantile of the request duration compared to the thresholds, between 0 and 1. Default: 0.95
+	Quantile float32 `yaml:"quantile,omitempty" json:"quantile"`
+}
+
+// DefaultLatencyQuantile is the quantile of the request duration used by the latency thresholds without quantile.
+const DefaultLatencyQuantile = 0.95
+
 // Rate holds configuration for customizing health computation. It specifies allowable rates for certain traffic types
 type Rate struct {
-	Namespace string      `yaml:"namespace,omitempty" json:"namespace,omitempty"`
-	Kind      string      `yaml:"kind,omitempty" json:"kind,omitempty"`
-	Name      string      `yaml:"name,omitempty" json:"name,omitempty"`
-	Tolerance []Tolerance `yaml:"tolerance,omitempty" json:"tolerance"`
+	Namespace string             `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	Kind      string             `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Latency   []LatencyThreshold `yaml:"latency,omitempty" json:"latency,omitempty"`
+	Name      string             `yaml:"name,omitempty" json:"name,omitempty"`
+	Tolerance []Tolerance        `yaml:"tolerance,omitempty" json:"tolerance"`
 }
 
 // DurationString is a duration expressed as a string (e.g. "5m", "1h") for config and API responses.
@@ -979,11 +1286,200 @@ type HealthCompute struct {
 	Timeout DurationString `yaml:"timeout,omitempty" json:"timeout,omitempty"`
 }
 
+// SLO types
+const (
+	SLOTypeAvailability = "availability"
+	SLOTypeLatency      = "latency"
+)
+
+// DefaultSLOWindow is the compliance window of the SLOs without window.
+const DefaultSLOWindow = "30d"
+
+// SLOTarget selects the services, apps or workloads an SLO applies to. Fields are regular expressions.
+type SLOTarget struct {
+	Kind      string `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name      string `yaml:"name,omitempty" json:"name,omitempty"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// SLO is a service level objective on the inbound requests of the targeted entities. The error budget, and its
+// burn rates, are computed from the Istio request metrics during the health refresh.
+type SLO struct {
+	// LatencyThreshold is the duration, in milliseconds, under which a request is good for a latency SLO. It must
+	// be a bucket boundary of the istio_request_duration_milliseconds histogram (e.g. 100, 250, 500, 1000).
+	LatencyThreshold float32 `yaml:"latency_threshold,omitempty" json:"latencyThreshold,omitempty"`
+	Name             string  `yaml:"name" json:"name"`
+	// Objective is the percentage of good requests over the window, e.g. 99.9
+	Objective float64   `yaml:"objective" json:"objective"`
+	Target    SLOTarget `yaml:"target,omitempty" json:"target,omitempty"`
+	// Type is availability (requests without 5xx responses) or latency (requests faster than the threshold).
+	Type string `yaml:"type" json:"type"`
+	// Window is the compliance window, as a Prometheus duration. Default: 30d
+	Window string `yaml:"window,omitempty" json:"window,omitempty"`
+}
+
+// HealthHistory configures the history of the health transitions of the apps, services and workloads, recorded
+// by the health cache refreshes.
+type HealthHistory struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// MaxAge is how long the transitions are kept after they ended (e.g. "24h"). The entities that are not seen
+	// for longer are forgotten.
+	// Default: 24h
+	MaxAge DurationString `yaml:"max_age,omitempty" json:"maxAge,omitempty"`
+	// MaxTransitions is the maximum number of transitions kept per entity. The oldest are dropped first.
+	// Default: 100
+	MaxTransitions int `yaml:"max_transitions,omitempty" json:"maxTransitions,omitempty"`
+}
+
+// HealthCountThresholds are the counts from which a health signal is degraded or failing. A threshold of 0
+// disables it.
+type HealthCountThresholds struct {
+	Degraded int32 `yaml:"degraded,omitempty" json:"degraded,omitempty"`
+	Failure  int32 `yaml:"failure,omitempty" json:"failure,omitempty"`
+}
+
+// HealthKubernetes configures the workload health calculated from Kubernetes signals: the restarts and the
+// CrashLoopBackOff and OOMKilled states of the containers of the pods, and the recent Warning Events involving
+// the pods or the workload. The Events are only read when the FailedProbes or WarningEvents thresholds are set:
+// the Kiali cache then watches the Warning Events, which requires the Kiali service account to list and watch
+// the events of the accessible namespaces.
+type HealthKubernetes struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// FailedProbes are the thresholds on the number of readiness probe failures reported by the recent Events.
+	// Default: disabled
+	FailedProbes HealthCountThresholds `yaml:"failed_probes,omitempty" json:"failedProbes,omitempty"`
+	// RecentWindow is how far back restarts, OOM kills and Events are considered (e.g. "15m").
+	// Default: 15m
+	RecentWindow DurationString `yaml:"recent_window,omitempty" json:"recentWindow,omitempty"`
+	// Restarts are the thresholds on the number of restarts of the containers that restarted recently.
+	// Default: degraded 3, failure 10
+	Restarts HealthCountThresholds `yaml:"restarts,omitempty" json:"restarts,omitempty"`
+	// WarningEvents are the thresholds on the number of recent Warning Events, probe failures excluded.
+	// Default: disabled
+	WarningEvents HealthCountThresholds `yaml:"warning_events,omitempty" json:"warningEvents,omitempty"`
+}
+
+// HealthDurationThresholds are the durations from which a health signal is degraded or failing (e.g. "5s"). An
+// empty threshold disables it.
+type HealthDurationThresholds struct {
+	Degraded DurationString `yaml:"degraded,omitempty" json:"degraded,omitempty"`
+	Failure  DurationString `yaml:"failure,omitempty" json:"failure,omitempty"`
+}
+
+// HealthMesh configures the health of the mesh infrastructure components: istiod, the ingress and egress gateways,
+// the waypoints and ztunnel. Their health combines the status of their pods and the Kubernetes signals with, for
+// istiod, the pilot_* metrics computed over the health rate interval and the expiry of the control plane
+// certificates.
+type HealthMesh struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+	// CertExpiry are the thresholds on the remaining validity of the control plane certificates: the health is
+	// degraded, or failing, when a certificate expires within them.
+	// Default: degraded 720h, failure 168h
+	CertExpiry HealthDurationThresholds `yaml:"cert_expiry,omitempty" json:"certExpiry,omitempty"`
+	// ConfigRejections are the thresholds on the number of xDS configs rejected by the proxies.
+	// Default: degraded 1
+	ConfigRejections HealthCountThresholds `yaml:"config_rejections,omitempty" json:"configRejections,omitempty"`
+	// ProxyConvergence are the thresholds on the 99th percentile of the time the proxies take to converge after a
+	// config change.
+	// Default: degraded 5s, failure 30s
+	ProxyConvergence HealthDurationThresholds `yaml:"proxy_convergence,omitempty" json:"proxyConvergence,omitempty"`
+	// XdsPushErrors are the thresholds on the number of push context errors, internal errors and write timeouts of
+	// the xDS pushes.
+	// Default: degraded 5, failure 50
+	XdsPushErrors HealthCountThresholds `yaml:"xds_push_errors,omitempty" json:"xdsPushErrors,omitempty"`
+	// XdsPushLatency are the thresholds on the 99th percentile of the xDS push time.
+	// Default: degraded 1s, failure 10s
+	XdsPushLatency HealthDurationThresholds `yaml:"xds_push_latency,omitempty" json:"xdsPushLatency,omitempty"`
+}
+
+const (
+	HealthAlertReceiverAlertmanager = "alertmanager"
+	HealthAlertReceiverSlack        = "slack"
+	HealthAlertReceiverWebhook      = "webhook"
+
+	HealthAlertSeverityCritical = "critical"
+	HealthAlertSeverityWarning  = "warning"
+)
+
+// HealthAlertSelector selects the apps, services and workloads of a health alert rule or silence. The kind, name
+// and namespace regexes must match fully. Empty fields match everything.
+type HealthAlertSelector struct {
+	// Kind is a regex on app, service or workload
+	Kind string `yaml:"kind,omitempty"`
+	// Labels is a label selector on the labels of the entity (e.g. "team=payments"). An app matches when one of its
+	// workloads matches.
+	Labels    string `yaml:"labels,omitempty"`
+	Name      string `yaml:"name,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	// NamespaceLabels is a label selector on the labels of the namespace of the entity
+	NamespaceLabels string `yaml:"namespace_labels,omitempty"`
+}
+
+// HealthAlertRule notifies its receivers when a selected entity stays unhealthy for a while, and again when it
+// recovers.
+type HealthAlertRule struct {
+	// For is how long the entity must stay unhealthy before it is notified (e.g. "10m").
+	// Default: notified by the first health refresh that sees it unhealthy
+	For  DurationString `yaml:"for,omitempty"`
+	Name string         `yaml:"name"`
+	// Receivers are the names of the notified receivers. Default: all the receivers
+	Receivers []string            `yaml:"receivers,omitempty"`
+	Selector  HealthAlertSelector `yaml:"selector,omitempty"`
+	// Severity is the least severe notified status: warning notifies the Degraded and Failure statuses, critical
+	// notifies the Failure status only.
+	// Default: warning
+	Severity string `yaml:"severity,omitempty"`
+}
+
+// HealthAlertReceiver is an endpoint the health alerts are posted to. The type is webhook (the alerts as JSON), slack
+// (a Slack-compatible incoming webhook message) or alertmanager (the v2 alerts API of the Alertmanager at the URL).
+type HealthAlertReceiver struct {
+	Auth    Auth              `yaml:"auth,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Name    string            `yaml:"name"`
+	// Timeout of the requests. Default: 5s
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	Type    string        `yaml:"type"`
+	URL     string        `yaml:"url"`
+}
+
+// HealthAlertSilence mutes the notifications of the alerts of the selected entities during a time window. The alerts
+// still firing when it ends are notified then. Recoveries are always notified.
+type HealthAlertSilence struct {
+	Comment string `yaml:"comment,omitempty"`
+	// EndsAt is when the silence ends, in RFC3339 format (e.g. "2026-01-01T08:00:00Z")
+	EndsAt string `yaml:"ends_at"`
+	// Rules are the names of the silenced rules. Default: all the rules
+	Rules    []string            `yaml:"rules,omitempty"`
+	Selector HealthAlertSelector `yaml:"selector,omitempty"`
+	// StartsAt is when the silence starts, in RFC3339 format. Default: already started
+	StartsAt string `yaml:"starts_at,omitempty"`
+}
+
+// HealthAlerts configures the notifications of the health transitions computed by the health refreshes. Each
+// alert is notified once when it fires, again when its status changes and when it recovers.
+type HealthAlerts struct {
+	Enabled   bool                  `yaml:"enabled"`
+	Receivers []HealthAlertReceiver `yaml:"receivers,omitempty"`
+	// RepeatInterval is how often a firing alert is notified again while its status does not change (e.g. "4h").
+	// Alertmanager receivers are sent the firing alerts by every health refresh, Alertmanager de-duplicates them.
+	// Default: not repeated
+	RepeatInterval DurationString       `yaml:"repeat_interval,omitempty"`
+	Rules          []HealthAlertRule    `yaml:"rules,omitempty"`
+	Silences       []HealthAlertSilence `yaml:"silences,omitempty"`
+}
+
 // HealthConfig holds both custom rate configurations for computing health, as well as the configuration about
 // the health computation job itself.
 type HealthConfig struct {
-	Compute HealthCompute `yaml:"compute,omitempty" json:"compute,omitempty"`
-	Rate    []Rate        `yaml:"rate,omitempty" json:"rate,omitempty"`
+	// Alerts are not exposed with the health config: the receivers hold credentials
+	Alerts     HealthAlerts     `yaml:"alerts,omitempty" json:"-"`
+	Compute    HealthCompute    `yaml:"compute,omitempty" json:"compute,omitempty"`
+	History    HealthHistory    `yaml:"history,omitempty" json:"history,omitempty"`
+	Kubernetes HealthKubernetes `yaml:"kubernetes,omitempty" json:"kubernetes,omitempty"`
+	Mesh       HealthMesh       `yaml:"mesh,omitempty" json:"mesh,omitempty"`
+	Rate       []Rate           `yaml:"rate,omitempty" json:"rate,omitempty"`
+	SLO        []SLO            `yaml:"slo,omitempty" json:"slo,omitempty"`
 }
 
 // DefaultHealthRateInterval is the default Prometheus rate window for health: it matches the default
@@ -1027,12 +1523,17 @@ type Config struct {
 	RunMode                  RunMode                             `yaml:"runMode,omitempty"`
 	ResolvedTLSPolicy        TLSPolicy                           `yaml:"-" json:"-"`
 	Server                   Server                              `yaml:",omitempty"`
+	SessionStore             SessionStoreConfig                  `yaml:"session_store,omitempty"`
 }
 
 // NewConfig creates a default Config struct
 func NewConfig() (c *Config) {
 	c = &Config{
 		Auth: AuthConfig{
+			APITokens: APITokensConfig{
+				Enabled:              false,
+				MaxExpirationSeconds: 90 * 24 * 3600,
+			},
 			Strategy: AuthStrategyToken,
 			OpenId: OpenIdConfig{
 				AdditionalRequestParams: map[string]string{},
@@ -1062,6 +1563,18 @@ func NewConfig() (c *Config) {
 				Impersonation:         ImpersonationConfig{Enabled: false, AllowedGroups: []string{}, AllowedUsers: []string{}},
 				InsecureSkipVerifyTLS: false,
 			},
+			KialiRBAC: KialiRBACConfig{
+				DefaultRoles: []string{},
+				Enabled:      false,
+				RoleBindings: []KialiRoleBinding{},
+				Roles:        []KialiRole{},
+			},
+			X509: X509Config{
+				AllowedGroups: []string{},
+				AllowedUsers:  []string{},
+				GroupsField:   X509FieldOrganization,
+				UsernameField: X509FieldCommonName,
+			},
 		},
 		ChatAI: ChatAIConfig{
 			Enabled:           false,
@@ -1104,6 +1617,21 @@ func NewConfig() (c *Config) {
 					},
 				},
 			},
+			GitOps: GitOpsConfig{
+				API: GitOpsAPIConfig{
+					Auth: Auth{
+						Type: AuthTypeNone,
+					},
+					Timeout: 10 * time.Second,
+				},
+				AuthorEmail:  "kiali@localhost",
+				AuthorName:   "Kiali",
+				BaseBranch:   "main",
+				BranchPrefix: "kiali/",
+				Enabled:      false,
+				PathTemplate: "{{.Cluster}}/{{.Namespace}}/{{.Kind}}/{{.Name}}.yaml",
+				Provider:     GitOpsProviderLocal,
+			},
 			Grafana: GrafanaConfig{
 				Auth: Auth{
 					Type: AuthTypeNone,
@@ -1186,6 +1714,24 @@ func NewConfig() (c *Config) {
 				RefreshInterval: "3m",
 				Timeout:         "10m",
 			},
+			History: HealthHistory{
+				Enabled:        true,
+				MaxAge:         "24h",
+				MaxTransitions: 100,
+			},
+			Kubernetes: HealthKubernetes{
+				Enabled:      true,
+				RecentWindow: "15m",
+				Restarts:     HealthCountThresholds{Degraded: 3, Failure: 10},
+			},
+			Mesh: HealthMesh{
+				Enabled:          true,
+				CertExpiry:       HealthDurationThresholds{Degraded: "720h", Failure: "168h"},
+				ConfigRejections: HealthCountThresholds{Degraded: 1},
+				ProxyConvergence: HealthDurationThresholds{Degraded: "5s", Failure: "30s"},
+				XdsPushErrors:    HealthCountThresholds{Degraded: 5, Failure: 50},
+				XdsPushLatency:   HealthDurationThresholds{Degraded: "1s", Failure: "10s"},
+			},
 		},
 		IstioLabels: IstioLabels{
 			AppLabelName:     "",
@@ -1198,6 +1744,11 @@ func NewConfig() (c *Config) {
 			Clustering: FeatureFlagClustering{
 				EnableExecProvider: false,
 			},
+			ConfigHistory: FeatureFlagConfigHistory{
+				Enabled:              true,
+				MaxRevisions:         10,
+				WatchExternalChanges: false,
+			},
 			DisabledFeatures:      []string{},
 			IstioAnnotationAction: true,
 			IstioInjectionAction:  true,
@@ -1315,8 +1866,32 @@ func NewConfig() (c *Config) {
 			ExpirationSeconds: 24 * 3600,
 			SigningKey:        "kiali",
 		},
+		SessionStore: SessionStoreConfig{
+			File: SessionStoreFileConfig{
+				Path: "/tmp/kiali-sessions",
+			},
+			Redis: SessionStoreRedisConfig{
+				KeyPrefix: "kiali:",
+				Timeout:   5 * time.Second,
+			},
+			Type: SessionStoreTypeMemory,
+		},
 		Server: Server{
-			AuditLog:    true,
+			AuditLog: true,
+			AuditTrail: AuditTrail{
+				File: AuditFileSink{
+					MaxBackups: 5,
+					MaxSizeMB:  100,
+					Path:       "/tmp/kiali-audit.log",
+				},
+				MaxMemoryRecords: 1000,
+				Webhook: AuditWebhookSink{
+					Auth: Auth{
+						Type: AuthTypeNone,
+					},
+					Timeout: 5 * time.Second,
+				},
+			},
 			GzipEnabled: true,
 			Observability: Observability{
 				Metrics: Metrics{
@@ -1393,6 +1968,155 @@ func (conf *Config) AddHealthDefault() {
 	conf.HealthConfig.Rate = append(conf.HealthConfig.Rate, healthConfig.Rate...)
 }
 
+// ValidateSLO returns an error when the SLO definition is not valid. It validates the SLOs of the configuration
+// and of the health annotations.
+func ValidateSLO(slo SLO) error {
+	if slo.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+	if slo.Objective <= 0 || slo.Objective >= 100 {
+		return fmt.Errorf("objective of SLO [%s] must be a percentage between 0 and 100, exclusive", slo.Name)
+	}
+	switch slo.Type {
+	case SLOTypeAvailability:
+	case SLOTypeLatency:
+		if slo.LatencyThreshold <= 0 {
+			return fmt.Errorf("latency_threshold of latency SLO [%s] must be set", slo.Name)
+		}
+	default:
+		return fmt.Errorf("type of SLO [%s] must be %s or %s", slo.Name, SLOTypeAvailability, SLOTypeLatency)
+	}
+	if slo.Window != "" {
+		if _, err := model.ParseDuration(slo.Window); err != nil {
+			return fmt.Errorf("window of SLO [%s] is not valid: %w", slo.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateHealthAlerts returns an error, prefixed with the path of the invalid field, when the health alerts are
+// not valid.
+// validateHealthCountThresholds validates the thresholds of a health_config signal.
+func validateHealthCountThresholds(name string, thresholds HealthCountThresholds) error {
+	if thresholds.Degraded < 0 || thresholds.Failure < 0 {
+		return fmt.Errorf("health_config.%s thresholds must not be negative", name)
+	}
+	if thresholds.Degraded > 0 && thresholds.Failure > 0 && thresholds.Degraded > thresholds.Failure {
+		return fmt.Errorf("health_config.%s.degraded must not be greater than the failure threshold", name)
+	}
+	return nil
+}
+
+// validateHealthDurationThresholds validates the thresholds of a health_config signal. When lowerIsWorse, the
+// failure threshold must not be greater than the degraded one.
+func validateHealthDurationThresholds(name string, thresholds HealthDurationThresholds, lowerIsWorse bool) error {
+	var degraded, failure time.Duration
+	var err error
+	if thresholds.Degraded != "" {
+		if degraded, err = thresholds.Degraded.ToDuration(); err != nil || degraded <= 0 {
+			return fmt.Errorf("health_config.%s.degraded [%s] must be a positive duration", name, thresholds.Degraded)
+		}
+	}
+	if thresholds.Failure != "" {
+		if failure, err = thresholds.Failure.ToDuration(); err != nil || failure <= 0 {
+			return fmt.Errorf("health_config.%s.failure [%s] must be a positive duration", name, thresholds.Failure)
+		}
+	}
+	if degraded > 0 && failure > 0 {
+		if lowerIsWorse && failure > degraded {
+			return fmt.Errorf("health_config.%s.failure must not be greater than the degraded threshold", name)
+		}
+		if !lowerIsWorse && degraded > failure {
+			return fmt.Errorf("health_config.%s.degraded must not be greater than the failure threshold", name)
+		}
+	}
+	return nil
+}
+
+func validateHealthAlerts(alerts HealthAlerts) error {
+	if alerts.RepeatInterval != "" {
+		if d, err := alerts.RepeatInterval.ToDuration(); err != nil || d <= 0 {
+			return fmt.Errorf("repeat_interval [%s] must be a positive duration", alerts.RepeatInterval)
+		}
+	}
+
+	receivers := map[string]bool{}
+	for i, receiver := range alerts.Receivers {
+		if receiver.Name == "" || receivers[receiver.Name] {
+			return fmt.Errorf("receivers[%d]: name must be set and unique", i)
+		}
+		receivers[receiver.Name] = true
+		switch receiver.Type {
+		case HealthAlertReceiverAlertmanager, HealthAlertReceiverSlack, HealthAlertReceiverWebhook:
+		default:
+			return fmt.Errorf("receivers[%d]: type must be %s, %s or %s", i, HealthAlertReceiverAlertmanager, HealthAlertReceiverSlack, HealthAlertReceiverWebhook)
+		}
+		if receiver.URL == "" {
+			return fmt.Errorf("receivers[%d]: url must be set", i)
+		}
+	}
+
+	rules := map[string]bool{}
+	for i, rule := range alerts.Rules {
+		if rule.Name == "" || rules[rule.Name] {
+			return fmt.Errorf("rules[%d]: name must be set and unique", i)
+		}
+		rules[rule.Name] = true
+		if rule.For != "" {
+			if d, err := rule.For.ToDuration(); err != nil || d < 0 {
+				return fmt.Errorf("rules[%d].for [%s] must be a duration", i, rule.For)
+			}
+		}
+		if rule.Severity != "" && rule.Severity != HealthAlertSeverityWarning && rule.Severity != HealthAlertSeverityCritical {
+			return fmt.Errorf("rules[%d].severity must be %s or %s", i, HealthAlertSeverityWarning, HealthAlertSeverityCritical)
+		}
+		for _, name := range rule.Receivers {
+			if !receivers[name] {
+				return fmt.Errorf("rules[%d]: unknown receiver [%s]", i, name)
+			}
+		}
+		if err := validateHealthAlertSelector(rule.Selector); err != nil {
+			return fmt.Errorf("rules[%d].selector: %w", i, err)
+		}
+	}
+
+	for i, silence := range alerts.Silences {
+		endsAt, err := time.Parse(time.RFC3339, silence.EndsAt)
+		if err != nil {
+			return fmt.Errorf("silences[%d].ends_at [%s] must be a RFC3339 time", i, silence.EndsAt)
+		}
+		if silence.StartsAt != "" {
+			startsAt, err := time.Parse(time.RFC3339, silence.StartsAt)
+			if err != nil || !startsAt.Before(endsAt) {
+				return fmt.Errorf("silences[%d].starts_at [%s] must be a RFC3339 time before ends_at", i, silence.StartsAt)
+			}
+		}
+		for _, name := range silence.Rules {
+			if !rules[name] {
+				return fmt.Errorf("silences[%d]: unknown rule [%s]", i, name)
+			}
+		}
+		if err := validateHealthAlertSelector(silence.Selector); err != nil {
+			return fmt.Errorf("silences[%d].selector: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateHealthAlertSelector(selector HealthAlertSelector) error {
+	for _, pattern := range []string{selector.Kind, selector.Name, selector.Namespace} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regex [%s]: %w", pattern, err)
+		}
+	}
+	for _, selector := range []string{selector.Labels, selector.NamespaceLabels} {
+		if _, err := labels.Parse(selector); err != nil {
+			return fmt.Errorf("invalid label selector [%s]: %w", selector, err)
+		}
+	}
+	return nil
+}
+
 func (conf *Config) ValidateAI() error {
 	if !conf.ChatAI.Enabled {
 		return nil
@@ -1632,9 +2356,28 @@ func (conf Config) Obfuscate() (obf Config) {
 	obf.ExternalServices.Prometheus.Auth.Obfuscate()
 	obf.ExternalServices.Tracing.Auth.Obfuscate()
 	obf.ExternalServices.CustomDashboards.Prometheus.Auth.Obfuscate()
+	obf.ExternalServices.GitOps.API.Auth.Obfuscate()
 	obf.Identity.Obfuscate()
 	obf.LoginToken.Obfuscate()
+	obf.SessionStore.Redis.Password = "xxx"
 	obf.Auth.OpenId.ClientSecret = "xxx"
+	if len(obf.Auth.OpenId.Clusters) > 0 {
+		clusters := make(map[string]OpenIdClusterConfig, len(obf.Auth.OpenId.Clusters))
+		for name, cluster := range obf.Auth.OpenId.Clusters {
+			cluster.ClientSecret = "xxx"
+			clusters[name] = cluster
+		}
+		obf.Auth.OpenId.Clusters = clusters
+	}
+	obf.Server.AuditTrail.Webhook.Auth.Obfuscate()
+	if len(obf.HealthConfig.Alerts.Receivers) > 0 {
+		receivers := make([]HealthAlertReceiver, len(obf.HealthConfig.Alerts.Receivers))
+		for i, receiver := range obf.HealthConfig.Alerts.Receivers {
+			receiver.Auth.Obfuscate()
+			receivers[i] = receiver
+		}
+		obf.HealthConfig.Alerts.Receivers = receivers
+	}
 	if len(obf.ChatAI.Providers) > 0 {
 		providers := make([]ProviderConfig, len(obf.ChatAI.Providers))
 		copy(providers, obf.ChatAI.Providers)
@@ -1885,6 +2628,21 @@ func Unmarshal(yamlString string) (conf *Config, err error) {
 			configValue: &conf.LoginToken.SigningKey,
 			fileName:    SecretFileLoginTokenSigningKey,
 		},
+		// Redis session store password
+		{
+			configValue: &conf.SessionStore.Redis.Password,
+			fileName:    SecretFileSessionStoreRedisPassword,
+		},
+	}
+
+	openIdClusterSecrets := make(map[string]*Credential, len(conf.Auth.OpenId.Clusters))
+	for name, cluster := range conf.Auth.OpenId.Clusters {
+		secret := cluster.ClientSecret
+		openIdClusterSecrets[name] = &secret
+		overrides = append(overrides, overridesType{
+			configValue: &secret,
+			fileName:    openIdClusterSecretFileName(name),
+		})
 	}
 
 	for i := range conf.ChatAI.Providers {
@@ -1945,6 +2703,13 @@ func Unmarshal(yamlString string) (conf *Config, err error) {
 		}
 	}
 
+	// Map values are not addressable: the overrides were applied to copies of the OpenID cluster secrets
+	for name, secret := range openIdClusterSecrets {
+		cluster := conf.Auth.OpenId.Clusters[name]
+		cluster.ClientSecret = *secret
+		conf.Auth.OpenId.Clusters[name] = cluster
+	}
+
 	// Handle OIDC client secret from mounted kiali-secret volume.
 	// Unlike the overrides above (which are in /kiali-override-secrets), this comes from
 	// a different volume mount (/kiali-secret) but follows the same pattern: if the file
@@ -2144,6 +2909,47 @@ func Validate(conf *Config) error {
 		return fmt.Errorf("invalid deployment.tls_config.source [%v]; must be 'auto' or 'config'", conf.Deployment.TLSConfig.Source)
 	}
 
+	auditTrail := conf.Server.AuditTrail
+	if auditTrail.File.Enabled && auditTrail.File.Path == "" {
+		return fmt.Errorf("server.audit_trail.file.path must be set when the file sink is enabled")
+	}
+	if auditTrail.Webhook.Enabled && auditTrail.Webhook.URL == "" {
+		return fmt.Errorf("server.audit_trail.webhook.url must be set when the webhook sink is enabled")
+	}
+
+	if gitOps := conf.ExternalServices.GitOps; gitOps.Enabled {
+		switch gitOps.Provider {
+		case GitOpsProviderLocal:
+			if gitOps.Local.RepositoryPath == "" {
+				return fmt.Errorf("external_services.gitops.local.repository_path must be set when the local GitOps provider is used")
+			}
+		case GitOpsProviderAPI:
+			if gitOps.API.URL == "" {
+				return fmt.Errorf("external_services.gitops.api.url must be set when the api GitOps provider is used")
+			}
+		default:
+			return fmt.Errorf("invalid external_services.gitops.provider [%s]; must be '%s' or '%s'", gitOps.Provider, GitOpsProviderLocal, GitOpsProviderAPI)
+		}
+	}
+
+	switch conf.SessionStore.Type {
+	case SessionStoreTypeMemory:
+	case SessionStoreTypeFile:
+		if conf.SessionStore.File.Path == "" {
+			return fmt.Errorf("session_store.file.path must be set when the file session store is used")
+		}
+	case SessionStoreTypeRedis:
+		if conf.SessionStore.Redis.Address == "" {
+			return fmt.Errorf("session_store.redis.address must be set when the redis session store is used")
+		}
+	default:
+		return fmt.Errorf("invalid session_store.type [%s]; must be '%s', '%s' or '%s'", conf.SessionStore.Type, SessionStoreTypeMemory, SessionStoreTypeFile, SessionStoreTypeRedis)
+	}
+
+	if configHistory := conf.KialiFeatureFlags.ConfigHistory; configHistory.Enabled && configHistory.MaxRevisions <= 0 {
+		return fmt.Errorf("kiali_feature_flags.config_history.max_revisions must be greater than 0 when the config history is enabled")
+	}
+
 	webRoot := conf.Server.WebRoot
 	if !validPathRegEx.MatchString(webRoot) {
 		return fmt.Errorf("web root must begin with a / and contain valid URL path characters: %v", webRoot)
@@ -2190,9 +2996,40 @@ func Validate(conf *Config) error {
 	} else if auth.Strategy != AuthStrategyOpenId &&
 		auth.Strategy != AuthStrategyOpenshift &&
 		auth.Strategy != AuthStrategyToken &&
-		auth.Strategy != AuthStrategyHeader {
+		auth.Strategy != AuthStrategyHeader &&
+		auth.Strategy != AuthStrategyX509 {
 		return fmt.Errorf("invalid authentication strategy [%v]", auth.Strategy)
 	}
+	if auth.Strategy == AuthStrategyX509 {
+		if err := validateX509Config(conf); err != nil {
+			return err
+		}
+	}
+	if err := validateKialiRBACConfig(auth.KialiRBAC); err != nil {
+		return err
+	}
+	if auth.Strategy == AuthStrategyOpenId {
+		if err := validateOpenIdClusters(conf); err != nil {
+			return err
+		}
+	}
+	if auth.APITokens.Enabled {
+		if !auth.ImpersonatesWithSA() {
+			return fmt.Errorf("auth.api_tokens requires an auth strategy impersonating the users with the Kiali service account: openshift with auth.openshift.impersonation.enabled, or x509, but auth.strategy is [%s]", auth.Strategy)
+		}
+		if auth.APITokens.MaxExpirationSeconds <= 0 {
+			return fmt.Errorf("auth.api_tokens.max_expiration_seconds must be greater than 0")
+		}
+	}
+
+	if auth.SessionManagement.Enabled {
+		if auth.Strategy == AuthStrategyAnonymous {
+			return fmt.Errorf("auth.session_management cannot be enabled with the anonymous auth strategy")
+		}
+		if !auth.KialiRBAC.Enabled {
+			return fmt.Errorf("auth.session_management requires auth.kiali_rbac to be enabled, to grant the [%s] capability", KialiCapabilityManageSessions)
+		}
+	}
 
 	// Check the ciphering key for sessions
 	// If signing key is a file path, read the actual content for validation
@@ -2246,6 +3083,84 @@ func Validate(conf *Config) error {
 		conf.HealthConfig.Compute.Duration = "1m"
 	}
 
+	if conf.HealthConfig.History.Enabled {
+		if d, err := conf.HealthConfig.History.MaxAge.ToDuration(); err != nil || d <= 0 {
+			return fmt.Errorf("health_config.history.max_age [%s] must be a positive duration", conf.HealthConfig.History.MaxAge)
+		}
+		if conf.HealthConfig.History.MaxTransitions <= 0 {
+			return fmt.Errorf("health_config.history.max_transitions must be positive")
+		}
+	}
+
+	if k8sHealth := conf.HealthConfig.Kubernetes; k8sHealth.Enabled {
+		if d, err := k8sHealth.RecentWindow.ToDuration(); err != nil || d <= 0 {
+			return fmt.Errorf("health_config.kubernetes.recent_window [%s] must be a positive duration", k8sHealth.RecentWindow)
+		}
+		for name, thresholds := range map[string]HealthCountThresholds{
+			"failed_probes":  k8sHealth.FailedProbes,
+			"restarts":       k8sHealth.Restarts,
+			"warning_events": k8sHealth.WarningEvents,
+		} {
+			if err := validateHealthCountThresholds("kubernetes."+name, thresholds); err != nil {
+				return err
+			}
+		}
+	}
+
+	if meshHealth := conf.HealthConfig.Mesh; meshHealth.Enabled {
+		for name, thresholds := range map[string]HealthCountThresholds{
+			"config_rejections": meshHealth.ConfigRejections,
+			"xds_push_errors":   meshHealth.XdsPushErrors,
+		} {
+			if err := validateHealthCountThresholds("mesh."+name, thresholds); err != nil {
+				return err
+			}
+		}
+		for name, thresholds := range map[string]HealthDurationThresholds{
+			"proxy_convergence": meshHealth.ProxyConvergence,
+			"xds_push_latency":  meshHealth.XdsPushLatency,
+		} {
+			if err := validateHealthDurationThresholds("mesh."+name, thresholds, false); err != nil {
+				return err
+			}
+		}
+		// The remaining validity of a certificate is worse when it is shorter
+		if err := validateHealthDurationThresholds("mesh.cert_expiry", meshHealth.CertExpiry, true); err != nil {
+			return err
+		}
+	}
+
+	for i, rate := range conf.HealthConfig.Rate {
+		for j, latency := range rate.Latency {
+			if latency.Quantile < 0 || latency.Quantile > 1 {
+				return fmt.Errorf("health_config.rate[%d].latency[%d].quantile must be between 0 and 1", i, j)
+			}
+			if latency.Degraded <= 0 && latency.Failure <= 0 {
+				return fmt.Errorf("health_config.rate[%d].latency[%d] must set a degraded or a failure threshold", i, j)
+			}
+			if latency.Degraded > 0 && latency.Failure > 0 && latency.Degraded > latency.Failure {
+				return fmt.Errorf("health_config.rate[%d].latency[%d].degraded must not be greater than the failure threshold", i, j)
+			}
+		}
+	}
+
+	if conf.HealthConfig.Alerts.Enabled {
+		if err := validateHealthAlerts(conf.HealthConfig.Alerts); err != nil {
+			return fmt.Errorf("health_config.alerts.%w", err)
+		}
+	}
+
+	sloNames := map[string]bool{}
+	for i, slo := range conf.HealthConfig.SLO {
+		if err := ValidateSLO(slo); err != nil {
+			return fmt.Errorf("health_config.slo[%d]: %w", i, err)
+		}
+		if sloNames[slo.Name] {
+			return fmt.Errorf("health_config.slo[%d]: duplicate SLO name [%s]", i, slo.Name)
+		}
+		sloNames[slo.Name] = true
+	}
+
 	oauth2Services := map[string]*Auth{
 		"custom_dashboards": &conf.ExternalServices.CustomDashboards.Prometheus.Auth,
 		"grafana":           &conf.ExternalServices.Grafana.Auth,
@@ -2291,6 +3206,93 @@ func Validate(conf *Config) error {
 	return nil
 }
 
+func validateX509Config(conf *Config) error {
+	x509 := conf.Auth.X509
+	if !conf.IsServerHTTPS() {
+		return fmt.Errorf("the x509 auth strategy requires the server to be configured with a certificate and a private key")
+	}
+	if x509.ClientCAFile == "" {
+		return fmt.Errorf("auth.x509.client_ca_file must be set when auth.strategy is [%s]", AuthStrategyX509)
+	}
+	switch x509.UsernameField {
+	case X509FieldCommonName, X509FieldEmail, X509FieldURI, X509FieldDNS:
+	default:
+		return fmt.Errorf("auth.x509.username_field [%s] is not valid, use cn, email, uri or dns", x509.UsernameField)
+	}
+	switch x509.GroupsField {
+	case X509FieldOrganization, X509FieldOrganizationalUnit, X509FieldNone:
+	default:
+		return fmt.Errorf("auth.x509.groups_field [%s] is not valid, use o, ou or none", x509.GroupsField)
+	}
+	for _, u := range x509.AllowedUsers {
+		if u == "" || strings.HasPrefix(u, "system:") {
+			return fmt.Errorf("auth.x509.allowed_users must not contain empty strings or system: prefixed identities, found [%s]", u)
+		}
+	}
+	return nil
+}
+
+// validateOpenIdClusters checks the per-cluster OpenID configuration. The home cluster always uses the Kiali login.
+func validateOpenIdClusters(conf *Config) error {
+	openId := conf.Auth.OpenId
+	if len(openId.Clusters) > 0 && openId.DisableRBAC {
+		return fmt.Errorf("auth.openid.clusters cannot be used with auth.openid.disable_rbac: the Kiali service account is used in all the clusters")
+	}
+	for name, cluster := range openId.Clusters {
+		if name == conf.KubernetesConfig.ClusterName {
+			return fmt.Errorf("auth.openid.clusters cannot configure the home cluster [%s]: it uses the token of the Kiali login", name)
+		}
+		if cluster.Mode != OpenIdClusterModeLogin && cluster.Mode != OpenIdClusterModeTokenExchange {
+			return fmt.Errorf("auth.openid.clusters[%s].mode [%s] is invalid: must be %s or %s", name, cluster.Mode, OpenIdClusterModeLogin, OpenIdClusterModeTokenExchange)
+		}
+		if cluster.ApiToken != "" && cluster.ApiToken != "id_token" && cluster.ApiToken != "access_token" {
+			return fmt.Errorf("auth.openid.clusters[%s].api_token [%s] is invalid: must be id_token or access_token", name, cluster.ApiToken)
+		}
+		if cluster.ClientId == "" {
+			return fmt.Errorf("auth.openid.clusters[%s].client_id must be set", name)
+		}
+		if cluster.IssuerUri == "" && (cluster.TokenEndpoint == "" || (cluster.Mode == OpenIdClusterModeLogin && cluster.AuthorizationEndpoint == "")) {
+			return fmt.Errorf("auth.openid.clusters[%s].issuer_uri must be set unless its endpoints are set", name)
+		}
+	}
+	return nil
+}
+
+func validateKialiRBACConfig(rbac KialiRBACConfig) error {
+	if !rbac.Enabled {
+		return nil
+	}
+	roles := make(map[string]bool, len(rbac.Roles))
+	for _, role := range rbac.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("auth.kiali_rbac.roles must have a name")
+		}
+		if roles[role.Name] {
+			return fmt.Errorf("auth.kiali_rbac.roles has a duplicated role [%s]", role.Name)
+		}
+		roles[role.Name] = true
+		for _, capability := range role.Capabilities {
+			if capability != KialiCapabilityAll && !slices.Contains(KialiCapabilities, capability) {
+				return fmt.Errorf("auth.kiali_rbac role [%s] has an unknown capability [%s], use * or one of %v", role.Name, capability, KialiCapabilities)
+			}
+		}
+	}
+	for _, role := range rbac.DefaultRoles {
+		if !roles[role] {
+			return fmt.Errorf("auth.kiali_rbac.default_roles has an unknown role [%s]", role)
+		}
+	}
+	for _, binding := range rbac.RoleBindings {
+		if !roles[binding.Role] {
+			return fmt.Errorf("auth.kiali_rbac.role_bindings has an unknown role [%s]", binding.Role)
+		}
+		if len(binding.Users) == 0 && len(binding.Groups) == 0 {
+			return fmt.Errorf("auth.kiali_rbac.role_bindings of role [%s] must have users or groups", binding.Role)
+		}
+	}
+	return nil
+}
+
 func validateSigningKey(signingKey string, authStrategy string) error {
 	if authStrategy != AuthStrategyAnonymous {
 		if len(signingKey) != 16 && len(signingKey) != 24 && len(signingKey) != 32 {
@@ -454,6 +454,49 @@ chat_ai:
 	assert.Equal(t, "my-openai-api-key", key)
 }
 
+// TestSecretOverride_OpenIdClusterClientSecret tests that the client secrets of the OpenID remote clusters
+// are detected and used
+func TestSecretOverride_OpenIdClusterClientSecret(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "config.yaml")
+	configContent := `
+auth:
+  strategy: openid
+  openid:
+    clusters:
+      West_Cluster:
+        client_id: kiali
+        issuer_uri: https://west.example.com
+        mode: token_exchange
+      north:
+        client_id: kiali
+        client_secret: inline-secret
+        issuer_uri: https://north.example.com
+        mode: login
+`
+	require.NoError(t, os.WriteFile(configFile, []byte(configContent), 0600))
+
+	secretsBaseDir := filepath.Join(tmpDir, "kiali-override-secrets")
+	secretDir := filepath.Join(secretsBaseDir, "openid-cluster-west-cluster-client-secret")
+	require.NoError(t, os.MkdirAll(secretDir, 0755))
+	secretFile := filepath.Join(secretDir, "value.txt")
+	require.NoError(t, os.WriteFile(secretFile, []byte("west-secret"), 0600))
+
+	originalSecretsDir := overrideSecretsDir
+	overrideSecretsDir = secretsBaseDir
+	defer func() { overrideSecretsDir = originalSecretsDir }()
+
+	conf, err := LoadFromFile(configFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, Credential(secretFile), conf.Auth.OpenId.Clusters["West_Cluster"].ClientSecret)
+	secret, err := conf.GetCredential(conf.Auth.OpenId.Clusters["West_Cluster"].ClientSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "west-secret", secret)
+	assert.Equal(t, Credential("inline-secret"), conf.Auth.OpenId.Clusters["north"].ClientSecret)
+	assert.Equal(t, "xxx", conf.Obfuscate().Auth.OpenId.Clusters["north"].ClientSecret.String())
+}
+
 // TestSecretOverride_ChatAIModelKey tests that chat_ai model key secrets are detected and used
 func TestSecretOverride_ChatAIModelKey(t *testing.T) {
 	// Create temporary config file with chat_ai model
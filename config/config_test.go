@@ -561,6 +561,328 @@ func TestValidateAuthStrategy(t *testing.T) {
 	}
 }
 
+func TestValidateX509AuthStrategy(t *testing.T) {
+	newX509Config := func() *Config {
+		conf := NewConfig()
+		conf.LoginToken.SigningKey = "kiali67890123456"
+		conf.Auth.Strategy = AuthStrategyX509
+		conf.Auth.X509.ClientCAFile = "/kiali-ca/ca.crt"
+		conf.Identity.CertFile = "/kiali-cert/tls.crt"
+		conf.Identity.PrivateKeyFile = "/kiali-cert/tls.key"
+		return conf
+	}
+	require.NoError(t, Validate(newX509Config()))
+
+	cases := map[string]func(*Config){
+		"no server certificate":    func(c *Config) { c.Identity.CertFile = "" },
+		"no client CA":             func(c *Config) { c.Auth.X509.ClientCAFile = "" },
+		"unknown username field":   func(c *Config) { c.Auth.X509.UsernameField = X509FieldOrganization },
+		"unknown groups field":     func(c *Config) { c.Auth.X509.GroupsField = X509FieldEmail },
+		"empty allowed user":       func(c *Config) { c.Auth.X509.AllowedUsers = []string{""} },
+		"system user in allowlist": func(c *Config) { c.Auth.X509.AllowedUsers = []string{"system:admin"} },
+	}
+	for name, configure := range cases {
+		t.Run(name, func(t *testing.T) {
+			conf := newX509Config()
+			configure(conf)
+			require.Error(t, Validate(conf))
+		})
+	}
+}
+
+func TestValidateSessionStore(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	require.NoError(t, Validate(conf))
+	assert.False(t, conf.SessionStore.IsShared())
+
+	conf.SessionStore.Type = SessionStoreTypeRedis
+	require.Error(t, Validate(conf))
+	conf.SessionStore.Redis.Address = "redis:6379"
+	require.NoError(t, Validate(conf))
+	assert.True(t, conf.SessionStore.IsShared())
+
+	conf.SessionStore.Type = SessionStoreTypeFile
+	conf.SessionStore.File.Path = ""
+	require.Error(t, Validate(conf))
+
+	conf.SessionStore.Type = "etcd"
+	require.Error(t, Validate(conf))
+}
+
+func TestValidateAPITokens(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.Auth.Strategy = AuthStrategyOpenshift
+	conf.Auth.OpenShift.Impersonation.Enabled = true
+	conf.Auth.APITokens.Enabled = true
+	require.NoError(t, Validate(conf))
+
+	conf.Auth.APITokens.MaxExpirationSeconds = 0
+	require.Error(t, Validate(conf))
+
+	conf.Auth.APITokens.MaxExpirationSeconds = 3600
+	conf.Auth.OpenShift.Impersonation.Enabled = false
+	require.Error(t, Validate(conf), "the strategy must impersonate the users with the Kiali SA")
+
+	for _, strategy := range []string{AuthStrategyAnonymous, AuthStrategyToken, AuthStrategyHeader, AuthStrategyOpenId} {
+		conf.Auth.Strategy = strategy
+		require.Error(t, Validate(conf), strategy)
+	}
+}
+
+func TestValidateSessionManagement(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.Auth.Strategy = AuthStrategyToken
+	conf.Auth.SessionManagement.Enabled = true
+	require.Error(t, Validate(conf), "the Kiali roles must be enabled")
+
+	conf.Auth.KialiRBAC.Enabled = true
+	require.NoError(t, Validate(conf))
+
+	conf.Auth.Strategy = AuthStrategyAnonymous
+	require.Error(t, Validate(conf))
+}
+
+func TestValidateHealthLatencyThresholds(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.HealthConfig.Rate = []Rate{{Latency: []LatencyThreshold{{Degraded: 500, Failure: 2000, Quantile: 0.99}}}}
+	require.NoError(t, Validate(conf))
+
+	conf.HealthConfig.Rate[0].Latency[0].Quantile = 0
+	require.NoError(t, Validate(conf), "the default quantile is used")
+
+	conf.HealthConfig.Rate[0].Latency[0].Quantile = 99
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Rate[0].Latency[0] = LatencyThreshold{Degraded: 3000, Failure: 2000}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Rate[0].Latency[0] = LatencyThreshold{}
+	require.Error(t, Validate(conf))
+}
+
+func TestValidateSLOs(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.HealthConfig.SLO = []SLO{
+		{Name: "availability", Objective: 99.9, Type: SLOTypeAvailability},
+		{Name: "latency", Objective: 99, Type: SLOTypeLatency, LatencyThreshold: 500, Window: "7d"},
+	}
+	require.NoError(t, Validate(conf))
+
+	conf.HealthConfig.SLO[1].Name = "availability"
+	require.Error(t, Validate(conf), "SLO names are unique")
+
+	conf.HealthConfig.SLO[1] = SLO{Name: "latency", Objective: 99, Type: SLOTypeLatency}
+	require.Error(t, Validate(conf), "latency SLOs need a threshold")
+
+	conf.HealthConfig.SLO[1] = SLO{Name: "latency", Objective: 100, Type: SLOTypeAvailability}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.SLO[1] = SLO{Name: "latency", Objective: 99, Type: SLOTypeAvailability, Window: "month"}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.SLO[1] = SLO{Name: "latency", Objective: 99, Type: "throughput"}
+	require.Error(t, Validate(conf))
+}
+
+func TestValidateHealthHistory(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	require.NoError(t, Validate(conf))
+
+	conf.HealthConfig.History.MaxAge = "1d"
+	require.Error(t, Validate(conf), "max_age is a Go duration")
+
+	conf.HealthConfig.History.MaxAge = "12h"
+	conf.HealthConfig.History.MaxTransitions = 0
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.History.Enabled = false
+	require.NoError(t, Validate(conf))
+}
+
+func TestValidateHealthKubernetes(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	require.NoError(t, Validate(conf))
+
+	conf.HealthConfig.Kubernetes.RecentWindow = "0s"
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Kubernetes.RecentWindow = "10m"
+	conf.HealthConfig.Kubernetes.Restarts = HealthCountThresholds{Degraded: 5, Failure: 2}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Kubernetes.Restarts = HealthCountThresholds{Degraded: 0, Failure: 2}
+	conf.HealthConfig.Kubernetes.WarningEvents = HealthCountThresholds{Degraded: -1}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Kubernetes.Enabled = false
+	require.NoError(t, Validate(conf))
+}
+
+func TestValidateHealthMesh(t *testing.T) {
+	conf := NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	require.NoError(t, Validate(conf))
+
+	conf.HealthConfig.Mesh.XdsPushLatency = HealthDurationThresholds{Degraded: "10s", Failure: "1s"}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Mesh.XdsPushLatency = HealthDurationThresholds{Failure: "1s"}
+	require.NoError(t, Validate(conf))
+
+	conf.HealthConfig.Mesh.ProxyConvergence = HealthDurationThresholds{Degraded: "soon"}
+	require.Error(t, Validate(conf))
+
+	// Expiring sooner is worse
+	conf.HealthConfig.Mesh.ProxyConvergence = HealthDurationThresholds{}
+	conf.HealthConfig.Mesh.CertExpiry = HealthDurationThresholds{Degraded: "168h", Failure: "720h"}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Mesh.CertExpiry = HealthDurationThresholds{Degraded: "720h", Failure: "168h"}
+	conf.HealthConfig.Mesh.ConfigRejections = HealthCountThresholds{Degraded: -1}
+	require.Error(t, Validate(conf))
+
+	conf.HealthConfig.Mesh.Enabled = false
+	require.NoError(t, Validate(conf))
+}
+
+func TestValidateHealthAlerts(t *testing.T) {
+	newAlertsConfig := func() *Config {
+		conf := NewConfig()
+		conf.LoginToken.SigningKey = "kiali67890123456"
+		conf.HealthConfig.Alerts = HealthAlerts{
+			Enabled:   true,
+			Receivers: []HealthAlertReceiver{{Name: "slack", Type: HealthAlertReceiverSlack, URL: "https://hooks.slack.com/services/x"}},
+			Rules:     []HealthAlertRule{{Name: "prod", For: "5m", Receivers: []string{"slack"}, Selector: HealthAlertSelector{NamespaceLabels: "env=prod"}}},
+			Silences:  []HealthAlertSilence{{EndsAt: "2026-01-01T00:00:00Z", Rules: []string{"prod"}}},
+		}
+		return conf
+	}
+	require.NoError(t, Validate(newAlertsConfig()))
+
+	cases := map[string]func(*HealthAlerts){
+		"negative repeat interval": func(a *HealthAlerts) { a.RepeatInterval = "-1h" },
+		"duplicate receiver":       func(a *HealthAlerts) { a.Receivers = append(a.Receivers, a.Receivers[0]) },
+		"unknown receiver type":    func(a *HealthAlerts) { a.Receivers[0].Type = "email" },
+		"no receiver url":          func(a *HealthAlerts) { a.Receivers[0].URL = "" },
+		"duplicate rule":           func(a *HealthAlerts) { a.Rules = append(a.Rules, a.Rules[0]) },
+		"invalid for":              func(a *HealthAlerts) { a.Rules[0].For = "soon" },
+		"unknown severity":         func(a *HealthAlerts) { a.Rules[0].Severity = "info" },
+		"unknown rule receiver":    func(a *HealthAlerts) { a.Rules[0].Receivers = []string{"pagerduty"} },
+		"invalid name regex":       func(a *HealthAlerts) { a.Rules[0].Selector.Name = "reviews-[" },
+		"invalid label selector":   func(a *HealthAlerts) { a.Rules[0].Selector.NamespaceLabels = "env in prod" },
+		"invalid silence end":      func(a *HealthAlerts) { a.Silences[0].EndsAt = "tomorrow" },
+		"silence ends before start": func(a *HealthAlerts) {
+			a.Silences[0].StartsAt = "2026-01-02T00:00:00Z"
+		},
+		"unknown silence rule": func(a *HealthAlerts) { a.Silences[0].Rules = []string{"staging"} },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			conf := newAlertsConfig()
+			mutate(&conf.HealthConfig.Alerts)
+			require.Error(t, Validate(conf))
+
+			conf.HealthConfig.Alerts.Enabled = false
+			require.NoError(t, Validate(conf))
+		})
+	}
+}
+
+func TestValidateOpenIdClusters(t *testing.T) {
+	newOpenIdConfig := func() *Config {
+		conf := NewConfig()
+		conf.LoginToken.SigningKey = "kiali67890123456"
+		conf.Auth.Strategy = AuthStrategyOpenId
+		conf.KubernetesConfig.ClusterName = "east"
+		conf.Auth.OpenId.Clusters = map[string]OpenIdClusterConfig{
+			"west": {ClientId: "kiali-west", IssuerUri: "https://west.example.com", Mode: OpenIdClusterModeTokenExchange},
+		}
+		return conf
+	}
+	require.NoError(t, Validate(newOpenIdConfig()))
+
+	cases := map[string]func(*OpenIdClusterConfig){
+		"unknown mode":      func(c *OpenIdClusterConfig) { c.Mode = "shared" },
+		"no client id":      func(c *OpenIdClusterConfig) { c.ClientId = "" },
+		"invalid api token": func(c *OpenIdClusterConfig) { c.ApiToken = "refresh_token" },
+		"no issuer":         func(c *OpenIdClusterConfig) { c.IssuerUri = "" },
+		"login without issuer": func(c *OpenIdClusterConfig) {
+			c.IssuerUri, c.Mode, c.TokenEndpoint = "", OpenIdClusterModeLogin, "https://west/token"
+		},
+	}
+	for name, modify := range cases {
+		t.Run(name, func(t *testing.T) {
+			conf := newOpenIdConfig()
+			cluster := conf.Auth.OpenId.Clusters["west"]
+			modify(&cluster)
+			conf.Auth.OpenId.Clusters["west"] = cluster
+			require.Error(t, Validate(conf))
+		})
+	}
+
+	conf := newOpenIdConfig()
+	conf.Auth.OpenId.Clusters["east"] = conf.Auth.OpenId.Clusters["west"]
+	require.Error(t, Validate(conf), "the home cluster uses the Kiali login")
+
+	conf = newOpenIdConfig()
+	conf.Auth.OpenId.DisableRBAC = true
+	require.Error(t, Validate(conf))
+
+	// Only explicit endpoints
+	conf = newOpenIdConfig()
+	conf.Auth.OpenId.Clusters["west"] = OpenIdClusterConfig{ClientId: "kiali-west", Mode: OpenIdClusterModeTokenExchange, TokenEndpoint: "https://west/token"}
+	require.NoError(t, Validate(conf))
+}
+
+func TestValidateKialiRBAC(t *testing.T) {
+	newRBACConfig := func() *Config {
+		conf := NewConfig()
+		conf.LoginToken.SigningKey = "kiali67890123456"
+		conf.Auth.KialiRBAC = KialiRBACConfig{
+			DefaultRoles: []string{"viewer"},
+			Enabled:      true,
+			RoleBindings: []KialiRoleBinding{{Groups: []string{"mesh-admins"}, Role: "admin"}},
+			Roles: []KialiRole{
+				{Name: "admin", Capabilities: []string{KialiCapabilityAll}},
+				{Name: "viewer", Capabilities: []string{KialiCapabilityViewGraph}},
+			},
+		}
+		return conf
+	}
+	require.NoError(t, Validate(newRBACConfig()))
+
+	cases := map[string]func(*Config){
+		"unnamed role":         func(c *Config) { c.Auth.KialiRBAC.Roles[0].Name = "" },
+		"duplicated role":      func(c *Config) { c.Auth.KialiRBAC.Roles[1].Name = "admin" },
+		"unknown capability":   func(c *Config) { c.Auth.KialiRBAC.Roles[1].Capabilities = []string{"delete-cluster"} },
+		"unknown default role": func(c *Config) { c.Auth.KialiRBAC.DefaultRoles = []string{"operator"} },
+		"unknown bound role":   func(c *Config) { c.Auth.KialiRBAC.RoleBindings[0].Role = "operator" },
+		"binding without subjects": func(c *Config) {
+			c.Auth.KialiRBAC.RoleBindings[0].Groups = nil
+		},
+	}
+	for name, configure := range cases {
+		t.Run(name, func(t *testing.T) {
+			conf := newRBACConfig()
+			configure(conf)
+			require.Error(t, Validate(conf))
+		})
+	}
+
+	// The roles are not validated while disabled
+	conf := newRBACConfig()
+	conf.Auth.KialiRBAC.Enabled = false
+	conf.Auth.KialiRBAC.DefaultRoles = []string{"operator"}
+	require.NoError(t, Validate(conf))
+}
+
 func TestValidateTLSConfigSource(t *testing.T) {
 	conf := NewConfig()
 	conf.LoginToken.SigningKey = Credential(util.RandomString(16))
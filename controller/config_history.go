@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+)
+
+// WatchConfigHistory records in the config history the changes made to the Istio config by other tools,
+// as observed by the informers of the kube caches. Changes made through Kiali are observed too but they
+// are recorded with the same resourceVersion so the cache keeps a single revision for them.
+// Types whose CRD is not installed in a cluster are skipped.
+func WatchConfigHistory(ctx context.Context, kubeCaches map[string]ctrlcache.Cache, kialiCache cache.KialiCache) error {
+	for cluster, kubeCache := range kubeCaches {
+		for _, gvk := range kubernetes.ResourceTypesToAPI {
+			obj, err := kubernetes.Scheme.New(gvk)
+			if err != nil {
+				return fmt.Errorf("unable to watch the config history of %s: %w", gvk, err)
+			}
+			clientObj, ok := obj.(client.Object)
+			if !ok {
+				return fmt.Errorf("unable to watch the config history of %s: not a client.Object", gvk)
+			}
+
+			informer, err := kubeCache.GetInformer(ctx, clientObj, ctrlcache.BlockUntilSynced(false))
+			if err != nil {
+				log.Debugf("Not watching the config history of %s in cluster [%s]: %s", gvk.Kind, cluster, err)
+				continue
+			}
+
+			if _, err := informer.AddEventHandler(NewConfigHistoryEventHandler(cluster, gvk, kialiCache)); err != nil {
+				return fmt.Errorf("unable to watch the config history of %s in cluster [%s]: %w", gvk.Kind, cluster, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NewConfigHistoryEventHandler returns the informer event handler recording the previous version of
+// the objects of the given type on every update and delete.
+func NewConfigHistoryEventHandler(cluster string, gvk schema.GroupVersionKind, kialiCache cache.KialiCache) toolscache.ResourceEventHandler {
+	record := func(obj client.Object, operation string) {
+		revision, err := models.NewIstioConfigRevision(gvk, obj, operation, models.ConfigRevisionSourceExternal)
+		if err != nil {
+			log.Errorf("Unable to record the revision of %s [%s/%s]: %s", gvk.Kind, obj.GetNamespace(), obj.GetName(), err)
+			return
+		}
+		kialiCache.AddConfigRevision(models.IstioConfigRevisionKey{
+			Cluster:   cluster,
+			Namespace: obj.GetNamespace(),
+			ObjectGVK: gvk,
+			Name:      obj.GetName(),
+		}, revision)
+	}
+
+	return toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj any) {
+			oldClientObj, ok := oldObj.(client.Object)
+			if !ok {
+				return
+			}
+			newClientObj, ok := newObj.(client.Object)
+			if !ok {
+				return
+			}
+			// Resyncs and status updates do not change the desired state of the object.
+			oldRevision, err := models.RevisionObject(gvk, oldClientObj)
+			if err != nil {
+				return
+			}
+			newRevision, err := models.RevisionObject(gvk, newClientObj)
+			if err != nil || string(oldRevision) == string(newRevision) {
+				return
+			}
+			record(oldClientObj, models.ConfigRevisionOperationUpdate)
+		},
+		DeleteFunc: func(obj any) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if clientObj, ok := obj.(client.Object); ok {
+				record(clientObj, models.ConfigRevisionOperationDelete)
+			}
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	api_networking_v1 "istio.io/api/networking/v1"
+	networking_v1 "istio.io/client-go/pkg/apis/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/controller"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+)
+
+func TestConfigHistoryEventHandler(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	kialiCache := cache.NewTestingCache(t, kubetest.NewFakeK8sClient(), *conf)
+	handler := controller.NewConfigHistoryEventHandler("east", kubernetes.VirtualServices, kialiCache)
+
+	vs := func(resourceVersion string, hosts ...string) *networking_v1.VirtualService {
+		return &networking_v1.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{Name: "reviews", Namespace: "bookinfo", ResourceVersion: resourceVersion},
+			Spec:       api_networking_v1.VirtualService{Hosts: hosts},
+		}
+	}
+
+	// Resyncs and status updates are not revisions
+	handler.OnUpdate(vs("1", "reviews"), vs("1", "reviews"))
+	handler.OnUpdate(vs("1", "reviews"), vs("2", "reviews"))
+	handler.OnUpdate(vs("2", "reviews"), vs("3", "ratings"))
+	handler.OnDelete(toolscache.DeletedFinalStateUnknown{Key: "bookinfo/reviews", Obj: vs("3", "ratings")})
+
+	revisions := kialiCache.GetConfigRevisions(models.IstioConfigRevisionKey{Cluster: "east", Namespace: "bookinfo", ObjectGVK: kubernetes.VirtualServices, Name: "reviews"})
+	require.Len(revisions, 2)
+	require.Equal("2", revisions[0].ResourceVersion)
+	require.Equal(models.ConfigRevisionOperationUpdate, revisions[0].Operation)
+	require.Equal(models.ConfigRevisionSourceExternal, revisions[0].Source)
+	require.Contains(string(revisions[0].Object), `"hosts":["reviews"]`)
+	require.Equal(models.ConfigRevisionOperationDelete, revisions[1].Operation)
+	require.Contains(string(revisions[1].Object), `"hosts":["ratings"]`)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	config_common "github.com/kiali/kiali/graph/config/common"
+	"github.com/kiali/kiali/handlers"
 	"github.com/kiali/kiali/handlers/authentication"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/models"
@@ -15,6 +16,31 @@ import (
 // - keep this alphabetized
 /////////////////////
 
+// Attributes of a new API token
+// swagger:parameters apiTokenCreate
+type APITokenBody struct {
+	// in: body
+	Body authentication.APITokenRequest
+}
+
+// swagger:parameters apiTokenRevoke
+type APITokenIDParam struct {
+	// The ID of the API token.
+	//
+	// in: path
+	// required: true
+	Name string `json:"id"`
+}
+
+// swagger:parameters sessionRevoke
+type SessionIDParam struct {
+	// The ID of the session.
+	//
+	// in: path
+	// required: true
+	Name string `json:"id"`
+}
+
 // swagger:parameters aggregateMetrics graphAggregate graphAggregateByService
 type AggregateParam struct {
 	// The aggregate name (label).
@@ -124,7 +150,7 @@ type DurationLogParam struct {
 	Name string `json:"duration"`
 }
 
-// swagger:parameters istioConfigCreate istioConfigDetails istioConfigDelete istioConfigUpdate
+// swagger:parameters istioConfigCreate istioConfigDetails istioConfigDelete istioConfigUpdate istioConfigRevisions istioConfigRollback
 type GVKGroupParam struct {
 	// The GVK group in a group/value/kind specification.
 	//
@@ -133,7 +159,7 @@ type GVKGroupParam struct {
 	Name string `json:"group"`
 }
 
-// swagger:parameters istioConfigCreate istioConfigDetails istioConfigDelete istioConfigUpdate
+// swagger:parameters istioConfigCreate istioConfigDetails istioConfigDelete istioConfigUpdate istioConfigRevisions istioConfigRollback
 type GVKKindParam struct {
 	// The GVK kind in a group/value/kind specification.
 	//
@@ -142,7 +168,7 @@ type GVKKindParam struct {
 	Name string `json:"kind"`
 }
 
-// swagger:parameters istioConfigCreate istioConfigDetails istioConfigDelete istioConfigUpdate
+// swagger:parameters istioConfigCreate istioConfigDetails istioConfigDelete istioConfigUpdate istioConfigRevisions istioConfigRollback
 type GVKVersionParam struct {
 	// The GVK version in a group/value/kind specification.
 	//
@@ -151,6 +177,35 @@ type GVKVersionParam struct {
 	Name string `json:"version"`
 }
 
+// swagger:parameters istioConfigRollback
+type IstioConfigRevisionParam struct {
+	// The number of the revision to restore.
+	//
+	// in: path
+	// required: true
+	Name int `json:"revision"`
+}
+
+// swagger:parameters istioConfigRollback
+type IstioConfigRollbackDryRunParam struct {
+	// Only validate the rollback with a server side dry run and return the resulting object, without applying it.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"dryRun"`
+}
+
+// swagger:parameters istioConfigCreate istioConfigDelete istioConfigUpdate
+type IstioConfigClustersParam struct {
+	// Comma separated list of the clusters to perform the operation in, instead of the single clusterName.
+	// The operation is performed in every cluster and the per cluster results are returned.
+	//
+	// in: query
+	// required: false
+	Name string `json:"clusters"`
+}
+
 // swagger:parameters podProxyLogging
 type LoggingParam struct {
 	// The log level for the pod's proxy.
@@ -160,7 +215,7 @@ type LoggingParam struct {
 	Level ProxyLogLevel `json:"level"`
 }
 
-// swagger:parameters istioConfigList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging namespaceInfo controlPlaneMetrics ztunnelDashboard ztunnelConfigDump usageMetrics
+// swagger:parameters istioConfigList workloadDetails workloadUpdate serviceDetails serviceUpdate appSpans serviceSpans workloadSpans appTraces serviceTraces workloadTraces errorTraces workloadValidations serviceMetrics aggregateMetrics appMetrics workloadMetrics istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype appDetails graphAggregate graphAggregateByService graphApp graphAppVersion graphNamespace graphService graphWorkload namespaceMetrics customDashboard appDashboard serviceDashboard workloadDashboard istioConfigCreate istioConfigCreateSubtype namespaceUpdate namespaceTls podDetails podLogs namespaceValidations podProxyDump podProxyResource podProxyLogging namespaceInfo controlPlaneMetrics ztunnelDashboard ztunnelConfigDump usageMetrics authorizationSimulate routeResolve istioConfigRevisions istioConfigRollback trafficTemplate canaryStart canaryList canaryGet canaryCancel istioConfigExport gatewayAPIConversion sidecarRecommendation namespaceHealthHistory
 type NamespacePathParam struct {
 	// The namespace name.
 	//
@@ -178,7 +233,7 @@ type NamespaceQueryParam struct {
 	Name string `json:"namespace"`
 }
 
-// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype
+// swagger:parameters istioConfigDetails istioConfigDetailsSubtype istioConfigDelete istioConfigDeleteSubtype istioConfigUpdate istioConfigUpdateSubtype istioConfigRevisions istioConfigRollback
 type ObjectNameParam struct {
 	// The Istio object name.
 	//
@@ -214,7 +269,7 @@ type ResourceParam struct {
 	Name string `json:"resource"`
 }
 
-// swagger:parameters serviceDetails serviceUpdate serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces
+// swagger:parameters serviceDetails serviceUpdate serviceMetrics graphService graphAggregateByService serviceDashboard serviceSpans serviceTraces trafficTemplate canaryStart canaryList canaryGet canaryCancel
 type ServiceParam struct {
 	// The service name.
 	//
@@ -223,6 +278,165 @@ type ServiceParam struct {
 	Name string `json:"service"`
 }
 
+// swagger:parameters trafficTemplate
+type TrafficTemplateParam struct {
+	// The traffic template: traffic_shifting, fault_injection or request_timeouts.
+	//
+	// in: path
+	// required: true
+	Name string `json:"template"`
+}
+
+// swagger:parameters trafficTemplate
+type TrafficTemplateApplyParam struct {
+	// Apply the generated objects to the cluster instead of only returning them. They are not applied when a validation fails.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"apply"`
+}
+
+// Parameters of a traffic template
+// swagger:parameters trafficTemplate
+type TrafficTemplateBody struct {
+	// in: body
+	Body models.TrafficTemplateRequest
+}
+
+// swagger:parameters gatewayAPIConversion
+type GatewayAPIConversionApplyParam struct {
+	// Apply the converted objects to the cluster instead of only returning them. They are not applied when a validation fails.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"apply"`
+}
+
+// Objects to convert to the Gateway API
+// swagger:parameters gatewayAPIConversion
+type GatewayAPIConversionBody struct {
+	// in: body
+	Body models.GatewayAPIConversionRequest
+}
+
+// swagger:parameters sidecarRecommendation
+type SidecarRecommendationDurationParam struct {
+	// Window of the observed traffic.
+	//
+	// in: query
+	// required: false
+	// default: 1h
+	Name string `json:"duration"`
+}
+
+// swagger:parameters sidecarRecommendation
+type SidecarRecommendationScopeParam struct {
+	// Recommend one Sidecar for the namespace, or one Sidecar per workload. Defaults to workload when a workload is selected, to namespace otherwise.
+	//
+	// in: query
+	// required: false
+	// enum: namespace,workload
+	Name string `json:"scope"`
+}
+
+// swagger:parameters sidecarRecommendation
+type SidecarRecommendationWorkloadParam struct {
+	// Restrict a workload scoped recommendation to this workload.
+	//
+	// in: query
+	// required: false
+	Name string `json:"workload"`
+}
+
+// swagger:parameters namespaceHealthHistory
+type HealthHistoryTypeParam struct {
+	// The type of the entities: app, service or workload.
+	//
+	// in: query
+	Name string `json:"type"`
+}
+
+// swagger:parameters namespaceHealthHistory
+type HealthHistoryNameParam struct {
+	// The name of the entity.
+	//
+	// in: query
+	Name string `json:"name"`
+}
+
+// swagger:parameters istioConfigExport
+type IstioConfigExportFormatParam struct {
+	// Format of the export: helm or kustomize.
+	//
+	// in: query
+	// required: true
+	Name string `json:"format"`
+}
+
+// swagger:parameters istioConfigExport
+type IstioConfigExportClustersParam struct {
+	// Comma separated list of the clusters the configuration is exported to, each one getting its own values file
+	// or overlay. Defaults to the cluster the configuration is exported from.
+	//
+	// in: query
+	// required: false
+	Name string `json:"clusters"`
+}
+
+// swagger:parameters istioConfigExport
+type IstioConfigExportChartNameParam struct {
+	// Name of the Helm chart, and root directory of the archive. Defaults to the namespace.
+	//
+	// in: query
+	// required: false
+	Name string `json:"chartName"`
+}
+
+// swagger:parameters istioConfigExport
+type IstioConfigExportArchiveParam struct {
+	// Return the files in a gzipped tarball instead of JSON.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"archive"`
+}
+
+// Istio objects selected by a bulk operation and the operation applied to them
+// swagger:parameters istioConfigBulk
+type IstioConfigBulkBody struct {
+	// in: body
+	Body models.IstioConfigBulkRequest
+}
+
+// swagger:parameters istioConfigBulk
+type IstioConfigBulkDryRunParam struct {
+	// Only preview the operation: list the matching objects and the validations that would change, without changing them.
+	//
+	// in: query
+	// required: false
+	// default: false
+	Name bool `json:"dryRun"`
+}
+
+// swagger:parameters canaryGet canaryCancel
+type CanaryRunParam struct {
+	// The ID of the canary run.
+	//
+	// in: path
+	// required: true
+	Name string `json:"run"`
+}
+
+// Parameters of a canary run
+// swagger:parameters canaryStart
+type CanaryBody struct {
+	// in: body
+	Body models.CanaryRequest
+}
+
 // swagger:parameters podLogs
 type SinceTimeParam struct {
 	// The start time for fetching logs. UNIX time in seconds. Default is all logs.
@@ -241,7 +455,7 @@ type TraceIDParam struct {
 	Name string `json:"traceID"`
 }
 
-// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces ztunnelDashboard
+// swagger:parameters workloadDetails workloadUpdate workloadValidations workloadMetrics graphWorkload workloadDashboard workloadSpans workloadTraces ztunnelDashboard authorizationSimulate routeResolve
 type WorkloadParam struct {
 	// The workload name.
 	//
@@ -383,6 +597,102 @@ type ThroughputParam struct {
 	Name string `json:"throughput"`
 }
 
+/////////////////////
+// SWAGGER PARAMETERS - AUDIT
+// - keep this alphabetized
+/////////////////////
+
+// swagger:parameters auditSearch
+type AuditClusterParam struct {
+	// Returns only the records of objects in this cluster.
+	//
+	// in: query
+	// required: false
+	Name string `json:"clusterName"`
+}
+
+// swagger:parameters auditSearch
+type AuditEndTimeParam struct {
+	// Returns only the records created at or before this Unix time, in seconds.
+	//
+	// in: query
+	// required: false
+	Name int64 `json:"endTime"`
+}
+
+// swagger:parameters auditSearch
+type AuditKindParam struct {
+	// Returns only the records of objects of this kind.
+	//
+	// in: query
+	// required: false
+	Name string `json:"kind"`
+}
+
+// swagger:parameters auditSearch
+type AuditLimitParam struct {
+	// Maximum number of records returned.
+	//
+	// in: query
+	// required: false
+	// default: 100
+	Name int `json:"limit"`
+}
+
+// swagger:parameters auditSearch
+type AuditNameParam struct {
+	// Returns only the records of objects with this name.
+	//
+	// in: query
+	// required: false
+	Name string `json:"name"`
+}
+
+// swagger:parameters auditSearch
+type AuditNamespaceParam struct {
+	// Returns only the records of objects in this namespace.
+	//
+	// in: query
+	// required: false
+	Name string `json:"namespace"`
+}
+
+// swagger:parameters auditSearch
+type AuditOperationParam struct {
+	// Returns only the records of this operation: CREATE, UPDATE or DELETE.
+	//
+	// in: query
+	// required: false
+	Name string `json:"operation"`
+}
+
+// swagger:parameters auditSearch
+type AuditResultParam struct {
+	// Returns only the records with this result: success or failure.
+	//
+	// in: query
+	// required: false
+	Name string `json:"result"`
+}
+
+// swagger:parameters auditSearch
+type AuditStartTimeParam struct {
+	// Returns only the records created at or after this Unix time, in seconds.
+	//
+	// in: query
+	// required: false
+	Name int64 `json:"startTime"`
+}
+
+// swagger:parameters auditSearch
+type AuditUserParam struct {
+	// Returns only the records of operations requested by this user.
+	//
+	// in: query
+	// required: false
+	Name string `json:"user"`
+}
+
 /////////////////////
 // SWAGGER PARAMETERS - METRICS
 // - keep this alphabetized
@@ -568,6 +878,34 @@ type NotFoundError struct {
 	} `json:"body"`
 }
 
+// A ForbiddenError is the error message that means the user is not allowed to perform the request
+//
+// swagger:response forbiddenError
+type ForbiddenError struct {
+	// in: body
+	Body struct {
+		// HTTP status code
+		// example: 403
+		// default: 403
+		Code    int32 `json:"code"`
+		Message error `json:"message"`
+	} `json:"body"`
+}
+
+// A ConflictError is the error message that means the request conflicts with the current state of the target
+//
+// swagger:response conflictError
+type ConflictError struct {
+	// in: body
+	Body struct {
+		// HTTP status code
+		// example: 409
+		// default: 409
+		Code    int32 `json:"code"`
+		Message error `json:"message"`
+	} `json:"body"`
+}
+
 // A NotAcceptable is the error message that means request can't be accepted
 //
 // swagger:response notAcceptableError
@@ -729,6 +1067,139 @@ type IstioConfigDetailsResponse struct {
 	Body models.IstioConfigDetails
 }
 
+// Result of evaluating a request against the AuthorizationPolicies of a workload
+// swagger:response authorizationSimulationResponse
+type AuthorizationSimulationResponse struct {
+	// in:body
+	Body models.AuthorizationSimulationResult
+}
+
+// Effective route of a request sent by a workload
+// swagger:response routeResolutionResponse
+type RouteResolutionResponse struct {
+	// in:body
+	Body models.RouteResolutionResult
+}
+
+// Revision history of an Istio object
+// swagger:response istioConfigRevisionsResponse
+type IstioConfigRevisionsResponse struct {
+	// in:body
+	Body models.IstioConfigRevisions
+}
+
+// Per cluster results of an Istio config write operation performed in several clusters
+// swagger:response istioConfigMultiClusterResponse
+type IstioConfigMultiClusterResponse struct {
+	// in:body
+	Body models.IstioConfigMultiClusterResult
+}
+
+// Istio config generated by a traffic template for a service, with its validations
+// swagger:response trafficTemplateResponse
+type TrafficTemplateResponse struct {
+	// in:body
+	Body models.TrafficTemplateResult
+}
+
+// Istio config of a namespace exported as a Helm chart or a Kustomize base with per-cluster overlays
+// swagger:response istioConfigExportResponse
+type IstioConfigExportResponse struct {
+	// in:body
+	Body models.IstioConfigExport
+}
+
+// Timeline of the health transitions of the entities of a namespace
+// swagger:response healthTimelineResponse
+type HealthTimelineResponse struct {
+	// in:body
+	Body models.HealthTimeline
+}
+
+// Health of the mesh infrastructure components
+// swagger:response meshHealthResponse
+type MeshHealthResponse struct {
+	// in:body
+	Body []models.MeshComponentHealth
+}
+
+// Prometheus recording and alerting rules of the SLOs of the health config, as YAML
+// swagger:response sloRulesResponse
+type SLORulesResponse struct {
+	// in:body
+	Body models.PrometheusRuleGroups
+}
+
+// Gateway API config converted from the Ingresses and Istio config of a namespace, with its issues and validations
+// swagger:response gatewayAPIConversionResponse
+type GatewayAPIConversionResponse struct {
+	// in:body
+	Body models.GatewayAPIConversion
+}
+
+// Sidecars restricting the egress of the workloads of a namespace to the destinations they called
+// swagger:response sidecarRecommendationResponse
+type SidecarRecommendationResponse struct {
+	// in:body
+	Body models.SidecarRecommendation
+}
+
+// Istio objects matching a bulk operation with the outcome of the operation
+// swagger:response istioConfigBulkResponse
+type IstioConfigBulkResponse struct {
+	// in:body
+	Body models.IstioConfigBulkResult
+}
+
+// Status and history of a canary run
+// swagger:response canaryRunResponse
+type CanaryRunResponse struct {
+	// in:body
+	Body models.CanaryRun
+}
+
+// Canary runs of a service
+// swagger:response canaryRunListResponse
+type CanaryRunListResponse struct {
+	// in:body
+	Body []models.CanaryRun
+}
+
+// Istio config change proposed to the GitOps repository
+// swagger:response gitOpsChangeResponse
+type GitOpsChangeResponse struct {
+	// in:body
+	Body models.GitOpsChange
+}
+
+// API token created for the user, including the token itself which is not returned again
+// swagger:response apiTokenResponse
+type APITokenResponse struct {
+	// in:body
+	Body handlers.APITokenResponse
+}
+
+// API tokens of the user, without their secrets
+// swagger:response apiTokensResponse
+type APITokensResponse struct {
+	// in:body
+	Body []authentication.APIToken
+}
+
+// Active login sessions, with the resources Kiali keeps for them
+// swagger:response activeSessionsResponse
+type ActiveSessionsResponse struct {
+	// in:body
+	Body []handlers.ActiveSessionResponse
+}
+
+// Audit records of the write operations performed through Kiali
+// swagger:response auditRecordsResponse
+type AuditRecordsResponse struct {
+	// in:body
+	Body models.AuditRecords
+}
+
 // Detailed information of an specific app
 // swagger:response appDetails
 type AppDetailsResponse struct {
@@ -101,11 +101,12 @@ class AuthenticationControllerComponent extends React.Component<
     } else {
       let dispatchLoginCycleOnLoad = false;
 
-      // If login strategy is "anonymous" or "header", dispatch login cycle
+      // If login strategy is "anonymous", "header" or "x509", dispatch login cycle
       // because there is no need to ask for any credentials
       if (
         authenticationConfig.strategy === AuthStrategy.anonymous ||
-        authenticationConfig.strategy === AuthStrategy.header
+        authenticationConfig.strategy === AuthStrategy.header ||
+        authenticationConfig.strategy === AuthStrategy.x509
       ) {
         dispatchLoginCycleOnLoad = true;
       }
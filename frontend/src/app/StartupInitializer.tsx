@@ -43,6 +43,13 @@ class InitializerComponent extends React.Component<InitializerComponentProps, In
       authenticationConfig.logoutEndpoint = authConfig.data.logoutEndpoint;
       authenticationConfig.logoutRedirect = authConfig.data.logoutRedirect;
       authenticationConfig.strategy = authConfig.data.strategy;
+      authenticationConfig.kialiRBACEnabled = authConfig.data.kialiRBACEnabled;
+      authenticationConfig.clusterCapabilities = {};
+      Object.values(authConfig.data.sessionInfo.clusterInfo ?? {}).forEach(clusterInfo => {
+        if (clusterInfo.capabilities) {
+          authenticationConfig.clusterCapabilities![clusterInfo.name] = clusterInfo.capabilities;
+        }
+      });
 
       if (authConfig.data.sessionInfo.expiresOn && authConfig.data.sessionInfo.username) {
         const intialInfo = {
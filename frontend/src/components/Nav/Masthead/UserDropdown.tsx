@@ -158,7 +158,9 @@ class UserDropdownComponent extends React.Component<UserProps, UserState> {
       this.props.session?.clusterInfo?.[cluster] !== undefined;
 
     const canLogout =
-      authenticationConfig.strategy !== AuthStrategy.anonymous && authenticationConfig.strategy !== AuthStrategy.header;
+      authenticationConfig.strategy !== AuthStrategy.anonymous &&
+      authenticationConfig.strategy !== AuthStrategy.header &&
+      authenticationConfig.strategy !== AuthStrategy.x509;
 
     // We want to show a dropdown per cluster the user is not logged into yet.
     // The clusters you are logged into are in session.clusterInfo and all
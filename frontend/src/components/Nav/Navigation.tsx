@@ -20,6 +20,8 @@ import {
 
 import { kialiStyle } from 'styles/StyleUtils';
 import { homeCluster, kialiLogoDark, kialiLogoLight, serverConfig } from '../../config';
+import { hasKialiCapability } from '../../config/AuthenticationConfig';
+import { KialiCapability } from '../../types/Auth';
 import type { KialiAppState } from '../../store/Store';
 import { UserSettingsThunkActions } from '../../actions/UserSettingsThunkActions';
 import { Menu } from './Menu';
@@ -163,7 +165,7 @@ export const NavigationComponent: React.FC<NavigationProps> = (props: Navigation
       <PageSection hasBodyWrapper={false} className={flexBoxColumnStyle}>
         <RenderPage isGraph={isGraph()} />
       </PageSection>
-      {!kioskMode && props.chatbotEnabled && <ChatBot />}
+      {!kioskMode && props.chatbotEnabled && hasKialiCapability(KialiCapability.useAIChat) && <ChatBot />}
     </Page>
   );
 };
@@ -43,10 +43,12 @@ export const PFBadges: { [key: string]: PFBadgeType } = Object.freeze({
   Gateway: { badge: 'G', tt: 'Gateway' } as PFBadgeType,
   Grafana: { badge: 'GR', tt: 'Grafana' } as PFBadgeType,
   HTTPRoute: { badge: 'HTTP', tt: 'HTTPRoute' } as PFBadgeType,
+  K8sBackendTLSPolicy: { badge: 'BTLS', tt: 'BackendTLSPolicy (K8s)' } as PFBadgeType,
   K8sGateway: { badge: 'G', tt: 'Gateway (K8s)' } as PFBadgeType,
   K8sGRPCRoute: { badge: 'gRPC', tt: 'GRPCRoute (K8s)' } as PFBadgeType,
   K8sHTTPRoute: { badge: 'HTTP', tt: 'HTTPRoute (K8s)' } as PFBadgeType,
   K8sInferencePool: { badge: 'IP', tt: 'InferencePool (K8s)' } as PFBadgeType,
+  K8sListenerSet: { badge: 'LS', tt: 'ListenerSet (K8s)' } as PFBadgeType,
   K8sReferenceGrant: { badge: 'RG', tt: 'ReferenceGrant (K8s)' } as PFBadgeType,
   K8sTCPRoute: { badge: 'TCP', tt: 'TCPRoute (K8s)' } as PFBadgeType,
   K8sTLSRoute: { badge: 'TLS', tt: 'TLSRoute (K8s)' } as PFBadgeType,
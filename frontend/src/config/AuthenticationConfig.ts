@@ -1,4 +1,5 @@
-import { AuthConfig, AuthStrategy } from '../types/Auth';
+import { AuthConfig, AuthStrategy, KialiCapability } from '../types/Auth';
+import { homeCluster } from './ServerConfig';
 
 export const authenticationConfig: AuthConfig = {
   strategy: AuthStrategy.token
@@ -7,3 +8,22 @@ export const authenticationConfig: AuthConfig = {
 // Returns true if authentication strategy is either 'openshift' or 'openid'
 export const isAuthStrategyOAuth = () =>
   authenticationConfig.strategy === AuthStrategy.openshift || authenticationConfig.strategy === AuthStrategy.openid;
+
+// Returns true if the Kiali roles grant the capability in the namespace of the cluster (the home cluster by default).
+// Without a namespace, the capability must be granted in all the namespaces. All the capabilities are granted when
+// the Kiali roles are disabled.
+export const hasKialiCapability = (capability: KialiCapability, cluster?: string, namespace?: string): boolean => {
+  if (!authenticationConfig.kialiRBACEnabled) {
+    return true;
+  }
+
+  const clusterCapabilities = authenticationConfig.clusterCapabilities?.[cluster ?? homeCluster?.name ?? ''];
+  if (!clusterCapabilities) {
+    return false;
+  }
+
+  return (
+    clusterCapabilities.capabilities.includes(capability) ||
+    (namespace !== undefined && !!clusterCapabilities.namespaces?.[namespace]?.includes(capability))
+  );
+};
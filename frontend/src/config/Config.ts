@@ -156,6 +156,7 @@ const conf = {
       istioStatus: () => 'api/istio/status',
       logout: 'api/logout',
       meshGraph: 'api/mesh/graph',
+      meshHealth: 'api/mesh/health',
       meshTls: () => 'api/mesh/tls',
       metricsStats: 'api/stats/metrics',
       namespace: (namespace: string) => `api/namespaces/${namespace}`,
@@ -118,6 +118,10 @@ export const istioTypeFilter: FilterType = {
       id: 'Gateway',
       title: 'Gateway'
     },
+    {
+      id: 'K8sBackendTLSPolicy',
+      title: 'K8sBackendTLSPolicy'
+    },
     {
       id: 'K8sGateway',
       title: 'K8sGateway'
@@ -134,6 +138,10 @@ export const istioTypeFilter: FilterType = {
       id: 'K8sInferencePool',
       title: 'K8sInferencePool'
     },
+    {
+      id: 'K8sListenerSet',
+      title: 'K8sListenerSet'
+    },
     {
       id: 'K8sReferenceGrant',
       title: 'K8sReferenceGrant'
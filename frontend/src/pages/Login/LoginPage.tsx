@@ -96,7 +96,10 @@ export class LoginPageComponent extends React.Component<LoginProps, LoginState>
     if (isAuthStrategyOAuth()) {
       // If we are using OpenShift or OpenId strategy, take the user back to the authorization endpoint
       window.location.href = authenticationConfig.authorizationEndpoint!;
-    } else if (authenticationConfig.strategy === AuthStrategy.header) {
+    } else if (
+      authenticationConfig.strategy === AuthStrategy.header ||
+      authenticationConfig.strategy === AuthStrategy.x509
+    ) {
       window.location.href = webRoot;
     } else if (authenticationConfig.strategy === AuthStrategy.token) {
       if (this.state.password.trim().length !== 0 && this.props.authenticate) {
@@ -119,7 +119,12 @@ export const renderHealthStatus = (data: MeshNodeData): React.ReactNode => {
           aria-label={t('Health status')}
           position={TooltipPosition.right}
           enableFlip={true}
-          content={<>{t(statusMsg[data.healthData])}</>}
+          content={
+            <>
+              {t(statusMsg[data.healthData])}
+              {data.infraHealth?.status.reason && <div>{data.infraHealth.status.reason}</div>}
+            </>
+          }
         >
           <span className={healthStatusStyle}>
             <Validation severity={healthSeverity} />
@@ -57,6 +57,8 @@ import { KioskElement } from '../../components/Kiosk/KioskElement';
 import { TimeDurationModal } from '../../components/Time/TimeDurationModal';
 import { TimeDurationIndicator } from '../../components/Time/TimeDurationIndicator';
 import { serverConfig } from '../../config';
+import { hasKialiCapability } from '../../config/AuthenticationConfig';
+import { KialiCapability } from '../../types/Auth';
 import type { ApiResponse } from 'types/Api';
 import { isParentKiosk, kioskNavigateAction } from 'components/Kiosk/KioskActions';
 import { TRACE_LIMIT_DEFAULT } from 'components/Metrics/TraceLimit';
@@ -919,6 +921,9 @@ export class WorkloadPodLogsComponent extends React.Component<WorkloadPodLogsPro
     const hasProxyContainer = this.state.containerOptions?.some(opt => {
       return opt.isProxy || opt.name === 'istio-proxy';
     });
+    const canChangeLogLevel =
+      !serverConfig.deployment.viewOnlyMode &&
+      hasKialiCapability(KialiCapability.changeProxyLogLevel, this.props.cluster, this.props.namespace);
     const logDropDowns = Object.keys(LogLevel).map(level => {
       return (
         <DropdownItem
@@ -927,7 +932,7 @@ export class WorkloadPodLogsComponent extends React.Component<WorkloadPodLogsPro
           onClick={() => {
             this.setLogLevel(LogLevel[level]);
           }}
-          isDisabled={serverConfig.deployment.viewOnlyMode}
+          isDisabled={!canChangeLogLevel}
         >
           {level}
         </DropdownItem>
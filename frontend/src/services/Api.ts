@@ -54,7 +54,7 @@ import type {
   TracingResponse,
   TracingSingleResponse
 } from '../types/TracingInfo';
-import type { ControlPlane, MeshDefinition, MeshQuery } from '../types/Mesh';
+import type { ControlPlane, MeshComponentHealth, MeshDefinition, MeshQuery } from '../types/Mesh';
 import type { DashboardQuery, IstioMetricsOptions, MetricsStatsQuery } from '../types/MetricsOptions';
 import type {
   IstioMetricsMap,
@@ -1524,6 +1524,13 @@ export const getMeshGraph = (params: MeshQuery): Promise<ApiResponse<MeshDefinit
   return newRequest<MeshDefinition>(HTTP_VERBS.GET, urls.meshGraph, params, {});
 };
 
+export const getMeshHealth = (params?: {
+  queryTime?: number;
+  rateInterval?: string;
+}): Promise<ApiResponse<MeshComponentHealth[]>> => {
+  return newRequest<MeshComponentHealth[]>(HTTP_VERBS.GET, urls.meshHealth, params ?? {}, {});
+};
+
 export const getDiagnoseStatus = (cluster?: string): Promise<ApiResponse<TracingCheck>> => {
   const queryParams: ClusterParam = {};
 
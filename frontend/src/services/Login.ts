@@ -103,6 +103,8 @@ export class LoginDispatcher {
     this.strategyMapping.set(AuthStrategy.anonymous, new AnonymousLogin());
     this.strategyMapping.set(AuthStrategy.token, new TokenLogin());
     this.strategyMapping.set(AuthStrategy.header, new HeaderLogin());
+    // The client certificate is sent with every request, like the headers of the header strategy
+    this.strategyMapping.set(AuthStrategy.x509, new HeaderLogin());
     this.strategyMapping.set(AuthStrategy.openshift, new OAuthLogin());
     this.strategyMapping.set(AuthStrategy.openid, new OAuthLogin());
   }
@@ -33,6 +33,7 @@ import type { MeshCluster, MeshDefinition, MeshTarget } from '../types/Mesh';
 import type { MeshLayout } from 'pages/Mesh/layouts/LayoutFactory';
 import type { ChatInteractionMode, ChatResourceHealth, ProviderAI } from 'types/Chatbot';
 import type { ChatbotDisplayMode } from '@patternfly/chatbot';
+import type { ClusterCapabilities } from '../types/Auth';
 
 // Store is the Redux Data store
 
@@ -139,6 +140,7 @@ export enum LoginStatus {
 }
 
 export interface SessionClusterInfo {
+  capabilities?: ClusterCapabilities;
   name: string;
 }
 
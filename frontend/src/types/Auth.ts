@@ -1,6 +1,8 @@
 export interface AuthConfig {
   authorizationEndpoint?: string;
   authorizationEndpointPerCluster?: { [cluster: string]: string };
+  clusterCapabilities?: { [cluster: string]: ClusterCapabilities };
+  kialiRBACEnabled?: boolean;
   logoutEndpoint?: string;
   logoutRedirect?: string;
   strategy: AuthStrategy;
@@ -15,7 +17,8 @@ export enum AuthStrategy {
   openshift = 'openshift',
   token = 'token',
   openid = 'openid',
-  header = 'header'
+  header = 'header',
+  x509 = 'x509'
 }
 
 // Stores the result of a computation:
@@ -30,7 +33,24 @@ export enum AuthResult {
   FAILURE = 'failure'
 }
 
+// KialiCapability are the capabilities granted by the Kiali roles, on top of Kubernetes RBAC
+export enum KialiCapability {
+  changeProxyLogLevel = 'change-proxy-log-level',
+  editIstioConfig = 'edit-istio-config',
+  manageSessions = 'manage-sessions',
+  useAIChat = 'use-ai-chat',
+  viewGraph = 'view-graph',
+  viewSecrets = 'view-secrets'
+}
+
+// ClusterCapabilities are granted in all the namespaces of a cluster, or only in some namespaces
+export interface ClusterCapabilities {
+  capabilities: KialiCapability[];
+  namespaces?: { [namespace: string]: KialiCapability[] };
+}
+
 export interface SessionClusterInfo {
+  capabilities?: ClusterCapabilities;
   name: string;
 }
 
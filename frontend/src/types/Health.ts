@@ -38,6 +38,8 @@ export interface HealthItem {
 // When present, this should be used instead of client-side calculation
 export interface CalculatedHealthStatus {
   errorRatio?: number; // Error ratio as percentage (0-100)
+  reason?: string; // Explanation of the status, when it is not healthy
+  signal?: string; // "controlPlane", "errorRate", "kubernetes", "latency", "slo", "workloadStatus": signal that caused the status, when it is not healthy
   status: string; // "Healthy", "Degraded", "Failure", "Not Ready", "NA"
 }
 
@@ -76,17 +78,67 @@ export interface WorkloadStatus {
   availableReplicas: number;
   currentReplicas: number;
   desiredReplicas: number;
+  kubernetes?: KubernetesSignals;
   name: string;
   syncedProxies: number;
 }
 
+// KubernetesSignals are the container states and recent Warning Events of the pods of a workload
+export interface KubernetesSignals {
+  crashLoopContainers?: string[]; // pod/container
+  failedProbes: number; // readiness probe failures in the recent window
+  lastWarningEvent?: string; // "reason: message"
+  oomKilledContainers?: string[]; // pod/container
+  recentRestarts: number;
+  warningEvents: number; // probe failures excluded
+}
+
+// SLOStatus is the state of a service level objective over its compliance window, computed by the health refresh
+export interface SLOStatus {
+  burnRates: { [window: string]: number }; // error budget burn rates by window (e.g. "1h")
+  errorBudgetRemaining: number; // percentage of the error budget left, negative when exhausted
+  latencyThreshold?: number; // ms, for latency SLOs
+  name: string;
+  objective: number; // percentage of good requests
+  reason?: string;
+  sli: number; // percentage of good requests over the window
+  status: string;
+  type: string; // "availability" or "latency"
+  window: string;
+}
+
+// HealthTransition is a change of the health status of an entity, or of the signal that caused it
+export interface HealthTransition {
+  durationSeconds: number; // until the next transition or, for the current status, until the entity was last seen
+  reason?: string;
+  signal?: string;
+  status: string;
+  timestamp: string;
+}
+
+export interface HealthEntityTimeline {
+  lastSeen: string;
+  name: string;
+  transitions: HealthTransition[]; // oldest first, the last one is the current status
+  type: string; // "app", "service" or "workload"
+}
+
+// HealthTimeline is the health history of the entities of a namespace, recorded by the health cache refreshes
+export interface HealthTimeline {
+  cluster: string;
+  entities: HealthEntityTimeline[];
+  namespace: string;
+}
+
 export interface AppHealthResponse {
   requests: RequestHealth;
+  slos?: SLOStatus[];
   workloadStatuses: WorkloadStatus[];
 }
 
 export interface WorkloadHealthResponse {
   requests: RequestHealth;
+  slos?: SLOStatus[];
   workloadStatus: WorkloadStatus;
 }
 
@@ -107,7 +159,9 @@ export interface RequestType {
 export interface RequestHealth {
   healthAnnotations: HealthAnnotationType;
   inbound: RequestType;
+  inboundLatency?: RequestType; // request duration quantiles (ms) by protocol and quantile
   outbound: RequestType;
+  outboundLatency?: RequestType;
 }
 
 // Valid health status IDs that match backend HealthStatus values
@@ -65,11 +65,13 @@ export enum gvkType {
   TrafficExtension = 'TrafficExtension',
   WasmPlugin = 'WasmPlugin',
 
+  K8sBackendTLSPolicy = 'K8sBackendTLSPolicy',
   K8sGateway = 'K8sGateway',
   K8sGatewayClass = 'K8sGatewayClass',
   K8sGRPCRoute = 'K8sGRPCRoute',
   K8sHTTPRoute = 'K8sHTTPRoute',
   K8sInferencePool = 'K8sInferencePool',
+  K8sListenerSet = 'K8sListenerSet',
   K8sReferenceGrant = 'K8sReferenceGrant',
   K8sTCPRoute = 'K8sTCPRoute',
   K8sTLSRoute = 'K8sTLSRoute',
@@ -104,11 +106,13 @@ export const dicTypeToGVK: { [key in gvkType]: GroupVersionKind } = {
   [gvkType.TrafficExtension]: { Group: 'extensions.istio.io', Version: 'v1alpha1', Kind: gvkType.TrafficExtension },
   [gvkType.WasmPlugin]: { Group: 'extensions.istio.io', Version: 'v1alpha1', Kind: gvkType.WasmPlugin },
 
+  [gvkType.K8sBackendTLSPolicy]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'BackendTLSPolicy' },
   [gvkType.K8sGateway]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'Gateway' },
   [gvkType.K8sGatewayClass]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'GatewayClass' },
   [gvkType.K8sGRPCRoute]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'GRPCRoute' },
   [gvkType.K8sHTTPRoute]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'HTTPRoute' },
   [gvkType.K8sInferencePool]: { Group: 'inference.networking.k8s.io', Version: 'v1', Kind: 'InferencePool' },
+  [gvkType.K8sListenerSet]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'ListenerSet' },
   [gvkType.K8sReferenceGrant]: { Group: 'gateway.networking.k8s.io', Version: 'v1beta1', Kind: 'ReferenceGrant' },
   [gvkType.K8sTCPRoute]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'TCPRoute' },
   [gvkType.K8sTLSRoute]: { Group: 'gateway.networking.k8s.io', Version: 'v1', Kind: 'TLSRoute' },
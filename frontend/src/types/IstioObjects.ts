@@ -157,7 +157,11 @@ export interface ContainerInfo {
   isAmbient: boolean;
   isProxy: boolean;
   isReady: boolean;
+  lastTerminatedAt?: string;
+  lastTerminationReason?: string; // e.g. OOMKilled
   name: string;
+  restartCount?: number;
+  waitingReason?: string; // e.g. CrashLoopBackOff
 }
 
 // 1.6
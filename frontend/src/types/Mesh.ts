@@ -4,6 +4,7 @@ import { AppenderString } from './Common';
 import { NamespaceInfo } from './NamespaceInfo';
 import { BoxByType } from './Graph';
 import { CertsInfo } from 'types/CertsInfo';
+import { CalculatedHealthStatus, WorkloadStatus } from './Health';
 
 export interface MeshCluster {
   accessible: boolean;
@@ -48,6 +49,26 @@ export enum MeshNodeType {
 
 export type MeshNodeHealthData = string;
 
+// ControlPlaneSignals are the signals of the health of istiod, computed over the health rate interval
+export interface ControlPlaneSignals {
+  certExpiry?: string; // when the first of the control plane certificates expires
+  configRejections?: number; // xDS configs rejected by the proxies
+  proxyConvergence?: number; // p99, in seconds
+  xdsPushErrors?: number; // push context errors, internal errors and write timeouts
+  xdsPushLatency?: number; // p99, in seconds
+}
+
+// MeshComponentHealth is the health of istiod, a gateway, a waypoint or ztunnel
+export interface MeshComponentHealth {
+  cluster: string;
+  name: string;
+  namespace: string;
+  signals?: ControlPlaneSignals; // for istiod
+  status: CalculatedHealthStatus;
+  type: 'gateway' | 'istiod' | 'waypoint' | 'ztunnel';
+  workloadStatus?: WorkloadStatus;
+}
+
 export interface IstiodNodeData extends BaseNodeData {
   infraData: ControlPlane;
   infraType: MeshInfraType.ISTIOD;
@@ -140,6 +161,7 @@ interface BaseNodeData {
   healthData?: MeshNodeHealthData;
   id: string;
   infraData?: MeshCluster | NamespaceInfo[] | ControlPlane | any; // add other type options as the case arises
+  infraHealth?: MeshComponentHealth; // for istiod, gateways, waypoints and ztunnel
   infraName: string;
   infraType: MeshInfraType;
   isAmbient?: boolean;
@@ -118,7 +118,54 @@ export interface HealthConfig {
     refreshInterval?: string;
     timeout?: string;
   };
+  history?: {
+    enabled: boolean;
+    maxAge?: string;
+    maxTransitions?: number;
+  };
+  kubernetes?: {
+    enabled: boolean;
+    failedProbes?: CountThresholds;
+    recentWindow?: string;
+    restarts?: CountThresholds;
+    warningEvents?: CountThresholds;
+  };
+  mesh?: {
+    certExpiry?: DurationThresholds;
+    configRejections?: CountThresholds;
+    enabled: boolean;
+    proxyConvergence?: DurationThresholds;
+    xdsPushErrors?: CountThresholds;
+    xdsPushLatency?: DurationThresholds;
+  };
   rate: RateHealthConfig[];
+  slo?: SLOConfig[];
+}
+
+// counts from which a signal is degraded or failing, 0 or unset disables the threshold
+export interface CountThresholds {
+  degraded?: number;
+  failure?: number;
+}
+
+// durations (e.g. "5s") from which a signal is degraded or failing, unset disables the threshold
+export interface DurationThresholds {
+  degraded?: string;
+  failure?: string;
+}
+
+// service level objective on the inbound requests of the targeted entities
+export interface SLOConfig {
+  latencyThreshold?: number; // ms, for latency SLOs
+  name: string;
+  objective: number; // percentage of good requests, e.g. 99.9
+  target?: {
+    kind?: RegexConfig;
+    name?: RegexConfig;
+    namespace?: RegexConfig;
+  };
+  type: 'availability' | 'latency';
+  window?: string;
 }
 
 export interface HealthCompute {
@@ -130,10 +177,20 @@ export interface HealthCompute {
 // rateHealthConfig
 export interface RateHealthConfig {
   kind?: RegexConfig;
+  latency?: LatencyThresholdConfig[];
   name?: RegexConfig;
   namespace?: RegexConfig;
   tolerance: ToleranceConfig[];
 }
+
+// latency thresholds, in milliseconds, for a quantile of the request duration
+export interface LatencyThresholdConfig {
+  degraded: number;
+  direction?: RegexConfig;
+  failure: number;
+  protocol?: RegexConfig;
+  quantile: number;
+}
 // toleranceConfig
 export interface ToleranceConfig {
   code: RegexConfig;
@@ -0,0 +1,83 @@
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/util/httputil"
+)
+
+// APIProvider posts the proposals as JSON to a Git hosting API endpoint, usually a small service or
+// automation that creates the branch, commit and pull request in the hosting service in use.
+// The endpoint must answer with a 2xx status and a JSON body with the "url" of the change
+// and, optionally, its "branch" and "commit". A 409 status means that the change is already in the base branch.
+type APIProvider struct {
+	client http.Client
+	url    string
+}
+
+// NewAPIProvider creates an APIProvider using the auth, headers and timeout of the API configuration.
+func NewAPIProvider(conf *config.Config, apiConf config.GitOpsAPIConfig) (*APIProvider, error) {
+	var auth *config.Auth
+	if apiConf.Auth.Type != "" && apiConf.Auth.Type != config.AuthTypeNone {
+		auth = &apiConf.Auth
+	}
+	transport, err := httputil.CreateTransport(conf, auth, &http.Transport{}, apiConf.Timeout, apiConf.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the GitOps API transport: %w", err)
+	}
+	return &APIProvider{
+		client: http.Client{Transport: transport, Timeout: apiConf.Timeout},
+		url:    apiConf.URL,
+	}, nil
+}
+
+func (a *APIProvider) Name() string {
+	return "api"
+}
+
+func (a *APIProvider) Propose(ctx context.Context, proposal Proposal) (models.GitOpsChange, error) {
+	body, err := json.Marshal(proposal)
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return models.GitOpsChange{}, ErrNoChanges
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return models.GitOpsChange{}, fmt.Errorf("GitOps API returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var change struct {
+		Branch string `json:"branch"`
+		Commit string `json:"commit"`
+		URL    string `json:"url"`
+	}
+	if err := json.Unmarshal(respBody, &change); err != nil {
+		return models.GitOpsChange{}, fmt.Errorf("unable to parse the GitOps API response: %w", err)
+	}
+	return models.GitOpsChange{Branch: change.Branch, Commit: change.Commit, URL: change.URL}, nil
+}
@@ -0,0 +1,8 @@
+package gitops
+
+import "time"
+
+// SetNow sets the clock used to name the branches of the proposals.
+func (p *Proposer) SetNow(now func() time.Time) {
+	p.now = now
+}
@@ -0,0 +1,232 @@
+// Package gitops proposes the Istio config changes made through Kiali as commits on new branches of a git
+// repository, for the clusters whose configuration is reconciled from git (e.g. by Argo CD or Flux).
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+// ErrNoChanges is returned when the proposed manifests are already in the base branch.
+var ErrNoChanges = errors.New("the proposed change is already in the GitOps repository")
+
+// Change is a write operation on an Istio object, with the manifest resulting from it.
+type Change struct {
+	// Operation is one of models.GitOpsOperationCreate, models.GitOpsOperationUpdate or models.GitOpsOperationDelete
+	Operation string
+	Cluster   string
+	Namespace string
+	Name      string
+	GVK       schema.GroupVersionKind
+	// User requesting the change, included in the description when known
+	User string
+	// Manifest is the YAML of the resulting object. Unused for deletions.
+	Manifest []byte
+	// Patch requested by the user, included in the description of the updates
+	Patch string
+}
+
+// File is a file added, replaced or removed by a Proposal.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Delete  bool   `json:"delete,omitempty"`
+}
+
+// Proposal is what a Provider commits to a new branch of the repository.
+type Proposal struct {
+	AuthorEmail string `json:"authorEmail"`
+	AuthorName  string `json:"authorName"`
+	BaseBranch  string `json:"baseBranch"`
+	Branch      string `json:"branch"`
+	Description string `json:"description"`
+	Files       []File `json:"files"`
+	Title       string `json:"title"`
+}
+
+// Provider creates the branch and commit of a Proposal in a git repository.
+type Provider interface {
+	// Name identifies the provider in logs.
+	Name() string
+	// Propose commits the proposal to a new branch. The returned change has at least the
+	// branch set, and the commit and URL when the provider knows them.
+	Propose(ctx context.Context, proposal Proposal) (models.GitOpsChange, error)
+}
+
+// Proposer turns the Istio config changes into proposals to the configured Provider.
+type Proposer struct {
+	conf         config.GitOpsConfig
+	now          func() time.Time
+	pathTemplate *template.Template
+	provider     Provider
+}
+
+// NewProposer creates a Proposer with the provider configured in external_services.gitops.
+// It returns nil when the GitOps mode is disabled.
+func NewProposer(conf *config.Config) (*Proposer, error) {
+	gitOpsConf := conf.ExternalServices.GitOps
+	if !gitOpsConf.Enabled {
+		return nil, nil
+	}
+
+	var provider Provider
+	switch gitOpsConf.Provider {
+	case config.GitOpsProviderAPI:
+		apiProvider, err := NewAPIProvider(conf, gitOpsConf.API)
+		if err != nil {
+			return nil, err
+		}
+		provider = apiProvider
+	default:
+		provider = NewLocalProvider(gitOpsConf.Local)
+	}
+	return NewProposerWithProvider(gitOpsConf, provider)
+}
+
+// NewProposerWithProvider creates a Proposer committing the changes with the given provider.
+func NewProposerWithProvider(gitOpsConf config.GitOpsConfig, provider Provider) (*Proposer, error) {
+	pathTemplate, err := template.New("path").Option("missingkey=error").Parse(gitOpsConf.PathTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid external_services.gitops.path_template: %w", err)
+	}
+	return &Proposer{
+		conf:         gitOpsConf,
+		now:          time.Now,
+		pathTemplate: pathTemplate,
+		provider:     provider,
+	}, nil
+}
+
+// Propose commits the manifest of the change to a new branch and returns where it can be reviewed.
+func (p *Proposer) Propose(ctx context.Context, change Change) (*models.GitOpsChange, error) {
+	filePath, err := p.filePath(change)
+	if err != nil {
+		return nil, err
+	}
+
+	file := File{Path: filePath}
+	if change.Operation == models.GitOpsOperationDelete {
+		file.Delete = true
+	} else {
+		file.Content = string(change.Manifest)
+	}
+
+	proposal := Proposal{
+		AuthorEmail: p.conf.AuthorEmail,
+		AuthorName:  p.conf.AuthorName,
+		BaseBranch:  p.conf.BaseBranch,
+		Branch:      p.branch(change),
+		Description: description(change, filePath),
+		Files:       []File{file},
+		Title:       title(change),
+	}
+
+	proposed, err := p.provider.Propose(ctx, proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed.Operation = change.Operation
+	proposed.Cluster = change.Cluster
+	proposed.Namespace = change.Namespace
+	proposed.Name = change.Name
+	proposed.ObjectGVK = change.GVK
+	proposed.BaseBranch = proposal.BaseBranch
+	proposed.Path = filePath
+	proposed.Title = proposal.Title
+	proposed.Description = proposal.Description
+	if proposed.Branch == "" {
+		proposed.Branch = proposal.Branch
+	}
+	return &proposed, nil
+}
+
+// filePath renders the path template for the change. The path must stay inside the repository.
+func (p *Proposer) filePath(change Change) (string, error) {
+	var buf bytes.Buffer
+	err := p.pathTemplate.Execute(&buf, map[string]string{
+		"Cluster":   change.Cluster,
+		"Namespace": change.Namespace,
+		"Group":     change.GVK.Group,
+		"Version":   change.GVK.Version,
+		"Kind":      change.GVK.Kind,
+		"Name":      change.Name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to render the GitOps manifest path: %w", err)
+	}
+
+	filePath := buf.String()
+	cleaned := path.Clean(filePath)
+	if filePath == "" || path.IsAbs(filePath) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid GitOps manifest path [%s]: it must be relative to the repository root", filePath)
+	}
+	return cleaned, nil
+}
+
+// branch returns the name of the branch of a change. It ends with a short hash of the change, so that the
+// same object changed in several clusters, or changed differently, within a second gets different branches.
+func (p *Proposer) branch(change Change) string {
+	hash := sha256.New()
+	for _, part := range []string{change.Operation, change.Cluster, change.Namespace, change.Name, change.GVK.String(), string(change.Manifest), change.Patch} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return strings.ToLower(fmt.Sprintf("%s%s-%s-%s-%s-%s-%s-%s", p.conf.BranchPrefix, change.Operation, change.GVK.Kind,
+		change.Cluster, change.Namespace, change.Name, p.now().UTC().Format("20060102150405"), hex.EncodeToString(hash.Sum(nil))[:8]))
+}
+
+func title(change Change) string {
+	verb := "Update"
+	switch change.Operation {
+	case models.GitOpsOperationCreate:
+		verb = "Create"
+	case models.GitOpsOperationDelete:
+		verb = "Delete"
+	}
+	return fmt.Sprintf("%s %s %s/%s", verb, change.GVK.Kind, change.Namespace, change.Name)
+}
+
+func description(change Change, filePath string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Change proposed by Kiali.\n\n")
+	fmt.Fprintf(&sb, "- Operation: %s\n", change.Operation)
+	fmt.Fprintf(&sb, "- Object: %s %s/%s\n", change.GVK.String(), change.Namespace, change.Name)
+	fmt.Fprintf(&sb, "- Cluster: %s\n", change.Cluster)
+	fmt.Fprintf(&sb, "- File: %s\n", filePath)
+	if change.User != "" {
+		fmt.Fprintf(&sb, "- Requested by: %s\n", change.User)
+	}
+	if change.Operation == models.GitOpsOperationUpdate && change.Patch != "" {
+		fmt.Fprintf(&sb, "\nRequested patch:\n\n```json\n%s\n```\n", change.Patch)
+	}
+	return sb.String()
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the proposer.
+func NewContext(ctx context.Context, proposer *Proposer) context.Context {
+	return context.WithValue(ctx, contextKey{}, proposer)
+}
+
+// FromContext returns the proposer stored in ctx, or nil when there is none and the changes must be applied to the cluster.
+func FromContext(ctx context.Context) *Proposer {
+	if proposer, ok := ctx.Value(contextKey{}).(*Proposer); ok {
+		return proposer
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package gitops_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(cmd.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com", "GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	require.NoErrorf(t, err, "git %v: %s", args, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// newRepository creates a repository with an empty commit in the main branch.
+func newRepository(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q", "-b", "main")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "init")
+	return dir
+}
+
+func reviewsChange(operation, manifest string) gitops.Change {
+	return gitops.Change{
+		Operation: operation,
+		Cluster:   "east",
+		Namespace: "bookinfo",
+		Name:      "reviews",
+		GVK:       kubernetes.VirtualServices,
+		User:      "jdoe",
+		Manifest:  []byte(manifest),
+		Patch:     `{"spec":{"hosts":["ratings"]}}`,
+	}
+}
+
+func TestLocalProviderProposals(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	repo := newRepository(t)
+
+	conf := config.NewConfig().ExternalServices.GitOps
+	conf.Local.RepositoryPath = repo
+	conf.Local.ChangeURLTemplate = "https://git.example.com/mesh/compare/{{.BaseBranch}}...{{.Branch}}"
+	proposer, err := gitops.NewProposerWithProvider(conf, gitops.NewLocalProvider(conf.Local))
+	require.NoError(err)
+
+	ctx := context.Background()
+	created, err := proposer.Propose(ctx, reviewsChange(models.GitOpsOperationCreate, "kind: VirtualService\n"))
+	require.NoError(err)
+	assert.Equal("east/bookinfo/VirtualService/reviews.yaml", created.Path)
+	assert.True(strings.HasPrefix(created.Branch, "kiali/create-virtualservice-east-bookinfo-reviews-"), created.Branch)
+	assert.Equal("https://git.example.com/mesh/compare/main..."+created.Branch, created.URL)
+	assert.Equal("Create VirtualService bookinfo/reviews", created.Title)
+	assert.Contains(created.Description, "Requested by: jdoe")
+
+	// The new branch has the manifest and the commit message, the base branch is untouched
+	assert.Equal("kind: VirtualService", runGit(t, repo, "show", created.Branch+":"+created.Path))
+	assert.Equal(created.Commit, runGit(t, repo, "rev-parse", created.Branch))
+	assert.Contains(runGit(t, repo, "log", "-1", "--format=%an <%ae> %B", created.Branch), "Kiali <kiali@localhost> Create VirtualService bookinfo/reviews")
+	assert.Equal("init", runGit(t, repo, "log", "-1", "--format=%s", "main"))
+	assert.Empty(runGit(t, repo, "status", "--porcelain"))
+
+	// Deleting a manifest that is not in the base branch changes nothing
+	_, err = proposer.Propose(ctx, reviewsChange(models.GitOpsOperationDelete, ""))
+	assert.ErrorIs(err, gitops.ErrNoChanges)
+
+	runGit(t, repo, "merge", "-q", created.Branch)
+	_, err = proposer.Propose(ctx, reviewsChange(models.GitOpsOperationUpdate, "kind: VirtualService\n"))
+	assert.ErrorIs(err, gitops.ErrNoChanges)
+
+	deleted, err := proposer.Propose(ctx, reviewsChange(models.GitOpsOperationDelete, ""))
+	require.NoError(err)
+	assert.Empty(runGit(t, repo, "ls-tree", "-r", "--name-only", deleted.Branch))
+}
+
+func TestLocalProviderProposalsForSeveralClusters(t *testing.T) {
+	require := require.New(t)
+	repo := newRepository(t)
+
+	conf := config.NewConfig().ExternalServices.GitOps
+	conf.Local.RepositoryPath = repo
+	proposer, err := gitops.NewProposerWithProvider(conf, gitops.NewLocalProvider(conf.Local))
+	require.NoError(err)
+	proposer.SetNow(func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) })
+
+	// The same change applied to two clusters at the same time is proposed in two branches
+	east := reviewsChange(models.GitOpsOperationCreate, "kind: VirtualService\n")
+	west := east
+	west.Cluster = "west"
+	eastProposal, err := proposer.Propose(context.Background(), east)
+	require.NoError(err)
+	westProposal, err := proposer.Propose(context.Background(), west)
+	require.NoError(err)
+	assert.NotEqual(t, eastProposal.Branch, westProposal.Branch)
+	assert.Contains(t, westProposal.Branch, "-west-")
+	assert.Equal(t, "kind: VirtualService", runGit(t, repo, "show", westProposal.Branch+":west/bookinfo/VirtualService/reviews.yaml"))
+}
+
+func TestLocalProviderMissingBaseBranch(t *testing.T) {
+	repo := newRepository(t)
+	conf := config.NewConfig().ExternalServices.GitOps
+	conf.BaseBranch = "production"
+	conf.Local.RepositoryPath = repo
+	proposer, err := gitops.NewProposerWithProvider(conf, gitops.NewLocalProvider(conf.Local))
+	require.NoError(t, err)
+
+	_, err = proposer.Propose(context.Background(), reviewsChange(models.GitOpsOperationCreate, "kind: VirtualService\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "production")
+}
+
+func TestProposerRejectsPathsOutsideTheRepository(t *testing.T) {
+	for _, pathTemplate := range []string{"/etc/{{.Name}}.yaml", "../{{.Name}}.yaml", "{{.Namespace}}/../../{{.Name}}.yaml", "{{.Unknown}}.yaml"} {
+		conf := config.NewConfig().ExternalServices.GitOps
+		conf.PathTemplate = pathTemplate
+		proposer, err := gitops.NewProposerWithProvider(conf, gitops.NewLocalProvider(conf.Local))
+		require.NoError(t, err)
+
+		_, err = proposer.Propose(context.Background(), reviewsChange(models.GitOpsOperationCreate, "kind: VirtualService\n"))
+		assert.Errorf(t, err, "path template: %s", pathTemplate)
+	}
+}
+
+func TestAPIProviderProposals(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	var received gitops.Proposal
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(json.NewDecoder(r.Body).Decode(&received))
+		if received.Files[0].Delete {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		_, _ = w.Write([]byte(`{"url":"https://git.example.com/mesh/pull/7","commit":"abc123"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	conf := config.NewConfig()
+	conf.ExternalServices.GitOps.Enabled = true
+	conf.ExternalServices.GitOps.Provider = config.GitOpsProviderAPI
+	conf.ExternalServices.GitOps.API.URL = ts.URL
+	conf.ExternalServices.GitOps.API.Auth = config.Auth{Type: config.AuthTypeBearer, Token: "secret"}
+	proposer, err := gitops.NewProposer(conf)
+	require.NoError(err)
+	require.NotNil(proposer)
+
+	change, err := proposer.Propose(context.Background(), reviewsChange(models.GitOpsOperationUpdate, "kind: VirtualService\n"))
+	require.NoError(err)
+	assert.Equal("https://git.example.com/mesh/pull/7", change.URL)
+	assert.Equal("abc123", change.Commit)
+	assert.Equal(received.Branch, change.Branch)
+	assert.Equal("main", received.BaseBranch)
+	assert.Equal([]gitops.File{{Path: "east/bookinfo/VirtualService/reviews.yaml", Content: "kind: VirtualService\n"}}, received.Files)
+	assert.Contains(received.Description, `{"spec":{"hosts":["ratings"]}}`)
+
+	_, err = proposer.Propose(context.Background(), reviewsChange(models.GitOpsOperationDelete, ""))
+	assert.ErrorIs(err, gitops.ErrNoChanges)
+}
+
+func TestNewProposerDisabled(t *testing.T) {
+	proposer, err := gitops.NewProposer(config.NewConfig())
+	require.NoError(t, err)
+	assert.Nil(t, proposer)
+	assert.Nil(t, gitops.FromContext(context.Background()))
+}
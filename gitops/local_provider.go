@@ -0,0 +1,146 @@
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/models"
+)
+
+// LocalProvider commits the proposals to a git repository on the local filesystem, optionally pushing the new branches.
+// It only uses git plumbing commands with a private index, so neither the working tree nor the checked out
+// branch of the repository are modified and it also works with bare repositories.
+type LocalProvider struct {
+	conf config.GitOpsLocalConfig
+	// git does not support concurrent writes to the same repository
+	lock sync.Mutex
+}
+
+// NewLocalProvider creates a LocalProvider for the repository in conf.RepositoryPath.
+func NewLocalProvider(conf config.GitOpsLocalConfig) *LocalProvider {
+	return &LocalProvider{conf: conf}
+}
+
+func (l *LocalProvider) Name() string {
+	return "local"
+}
+
+func (l *LocalProvider) Propose(ctx context.Context, proposal Proposal) (models.GitOpsChange, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	indexDir, err := os.MkdirTemp("", "kiali-gitops-")
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+	defer os.RemoveAll(indexDir)
+
+	env := []string{
+		"GIT_INDEX_FILE=" + filepath.Join(indexDir, "index"),
+		"GIT_AUTHOR_NAME=" + proposal.AuthorName,
+		"GIT_AUTHOR_EMAIL=" + proposal.AuthorEmail,
+		"GIT_COMMITTER_NAME=" + proposal.AuthorName,
+		"GIT_COMMITTER_EMAIL=" + proposal.AuthorEmail,
+	}
+	git := func(stdin string, args ...string) (string, error) {
+		return l.git(ctx, env, stdin, args...)
+	}
+
+	base, err := git("", "rev-parse", "--verify", "refs/heads/"+proposal.BaseBranch+"^{commit}")
+	if err != nil {
+		return models.GitOpsChange{}, fmt.Errorf("unable to find the GitOps base branch [%s]: %w", proposal.BaseBranch, err)
+	}
+	baseTree, err := git("", "rev-parse", base+"^{tree}")
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+	if _, err := git("", "read-tree", base); err != nil {
+		return models.GitOpsChange{}, err
+	}
+
+	for _, file := range proposal.Files {
+		if file.Delete {
+			if _, err := git("", "update-index", "--force-remove", "--", file.Path); err != nil {
+				return models.GitOpsChange{}, err
+			}
+			continue
+		}
+		blob, err := git(file.Content, "hash-object", "-w", "--stdin")
+		if err != nil {
+			return models.GitOpsChange{}, err
+		}
+		if _, err := git("", "update-index", "--add", "--cacheinfo", "100644,"+blob+","+file.Path); err != nil {
+			return models.GitOpsChange{}, err
+		}
+	}
+
+	tree, err := git("", "write-tree")
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+	if tree == baseTree {
+		return models.GitOpsChange{}, ErrNoChanges
+	}
+
+	commit, err := git(proposal.Title+"\n\n"+proposal.Description, "commit-tree", tree, "-p", base)
+	if err != nil {
+		return models.GitOpsChange{}, err
+	}
+	// The empty old value makes the update fail when the branch already exists
+	if _, err := git("", "update-ref", "refs/heads/"+proposal.Branch, commit, ""); err != nil {
+		return models.GitOpsChange{}, fmt.Errorf("unable to create the GitOps branch [%s]: %w", proposal.Branch, err)
+	}
+
+	if l.conf.Remote != "" {
+		if _, err := git("", "push", l.conf.Remote, "refs/heads/"+proposal.Branch); err != nil {
+			return models.GitOpsChange{}, fmt.Errorf("unable to push the GitOps branch [%s] to [%s]: %w", proposal.Branch, l.conf.Remote, err)
+		}
+	}
+
+	change := models.GitOpsChange{Branch: proposal.Branch, Commit: commit}
+	if l.conf.ChangeURLTemplate != "" {
+		changeURL, err := changeURL(l.conf.ChangeURLTemplate, proposal, commit)
+		if err != nil {
+			return models.GitOpsChange{}, err
+		}
+		change.URL = changeURL
+	}
+	return change, nil
+}
+
+func (l *LocalProvider) git(ctx context.Context, env []string, stdin string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", l.conf.RepositoryPath}, args...)...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func changeURL(urlTemplate string, proposal Proposal, commit string) (string, error) {
+	tmpl, err := template.New("url").Option("missingkey=error").Parse(urlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid external_services.gitops.local.change_url_template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]string{
+		"BaseBranch": proposal.BaseBranch,
+		"Branch":     proposal.Branch,
+		"Commit":     commit,
+	}); err != nil {
+		return "", fmt.Errorf("unable to render the GitOps change URL: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -39,6 +39,9 @@ type GraphCache interface {
 	// GetSessionGraph retrieves a session's cached graph if it exists
 	GetSessionGraph(sessionID string) (*CachedGraph, bool)
 
+	// GetSessionGraphStats returns the stats of a session's cached graph if it exists, without accessing it
+	GetSessionGraphStats(sessionID string) (*SessionGraphStats, bool)
+
 	// SetGraphGenerator sets the graph generator function for background refresh
 	SetGraphGenerator(generator GraphGenerator)
 
@@ -60,6 +63,14 @@ type CachedGraph struct {
 	mu              sync.RWMutex // Protects LastAccessed field
 }
 
+// SessionGraphStats describes a session's cached graph
+type SessionGraphStats struct {
+	EstimatedMB     float64       `json:"estimatedMB"`
+	LastAccessed    time.Time     `json:"lastAccessed"`
+	RefreshInterval time.Duration `json:"refreshInterval"`
+	Timestamp       time.Time     `json:"timestamp"`
+}
+
 // GraphCacheConfig holds graph cache configuration
 type GraphCacheConfig struct {
 	Enabled           bool
@@ -115,6 +126,26 @@ func (c *GraphCacheImpl) GetSessionGraph(sessionID string) (*CachedGraph, bool)
 	return cached, true
 }
 
+// GetSessionGraphStats returns the stats of a session's cached graph without updating last accessed time
+func (c *GraphCacheImpl) GetSessionGraphStats(sessionID string) (*SessionGraphStats, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cached, found := c.sessionGraphs[sessionID]
+	if !found {
+		return nil, false
+	}
+
+	cached.mu.RLock()
+	defer cached.mu.RUnlock()
+	return &SessionGraphStats{
+		EstimatedMB:     cached.estimatedMB,
+		LastAccessed:    cached.LastAccessed,
+		RefreshInterval: cached.RefreshInterval,
+		Timestamp:       cached.Timestamp,
+	}, true
+}
+
 // getSessionGraphInternal retrieves a session's cached graph without updating last accessed time
 // This is used internally by refresh jobs to check inactivity without affecting the access time
 func (c *GraphCacheImpl) getSessionGraphInternal(sessionID string) (*CachedGraph, bool) {
@@ -274,6 +274,27 @@ func TestGraphCache_UpdateLastAccessed(t *testing.T) {
 	assert.WithinDuration(t, time.Now(), retrieved.LastAccessed, 1*time.Second)
 }
 
+func TestGraphCache_GetSessionGraphStatsDoesNotUpdateLastAccessed(t *testing.T) {
+	cache := NewGraphCache(context.Background(), &GraphCacheConfig{Enabled: true, MaxCacheMemoryMB: 1024})
+	oldTime := time.Now().Add(-5 * time.Minute)
+	err := cache.SetSessionGraph("test-session", &CachedGraph{
+		LastAccessed:    oldTime,
+		RefreshInterval: 30 * time.Second,
+		Timestamp:       oldTime,
+		TrafficMap:      createTestTrafficMap(5),
+	})
+	require.NoError(t, err)
+
+	stats, found := cache.GetSessionGraphStats("test-session")
+	require.True(t, found)
+	assert.Equal(t, oldTime, stats.LastAccessed)
+	assert.Equal(t, 30*time.Second, stats.RefreshInterval)
+	assert.Greater(t, stats.EstimatedMB, 0.0)
+
+	_, found = cache.GetSessionGraphStats("other-session")
+	assert.False(t, found)
+}
+
 func TestGraphCache_ReplaceExistingGraph(t *testing.T) {
 	ctx := context.Background()
 	config := &GraphCacheConfig{
@@ -328,6 +328,7 @@ func (a *HealthAppender) attachHealth(ctx context.Context, trafficMap graph.Traf
 			if h, found := appHealth[n.App+n.Namespace+n.Cluster]; found {
 				health.WorkloadStatuses = h.WorkloadStatuses
 				health.Requests.HealthAnnotations = h.Requests.HealthAnnotations
+				health.SLOs = h.SLOs
 			}
 			n.Metadata[key] = health
 		case graph.NodeTypeService:
@@ -340,6 +341,7 @@ func (a *HealthAppender) attachHealth(ctx context.Context, trafficMap graph.Traf
 
 			if h, found := serviceHealth[n.Service+n.Namespace+n.Cluster]; found {
 				health.Requests.HealthAnnotations = h.Requests.HealthAnnotations
+				health.SLOs = h.SLOs
 			}
 			n.Metadata[graph.HealthData] = health
 		case graph.NodeTypeWorkload:
@@ -353,6 +355,7 @@ func (a *HealthAppender) attachHealth(ctx context.Context, trafficMap graph.Traf
 			if h, found := workloadHealth[n.Workload+n.Namespace+n.Cluster]; found {
 				health.WorkloadStatus = h.WorkloadStatus
 				health.Requests.HealthAnnotations = h.Requests.HealthAnnotations
+				health.SLOs = h.SLOs
 			}
 			n.Metadata[graph.HealthData] = health
 		}
@@ -191,6 +191,9 @@ func ChatAI(
 			RespondWithError(w, http.StatusInternalServerError, "ChatAI is not enabled")
 			return
 		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityUseAIChat, "") {
+			return
+		}
 		var req aiTypes.AIRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			RespondWithError(w, http.StatusBadRequest, "Invalid request body")
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/handlers/authentication"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+)
+
+// apiTokenGVK identifies the API tokens in the audit records. They are not Kubernetes objects.
+var apiTokenGVK = schema.GroupVersionKind{Group: "kiali.io", Kind: "APIToken"}
+
+// APITokenResponse is returned once, when an API token is created. It is the only copy of the token.
+type APITokenResponse struct {
+	authentication.APIToken
+	Token string `json:"token"`
+}
+
+// apiTokenOwner returns the identity the cluster authorizes for the user of the request, which the API
+// tokens impersonate: the impersonated user, or else the user of the token reviewed in the home cluster.
+func apiTokenOwner(r *http.Request, conf *config.Config, clientFactory kubernetes.ClientFactory) (*authentication.UserIdentity, error) {
+	authInfos, err := getAuthInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	authInfo, ok := authInfos[conf.KubernetesConfig.ClusterName]
+	if !ok || authInfo == nil {
+		return nil, fmt.Errorf("no session in the home cluster [%s]", conf.KubernetesConfig.ClusterName)
+	}
+	if authInfo.Impersonate != "" {
+		return &authentication.UserIdentity{Groups: authInfo.ImpersonateGroups, Username: authInfo.Impersonate}, nil
+	}
+	if authInfo.Token == "" {
+		return nil, errors.New("the session has no credentials identifying the user")
+	}
+	userInfo, err := clientFactory.GetSAHomeClusterClient().GetTokenUserInfo(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("unable to identify the user of the session: %w", err)
+	}
+	return &authentication.UserIdentity{Groups: userInfo.Groups, Username: userInfo.Username}, nil
+}
+
+// checkAPITokensEnabled responds with a 404 and returns false when API tokens are disabled, and with a 403
+// when the request is itself authenticated with an API token: tokens cannot manage tokens.
+func checkAPITokensEnabled(w http.ResponseWriter, r *http.Request, apiTokens *authentication.APITokens) bool {
+	if !apiTokens.Enabled() {
+		RespondWithError(w, http.StatusNotFound, "API tokens are disabled")
+		return false
+	}
+	if authentication.GetAPITokenContext(r.Context()) != nil {
+		RespondWithError(w, http.StatusForbidden, "API tokens cannot be managed with an API token")
+		return false
+	}
+	return true
+}
+
+// CreateAPIToken is the API handler to issue an API token owned by the user of the request.
+func CreateAPIToken(conf *config.Config, clientFactory kubernetes.ClientFactory, apiTokens *authentication.APITokens) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAPITokensEnabled(w, r, apiTokens) {
+			return
+		}
+
+		body, err := boundedReadAll(r)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "API token request could not be read: "+err.Error())
+			return
+		}
+		var req authentication.APITokenRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "API token request is not valid: "+err.Error())
+			return
+		}
+
+		owner, err := apiTokenOwner(r, conf, clientFactory)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "API token could not be created: "+err.Error())
+			return
+		}
+
+		tokenString, token, err := apiTokens.Create(r.Context(), owner, req)
+		entry := audit.Entry{
+			Operation: "CREATE",
+			Cluster:   conf.KubernetesConfig.ClusterName,
+			Name:      req.Name,
+			GVK:       apiTokenGVK,
+			Err:       err,
+			Message:   fmt.Sprintf("API token [%s] creation for user [%s]", req.Name, owner.Username),
+		}
+		if token != nil {
+			entry.Message = fmt.Sprintf("API token [%s] (id [%s], read-only [%t], expires on [%s]) created for user [%s]", token.Name, token.ID, token.ReadOnly, token.ExpiresOn.Format(time.RFC3339), token.Owner)
+		}
+		audit.Log(r, conf, models.AuditSourceAPI, entry)
+		if err != nil {
+			if errors.Is(err, authentication.ErrInvalidAPITokenRequest) {
+				RespondWithError(w, http.StatusBadRequest, err.Error())
+			} else {
+				RespondWithError(w, http.StatusInternalServerError, "API token could not be created: "+err.Error())
+			}
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, APITokenResponse{APIToken: *token, Token: tokenString})
+	}
+}
+
+// ListAPITokens is the API handler to list the API tokens of the user of the request. Secrets are never returned.
+func ListAPITokens(conf *config.Config, clientFactory kubernetes.ClientFactory, apiTokens *authentication.APITokens) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAPITokensEnabled(w, r, apiTokens) {
+			return
+		}
+		owner, err := apiTokenOwner(r, conf, clientFactory)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "API tokens could not be listed: "+err.Error())
+			return
+		}
+		tokens, err := apiTokens.List(r.Context(), owner.Username)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "API tokens could not be listed: "+err.Error())
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, tokens)
+	}
+}
+
+// RevokeAPIToken is the API handler to revoke an API token of the user of the request.
+func RevokeAPIToken(conf *config.Config, clientFactory kubernetes.ClientFactory, apiTokens *authentication.APITokens) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkAPITokensEnabled(w, r, apiTokens) {
+			return
+		}
+		id := mux.Vars(r)["id"]
+		owner, err := apiTokenOwner(r, conf, clientFactory)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "API token could not be revoked: "+err.Error())
+			return
+		}
+
+		token, err := apiTokens.Revoke(r.Context(), owner.Username, id)
+		if errors.Is(err, authentication.ErrAPITokenNotFound) {
+			RespondWithError(w, http.StatusNotFound, fmt.Sprintf("API token [%s] not found", id))
+			return
+		}
+		entry := audit.Entry{
+			Operation: "DELETE",
+			Cluster:   conf.KubernetesConfig.ClusterName,
+			Name:      id,
+			GVK:       apiTokenGVK,
+			Err:       err,
+			Message:   fmt.Sprintf("API token [%s] revocation for user [%s]", id, owner.Username),
+		}
+		if token != nil {
+			entry.Name = token.Name
+			entry.Message = fmt.Sprintf("API token [%s] (id [%s]) revoked for user [%s]", token.Name, token.ID, token.Owner)
+		}
+		audit.Log(r, conf, models.AuditSourceAPI, entry)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "API token could not be revoked: "+err.Error())
+			return
+		}
+		RespondWithCode(w, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/handlers/authentication"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+	utilcontext "github.com/kiali/kiali/util/context"
+)
+
+func newAPITokensTestConfig() *config.Config {
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyX509
+	conf.Auth.APITokens.Enabled = true
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.LoginToken.SigningKey = config.Credential(util.RandomString(16))
+	return conf
+}
+
+// createTestAPIToken creates an API token through the API, as the impersonated user alice.
+func createTestAPIToken(t *testing.T, conf *config.Config, apiTokens *authentication.APITokens, request authentication.APITokenRequest) APITokenResponse {
+	t.Helper()
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/api/auth/tokens", bytes.NewReader(body))
+	r = r.WithContext(authentication.SetAuthInfoContext(r.Context(), map[string]*api.AuthInfo{
+		"east": {Impersonate: "alice", ImpersonateGroups: []string{"devs"}},
+	}))
+	w := httptest.NewRecorder()
+	clientFactory := kubetest.NewFakeClientFactoryWithClient(conf, kubetest.NewFakeK8sClient())
+	CreateAPIToken(conf, clientFactory, apiTokens)(w, r)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var response APITokenResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	return response
+}
+
+func TestAPITokenAuthenticatesRequests(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Now()}
+	conf := newAPITokensTestConfig()
+	apiTokens := authentication.NewAPITokens(conf, sessionstore.NewMemoryStore())
+	created := createTestAPIToken(t, conf, apiTokens, authentication.APITokenRequest{
+		Name:       "ci",
+		Namespaces: []string{"bookinfo"},
+		ReadOnly:   true,
+	})
+	assert.Equal(t, "alice", created.Owner)
+
+	// The configured strategy must not be used for API token requests
+	authController := &fakeAuthController{err: authentication.ErrSessionNotFound}
+	handler := NewAuthenticationHandler(conf, authController, kubetest.NewFakeK8sClient(), nil,
+		map[string]kubernetes.ClientInterface{"east": kubetest.NewFakeK8sClient(), "west": kubetest.NewFakeK8sClient()}, apiTokens)
+
+	var captured *http.Request
+	next := handler.Handle(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured = r
+		w.WriteHeader(http.StatusOK)
+	}))
+	request := func(method string, namespace string, token string) *httptest.ResponseRecorder {
+		captured = nil
+		r := httptest.NewRequest(method, "/api/namespaces/"+namespace+"/services", nil)
+		r = mux.SetURLVars(r, map[string]string{"namespace": namespace})
+		r.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		next.ServeHTTP(w, r)
+		return w
+	}
+
+	require.Equal(http.StatusOK, request(http.MethodGet, "bookinfo", created.Token).Code)
+	require.NotNil(captured)
+	authInfos, err := getAuthInfo(captured)
+	require.NoError(err)
+	require.Len(authInfos, 2)
+	assert.Equal(t, "alice", authInfos["east"].Impersonate)
+	assert.Equal(t, "alice", captured.Header.Get("Kiali-User"))
+	assert.Equal(t, created.ID, utilcontext.GetAPITokenIDContext(captured.Context()))
+	assert.Equal(t, "alice", authentication.GetUserIdentityContext(captured.Context()).Username)
+
+	assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "istio-system", created.Token).Code)
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPatch, "bookinfo", created.Token).Code)
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "bookinfo", created.Token+"x").Code)
+	assert.Nil(t, captured)
+
+	// Revoked tokens are rejected
+	r := httptest.NewRequest(http.MethodDelete, "/api/auth/tokens/"+created.ID, nil)
+	r = mux.SetURLVars(r, map[string]string{"id": created.ID})
+	r = r.WithContext(authentication.SetAuthInfoContext(r.Context(), map[string]*api.AuthInfo{"east": {Impersonate: "alice"}}))
+	w := httptest.NewRecorder()
+	RevokeAPIToken(conf, kubetest.NewFakeClientFactoryWithClient(conf, kubetest.NewFakeK8sClient()), apiTokens)(w, r)
+	require.Equal(http.StatusNoContent, w.Code)
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "bookinfo", created.Token).Code)
+}
+
+func TestAPITokenRequestClusters(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/namespaces/bookinfo/istio/validations?clusterName=east&clusters=west,%20north", nil)
+	r = mux.SetURLVars(r, map[string]string{"cluster": "south", "namespace": "bookinfo"})
+	assert.Equal(t, []string{"south", "east", "west", "north"}, apiTokenRequestClusters(r))
+
+	token := &authentication.APIToken{Clusters: []string{"east"}, Name: "ci"}
+	r = httptest.NewRequest(http.MethodPost, "/api/istio/config/export?clusters=east,west", nil)
+	assert.Error(t, token.Authorize(r, nil, apiTokenRequestClusters(r)))
+	r = httptest.NewRequest(http.MethodPost, "/api/istio/config/export?clusters=east", nil)
+	assert.NoError(t, token.Authorize(r, nil, apiTokenRequestClusters(r)))
+}
+
+func TestAPITokensCannotManageAPITokens(t *testing.T) {
+	conf := newAPITokensTestConfig()
+	apiTokens := authentication.NewAPITokens(conf, sessionstore.NewMemoryStore())
+
+	r := httptest.NewRequest(http.MethodGet, "/api/auth/tokens", nil)
+	r = r.WithContext(authentication.SetAPITokenContext(r.Context(), &authentication.APIToken{ID: "1"}))
+	w := httptest.NewRecorder()
+	ListAPITokens(conf, kubetest.NewFakeClientFactoryWithClient(conf, kubetest.NewFakeK8sClient()), apiTokens)(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	conf.Auth.APITokens.Enabled = false
+	w = httptest.NewRecorder()
+	ListAPITokens(conf, kubetest.NewFakeClientFactoryWithClient(conf, kubetest.NewFakeK8sClient()), apiTokens)(w, httptest.NewRequest(http.MethodGet, "/api/auth/tokens", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
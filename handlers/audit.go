@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers/queryparams"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+const defaultAuditSearchLimit = 100
+
+var auditSearchQueryParams = []queryparams.Param{
+	queryparams.StringParam("user", ""),
+	queryparams.StringParam("clusterName", ""),
+	queryparams.StringParam("namespace", ""),
+	queryparams.StringParam("name", ""),
+	queryparams.StringParam("kind", ""),
+	queryparams.EnumParam("operation", "CREATE", "UPDATE", "DELETE"),
+	queryparams.EnumParam("result", models.AuditResultSuccess, models.AuditResultFailure),
+	{Name: "startTime", Kind: queryparams.KindTimestamp},
+	{Name: "endTime", Kind: queryparams.KindTimestamp},
+	queryparams.PresenceParam("limit"),
+}
+
+// AuditSearch is the API handler to search the audit records of the write operations performed through Kiali.
+// Only the records of namespaces accessible to the user are returned.
+func AuditSearch(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+	auditTrail *audit.Trail,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseAuditQuery(r, conf)
+		if err != nil {
+			RespondWithQueryParamError(w, err.Error())
+			return
+		}
+
+		if !auditTrail.Searchable() {
+			RespondWithError(w, http.StatusServiceUnavailable, audit.ErrSearchNotSupported.Error())
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		namespaces, err := business.Namespace.GetNamespaces(r.Context())
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		accessible := make(map[string]bool, len(namespaces))
+		for _, ns := range namespaces {
+			accessible[ns.Cluster+"/"+ns.Name] = true
+		}
+
+		// The limit applies to the accessible records
+		limit := query.Limit
+		query.Limit = 0
+		records, err := auditTrail.Search(r.Context(), query)
+		if err != nil {
+			if errors.Is(err, audit.ErrSearchNotSupported) {
+				RespondWithError(w, http.StatusServiceUnavailable, err.Error())
+				return
+			}
+			RespondWithError(w, http.StatusInternalServerError, "Audit search error: "+err.Error())
+			return
+		}
+
+		result := models.AuditRecords{Records: []models.AuditRecord{}}
+		for _, record := range records {
+			if len(result.Records) == limit {
+				break
+			}
+			if accessible[record.Cluster+"/"+record.Namespace] {
+				result.Records = append(result.Records, record)
+			}
+		}
+
+		RespondWithJSON(w, http.StatusOK, result)
+	}
+}
+
+func parseAuditQuery(r *http.Request, conf *config.Config) (models.AuditQuery, error) {
+	parsed, err := queryparams.ParseWithConfig(r.URL.Query(), conf, auditSearchQueryParams)
+	if err != nil {
+		return models.AuditQuery{}, err
+	}
+
+	query := models.AuditQuery{
+		User:      parsed.String("user"),
+		Cluster:   parsed.String("clusterName"),
+		Namespace: parsed.String("namespace"),
+		Name:      parsed.String("name"),
+		Kind:      parsed.String("kind"),
+		Operation: parsed.String("operation"),
+		Result:    parsed.String("result"),
+		StartTime: parsed.Time("startTime"),
+		EndTime:   parsed.Time("endTime"),
+		Limit:     defaultAuditSearchLimit,
+	}
+	if limit := parsed.String("limit"); limit != "" {
+		query.Limit, err = queryparams.ParseIntParam(limit, "limit")
+		if err != nil {
+			return models.AuditQuery{}, err
+		}
+		if query.Limit <= 0 {
+			return models.AuditQuery{}, fmt.Errorf("query parameter 'limit' must be greater than 0")
+		}
+	}
+	if !query.StartTime.IsZero() && !query.EndTime.IsZero() && query.EndTime.Before(query.StartTime) {
+		return models.AuditQuery{}, fmt.Errorf("query parameter 'endTime' must not be before 'startTime'")
+	}
+	return query, nil
+}
@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tracing"
+)
+
+func setupAuditSearchServer(t *testing.T, trail *audit.Trail) (*httptest.Server, *config.Config) {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bookinfo"}},
+	)
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(t, err)
+
+	handler := handlers.WithFakeAuthInfo(conf, handlers.AuditSearch(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc, trail))
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/audit", handler)
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	return ts, conf
+}
+
+func TestAuditSearch(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	trail := audit.NewTrailWithSinks(audit.NewMemorySink(10))
+	ts, conf := setupAuditSearchServer(t, trail)
+
+	now := time.Now()
+	cluster := conf.KubernetesConfig.ClusterName
+	trail.Record(context.TODO(), models.AuditRecord{Timestamp: now.Add(-time.Hour), User: "alice", Operation: "UPDATE", Cluster: cluster, Namespace: "bookinfo", Name: "reviews", ObjectGVK: kubernetes.VirtualServices})
+	trail.Record(context.TODO(), models.AuditRecord{Timestamp: now.Add(-time.Minute), User: "bob", Operation: "DELETE", Cluster: cluster, Namespace: "bookinfo", Name: "ratings", ObjectGVK: kubernetes.DestinationRules})
+	// Records of namespaces the user cannot access are never returned
+	trail.Record(context.TODO(), models.AuditRecord{Timestamp: now, User: "alice", Operation: "UPDATE", Cluster: cluster, Namespace: "hidden", Name: "secret", ObjectGVK: kubernetes.VirtualServices})
+
+	search := func(query string) models.AuditRecords {
+		resp, err := ts.Client().Get(ts.URL + "/api/audit" + query)
+		require.NoError(err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		require.Equalf(http.StatusOK, resp.StatusCode, "response text: %s", string(body))
+
+		result := models.AuditRecords{}
+		require.NoError(json.Unmarshal(body, &result))
+		return result
+	}
+
+	result := search("")
+	require.Len(result.Records, 2)
+	assert.Equal("ratings", result.Records[0].Name)
+	assert.Equal("reviews", result.Records[1].Name)
+
+	result = search("?user=alice")
+	require.Len(result.Records, 1)
+	assert.Equal("reviews", result.Records[0].Name)
+
+	result = search("?kind=DestinationRule&operation=DELETE")
+	require.Len(result.Records, 1)
+	assert.Equal("bob", result.Records[0].User)
+
+	result = search("?limit=1")
+	require.Len(result.Records, 1)
+	assert.Equal("ratings", result.Records[0].Name)
+}
+
+func TestAuditSearchBadRequest(t *testing.T) {
+	ts, _ := setupAuditSearchServer(t, audit.NewTrailWithSinks(audit.NewMemorySink(10)))
+
+	for _, query := range []string{"?limit=0", "?operation=PATCH", "?startTime=20&endTime=10", "?unknown=true"} {
+		resp, err := ts.Client().Get(ts.URL + "/api/audit" + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equalf(t, http.StatusBadRequest, resp.StatusCode, "query: %s", query)
+	}
+}
+
+func TestAuditSearchNotSupported(t *testing.T) {
+	ts, _ := setupAuditSearchServer(t, audit.NewTrailWithSinks())
+
+	resp, err := ts.Client().Get(ts.URL + "/api/audit")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
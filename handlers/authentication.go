@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/clientcmd/api"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/kiali/kiali/handlers/authentication"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
+	utilcontext "github.com/kiali/kiali/util/context"
 	"github.com/kiali/kiali/util/httputil"
 )
 
@@ -24,6 +26,7 @@ const AnonymousSessionID = "anonymous-shared"
 
 type AuthenticationHandler struct {
 	conf                *config.Config
+	apiTokens           *authentication.APITokens
 	authController      authentication.AuthController
 	homeClusterSAClient kubernetes.ClientInterface
 	kialiSAClients      map[string]kubernetes.ClientInterface
@@ -34,6 +37,7 @@ type AuthInfo struct {
 	AuthorizationEndpoint           string            `json:"authorizationEndpoint,omitempty"`
 	AuthorizationEndpointPerCluster map[string]string `json:"authorizationEndpointPerCluster,omitempty"`
 	ImpersonationEnabled            bool              `json:"impersonationEnabled,omitempty"`
+	KialiRBACEnabled                bool              `json:"kialiRBACEnabled,omitempty"`
 	LogoutEndpoint                  string            `json:"logoutEndpoint,omitempty"`
 	LogoutRedirect                  string            `json:"logoutRedirect,omitempty"`
 	SecretMissing                   bool              `json:"secretMissing,omitempty"`
@@ -42,7 +46,9 @@ type AuthInfo struct {
 }
 
 type sessionClusterInfo struct {
-	Name string `json:"name,omitempty"`
+	// Capabilities are the capabilities granted to the user in the cluster by the Kiali roles.
+	Capabilities *authentication.ClusterCapabilities `json:"capabilities,omitempty"`
+	Name         string                              `json:"name,omitempty"`
 }
 
 // sessionInfo represents all the logged in sessions across all clusters
@@ -63,8 +69,10 @@ func NewAuthenticationHandler(
 	homeClusterSAClient kubernetes.ClientInterface,
 	authRedirectHandler http.Handler,
 	kialiSAClients map[string]kubernetes.ClientInterface,
+	apiTokens *authentication.APITokens,
 ) AuthenticationHandler {
 	return AuthenticationHandler{
+		apiTokens:           apiTokens,
 		authController:      authController,
 		authRedirectHandler: authRedirectHandler,
 		conf:                conf,
@@ -87,11 +95,17 @@ func (aHandler *AuthenticationHandler) Handle(next http.Handler) http.Handler {
 			}
 		}
 
+		// API tokens are accepted alongside the configured strategy, for scripts and CI jobs.
+		if aHandler.apiTokens.Enabled() && authentication.IsAPITokenRequest(r) {
+			aHandler.handleAPIToken(next, w, r)
+			return
+		}
+
 		statusCode := http.StatusOK
 		userSessions := make(authentication.UserSessions)
 
 		switch authStrategy := aHandler.conf.Auth.Strategy; authStrategy {
-		case config.AuthStrategyToken, config.AuthStrategyOpenId, config.AuthStrategyOpenshift, config.AuthStrategyHeader:
+		case config.AuthStrategyToken, config.AuthStrategyOpenId, config.AuthStrategyOpenshift, config.AuthStrategyHeader, config.AuthStrategyX509:
 			sessions, err := aHandler.authController.ValidateSession(r, w)
 			if err != nil {
 				if errors.Is(err, authentication.ErrSessionNotFound) || errors.Is(err, authentication.ErrSubjectMismatch) {
@@ -146,6 +160,9 @@ func (aHandler *AuthenticationHandler) Handle(next http.Handler) http.Handler {
 			// SessionID may be empty for 3rd-party auth (e.g., OpenShift Bearer header/oauth_token)
 			if homeSession, ok := userSessions[aHandler.conf.KubernetesConfig.ClusterName]; ok {
 				ctx = authentication.SetSessionIDContext(ctx, homeSession.SessionID)
+				if aHandler.conf.Auth.Strategy != config.AuthStrategyAnonymous {
+					ctx = authentication.SetUserIdentityContext(ctx, authentication.NewUserIdentity(homeSession))
+				}
 			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		case http.StatusUnauthorized:
@@ -164,6 +181,89 @@ func (aHandler *AuthenticationHandler) Handle(next http.Handler) http.Handler {
 	})
 }
 
+// handleAPIToken authenticates a request carrying a Kiali API token. The request only reaches the next
+// handler when the restrictions of the token allow it.
+func (aHandler *AuthenticationHandler) handleAPIToken(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	clusters := make([]string, 0, len(aHandler.kialiSAClients))
+	for cluster := range aHandler.kialiSAClients {
+		clusters = append(clusters, cluster)
+	}
+	token, userSessions, err := aHandler.apiTokens.Authenticate(r, clusters)
+	if err != nil {
+		if errors.Is(err, authentication.ErrInvalidAPIToken) {
+			log.Warningf("Rejected API token [client: %s]", r.RemoteAddr)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		} else {
+			log.Errorf("Failed to validate API token [client: %s]: %s", r.RemoteAddr, err.Error())
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := token.Authorize(r, apiTokenRequestNamespaces(r), apiTokenRequestClusters(r)); err != nil {
+		log.Warningf("API token [%s] denied access to [%s %s] [client: %s]: %s", token.ID, r.Method, r.URL.Path, r.RemoteAddr, err.Error())
+		RespondWithError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if len(userSessions) == 0 {
+		RespondWithError(w, http.StatusForbidden, fmt.Sprintf("API token [%s] is not allowed in any cluster", token.Name))
+		return
+	}
+
+	r.Header.Set("Kiali-User", token.Owner) // Internal header used to propagate the subject of the request for audit purposes
+	ctx := authentication.SetAuthInfoContext(r.Context(), userSessions.GetAuthInfos())
+	ctx = authentication.SetAPITokenContext(ctx, token)
+	ctx = utilcontext.SetAPITokenIDContext(ctx, token.ID)
+	ctx = authentication.SetSessionIDContext(ctx, authentication.APITokenPrefix+token.ID)
+	// All the sessions of a token are for its owner
+	for _, session := range userSessions {
+		ctx = authentication.SetUserIdentityContext(ctx, authentication.NewUserIdentity(session))
+		break
+	}
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// apiTokenRequestNamespaces returns the namespaces targeted by a request: the namespace of the route and
+// the namespaces of the query. The handlers reading their namespaces from the body, like the bulk Istio
+// config operations, authorize the API token of the request themselves.
+func apiTokenRequestNamespaces(r *http.Request) []string {
+	var namespaces []string
+	if namespace := mux.Vars(r)["namespace"]; namespace != "" {
+		namespaces = append(namespaces, namespace)
+	}
+	query := r.URL.Query()
+	for _, param := range []string{"namespace", "namespaces"} {
+		for _, value := range query[param] {
+			for _, namespace := range strings.Split(value, ",") {
+				if namespace = strings.TrimSpace(namespace); namespace != "" {
+					namespaces = append(namespaces, namespace)
+				}
+			}
+		}
+	}
+	return namespaces
+}
+
+// apiTokenRequestClusters returns the clusters targeted by a request: the cluster of the route, the cluster
+// of the query and the clusters of the multi-cluster operations.
+func apiTokenRequestClusters(r *http.Request) []string {
+	var clusters []string
+	if cluster := mux.Vars(r)["cluster"]; cluster != "" {
+		clusters = append(clusters, cluster)
+	}
+	query := r.URL.Query()
+	for _, param := range []string{"clusterName", "clusters"} {
+		for _, value := range query[param] {
+			for _, cluster := range strings.Split(value, ",") {
+				if cluster = strings.TrimSpace(cluster); cluster != "" {
+					clusters = append(clusters, cluster)
+				}
+			}
+		}
+	}
+	return clusters
+}
+
 func (aHandler *AuthenticationHandler) HandleUnauthenticated(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authInfos := make(map[string]*api.AuthInfo)
@@ -178,7 +278,7 @@ func (aHandler *AuthenticationHandler) HandleUnauthenticated(next http.Handler)
 func Authenticate(conf *config.Config, authController authentication.AuthController) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		switch conf.Auth.Strategy {
-		case config.AuthStrategyToken, config.AuthStrategyOpenId, config.AuthStrategyOpenshift, config.AuthStrategyHeader:
+		case config.AuthStrategyToken, config.AuthStrategyOpenId, config.AuthStrategyOpenshift, config.AuthStrategyHeader, config.AuthStrategyX509:
 			response, err := authController.Authenticate(r, w)
 			if err != nil {
 				if e, ok := err.(*authentication.AuthenticationFailureError); ok {
@@ -222,6 +322,7 @@ func AuthenticationInfo(conf *config.Config, authController authentication.AuthC
 		var response AuthInfo
 
 		response.Strategy = conf.Auth.Strategy
+		response.KialiRBACEnabled = conf.Auth.KialiRBAC.Enabled
 
 		switch conf.Auth.Strategy {
 		case config.AuthStrategyOpenshift:
@@ -238,18 +339,47 @@ func AuthenticationInfo(conf *config.Config, authController authentication.AuthC
 			// Do the redirection through an intermediary own endpoint
 			response.AuthorizationEndpoint = fmt.Sprintf("%s/api/auth/openid_redirect",
 				httputil.GuessKialiURL(conf, r))
+			// Clusters trusting another issuer are logged in one by one, after the Kiali login
+			for cluster, clusterConf := range conf.Auth.OpenId.Clusters {
+				if clusterConf.Mode != config.OpenIdClusterModeLogin {
+					continue
+				}
+				if response.AuthorizationEndpointPerCluster == nil {
+					response.AuthorizationEndpointPerCluster = make(map[string]string)
+				}
+				response.AuthorizationEndpointPerCluster[cluster] = fmt.Sprintf("%s/api/auth/openid_redirect/%s", httputil.GuessKialiURL(conf, r), cluster)
+			}
+		case config.AuthStrategyX509:
+			// The client certificate identity is impersonated on every cluster: a single login covers all of them
+			response.ImpersonationEnabled = true
 		}
 
-		if conf.Auth.Strategy != config.AuthStrategyAnonymous {
+		if conf.Auth.Strategy == config.AuthStrategyAnonymous && conf.Auth.KialiRBAC.Enabled {
+			// Anonymous users only get the capabilities of the default roles
+			response.SessionInfo.ClusterInfo = make(map[string]sessionClusterInfo)
+			for _, cluster := range clusters {
+				capabilities := authentication.UserCapabilities(conf, nil, cluster)
+				response.SessionInfo.ClusterInfo[cluster] = sessionClusterInfo{Capabilities: &capabilities, Name: cluster}
+			}
+		} else if conf.Auth.Strategy != config.AuthStrategyAnonymous {
 			sessions, err := authController.ValidateSession(r, w)
 			if err != nil {
 				log.Debugf("Unable to validate session: %v", err)
 			}
 
 			response.SessionInfo.ClusterInfo = make(map[string]sessionClusterInfo)
+			var identity *authentication.UserIdentity
+			if homeSession, ok := sessions[conf.KubernetesConfig.ClusterName]; ok {
+				identity = authentication.NewUserIdentity(homeSession)
+			}
 			// TODO: Handle different usernames and expiration dates for different sessions.
 			for cluster, session := range sessions {
-				response.SessionInfo.ClusterInfo[cluster] = sessionClusterInfo{Name: cluster}
+				clusterInfo := sessionClusterInfo{Name: cluster}
+				if conf.Auth.KialiRBAC.Enabled {
+					capabilities := authentication.UserCapabilities(conf, identity, cluster)
+					clusterInfo.Capabilities = &capabilities
+				}
+				response.SessionInfo.ClusterInfo[cluster] = clusterInfo
 				if cluster == conf.KubernetesConfig.ClusterName {
 					response.SessionInfo.ExpiresOn = session.ExpiresOn.Format(time.RFC1123Z)
 					response.SessionInfo.Username = session.Username
@@ -0,0 +1,287 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+)
+
+// APITokenPrefix starts every API token, so that they are easy to recognize (e.g. by secret scanners)
+// and cannot be mistaken for Kubernetes tokens.
+const APITokenPrefix = "kiali_"
+
+// apiTokenStoreKeyPrefix prefixes the keys of the API tokens in the session store.
+const apiTokenStoreKeyPrefix = "apitoken:"
+
+var (
+	// ErrAPITokenNotFound is returned when an API token does not exist, expired or was revoked.
+	ErrAPITokenNotFound = errors.New("API token not found")
+	// ErrInvalidAPIToken is returned when a request carries a malformed or unknown API token.
+	ErrInvalidAPIToken = errors.New("invalid API token")
+	// ErrInvalidAPITokenRequest is returned when an API token cannot be issued with the requested attributes.
+	ErrInvalidAPITokenRequest = errors.New("invalid API token request")
+)
+
+// APIToken is a Kiali-issued token for scripts and CI jobs calling the Kiali API. It acts on behalf of
+// its owner, within its restrictions. Only a hash of the secret part of the token is stored.
+type APIToken struct {
+	// Clusters restricts the token to these clusters. Empty allows all the clusters.
+	Clusters  []string  `json:"clusters,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresOn time.Time `json:"expiresOn"`
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	// Namespaces restricts the token to these namespaces. Empty allows all the namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+	Owner      string   `json:"owner"`
+	// ReadOnly tokens can only be used for requests that do not change anything (GET and HEAD).
+	ReadOnly bool `json:"readOnly"`
+}
+
+// APITokenRequest holds the attributes of a new API token.
+type APITokenRequest struct {
+	Clusters []string `json:"clusters,omitempty"`
+	// ExpirationSeconds is the lifetime of the token. Zero uses the longest lifetime allowed.
+	ExpirationSeconds int64    `json:"expirationSeconds,omitempty"`
+	Name              string   `json:"name"`
+	Namespaces        []string `json:"namespaces,omitempty"`
+	ReadOnly          bool     `json:"readOnly"`
+}
+
+// storedAPIToken is an API token as kept in the session store.
+type storedAPIToken struct {
+	APIToken
+	// Groups of the owner when the token was created, impersonated with the owner.
+	Groups []string `json:"groups,omitempty"`
+	// Hash is the SHA-256 of the secret part of the token.
+	Hash string `json:"hash"`
+}
+
+// APITokens issues, validates and revokes the API tokens.
+type APITokens struct {
+	conf  *config.Config
+	store sessionstore.Store
+}
+
+// NewAPITokens returns the API tokens kept in the store.
+func NewAPITokens(conf *config.Config, store sessionstore.Store) *APITokens {
+	return &APITokens{conf: conf, store: store}
+}
+
+// Enabled returns true when API tokens are accepted: only with the auth strategies impersonating the users with
+// the Kiali SA, which is then already allowed to impersonate the token owners.
+func (t *APITokens) Enabled() bool {
+	return t != nil && t.conf.Auth.APITokens.Enabled && t.conf.Auth.ImpersonatesWithSA()
+}
+
+func hashAPITokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create issues a new API token owned by the user. The returned token string is the only copy of the secret.
+func (t *APITokens) Create(ctx context.Context, owner *UserIdentity, request APITokenRequest) (string, *APIToken, error) {
+	if !t.Enabled() {
+		return "", nil, fmt.Errorf("%w: API tokens require an auth strategy impersonating the users with the Kiali service account", ErrInvalidAPITokenRequest)
+	}
+	if owner == nil || owner.Username == "" {
+		return "", nil, fmt.Errorf("%w: the user of the session is unknown", ErrInvalidAPITokenRequest)
+	}
+	// The owner is impersonated by the requests using the token
+	if err := ValidateImpersonationIdentity(owner.Username, owner.Groups); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidAPITokenRequest, err)
+	}
+	if strings.TrimSpace(request.Name) == "" {
+		return "", nil, fmt.Errorf("%w: the name of the token is required", ErrInvalidAPITokenRequest)
+	}
+	maxExpiration := t.conf.Auth.APITokens.MaxExpirationSeconds
+	if request.ExpirationSeconds < 0 || request.ExpirationSeconds > maxExpiration {
+		return "", nil, fmt.Errorf("%w: the expiration of the token must be between 1 and %d seconds", ErrInvalidAPITokenRequest, maxExpiration)
+	}
+	expiration := request.ExpirationSeconds
+	if expiration == 0 {
+		expiration = maxExpiration
+	}
+
+	secretBytes, err := util.CryptoRandomBytes(32)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to generate the token: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+	now := util.Clock.Now()
+	token := storedAPIToken{
+		APIToken: APIToken{
+			Clusters:   request.Clusters,
+			CreatedAt:  now,
+			ExpiresOn:  now.Add(time.Duration(expiration) * time.Second),
+			ID:         strings.ReplaceAll(uuid.New().String(), "-", ""),
+			Name:       strings.TrimSpace(request.Name),
+			Namespaces: request.Namespaces,
+			Owner:      owner.Username,
+			ReadOnly:   request.ReadOnly,
+		},
+		Groups: owner.Groups,
+		Hash:   hashAPITokenSecret(secret),
+	}
+	content, err := json.Marshal(token)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := t.store.Set(ctx, apiTokenStoreKeyPrefix+token.ID, content, token.ExpiresOn.Sub(now)); err != nil {
+		return "", nil, fmt.Errorf("unable to store the token: %w", err)
+	}
+	return APITokenPrefix + token.ID + "_" + secret, &token.APIToken, nil
+}
+
+func (t *APITokens) get(ctx context.Context, id string) (*storedAPIToken, error) {
+	content, err := t.store.Get(ctx, apiTokenStoreKeyPrefix+id)
+	if err != nil {
+		if errors.Is(err, sessionstore.ErrNotFound) {
+			return nil, ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	token := &storedAPIToken{}
+	if err := json.Unmarshal(content, token); err != nil {
+		return nil, fmt.Errorf("unable to parse API token [%s]: %w", id, err)
+	}
+	if !util.Clock.Now().Before(token.ExpiresOn) {
+		return nil, ErrAPITokenNotFound
+	}
+	return token, nil
+}
+
+// List returns the API tokens of the owner, the most recent first.
+func (t *APITokens) List(ctx context.Context, owner string) ([]APIToken, error) {
+	keys, err := t.store.Keys(ctx, apiTokenStoreKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	tokens := []APIToken{}
+	for _, key := range keys {
+		token, err := t.get(ctx, strings.TrimPrefix(key, apiTokenStoreKeyPrefix))
+		if err != nil {
+			if !errors.Is(err, ErrAPITokenNotFound) {
+				log.Warningf("Skipping API token [%s]: %v", key, err)
+			}
+			continue
+		}
+		if token.Owner == owner {
+			tokens = append(tokens, token.APIToken)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.After(tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+// Revoke deletes the API token of the owner. Tokens of other users are not found.
+func (t *APITokens) Revoke(ctx context.Context, owner, id string) (*APIToken, error) {
+	token, err := t.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if token.Owner != owner {
+		return nil, ErrAPITokenNotFound
+	}
+	if err := t.store.Delete(ctx, apiTokenStoreKeyPrefix+id); err != nil {
+		return nil, err
+	}
+	return &token.APIToken, nil
+}
+
+// IsAPITokenRequest returns true when the request carries an API token as bearer token.
+func IsAPITokenRequest(r *http.Request) bool {
+	bearer, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return found && strings.HasPrefix(bearer, APITokenPrefix)
+}
+
+// Authenticate returns the API token carried by the request and its sessions in the clusters, among the
+// given ones, that the token is allowed to use.
+func (t *APITokens) Authenticate(r *http.Request, clusters []string) (*APIToken, UserSessions, error) {
+	bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	id, secret, found := strings.Cut(strings.TrimPrefix(bearer, APITokenPrefix), "_")
+	if !found || id == "" || secret == "" {
+		return nil, nil, ErrInvalidAPIToken
+	}
+	token, err := t.get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrAPITokenNotFound) {
+			return nil, nil, ErrInvalidAPIToken
+		}
+		return nil, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(token.Hash), []byte(hashAPITokenSecret(secret))) != 1 {
+		return nil, nil, ErrInvalidAPIToken
+	}
+	return &token.APIToken, token.sessions(clusters), nil
+}
+
+// sessions returns the sessions of the API token in the clusters it is allowed to use. The owner of the token
+// is impersonated with the Kiali SA credentials.
+func (t *storedAPIToken) sessions(clusters []string) UserSessions {
+	sessions := UserSessions{}
+	for _, cluster := range clusters {
+		if len(t.Clusters) > 0 && !slices.Contains(t.Clusters, cluster) {
+			continue
+		}
+		sessions[cluster] = &UserSessionData{
+			AuthInfo: &api.AuthInfo{
+				Impersonate:          t.Owner,
+				ImpersonateGroups:    t.Groups,
+				ImpersonateUserExtra: map[string][]string{kubernetes.ImpersonateWithSAExtra: {t.ID}},
+			},
+			ExpiresOn: t.ExpiresOn,
+			Groups:    t.Groups,
+			SessionID: APITokenPrefix + t.ID,
+			Username:  t.Owner,
+		}
+	}
+	return sessions
+}
+
+// Authorize returns an error when the restrictions of the API token do not allow the request. The namespaces
+// and clusters targeted by the request are read from the route variables and the query parameters.
+func (t *APIToken) Authorize(r *http.Request, namespaces []string, clusters []string) error {
+	if t.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return fmt.Errorf("API token [%s] is read-only", t.Name)
+	}
+	if len(t.Clusters) > 0 {
+		for _, cluster := range clusters {
+			if !slices.Contains(t.Clusters, cluster) {
+				return fmt.Errorf("API token [%s] is not allowed in cluster [%s]", t.Name, cluster)
+			}
+		}
+	}
+	if len(t.Namespaces) > 0 {
+		if len(namespaces) == 0 {
+			return fmt.Errorf("API token [%s] is restricted to namespaces [%s]: the request must target some of them", t.Name, strings.Join(t.Namespaces, ","))
+		}
+		for _, ns := range namespaces {
+			if !slices.Contains(t.Namespaces, ns) {
+				return fmt.Errorf("API token [%s] is not allowed in namespace [%s]", t.Name, ns)
+			}
+		}
+	}
+	return nil
+}
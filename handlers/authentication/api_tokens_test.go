@@ -0,0 +1,188 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+)
+
+func newTestAPITokens() (*APITokens, sessionstore.Store) {
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyOpenshift
+	conf.Auth.OpenShift.Impersonation.Enabled = true
+	conf.Auth.APITokens.Enabled = true
+	conf.Auth.APITokens.MaxExpirationSeconds = 3600
+	store := sessionstore.NewMemoryStore()
+	return NewAPITokens(conf, store), store
+}
+
+func apiTokenRequest(method, token string) *http.Request {
+	r := httptest.NewRequest(method, "/api/namespaces", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestAPITokenLifecycle(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	apiTokens, store := newTestAPITokens()
+	owner := &UserIdentity{Groups: []string{"devs"}, Username: "alice"}
+
+	tokenString, token, err := apiTokens.Create(context.Background(), owner, APITokenRequest{Name: "ci", ExpirationSeconds: 600})
+	require.NoError(err)
+	require.True(strings.HasPrefix(tokenString, APITokenPrefix+token.ID+"_"))
+	assert.Equal(t, util.Clock.Now().Add(10*time.Minute), token.ExpiresOn)
+
+	// Only the hash of the secret is stored
+	secret := strings.TrimPrefix(tokenString, APITokenPrefix+token.ID+"_")
+	stored, err := store.Get(context.Background(), apiTokenStoreKeyPrefix+token.ID)
+	require.NoError(err)
+	assert.NotContains(t, string(stored), secret)
+	assert.Contains(t, string(stored), hashAPITokenSecret(secret))
+
+	r := apiTokenRequest(http.MethodGet, tokenString)
+	require.True(IsAPITokenRequest(r))
+	authenticated, sessions, err := apiTokens.Authenticate(r, []string{"east", "west"})
+	require.NoError(err)
+	assert.Equal(t, token.ID, authenticated.ID)
+	require.Len(sessions, 2)
+	assert.Equal(t, "alice", sessions["east"].AuthInfo.Impersonate)
+	assert.Equal(t, []string{"devs"}, sessions["east"].AuthInfo.ImpersonateGroups)
+	assert.Equal(t, []string{token.ID}, sessions["east"].AuthInfo.ImpersonateUserExtra[kubernetes.ImpersonateWithSAExtra])
+	assert.Empty(t, sessions["east"].AuthInfo.Token)
+
+	tokens, err := apiTokens.List(context.Background(), "alice")
+	require.NoError(err)
+	require.Len(tokens, 1)
+	tokens, err = apiTokens.List(context.Background(), "bob")
+	require.NoError(err)
+	assert.Empty(t, tokens)
+
+	// Tokens of other users cannot be revoked
+	_, err = apiTokens.Revoke(context.Background(), "bob", token.ID)
+	require.ErrorIs(err, ErrAPITokenNotFound)
+	_, err = apiTokens.Revoke(context.Background(), "alice", token.ID)
+	require.NoError(err)
+	_, _, err = apiTokens.Authenticate(apiTokenRequest(http.MethodGet, tokenString), []string{"east"})
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+}
+
+func TestAPITokenRejectsWrongSecretAndExpiredToken(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	apiTokens, _ := newTestAPITokens()
+
+	tokenString, token, err := apiTokens.Create(context.Background(), &UserIdentity{Username: "alice"}, APITokenRequest{Name: "ci"})
+	require.NoError(err)
+	assert.Equal(t, util.Clock.Now().Add(time.Hour), token.ExpiresOn)
+
+	for _, invalid := range []string{APITokenPrefix + token.ID + "_wrong", APITokenPrefix + token.ID, APITokenPrefix + "unknown_secret"} {
+		_, _, err = apiTokens.Authenticate(apiTokenRequest(http.MethodGet, invalid), []string{"east"})
+		assert.ErrorIs(t, err, ErrInvalidAPIToken, invalid)
+	}
+
+	util.Clock = util.ClockMock{Time: token.ExpiresOn}
+	_, _, err = apiTokens.Authenticate(apiTokenRequest(http.MethodGet, tokenString), []string{"east"})
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+}
+
+func TestAPITokenCreateValidation(t *testing.T) {
+	util.Clock = util.ClockMock{Time: time.Now()}
+	apiTokens, _ := newTestAPITokens()
+
+	cases := map[string]struct {
+		owner   *UserIdentity
+		request APITokenRequest
+	}{
+		"no owner":            {request: APITokenRequest{Name: "ci"}},
+		"system user":         {owner: &UserIdentity{Username: "system:admin"}, request: APITokenRequest{Name: "ci"}},
+		"privileged group":    {owner: &UserIdentity{Username: "alice", Groups: []string{"system:masters"}}, request: APITokenRequest{Name: "ci"}},
+		"no name":             {owner: &UserIdentity{Username: "alice"}, request: APITokenRequest{Name: " "}},
+		"expiration too long": {owner: &UserIdentity{Username: "alice"}, request: APITokenRequest{Name: "ci", ExpirationSeconds: 3601}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := apiTokens.Create(context.Background(), tc.owner, tc.request)
+			assert.ErrorIs(t, err, ErrInvalidAPITokenRequest)
+		})
+	}
+}
+
+func TestAPITokenRequiresImpersonationWithSA(t *testing.T) {
+	util.Clock = util.ClockMock{Time: time.Now()}
+	apiTokens, _ := newTestAPITokens()
+	apiTokens.conf.Auth.OpenShift.Impersonation.Enabled = false
+	assert.False(t, apiTokens.Enabled())
+
+	_, _, err := apiTokens.Create(context.Background(), &UserIdentity{Username: "alice"}, APITokenRequest{Name: "ci"})
+	assert.ErrorIs(t, err, ErrInvalidAPITokenRequest)
+
+	apiTokens.conf.Auth.Strategy = config.AuthStrategyX509
+	assert.True(t, apiTokens.Enabled())
+}
+
+func TestAPITokenAuthorize(t *testing.T) {
+	token := &APIToken{Clusters: []string{"east"}, Name: "ci", Namespaces: []string{"bookinfo", "travels"}, ReadOnly: true}
+
+	cases := map[string]struct {
+		method     string
+		namespaces []string
+		clusters   []string
+		allowed    bool
+	}{
+		"read in allowed namespace":     {method: http.MethodGet, namespaces: []string{"bookinfo"}, allowed: true},
+		"read in allowed cluster":       {method: http.MethodGet, namespaces: []string{"bookinfo", "travels"}, clusters: []string{"east"}, allowed: true},
+		"write with read-only token":    {method: http.MethodPatch, namespaces: []string{"bookinfo"}},
+		"read in other namespace":       {method: http.MethodGet, namespaces: []string{"bookinfo", "istio-system"}},
+		"read in other cluster":         {method: http.MethodGet, namespaces: []string{"bookinfo"}, clusters: []string{"west"}},
+		"read in some other cluster":    {method: http.MethodGet, namespaces: []string{"bookinfo"}, clusters: []string{"east", "west"}},
+		"read not targeting namespaces": {method: http.MethodGet},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := token.Authorize(httptest.NewRequest(tc.method, "/api", nil), tc.namespaces, tc.clusters)
+			if tc.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	// Unrestricted write tokens allow everything
+	assert.NoError(t, (&APIToken{Name: "admin"}).Authorize(httptest.NewRequest(http.MethodDelete, "/api", nil), nil, []string{"west"}))
+}
+
+func TestAPITokenSessionsOnlyInAllowedClusters(t *testing.T) {
+	util.Clock = util.ClockMock{Time: time.Now()}
+	apiTokens, _ := newTestAPITokens()
+
+	tokenString, _, err := apiTokens.Create(context.Background(), &UserIdentity{Username: "alice"}, APITokenRequest{Clusters: []string{"west"}, Name: "ci"})
+	require.NoError(t, err)
+	_, sessions, err := apiTokens.Authenticate(apiTokenRequest(http.MethodGet, tokenString), []string{"east", "west"})
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Contains(t, sessions, "west")
+}
+
+func TestAPITokensDisabled(t *testing.T) {
+	var nilTokens *APITokens
+	assert.False(t, nilTokens.Enabled())
+
+	apiTokens, _ := newTestAPITokens()
+	assert.True(t, apiTokens.Enabled())
+	apiTokens.conf.Auth.APITokens.Enabled = false
+	assert.False(t, apiTokens.Enabled())
+	assert.False(t, IsAPITokenRequest(apiTokenRequest(http.MethodGet, "eyJhbGciOi")))
+}
@@ -80,6 +80,10 @@ type UserSessionData struct {
 	// required: true
 	ExpiresOn time.Time `json:"expiresOn"`
 
+	// Groups are the groups of the user, when the authentication strategy knows them.
+	// Used internally to evaluate the Kiali roles. Not exposed in API responses.
+	Groups []string `json:"-"`
+
 	// SessionID is a unique identifier for this session instance.
 	// Used internally for graph caching. Not exposed in API responses.
 	SessionID string `json:"-"`
@@ -6,8 +6,23 @@ import (
 
 type contextKey string
 
+var ContextKeyAPIToken contextKey = "apiToken"
 var ContextKeyAuthInfo contextKey = "authInfo"
 var ContextKeySessionID contextKey = "sessionID"
+var ContextKeyUserIdentity contextKey = "userIdentity"
+
+func SetAPITokenContext(ctx context.Context, token *APIToken) context.Context {
+	return context.WithValue(ctx, ContextKeyAPIToken, token)
+}
+
+// GetAPITokenContext returns the API token authenticating the request. It is nil when the request is
+// authenticated with the configured auth strategy.
+func GetAPITokenContext(ctx context.Context) *APIToken {
+	if token, ok := ctx.Value(ContextKeyAPIToken).(*APIToken); ok {
+		return token
+	}
+	return nil
+}
 
 func SetAuthInfoContext(ctx context.Context, value interface{}) context.Context {
 	return context.WithValue(ctx, ContextKeyAuthInfo, value)
@@ -29,3 +44,18 @@ func GetSessionIDContext(ctx context.Context) string {
 	}
 	return ""
 }
+
+func SetUserIdentityContext(ctx context.Context, identity *UserIdentity) context.Context {
+	return context.WithValue(ctx, ContextKeyUserIdentity, identity)
+}
+
+// GetUserIdentityContext returns the identity of the user of the request. It is nil for anonymous
+// and unauthenticated requests.
+func GetUserIdentityContext(ctx context.Context) *UserIdentity {
+	if value := ctx.Value(ContextKeyUserIdentity); value != nil {
+		if identity, ok := value.(*UserIdentity); ok {
+			return identity
+		}
+	}
+	return nil
+}
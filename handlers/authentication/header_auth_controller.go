@@ -42,7 +42,7 @@ type headerSessionPayload struct {
 // given persistor and the given businessInstantiator. The businessInstantiator can be nil and
 // the initialized controller will use the business.Get function.
 func NewHeaderAuthController(conf *config.Config, homeClusterSAClient kubernetes.ClientInterface) (*headerAuthController, error) {
-	store, err := NewCookieSessionPersistor[headerSessionPayload](conf)
+	store, err := NewSessionPersistor[headerSessionPayload](conf)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +101,7 @@ func (c headerAuthController) Authenticate(r *http.Request, w http.ResponseWrite
 	return &UserSessionData{
 		AuthInfo:  authInfo,
 		ExpiresOn: timeExpire,
+		Groups:    authInfo.ImpersonateGroups,
 		SessionID: sessionData.SessionID,
 		Username:  displayName,
 	}, nil
@@ -155,6 +156,7 @@ func (c headerAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 		conf.KubernetesConfig.ClusterName: &UserSessionData{
 			AuthInfo:  authInfo,
 			ExpiresOn: expiration,
+			Groups:    authInfo.ImpersonateGroups,
 			SessionID: sessionID,
 			Username:  subject,
 		},
@@ -0,0 +1,167 @@
+package authentication
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/kiali/kiali/config"
+)
+
+// UserIdentity is the identity of the user of a request, as seen by the Kiali roles.
+type UserIdentity struct {
+	Groups   []string
+	Username string
+}
+
+// NewUserIdentity returns the identity of the user of a session. The impersonated user, if any,
+// is the identity the cluster authorizes; the Kiali roles use the same one.
+func NewUserIdentity(session *UserSessionData) *UserIdentity {
+	identity := &UserIdentity{Groups: session.Groups, Username: session.Username}
+	if session.AuthInfo != nil && session.AuthInfo.Impersonate != "" {
+		identity.Username = session.AuthInfo.Impersonate
+		if len(identity.Groups) == 0 {
+			identity.Groups = session.AuthInfo.ImpersonateGroups
+		}
+	}
+	return identity
+}
+
+// ClusterCapabilities are the capabilities the Kiali roles grant to a user in a cluster.
+type ClusterCapabilities struct {
+	// Capabilities are granted in all the namespaces of the cluster.
+	Capabilities []string `json:"capabilities"`
+	// Namespaces lists the capabilities granted only in some namespaces, in addition to Capabilities.
+	Namespaces map[string][]string `json:"namespaces,omitempty"`
+}
+
+// HasCapability returns whether the Kiali roles grant the capability to the user in the namespace of the cluster.
+// An empty namespace asks for the capability in all the namespaces, as needed by the actions that are not
+// scoped to a namespace. All the capabilities are granted when the Kiali roles are disabled. The user can be nil
+// for anonymous access: only the default roles apply then.
+func HasCapability(conf *config.Config, identity *UserIdentity, capability, cluster, namespace string) bool {
+	rbac := conf.Auth.KialiRBAC
+	if !rbac.Enabled {
+		return true
+	}
+	for _, roleName := range rbac.DefaultRoles {
+		if roleGrants(rbac, roleName, capability) {
+			return true
+		}
+	}
+	for _, binding := range rbac.RoleBindings {
+		if !bindingMatches(binding, identity, cluster) {
+			continue
+		}
+		if len(binding.Namespaces) > 0 && (namespace == "" || !slices.Contains(binding.Namespaces, namespace)) {
+			continue
+		}
+		if roleGrants(rbac, binding.Role, capability) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserCapabilities returns the capabilities the Kiali roles grant to the user in the cluster.
+// All the capabilities are granted when the Kiali roles are disabled.
+func UserCapabilities(conf *config.Config, identity *UserIdentity, cluster string) ClusterCapabilities {
+	rbac := conf.Auth.KialiRBAC
+	if !rbac.Enabled {
+		return ClusterCapabilities{Capabilities: slices.Clone(config.KialiCapabilities)}
+	}
+
+	clusterWide := map[string]bool{}
+	for _, roleName := range rbac.DefaultRoles {
+		addRoleCapabilities(rbac, roleName, clusterWide)
+	}
+	namespaced := map[string]map[string]bool{}
+	for _, binding := range rbac.RoleBindings {
+		if !bindingMatches(binding, identity, cluster) {
+			continue
+		}
+		if len(binding.Namespaces) == 0 {
+			addRoleCapabilities(rbac, binding.Role, clusterWide)
+			continue
+		}
+		for _, ns := range binding.Namespaces {
+			if namespaced[ns] == nil {
+				namespaced[ns] = map[string]bool{}
+			}
+			addRoleCapabilities(rbac, binding.Role, namespaced[ns])
+		}
+	}
+
+	result := ClusterCapabilities{Capabilities: sortedCapabilities(clusterWide, nil)}
+	for ns, capabilities := range namespaced {
+		if extra := sortedCapabilities(capabilities, clusterWide); len(extra) > 0 {
+			if result.Namespaces == nil {
+				result.Namespaces = map[string][]string{}
+			}
+			result.Namespaces[ns] = extra
+		}
+	}
+	return result
+}
+
+// bindingMatches returns whether the binding applies to the user in the cluster.
+func bindingMatches(binding config.KialiRoleBinding, identity *UserIdentity, cluster string) bool {
+	if identity == nil {
+		return false
+	}
+	if len(binding.Clusters) > 0 && !slices.Contains(binding.Clusters, cluster) {
+		return false
+	}
+	if identity.Username != "" && slices.Contains(binding.Users, identity.Username) {
+		return true
+	}
+	for _, g := range identity.Groups {
+		if slices.Contains(binding.Groups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+func findRole(rbac config.KialiRBACConfig, name string) *config.KialiRole {
+	for i := range rbac.Roles {
+		if rbac.Roles[i].Name == name {
+			return &rbac.Roles[i]
+		}
+	}
+	return nil
+}
+
+func roleGrants(rbac config.KialiRBACConfig, roleName, capability string) bool {
+	role := findRole(rbac, roleName)
+	if role == nil {
+		return false
+	}
+	return slices.Contains(role.Capabilities, config.KialiCapabilityAll) || slices.Contains(role.Capabilities, capability)
+}
+
+func addRoleCapabilities(rbac config.KialiRBACConfig, roleName string, capabilities map[string]bool) {
+	role := findRole(rbac, roleName)
+	if role == nil {
+		return
+	}
+	for _, c := range role.Capabilities {
+		if c == config.KialiCapabilityAll {
+			for _, all := range config.KialiCapabilities {
+				capabilities[all] = true
+			}
+			continue
+		}
+		capabilities[c] = true
+	}
+}
+
+func sortedCapabilities(capabilities, exclude map[string]bool) []string {
+	result := make([]string, 0, len(capabilities))
+	for c := range capabilities {
+		if !exclude[c] {
+			result = append(result, c)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,94 @@
+package authentication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/config"
+)
+
+func kialiRolesTestConfig() *config.Config {
+	conf := config.NewConfig()
+	conf.Auth.KialiRBAC = config.KialiRBACConfig{
+		DefaultRoles: []string{"viewer"},
+		Enabled:      true,
+		RoleBindings: []config.KialiRoleBinding{
+			{Groups: []string{"mesh-admins"}, Role: "admin"},
+			{Clusters: []string{"east"}, Namespaces: []string{"bookinfo"}, Role: "editor", Users: []string{"jdoe"}},
+		},
+		Roles: []config.KialiRole{
+			{Name: "admin", Capabilities: []string{config.KialiCapabilityAll}},
+			{Name: "editor", Capabilities: []string{config.KialiCapabilityEditIstioConfig, config.KialiCapabilityChangeProxyLogLevel}},
+			{Name: "viewer", Capabilities: []string{config.KialiCapabilityViewGraph}},
+		},
+	}
+	return conf
+}
+
+func TestHasCapability(t *testing.T) {
+	conf := kialiRolesTestConfig()
+	jdoe := &UserIdentity{Username: "jdoe", Groups: []string{"devs"}}
+	admin := &UserIdentity{Username: "alice", Groups: []string{"mesh-admins"}}
+
+	cases := map[string]struct {
+		identity   *UserIdentity
+		capability string
+		cluster    string
+		namespace  string
+		expected   bool
+	}{
+		"default role for anonymous":          {nil, config.KialiCapabilityViewGraph, "east", "bookinfo", true},
+		"nothing else for anonymous":          {nil, config.KialiCapabilityEditIstioConfig, "east", "bookinfo", false},
+		"namespaced binding":                  {jdoe, config.KialiCapabilityEditIstioConfig, "east", "bookinfo", true},
+		"namespaced binding in other ns":      {jdoe, config.KialiCapabilityEditIstioConfig, "east", "default", false},
+		"namespaced binding in other cluster": {jdoe, config.KialiCapabilityEditIstioConfig, "west", "bookinfo", false},
+		"namespaced binding without ns":       {jdoe, config.KialiCapabilityEditIstioConfig, "east", "", false},
+		"capability not in role":              {jdoe, config.KialiCapabilityViewSecrets, "east", "bookinfo", false},
+		"group binding with all":              {admin, config.KialiCapabilityViewSecrets, "west", "", true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, HasCapability(conf, tc.identity, tc.capability, tc.cluster, tc.namespace))
+		})
+	}
+
+	// All the capabilities are granted when the Kiali roles are disabled
+	conf.Auth.KialiRBAC.Enabled = false
+	assert.True(t, HasCapability(conf, nil, config.KialiCapabilityViewSecrets, "east", ""))
+}
+
+func TestUserCapabilities(t *testing.T) {
+	conf := kialiRolesTestConfig()
+	jdoe := &UserIdentity{Username: "jdoe"}
+
+	capabilities := UserCapabilities(conf, jdoe, "east")
+	assert.Equal(t, []string{config.KialiCapabilityViewGraph}, capabilities.Capabilities)
+	assert.Equal(t, map[string][]string{
+		"bookinfo": {config.KialiCapabilityChangeProxyLogLevel, config.KialiCapabilityEditIstioConfig},
+	}, capabilities.Namespaces)
+
+	capabilities = UserCapabilities(conf, jdoe, "west")
+	assert.Equal(t, []string{config.KialiCapabilityViewGraph}, capabilities.Capabilities)
+	assert.Empty(t, capabilities.Namespaces)
+
+	capabilities = UserCapabilities(conf, &UserIdentity{Username: "alice", Groups: []string{"mesh-admins"}}, "west")
+	assert.ElementsMatch(t, config.KialiCapabilities, capabilities.Capabilities)
+
+	conf.Auth.KialiRBAC.Enabled = false
+	assert.ElementsMatch(t, config.KialiCapabilities, UserCapabilities(conf, nil, "east").Capabilities)
+}
+
+func TestNewUserIdentityUsesImpersonatedUser(t *testing.T) {
+	identity := NewUserIdentity(&UserSessionData{
+		AuthInfo: &api.AuthInfo{Impersonate: "jdoe", ImpersonateGroups: []string{"devs"}},
+		Username: "kiali-proxy",
+	})
+	assert.Equal(t, "jdoe", identity.Username)
+	assert.Equal(t, []string{"devs"}, identity.Groups)
+
+	identity = NewUserIdentity(&UserSessionData{AuthInfo: &api.AuthInfo{Token: "token"}, Groups: []string{"ops"}, Username: "alice"})
+	assert.Equal(t, "alice", identity.Username)
+	assert.Equal(t, []string{"ops"}, identity.Groups)
+}
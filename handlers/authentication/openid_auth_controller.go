@@ -76,6 +76,9 @@ type oidcSessionPayload struct {
 	// TerminateSession uses IdToken for id_token_hint, falling back to Token when empty.
 	IdToken string `json:"id_token,omitempty"`
 
+	// Groups are read from the configured groups claim of the id_token. They are only used by the Kiali roles.
+	Groups []string `json:"groups,omitempty"`
+
 	// Subject is the resolved name of the user that logged into Kiali.
 	Subject string `json:"subject,omitempty"`
 
@@ -83,6 +86,9 @@ type oidcSessionPayload struct {
 	// the access_token, depending on the Kiali configuration. If RBAC is enabled,
 	// this is the token that can be used against the Kubernetes API.
 	Token string `json:"token,omitempty"`
+
+	// ClusterTokens are the tokens of the clusters configured for token exchange, by cluster.
+	ClusterTokens map[string]openIdClusterToken `json:"cluster_tokens,omitempty"`
 }
 
 // badOidcRequest is a helper type implementing Go's error interface. It's used to assist in
@@ -144,7 +150,7 @@ func NewOpenIdAuthController(kialiCache cache.KialiCache, clientFactory kubernet
 		log.Warning("OpenID auth strategy is active but server.web_fqdn is not set. The OIDC redirect_uri will be derived from request headers, which can be manipulated if Kiali is not behind a trusted proxy. Set server.web_fqdn to a known hostname to prevent this.")
 	}
 
-	store, err := NewCookieSessionPersistor[oidcSessionPayload](conf)
+	store, err := NewSessionPersistor[oidcSessionPayload](conf)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +206,45 @@ func (c OpenIdAuthController) PostRoutes(router *mux.Router) {
 		Path("/api/auth/openid_redirect").
 		Name("OpenIdRedirect").
 		HandlerFunc(c.redirectToAuthServerHandler)
+
+	// swagger:route GET /auth/openid_redirect/{cluster} auth openidClusterRedirect
+	// ---
+	// Endpoint to redirect the browser of the user to the authentication
+	// endpoint of the OpenId provider of a cluster configured for login.
+	//
+	//     Produces:
+	//     - application/html
+	//
+	//     Schemes: http, https
+	//
+	// responses:
+	//      500: internalError
+	//      404: notFoundError
+	//      302: noContent
+	router.
+		Methods("GET").
+		Path("/api/auth/openid_redirect/{cluster}").
+		Name("OpenIdClusterRedirect").
+		HandlerFunc(c.redirectToClusterAuthServerHandler)
+
+	// swagger:route GET /auth/openid_callback/{cluster} auth openidClusterCallback
+	// ---
+	// Endpoint where the OpenId provider of a cluster configured for login
+	// redirects the browser of the user after the authentication.
+	//
+	//     Produces:
+	//     - application/html
+	//
+	//     Schemes: http, https
+	//
+	// responses:
+	//      404: notFoundError
+	//      302: noContent
+	router.
+		Methods("GET").
+		Path("/api/auth/openid_callback/{cluster}").
+		Name("OpenIdClusterCallback").
+		HandlerFunc(c.clusterAuthCallbackHandler)
 }
 
 // ValidateSession restores a session previously created by the Authenticate function. A sanity check of
@@ -253,12 +298,18 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 	userSessions := make(UserSessions)
 	if !c.conf.Auth.OpenId.DisableRBAC {
 		// If RBAC is ENABLED, check that the user has privileges on the cluster.
+		// Clusters trusting another issuer get their own credential; clusters without one are left out.
+		sessionChanged := false
 		for cluster := range c.clientFactory.GetSAClients() {
-			userSessions[cluster] = &UserSessionData{
-				AuthInfo:  &api.AuthInfo{Token: sData.Payload.Token},
-				ExpiresOn: sData.ExpiresOn,
-				SessionID: sData.SessionID,
-				Username:  sData.Payload.Subject,
+			session, changed := c.clusterSession(r, w, cluster, sData)
+			if session != nil {
+				userSessions[cluster] = session
+			}
+			sessionChanged = sessionChanged || changed
+		}
+		if sessionChanged {
+			if err := c.SessionStore.CreateSession(r, w, *sData); err != nil {
+				log.Warningf("Could not save the cluster tokens in the session: %v", err)
 			}
 		}
 		userClients, err := c.clientFactory.GetClients(userSessions.GetAuthInfos())
@@ -282,6 +333,7 @@ func (c OpenIdAuthController) ValidateSession(r *http.Request, w http.ResponseWr
 			userSessions[cluster] = &UserSessionData{
 				AuthInfo:  &api.AuthInfo{Token: token},
 				ExpiresOn: sData.ExpiresOn,
+				Groups:    sData.Payload.Groups,
 				SessionID: sData.SessionID,
 				Username:  sData.Payload.Subject,
 			}
@@ -312,6 +364,9 @@ func (c OpenIdAuthController) TerminateSession(r *http.Request, w http.ResponseW
 	}
 
 	c.SessionStore.TerminateSession(r, w, c.conf.KubernetesConfig.ClusterName)
+	for _, cluster := range c.loginClusters() {
+		c.SessionStore.TerminateSession(r, w, cluster)
+	}
 
 	metadata, err := getOpenIdMetadata(c.conf)
 	if err != nil {
@@ -555,6 +610,9 @@ type openidFlowHelper struct {
 	// ExpiresOn is the expiration time of the id_token.
 	ExpiresOn time.Time
 
+	// Groups is the resolved list of groups of the person that authenticated through an OpenId server.
+	Groups []string
+
 	// IdToken is the identity token provided by the OpenId server, either during the callback
 	// of the implicit flow, or on the request to exchange the authorization code.
 	IdToken string
@@ -773,7 +831,7 @@ func (p *openidFlowHelper) checkUserPrivileges() *openidFlowHelper {
 			apiToken = p.AccessToken
 			p.UseAccessToken = true
 		}
-		httpStatus, errMsg, detailedError := verifyOpenIdUserAccess(apiToken, p.clientFactory, p.kialiCache, p.conf, p.discovery)
+		httpStatus, errMsg, detailedError := verifyOpenIdUserAccess(apiToken, p.conf.KubernetesConfig.ClusterName, p.clientFactory, p.kialiCache, p.conf, p.discovery)
 		if httpStatus != http.StatusOK {
 			p.Error = &AuthenticationFailureError{
 				HttpStatus: httpStatus,
@@ -871,9 +929,30 @@ func (p *openidFlowHelper) parseOpenIdToken() *openidFlowHelper {
 		}
 	}
 
+	// Extract the groups of the user from the id_token.
+	if groupsClaim := p.conf.Auth.OpenId.GroupsClaim; groupsClaim != "" {
+		p.Groups = parseGroupsClaim(claims[groupsClaim])
+	}
+
 	return p
 }
 
+// parseGroupsClaim returns the groups of a groups claim. Groups claims are either a list or a single string.
+func parseGroupsClaim(claim interface{}) []string {
+	var result []string
+	switch groups := claim.(type) {
+	case string:
+		result = []string{groups}
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok && len(s) > 0 {
+				result = append(result, s)
+			}
+		}
+	}
+	return result
+}
+
 // validateOpenIdNonceCode checks that the nonce hash that is present in the id_token is the right
 // hash, given the nonce code present in the http cookie.
 //
@@ -1052,6 +1131,7 @@ func buildSessionPayload(openIdParams *openidFlowHelper) *oidcSessionPayload {
 	}
 
 	payload := &oidcSessionPayload{
+		Groups:  openIdParams.Groups,
 		Subject: openIdParams.Subject,
 		Token:   token,
 	}
@@ -1341,48 +1421,11 @@ func getOpenIdMetadata(conf *config.Config) (*openIdMetadata, error) {
 		// Original auto-discovery logic continues here...
 		log.Infof("Using OpenID auto-discovery from provider")
 
-		// Remove trailing slash from issuer URI, if needed
-		trimmedIssuerUri := strings.TrimRight(cfg.IssuerUri, "/")
-
-		httpClient, err := createHttpClient(conf, trimmedIssuerUri)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create http client to fetch OpenId Metadata: %w", err)
-		}
-
-		// Fetch IdP metadata
-		response, err := httpClient.Get(trimmedIssuerUri + "/.well-known/openid-configuration")
+		metadata, err := discoverOpenIdMetadata(conf, cfg.IssuerUri)
 		if err != nil {
 			return nil, err
 		}
 
-		defer response.Body.Close()
-		if response.StatusCode != 200 {
-			return nil, fmt.Errorf("cannot fetch OpenId Metadata (HTTP response status = %s)", response.Status)
-		}
-
-		// Parse JSON document
-		var metadata openIdMetadata
-
-		rawMetadata, err := io.ReadAll(response.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read OpenId Metadata: %s", err.Error())
-		}
-
-		err = json.Unmarshal(rawMetadata, &metadata)
-		if err != nil {
-			return nil, fmt.Errorf("cannot parse OpenId Metadata: %s", err.Error())
-		}
-
-		// Validate issuer == issuerUri
-		if metadata.Issuer != cfg.IssuerUri {
-			return nil, fmt.Errorf("mismatch between the configured issuer_uri (%s) and the exposed Issuer URI in OpenId provider metadata (%s)", cfg.IssuerUri, metadata.Issuer)
-		}
-
-		// Validate there is an authorization endpoint
-		if len(metadata.AuthURL) == 0 {
-			return nil, errors.New("the OpenID provider does not expose an authorization endpoint")
-		}
-
 		// Log warning if OpenId provider informs that some of the configured scopes are not supported
 		// It's possible to try authentication. If metadata is right, the error will be evident to the user when trying to login.
 		scopes := getConfiguredOpenIdScopes(conf)
@@ -1402,8 +1445,8 @@ func getOpenIdMetadata(conf *config.Config) (*openIdMetadata, error) {
 		}
 
 		// Return parsed metadata
-		cachedOpenIdMetadata.Store(&metadata)
-		return &metadata, nil
+		cachedOpenIdMetadata.Store(metadata)
+		return metadata, nil
 	})
 
 	if fetchError != nil {
@@ -1413,6 +1456,54 @@ func getOpenIdMetadata(conf *config.Config) (*openIdMetadata, error) {
 	return fetchedMetadata.(*openIdMetadata), nil
 }
 
+// discoverOpenIdMetadata fetches the metadata of the OpenID provider from its /.well-known/openid-configuration
+// endpoint, and checks that it is the metadata of the issuer.
+func discoverOpenIdMetadata(conf *config.Config, issuerUri string) (*openIdMetadata, error) {
+	// Remove trailing slash from issuer URI, if needed
+	trimmedIssuerUri := strings.TrimRight(issuerUri, "/")
+
+	httpClient, err := createHttpClient(conf, trimmedIssuerUri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client to fetch OpenId Metadata: %w", err)
+	}
+
+	// Fetch IdP metadata
+	response, err := httpClient.Get(trimmedIssuerUri + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	if response.StatusCode != 200 {
+		return nil, fmt.Errorf("cannot fetch OpenId Metadata (HTTP response status = %s)", response.Status)
+	}
+
+	// Parse JSON document
+	var metadata openIdMetadata
+
+	rawMetadata, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenId Metadata: %s", err.Error())
+	}
+
+	err = json.Unmarshal(rawMetadata, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse OpenId Metadata: %s", err.Error())
+	}
+
+	// Validate issuer == issuerUri
+	if metadata.Issuer != issuerUri {
+		return nil, fmt.Errorf("mismatch between the configured issuer_uri (%s) and the exposed Issuer URI in OpenId provider metadata (%s)", issuerUri, metadata.Issuer)
+	}
+
+	// Validate there is an authorization endpoint
+	if len(metadata.AuthURL) == 0 {
+		return nil, errors.New("the OpenID provider does not expose an authorization endpoint")
+	}
+
+	return &metadata, nil
+}
+
 // getOpenIdAuthorizationEndpoint returns the URL used to start the OpenID authorization code flow.
 //
 // Precedence:
@@ -1646,15 +1737,14 @@ func validateOpenIdTokenInHouse(openIdParams *openidFlowHelper) error {
 }
 
 // verifyOpenIdUserAccess checks that the provided token has enough privileges on the cluster to
-// allow a login to Kiali.
-func verifyOpenIdUserAccess(token string, clientFactory kubernetes.ClientFactory, kialiCache cache.KialiCache, conf *config.Config, discovery *istio.Discovery) (int, string, error) {
+// allow a login to Kiali, or to the cluster.
+func verifyOpenIdUserAccess(token string, cluster string, clientFactory kubernetes.ClientFactory, kialiCache cache.KialiCache, conf *config.Config, discovery *istio.Discovery) (int, string, error) {
 	authInfo := &api.AuthInfo{Token: token}
-	// TODO: Multicluster
-	userClient, err := clientFactory.GetClient(authInfo, conf.KubernetesConfig.ClusterName)
+	userClient, err := clientFactory.GetClient(authInfo, cluster)
 	if err != nil {
 		return http.StatusInternalServerError, "Unable to create a Kubernetes client from the auth token", err
 	}
-	userClients := map[string]kubernetes.UserClientInterface{conf.KubernetesConfig.ClusterName: userClient}
+	userClients := map[string]kubernetes.UserClientInterface{cluster: userClient}
 
 	namespaceService := business.NewNamespaceService(kialiCache, conf, discovery, clientFactory.GetSAClients(), userClients)
 
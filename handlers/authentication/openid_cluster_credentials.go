@@ -0,0 +1,471 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/config"
+	jwtpkg "github.com/kiali/kiali/jwt"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/util"
+	"github.com/kiali/kiali/util/httputil"
+)
+
+// The token types and grant type of the OAuth 2.0 token exchange (RFC 8693)
+const (
+	tokenExchangeGrantType      = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeAccessToken        = "urn:ietf:params:oauth:token-type:access_token"
+	tokenTypeIdToken            = "urn:ietf:params:oauth:token-type:id_token"
+	openIdClusterCallbackPath   = "/api/auth/openid_callback/"
+	openIdClusterTokenRenewTime = time.Minute
+)
+
+// cachedOpenIdClusterMetadata stores the metadata of the OpenID providers of the remote clusters, by issuer.
+var cachedOpenIdClusterMetadata sync.Map
+
+// openIdClusterToken is a token of a remote cluster obtained by token exchange. It is kept in the session
+// of the Kiali login until it expires.
+type openIdClusterToken struct {
+	ExpiresOn time.Time `json:"expires_on"`
+	Token     string    `json:"token"`
+}
+
+// getOpenIdClusterMetadata returns the metadata of the OpenID provider of a remote cluster. The configured
+// endpoints take precedence over the discovered ones; discovery is skipped when all the needed ones are configured.
+func getOpenIdClusterMetadata(conf *config.Config, cluster config.OpenIdClusterConfig) (*openIdMetadata, error) {
+	metadata := &openIdMetadata{Issuer: cluster.IssuerUri, ResponseTypesSupported: []string{"code"}}
+	needsAuthURL := cluster.Mode == config.OpenIdClusterModeLogin && cluster.AuthorizationEndpoint == ""
+	if cluster.TokenEndpoint == "" || needsAuthURL {
+		if cached, ok := cachedOpenIdClusterMetadata.Load(cluster.IssuerUri); ok {
+			discovered := *cached.(*openIdMetadata)
+			metadata = &discovered
+		} else {
+			discovered, err, _ := openIdFlightGroup.Do("metadata:"+cluster.IssuerUri, func() (interface{}, error) {
+				discovered, err := discoverOpenIdMetadata(conf, cluster.IssuerUri)
+				if err != nil {
+					return nil, err
+				}
+				cachedOpenIdClusterMetadata.Store(cluster.IssuerUri, discovered)
+				return discovered, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			copied := *discovered.(*openIdMetadata)
+			metadata = &copied
+		}
+	}
+	if cluster.AuthorizationEndpoint != "" {
+		metadata.AuthURL = cluster.AuthorizationEndpoint
+	}
+	if cluster.TokenEndpoint != "" {
+		metadata.TokenURL = cluster.TokenEndpoint
+	}
+	return metadata, nil
+}
+
+// openIdClusterTokenType returns the token type (RFC 8693) of the configured api_token.
+func openIdClusterTokenType(apiToken string) string {
+	if apiToken == "access_token" {
+		return tokenTypeAccessToken
+	}
+	return tokenTypeIdToken
+}
+
+// requestOpenIdClusterToken posts a request to the token endpoint of the OpenID provider of a remote cluster,
+// authenticated with the client of the cluster, and returns the parsed response.
+func requestOpenIdClusterToken(ctx context.Context, conf *config.Config, cluster config.OpenIdClusterConfig, params url.Values, response any) error {
+	metadata, err := getOpenIdClusterMetadata(conf, cluster)
+	if err != nil {
+		return err
+	}
+	httpClient, err := createHttpClient(conf, metadata.TokenURL)
+	if err != nil {
+		return fmt.Errorf("failure when creating http client to request the token: %w", err)
+	}
+	clientSecret, err := conf.GetCredential(cluster.ClientSecret)
+	if err != nil {
+		return fmt.Errorf("failed to read the OpenID client secret: %w", err)
+	}
+	if len(clientSecret) == 0 {
+		params.Set("client_id", cluster.ClientId)
+	}
+
+	tokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return fmt.Errorf("failure when creating the token request: %w", err)
+	}
+	if len(clientSecret) > 0 {
+		tokenRequest.SetBasicAuth(url.QueryEscape(cluster.ClientId), url.QueryEscape(clientSecret))
+	}
+	tokenRequest.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResponse, err := httpClient.Do(tokenRequest)
+	if err != nil {
+		return fmt.Errorf("failure when requesting token from IdP: %w", err)
+	}
+	defer httpResponse.Body.Close()
+	rawResponse, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read token response from IdP: %w", err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		log.Debugf("OpenId token request failed with status %s (response body: %d bytes)", httpResponse.Status, len(rawResponse))
+		return fmt.Errorf("request failed (HTTP response status = %s)", httpResponse.Status)
+	}
+	if err := json.Unmarshal(rawResponse, response); err != nil {
+		return fmt.Errorf("cannot parse OpenId token response: %w", err)
+	}
+	return nil
+}
+
+// exchangeOpenIdToken exchanges the token of the Kiali login for a token of a remote cluster (RFC 8693),
+// at the token endpoint of the issuer trusted by the cluster. The token expires at the latest with the session.
+func exchangeOpenIdToken(ctx context.Context, conf *config.Config, cluster config.OpenIdClusterConfig, subjectToken string, sessionExpiresOn time.Time) (*openIdClusterToken, error) {
+	params := url.Values{}
+	params.Set("grant_type", tokenExchangeGrantType)
+	params.Set("subject_token", subjectToken)
+	params.Set("subject_token_type", openIdClusterTokenType(conf.Auth.OpenId.ApiToken))
+	params.Set("requested_token_type", openIdClusterTokenType(cluster.ApiToken))
+	if cluster.Audience != "" {
+		params.Set("audience", cluster.Audience)
+	}
+
+	// The issued token is returned in access_token, whatever its type
+	var response struct {
+		AccessToken     string `json:"access_token"`
+		ExpiresIn       int64  `json:"expires_in"`
+		IssuedTokenType string `json:"issued_token_type"`
+	}
+	if err := requestOpenIdClusterToken(ctx, conf, cluster, params, &response); err != nil {
+		return nil, fmt.Errorf("token exchange failed: %w", err)
+	}
+	if response.AccessToken == "" {
+		return nil, errors.New("token exchange failed: the IdP did not issue a token")
+	}
+
+	token := &openIdClusterToken{ExpiresOn: sessionExpiresOn, Token: response.AccessToken}
+	if response.ExpiresIn > 0 {
+		token.ExpiresOn = util.Clock.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	} else if expiresOn, ok := tokenExpiration(response.AccessToken); ok {
+		token.ExpiresOn = expiresOn
+	}
+	if token.ExpiresOn.After(sessionExpiresOn) {
+		token.ExpiresOn = sessionExpiresOn
+	}
+	return token, nil
+}
+
+// tokenExpiration returns the exp claim of a JWT, without verifying it.
+func tokenExpiration(token string) (time.Time, bool) {
+	parsed, err := jwtpkg.ParseSigned(token)
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims map[string]interface{}
+	if err := parsed.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		return time.Time{}, false
+	}
+	exp, ok := claims["exp"]
+	if !ok {
+		return time.Time{}, false
+	}
+	expiresOn, err := parseTimeClaim(exp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(expiresOn, 0), true
+}
+
+// clusterSession returns the session of the user in a cluster, given the session of the Kiali login. The token of
+// the Kiali login is used unless the cluster has its own OpenID configuration. A nil session is returned when the
+// user has no credential for the cluster. The second return value is true when the Kiali login session changed
+// and must be saved.
+func (c OpenIdAuthController) clusterSession(r *http.Request, w http.ResponseWriter, cluster string, sData *SessionData[oidcSessionPayload]) (*UserSessionData, bool) {
+	session := &UserSessionData{
+		AuthInfo:  &api.AuthInfo{Token: sData.Payload.Token},
+		ExpiresOn: sData.ExpiresOn,
+		Groups:    sData.Payload.Groups,
+		SessionID: sData.SessionID,
+		Username:  sData.Payload.Subject,
+	}
+	clusterConf, ok := c.conf.Auth.OpenId.Clusters[cluster]
+	if !ok || cluster == c.conf.KubernetesConfig.ClusterName {
+		return session, false
+	}
+
+	switch clusterConf.Mode {
+	case config.OpenIdClusterModeTokenExchange:
+		token, found := sData.Payload.ClusterTokens[cluster]
+		if found && util.Clock.Now().Add(openIdClusterTokenRenewTime).Before(token.ExpiresOn) {
+			session.AuthInfo.Token = token.Token
+			session.ExpiresOn = token.ExpiresOn
+			return session, false
+		}
+		exchanged, err := exchangeOpenIdToken(r.Context(), c.conf, clusterConf, sData.Payload.Token, sData.ExpiresOn)
+		if err != nil {
+			log.Warningf("Unable to get a token for cluster [%s]: %v", cluster, err)
+			return nil, false
+		}
+		if sData.Payload.ClusterTokens == nil {
+			sData.Payload.ClusterTokens = map[string]openIdClusterToken{}
+		}
+		sData.Payload.ClusterTokens[cluster] = *exchanged
+		session.AuthInfo.Token = exchanged.Token
+		session.ExpiresOn = exchanged.ExpiresOn
+		return session, true
+	case config.OpenIdClusterModeLogin:
+		clusterData, err := c.SessionStore.ReadSession(r, w, cluster)
+		if err != nil {
+			log.Tracef("No session in cluster [%s]: %v", cluster, err)
+			return nil, false
+		}
+		return &UserSessionData{
+			AuthInfo:  &api.AuthInfo{Token: clusterData.Payload.Token},
+			ExpiresOn: clusterData.ExpiresOn,
+			Groups:    clusterData.Payload.Groups,
+			SessionID: sData.SessionID,
+			Username:  clusterData.Payload.Subject,
+		}, false
+	}
+	return nil, false
+}
+
+// loginClusters returns the clusters where the user logs in after the Kiali login.
+func (c OpenIdAuthController) loginClusters() []string {
+	var clusters []string
+	for name, cluster := range c.conf.Auth.OpenId.Clusters {
+		if cluster.Mode == config.OpenIdClusterModeLogin {
+			clusters = append(clusters, name)
+		}
+	}
+	return clusters
+}
+
+// openIdClusterState returns the state parameter of the login in a cluster: a CSRF token bound to the nonce, the
+// time, the cluster and the Kiali signing key, followed by the time.
+func openIdClusterState(nonce, timestamp, cluster, signingKey string) string {
+	csrfHash := sha256.Sum224([]byte(fmt.Sprintf("%s+%s+%s+%s", nonce, timestamp, cluster, signingKey)))
+	return fmt.Sprintf("%x-%s", csrfHash, timestamp)
+}
+
+// loginCluster returns the name and configuration of the cluster of a login request.
+func (c OpenIdAuthController) loginCluster(r *http.Request) (string, config.OpenIdClusterConfig, bool) {
+	cluster := mux.Vars(r)["cluster"]
+	clusterConf, ok := c.conf.Auth.OpenId.Clusters[cluster]
+	return cluster, clusterConf, ok && clusterConf.Mode == config.OpenIdClusterModeLogin && c.conf.Auth.Strategy == config.AuthStrategyOpenId
+}
+
+// redirectToClusterAuthServerHandler starts the login of the user in the OpenID provider of a remote cluster,
+// once logged in Kiali. It works like redirectToAuthServerHandler, with the client of the cluster.
+func (c OpenIdAuthController) redirectToClusterAuthServerHandler(w http.ResponseWriter, r *http.Request) {
+	cluster, clusterConf, ok := c.loginCluster(r)
+	if !ok {
+		http.Error(w, "OpenID login is not enabled for the cluster", http.StatusNotFound)
+		return
+	}
+	if _, err := c.SessionStore.ReadSession(r, w, c.conf.KubernetesConfig.ClusterName); err != nil {
+		http.Error(w, "Log in to Kiali before logging in to a cluster", http.StatusUnauthorized)
+		return
+	}
+
+	signingKey, err := c.conf.GetCredential(c.conf.LoginToken.SigningKey)
+	if err != nil {
+		http.Error(w, "Error reading signing key: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	metadata, err := getOpenIdClusterMetadata(c.conf, clusterConf)
+	if err != nil {
+		log.Errorf("Error fetching OpenID provider metadata of cluster [%s]: %v", cluster, err)
+		http.Error(w, "Error fetching OpenID provider metadata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nonceCode, err := util.CryptoRandomString(15)
+	if err != nil {
+		http.Error(w, "Random number generator failed", http.StatusInternalServerError)
+		return
+	}
+	codeVerifier, err := generatePKCECodeVerifier()
+	if err != nil {
+		http.Error(w, "Failed to generate PKCE code verifier", http.StatusInternalServerError)
+		return
+	}
+
+	guessedKialiURL := httputil.GuessKialiURL(c.conf, r)
+	secureFlag := c.conf.IsServerHTTPS() || strings.HasPrefix(guessedKialiURL, "https:")
+	nowTime := util.Clock.Now()
+	expirationTime := nowTime.Add(time.Duration(c.conf.Auth.OpenId.AuthenticationTimeout) * time.Second)
+	// Lax cookies: the callback is a cross-site navigation from the identity provider
+	for name, value := range map[string]string{nonceCookieName(cluster): nonceCode, codeVerifierCookieName(cluster): codeVerifier} {
+		http.SetCookie(w, &http.Cookie{
+			Expires:  expirationTime,
+			HttpOnly: true,
+			Secure:   secureFlag,
+			Name:     name,
+			Path:     c.conf.Server.WebRoot,
+			SameSite: http.SameSiteLaxMode,
+			Value:    value,
+		})
+	}
+
+	scopes := getConfiguredOpenIdScopes(&config.Config{Auth: config.AuthConfig{OpenId: config.OpenIdConfig{Scopes: clusterConf.Scopes}}})
+	params := url.Values{}
+	params.Set("client_id", clusterConf.ClientId)
+	params.Set("response_type", "code")
+	params.Set("redirect_uri", guessedKialiURL+openIdClusterCallbackPath+url.PathEscape(cluster))
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("nonce", fmt.Sprintf("%x", sha256.Sum224([]byte(nonceCode))))
+	params.Set("state", openIdClusterState(nonceCode, nowTime.UTC().Format("060102150405"), cluster, signingKey))
+	params.Set("code_challenge", generatePKCECodeChallenge(codeVerifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthURL, "?") {
+		separator = "&"
+	}
+	http.Redirect(w, r, metadata.AuthURL+separator+params.Encode(), http.StatusFound)
+}
+
+// clusterAuthCallbackHandler completes the login of the user in the OpenID provider of a remote cluster and
+// creates the session of the cluster.
+func (c OpenIdAuthController) clusterAuthCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	webRootWithSlash := c.conf.Server.WebRoot + "/"
+	cluster, clusterConf, ok := c.loginCluster(r)
+	if !ok {
+		http.Error(w, "OpenID login is not enabled for the cluster", http.StatusNotFound)
+		return
+	}
+	fail := func(reason string, err error) {
+		log.Warningf("Authentication in cluster [%s] rejected: %s: %v", cluster, reason, err)
+		http.Redirect(w, r, fmt.Sprintf("%s?openid_error=%s&cluster=%s", webRootWithSlash, url.QueryEscape(reason), url.QueryEscape(cluster)), http.StatusFound)
+	}
+
+	nonceCookie, nonceErr := r.Cookie(nonceCookieName(cluster))
+	codeVerifierCookie, codeVerifierErr := r.Cookie(codeVerifierCookieName(cluster))
+	secureFlag := c.conf.IsServerHTTPS() || strings.HasPrefix(httputil.GuessKialiURL(c.conf, r), "https:")
+	for _, name := range []string{nonceCookieName(cluster), codeVerifierCookieName(cluster)} {
+		http.SetCookie(w, &http.Cookie{
+			Expires:  time.Unix(0, 0),
+			HttpOnly: true,
+			MaxAge:   -1,
+			Name:     name,
+			Path:     c.conf.Server.WebRoot,
+			Secure:   secureFlag,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+	if nonceErr != nil || codeVerifierErr != nil {
+		fail("login window may have timed out or cookies were blocked", errors.Join(nonceErr, codeVerifierErr))
+		return
+	}
+	if idpError := r.FormValue("error"); idpError != "" {
+		fail("the OpenID provider rejected the login: "+idpError, errors.New(r.FormValue("error_description")))
+		return
+	}
+	code, state := r.FormValue("code"), r.FormValue("state")
+	if code == "" || state == "" {
+		fail("the OpenID provider did not return an authorization code", nil)
+		return
+	}
+
+	homeSession, err := c.SessionStore.ReadSession(r, w, c.conf.KubernetesConfig.ClusterName)
+	if err != nil {
+		fail("log in to Kiali before logging in to a cluster", err)
+		return
+	}
+	signingKey, err := c.conf.GetCredential(c.conf.LoginToken.SigningKey)
+	if err != nil {
+		fail("error reading signing key", err)
+		return
+	}
+	separator := strings.LastIndexByte(state, '-')
+	if separator == -1 || openIdClusterState(nonceCookie.Value, state[separator+1:], cluster, signingKey) != state {
+		fail("Request rejected: CSRF mitigation", nil)
+		return
+	}
+
+	params := url.Values{}
+	params.Set("code", code)
+	params.Set("grant_type", "authorization_code")
+	params.Set("redirect_uri", httputil.GuessKialiURL(c.conf, r)+openIdClusterCallbackPath+url.PathEscape(cluster))
+	params.Set("code_verifier", codeVerifierCookie.Value)
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		IdToken     string `json:"id_token"`
+	}
+	if err := requestOpenIdClusterToken(r.Context(), c.conf, clusterConf, params, &tokenResponse); err != nil {
+		fail("unable to get the token of the cluster", err)
+		return
+	}
+	if tokenResponse.IdToken == "" {
+		fail("the IdP did not provide an id_token", nil)
+		return
+	}
+
+	parsed, err := jwtpkg.ParseSigned(tokenResponse.IdToken)
+	if err != nil {
+		fail("cannot parse received id_token from the OpenId provider", err)
+		return
+	}
+	var claims map[string]interface{}
+	if err := parsed.UnsafeClaimsWithoutVerification(&claims); err != nil {
+		fail("cannot parse the payload of the id_token from the OpenId provider", err)
+		return
+	}
+	if nonce, _ := claims["nonce"].(string); nonce != fmt.Sprintf("%x", sha256.Sum224([]byte(nonceCookie.Value))) {
+		fail("OpenId token rejected: nonce code mismatch", nil)
+		return
+	}
+	expiresOn, ok := tokenExpiration(tokenResponse.IdToken)
+	if !ok {
+		fail("the received id_token from the OpenId provider has a missing or invalid 'exp' claim", nil)
+		return
+	}
+	if expiresOn.After(homeSession.ExpiresOn) {
+		expiresOn = homeSession.ExpiresOn
+	}
+
+	payload := &oidcSessionPayload{Subject: homeSession.Payload.Subject, Token: tokenResponse.IdToken}
+	usernameClaim := clusterConf.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = c.conf.Auth.OpenId.UsernameClaim
+	}
+	if subject, ok := claims[usernameClaim].(string); ok && subject != "" {
+		payload.Subject = subject
+	}
+	if groupsClaim := c.conf.Auth.OpenId.GroupsClaim; groupsClaim != "" {
+		payload.Groups = parseGroupsClaim(claims[groupsClaim])
+	}
+	if clusterConf.ApiToken == "access_token" {
+		payload.IdToken = tokenResponse.IdToken
+		payload.Token = tokenResponse.AccessToken
+	}
+
+	if httpStatus, reason, err := verifyOpenIdUserAccess(payload.Token, cluster, c.clientFactory, c.kialiCache, c.conf, c.discovery); httpStatus != http.StatusOK {
+		fail(reason, err)
+		return
+	}
+
+	sessionData, err := NewSessionData(cluster, config.AuthStrategyOpenId, expiresOn, payload)
+	if err == nil {
+		err = c.SessionStore.CreateSession(r, w, *sessionData)
+	}
+	if err != nil {
+		fail("could not create the session", err)
+		return
+	}
+	log.Infof("User [%s] logged in cluster [%s] as [%s]", homeSession.Payload.Subject, cluster, payload.Subject)
+	http.Redirect(w, r, webRootWithSlash, http.StatusFound)
+}
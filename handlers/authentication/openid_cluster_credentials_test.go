@@ -0,0 +1,258 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/util"
+)
+
+// newOpenIdClustersTestController returns an OpenID controller for the home cluster east and the remote
+// clusters west (token exchange) and north (login), whose IdP is served at idpURL.
+func newOpenIdClustersTestController(t *testing.T, idpURL string) (*OpenIdAuthController, *config.Config) {
+	t.Helper()
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.Auth.Strategy = config.AuthStrategyOpenId
+	conf.Auth.OpenId.IssuerUri = "https://home-issuer"
+	conf.Auth.OpenId.ClientId = "kiali-client"
+	conf.Auth.OpenId.Clusters = map[string]config.OpenIdClusterConfig{
+		"west": {
+			Audience:      "west-cluster",
+			ClientId:      "kiali-west",
+			ClientSecret:  "west-secret",
+			Mode:          config.OpenIdClusterModeTokenExchange,
+			TokenEndpoint: idpURL + "/token",
+		},
+		"north": {
+			AuthorizationEndpoint: idpURL + "/auth",
+			ClientId:              "kiali-north",
+			Mode:                  config.OpenIdClusterModeLogin,
+			TokenEndpoint:         idpURL + "/token",
+			UsernameClaim:         "email",
+		},
+	}
+	config.Set(conf)
+
+	clientFactory := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{
+		"east":  kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo")),
+		"west":  kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo")),
+		"north": kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo")),
+	})
+	kialiCache := cache.NewTestingCacheWithFactory(t, clientFactory, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(clientFactory.Clients), kialiCache, conf)
+	controller, err := NewOpenIdAuthController(kialiCache, clientFactory, conf, discovery)
+	require.NoError(t, err)
+	return controller, conf
+}
+
+// openIdSessionRequest returns a request carrying the cookies set in a response.
+func openIdSessionRequest(target string, rr *httptest.ResponseRecorder) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for _, c := range rr.Result().Cookies() {
+		if c.MaxAge >= 0 {
+			r.AddCookie(c)
+		}
+	}
+	return r
+}
+
+func TestOpenIdTokenExchange(t *testing.T) {
+	require := require.New(t)
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(r.ParseForm())
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "kiali-west", user)
+		assert.Equal(t, "west-secret", password)
+		assert.Equal(t, tokenExchangeGrantType, r.Form.Get("grant_type"))
+		assert.Equal(t, "home-token", r.Form.Get("subject_token"))
+		assert.Equal(t, tokenTypeIdToken, r.Form.Get("subject_token_type"))
+		assert.Equal(t, tokenTypeIdToken, r.Form.Get("requested_token_type"))
+		assert.Equal(t, "west-cluster", r.Form.Get("audience"))
+		_, _ = w.Write([]byte(`{"access_token": "west-token", "issued_token_type": "` + tokenTypeIdToken + `", "expires_in": 600}`))
+	}))
+	defer idp.Close()
+	_, conf := newOpenIdClustersTestController(t, idp.URL)
+
+	token, err := exchangeOpenIdToken(context.Background(), conf, conf.Auth.OpenId.Clusters["west"], "home-token", clockTime.Add(time.Hour))
+	require.NoError(err)
+	assert.Equal(t, "west-token", token.Token)
+	assert.Equal(t, clockTime.Add(10*time.Minute), token.ExpiresOn)
+
+	// Tokens never outlive the Kiali session
+	token, err = exchangeOpenIdToken(context.Background(), conf, conf.Auth.OpenId.Clusters["west"], "home-token", clockTime.Add(time.Minute))
+	require.NoError(err)
+	assert.Equal(t, clockTime.Add(time.Minute), token.ExpiresOn)
+}
+
+func TestOpenIdValidateSessionUsesClusterCredentials(t *testing.T) {
+	require := require.New(t)
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	exchanges := 0
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		exchanges++
+		_, _ = w.Write([]byte(`{"access_token": "west-token", "expires_in": 600}`))
+	}))
+	defer idp.Close()
+	controller, conf := newOpenIdClustersTestController(t, idp.URL)
+
+	sessionData, err := NewSessionData(conf.KubernetesConfig.ClusterName, config.AuthStrategyOpenId, clockTime.Add(time.Hour), &oidcSessionPayload{
+		Subject: "jdoe@domain.com",
+		Token:   openIdTestToken,
+	})
+	require.NoError(err)
+	rr := httptest.NewRecorder()
+	require.NoError(controller.SessionStore.CreateSession(httptest.NewRequest(http.MethodGet, "/api", nil), rr, *sessionData))
+
+	w := httptest.NewRecorder()
+	sessions, err := controller.ValidateSession(openIdSessionRequest("/api/namespaces", rr), w)
+	require.NoError(err)
+	require.Len(sessions, 2, "north has no session until the user logs in the cluster")
+	assert.Equal(t, openIdTestToken, sessions["east"].AuthInfo.Token)
+	assert.Equal(t, "west-token", sessions["west"].AuthInfo.Token)
+	assert.Equal(t, clockTime.Add(10*time.Minute), sessions["west"].ExpiresOn)
+	assert.Equal(t, 1, exchanges)
+
+	// The exchanged token is kept in the session until it expires
+	sessions, err = controller.ValidateSession(openIdSessionRequest("/api/namespaces", w), httptest.NewRecorder())
+	require.NoError(err)
+	assert.Equal(t, "west-token", sessions["west"].AuthInfo.Token)
+	assert.Equal(t, 1, exchanges)
+}
+
+func TestOpenIdClusterLogin(t *testing.T) {
+	require := require.New(t)
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	var idToken string
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(r.ParseForm())
+		if r.Form.Get("grant_type") == tokenExchangeGrantType {
+			_, _ = w.Write([]byte(`{"access_token": "west-token"}`))
+			return
+		}
+		assert.Equal(t, "authorization_code", r.Form.Get("grant_type"))
+		assert.Equal(t, "kiali-north", r.Form.Get("client_id"))
+		assert.Equal(t, "north-code", r.Form.Get("code"))
+		assert.Equal(t, "http://kiali.io/api/auth/openid_callback/north", r.Form.Get("redirect_uri"))
+		_, _ = w.Write([]byte(`{"id_token": "` + idToken + `"}`))
+	}))
+	defer idp.Close()
+	controller, conf := newOpenIdClustersTestController(t, idp.URL)
+
+	sessionData, err := NewSessionData(conf.KubernetesConfig.ClusterName, config.AuthStrategyOpenId, clockTime.Add(time.Hour), &oidcSessionPayload{
+		Subject: "jdoe@domain.com",
+		Token:   openIdTestToken,
+	})
+	require.NoError(err)
+	homeSession := httptest.NewRecorder()
+	require.NoError(controller.SessionStore.CreateSession(httptest.NewRequest(http.MethodGet, "/api", nil), homeSession, *sessionData))
+
+	// The login in the cluster requires the Kiali login
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "http://kiali.io/api/auth/openid_redirect/north", nil), map[string]string{"cluster": "north"})
+	rr := httptest.NewRecorder()
+	controller.redirectToClusterAuthServerHandler(rr, r)
+	require.Equal(http.StatusUnauthorized, rr.Code)
+
+	r = mux.SetURLVars(openIdSessionRequest("http://kiali.io/api/auth/openid_redirect/north", homeSession), map[string]string{"cluster": "north"})
+	rr = httptest.NewRecorder()
+	controller.redirectToClusterAuthServerHandler(rr, r)
+	require.Equal(http.StatusFound, rr.Code)
+	location, err := url.Parse(rr.Header().Get("Location"))
+	require.NoError(err)
+	assert.Equal(t, idp.URL+"/auth", fmt.Sprintf("%s://%s%s", location.Scheme, location.Host, location.Path))
+	assert.Equal(t, "kiali-north", location.Query().Get("client_id"))
+
+	var nonce string
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == nonceCookieName("north") {
+			nonce = c.Value
+		}
+	}
+	require.NotEmpty(nonce)
+	assert.Equal(t, fmt.Sprintf("%x", sha256.Sum224([]byte(nonce))), location.Query().Get("nonce"))
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte("kiali67890123456kiali67890123456")}, nil)
+	require.NoError(err)
+	claims, err := json.Marshal(map[string]interface{}{
+		"email": "john@north",
+		"exp":   clockTime.Add(2 * time.Hour).Unix(),
+		"nonce": location.Query().Get("nonce"),
+		"sub":   "1234",
+	})
+	require.NoError(err)
+	signed, err := signer.Sign(claims)
+	require.NoError(err)
+	idToken, err = signed.CompactSerialize()
+	require.NoError(err)
+
+	callback := "http://kiali.io/api/auth/openid_callback/north?code=north-code&state=" + url.QueryEscape(location.Query().Get("state"))
+	r = httptest.NewRequest(http.MethodGet, callback, nil)
+	for _, c := range append(homeSession.Result().Cookies(), rr.Result().Cookies()...) {
+		r.AddCookie(c)
+	}
+	r = mux.SetURLVars(r, map[string]string{"cluster": "north"})
+	rr = httptest.NewRecorder()
+	controller.clusterAuthCallbackHandler(rr, r)
+	require.Equal(http.StatusFound, rr.Code)
+	require.Equal("/", rr.Header().Get("Location"))
+
+	r = openIdSessionRequest("/api/namespaces", homeSession)
+	for _, c := range rr.Result().Cookies() {
+		if c.MaxAge >= 0 {
+			r.AddCookie(c)
+		}
+	}
+	sessions, err := controller.ValidateSession(r, httptest.NewRecorder())
+	require.NoError(err)
+	require.Contains(sessions, "north")
+	assert.Equal(t, idToken, sessions["north"].AuthInfo.Token)
+	assert.Equal(t, "john@north", sessions["north"].Username)
+	assert.Equal(t, clockTime.Add(time.Hour), sessions["north"].ExpiresOn, "the cluster session ends with the Kiali session")
+}
+
+func TestOpenIdClusterLoginRejectsBadState(t *testing.T) {
+	util.Clock = util.ClockMock{Time: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)}
+	controller, _ := newOpenIdClustersTestController(t, "http://idp")
+
+	r := httptest.NewRequest(http.MethodGet, "http://kiali.io/api/auth/openid_callback/north?code=c&state=bad-210101000000", nil)
+	r.AddCookie(&http.Cookie{Name: nonceCookieName("north"), Value: "nonce"})
+	r.AddCookie(&http.Cookie{Name: codeVerifierCookieName("north"), Value: "verifier"})
+	r = mux.SetURLVars(r, map[string]string{"cluster": "north"})
+	rr := httptest.NewRecorder()
+	controller.clusterAuthCallbackHandler(rr, r)
+	require.Equal(t, http.StatusFound, rr.Code)
+	assert.Contains(t, rr.Header().Get("Location"), "openid_error=")
+	assert.Contains(t, rr.Header().Get("Location"), "cluster=north")
+
+	// Clusters not configured for login have no login endpoints
+	r = mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/api/auth/openid_callback/west", nil), map[string]string{"cluster": "west"})
+	rr = httptest.NewRecorder()
+	controller.clusterAuthCallbackHandler(rr, r)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -105,7 +105,7 @@ func NewOpenshiftAuthController(conf *config.Config, clientFactory kubernetes.Cl
 		return nil, err
 	}
 
-	store, err := NewCookieSessionPersistor[openshiftSessionPayload](conf)
+	store, err := NewSessionPersistor[openshiftSessionPayload](conf)
 	if err != nil {
 		return nil, err
 	}
@@ -256,6 +256,7 @@ func (o *OpenshiftAuthController) ValidateSession(r *http.Request, w http.Respon
 		userSessions[o.conf.KubernetesConfig.ClusterName] = &UserSessionData{
 			AuthInfo:  &api.AuthInfo{Token: token},
 			ExpiresOn: expires,
+			Groups:    user.Groups,
 			SessionID: "",
 			Username:  user.Name,
 		}
@@ -276,6 +277,7 @@ func (o *OpenshiftAuthController) ValidateSession(r *http.Request, w http.Respon
 		userSessions[o.conf.KubernetesConfig.ClusterName] = &UserSessionData{
 			AuthInfo:  &api.AuthInfo{Token: token},
 			ExpiresOn: expires,
+			Groups:    user.Groups,
 			SessionID: "",
 			Username:  user.Name,
 		}
@@ -304,6 +306,7 @@ func (o *OpenshiftAuthController) ValidateSession(r *http.Request, w http.Respon
 			userSessions[session.Cluster] = &UserSessionData{
 				AuthInfo:  &api.AuthInfo{Token: session.Payload.AccessToken},
 				ExpiresOn: session.ExpiresOn,
+				Groups:    user.Groups,
 				SessionID: session.SessionID,
 				Username:  user.Name,
 			}
@@ -353,6 +356,7 @@ func (o *OpenshiftAuthController) ValidateSession(r *http.Request, w http.Respon
 						ImpersonateGroups: groups,
 					},
 					ExpiresOn: homeSession.ExpiresOn,
+					Groups:    groups,
 					SessionID: existingSessionID,
 					Username:  homeUserName,
 				}
@@ -0,0 +1,171 @@
+package authentication
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+)
+
+// sessionStoreKeyPrefix prefixes the keys of the login sessions in the session store.
+const sessionStoreKeyPrefix = "session:"
+
+// NewSessionPersistor creates the session persistor configured in session_store. Sessions are kept in browser
+// cookies unless a shared session store is configured: then they are kept in the store so that any replica can
+// read them.
+func NewSessionPersistor[T any](conf *config.Config) (SessionPersistor[T], error) {
+	if !conf.SessionStore.IsShared() {
+		return NewCookieSessionPersistor[T](conf)
+	}
+	store, err := sessionstore.New(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating the session persistor: %w", err)
+	}
+	return NewServerSessionPersistor[T](conf, store)
+}
+
+// sessionReference is the payload of the session cookie of a server-side session.
+type sessionReference struct{}
+
+// serverSessionPersistor keeps the session data in a session store. The browser only gets an (encrypted)
+// session cookie holding the ID of the session, so the data does not need to fit in cookies and is shared
+// by all the replicas using the same store. The data is encrypted in the store with the same key as cookies.
+type serverSessionPersistor[T any] struct {
+	conf    *config.Config
+	cookies *cookieSessionPersistor[sessionReference]
+	store   sessionstore.Store
+}
+
+// NewServerSessionPersistor creates a session persistor keeping the sessions in the store.
+func NewServerSessionPersistor[T any](conf *config.Config, store sessionstore.Store) (*serverSessionPersistor[T], error) {
+	cookies, err := NewCookieSessionPersistor[sessionReference](conf)
+	if err != nil {
+		return nil, err
+	}
+	return &serverSessionPersistor[T]{conf: conf, cookies: cookies, store: store}, nil
+}
+
+func sessionStoreKey(sessionID string) string {
+	return sessionStoreKeyPrefix + sessionID
+}
+
+// CreateSession stores the session data in the session store and sets the session cookie referencing it.
+func (p *serverSessionPersistor[T]) CreateSession(r *http.Request, w http.ResponseWriter, s SessionData[T]) error {
+	aesGCM, err := p.cookies.getCipher()
+	if err != nil {
+		return fmt.Errorf("error when creating the session: %w", err)
+	}
+	sDataJson, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error when creating the session - failed to marshal JSON: %w", err)
+	}
+	aesGcmNonce, err := util.CryptoRandomBytes(aesGCM.NonceSize())
+	if err != nil {
+		return fmt.Errorf("error when creating the session - failed to generate random bytes: %w", err)
+	}
+	ttl := s.ExpiresOn.Sub(util.Clock.Now())
+	if err := p.store.Set(r.Context(), sessionStoreKey(s.SessionID), aesGCM.Seal(aesGcmNonce, aesGcmNonce, sDataJson, nil), ttl); err != nil {
+		return fmt.Errorf("error when creating the session - failed to store the session: %w", err)
+	}
+
+	reference := SessionData[sessionReference]{
+		Cluster:   s.Cluster,
+		ExpiresOn: s.ExpiresOn,
+		Payload:   &sessionReference{},
+		SessionID: s.SessionID,
+		Strategy:  s.Strategy,
+	}
+	return p.cookies.CreateSession(r, w, reference)
+}
+
+// readStoredSession reads the data of the session referenced by a session cookie.
+func (p *serverSessionPersistor[T]) readStoredSession(r *http.Request, reference *SessionData[sessionReference]) (*SessionData[T], error) {
+	aesGCM, err := p.cookies.getCipher()
+	if err != nil {
+		return nil, fmt.Errorf("error when reading the session: %w", err)
+	}
+	cipherSessionData, err := p.store.Get(r.Context(), sessionStoreKey(reference.SessionID))
+	if err != nil {
+		if errors.Is(err, sessionstore.ErrNotFound) {
+			return nil, fmt.Errorf("session [%w]: session [%s] does not exist in the session store", ErrSessionNotFound, reference.SessionID)
+		}
+		return nil, fmt.Errorf("error when reading the session: %w", err)
+	}
+
+	nonceSize := aesGCM.NonceSize()
+	if len(cipherSessionData) < nonceSize {
+		return nil, fmt.Errorf("unable to decrypt session")
+	}
+	sessionDataJson, err := aesGCM.Open(nil, cipherSessionData[:nonceSize], cipherSessionData[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("error when restoring the session - failed to decrypt: %w", err)
+	}
+	var sData SessionData[T]
+	if err := json.Unmarshal(sessionDataJson, &sData); err != nil {
+		return nil, fmt.Errorf("error when restoring the session - failed to parse the session data: %w", err)
+	}
+
+	// The cookie could only reference another session if the store was tampered with.
+	if sData.SessionID != reference.SessionID || sData.Cluster != reference.Cluster {
+		return nil, fmt.Errorf("session [%s] does not match its session cookie", reference.SessionID)
+	}
+	return &sData, nil
+}
+
+// ReadSession returns the data of the session referenced by the session cookie of the key. The cookie
+// persistor validates the strategy and expiration of the session. Sessions terminated in another replica
+// are gone from the store, so their cookie is dropped.
+func (p *serverSessionPersistor[T]) ReadSession(r *http.Request, w http.ResponseWriter, key string) (*SessionData[T], error) {
+	reference, err := p.cookies.ReadSession(r, w, key)
+	if err != nil {
+		return nil, err
+	}
+	sData, err := p.readStoredSession(r, reference)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			p.cookies.TerminateSession(r, w, key)
+		}
+		return nil, err
+	}
+	return sData, nil
+}
+
+// ReadAllSessions returns the data of all the sessions referenced by session cookies.
+func (p *serverSessionPersistor[T]) ReadAllSessions(r *http.Request, w http.ResponseWriter) ([]*SessionData[T], error) {
+	references, err := p.cookies.ReadAllSessions(r, w)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*SessionData[T]
+	for _, reference := range references {
+		sData, err := p.readStoredSession(r, reference)
+		if err != nil {
+			log.Debugf("Skipping session [%s] of cluster [%s]: %v", reference.SessionID, reference.Cluster, err)
+			if errors.Is(err, ErrSessionNotFound) {
+				p.cookies.TerminateSession(r, w, reference.Cluster)
+			}
+			continue
+		}
+		sessions = append(sessions, sData)
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("sessions [%w]: no session in the session store is referenced by the request", ErrSessionNotFound)
+	}
+	return sessions, nil
+}
+
+// TerminateSession removes the session from the session store, so that it is terminated in all the replicas,
+// and drops its session cookie.
+func (p *serverSessionPersistor[T]) TerminateSession(r *http.Request, w http.ResponseWriter, key string) {
+	if reference, err := p.cookies.readKialiCookie(sessionCookieName(SessionCookieName, key), r); err == nil {
+		if err := p.store.Delete(r.Context(), sessionStoreKey(reference.SessionID)); err != nil {
+			log.Errorf("Unable to delete session [%s] from the session store: %v", reference.SessionID, err)
+		}
+	}
+	p.cookies.TerminateSession(r, w, key)
+}
@@ -0,0 +1,121 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/sessionstore/sessionstoretest"
+	"github.com/kiali/kiali/util"
+)
+
+func newServerSessionTestConfig(t *testing.T) *config.Config {
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.SessionStore.Type = config.SessionStoreTypeRedis
+	conf.SessionStore.Redis.Address = sessionstoretest.NewRedisServer(t, "").Addr()
+	return conf
+}
+
+func requestWithCookies(rr *httptest.ResponseRecorder) *http.Request {
+	request := httptest.NewRequest(http.MethodGet, "/api", nil)
+	for _, c := range rr.Result().Cookies() {
+		if c.MaxAge >= 0 {
+			request.AddCookie(c)
+		}
+	}
+	return request
+}
+
+// TestServerSessionIsSharedByReplicas tests that a session created by a replica can be read and
+// terminated by another replica using the same session store.
+func TestServerSessionIsSharedByReplicas(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Now()}
+	conf := newServerSessionTestConfig(t)
+
+	replicaA, err := NewSessionPersistor[testSessionPayload](conf)
+	require.NoError(err)
+	replicaB, err := NewSessionPersistor[testSessionPayload](conf)
+	require.NoError(err)
+
+	// A large payload would need several cookies with the cookie persistor
+	payload := testSessionPayload{FirstField: strings.Repeat("x", 3*SessionCookieMaxSize)}
+	session, err := NewSessionData("east", conf.Auth.Strategy, util.Clock.Now().Add(time.Hour), &payload)
+	require.NoError(err)
+	rr := httptest.NewRecorder()
+	require.NoError(replicaA.CreateSession(httptest.NewRequest(http.MethodPost, "/api", nil), rr, *session))
+	require.Len(rr.Result().Cookies(), 1)
+
+	read, err := replicaB.ReadSession(requestWithCookies(rr), httptest.NewRecorder(), "east")
+	require.NoError(err)
+	assert.Equal(t, payload, *read.Payload)
+	assert.Equal(t, session.SessionID, read.SessionID)
+
+	all, err := replicaB.ReadAllSessions(requestWithCookies(rr), httptest.NewRecorder())
+	require.NoError(err)
+	require.Len(all, 1)
+	assert.Equal(t, "east", all[0].Cluster)
+
+	replicaB.TerminateSession(requestWithCookies(rr), httptest.NewRecorder(), "east")
+	_, err = replicaA.ReadSession(requestWithCookies(rr), httptest.NewRecorder(), "east")
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+}
+
+// TestServerSessionIsEncryptedInStore tests that the session data is not readable in the session store.
+func TestServerSessionIsEncryptedInStore(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)}
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	store := sessionstore.NewMemoryStore()
+
+	persistor, err := NewServerSessionPersistor[testSessionPayload](conf, store)
+	require.NoError(err)
+	session, err := NewSessionData("", conf.Auth.Strategy, util.Clock.Now().Add(time.Hour), &testSessionPayload{FirstField: "secret-token"})
+	require.NoError(err)
+	require.NoError(persistor.CreateSession(httptest.NewRequest(http.MethodPost, "/api", nil), httptest.NewRecorder(), *session))
+
+	stored, err := store.Get(context.Background(), sessionStoreKey(session.SessionID))
+	require.NoError(err)
+	assert.NotContains(t, string(stored), "secret-token")
+
+	// The session expires in the store with the session
+	util.Clock = util.ClockMock{Time: session.ExpiresOn}
+	_, err = store.Get(context.Background(), sessionStoreKey(session.SessionID))
+	assert.ErrorIs(t, err, sessionstore.ErrNotFound)
+}
+
+// TestServerSessionRejectsOtherStrategy tests that the session cookie is validated like any other session cookie.
+func TestServerSessionRejectsOtherStrategy(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Now()}
+	conf := newServerSessionTestConfig(t)
+
+	persistor, err := NewSessionPersistor[testSessionPayload](conf)
+	require.NoError(err)
+	session, err := NewSessionData("", config.AuthStrategyOpenId, util.Clock.Now().Add(time.Hour), &testSessionPayload{FirstField: "Foo"})
+	require.NoError(err)
+	rr := httptest.NewRecorder()
+	require.NoError(persistor.CreateSession(httptest.NewRequest(http.MethodPost, "/api", nil), rr, *session))
+
+	_, err = persistor.ReadSession(requestWithCookies(rr), httptest.NewRecorder(), "")
+	assert.ErrorContains(t, err, "does not match current strategy")
+}
+
+func TestNewSessionPersistorUsesCookiesByDefault(t *testing.T) {
+	conf := config.NewConfig()
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	persistor, err := NewSessionPersistor[testSessionPayload](conf)
+	require.NoError(t, err)
+	assert.IsType(t, &cookieSessionPersistor[testSessionPayload]{}, persistor)
+}
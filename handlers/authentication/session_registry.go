@@ -0,0 +1,277 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+)
+
+const (
+	// activeSessionStoreKeyPrefix prefixes the keys of the records of the active sessions in the session store.
+	activeSessionStoreKeyPrefix = "activesession:"
+	// endedSessionStoreKeyPrefix prefixes the keys of the revoked and logged out sessions in the session store.
+	// They are kept until the sessions expire, so that their cookies are rejected if they are replayed.
+	endedSessionStoreKeyPrefix = "endedsession:"
+	// activeSessionUpdateInterval is how often a replica updates the last time a session was seen.
+	activeSessionUpdateInterval = time.Minute
+)
+
+var (
+	// ErrActiveSessionNotFound is returned when a session is not active: unknown, expired or ended.
+	ErrActiveSessionNotFound = errors.New("active session not found")
+	// ErrSessionRevoked is returned when validating a revoked session.
+	ErrSessionRevoked = errors.New("session revoked")
+)
+
+// ActiveSession describes a login session, as listed by the session management API.
+type ActiveSession struct {
+	// ClientIP is the address of the client when the session was last seen.
+	ClientIP  string    `json:"clientIP"`
+	Clusters  []string  `json:"clusters"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresOn time.Time `json:"expiresOn"`
+	ID        string    `json:"id"`
+	LastSeen  time.Time `json:"lastSeen"`
+	Strategy  string    `json:"strategy"`
+	Username  string    `json:"username"`
+}
+
+// SessionRegistry tracks the login sessions validated by Kiali, so that they can be listed and revoked.
+// Sessions are tracked in the session store: with a shared store, all the replicas see and reject the same
+// sessions. The teardown functions release what Kiali keeps for a session when it ends; each replica runs
+// them when it sees the end of a session.
+type SessionRegistry struct {
+	conf      *config.Config
+	store     sessionstore.Store
+	teardowns []func(sessionID string)
+	// updated holds when this replica last updated the record of each session, to update it at most once per
+	// activeSessionUpdateInterval.
+	updated   map[string]time.Time
+	updatedMu sync.Mutex
+}
+
+// NewSessionRegistry returns the session registry keeping the sessions in the store.
+func NewSessionRegistry(conf *config.Config, store sessionstore.Store) *SessionRegistry {
+	return &SessionRegistry{conf: conf, store: store, updated: map[string]time.Time{}}
+}
+
+// Enabled returns true when the sessions are tracked.
+func (s *SessionRegistry) Enabled() bool {
+	return s != nil && s.conf.Auth.SessionManagement.Enabled && s.conf.Auth.Strategy != config.AuthStrategyAnonymous
+}
+
+// OnEnd adds a function releasing the resources of a session when it ends. It must be called on startup,
+// before the registry is used.
+func (s *SessionRegistry) OnEnd(teardown func(sessionID string)) {
+	s.teardowns = append(s.teardowns, teardown)
+}
+
+func (s *SessionRegistry) teardown(sessionID string) {
+	s.updatedMu.Lock()
+	delete(s.updated, sessionID)
+	s.updatedMu.Unlock()
+	for _, teardown := range s.teardowns {
+		teardown(sessionID)
+	}
+}
+
+// clientIP returns the address of the client of the request, without the port.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// Track records the session of the home cluster as seen in the request. An error wrapping ErrSessionNotFound
+// and ErrSessionRevoked is returned when the session was revoked or logged out. Sessions without an ID (e.g.
+// bearer tokens sent by a proxy) are not tracked.
+func (s *SessionRegistry) Track(r *http.Request, sessions UserSessions) error {
+	home, ok := sessions[s.conf.KubernetesConfig.ClusterName]
+	if !ok || home.SessionID == "" {
+		return nil
+	}
+	ctx := r.Context()
+	if _, err := s.store.Get(ctx, endedSessionStoreKeyPrefix+home.SessionID); err == nil {
+		s.teardown(home.SessionID)
+		return fmt.Errorf("session [%w]: session [%s] was ended: %w", ErrSessionNotFound, home.SessionID, ErrSessionRevoked)
+	} else if !errors.Is(err, sessionstore.ErrNotFound) {
+		return fmt.Errorf("unable to check the session [%s]: %w", home.SessionID, err)
+	}
+
+	now := util.Clock.Now()
+	s.updatedMu.Lock()
+	lastUpdate, found := s.updated[home.SessionID]
+	if found && now.Sub(lastUpdate) < activeSessionUpdateInterval {
+		s.updatedMu.Unlock()
+		return nil
+	}
+	s.updated[home.SessionID] = now
+	// Forget the sessions this replica did not see for a while
+	for id, updated := range s.updated {
+		if now.Sub(updated) > time.Duration(s.conf.LoginToken.ExpirationSeconds)*time.Second+activeSessionUpdateInterval {
+			delete(s.updated, id)
+		}
+	}
+	s.updatedMu.Unlock()
+
+	session, err := s.get(ctx, home.SessionID)
+	if err != nil {
+		if !errors.Is(err, ErrActiveSessionNotFound) {
+			log.Warningf("Unable to read the record of session [%s]: %v", home.SessionID, err)
+		}
+		session = &ActiveSession{CreatedAt: now, ID: home.SessionID, Strategy: s.conf.Auth.Strategy}
+	}
+	session.ClientIP = clientIP(r)
+	session.Clusters = make([]string, 0, len(sessions))
+	for cluster := range sessions {
+		session.Clusters = append(session.Clusters, cluster)
+	}
+	sort.Strings(session.Clusters)
+	session.ExpiresOn = home.ExpiresOn
+	if session.ExpiresOn.IsZero() {
+		session.ExpiresOn = now.Add(time.Duration(s.conf.LoginToken.ExpirationSeconds) * time.Second)
+	}
+	session.LastSeen = now
+	session.Username = NewUserIdentity(home).Username
+
+	content, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := s.store.Set(ctx, activeSessionStoreKeyPrefix+session.ID, content, session.ExpiresOn.Sub(now)); err != nil {
+		// The session is still valid: failing to record it must not deny access
+		log.Warningf("Unable to record session [%s]: %v", session.ID, err)
+	}
+	return nil
+}
+
+func (s *SessionRegistry) get(ctx context.Context, id string) (*ActiveSession, error) {
+	content, err := s.store.Get(ctx, activeSessionStoreKeyPrefix+id)
+	if err != nil {
+		if errors.Is(err, sessionstore.ErrNotFound) {
+			return nil, ErrActiveSessionNotFound
+		}
+		return nil, err
+	}
+	session := &ActiveSession{}
+	if err := json.Unmarshal(content, session); err != nil {
+		return nil, fmt.Errorf("unable to parse the record of session [%s]: %w", id, err)
+	}
+	if !util.Clock.Now().Before(session.ExpiresOn) {
+		return nil, ErrActiveSessionNotFound
+	}
+	return session, nil
+}
+
+// List returns the active sessions, the most recently seen first.
+func (s *SessionRegistry) List(ctx context.Context) ([]ActiveSession, error) {
+	keys, err := s.store.Keys(ctx, activeSessionStoreKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	sessions := []ActiveSession{}
+	for _, key := range keys {
+		id := strings.TrimPrefix(key, activeSessionStoreKeyPrefix)
+		session, err := s.get(ctx, id)
+		if err != nil {
+			if !errors.Is(err, ErrActiveSessionNotFound) {
+				log.Warningf("Skipping session [%s]: %v", id, err)
+			}
+			continue
+		}
+		// A replica may record a session while it is being ended
+		if _, err := s.store.Get(ctx, endedSessionStoreKeyPrefix+id); err == nil {
+			continue
+		}
+		sessions = append(sessions, *session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeen.After(sessions[j].LastSeen)
+	})
+	return sessions, nil
+}
+
+// end rejects the session in all the replicas until it expires, and releases its resources in this replica.
+func (s *SessionRegistry) end(ctx context.Context, id string, expiresOn time.Time) error {
+	ttl := expiresOn.Sub(util.Clock.Now())
+	if ttl <= 0 {
+		ttl = time.Duration(s.conf.LoginToken.ExpirationSeconds) * time.Second
+	}
+	if err := s.store.Set(ctx, endedSessionStoreKeyPrefix+id, []byte(expiresOn.Format(time.RFC3339)), ttl); err != nil {
+		return fmt.Errorf("unable to end session [%s]: %w", id, err)
+	}
+	if err := s.store.Delete(ctx, activeSessionStoreKeyPrefix+id); err != nil {
+		log.Warningf("Unable to delete the record of session [%s]: %v", id, err)
+	}
+	s.teardown(id)
+	return nil
+}
+
+// Revoke ends an active session: the next requests of the session are rejected as unauthenticated.
+func (s *SessionRegistry) Revoke(ctx context.Context, id string) (*ActiveSession, error) {
+	session, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.end(ctx, id, session.ExpiresOn); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// sessionRegistryController tracks the sessions validated by an auth controller in the session registry,
+// and rejects the ended ones.
+type sessionRegistryController struct {
+	AuthController
+	conf     *config.Config
+	cookies  SessionPersistor[json.RawMessage]
+	registry *SessionRegistry
+}
+
+// NewSessionRegistryController wraps the auth controller so that the sessions it validates are tracked in
+// the registry. ValidateSession rejects the revoked sessions, and TerminateSession ends the session in all
+// the replicas.
+func NewSessionRegistryController(conf *config.Config, controller AuthController, registry *SessionRegistry) (AuthController, error) {
+	// Only the generic part of the session cookie is read, whatever the payload of the strategy
+	cookies, err := NewCookieSessionPersistor[json.RawMessage](conf)
+	if err != nil {
+		return nil, err
+	}
+	return &sessionRegistryController{AuthController: controller, conf: conf, cookies: cookies, registry: registry}, nil
+}
+
+// ValidateSession validates the session with the wrapped controller and tracks it.
+func (c *sessionRegistryController) ValidateSession(r *http.Request, w http.ResponseWriter) (UserSessions, error) {
+	sessions, err := c.AuthController.ValidateSession(r, w)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.registry.Track(r, sessions); err != nil {
+		log.Infof("Rejected ended session [client: %s]: %v", r.RemoteAddr, err)
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TerminateSession ends the session in the registry before terminating it with the wrapped controller.
+func (c *sessionRegistryController) TerminateSession(r *http.Request, w http.ResponseWriter) error {
+	if sData, err := c.cookies.ReadSession(r, w, c.conf.KubernetesConfig.ClusterName); err == nil && sData.SessionID != "" {
+		if err := c.registry.end(r.Context(), sData.SessionID, sData.ExpiresOn); err != nil {
+			log.Errorf("Failed to end session [%s] in the session registry: %v", sData.SessionID, err)
+		}
+	}
+	return c.AuthController.TerminateSession(r, w)
+}
@@ -0,0 +1,152 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+)
+
+func newTestSessionRegistry() *SessionRegistry {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	conf.Auth.Strategy = config.AuthStrategyToken
+	conf.Auth.SessionManagement.Enabled = true
+	return NewSessionRegistry(conf, sessionstore.NewMemoryStore())
+}
+
+func testUserSessions(sessionID string, expiresOn time.Time) UserSessions {
+	return UserSessions{
+		"east": {AuthInfo: &api.AuthInfo{Token: "t"}, ExpiresOn: expiresOn, SessionID: sessionID, Username: "alice"},
+		"west": {AuthInfo: &api.AuthInfo{Token: "t"}, ExpiresOn: expiresOn, SessionID: sessionID, Username: "alice"},
+	}
+}
+
+// fakeSessionController validates the sessions it holds.
+type fakeSessionController struct {
+	sessions   UserSessions
+	terminated bool
+}
+
+func (f *fakeSessionController) Authenticate(r *http.Request, w http.ResponseWriter) (*UserSessionData, error) {
+	return nil, nil
+}
+
+func (f *fakeSessionController) TerminateSession(r *http.Request, w http.ResponseWriter) error {
+	f.terminated = true
+	return nil
+}
+
+func (f *fakeSessionController) ValidateSession(r *http.Request, w http.ResponseWriter) (UserSessions, error) {
+	return f.sessions, nil
+}
+
+func TestSessionRegistryTracksAndRevokesSessions(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: now}
+	registry := newTestSessionRegistry()
+	var tornDown []string
+	registry.OnEnd(func(sessionID string) { tornDown = append(tornDown, sessionID) })
+
+	r := httptest.NewRequest(http.MethodGet, "/api/namespaces", nil)
+	r.RemoteAddr = "10.0.0.1:4321"
+	require.NoError(registry.Track(r, testUserSessions("s1", now.Add(time.Hour))))
+	require.NoError(registry.Track(r, UserSessions{"east": {SessionID: "s2", ExpiresOn: now.Add(time.Hour), Username: "bob"}}))
+	// Sessions without an ID are not tracked
+	require.NoError(registry.Track(r, UserSessions{"east": {ExpiresOn: now.Add(time.Hour), Username: "proxy"}}))
+
+	util.Clock = util.ClockMock{Time: now.Add(2 * time.Minute)}
+	require.NoError(registry.Track(r, testUserSessions("s1", now.Add(time.Hour))))
+
+	sessions, err := registry.List(context.Background())
+	require.NoError(err)
+	require.Len(sessions, 2)
+	assert.Equal(t, "s1", sessions[0].ID, "the most recently seen first")
+	assert.Equal(t, "alice", sessions[0].Username)
+	assert.Equal(t, []string{"east", "west"}, sessions[0].Clusters)
+	assert.Equal(t, "10.0.0.1", sessions[0].ClientIP)
+	assert.Equal(t, now, sessions[0].CreatedAt)
+	assert.Equal(t, now.Add(2*time.Minute), sessions[0].LastSeen)
+	assert.Equal(t, config.AuthStrategyToken, sessions[0].Strategy)
+
+	revoked, err := registry.Revoke(context.Background(), "s1")
+	require.NoError(err)
+	assert.Equal(t, "alice", revoked.Username)
+	assert.Equal(t, []string{"s1"}, tornDown)
+
+	err = registry.Track(r, testUserSessions("s1", now.Add(time.Hour)))
+	require.ErrorIs(err, ErrSessionNotFound)
+	require.ErrorIs(err, ErrSessionRevoked)
+	sessions, err = registry.List(context.Background())
+	require.NoError(err)
+	require.Len(sessions, 1)
+	assert.Equal(t, "s2", sessions[0].ID)
+
+	_, err = registry.Revoke(context.Background(), "s1")
+	assert.ErrorIs(t, err, ErrActiveSessionNotFound)
+}
+
+func TestSessionRegistryControllerRejectsRevokedSessions(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: now}
+	registry := newTestSessionRegistry()
+	inner := &fakeSessionController{sessions: testUserSessions("s1", now.Add(time.Hour))}
+	controller, err := NewSessionRegistryController(registry.conf, inner, registry)
+	require.NoError(err)
+
+	sessions, err := controller.ValidateSession(httptest.NewRequest(http.MethodGet, "/api", nil), httptest.NewRecorder())
+	require.NoError(err)
+	require.Len(sessions, 2)
+
+	_, err = registry.Revoke(context.Background(), "s1")
+	require.NoError(err)
+	_, err = controller.ValidateSession(httptest.NewRequest(http.MethodGet, "/api", nil), httptest.NewRecorder())
+	require.ErrorIs(err, ErrSessionNotFound)
+}
+
+func TestSessionRegistryControllerEndsSessionOnLogout(t *testing.T) {
+	require := require.New(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: now}
+	registry := newTestSessionRegistry()
+
+	// Log in: the session cookie holds the ID of the session
+	persistor, err := NewCookieSessionPersistor[tokenSessionPayload](registry.conf)
+	require.NoError(err)
+	sData, err := NewSessionData("east", config.AuthStrategyToken, now.Add(time.Hour), &tokenSessionPayload{Token: "t"})
+	require.NoError(err)
+	login := httptest.NewRecorder()
+	require.NoError(persistor.CreateSession(httptest.NewRequest(http.MethodPost, "/api/authenticate", nil), login, *sData))
+
+	inner := &fakeSessionController{sessions: testUserSessions(sData.SessionID, sData.ExpiresOn)}
+	controller, err := NewSessionRegistryController(registry.conf, inner, registry)
+	require.NoError(err)
+	_, err = controller.ValidateSession(httptest.NewRequest(http.MethodGet, "/api", nil), httptest.NewRecorder())
+	require.NoError(err)
+
+	logout := httptest.NewRequest(http.MethodGet, "/api/logout", nil)
+	for _, c := range login.Result().Cookies() {
+		logout.AddCookie(c)
+	}
+	require.NoError(controller.TerminateSession(logout, httptest.NewRecorder()))
+	assert.True(t, inner.terminated)
+
+	sessions, err := registry.List(context.Background())
+	require.NoError(err)
+	assert.Empty(t, sessions)
+	// A replayed cookie of the session is rejected
+	_, err = controller.ValidateSession(httptest.NewRequest(http.MethodGet, "/api", nil), httptest.NewRecorder())
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
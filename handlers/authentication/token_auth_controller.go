@@ -44,7 +44,7 @@ type tokenSessionPayload struct {
 // given persistor and the given businessInstantiator. The businessInstantiator can be nil and
 // the initialized contoller will use the business.Get function.
 func NewTokenAuthController(clientFactory kubernetes.ClientFactory, kialiCache cache.KialiCache, conf *config.Config, discovery *istio.Discovery) (*tokenAuthController, error) {
-	store, err := NewCookieSessionPersistor[tokenSessionPayload](conf)
+	store, err := NewSessionPersistor[tokenSessionPayload](conf)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,234 @@
+package authentication
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"golang.org/x/exp/maps"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/util"
+)
+
+// ErrNoClientCertificate is returned when a request reaches Kiali without a verified client certificate.
+var ErrNoClientCertificate = errors.New("no verified client certificate")
+
+// x509AuthController contains the backing logic to implement Kiali's "x509" authentication strategy.
+// The Kiali listener verifies the client certificates given during the TLS handshake, and the requests without
+// a verified one are rejected, so every authenticated request carries the identity of the user. The username
+// and groups read from the certificate are impersonated on every cluster with the Kiali service account: users
+// have no token of their own.
+type x509AuthController struct {
+	clusters []string
+	conf     *config.Config
+	// sessionStore persists the session between HTTP requests.
+	sessionStore SessionPersistor[x509SessionPayload]
+}
+
+// x509SessionPayload is a helper type used as session data storage. An instance
+// of this type is used with the SessionPersistor for session creation and persistence.
+type x509SessionPayload struct {
+	// Fingerprint is the SHA-256 fingerprint of the client certificate the session was created with.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Subject is the username read from the client certificate.
+	Subject string `json:"subject,omitempty"`
+}
+
+// x509Identity is the user identity read from a client certificate.
+type x509Identity struct {
+	certificate *x509.Certificate
+	groups      []string
+	username    string
+}
+
+// NewX509AuthController initializes a new controller authenticating users with client certificates.
+func NewX509AuthController(conf *config.Config, clientFactory kubernetes.ClientFactory) (*x509AuthController, error) {
+	store, err := NewSessionPersistor[x509SessionPayload](conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &x509AuthController{
+		clusters:     maps.Keys(clientFactory.GetSAClients()),
+		conf:         conf,
+		sessionStore: store,
+	}, nil
+}
+
+// Authenticate creates a session for the identity of the client certificate of the request.
+// The session expires with the certificate, if it expires before the configured session expiration.
+func (c *x509AuthController) Authenticate(r *http.Request, w http.ResponseWriter) (*UserSessionData, error) {
+	identity, err := c.identity(r)
+	if err != nil {
+		c.sessionStore.TerminateSession(r, w, c.conf.KubernetesConfig.ClusterName)
+		status := http.StatusUnauthorized
+		if errors.Is(err, ErrNotInAllowlist) {
+			status = http.StatusForbidden
+		}
+		return nil, &AuthenticationFailureError{
+			Detail:     err,
+			HttpStatus: status,
+			Reason:     "Client certificate is not valid for Kiali",
+		}
+	}
+
+	timeExpire := util.Clock.Now().Add(time.Second * time.Duration(c.conf.LoginToken.ExpirationSeconds))
+	if identity.certificate.NotAfter.Before(timeExpire) {
+		timeExpire = identity.certificate.NotAfter
+	}
+	sessionData, err := NewSessionData(c.conf.KubernetesConfig.ClusterName, config.AuthStrategyX509, timeExpire, &x509SessionPayload{
+		Fingerprint: certificateFingerprint(identity.certificate),
+		Subject:     identity.username,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.sessionStore.CreateSession(r, w, *sessionData); err != nil {
+		return nil, err
+	}
+	log.Infof("x509 auth: user [%s] logged in with certificate [%s]", identity.username, identity.certificate.Subject.String())
+
+	return &UserSessionData{
+		AuthInfo:  identity.authInfo(),
+		ExpiresOn: timeExpire,
+		Groups:    identity.groups,
+		SessionID: sessionData.SessionID,
+		Username:  identity.username,
+	}, nil
+}
+
+// ValidateSession returns the sessions of the identity of the client certificate of the request, one per cluster.
+// The client certificate is enough to authenticate a request; when a session was created with Authenticate, it
+// must have been created with the same certificate.
+func (c *x509AuthController) ValidateSession(r *http.Request, w http.ResponseWriter) (UserSessions, error) {
+	identity, err := c.identity(r)
+	if errors.Is(err, ErrNoClientCertificate) {
+		return nil, fmt.Errorf("session %w: %v", ErrSessionNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sData, err := c.sessionStore.ReadSession(r, w, c.conf.KubernetesConfig.ClusterName)
+	if err != nil && !errors.Is(err, ErrSessionNotFound) {
+		log.Warningf("Could not read the session: %v", err)
+		return nil, err
+	}
+
+	expiration := identity.certificate.NotAfter
+	sessionID := ""
+	if sData != nil {
+		if sData.Payload.Fingerprint != certificateFingerprint(identity.certificate) {
+			log.Warningf("x509 auth: the session of user [%s] was created with another client certificate [client: %s]", sData.Payload.Subject, r.RemoteAddr)
+			return nil, fmt.Errorf("%w: the session was created with another client certificate", ErrSubjectMismatch)
+		}
+		expiration = sData.ExpiresOn
+		sessionID = sData.SessionID
+	}
+
+	// Internal header used to propagate the subject of the request for audit purposes
+	r.Header.Set("Kiali-User", identity.username)
+
+	sessions := UserSessions{}
+	for _, cluster := range c.clusters {
+		sessions[cluster] = &UserSessionData{
+			// Token is intentionally empty. The SA token authenticates the requests and the
+			// impersonation headers carry the user identity. The client factory handles this.
+			AuthInfo:  identity.authInfo(),
+			ExpiresOn: expiration,
+			Groups:    identity.groups,
+			SessionID: sessionID,
+			Username:  identity.username,
+		}
+	}
+	return sessions, nil
+}
+
+// TerminateSession unconditionally terminates any existing session without any validation. The client certificate
+// still authenticates the next requests: logging out only clears the session expiration.
+func (c *x509AuthController) TerminateSession(r *http.Request, w http.ResponseWriter) error {
+	c.sessionStore.TerminateSession(r, w, c.conf.KubernetesConfig.ClusterName)
+	return nil
+}
+
+// identity reads the user identity from the verified client certificate of the request and checks that it can be
+// impersonated.
+func (c *x509AuthController) identity(r *http.Request) (*x509Identity, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, ErrNoClientCertificate
+	}
+	cert := r.TLS.VerifiedChains[0][0]
+	x509Conf := c.conf.Auth.X509
+
+	var username string
+	switch x509Conf.UsernameField {
+	case config.X509FieldCommonName:
+		username = cert.Subject.CommonName
+	case config.X509FieldEmail:
+		if len(cert.EmailAddresses) > 0 {
+			username = cert.EmailAddresses[0]
+		}
+	case config.X509FieldURI:
+		if len(cert.URIs) > 0 {
+			username = cert.URIs[0].String()
+		}
+	case config.X509FieldDNS:
+		if len(cert.DNSNames) > 0 {
+			username = cert.DNSNames[0]
+		}
+	}
+	if username == "" {
+		return nil, fmt.Errorf("%w: the client certificate [%s] has no [%s] username field", ErrImpersonationDenied, cert.Subject.String(), x509Conf.UsernameField)
+	}
+	username = x509Conf.UsernamePrefix + username
+
+	var groups []string
+	switch x509Conf.GroupsField {
+	case config.X509FieldOrganization:
+		groups = cert.Subject.Organization
+	case config.X509FieldOrganizationalUnit:
+		groups = cert.Subject.OrganizationalUnit
+	}
+	prefixed := make([]string, 0, len(groups))
+	for _, g := range groups {
+		prefixed = append(prefixed, x509Conf.GroupsPrefix+g)
+	}
+
+	if err := ValidateImpersonationIdentity(username, prefixed); err != nil {
+		log.Warningf("Impersonation rejected for user %q: %v", username, err)
+		return nil, err
+	}
+	if len(x509Conf.AllowedUsers) > 0 && !slices.Contains(x509Conf.AllowedUsers, username) {
+		log.Warningf("Impersonation denied: user %q is not in allowed_users list", username)
+		return nil, fmt.Errorf("%w: user %q", ErrNotInAllowlist, username)
+	}
+
+	return &x509Identity{
+		certificate: cert,
+		groups:      FilterImpersonationGroups(prefixed, x509Conf.AllowedGroups, username),
+		username:    username,
+	}, nil
+}
+
+func (i *x509Identity) authInfo() *api.AuthInfo {
+	return &api.AuthInfo{
+		Impersonate:       i.username,
+		ImpersonateGroups: i.groups,
+	}
+}
+
+func certificateFingerprint(cert *x509.Certificate) string {
+	return fmt.Sprintf("%x", sha256.Sum256(cert.Raw))
+}
+
+// Interface guard to ensure that x509AuthController implements the AuthController interface.
+var _ AuthController = &x509AuthController{}
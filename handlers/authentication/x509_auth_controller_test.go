@@ -0,0 +1,216 @@
+package authentication
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/util"
+)
+
+func newTestClientCertificate(t *testing.T, subject pkix.Name, emails []string, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        subject,
+		EmailAddresses: emails,
+		NotBefore:      notAfter.Add(-48 * time.Hour),
+		NotAfter:       notAfter,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func newX509Request(cert *x509.Certificate) *http.Request {
+	request := httptest.NewRequest(http.MethodPost, "/api/authenticate", nil)
+	if cert != nil {
+		request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	return request
+}
+
+func newTestX509AuthController(t *testing.T, conf *config.Config) *x509AuthController {
+	t.Helper()
+	controller, err := NewX509AuthController(conf, kubetest.NewK8SClientFactoryMock(kubetest.NewFakeK8sClient()))
+	require.NoError(t, err)
+	return controller
+}
+
+func newX509TestConfig() *config.Config {
+	conf := config.NewConfig()
+	conf.Auth.Strategy = config.AuthStrategyX509
+	conf.LoginToken.SigningKey = "kiali67890123456"
+	return conf
+}
+
+func TestX509AuthControllerAuthenticatesAndValidatesSession(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	clockTime := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	util.Clock = util.ClockMock{Time: clockTime}
+
+	conf := newX509TestConfig()
+	conf.Auth.X509.GroupsPrefix = "x509:"
+	conf.Auth.X509.UsernamePrefix = "x509:"
+	controller := newTestX509AuthController(t, conf)
+
+	// The certificate expires before the session would
+	notAfter := clockTime.Add(time.Hour)
+	cert := newTestClientCertificate(t, pkix.Name{CommonName: "jdoe", Organization: []string{"devs", "ops"}}, nil, notAfter)
+
+	rr := httptest.NewRecorder()
+	sData, err := controller.Authenticate(newX509Request(cert), rr)
+	require.NoError(err)
+	assert.Equal("x509:jdoe", sData.Username)
+	assert.Empty(sData.AuthInfo.Token)
+	assert.Equal("x509:jdoe", sData.AuthInfo.Impersonate)
+	assert.ElementsMatch([]string{"x509:devs", "x509:ops"}, sData.AuthInfo.ImpersonateGroups)
+	assert.Equal(notAfter, sData.ExpiresOn)
+	cookies := rr.Result().Cookies()
+	require.NotEmpty(cookies)
+
+	// The session cookie is accepted with the same certificate
+	request := newX509Request(cert)
+	for _, c := range cookies {
+		request.AddCookie(c)
+	}
+	sessions, err := controller.ValidateSession(request, httptest.NewRecorder())
+	require.NoError(err)
+	require.Contains(sessions, conf.KubernetesConfig.ClusterName)
+	session := sessions[conf.KubernetesConfig.ClusterName]
+	assert.Equal("x509:jdoe", session.Username)
+	assert.Equal(sData.SessionID, session.SessionID)
+	assert.Equal("x509:jdoe", request.Header.Get("Kiali-User"))
+
+	// The session cookie is rejected with another certificate
+	other := newTestClientCertificate(t, pkix.Name{CommonName: "jdoe"}, nil, notAfter)
+	request = newX509Request(other)
+	for _, c := range cookies {
+		request.AddCookie(c)
+	}
+	_, err = controller.ValidateSession(request, httptest.NewRecorder())
+	assert.True(errors.Is(err, ErrSubjectMismatch))
+
+	// The certificate is enough without a session cookie
+	sessions, err = controller.ValidateSession(newX509Request(other), httptest.NewRecorder())
+	require.NoError(err)
+	assert.Equal(notAfter, sessions[conf.KubernetesConfig.ClusterName].ExpiresOn)
+	assert.Empty(sessions[conf.KubernetesConfig.ClusterName].SessionID)
+}
+
+func TestX509AuthControllerRequiresClientCertificate(t *testing.T) {
+	controller := newTestX509AuthController(t, newX509TestConfig())
+
+	_, err := controller.ValidateSession(newX509Request(nil), httptest.NewRecorder())
+	assert.True(t, errors.Is(err, ErrSessionNotFound))
+
+	rr := httptest.NewRecorder()
+	_, err = controller.Authenticate(newX509Request(nil), rr)
+	authErr := &AuthenticationFailureError{}
+	require.True(t, errors.As(err, &authErr))
+	assert.Equal(t, http.StatusUnauthorized, authErr.HttpStatus)
+}
+
+func TestX509AuthControllerMapsIdentity(t *testing.T) {
+	util.Clock = util.ClockMock{Time: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)}
+	notAfter := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		configure      func(*config.X509Config)
+		subject        pkix.Name
+		emails         []string
+		expectedErr    error
+		expectedStatus int
+		expectedUser   string
+		expectedGroups []string
+	}{
+		"email and organizational units": {
+			configure: func(c *config.X509Config) {
+				c.GroupsField = config.X509FieldOrganizationalUnit
+				c.UsernameField = config.X509FieldEmail
+			},
+			subject:        pkix.Name{CommonName: "jdoe", Organization: []string{"acme"}, OrganizationalUnit: []string{"sre"}},
+			emails:         []string{"jdoe@example.com"},
+			expectedUser:   "jdoe@example.com",
+			expectedGroups: []string{"sre"},
+		},
+		"no groups": {
+			configure:      func(c *config.X509Config) { c.GroupsField = config.X509FieldNone },
+			subject:        pkix.Name{CommonName: "jdoe", Organization: []string{"acme"}},
+			expectedUser:   "jdoe",
+			expectedGroups: []string{},
+		},
+		"groups not in allowlist are dropped": {
+			configure:      func(c *config.X509Config) { c.AllowedGroups = []string{"devs"} },
+			subject:        pkix.Name{CommonName: "jdoe", Organization: []string{"devs", "admins"}},
+			expectedUser:   "jdoe",
+			expectedGroups: []string{"devs"},
+		},
+		"missing username field": {
+			configure:      func(c *config.X509Config) { c.UsernameField = config.X509FieldURI },
+			subject:        pkix.Name{CommonName: "jdoe"},
+			expectedErr:    ErrImpersonationDenied,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"system user": {
+			configure:      func(c *config.X509Config) {},
+			subject:        pkix.Name{CommonName: "system:admin"},
+			expectedErr:    ErrImpersonationDenied,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"privileged group": {
+			configure:      func(c *config.X509Config) {},
+			subject:        pkix.Name{CommonName: "jdoe", Organization: []string{"system:masters"}},
+			expectedErr:    ErrImpersonationDenied,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		"user not in allowlist": {
+			configure:      func(c *config.X509Config) { c.AllowedUsers = []string{"alice"} },
+			subject:        pkix.Name{CommonName: "jdoe"},
+			expectedErr:    ErrNotInAllowlist,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			conf := newX509TestConfig()
+			tc.configure(&conf.Auth.X509)
+			controller := newTestX509AuthController(t, conf)
+			cert := newTestClientCertificate(t, tc.subject, tc.emails, notAfter)
+
+			sData, err := controller.Authenticate(newX509Request(cert), httptest.NewRecorder())
+			if tc.expectedErr != nil {
+				authErr := &AuthenticationFailureError{}
+				require.True(t, errors.As(err, &authErr))
+				assert.True(t, errors.Is(authErr.Detail, tc.expectedErr), authErr.Detail)
+				assert.Equal(t, tc.expectedStatus, authErr.HttpStatus)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedUser, sData.AuthInfo.Impersonate)
+			assert.Equal(t, tc.expectedGroups, sData.AuthInfo.ImpersonateGroups)
+		})
+	}
+}
@@ -501,6 +501,7 @@ func TestHandleStripsImpersonationHeadersForOpenShift(t *testing.T) {
 		kubetest.NewFakeK8sClient(),
 		nil,
 		map[string]kubernetes.ClientInterface{},
+		nil,
 	)
 
 	r := httptest.NewRequest("GET", "/api/namespaces", nil)
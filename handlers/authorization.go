@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+// AuthorizationSimulate is the API handler to evaluate whether a concrete request to a workload
+// would be allowed by the AuthorizationPolicies applied to it.
+func AuthorizationSimulate(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+		workload := params["workload"]
+
+		cluster, err := parseIstioConfigClusterParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+
+		body, err := boundedReadAll(r)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Simulation request could not be read: "+err.Error())
+			return
+		}
+		var req models.AuthorizationSimulationRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Simulation request is not valid: "+err.Error())
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		result, err := business.Authorization.SimulateRequest(r.Context(), cluster, namespace, workload, req)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, result)
+	}
+}
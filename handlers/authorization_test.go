@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tracing"
+)
+
+func setupAuthorizationSimulateServer(t *testing.T) *httptest.Server {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "Namespace"}},
+		&business.FakeDepSyncedWithRS(conf)[0],
+	)
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(t, err)
+
+	handler := handlers.WithFakeAuthInfo(conf, handlers.AuthorizationSimulate(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/workloads/{workload}/authorization/simulate", handler)
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestAuthorizationSimulate(t *testing.T) {
+	ts := setupAuthorizationSimulateServer(t)
+
+	url := ts.URL + "/api/namespaces/Namespace/workloads/details-v1/authorization/simulate"
+	resp, err := ts.Client().Post(url, "application/json", strings.NewReader(`{"sourceNamespace":"Namespace","sourceServiceAccount":"default","method":"GET","path":"/"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	require.Equalf(t, http.StatusOK, resp.StatusCode, "response text: %s", string(body))
+
+	result := models.AuthorizationSimulationResult{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, models.AuthorizationDecisionAllow, result.Decision)
+	assert.Equal(t, "cluster.local/ns/Namespace/sa/default", result.SourcePrincipal)
+}
+
+func TestAuthorizationSimulateBadRequest(t *testing.T) {
+	ts := setupAuthorizationSimulateServer(t)
+
+	url := ts.URL + "/api/namespaces/Namespace/workloads/details-v1/authorization/simulate"
+	resp, err := ts.Client().Post(url, "application/json", strings.NewReader(`{"port":"not-a-number"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equalf(t, http.StatusBadRequest, resp.StatusCode, "response text: %s", string(body))
+}
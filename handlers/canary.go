@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+// CanaryStart is the API handler to start a canary run, shifting the traffic of a service to a canary version in steps.
+func CanaryStart(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+	canaries *business.CanaryController,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+		service := params["service"]
+
+		cluster, err := parseIstioConfigClusterParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+		if conf.Deployment.ViewOnlyMode {
+			RespondWithError(w, http.StatusForbidden, "Canary runs cannot be started in view-only mode")
+			return
+		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, cluster) {
+			return
+		}
+		if gitops.FromContext(r.Context()) != nil {
+			RespondWithError(w, http.StatusConflict, "Canary runs shift the traffic in the cluster and cannot be started in GitOps mode")
+			return
+		}
+
+		body, err := boundedReadAll(r)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Canary request could not be read: "+err.Error())
+			return
+		}
+		var req models.CanaryRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Canary request is not valid: "+err.Error())
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		run, err := canaries.Start(r.Context(), business, cluster, namespace, service, r.Header.Get("Kiali-User"), req)
+		if err != nil {
+			handleCanaryError(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, run)
+	}
+}
+
+// CanaryList is the API handler to list the canary runs of a service, the most recent first.
+func CanaryList(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+	canaries *business.CanaryController,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+
+		cluster, err := parseIstioConfigClusterParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+		if !checkCanaryNamespaceAccess(w, r, conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, namespace, cluster) {
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, canaries.List(cluster, namespace, params["service"]))
+	}
+}
+
+// CanaryGet is the API handler to get the status and history of a canary run.
+func CanaryGet(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+	canaries *business.CanaryController,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+
+		cluster, err := parseIstioConfigClusterParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+		if !checkCanaryNamespaceAccess(w, r, conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, namespace, cluster) {
+			return
+		}
+		run, found := canaries.Get(params["run"])
+		if !found || run.Cluster != cluster || run.Namespace != namespace || run.Service != params["service"] {
+			RespondWithError(w, http.StatusNotFound, "Canary run "+params["run"]+" not found")
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, run)
+	}
+}
+
+// CanaryCancel is the API handler to cancel a canary run. All the traffic is shifted back to the baseline.
+func CanaryCancel(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+	canaries *business.CanaryController,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+
+		cluster, err := parseIstioConfigClusterParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+		if conf.Deployment.ViewOnlyMode {
+			RespondWithError(w, http.StatusForbidden, "Canary runs cannot be cancelled in view-only mode")
+			return
+		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, cluster) {
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+		run, found := canaries.Get(params["run"])
+		if !found || run.Cluster != cluster || run.Namespace != namespace || run.Service != params["service"] {
+			RespondWithError(w, http.StatusNotFound, "Canary run "+params["run"]+" not found")
+			return
+		}
+
+		run, err = canaries.Cancel(r.Context(), business, run.ID, r.Header.Get("Kiali-User"))
+		if err != nil {
+			handleCanaryError(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, run)
+	}
+}
+
+// checkCanaryNamespaceAccess responds with an error when the user cannot access the namespace of the runs.
+func checkCanaryNamespaceAccess(
+	w http.ResponseWriter,
+	r *http.Request,
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+	namespace, cluster string,
+) bool {
+	business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return false
+	}
+	if _, err := business.Namespace.GetClusterNamespace(r.Context(), namespace, cluster); err != nil {
+		handleErrorResponse(w, err)
+		return false
+	}
+	return true
+}
+
+func handleCanaryError(w http.ResponseWriter, err error) {
+	switch {
+	case api_errors.IsBadRequest(err):
+		RespondWithError(w, http.StatusBadRequest, "Canary request is not valid: "+err.Error())
+	case api_errors.IsConflict(err):
+		RespondWithError(w, http.StatusConflict, err.Error())
+	default:
+		handleErrorResponse(w, err)
+	}
+}
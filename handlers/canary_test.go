@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestCanaryRuns(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		&reviews,
+		&core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews", "version": "v1"}}},
+		&core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v2", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews", "version": "v2"}}},
+	)
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": k8s})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+	canaries := business.NewCanaryController(t.Context(), cache, cf, conf, discovery, prom)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/canaries", handlers.WithFakeAuthInfo(conf,
+		handlers.CanaryStart(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc, canaries))).Methods(http.MethodPost)
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/canaries", handlers.WithFakeAuthInfo(conf,
+		handlers.CanaryList(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc, canaries))).Methods(http.MethodGet)
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/canaries/{run}", handlers.WithFakeAuthInfo(conf,
+		handlers.CanaryGet(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc, canaries))).Methods(http.MethodGet)
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/canaries/{run}", handlers.WithFakeAuthInfo(conf,
+		handlers.CanaryCancel(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc, canaries))).Methods(http.MethodDelete)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	do := func(method, path, body string, result any) int {
+		req, err := http.NewRequest(method, ts.URL+"/api/namespaces/bookinfo/services/"+path, strings.NewReader(body))
+		require.NoError(err)
+		resp, err := ts.Client().Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode == http.StatusOK && result != nil {
+			require.NoErrorf(json.Unmarshal(raw, result), "response text: %s", string(raw))
+		}
+		return resp.StatusCode
+	}
+
+	start := `{"baseline":{"version":"v1"},"canary":{"version":"v2"},"stepWeight":25,"interval":"1h"}`
+	run := models.CanaryRun{}
+	require.Equal(http.StatusOK, do(http.MethodPost, "reviews/canaries", start, &run))
+	assert.Equal(models.CanaryStatusRunning, run.Status)
+	assert.Equal(int32(25), run.Weight)
+	assert.Equal(http.StatusConflict, do(http.MethodPost, "reviews/canaries", start, nil))
+	assert.Equal(http.StatusBadRequest, do(http.MethodPost, "reviews/canaries", `{"baseline":{"version":"v1"}}`, nil))
+
+	runs := []models.CanaryRun{}
+	require.Equal(http.StatusOK, do(http.MethodGet, "reviews/canaries", "", &runs))
+	require.Len(runs, 1)
+	assert.Equal(run.ID, runs[0].ID)
+	fetched := models.CanaryRun{}
+	require.Equal(http.StatusOK, do(http.MethodGet, "reviews/canaries/"+run.ID, "", &fetched))
+	assert.Len(fetched.History, 1)
+	assert.Equal(http.StatusNotFound, do(http.MethodGet, "details/canaries/"+run.ID, "", nil))
+
+	conf.Deployment.ViewOnlyMode = true
+	assert.Equal(http.StatusForbidden, do(http.MethodDelete, "reviews/canaries/"+run.ID, "", nil))
+	assert.Equal(http.StatusForbidden, do(http.MethodPost, "reviews/canaries", start, nil))
+	conf.Deployment.ViewOnlyMode = false
+
+	require.Equal(http.StatusOK, do(http.MethodDelete, "reviews/canaries/"+run.ID, "", &run))
+	assert.Equal(models.CanaryStatusCancelled, run.Status)
+	assert.Equal(int32(0), run.Weight)
+	assert.Equal(http.StatusConflict, do(http.MethodDelete, "reviews/canaries/"+run.ID, "", nil))
+}
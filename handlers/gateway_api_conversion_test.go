@@ -0,0 +1,129 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_networking_v1 "istio.io/api/networking/v1"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestGatewayAPIConversion(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	reviewsSvc := kubetest.FakeService("bookinfo", "reviews")
+	reviewsSvc.Spec.Ports = []core_v1.ServicePort{{Name: "http", Protocol: "TCP", Port: 9080}}
+	route := []*api_networking_v1.HTTPRoute{{
+		Route: []*api_networking_v1.HTTPRouteDestination{{Destination: &api_networking_v1.Destination{Host: "reviews"}}},
+	}}
+	reviews := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	reviews.Spec.Http = route
+	// The gateway is not converted: the route attached to it is not valid
+	edge := data.AddGatewaysToVirtualService([]string{"edge-gateway"}, data.CreateEmptyVirtualService("edge", "bookinfo", []string{"bookinfo.example.com"}))
+	edge.Spec.Http = route
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), &reviewsSvc, reviews, edge)
+	k8s.GatewayAPIEnabled = true
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": k8s})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/gateway_api_conversion", handlers.WithFakeAuthInfo(conf,
+		handlers.GatewayAPIConversion(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPost)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	post := func(query, body string) (int, models.GatewayAPIConversion) {
+		resp, err := ts.Client().Post(ts.URL+"/api/namespaces/bookinfo/istio/gateway_api_conversion"+query, "application/json", strings.NewReader(body))
+		require.NoError(err)
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(resp.Body)
+		result := models.GatewayAPIConversion{}
+		if resp.StatusCode < http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity {
+			require.NoErrorf(json.Unmarshal(raw, &result), "response text: %s", string(raw))
+		}
+		return resp.StatusCode, result
+	}
+
+	status, result := post("", "")
+	require.Equal(http.StatusOK, status)
+	assert.False(result.Applied)
+	assert.False(result.Valid)
+	require.Len(result.Objects, 2)
+	require.Len(result.Issues, 1)
+	assert.Equal("spec/gateways[0]", result.Issues[0].Path)
+
+	status, result = post("?apply=true", "")
+	require.Equal(http.StatusUnprocessableEntity, status)
+	assert.False(result.Applied)
+	_, err = k8s.GatewayAPI().GatewayV1().HTTPRoutes("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+
+	// The Kiali roles must grant the edit-istio-config capability to apply, not to review
+	conf.Auth.KialiRBAC = config.KialiRBACConfig{
+		DefaultRoles: []string{"viewer"},
+		Enabled:      true,
+		Roles:        []config.KialiRole{{Name: "viewer", Capabilities: []string{config.KialiCapabilityViewGraph}}},
+	}
+	status, _ = post("", `{"virtualServices":["reviews"]}`)
+	assert.Equal(http.StatusOK, status)
+	status, _ = post("?apply=true", `{"virtualServices":["reviews"]}`)
+	assert.Equal(http.StatusForbidden, status)
+	_, err = k8s.GatewayAPI().GatewayV1().HTTPRoutes("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+	conf.Auth.KialiRBAC.Enabled = false
+
+	status, result = post("?apply=true", `{"virtualServices":["reviews"]}`)
+	require.Equal(http.StatusOK, status)
+	assert.True(result.Applied)
+	assert.True(result.Valid)
+	httpRoute, err := k8s.GatewayAPI().GatewayV1().HTTPRoutes("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal("reviews", string(httpRoute.Spec.ParentRefs[0].Name))
+	// The VirtualService is not deleted
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.NoError(err)
+
+	status, _ = post("", `{"virtualServices":["ratings"]}`)
+	assert.Equal(http.StatusNotFound, status)
+	status, _ = post("", `{"virtualServices":`)
+	assert.Equal(http.StatusBadRequest, status)
+	status, _ = post("?dryRun=true", "")
+	assert.Equal(http.StatusBadRequest, status)
+}
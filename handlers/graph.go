@@ -71,6 +71,9 @@ func GraphNamespaces(
 		graph.CheckError(err)
 
 		o := graph.NewOptions(r, business, conf)
+		if !checkGraphCapability(w, r, conf, o) {
+			return
+		}
 
 		code, payload := graphNamespacesWithCache(r.Context(), business, prom, o, graphCache, refreshJobManager)
 		respond(w, code, payload)
@@ -96,12 +99,43 @@ func GraphNode(
 		graph.CheckError(err)
 
 		o := graph.NewOptions(r, business, conf)
+		if !checkGraphCapability(w, r, conf, o) {
+			return
+		}
 
 		code, payload := api.GraphNode(r.Context(), business, prom, o)
 		respond(w, code, payload)
 	}
 }
 
+// checkGraphCapability checks that the Kiali roles grant the view-graph capability in the requested namespaces, in
+// all the clusters the graph covers: the cluster of a node graph, or all the clusters where the namespace is accessible.
+func checkGraphCapability(w http.ResponseWriter, r *http.Request, conf *config.Config, o graph.Options) bool {
+	namespaces := map[string]bool{}
+	for name := range o.Namespaces {
+		namespaces[name] = true
+	}
+	if o.NodeOptions.Namespace.Name != "" {
+		namespaces[o.NodeOptions.Namespace.Name] = true
+	}
+	for name := range namespaces {
+		var clusters []string
+		if o.NodeOptions.Cluster != "" && name == o.NodeOptions.Namespace.Name {
+			clusters = []string{o.NodeOptions.Cluster}
+		} else {
+			for _, an := range o.AccessibleNamespaces {
+				if an.Name == name {
+					clusters = append(clusters, an.Cluster)
+				}
+			}
+		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityViewGraph, name, clusters...) {
+			return false
+		}
+	}
+	return true
+}
+
 func handlePanic(ctx context.Context, w http.ResponseWriter) {
 	code := http.StatusInternalServerError
 	if r := recover(); r != nil {
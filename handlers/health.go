@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
+
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -14,6 +16,7 @@ import (
 	"github.com/kiali/kiali/istio"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/tracing"
 	"github.com/kiali/kiali/util"
@@ -111,3 +114,65 @@ func adjustRateInterval(ctx context.Context, business *business.Layer, namespace
 
 	return interval, nil
 }
+
+// HealthHistory is the API handler to get the timeline of the health transitions of the apps, services and
+// workloads of a namespace, as recorded by the health cache refreshes. The 'type' and 'name' query parameters
+// select the entities of a type, or a single entity.
+func HealthHistory(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !conf.HealthConfig.History.Enabled {
+			RespondWithError(w, http.StatusNotFound, "Health history is disabled")
+			return
+		}
+		parsed, err := queryparams.ParseWithConfig(r.URL.Query(), conf, healthHistoryQueryParams)
+		if err != nil {
+			RespondWithQueryParamError(w, err.Error())
+			return
+		}
+		namespace := mux.Vars(r)["namespace"]
+		cluster := parsed.Cluster()
+
+		businessLayer, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Initialization error: "+err.Error())
+			return
+		}
+		// Only the users with access to the namespace get its history
+		if _, err := businessLayer.Namespace.GetClusterNamespace(r.Context(), namespace, cluster); err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, models.HealthTimeline{
+			Cluster:   cluster,
+			Entities:  kialiCache.GetHealthHistory(cluster, namespace, parsed.String("type"), parsed.String("name")),
+			Namespace: namespace,
+		})
+	}
+}
+
+// SLORules is the API handler to get the Prometheus recording and alerting rules of the SLOs of the health config,
+// as a rules file that Prometheus can load.
+func SLORules(conf *config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rules, err := business.GenerateSLORules(conf)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "SLO rules generation error: "+err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(rules); err != nil {
+			log.FromRequest(r).Error().Msgf("Error writing the SLO rules: %s", err)
+		}
+	}
+}
@@ -60,3 +60,16 @@ func (p *baseHealthParams) parseSchema(conf *config.Config, r *http.Request, ext
 	p.apply(result)
 	return result, nil
 }
+
+// healthHistoryQueryParams documents the HealthHistory query contract.
+var healthHistoryQueryParams = []queryparams.Param{
+	queryparams.ClusterParam(),
+	queryparams.StringParam("name", ""),
+	queryparams.EnumParam("type", "app", "service", "workload"),
+}
+
+// meshHealthQueryParams documents the MeshHealth query contract.
+var meshHealthQueryParams = []queryparams.Param{
+	queryparams.TimestampParam("queryTime"),
+	queryparams.PromDurationParam("rateInterval", config.DefaultHealthRateInterval),
+}
@@ -619,3 +619,58 @@ func TestCacheUpdateAddsNewEntry(t *testing.T) {
 	require.NotNil(t, retrieved.WorkloadHealth["workload2"])
 	assert.Equal(t, int32(2), retrieved.WorkloadHealth["workload2"].WorkloadStatus.DesiredReplicas)
 }
+
+func TestSLORules(t *testing.T) {
+	conf := config.NewConfig()
+	conf.HealthConfig.SLO = []config.SLO{{Name: "availability", Objective: 99.9, Type: config.SLOTypeAvailability}}
+
+	w := httptest.NewRecorder()
+	SLORules(conf)(w, httptest.NewRequest(http.MethodGet, "/api/health/slo/rules", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "record: kiali:slo_errors:ratio_rate30d")
+	assert.Contains(t, w.Body.String(), "alert: KialiSLOErrorBudgetBurn")
+}
+
+func TestHealthHistory(t *testing.T) {
+	require := require.New(t)
+	conf := config.NewConfig()
+	k8s := kubetest.NewFakeK8sClient(setupMockData())
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	kialiCache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), kialiCache, conf)
+	traceLoader := func() tracing.ClientInterface { return nil }
+
+	cluster := conf.KubernetesConfig.ClusterName
+	kialiCache.SetHealth(cluster, "ns", &models.CachedHealthData{
+		ComputedAt: time.Now(),
+		AppHealth:  models.NamespaceAppHealth{"reviews": {Status: &models.CalculatedHealthStatus{Status: models.HealthStatusHealthy}}},
+		WorkloadHealth: models.NamespaceWorkloadHealth{
+			"reviews-v1": {Status: &models.CalculatedHealthStatus{Status: models.HealthStatusFailure, Signal: models.HealthSignalWorkloadStatus}},
+		},
+	})
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/health/history", WithFakeAuthInfo(conf, HealthHistory(conf, kialiCache, cf, prom, traceLoader, discovery, &business.FakeControlPlaneMonitor{}, nil)))
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	resp, err := ts.Client().Get(ts.URL + "/api/namespaces/ns/health/history?type=workload&name=reviews-v1")
+	require.NoError(err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	require.Equal(http.StatusOK, resp.StatusCode, string(body))
+
+	timeline := models.HealthTimeline{}
+	require.NoError(json.Unmarshal(body, &timeline))
+	require.Equal("ns", timeline.Namespace)
+	require.Len(timeline.Entities, 1)
+	require.Equal("reviews-v1", timeline.Entities[0].Name)
+	require.Equal(models.HealthStatusFailure, timeline.Entities[0].Transitions[0].Status)
+
+	resp, err = ts.Client().Get(ts.URL + "/api/namespaces/unknown/health/history")
+	require.NoError(err)
+	defer resp.Body.Close()
+	require.NotEqual(http.StatusOK, resp.StatusCode)
+}
@@ -6,11 +6,14 @@ import (
 
 	"github.com/gorilla/mux"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers/authentication"
 	"github.com/kiali/kiali/istio"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
@@ -223,10 +226,13 @@ func IstioConfigDelete(
 		object := params["object"]
 
 		query := r.URL.Query()
-		cluster, err := parseIstioConfigClusterParams(conf, query)
+		cluster, clusters, err := parseIstioConfigWriteParams(conf, query)
 		if respondQueryParamError(w, err) {
 			return
 		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, append(clusters, cluster)...) {
+			return
+		}
 
 		gvk := schema.GroupVersionKind{
 			Group:   objectGroup,
@@ -244,12 +250,29 @@ func IstioConfigDelete(
 			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
 			return
 		}
+		if len(clusters) > 0 {
+			respondIstioConfigInClusters(w, r, conf, business, models.GitOpsOperationDelete, clusters, namespace, gvk, object, nil)
+			return
+		}
+		if proposeIstioConfigChange(w, r, conf, business, models.GitOpsOperationDelete, cluster, namespace, gvk, object, nil) {
+			return
+		}
+		before := auditedIstioObject(r, conf, business, cluster, namespace, gvk, object)
 		err = business.IstioConfig.DeleteIstioConfigDetail(r.Context(), cluster, namespace, gvk, object)
+		audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+			Operation: "DELETE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			Name:      object,
+			GVK:       gvk,
+			Before:    before,
+			Err:       err,
+			Message:   "Name: [" + object + "]",
+		})
 		if err != nil {
 			handleErrorResponse(w, err)
 			return
 		} else {
-			audit(r, "DELETE", namespace, gvk.String(), "Name: ["+object+"]")
 			RespondWithCode(w, http.StatusOK)
 		}
 	}
@@ -275,10 +298,13 @@ func IstioConfigUpdate(
 		object := params["object"]
 
 		query := r.URL.Query()
-		cluster, err := parseIstioConfigClusterParams(conf, query)
+		cluster, clusters, err := parseIstioConfigWriteParams(conf, query)
 		if respondQueryParamError(w, err) {
 			return
 		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, append(clusters, cluster)...) {
+			return
+		}
 
 		gvk := schema.GroupVersionKind{
 			Group:   objectGroup,
@@ -302,14 +328,33 @@ func IstioConfigUpdate(
 			RespondWithError(w, http.StatusBadRequest, "Update request with bad update patch: "+err.Error())
 			return
 		}
+		if len(clusters) > 0 {
+			respondIstioConfigInClusters(w, r, conf, business, models.GitOpsOperationUpdate, clusters, namespace, gvk, object, body)
+			return
+		}
+		if proposeIstioConfigChange(w, r, conf, business, models.GitOpsOperationUpdate, cluster, namespace, gvk, object, body) {
+			return
+		}
 		jsonPatch := string(body)
+		before := auditedIstioObject(r, conf, business, cluster, namespace, gvk, object)
 		updatedConfigDetails, err := business.IstioConfig.UpdateIstioConfigDetail(r.Context(), cluster, namespace, gvk, object, jsonPatch)
+		audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+			Operation: "UPDATE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			Name:      object,
+			GVK:       gvk,
+			Before:    before,
+			After:     updatedConfigDetails.Object,
+			Patch:     jsonPatch,
+			Err:       err,
+			Message:   "Name: [" + object + "], Patch: " + jsonPatch,
+		})
 		if err != nil {
 			handleErrorResponse(w, err)
 			return
 		}
 
-		audit(r, "UPDATE", namespace, gvk.String(), "Name: ["+object+"], Patch: "+jsonPatch)
 		RespondWithJSON(w, http.StatusOK, updatedConfigDetails)
 	}
 }
@@ -334,10 +379,13 @@ func IstioConfigCreate(
 		objectKind := params["kind"]
 
 		query := r.URL.Query()
-		cluster, err := parseIstioConfigClusterParams(conf, query)
+		cluster, clusters, err := parseIstioConfigWriteParams(conf, query)
 		if respondQueryParamError(w, err) {
 			return
 		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, append(clusters, cluster)...) {
+			return
+		}
 
 		gvk := schema.GroupVersionKind{
 			Group:   objectGroup,
@@ -362,13 +410,33 @@ func IstioConfigCreate(
 			return
 		}
 
+		if len(clusters) > 0 {
+			respondIstioConfigInClusters(w, r, conf, business, models.GitOpsOperationCreate, clusters, namespace, gvk, "", body)
+			return
+		}
+		if proposeIstioConfigChange(w, r, conf, business, models.GitOpsOperationCreate, cluster, namespace, gvk, "", body) {
+			return
+		}
+
 		createdConfigDetails, err := business.IstioConfig.CreateIstioConfigDetail(r.Context(), cluster, namespace, gvk, body)
+		entry := audit.Entry{
+			Operation: "CREATE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			GVK:       gvk,
+			After:     createdConfigDetails.Object,
+			Err:       err,
+			Message:   "Object: " + string(body),
+		}
+		if err == nil && createdConfigDetails.Object != nil {
+			entry.Name = createdConfigDetails.Object.GetName()
+		}
+		audit.Log(r, conf, models.AuditSourceAPI, entry)
 		if err != nil {
 			handleErrorResponse(w, err)
 			return
 		}
 
-		audit(r, "CREATE", namespace, gvk.String(), "Object: "+string(body))
 		RespondWithJSON(w, http.StatusOK, createdConfigDetails)
 	}
 }
@@ -377,17 +445,17 @@ func checkObjectType(gvk schema.GroupVersionKind) bool {
 	return business.GetIstioAPI(gvk)
 }
 
-func audit(r *http.Request, operation, namespace, gvk, message string) {
-	if config.Get().Server.AuditLog {
-		user := r.Header.Get("Kiali-User")
-		log.FromRequest(r).
-			Info().
-			Str("operation", operation).
-			Str("namespace", namespace).
-			Str("gvk", gvk).
-			Str("user", user).
-			Msgf("AUDIT: %s", message)
+// auditedIstioObject returns the current state of the object to be modified, or nil when audit is disabled
+// or the object cannot be read.
+func auditedIstioObject(r *http.Request, conf *config.Config, layer *business.Layer, cluster, namespace string, gvk schema.GroupVersionKind, object string) client.Object {
+	if !conf.Server.AuditLog || audit.FromContext(r.Context()) == nil {
+		return nil
+	}
+	details, err := layer.IstioConfig.GetIstioConfigDetails(r.Context(), cluster, namespace, gvk, object)
+	if err != nil {
+		return nil
 	}
+	return details.Object
 }
 
 func IstioConfigPermissions(
@@ -425,6 +493,16 @@ func IstioConfigPermissions(
 			ns := strings.Split(namespaces, ",")
 			istioConfigPermissions = business.IstioConfig.GetIstioConfigPermissions(r.Context(), ns, cluster)
 		}
+		// The Kiali roles can only take away the write permissions granted by Kubernetes RBAC
+		identity := authentication.GetUserIdentityContext(r.Context())
+		for namespace, permissions := range istioConfigPermissions {
+			if permissions == nil || authentication.HasCapability(conf, identity, config.KialiCapabilityEditIstioConfig, cluster, namespace) {
+				continue
+			}
+			for _, rp := range *permissions {
+				rp.Create, rp.Update, rp.Delete = false, false, false
+			}
+		}
 		RespondWithJSON(w, http.StatusOK, istioConfigPermissions)
 	}
 }
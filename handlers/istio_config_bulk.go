@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers/authentication"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+// IstioConfigBulk is the API handler to patch, change the metadata of or delete all the Istio objects matching some criteria.
+// It responds 200 when the operation succeeded for every object and 207 otherwise. A dry run only previews the operation.
+func IstioConfigBulk(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun, err := parseIstioConfigBulkParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+		if !dryRun {
+			if conf.Deployment.ViewOnlyMode {
+				RespondWithError(w, http.StatusForbidden, "Istio config cannot be changed in bulk in view-only mode")
+				return
+			}
+			if gitops.FromContext(r.Context()) != nil {
+				RespondWithError(w, http.StatusConflict, "Bulk operations change the objects in the cluster and cannot be applied in GitOps mode")
+				return
+			}
+		}
+
+		body, err := boundedReadAll(r)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Bulk request could not be read: "+err.Error())
+			return
+		}
+		var req models.IstioConfigBulkRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Bulk request is not valid: "+err.Error())
+			return
+		}
+		// The namespaces and clusters of the operation are only known from the body, so the restrictions of the
+		// API token authenticating the request are checked here
+		if token := authentication.GetAPITokenContext(r.Context()); token != nil {
+			clusters := req.Clusters
+			if len(clusters) == 0 {
+				clusters = []string{conf.KubernetesConfig.ClusterName}
+			}
+			if err := token.Authorize(r, req.Namespaces, clusters); err != nil {
+				RespondWithError(w, http.StatusForbidden, err.Error())
+				return
+			}
+		}
+		if !dryRun {
+			// Without namespaces, the bulk operation applies to all the accessible namespaces
+			namespaces := req.Namespaces
+			if len(namespaces) == 0 {
+				namespaces = []string{""}
+			}
+			for _, namespace := range namespaces {
+				if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, req.Clusters...) {
+					return
+				}
+			}
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		result, err := business.IstioConfig.BulkIstioConfig(r.Context(), req, dryRun)
+		if err != nil {
+			if api_errors.IsBadRequest(err) {
+				RespondWithError(w, http.StatusBadRequest, "Bulk request is not valid: "+err.Error())
+				return
+			}
+			handleErrorResponse(w, err)
+			return
+		}
+
+		if !dryRun {
+			for _, obj := range result.Objects {
+				entry := audit.Entry{
+					Operation: models.GitOpsOperationUpdate,
+					Cluster:   obj.Cluster,
+					Namespace: obj.Namespace,
+					Name:      obj.Name,
+					GVK:       obj.ObjectGVK,
+					Before:    obj.Before,
+					After:     obj.After,
+					Message:   "Name: [" + obj.Name + "], Bulk operation: " + req.Operation,
+				}
+				if req.Operation == models.IstioConfigBulkDelete {
+					entry.Operation = models.GitOpsOperationDelete
+				}
+				if obj.Error != "" {
+					entry.Err = errors.New(obj.Error)
+				}
+				audit.Log(r, conf, models.AuditSourceAPI, entry)
+			}
+		}
+
+		status := http.StatusOK
+		if result.Failed() > 0 {
+			status = http.StatusMultiStatus
+		}
+		RespondWithJSON(w, status, result)
+	}
+}
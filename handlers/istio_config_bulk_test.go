@@ -0,0 +1,159 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/handlers/authentication"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestIstioConfigBulk(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	reviews := data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"})
+	reviews.Labels = map[string]string{"migrated": "true"}
+	details := data.CreateEmptyVirtualService("details", "bookinfo", []string{"details"})
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"), reviews, details)
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": k8s})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/istio/config/bulk", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigBulk(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPost)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	post := func(query, body string) (int, models.IstioConfigBulkResult) {
+		resp, err := ts.Client().Post(ts.URL+"/api/istio/config/bulk"+query, "application/json", strings.NewReader(body))
+		require.NoError(err)
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(resp.Body)
+		result := models.IstioConfigBulkResult{}
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusMultiStatus {
+			require.NoErrorf(json.Unmarshal(raw, &result), "response text: %s", string(raw))
+		}
+		return resp.StatusCode, result
+	}
+
+	deleteMigrated := `{"labelSelector":"migrated=true","operation":"delete"}`
+	status, result := post("?dryRun=true", deleteMigrated)
+	require.Equal(http.StatusOK, status)
+	assert.True(result.DryRun)
+	require.Len(result.Objects, 1)
+	assert.Equal("reviews", result.Objects[0].Name)
+
+	status, _ = post("", `{"operation":"delete"}`)
+	assert.Equal(http.StatusBadRequest, status)
+	status, _ = post("?dryRun=maybe", deleteMigrated)
+	assert.Equal(http.StatusBadRequest, status)
+
+	// A dry run is allowed in view-only mode
+	conf.Deployment.ViewOnlyMode = true
+	status, _ = post("", deleteMigrated)
+	assert.Equal(http.StatusForbidden, status)
+	status, _ = post("?dryRun=true", deleteMigrated)
+	assert.Equal(http.StatusOK, status)
+	conf.Deployment.ViewOnlyMode = false
+
+	status, result = post("", deleteMigrated)
+	require.Equal(http.StatusOK, status)
+	assert.False(result.DryRun)
+	require.Len(result.Objects, 1)
+	assert.Empty(result.Objects[0].Error)
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "details", meta_v1.GetOptions{})
+	assert.NoError(err)
+}
+
+func TestIstioConfigBulkAppliesAPITokenRestrictions(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	kubernetes.CacheWaitTimeout = 1 * time.Millisecond
+	t.Cleanup(func() { kubernetes.CacheWaitTimeout = 5 * time.Second })
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	nsA := data.CreateEmptyVirtualService("reviews", "ns-a", []string{"reviews"})
+	nsA.Labels = map[string]string{"migrated": "true"}
+	nsB := data.CreateEmptyVirtualService("reviews", "ns-b", []string{"reviews"})
+	nsB.Labels = map[string]string{"migrated": "true"}
+	k8s := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("ns-a"), kubetest.FakeNamespace("ns-b"), nsA, nsB)
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": k8s})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+
+	// The request is authenticated with an API token restricted to ns-a
+	token := &authentication.APIToken{ID: "1", Name: "ci", Namespaces: []string{"ns-a"}}
+	bulk := handlers.WithFakeAuthInfo(conf, handlers.IstioConfigBulk(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/istio/config/bulk", func(w http.ResponseWriter, r *http.Request) {
+		bulk(w, r.WithContext(authentication.SetAPITokenContext(r.Context(), token)))
+	}).Methods(http.MethodPost)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	post := func(body string) int {
+		resp, err := ts.Client().Post(ts.URL+"/api/istio/config/bulk", "application/json", strings.NewReader(body))
+		require.NoError(err)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(http.StatusForbidden, post(`{"labelSelector":"migrated=true","namespaces":["ns-b"],"operation":"delete"}`))
+	assert.Equal(http.StatusForbidden, post(`{"labelSelector":"migrated=true","operation":"delete"}`), "all the namespaces")
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("ns-b").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.NoError(err)
+
+	token.Clusters = []string{"west"}
+	assert.Equal(http.StatusForbidden, post(`{"labelSelector":"migrated=true","namespaces":["ns-a"],"operation":"delete"}`), "the home cluster")
+	token.Clusters = nil
+
+	assert.Equal(http.StatusOK, post(`{"labelSelector":"migrated=true","namespaces":["ns-a"],"operation":"delete"}`))
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("ns-a").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+}
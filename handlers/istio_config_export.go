@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"path"
+
+	"github.com/gorilla/mux"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+// IstioConfigExport is the API handler to export the Istio config of a namespace as a Helm chart or a Kustomize
+// base with per-cluster overlays. The files are returned as JSON, or as a gzipped tarball with the archive parameter.
+func IstioConfigExport(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := mux.Vars(r)["namespace"]
+
+		params, err := parseIstioConfigExportParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+
+		criteria := business.ParseIstioConfigCriteria(params.Objects, params.LabelSelector, params.WorkloadSelector)
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		export, err := business.IstioConfig.ExportIstioConfig(r.Context(), params.ClusterName, namespace, criteria, params.Options)
+		if err != nil {
+			if api_errors.IsBadRequest(err) {
+				RespondWithError(w, http.StatusBadRequest, "Export request is not valid: "+err.Error())
+				return
+			}
+			handleErrorResponse(w, err)
+			return
+		}
+
+		if !params.Archive {
+			RespondWithJSON(w, http.StatusOK, export)
+			return
+		}
+
+		root := params.Options.ChartName
+		if root == "" {
+			root = namespace
+		}
+		archive, err := exportArchive(root, export)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Export archive error: "+err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+root+"-"+export.Format+`.tgz"`)
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(archive); err != nil {
+			log.FromRequest(r).Error().Msgf("Error writing the export archive: %s", err)
+		}
+	}
+}
+
+// exportArchive returns the files of the export in a gzipped tarball, under the root directory like a packaged chart.
+func exportArchive(root string, export *models.IstioConfigExport) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range export.Files {
+		header := &tar.Header{Name: path.Join(root, file.Path), Mode: 0o644, Size: int64(len(file.Content))}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(file.Content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
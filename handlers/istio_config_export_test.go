@@ -0,0 +1,92 @@
+package handlers_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestIstioConfigExport(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews.example.com"}),
+	)
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": k8s})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/export", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigExport(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodGet)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	get := func(query string) *http.Response {
+		resp, err := ts.Client().Get(ts.URL + "/api/namespaces/bookinfo/istio/export" + query)
+		require.NoError(err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := get("?format=kustomize")
+	require.Equal(http.StatusOK, resp.StatusCode)
+	export := models.IstioConfigExport{}
+	require.NoError(json.NewDecoder(resp.Body).Decode(&export))
+	assert.Equal("east", export.Cluster)
+	assert.Equal(map[string]string{"reviews_example_com": "reviews.example.com"}, export.Hosts)
+	assert.Len(export.Files, 3)
+
+	resp = get("?format=helm&chartName=reviews&archive=true")
+	require.Equal(http.StatusOK, resp.StatusCode)
+	assert.Equal("application/gzip", resp.Header.Get("Content-Type"))
+	assert.Contains(resp.Header.Get("Content-Disposition"), "reviews-helm.tgz")
+	gz, err := gzip.NewReader(resp.Body)
+	require.NoError(err)
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		names = append(names, header.Name)
+	}
+	assert.Equal([]string{"reviews/Chart.yaml", "reviews/templates/virtualservice-reviews.yaml", "reviews/values-east.yaml", "reviews/values.yaml"}, names)
+
+	assert.Equal(http.StatusBadRequest, get("").StatusCode)
+	assert.Equal(http.StatusBadRequest, get("?format=helm&chartName=Reviews").StatusCode)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
+)
+
+// proposeIstioConfigChange proposes the write operation to the GitOps repository when the GitOps mode is enabled,
+// instead of applying it to the cluster. It returns false, without writing any response, when the mode is disabled.
+// body is the object of a CREATE and the patch of an UPDATE.
+func proposeIstioConfigChange(w http.ResponseWriter, r *http.Request, conf *config.Config, layer *business.Layer, operation, cluster, namespace string, gvk schema.GroupVersionKind, name string, body []byte) bool {
+	proposer := gitops.FromContext(r.Context())
+	if proposer == nil {
+		return false
+	}
+
+	proposed, err := proposeAndAudit(r, conf, layer, proposer, operation, cluster, namespace, gvk, name, body)
+	if errors.Is(err, gitops.ErrNoChanges) {
+		RespondWithError(w, http.StatusConflict, err.Error())
+		return true
+	}
+	if err != nil {
+		handleErrorResponse(w, err)
+		return true
+	}
+
+	RespondWithJSON(w, http.StatusAccepted, proposed)
+	return true
+}
+
+// proposeAndAudit proposes the write operation in one cluster to the GitOps repository and audits the proposal.
+func proposeAndAudit(r *http.Request, conf *config.Config, layer *business.Layer, proposer *gitops.Proposer, operation, cluster, namespace string, gvk schema.GroupVersionKind, name string, body []byte) (*models.GitOpsChange, error) {
+	change := gitops.Change{
+		Operation: operation,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+		GVK:       gvk,
+		User:      r.Header.Get("Kiali-User"),
+	}
+	if operation == models.GitOpsOperationUpdate {
+		change.Patch = string(body)
+	}
+
+	proposed, err := layer.IstioConfig.ProposeIstioConfigChange(r.Context(), proposer, change, body)
+	entry := audit.Entry{
+		Operation: operation,
+		Cluster:   cluster,
+		Namespace: namespace,
+		Name:      name,
+		GVK:       gvk,
+		Patch:     change.Patch,
+		Err:       err,
+	}
+	if err == nil {
+		entry.Name = proposed.Name
+		entry.Message = "GitOps proposal: Name: [" + proposed.Name + "], Branch: [" + proposed.Branch + "], URL: [" + proposed.URL + "]"
+	} else {
+		entry.Message = "GitOps proposal failed: Name: [" + name + "]"
+	}
+	audit.Log(r, conf, models.AuditSourceAPI, entry)
+
+	return proposed, err
+}
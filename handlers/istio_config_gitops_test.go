@@ -0,0 +1,177 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestIstioConfigDeleteInGitOpsMode(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	proposals := 0
+	gitAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proposals++
+		if proposals > 1 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		_, _ = w.Write([]byte(`{"url":"https://git.example.com/mesh/pull/1"}`))
+	}))
+	t.Cleanup(gitAPI.Close)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.ExternalServices.GitOps.Enabled = true
+	conf.ExternalServices.GitOps.Provider = config.GitOpsProviderAPI
+	conf.ExternalServices.GitOps.API.URL = gitAPI.URL
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+	proposer, err := gitops.NewProposer(conf)
+	require.NoError(err)
+
+	handler := handlers.WithFakeAuthInfo(conf, handlers.IstioConfigDelete(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}", func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r.WithContext(gitops.NewContext(r.Context(), proposer)))
+	}).Methods(http.MethodDelete)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	del := func() (int, []byte) {
+		req, err := http.NewRequest(http.MethodDelete, ts.URL+reviewsVirtualServicePath, nil)
+		require.NoError(err)
+		resp, err := ts.Client().Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, body
+	}
+
+	status, body := del()
+	require.Equalf(http.StatusAccepted, status, "response text: %s", string(body))
+	change := models.GitOpsChange{}
+	require.NoError(json.Unmarshal(body, &change))
+	assert.Equal(models.GitOpsOperationDelete, change.Operation)
+	assert.Equal("https://git.example.com/mesh/pull/1", change.URL)
+	assert.Equal("east/bookinfo/VirtualService/reviews.yaml", change.Path)
+
+	// The cluster object is untouched
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+
+	status, _ = del()
+	assert.Equal(http.StatusConflict, status)
+}
+
+func TestIstioConfigRollbackInGitOpsMode(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	var proposed map[string]any
+	gitAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&proposed)
+		_, _ = w.Write([]byte(`{"url":"https://git.example.com/mesh/pull/2"}`))
+	}))
+	t.Cleanup(gitAPI.Close)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	conf.ExternalServices.GitOps.Enabled = true
+	conf.ExternalServices.GitOps.Provider = config.GitOpsProviderAPI
+	conf.ExternalServices.GitOps.API.URL = gitAPI.URL
+	conf.KialiFeatureFlags.ConfigHistory.Enabled = true
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+	proposer, err := gitops.NewProposer(conf)
+	require.NoError(err)
+
+	// The revision is recorded by an update applied before the GitOps mode
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigUpdate(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPatch)
+	rollback := handlers.WithFakeAuthInfo(conf, handlers.IstioConfigRollback(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions/{revision}/rollback", func(w http.ResponseWriter, r *http.Request) {
+		rollback(w, r.WithContext(gitops.NewContext(r.Context(), proposer)))
+	}).Methods(http.MethodPost)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	do := func(method, path, body string) (int, []byte) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(err)
+		resp, err := ts.Client().Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, respBody
+	}
+
+	status, body := do(http.MethodPatch, reviewsVirtualServicePath, `{"spec":{"hosts":["ratings"]}}`)
+	require.Equalf(http.StatusOK, status, "response text: %s", string(body))
+
+	status, body = do(http.MethodPost, reviewsVirtualServicePath+"/revisions/1/rollback", "")
+	require.Equalf(http.StatusAccepted, status, "response text: %s", string(body))
+	change := models.GitOpsChange{}
+	require.NoError(json.Unmarshal(body, &change))
+	assert.Equal(models.GitOpsOperationUpdate, change.Operation)
+	assert.Equal("https://git.example.com/mesh/pull/2", change.URL)
+	assert.Contains(fmt.Sprint(proposed), "- reviews")
+
+	// Nothing is applied: an applied rollback records the replaced version as a new revision
+	key := models.IstioConfigRevisionKey{Cluster: "east", Namespace: "bookinfo", ObjectGVK: kubernetes.VirtualServices, Name: "reviews"}
+	assert.Len(cache.GetConfigRevisions(key), 1)
+
+	// A dry run is not proposed
+	status, body = do(http.MethodPost, reviewsVirtualServicePath+"/revisions/1/rollback?dryRun=true", "")
+	require.Equalf(http.StatusOK, status, "response text: %s", string(body))
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+const configHistoryDisabled = "The Istio config history is disabled"
+
+// IstioConfigRevisions is the API handler to list the revisions of an Istio object with their diffs.
+func IstioConfigRevisions(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+		object := params["object"]
+		gvk := schema.GroupVersionKind{Group: params["group"], Version: params["version"], Kind: params["kind"]}
+
+		cluster, err := parseIstioConfigClusterParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+
+		if !conf.KialiFeatureFlags.ConfigHistory.Enabled {
+			RespondWithError(w, http.StatusServiceUnavailable, configHistoryDisabled)
+			return
+		}
+
+		if !business.GetIstioAPI(gvk) {
+			RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+gvk.String())
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		revisions, err := business.IstioConfig.GetIstioConfigRevisions(r.Context(), cluster, namespace, gvk, object)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, revisions)
+	}
+}
+
+// IstioConfigRollback is the API handler to restore a revision of an Istio object.
+// The rollback is validated with a server side dry run before it is applied.
+func IstioConfigRollback(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+		object := params["object"]
+		gvk := schema.GroupVersionKind{Group: params["group"], Version: params["version"], Kind: params["kind"]}
+
+		cluster, dryRun, err := parseIstioConfigRollbackParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+		if !dryRun && !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, cluster) {
+			return
+		}
+
+		revision, err := strconv.Atoi(params["revision"])
+		if err != nil || revision <= 0 {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Invalid revision [%s]", params["revision"]))
+			return
+		}
+
+		if !conf.KialiFeatureFlags.ConfigHistory.Enabled {
+			RespondWithError(w, http.StatusServiceUnavailable, configHistoryDisabled)
+			return
+		}
+
+		if !business.GetIstioAPI(gvk) {
+			RespondWithError(w, http.StatusBadRequest, "Object type not managed: "+gvk.String())
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		// In GitOps mode the rollback is proposed to the repository, as the other writes are.
+		if !dryRun && gitops.FromContext(r.Context()) != nil {
+			operation, body, err := business.IstioConfig.RollbackIstioConfigChange(r.Context(), cluster, namespace, gvk, object, revision)
+			if err != nil {
+				handleErrorResponse(w, err)
+				return
+			}
+			proposeIstioConfigChange(w, r, conf, business, operation, cluster, namespace, gvk, object, body)
+			return
+		}
+
+		// A dry run does not change anything so it is not audited.
+		var details models.IstioConfigDetails
+		if dryRun {
+			details, err = business.IstioConfig.RollbackIstioConfig(r.Context(), cluster, namespace, gvk, object, revision, true)
+		} else {
+			before := auditedIstioObject(r, conf, business, cluster, namespace, gvk, object)
+			details, err = business.IstioConfig.RollbackIstioConfig(r.Context(), cluster, namespace, gvk, object, revision, false)
+			audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+				Operation: "UPDATE",
+				Cluster:   cluster,
+				Namespace: namespace,
+				Name:      object,
+				GVK:       gvk,
+				Before:    before,
+				After:     details.Object,
+				Err:       err,
+				Message:   fmt.Sprintf("Name: [%s], Rollback to revision: [%d]", object, revision),
+			})
+		}
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, details)
+	}
+}
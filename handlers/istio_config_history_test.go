@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tracing"
+)
+
+const reviewsVirtualServicePath = "/api/namespaces/bookinfo/istio/networking.istio.io/v1/VirtualService/reviews"
+
+func setupConfigHistoryServer(t *testing.T) (*httptest.Server, *config.Config) {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(t, err)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigUpdate(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPatch)
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigRevisions(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc)))
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions/{revision}/rollback", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigRollback(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPost)
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	return ts, conf
+}
+
+func TestIstioConfigRevisionsAndRollback(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+	ts, _ := setupConfigHistoryServer(t)
+
+	do := func(method, path string, body string) (int, []byte) {
+		req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		require.NoError(err)
+		resp, err := ts.Client().Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, respBody
+	}
+
+	status, body := do(http.MethodPatch, reviewsVirtualServicePath, `{"spec":{"hosts":["ratings"]}}`)
+	require.Equalf(http.StatusOK, status, "response text: %s", string(body))
+
+	status, body = do(http.MethodGet, reviewsVirtualServicePath+"/revisions", "")
+	require.Equalf(http.StatusOK, status, "response text: %s", string(body))
+	revisions := models.IstioConfigRevisions{}
+	require.NoError(json.Unmarshal(body, &revisions))
+	require.Len(revisions.Revisions, 1)
+	assert.Equal("reviews", revisions.Name)
+	assert.Equal(1, revisions.Revisions[0].Revision)
+	assert.Contains(string(revisions.Revisions[0].Object), `"hosts":["reviews"]`)
+	assert.JSONEq(`{"spec":{"hosts":["ratings"]}}`, string(revisions.Revisions[0].Diff))
+
+	status, body = do(http.MethodPost, reviewsVirtualServicePath+"/revisions/1/rollback", "")
+	require.Equalf(http.StatusOK, status, "response text: %s", string(body))
+	details := models.IstioConfigDetails{}
+	require.NoError(json.Unmarshal(body, &details))
+	assert.Equal([]string{"reviews"}, details.VirtualService.Spec.Hosts)
+
+	status, _ = do(http.MethodPost, reviewsVirtualServicePath+"/revisions/5/rollback", "")
+	assert.Equal(http.StatusNotFound, status)
+}
+
+func TestIstioConfigRollbackBadRequest(t *testing.T) {
+	ts, conf := setupConfigHistoryServer(t)
+
+	for _, path := range []string{
+		reviewsVirtualServicePath + "/revisions/latest/rollback",
+		reviewsVirtualServicePath + "/revisions/1/rollback?dryRun=maybe",
+		"/api/namespaces/bookinfo/istio/apps/v1/Deployment/reviews/revisions/1/rollback",
+	} {
+		resp, err := ts.Client().Post(ts.URL+path, "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equalf(t, http.StatusBadRequest, resp.StatusCode, "path: %s", path)
+	}
+
+	conf.KialiFeatureFlags.ConfigHistory.Enabled = false
+	resp, err := ts.Client().Get(ts.URL + reviewsVirtualServicePath + "/revisions")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/models"
+)
+
+// respondIstioConfigInClusters performs the write operation in every cluster and responds with the per cluster results:
+// 200 (202 in GitOps mode) when it succeeded everywhere, 207 otherwise.
+// body is the object of a CREATE and the patch of an UPDATE.
+func respondIstioConfigInClusters(w http.ResponseWriter, r *http.Request, conf *config.Config, layer *business.Layer, operation string, clusters []string, namespace string, gvk schema.GroupVersionKind, name string, body []byte) {
+	result := models.IstioConfigMultiClusterResult{
+		Operation: operation,
+		Namespace: namespace,
+		Name:      name,
+		ObjectGVK: gvk,
+		Succeeded: []string{},
+		Failed:    []string{},
+		Results:   make([]models.IstioConfigClusterResult, 0, len(clusters)),
+	}
+
+	successStatus := http.StatusOK
+	if proposer := gitops.FromContext(r.Context()); proposer != nil {
+		successStatus = http.StatusAccepted
+		// The proposals are sequential: the local provider commits to the same repository.
+		for _, cluster := range clusters {
+			proposed, err := proposeAndAudit(r, conf, layer, proposer, operation, cluster, namespace, gvk, name, body)
+			clusterResult := clusterWriteResult(cluster, successStatus, err)
+			if err == nil {
+				clusterResult.GitOpsChange = proposed
+				if result.Name == "" {
+					result.Name = proposed.Name
+				}
+			}
+			result.AddResult(clusterResult)
+		}
+	} else {
+		before := map[string]client.Object{}
+		if operation != models.GitOpsOperationCreate {
+			for _, cluster := range clusters {
+				before[cluster] = auditedIstioObject(r, conf, layer, cluster, namespace, gvk, name)
+			}
+		}
+
+		var writes []business.IstioConfigClusterWrite
+		switch operation {
+		case models.GitOpsOperationCreate:
+			writes = layer.IstioConfig.CreateIstioConfigDetailInClusters(r.Context(), clusters, namespace, gvk, body)
+		case models.GitOpsOperationUpdate:
+			writes = layer.IstioConfig.UpdateIstioConfigDetailInClusters(r.Context(), clusters, namespace, gvk, name, string(body))
+		case models.GitOpsOperationDelete:
+			writes = layer.IstioConfig.DeleteIstioConfigDetailInClusters(r.Context(), clusters, namespace, gvk, name)
+		}
+
+		for _, write := range writes {
+			entry := audit.Entry{
+				Operation: operation,
+				Cluster:   write.Cluster,
+				Namespace: namespace,
+				Name:      name,
+				GVK:       gvk,
+				Before:    before[write.Cluster],
+				After:     write.Details.Object,
+				Err:       write.Err,
+				Message:   "Name: [" + name + "]",
+			}
+			switch operation {
+			case models.GitOpsOperationCreate:
+				entry.Message = "Object: " + string(body)
+				if write.Err == nil && write.Details.Object != nil {
+					entry.Name = write.Details.Object.GetName()
+				}
+			case models.GitOpsOperationUpdate:
+				entry.Patch = string(body)
+				entry.Message = "Name: [" + name + "], Patch: " + string(body)
+			}
+			audit.Log(r, conf, models.AuditSourceAPI, entry)
+
+			clusterResult := clusterWriteResult(write.Cluster, successStatus, write.Err)
+			if write.Err == nil && write.Details.Object != nil {
+				details := write.Details
+				clusterResult.Details = &details
+				clusterResult.Validation = write.Validation
+				if result.Name == "" {
+					result.Name = details.Object.GetName()
+				}
+			}
+			result.AddResult(clusterResult)
+		}
+	}
+
+	status := successStatus
+	if len(result.Failed) > 0 {
+		status = http.StatusMultiStatus
+	}
+	RespondWithJSON(w, status, result)
+}
+
+// clusterWriteResult returns the result of the write operation in a cluster, with the HTTP status of its error.
+func clusterWriteResult(cluster string, successStatus int, err error) models.IstioConfigClusterResult {
+	if err == nil {
+		return models.IstioConfigClusterResult{Cluster: cluster, Status: successStatus}
+	}
+
+	status := http.StatusInternalServerError
+	var statusError api_errors.APIStatus
+	switch {
+	case errors.Is(err, gitops.ErrNoChanges):
+		status = http.StatusConflict
+	case business.IsAccessibleError(err):
+		status = http.StatusForbidden
+	case errors.As(err, &statusError) && statusError.Status().Code != 0:
+		status = int(statusError.Status().Code)
+	}
+	return models.IstioConfigClusterResult{Cluster: cluster, Status: status, Error: err.Error()}
+}
@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tests/data"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestIstioConfigUpdateInClusters(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	east := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		data.CreateEmptyVirtualService("reviews", "bookinfo", []string{"reviews"}),
+	)
+	// The object is missing in the west cluster
+	west := kubetest.NewFakeK8sClient(kubetest.FakeNamespace("bookinfo"))
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": east, "west": west})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}", handlers.WithFakeAuthInfo(conf,
+		handlers.IstioConfigUpdate(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPatch)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	patch := func(query string) (int, []byte) {
+		req, err := http.NewRequest(http.MethodPatch, ts.URL+reviewsVirtualServicePath+query, strings.NewReader(`{"spec":{"hosts":["ratings"]}}`))
+		require.NoError(err)
+		resp, err := ts.Client().Do(req)
+		require.NoError(err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, body
+	}
+
+	status, body := patch("?clusters=east,west,north,east")
+	require.Equalf(http.StatusMultiStatus, status, "response text: %s", string(body))
+	result := models.IstioConfigMultiClusterResult{}
+	require.NoError(json.Unmarshal(body, &result))
+	assert.Equal("reviews", result.Name)
+	assert.Equal([]string{"east"}, result.Succeeded)
+	assert.Equal([]string{"west", "north"}, result.Failed)
+	require.Len(result.Results, 3)
+	assert.Equal(http.StatusOK, result.Results[0].Status)
+	assert.NotNil(result.Results[0].Details)
+	assert.NotNil(result.Results[0].Validation)
+	assert.Equal(http.StatusNotFound, result.Results[1].Status)
+	assert.NotEmpty(result.Results[1].Error)
+	assert.Equal(http.StatusNotFound, result.Results[2].Status)
+
+	vs, err := east.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal([]string{"ratings"}, vs.Spec.Hosts)
+
+	status, body = patch("?clusters=east")
+	require.Equalf(http.StatusOK, status, "response text: %s", string(body))
+
+	status, _ = patch("?clusters=east&clusterName=east")
+	assert.Equal(http.StatusBadRequest, status)
+	status, _ = patch("?clusters=,")
+	assert.Equal(http.StatusBadRequest, status)
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/handlers/queryparams"
+	"github.com/kiali/kiali/models"
 )
 
 type istioConfigListParams struct {
@@ -101,6 +105,170 @@ func parseIstioConfigNamespacesParams(conf *config.Config, query url.Values) (cl
 	return result.Cluster(), result.String("namespaces"), nil
 }
 
+var istioConfigRollbackQueryParams = []queryparams.Param{
+	queryparams.ClusterParam(),
+	queryparams.BoolParam("dryRun", false),
+}
+
+func parseIstioConfigRollbackParams(conf *config.Config, query url.Values) (cluster string, dryRun bool, err error) {
+	result, err := queryparams.ParseWithConfig(query, conf, istioConfigRollbackQueryParams)
+	if err != nil {
+		return "", false, err
+	}
+	return result.Cluster(), result.Bool("dryRun"), nil
+}
+
+var istioConfigWriteQueryParams = []queryparams.Param{
+	queryparams.ClusterParam(),
+	queryparams.StringParam("clusters", ""),
+}
+
+// parseIstioConfigWriteParams parses the target of a write operation: a single cluster, or the comma separated
+// list of clusters of the "clusters" parameter for a multi-cluster operation.
+func parseIstioConfigWriteParams(conf *config.Config, query url.Values) (cluster string, clusters []string, err error) {
+	result, err := queryparams.ParseWithConfig(query, conf, istioConfigWriteQueryParams)
+	if err != nil {
+		return "", nil, err
+	}
+	if query.Get("clusters") == "" {
+		return result.Cluster(), nil, nil
+	}
+	if query.Get("clusterName") != "" {
+		return "", nil, fmt.Errorf("query parameters 'clusterName' and 'clusters' cannot be used together")
+	}
+
+	for _, c := range strings.Split(result.String("clusters"), ",") {
+		c = strings.TrimSpace(c)
+		if c != "" && !slices.Contains(clusters, c) {
+			clusters = append(clusters, c)
+		}
+	}
+	if len(clusters) == 0 {
+		return "", nil, fmt.Errorf("query parameter 'clusters' must list at least one cluster")
+	}
+	return "", clusters, nil
+}
+
+var istioConfigBulkQueryParams = []queryparams.Param{
+	queryparams.BoolParam("dryRun", false),
+}
+
+func parseIstioConfigBulkParams(conf *config.Config, query url.Values) (dryRun bool, err error) {
+	result, err := queryparams.ParseWithConfig(query, conf, istioConfigBulkQueryParams)
+	if err != nil {
+		return false, err
+	}
+	return result.Bool("dryRun"), nil
+}
+
+type istioConfigExportParams struct {
+	Archive          bool
+	ClusterName      string
+	LabelSelector    string
+	Objects          string
+	Options          models.IstioConfigExportOptions
+	WorkloadSelector string
+}
+
+var istioConfigExportQueryParams = []queryparams.Param{
+	queryparams.BoolParam("archive", false),
+	queryparams.StringParam("chartName", ""),
+	queryparams.ClusterParam(),
+	queryparams.StringParam("clusters", ""),
+	{Name: "format", Kind: queryparams.KindEnum, EnumValues: []string{models.IstioConfigExportHelm, models.IstioConfigExportKustomize}, Required: true},
+	queryparams.StringParam("labelSelector", ""),
+	queryparams.StringParam("objects", ""),
+	queryparams.StringParam("workloadSelector", ""),
+}
+
+// parseIstioConfigExportParams parses the selection of the exported objects and the options of the export.
+// The "clusters" parameter lists the clusters the configuration is exported to, comma separated.
+func parseIstioConfigExportParams(conf *config.Config, query url.Values) (istioConfigExportParams, error) {
+	result, err := queryparams.ParseWithConfig(query, conf, istioConfigExportQueryParams)
+	if err != nil {
+		return istioConfigExportParams{}, err
+	}
+
+	params := istioConfigExportParams{
+		Archive:       result.Bool("archive"),
+		ClusterName:   result.Cluster(),
+		LabelSelector: result.String("labelSelector"),
+		Objects:       result.String("objects"),
+		Options: models.IstioConfigExportOptions{
+			Format:    result.String("format"),
+			ChartName: result.String("chartName"),
+		},
+		WorkloadSelector: result.String("workloadSelector"),
+	}
+	for _, c := range strings.Split(result.String("clusters"), ",") {
+		c = strings.TrimSpace(c)
+		if c != "" && !slices.Contains(params.Options.Clusters, c) {
+			params.Options.Clusters = append(params.Options.Clusters, c)
+		}
+	}
+	return params, nil
+}
+
+var trafficTemplateQueryParams = []queryparams.Param{
+	queryparams.ClusterParam(),
+	queryparams.BoolParam("apply", false),
+}
+
+func parseTrafficTemplateParams(conf *config.Config, query url.Values) (cluster string, apply bool, err error) {
+	result, err := queryparams.ParseWithConfig(query, conf, trafficTemplateQueryParams)
+	if err != nil {
+		return "", false, err
+	}
+	return result.Cluster(), result.Bool("apply"), nil
+}
+
+var gatewayAPIConversionQueryParams = []queryparams.Param{
+	queryparams.ClusterParam(),
+	queryparams.BoolParam("apply", false),
+}
+
+func parseGatewayAPIConversionParams(conf *config.Config, query url.Values) (cluster string, apply bool, err error) {
+	result, err := queryparams.ParseWithConfig(query, conf, gatewayAPIConversionQueryParams)
+	if err != nil {
+		return "", false, err
+	}
+	return result.Cluster(), result.Bool("apply"), nil
+}
+
+type sidecarRecommendationParams struct {
+	ClusterName string
+	Duration    string
+	Scope       string
+	Workload    string
+}
+
+var sidecarRecommendationQueryParams = []queryparams.Param{
+	queryparams.ClusterParam(),
+	queryparams.PromDurationParam("duration", "1h"),
+	queryparams.EnumParam("scope", models.SidecarRecommendationScopeNamespace, models.SidecarRecommendationScopeWorkload),
+	queryparams.StringParam("workload", ""),
+}
+
+func parseSidecarRecommendationParams(conf *config.Config, query url.Values) (sidecarRecommendationParams, error) {
+	result, err := queryparams.ParseWithConfig(query, conf, sidecarRecommendationQueryParams)
+	if err != nil {
+		return sidecarRecommendationParams{}, err
+	}
+	scope := result.String("scope")
+	if scope == "" {
+		scope = models.SidecarRecommendationScopeNamespace
+		if result.String("workload") != "" {
+			scope = models.SidecarRecommendationScopeWorkload
+		}
+	}
+	return sidecarRecommendationParams{
+		ClusterName: result.Cluster(),
+		Duration:    result.Duration("duration"),
+		Scope:       scope,
+		Workload:    result.String("workload"),
+	}, nil
+}
+
 func respondQueryParamError(w http.ResponseWriter, err error) bool {
 	if err == nil {
 		return false
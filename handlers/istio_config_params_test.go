@@ -30,3 +30,26 @@ func TestParseIstioConfigListParamsInvalidValidate(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "validate")
 }
+
+func TestParseIstioConfigExportParams(t *testing.T) {
+	conf := config.NewConfig()
+	query := url.Values{}
+	query.Set("format", "helm")
+	query.Set("clusters", "east, west,east")
+
+	params, err := parseIstioConfigExportParams(conf, query)
+	require.NoError(t, err)
+	assert.Equal(t, "helm", params.Options.Format)
+	assert.Equal(t, []string{"east", "west"}, params.Options.Clusters)
+	assert.False(t, params.Archive)
+
+	query.Del("format")
+	_, err = parseIstioConfigExportParams(conf, query)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "format")
+
+	query.Set("format", "tar")
+	_, err = parseIstioConfigExportParams(conf, query)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "format")
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/handlers/authentication"
+)
+
+// checkKialiCapability responds with a 403 and returns false when the Kiali roles do not grant the capability to the
+// user of the request in the namespace of all the clusters. The home cluster is checked when no cluster is given.
+// An empty namespace checks the capability in all the namespaces, for the actions not scoped to a namespace.
+func checkKialiCapability(w http.ResponseWriter, r *http.Request, conf *config.Config, capability, namespace string, clusters ...string) bool {
+	if len(clusters) == 0 {
+		clusters = []string{conf.KubernetesConfig.ClusterName}
+	}
+	identity := authentication.GetUserIdentityContext(r.Context())
+	for _, cluster := range clusters {
+		if cluster == "" {
+			cluster = conf.KubernetesConfig.ClusterName
+		}
+		if !authentication.HasCapability(conf, identity, capability, cluster, namespace) {
+			scope := "cluster [" + cluster + "]"
+			if namespace != "" {
+				scope = fmt.Sprintf("namespace [%s] of cluster [%s]", namespace, cluster)
+			}
+			RespondWithError(w, http.StatusForbidden, fmt.Sprintf("The Kiali roles do not grant the [%s] capability in %s", capability, scope))
+			return false
+		}
+	}
+	return true
+}
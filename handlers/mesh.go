@@ -10,6 +10,7 @@ import (
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers/queryparams"
 	"github.com/kiali/kiali/istio"
 	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/mesh"
@@ -66,6 +67,47 @@ func ControlPlanes(
 	}
 }
 
+// MeshHealth is the API handler to get the health of the mesh infrastructure components: istiod, the ingress and
+// egress gateways, the waypoints and ztunnel.
+func MeshHealth(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !conf.HealthConfig.Mesh.Enabled {
+			RespondWithError(w, http.StatusNotFound, "Mesh health is disabled")
+			return
+		}
+		parsed, err := queryparams.ParseWithConfig(r.URL.Query(), conf, meshHealthQueryParams)
+		if err != nil {
+			RespondWithQueryParamError(w, err.Error())
+			return
+		}
+
+		businessLayer, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Initialization error: "+err.Error())
+			return
+		}
+		criteria := business.MeshHealthCriteria{
+			QueryTime:    parsed.Time("queryTime"),
+			RateInterval: parsed.Duration("rateInterval"),
+		}
+		health, err := businessLayer.Health.GetMeshHealth(r.Context(), criteria)
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, health)
+	}
+}
+
 // MeshGraph is a REST http.HandlerFunc handling graph generation for the mesh
 func MeshGraph(
 	conf *config.Config,
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	prom_v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/stretchr/testify/mock"
@@ -29,6 +30,7 @@ import (
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/prometheustest"
 	"github.com/kiali/kiali/tracing"
+	"github.com/kiali/kiali/util"
 	"github.com/kiali/kiali/util/certtest"
 )
 
@@ -160,6 +162,58 @@ func TestControlPlanes(t *testing.T) {
 	require.Len(controlPlaneResponse, 1)
 }
 
+func TestMeshHealth(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Now()}
+
+	conf := config.NewConfig()
+	clients := map[string]kubernetes.UserClientInterface{conf.KubernetesConfig.ClusterName: kubetest.NewFakeK8sClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "istio-system"}},
+	)}
+	cf := kubetest.NewFakeClientFactory(conf, clients)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := &istiotest.FakeDiscovery{
+		MeshReturn: models.Mesh{
+			ControlPlanes: []models.ControlPlane{{
+				Cluster: &models.KubeCluster{Name: conf.KubernetesConfig.ClusterName},
+				Config: models.ControlPlaneConfiguration{
+					Certificates: []models.Certificate{{NotAfter: util.Clock.Now().Add(-time.Hour)}},
+				},
+				IstiodName:      "istiod",
+				IstiodNamespace: "istio-system",
+			}},
+		},
+	}
+	cpm := &business.FakeControlPlaneMonitor{}
+	prom := new(prometheustest.PromClientMock)
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, clients[conf.KubernetesConfig.ClusterName])
+	require.NoError(err)
+
+	authInfo := map[string]*api.AuthInfo{conf.KubernetesConfig.ClusterName: {Token: "test"}}
+	handler := handlers.WithAuthInfo(authInfo, handlers.MeshHealth(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest("GET", "/api/mesh/health?rateInterval=10m", nil))
+	require.Equal(http.StatusOK, w.Code)
+
+	var health []models.MeshComponentHealth
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &health))
+	require.Len(health, 1)
+	require.Equal(models.MeshComponentIstiod, health[0].Type)
+	require.Equal(models.HealthStatusFailure, health[0].Status.Status)
+	require.Equal(models.HealthSignalControlPlane, health[0].Status.Signal)
+
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest("GET", "/api/mesh/health?rateInterval=soon", nil))
+	require.Equal(http.StatusBadRequest, w.Code)
+
+	conf.HealthConfig.Mesh.Enabled = false
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest("GET", "/api/mesh/health", nil))
+	require.Equal(http.StatusNotFound, w.Code)
+}
+
 func TestControlPlanesUnauthorized(t *testing.T) {
 	require := require.New(t)
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -155,11 +156,20 @@ func NamespaceUpdate(conf *config.Config, kialiCache cache.KialiCache, clientFac
 
 		namespaceService := business.NewNamespaceService(kialiCache, conf, discovery, clientFactory.GetSAClients(), userClients)
 		ns, err := namespaceService.UpdateNamespace(r.Context(), namespace, jsonPatch, cluster)
+		audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+			Operation: "UPDATE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			Name:      namespace,
+			GVK:       kubernetes.Namespaces,
+			Patch:     jsonPatch,
+			Err:       err,
+			Message:   "Namespace Update. Patch: " + jsonPatch,
+		})
 		if err != nil {
 			handleErrorResponse(w, err)
 			return
 		}
-		audit(r, "UPDATE", namespace, "n/a", "Namespace Update. Patch: "+jsonPatch)
 		RespondWithJSON(w, http.StatusOK, ns)
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -14,6 +15,7 @@ import (
 	"github.com/kiali/kiali/handlers/queryparams"
 	"github.com/kiali/kiali/istio"
 	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/tracing"
 )
@@ -61,12 +63,24 @@ func LoggingUpdate(
 		}
 
 		cluster := queryparams.ClusterName(conf, query)
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityChangeProxyLogLevel, namespace, cluster) {
+			return
+		}
 
-		if err := businessLayer.ProxyLogging.SetLogLevel(cluster, namespace, pod, level); err != nil {
+		err = businessLayer.ProxyLogging.SetLogLevel(cluster, namespace, pod, level)
+		audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+			Operation: "UPDATE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			Name:      pod,
+			GVK:       kubernetes.Pods,
+			Err:       err,
+			Message:   "Envoy Log Level. Cluster: [" + cluster + "], Pod: [" + pod + "], Log Level: [" + level + "]",
+		})
+		if err != nil {
 			handleErrorResponse(w, err)
 			return
 		}
-		audit(r, "UPDATE", namespace, "n/a", "Envoy Log Level. Cluster: ["+cluster+"], Pod: ["+pod+"], Log Level: ["+level+"]")
 		RespondWithCode(w, 200)
 	}
 }
@@ -26,7 +26,10 @@ import (
 )
 
 func setupTestLoggingServer(t *testing.T, namespace, pod string) *httptest.Server {
-	conf := config.NewConfig()
+	return setupTestLoggingServerWithConfig(t, config.NewConfig(), namespace, pod)
+}
+
+func setupTestLoggingServerWithConfig(t *testing.T, conf *config.Config, namespace, pod string) *httptest.Server {
 	k8s := kubetest.NewFakeK8sClient(
 		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
 		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: pod, Namespace: namespace}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
@@ -69,6 +72,29 @@ func TestProxyLoggingSucceeds(t *testing.T) {
 	assert.Equalf(200, resp.StatusCode, "response text: %s", string(body))
 }
 
+func TestProxyLoggingRequiresKialiCapability(t *testing.T) {
+	const (
+		namespace = "bookinfo"
+		pod       = "details-v1-79f774bdb9-hgcch"
+	)
+	conf := config.NewConfig()
+	conf.Auth.KialiRBAC = config.KialiRBACConfig{
+		DefaultRoles: []string{"viewer"},
+		Enabled:      true,
+		Roles:        []config.KialiRole{{Name: "viewer", Capabilities: []string{config.KialiCapabilityViewGraph}}},
+	}
+	ts := setupTestLoggingServerWithConfig(t, conf, namespace, pod)
+
+	url := ts.URL + fmt.Sprintf("/api/namespaces/%s/pods/%s/logging?level=info", namespace, pod)
+	resp, err := ts.Client().Post(url, "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equalf(t, http.StatusForbidden, resp.StatusCode, "response text: %s", string(body))
+	assert.Contains(t, string(body), config.KialiCapabilityChangeProxyLogLevel)
+}
+
 func TestMissingQueryParamFails(t *testing.T) {
 	const (
 		namespace = "bookinfo"
@@ -26,6 +26,10 @@ func ConfigDump(conf *config.Config, kialiCache cache.KialiCache, clientFactory
 		cluster := queryparams.ClusterName(conf, query)
 		namespace := params["namespace"]
 		pod := params["pod"]
+		// The full config dump includes the secrets of the proxy
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityViewSecrets, namespace, cluster) {
+			return
+		}
 
 		userClients, err := getUserClients(r, clientFactory)
 		if err != nil {
@@ -65,6 +69,9 @@ func ConfigDumpResourceEntries(conf *config.Config, kialiCache cache.KialiCache,
 		namespace := params["namespace"]
 		pod := params["pod"]
 		resource := params["resource"]
+		if resource == "secrets" && !checkKialiCapability(w, r, conf, config.KialiCapabilityViewSecrets, namespace, cluster) {
+			return
+		}
 
 		userClients, err := getUserClients(r, clientFactory)
 		if err != nil {
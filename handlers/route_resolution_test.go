@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tracing"
+)
+
+func setupRouteResolveServer(t *testing.T) *httptest.Server {
+	conf := config.NewConfig()
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	k8s := kubetest.NewFakeK8sClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "Namespace"}},
+		&business.FakeDepSyncedWithRS(conf)[0],
+	)
+	prom := new(prometheustest.PromClientMock)
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, k8s)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(t, err)
+
+	handler := handlers.WithFakeAuthInfo(conf, handlers.RouteResolve(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/workloads/{workload}/routing/resolve", handler)
+
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func TestRouteResolve(t *testing.T) {
+	ts := setupRouteResolveServer(t)
+
+	url := ts.URL + "/api/namespaces/Namespace/workloads/details-v1/routing/resolve"
+	resp, err := ts.Client().Post(url, "application/json", strings.NewReader(`{"host":"reviews","port":9080,"path":"/reviews/1","method":"GET"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	require.Equalf(t, http.StatusOK, resp.StatusCode, "response text: %s", string(body))
+
+	result := models.RouteResolutionResult{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "reviews.Namespace.svc.cluster.local", result.Host)
+	assert.Equal(t, models.RouteSourceDefault, result.RouteSource)
+}
+
+func TestRouteResolveBadRequest(t *testing.T) {
+	ts := setupRouteResolveServer(t)
+
+	url := ts.URL + "/api/namespaces/Namespace/workloads/details-v1/routing/resolve"
+	resp, err := ts.Client().Post(url, "application/json", strings.NewReader(`{"path":"/"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equalf(t, http.StatusBadRequest, resp.StatusCode, "response text: %s", string(body))
+}
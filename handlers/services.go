@@ -8,6 +8,7 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -291,6 +292,16 @@ func ServiceUpdate(
 		}
 
 		serviceDetails, err := business.Svc.UpdateService(r.Context(), cluster, namespace, service, rateInterval, queryTime, jsonPatch, patchType)
+		audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+			Operation: "UPDATE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			Name:      service,
+			GVK:       kubernetes.Services,
+			Patch:     jsonPatch,
+			Err:       err,
+			Message:   "Service Update. Name: [" + service + "], Patch: " + jsonPatch,
+		})
 
 		if includeValidations && err == nil {
 			wg.Wait()
@@ -306,7 +317,6 @@ func ServiceUpdate(
 			return
 		}
 
-		audit(r, "UPDATE", namespace, "n/a", "Service Update. Name: ["+service+"], Patch: "+jsonPatch)
 		RespondWithJSON(w, http.StatusOK, serviceDetails)
 	}
 }
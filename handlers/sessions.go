@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	aiTypes "github.com/kiali/kiali/ai/types"
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/handlers/authentication"
+	"github.com/kiali/kiali/models"
+)
+
+// sessionGVK identifies the login sessions in the audit records. They are not Kubernetes objects.
+var sessionGVK = schema.GroupVersionKind{Group: "kiali.io", Kind: "Session"}
+
+// ActiveSessionResponse is an active login session with the resources Kiali keeps for it in this replica.
+type ActiveSessionResponse struct {
+	authentication.ActiveSession
+	// AIUsage is the usage of the AI chat by the session.
+	AIUsage []aiTypes.UsageMetric `json:"aiUsage,omitempty"`
+	// Graph is the cached graph of the session, if any.
+	Graph *graph.SessionGraphStats `json:"graph,omitempty"`
+	// GraphRefreshing is true when the cached graph is refreshed in the background.
+	GraphRefreshing bool `json:"graphRefreshing"`
+}
+
+// checkSessionManagement responds with a 404 and returns false when the session management API is disabled,
+// and with a 403 when the Kiali roles do not grant the manage-sessions capability to the user.
+func checkSessionManagement(w http.ResponseWriter, r *http.Request, conf *config.Config, sessionRegistry *authentication.SessionRegistry) bool {
+	if !sessionRegistry.Enabled() {
+		RespondWithError(w, http.StatusNotFound, "Session management is disabled")
+		return false
+	}
+	return checkKialiCapability(w, r, conf, config.KialiCapabilityManageSessions, "")
+}
+
+// ListSessions is the API handler to list the active login sessions.
+func ListSessions(
+	conf *config.Config,
+	sessionRegistry *authentication.SessionRegistry,
+	graphCache graph.GraphCache,
+	refreshJobManager *graph.RefreshJobManager,
+	aiStore aiTypes.AIStore,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkSessionManagement(w, r, conf, sessionRegistry) {
+			return
+		}
+		sessions, err := sessionRegistry.List(r.Context())
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Sessions could not be listed: "+err.Error())
+			return
+		}
+
+		response := make([]ActiveSessionResponse, 0, len(sessions))
+		for _, session := range sessions {
+			item := ActiveSessionResponse{ActiveSession: session}
+			if graphCache != nil {
+				if stats, found := graphCache.GetSessionGraphStats(session.ID); found {
+					item.Graph = stats
+				}
+			}
+			if refreshJobManager != nil {
+				item.GraphRefreshing = refreshJobManager.HasJob(session.ID)
+			}
+			if aiStore != nil && aiStore.Enabled() {
+				item.AIUsage = aiStore.GetUsageMetrics(session.ID)
+			}
+			response = append(response, item)
+		}
+		RespondWithJSON(w, http.StatusOK, response)
+	}
+}
+
+// RevokeSession is the API handler to revoke an active login session.
+func RevokeSession(conf *config.Config, sessionRegistry *authentication.SessionRegistry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkSessionManagement(w, r, conf, sessionRegistry) {
+			return
+		}
+		id := mux.Vars(r)["id"]
+		session, err := sessionRegistry.Revoke(r.Context(), id)
+		if errors.Is(err, authentication.ErrActiveSessionNotFound) {
+			RespondWithError(w, http.StatusNotFound, fmt.Sprintf("Session [%s] not found", id))
+			return
+		}
+		entry := audit.Entry{
+			Operation: "DELETE",
+			Cluster:   conf.KubernetesConfig.ClusterName,
+			Name:      id,
+			GVK:       sessionGVK,
+			Err:       err,
+			Message:   fmt.Sprintf("Session [%s] revocation", id),
+		}
+		if session != nil {
+			entry.Message = fmt.Sprintf("Session [%s] of user [%s] revoked", session.ID, session.Username)
+		}
+		audit.Log(r, conf, models.AuditSourceAPI, entry)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Session could not be revoked: "+err.Error())
+			return
+		}
+		RespondWithCode(w, http.StatusNoContent)
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/kiali/kiali/ai"
+	aiTypes "github.com/kiali/kiali/ai/types"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/handlers/authentication"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/util"
+)
+
+func newSessionsTestConfig() *config.Config {
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.Auth.Strategy = config.AuthStrategyToken
+	conf.Auth.SessionManagement.Enabled = true
+	conf.Auth.KialiRBAC = config.KialiRBACConfig{
+		Enabled:      true,
+		RoleBindings: []config.KialiRoleBinding{{Role: "admin", Users: []string{"admin"}}},
+		Roles:        []config.KialiRole{{Name: "admin", Capabilities: []string{config.KialiCapabilityManageSessions}}},
+	}
+	return conf
+}
+
+func sessionsRequest(method, target, username string) *http.Request {
+	r := httptest.NewRequest(method, target, nil)
+	return r.WithContext(authentication.SetUserIdentityContext(r.Context(), &authentication.UserIdentity{Username: username}))
+}
+
+func TestSessionManagementAPI(t *testing.T) {
+	require := require.New(t)
+	util.Clock = util.ClockMock{Time: time.Now()}
+	conf := newSessionsTestConfig()
+	registry := authentication.NewSessionRegistry(conf, sessionstore.NewMemoryStore())
+
+	graphCache := graph.NewGraphCache(context.Background(), &graph.GraphCacheConfig{Enabled: true, MaxCacheMemoryMB: 1024})
+	require.NoError(graphCache.SetSessionGraph("s1", &graph.CachedGraph{RefreshInterval: time.Minute, TrafficMap: graph.NewTrafficMap()}))
+	aiStore := ai.NewAIStore(context.Background(), &ai.AiStoreConfig{Enabled: true, MaxCacheMemoryMB: 1})
+	require.NoError(aiStore.SetConversation("s1", "conv-1", &aiTypes.Conversation{}))
+	require.NoError(aiStore.RecordUsage("s1", "openai", "gpt", aiTypes.TokenUsage{TotalTokens: 3}))
+	registry.OnEnd(func(sessionID string) {
+		graphCache.Evict(sessionID)
+		require.NoError(aiStore.DeleteSessionConversations(sessionID))
+	})
+
+	require.NoError(registry.Track(httptest.NewRequest(http.MethodGet, "/api", nil), authentication.UserSessions{
+		"east": {AuthInfo: &api.AuthInfo{Token: "t"}, ExpiresOn: util.Clock.Now().Add(time.Hour), SessionID: "s1", Username: "alice"},
+	}))
+
+	// Only the users granted the manage-sessions capability can manage sessions
+	w := httptest.NewRecorder()
+	ListSessions(conf, registry, graphCache, nil, aiStore)(w, sessionsRequest(http.MethodGet, "/api/auth/sessions", "alice"))
+	require.Equal(http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	ListSessions(conf, registry, graphCache, nil, aiStore)(w, sessionsRequest(http.MethodGet, "/api/auth/sessions", "admin"))
+	require.Equal(http.StatusOK, w.Code, w.Body.String())
+	var sessions []ActiveSessionResponse
+	require.NoError(json.NewDecoder(w.Body).Decode(&sessions))
+	require.Len(sessions, 1)
+	assert.Equal(t, "alice", sessions[0].Username)
+	require.NotNil(sessions[0].Graph)
+	assert.Equal(t, time.Minute, sessions[0].Graph.RefreshInterval)
+	require.Len(sessions[0].AIUsage, 1)
+	assert.Equal(t, int64(3), sessions[0].AIUsage[0].TotalTokens)
+
+	// Revocation tears down what Kiali keeps for the session
+	r := mux.SetURLVars(sessionsRequest(http.MethodDelete, "/api/auth/sessions/s1", "admin"), map[string]string{"id": "s1"})
+	w = httptest.NewRecorder()
+	RevokeSession(conf, registry)(w, r)
+	require.Equal(http.StatusNoContent, w.Code, w.Body.String())
+	_, found := graphCache.GetSessionGraphStats("s1")
+	assert.False(t, found)
+	_, found = aiStore.GetConversation("s1", "conv-1")
+	assert.False(t, found)
+
+	w = httptest.NewRecorder()
+	RevokeSession(conf, registry)(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSessionManagementDisabled(t *testing.T) {
+	conf := newSessionsTestConfig()
+	conf.Auth.SessionManagement.Enabled = false
+	registry := authentication.NewSessionRegistry(conf, sessionstore.NewMemoryStore())
+
+	w := httptest.NewRecorder()
+	ListSessions(conf, registry, nil, nil, nil)(w, sessionsRequest(http.MethodGet, "/api/auth/sessions", "admin"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
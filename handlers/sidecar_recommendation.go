@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/graph/api"
+	"github.com/kiali/kiali/graph/telemetry/istio/appender"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+// SidecarRecommendation is the API handler recommending Sidecars that restrict the egress of the workloads of a
+// namespace to the destinations they called in the traffic graph over the requested window.
+func SidecarRecommendation(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer handlePanic(r.Context(), w)
+		namespace := mux.Vars(r)["namespace"]
+
+		params, err := parseSidecarRecommendationParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		// The workload graph of the namespace, with service nodes, gives the services and ServiceEntries each workload called
+		graphReq, err := http.NewRequestWithContext(r.Context(), http.MethodGet, r.URL.Path, nil)
+		graph.CheckError(err)
+		query := graphReq.URL.Query()
+		query.Set("appenders", appender.ServiceEntryAppenderName)
+		query.Set("duration", params.Duration)
+		query.Set("graphType", graph.GraphTypeWorkload)
+		query.Set("injectServiceNodes", "true")
+		query.Set("namespaces", namespace)
+		graphReq.URL.RawQuery = query.Encode()
+		code, payload, trafficMap := api.GraphNamespaces(r.Context(), business, prom, graph.NewOptions(graphReq, business, conf))
+		if code != http.StatusOK {
+			respond(w, code, payload)
+			return
+		}
+
+		result, err := business.IstioConfig.RecommendSidecars(r.Context(), params.ClusterName, namespace, sidecarRecommendationCriteria(params, trafficMap, namespace))
+		if api_errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, "Sidecar recommendation request is not valid: "+err.Error())
+			return
+		}
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		RespondWithJSON(w, http.StatusOK, result)
+	}
+}
+
+// sidecarRecommendationCriteria collects the destinations called by the workloads of the namespace from the outbound
+// edges of the traffic graph. Failed traffic and destinations that telemetry could not identify are ignored.
+func sidecarRecommendationCriteria(params sidecarRecommendationParams, trafficMap graph.TrafficMap, namespace string) business.SidecarRecommendationCriteria {
+	criteria := business.SidecarRecommendationCriteria{
+		Duration: params.Duration,
+		Scope:    params.Scope,
+		Traffic:  map[string][]models.SidecarDestination{},
+		Workload: params.Workload,
+	}
+	for _, n := range trafficMap {
+		if n.NodeType != graph.NodeTypeWorkload || n.Cluster != params.ClusterName || n.Namespace != namespace {
+			continue
+		}
+		for _, e := range n.Edges {
+			dest := e.Dest
+			if dest.NodeType != graph.NodeTypeService {
+				continue
+			}
+			if se, ok := dest.Metadata[graph.IsServiceEntry].(*graph.SEInfo); ok {
+				for _, host := range se.Hosts {
+					criteria.Traffic[n.Workload] = append(criteria.Traffic[n.Workload], models.SidecarDestination{Namespace: se.Namespace, Host: host, ServiceEntry: dest.Service})
+				}
+				continue
+			}
+			switch {
+			case dest.Service == graph.PassthroughCluster:
+				criteria.Traffic[n.Workload] = append(criteria.Traffic[n.Workload], models.SidecarDestination{})
+			case dest.Service == graph.BlackHoleCluster || !graph.IsOK(dest.Service) || !graph.IsOK(dest.Namespace):
+			default:
+				criteria.Traffic[n.Workload] = append(criteria.Traffic[n.Workload], models.SidecarDestination{Namespace: dest.Namespace, Service: dest.Service})
+			}
+		}
+	}
+	return criteria
+}
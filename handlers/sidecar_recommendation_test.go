@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/graph"
+	"github.com/kiali/kiali/models"
+)
+
+func TestSidecarRecommendationCriteria(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	trafficMap := graph.NewTrafficMap()
+	node := func(cluster, namespace, service, workload string) *graph.Node {
+		n, err := graph.NewNode(cluster, namespace, service, namespace, workload, graph.Unknown, graph.Unknown, graph.GraphTypeWorkload)
+		require.NoError(err)
+		trafficMap[n.ID] = n
+		return n
+	}
+	productpage := node("east", "bookinfo", "", "productpage-v1")
+	productpage.AddEdge(node("east", "bookinfo", "reviews", ""))
+	productpage.AddEdge(node("east", "bookinfo", graph.PassthroughCluster, ""))
+	productpage.AddEdge(node("east", "bookinfo", graph.BlackHoleCluster, ""))
+	external := node("east", "bookinfo", "api", "")
+	external.Metadata[graph.IsServiceEntry] = &graph.SEInfo{Hosts: []string{"api.example.com"}, Location: "MESH_EXTERNAL", Namespace: "external"}
+	productpage.AddEdge(external)
+	// Traffic of other namespaces and clusters is not considered
+	node("east", "other", "", "client").AddEdge(node("east", "bookinfo", "ratings", ""))
+	node("west", "bookinfo", "", "productpage-v1").AddEdge(node("west", "bookinfo", "ratings", ""))
+
+	params := sidecarRecommendationParams{ClusterName: "east", Duration: "1h", Scope: models.SidecarRecommendationScopeNamespace}
+	criteria := sidecarRecommendationCriteria(params, trafficMap, "bookinfo")
+	assert.Equal("1h", criteria.Duration)
+	require.Len(criteria.Traffic, 1)
+	assert.ElementsMatch([]models.SidecarDestination{
+		{Namespace: "bookinfo", Service: "reviews"},
+		{},
+		{Namespace: "external", Host: "api.example.com", ServiceEntry: "api"},
+	}, criteria.Traffic["productpage-v1"])
+}
+
+func TestParseSidecarRecommendationParams(t *testing.T) {
+	conf := config.NewConfig()
+
+	params, err := parseSidecarRecommendationParams(conf, url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, "1h", params.Duration)
+	assert.Equal(t, models.SidecarRecommendationScopeNamespace, params.Scope)
+
+	params, err = parseSidecarRecommendationParams(conf, url.Values{"workload": {"reviews-v1"}, "duration": {"30m"}})
+	require.NoError(t, err)
+	assert.Equal(t, models.SidecarRecommendationScopeWorkload, params.Scope)
+	assert.Equal(t, "30m", params.Duration)
+
+	_, err = parseSidecarRecommendationParams(conf, url.Values{"scope": {"mesh"}})
+	require.Error(t, err)
+	_, err = parseSidecarRecommendationParams(conf, url.Values{"duration": {"forever"}})
+	require.Error(t, err)
+}
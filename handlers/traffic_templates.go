@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kiali/kiali/audit"
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/tracing"
+)
+
+// TrafficTemplate is the API handler to generate the Istio config of a traffic template for a service.
+// The generated objects are validated and returned for review, or applied when the apply parameter is set.
+func TrafficTemplate(
+	conf *config.Config,
+	kialiCache cache.KialiCache,
+	clientFactory kubernetes.ClientFactory,
+	prom prometheus.ClientInterface,
+	traceClientLoader func() tracing.ClientInterface,
+	discovery istio.MeshDiscovery,
+	cpm business.ControlPlaneMonitor,
+	grafana *grafana.Service,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := mux.Vars(r)
+		namespace := params["namespace"]
+		service := params["service"]
+		template := params["template"]
+
+		cluster, apply, err := parseTrafficTemplateParams(conf, r.URL.Query())
+		if respondQueryParamError(w, err) {
+			return
+		}
+
+		body, err := boundedReadAll(r)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Traffic template request could not be read: "+err.Error())
+			return
+		}
+		var req models.TrafficTemplateRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Traffic template request is not valid: "+err.Error())
+			return
+		}
+
+		business, err := getLayer(r, conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		result, err := business.IstioConfig.GenerateTrafficTemplate(r.Context(), cluster, namespace, service, template, req)
+		if api_errors.IsBadRequest(err) {
+			RespondWithError(w, http.StatusBadRequest, "Traffic template request is not valid: "+err.Error())
+			return
+		}
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+		if !apply {
+			RespondWithJSON(w, http.StatusOK, result)
+			return
+		}
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityEditIstioConfig, namespace, cluster) {
+			return
+		}
+		if !result.Valid {
+			RespondWithJSON(w, http.StatusUnprocessableEntity, result)
+			return
+		}
+
+		if proposer := gitops.FromContext(r.Context()); proposer != nil {
+			for _, obj := range result.Objects {
+				body := []byte(obj.Manifest)
+				if obj.Operation == models.GitOpsOperationUpdate {
+					body = []byte(obj.Patch)
+				}
+				proposed, err := proposeAndAudit(r, conf, business, proposer, obj.Operation, cluster, namespace, obj.ObjectGVK, obj.Name, body)
+				if errors.Is(err, gitops.ErrNoChanges) {
+					continue
+				}
+				if err != nil {
+					handleErrorResponse(w, err)
+					return
+				}
+				result.GitOpsChanges = append(result.GitOpsChanges, *proposed)
+			}
+			RespondWithJSON(w, http.StatusAccepted, result)
+			return
+		}
+
+		before := make([]client.Object, len(result.Objects))
+		for i, obj := range result.Objects {
+			if obj.Operation == models.GitOpsOperationUpdate {
+				before[i] = auditedIstioObject(r, conf, business, cluster, namespace, obj.ObjectGVK, obj.Name)
+			}
+		}
+		written, err := business.IstioConfig.ApplyTrafficTemplate(r.Context(), result)
+		for i, obj := range result.Objects {
+			entry := audit.Entry{
+				Operation: obj.Operation,
+				Cluster:   cluster,
+				Namespace: namespace,
+				Name:      obj.Name,
+				GVK:       obj.ObjectGVK,
+				Before:    before[i],
+				Patch:     obj.Patch,
+				Message:   "Traffic template: [" + template + "], Service: [" + service + "]",
+			}
+			if i < len(written) {
+				entry.After = written[i].Object
+			} else {
+				// The objects are applied in order: this one failed and the next ones were not applied.
+				entry.Err = err
+			}
+			audit.Log(r, conf, models.AuditSourceAPI, entry)
+			if entry.Err != nil {
+				break
+			}
+		}
+		if err != nil {
+			handleErrorResponse(w, err)
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, result)
+	}
+}
@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/cache"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/grafana"
+	"github.com/kiali/kiali/handlers"
+	"github.com/kiali/kiali/istio"
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/kubetest"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus/prometheustest"
+	"github.com/kiali/kiali/tracing"
+)
+
+func TestTrafficTemplate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	conf := config.NewConfig()
+	conf.KubernetesConfig.ClusterName = "east"
+	conf.ExternalServices.CustomDashboards.Enabled = false
+	config.Set(conf)
+	reviews := kubetest.FakeService("bookinfo", "reviews")
+	k8s := kubetest.NewFakeK8sClient(
+		kubetest.FakeNamespace("bookinfo"),
+		&reviews,
+		&core_v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo", Labels: map[string]string{"app": "reviews", "version": "v1"}}},
+	)
+	cf := kubetest.NewFakeClientFactory(conf, map[string]kubernetes.UserClientInterface{"east": k8s})
+	prom := new(prometheustest.PromClientMock)
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	discovery := istio.NewDiscovery(kubernetes.ConvertFromUserClients(cf.Clients), cache, conf)
+	cpm := &business.FakeControlPlaneMonitor{}
+	traceLoader := func() tracing.ClientInterface { return nil }
+	grafanaSvc, err := grafana.NewService(conf, cf.GetSAHomeClusterClient())
+	require.NoError(err)
+
+	mr := mux.NewRouter()
+	mr.HandleFunc("/api/namespaces/{namespace}/services/{service}/traffic_templates/{template}", handlers.WithFakeAuthInfo(conf,
+		handlers.TrafficTemplate(conf, cache, cf, prom, traceLoader, discovery, cpm, grafanaSvc))).Methods(http.MethodPost)
+	ts := httptest.NewServer(mr)
+	t.Cleanup(ts.Close)
+
+	post := func(template, query, body string) (int, models.TrafficTemplateResult) {
+		resp, err := ts.Client().Post(ts.URL+"/api/namespaces/bookinfo/services/reviews/traffic_templates/"+template+query, "application/json", strings.NewReader(body))
+		require.NoError(err)
+		defer resp.Body.Close()
+		raw, _ := io.ReadAll(resp.Body)
+		result := models.TrafficTemplateResult{}
+		if resp.StatusCode < http.StatusBadRequest || resp.StatusCode == http.StatusUnprocessableEntity {
+			require.NoErrorf(json.Unmarshal(raw, &result), "response text: %s", string(raw))
+		}
+		return resp.StatusCode, result
+	}
+
+	// The v2 workloads do not exist: the generated DestinationRule is not valid and it is not applied
+	invalid := `{"routes":[{"version":"v1","weight":50},{"version":"v2","weight":50}]}`
+	status, result := post(models.TrafficTemplateTrafficShifting, "", invalid)
+	require.Equal(http.StatusOK, status)
+	assert.False(result.Applied)
+	assert.False(result.Valid)
+	require.Len(result.Objects, 2)
+	status, result = post(models.TrafficTemplateTrafficShifting, "?apply=true", invalid)
+	require.Equal(http.StatusUnprocessableEntity, status)
+	assert.False(result.Applied)
+	_, err = k8s.Istio().NetworkingV1().DestinationRules("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+
+	// The Kiali roles must grant the edit-istio-config capability to apply, not to review
+	faultInjection := `{"routes":[{"version":"v1","weight":100}],"fault":{"abortPercentage":10,"httpStatus":503}}`
+	conf.Auth.KialiRBAC = config.KialiRBACConfig{
+		DefaultRoles: []string{"viewer"},
+		Enabled:      true,
+		Roles:        []config.KialiRole{{Name: "viewer", Capabilities: []string{config.KialiCapabilityViewGraph}}},
+	}
+	status, _ = post(models.TrafficTemplateFaultInjection, "", faultInjection)
+	assert.Equal(http.StatusOK, status)
+	status, _ = post(models.TrafficTemplateFaultInjection, "?apply=true", faultInjection)
+	assert.Equal(http.StatusForbidden, status)
+	_, err = k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	assert.True(api_errors.IsNotFound(err))
+	conf.Auth.KialiRBAC.Enabled = false
+
+	status, result = post(models.TrafficTemplateFaultInjection, "?apply=true", faultInjection)
+	require.Equal(http.StatusOK, status)
+	assert.True(result.Applied)
+	assert.True(result.Valid)
+	vs, err := k8s.Istio().NetworkingV1().VirtualServices("bookinfo").Get(context.Background(), "reviews", meta_v1.GetOptions{})
+	require.NoError(err)
+	assert.Equal(models.TrafficTemplateFaultInjection, vs.Labels["kiali_wizard"])
+	assert.Equal(float64(10), vs.Spec.Http[0].Fault.Abort.Percentage.Value)
+
+	status, _ = post(models.TrafficTemplateTrafficShifting, "", `{"routes":[{"version":"v1","weight":10}]}`)
+	assert.Equal(http.StatusBadRequest, status)
+	status, _ = post(models.TrafficTemplateTrafficShifting, "?dryRun=true", invalid)
+	assert.Equal(http.StatusBadRequest, status)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -289,6 +290,16 @@ func WorkloadUpdate(
 		}
 
 		workloadDetails, err := business.Workload.UpdateWorkload(r.Context(), cluster, namespace, workload, workloadGVK, true, jsonPatch, patchType)
+		audit.Log(r, conf, models.AuditSourceAPI, audit.Entry{
+			Operation: "UPDATE",
+			Cluster:   cluster,
+			Namespace: namespace,
+			Name:      workload,
+			GVK:       workloadGVK,
+			Patch:     jsonPatch,
+			Err:       err,
+			Message:   "Workload Update. Cluster: [" + cluster + "], Workload Name: [" + workload + "], Patch: " + jsonPatch,
+		})
 		if includeValidations && err == nil {
 			wg.Wait()
 			workloadDetails.Validations = istioConfigValidations
@@ -301,7 +312,6 @@ func WorkloadUpdate(
 			handleErrorResponse(w, err)
 			return
 		}
-		audit(r, "UPDATE", namespace, workloadGVK.String(), "Workload Update. Cluster: ["+cluster+"], Workload Name: ["+workload+"], Patch: "+jsonPatch)
 		RespondWithJSON(w, http.StatusOK, workloadDetails)
 	}
 }
@@ -428,6 +438,10 @@ func ConfigDumpZtunnel(
 		cluster := queryparams.ClusterName(conf, query)
 		namespace := params["namespace"]
 		pod := params["pod"]
+		// The ztunnel config dump includes the certificates of the workloads
+		if !checkKialiCapability(w, r, conf, config.KialiCapabilityViewSecrets, namespace, cluster) {
+			return
+		}
 
 		dump := business.Workload.GetZtunnelConfig(cluster, namespace, pod)
 		RespondWithJSON(w, http.StatusOK, dump)
@@ -55,6 +55,8 @@ type ClientInterface interface {
 	GetToken() string
 	IsOpenShift() bool
 	IsGatewayAPI() bool
+	HasBackendTLSPolicyInV1() bool
+	HasListenerSetInV1() bool
 	HasTCPRouteInV1() bool
 	HasTLSRouteInV1() bool
 	HasUDPRouteInV1() bool
@@ -116,6 +118,10 @@ type K8SClient struct {
 	isOpenShift *bool
 	// isGatewayAPI private variable will check if K8s Gateway API CRD exists on cluster or not
 	isGatewayAPI *bool
+	// hasBackendTLSPolicyInV1 private variable will check if BackendTLSPolicy exists in v1 (GW API 1.4+)
+	hasBackendTLSPolicyInV1 *bool
+	// hasListenerSetInV1 private variable will check if ListenerSet exists in v1 (GW API 1.5+)
+	hasListenerSetInV1 *bool
 	// hasTCPRouteInV1 private variable will check if TCPRoute exists in v1 (GW API 1.6+)
 	hasTCPRouteInV1 *bool
 	// hasTLSRouteInV1 private variable will check if TLSRoute exists in v1 (GW API 1.5+)
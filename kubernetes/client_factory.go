@@ -148,6 +148,9 @@ func newClientFactory(ctx context.Context, kialiConf *kialiconfig.Config, restCo
 	if kialiConf.Auth.OpenShift.Impersonation.Enabled && saTokenFile == "" {
 		return nil, fmt.Errorf("impersonation is enabled but no SA token file is available; Kiali must run in-cluster")
 	}
+	if kialiConf.Auth.Strategy == kialiconfig.AuthStrategyX509 && saTokenFile == "" {
+		return nil, fmt.Errorf("the x509 auth strategy impersonates users but no SA token file is available; Kiali must run in-cluster")
+	}
 	baseRestConfig := *restConf
 	stripAuthInfo(&baseRestConfig)
 
@@ -176,6 +179,23 @@ func newClientFactory(ctx context.Context, kialiConf *kialiconfig.Config, restCo
 	return f, nil
 }
 
+// ImpersonateWithSAExtra is set in the impersonation extras of the users that are impersonated with the Kiali SA
+// credentials whatever the auth strategy, like the owners of API tokens. It is never sent to the clusters.
+const ImpersonateWithSAExtra = "kiali.io/impersonate-with-sa"
+
+// impersonatesWithSA returns true when the user clients authenticate with the Kiali SA credentials and carry the
+// user identity in impersonation headers: with the openshift strategy when impersonation is enabled, always with
+// the x509 strategy, whose users have no token, and for the users marked with ImpersonateWithSAExtra.
+func (cf *clientFactory) impersonatesWithSA(authInfo *api.AuthInfo) bool {
+	if authInfo.Impersonate == "" {
+		return false
+	}
+	if _, ok := authInfo.ImpersonateUserExtra[ImpersonateWithSAExtra]; ok {
+		return true
+	}
+	return cf.kialiConfig.Auth.ImpersonatesWithSA()
+}
+
 // newClient creates a new UserClientInterface based on a users k8s token. It is assumed users do not have a token file in authInfo.
 func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.Duration, cluster string) (UserClientInterface, error) {
 	config := *cf.baseRestConfig
@@ -210,8 +230,8 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 	}
 
 	// HOME CLUSTER impersonation for header strategy — uses proxy-supplied identity.
-	// OpenShift strategy handles its own impersonation (both home and remote) in the
-	// conditional blocks below, based on auth.openshift.impersonation.enabled.
+	// OpenShift and x509 strategies handle their own impersonation (both home and remote) in the
+	// conditional blocks below, see impersonatesWithSA.
 	// Do NOT merge these two impersonation paths.
 	if cf.kialiConfig.Auth.Strategy == kialiconfig.AuthStrategyHeader && authInfo.Impersonate != "" {
 		config.Impersonate.UserName = authInfo.Impersonate
@@ -221,9 +241,7 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 
 	var newClient UserClientInterface
 	if cluster == cf.homeCluster {
-		if cf.kialiConfig.Auth.Strategy == kialiconfig.AuthStrategyOpenshift &&
-			cf.kialiConfig.Auth.OpenShift.Impersonation.Enabled &&
-			authInfo.Impersonate != "" {
+		if cf.impersonatesWithSA(authInfo) {
 			// IMPERSONATION PATH for home cluster: use the in-cluster SA credentials
 			// (token file) + impersonation headers. The user's OAuth token was
 			// already used for identity verification in ValidateSession.
@@ -264,9 +282,7 @@ func (cf *clientFactory) newClient(authInfo *api.AuthInfo, expirationTime time.D
 		remoteConfig := rest.CopyConfig(clusterInfo.ClientConfig)
 		cf.applySettings(remoteConfig)
 
-		if cf.kialiConfig.Auth.Strategy == kialiconfig.AuthStrategyOpenshift &&
-			cf.kialiConfig.Auth.OpenShift.Impersonation.Enabled &&
-			authInfo.Impersonate != "" {
+		if cf.impersonatesWithSA(authInfo) {
 			// IMPERSONATION PATH: SA token from secret authenticates the request;
 			// impersonation headers carry the user's identity.
 			// stripAuthInfo is NOT called — the SA token must remain in remoteConfig.
@@ -96,7 +96,12 @@ type VirtualHostFilter struct {
 		Match    map[string]interface{} `mapstructure:"match"`
 		Metadata *EnvoyMetadata         `mapstructure:"metadata,omitempty"`
 		Route    *struct {
-			Cluster string `mapstructure:"cluster,omitempty"`
+			Cluster          string `mapstructure:"cluster,omitempty"`
+			WeightedClusters *struct {
+				Clusters []struct {
+					Name string `mapstructure:"name"`
+				} `mapstructure:"clusters"`
+			} `mapstructure:"weighted_clusters,omitempty"`
 		} `mapstructure:"route,omitempty"`
 	} `mapstructure:"routes,omitempty"`
 }
@@ -257,6 +257,18 @@ func HostWithinWildcardHost(subdomain, wildcardDomain string) bool {
 	return strings.HasSuffix(subdomain, wildcardDomain[1:])
 }
 
+// K8sHostnamesIntersect returns true when two Gateway API hostnames can match the same request.
+// An empty hostname matches everything, and a wildcard hostname matches any of its subdomains.
+func K8sHostnamesIntersect(hostname1, hostname2 string) bool {
+	if hostname1 == "" || hostname2 == "" || hostname1 == hostname2 {
+		return true
+	}
+	if strings.HasPrefix(hostname1, "*.") && HostWithinWildcardHost(hostname2, hostname1) {
+		return true
+	}
+	return strings.HasPrefix(hostname2, "*.") && HostWithinWildcardHost(hostname1, hostname2)
+}
+
 func ParseGatewayAsHost(gateway, currentNamespace, identityDomain string) Host {
 	currentCluster := identityDomain
 
@@ -57,6 +57,29 @@ func TestHostWithinWildcardHost(t *testing.T) {
 	}
 }
 
+func TestK8sHostnamesIntersect(t *testing.T) {
+	cases := []struct {
+		hostname1, hostname2 string
+		expected             bool
+		reason               string
+	}{
+		{"bookinfo.example.com", "bookinfo.example.com", true, "identical hostnames"},
+		{"", "bookinfo.example.com", true, "empty hostname matches all"},
+		{"*.example.com", "bookinfo.example.com", true, "wildcard covers concrete hostname"},
+		{"bookinfo.example.com", "*.example.com", true, "concrete hostname within wildcard"},
+		{"*.example.com", "*.bookinfo.example.com", true, "nested wildcards"},
+		{"bookinfo.example.com", "reviews.example.com", false, "different concrete hostnames"},
+		{"*.example.com", "*.example.org", false, "disjoint wildcards"},
+		{"bookinfo.notexample.com", "*.example.com", false, "partial label must not match"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.reason, func(t *testing.T) {
+			assert.Equal(t, tc.expected, K8sHostnamesIntersect(tc.hostname1, tc.hostname2))
+		})
+	}
+}
+
 func TestHasMatchingVirtualServices(t *testing.T) {
 	assert := assert.New(t)
 
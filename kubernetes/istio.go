@@ -427,6 +427,78 @@ func K8sGatewayNames(gateways []*k8s_networking_v1.Gateway, identityDomain strin
 	return names
 }
 
+// IsK8sListenerSetParent returns true when the ListenerSet parentRef points to the Gateway with the given name and namespace.
+func IsK8sListenerSetParent(ls *k8s_networking_v1.ListenerSet, name, namespace string) bool {
+	ref := ls.Spec.ParentRef
+	if ref.Kind != nil && string(*ref.Kind) != K8sGateways.Kind {
+		return false
+	}
+	if ref.Group != nil && string(*ref.Group) != K8sGateways.Group {
+		return false
+	}
+	refNamespace := ls.Namespace
+	if ref.Namespace != nil && string(*ref.Namespace) != "" {
+		refNamespace = string(*ref.Namespace)
+	}
+	return string(ref.Name) == name && refNamespace == namespace
+}
+
+// K8sGatewayListeners returns the Gateway listeners together with the listeners of the ListenerSets attached to it.
+func K8sGatewayListeners(gw *k8s_networking_v1.Gateway, listenerSets []*k8s_networking_v1.ListenerSet) []k8s_networking_v1.Listener {
+	listeners := append([]k8s_networking_v1.Listener{}, gw.Spec.Listeners...)
+	for _, ls := range listenerSets {
+		if !IsK8sListenerSetParent(ls, gw.Name, gw.Namespace) {
+			continue
+		}
+		for _, l := range ls.Spec.Listeners {
+			listeners = append(listeners, k8s_networking_v1.Listener(l))
+		}
+	}
+	return listeners
+}
+
+// K8sParentRefListeners returns the listeners a route in routeNamespace can attach to through the given parentRef.
+// The parent may be a Gateway, looked up in gatewayNames (see K8sGatewayNames), or a ListenerSet. The listeners are
+// filtered by the parentRef sectionName and port. The second value is false when the parent object is not found.
+func K8sParentRefListeners(parentRef k8s_networking_v1.ParentReference, routeNamespace string, gatewayNames map[string]k8s_networking_v1.Gateway, listenerSets []*k8s_networking_v1.ListenerSet, identityDomain string) ([]k8s_networking_v1.Listener, bool) {
+	namespace := routeNamespace
+	if parentRef.Namespace != nil && string(*parentRef.Namespace) != "" {
+		namespace = string(*parentRef.Namespace)
+	}
+
+	var listeners []k8s_networking_v1.Listener
+	found := false
+	switch {
+	case parentRef.Kind == nil || string(*parentRef.Kind) == K8sGateways.Kind:
+		if gw, ok := gatewayNames[ParseHost(string(parentRef.Name), namespace, identityDomain).String()]; ok {
+			listeners = gw.Spec.Listeners
+			found = true
+		}
+	case string(*parentRef.Kind) == K8sListenerSets.Kind:
+		for _, ls := range listenerSets {
+			if ls.Name == string(parentRef.Name) && ls.Namespace == namespace {
+				for _, l := range ls.Spec.Listeners {
+					listeners = append(listeners, k8s_networking_v1.Listener(l))
+				}
+				found = true
+				break
+			}
+		}
+	}
+
+	selected := make([]k8s_networking_v1.Listener, 0, len(listeners))
+	for _, l := range listeners {
+		if parentRef.SectionName != nil && *parentRef.SectionName != "" && l.Name != *parentRef.SectionName {
+			continue
+		}
+		if parentRef.Port != nil && *parentRef.Port != 0 && l.Port != *parentRef.Port {
+			continue
+		}
+		selected = append(selected, l)
+	}
+	return selected, found
+}
+
 func PeerAuthnHasStrictMTLS(peerAuthn *security_v1.PeerAuthentication) bool {
 	_, mode := PeerAuthnHasMTLSEnabled(peerAuthn)
 	return mode == "STRICT"
@@ -60,6 +60,7 @@ type K8SClientInterface interface {
 	GetSecret(namespace, name string) (*core_v1.Secret, error)
 	GetSelfSubjectAccessReview(ctx context.Context, namespace, api, resourceType string, verbs []string) ([]*auth_v1.SelfSubjectAccessReview, error)
 	GetTokenSubject(authInfo *api.AuthInfo) (string, error)
+	GetTokenUserInfo(authInfo *api.AuthInfo) (*v1.UserInfo, error)
 	ForwardGetRequest(namespace, podName string, destinationPort int, path string) ([]byte, error)
 	StreamPodLogs(namespace, name string, opts *core_v1.PodLogOptions) (io.ReadCloser, error)
 }
@@ -241,6 +242,40 @@ func (in *K8SClient) IsGatewayAPI() bool {
 	return *in.isGatewayAPI
 }
 
+// HasBackendTLSPolicyInV1 returns true if BackendTLSPolicy exists in gateway.networking.k8s.io/v1 (GW API 1.4+).
+func (in *K8SClient) HasBackendTLSPolicyInV1() bool {
+	in.rwMutex.Lock()
+	defer in.rwMutex.Unlock()
+	if in.GatewayAPI() == nil {
+		return false
+	}
+	if in.hasBackendTLSPolicyInV1 == nil {
+		v1Types := map[string]string{
+			K8sBackendTLSPolicyType: PluralNames[K8sBackendTLSPolicyType],
+		}
+		hasBackendTLSPolicy := checkGatewayAPIs(in, K8sNetworkingGroupVersionV1.String(), v1Types, true)
+		in.hasBackendTLSPolicyInV1 = &hasBackendTLSPolicy
+	}
+	return *in.hasBackendTLSPolicyInV1
+}
+
+// HasListenerSetInV1 returns true if ListenerSet exists in gateway.networking.k8s.io/v1 (GW API 1.5+).
+func (in *K8SClient) HasListenerSetInV1() bool {
+	in.rwMutex.Lock()
+	defer in.rwMutex.Unlock()
+	if in.GatewayAPI() == nil {
+		return false
+	}
+	if in.hasListenerSetInV1 == nil {
+		v1Types := map[string]string{
+			K8sListenerSetType: PluralNames[K8sListenerSetType],
+		}
+		hasListenerSet := checkGatewayAPIs(in, K8sNetworkingGroupVersionV1.String(), v1Types, true)
+		in.hasListenerSetInV1 = &hasListenerSet
+	}
+	return *in.hasListenerSetInV1
+}
+
 // HasTCPRouteInV1 returns true if TCPRoute exists in gateway.networking.k8s.io/v1 (GW API 1.6+).
 func (in *K8SClient) HasTCPRouteInV1() bool {
 	in.rwMutex.Lock()
@@ -621,17 +656,27 @@ func (in *K8SClient) UpdateNamespace(namespace string, jsonPatch string) (*core_
 // GetTokenSubject returns the subject of the authInfo using
 // the TokenReview api
 func (in *K8SClient) GetTokenSubject(authInfo *api.AuthInfo) (string, error) {
+	userInfo, err := in.GetTokenUserInfo(authInfo)
+	if err != nil {
+		return "", err
+	}
+	return userInfo.Username, nil
+}
+
+// GetTokenUserInfo returns the user (name and groups) of the authInfo using
+// the TokenReview api
+func (in *K8SClient) GetTokenUserInfo(authInfo *api.AuthInfo) (*v1.UserInfo, error) {
 	tokenReview := &v1.TokenReview{}
 	tokenReview.Spec.Token = authInfo.Token
 
 	result, err := in.k8s.AuthenticationV1().TokenReviews().Create(in.ctx, tokenReview, meta_v1.CreateOptions{})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	} else if result.Status.Error != "" {
-		return "", goerrors.New(result.Status.Error)
+		return nil, goerrors.New(result.Status.Error)
 	} else {
-		return result.Status.User.Username, nil
+		return &result.Status.User, nil
 	}
 }
 
@@ -199,14 +199,16 @@ type FakeK8sClient struct {
 	OAuthFake       *oauthfake.Clientset
 }
 
-func (c *FakeK8sClient) IsOpenShift() bool     { return c.OpenShift }
-func (c *FakeK8sClient) IsGatewayAPI() bool    { return c.GatewayAPIEnabled }
-func (c *FakeK8sClient) HasTCPRouteInV1() bool { return c.GatewayAPIEnabled }
-func (c *FakeK8sClient) HasTLSRouteInV1() bool { return c.GatewayAPIEnabled }
-func (c *FakeK8sClient) HasUDPRouteInV1() bool { return c.GatewayAPIEnabled }
-func (c *FakeK8sClient) IsInferenceAPI() bool  { return c.InferenceAPIEnabled }
-func (c *FakeK8sClient) IsIstioGateway() bool  { return c.IstioGatewayInstalled }
-func (c *FakeK8sClient) IsIstioAPI() bool      { return c.IstioAPIInstalled }
+func (c *FakeK8sClient) IsOpenShift() bool             { return c.OpenShift }
+func (c *FakeK8sClient) IsGatewayAPI() bool            { return c.GatewayAPIEnabled }
+func (c *FakeK8sClient) HasBackendTLSPolicyInV1() bool { return c.GatewayAPIEnabled }
+func (c *FakeK8sClient) HasListenerSetInV1() bool      { return c.GatewayAPIEnabled }
+func (c *FakeK8sClient) HasTCPRouteInV1() bool         { return c.GatewayAPIEnabled }
+func (c *FakeK8sClient) HasTLSRouteInV1() bool         { return c.GatewayAPIEnabled }
+func (c *FakeK8sClient) HasUDPRouteInV1() bool         { return c.GatewayAPIEnabled }
+func (c *FakeK8sClient) IsInferenceAPI() bool          { return c.InferenceAPIEnabled }
+func (c *FakeK8sClient) IsIstioGateway() bool          { return c.IstioGatewayInstalled }
+func (c *FakeK8sClient) IsIstioAPI() bool              { return c.IstioAPIInstalled }
 func (c *FakeK8sClient) GetCacheKey() string {
 	if c.CacheKey != "" {
 		return c.CacheKey
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	istio_fake "istio.io/client-go/pkg/clientset/versioned/fake"
 	apps_v1 "k8s.io/api/apps/v1"
+	authentication_v1 "k8s.io/api/authentication/v1"
 	batch_v1 "k8s.io/api/batch/v1"
 	core_v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -118,6 +119,8 @@ func NewK8SClientMock() *K8SClientMock {
 	k8s := new(K8SClientMock)
 	k8s.On("IsOpenShift").Return(true)
 	k8s.On("IsGatewayAPI").Return(false)
+	k8s.On("HasBackendTLSPolicyInV1").Return(false)
+	k8s.On("HasListenerSetInV1").Return(false)
 	k8s.On("HasTCPRouteInV1").Return(false)
 	k8s.On("HasTLSRouteInV1").Return(false)
 	k8s.On("HasUDPRouteInV1").Return(false)
@@ -168,6 +171,16 @@ func (o *K8SClientMock) IsGatewayAPI() bool {
 	return args.Get(0).(bool)
 }
 
+func (o *K8SClientMock) HasBackendTLSPolicyInV1() bool {
+	args := o.Called()
+	return args.Get(0).(bool)
+}
+
+func (o *K8SClientMock) HasListenerSetInV1() bool {
+	args := o.Called()
+	return args.Get(0).(bool)
+}
+
 func (o *K8SClientMock) HasTCPRouteInV1() bool {
 	args := o.Called()
 	return args.Get(0).(bool)
@@ -239,6 +252,16 @@ func (o *K8SClientMock) GetTokenSubject(authInfo *api.AuthInfo) (string, error)
 	return authInfo.Token, nil
 }
 
+// GetTokenUserInfo returns the user of the authInfo. The mock only knows the
+// subject of the token, without groups.
+func (o *K8SClientMock) GetTokenUserInfo(authInfo *api.AuthInfo) (*authentication_v1.UserInfo, error) {
+	subject, err := o.GetTokenSubject(authInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &authentication_v1.UserInfo{Username: subject}, nil
+}
+
 func (o *K8SClientMock) ForwardGetRequest(namespace, podName string, destinationPort int, path string) ([]byte, error) {
 	args := o.Called(namespace, podName, destinationPort, path)
 	return args.Get(0).([]byte), args.Error(1)
@@ -22,15 +22,17 @@ const (
 	WorkloadGroupType    = "WorkloadGroup"
 
 	// K8s Networking
-	K8sGatewayType        = "Gateway"
-	K8sGatewayClassType   = "GatewayClass"
-	K8sGRPCRouteType      = "GRPCRoute"
-	K8sHTTPRouteType      = "HTTPRoute"
-	K8sInferencePoolsType = "InferencePool"
-	K8sReferenceGrantType = "ReferenceGrant"
-	K8sTCPRouteType       = "TCPRoute"
-	K8sTLSRouteType       = "TLSRoute"
-	K8sUDPRouteType       = "UDPRoute"
+	K8sBackendTLSPolicyType = "BackendTLSPolicy"
+	K8sGatewayType          = "Gateway"
+	K8sGatewayClassType     = "GatewayClass"
+	K8sGRPCRouteType        = "GRPCRoute"
+	K8sHTTPRouteType        = "HTTPRoute"
+	K8sInferencePoolsType   = "InferencePool"
+	K8sListenerSetType      = "ListenerSet"
+	K8sReferenceGrantType   = "ReferenceGrant"
+	K8sTCPRouteType         = "TCPRoute"
+	K8sTLSRouteType         = "TLSRoute"
+	K8sUDPRouteType         = "UDPRoute"
 
 	// Authorization PeerAuthentications
 	AuthorizationPoliciesType = "AuthorizationPolicy"
@@ -48,9 +50,11 @@ const (
 	DeploymentType            = "Deployment"
 	DeploymentConfigType      = "DeploymentConfig"
 	JobType                   = "Job"
+	NamespaceType             = "Namespace"
 	PodType                   = "Pod"
 	ReplicationControllerType = "ReplicationController"
 	ReplicaSetType            = "ReplicaSet"
+	SecretType                = "Secret"
 	ServiceType               = "Service"
 	StatefulSetType           = "StatefulSet"
 )
@@ -70,15 +74,17 @@ var (
 	WorkloadGroups    = NetworkingGroupVersionV1.WithKind(WorkloadGroupType)
 
 	// K8s Networking
-	K8sGateways        = K8sNetworkingGroupVersionV1.WithKind(K8sGatewayType)
-	K8sGatewayClasses  = K8sNetworkingGroupVersionV1.WithKind(K8sGatewayClassType)
-	K8sGRPCRoutes      = K8sNetworkingGroupVersionV1.WithKind(K8sGRPCRouteType)
-	K8sHTTPRoutes      = K8sNetworkingGroupVersionV1.WithKind(K8sHTTPRouteType)
-	K8sInferencePools  = K8sInferenceGroupVersionV1.WithKind(K8sInferencePoolsType)
-	K8sReferenceGrants = K8sNetworkingGroupVersionV1Beta1.WithKind(K8sReferenceGrantType)
-	K8sTCPRoutes       = K8sNetworkingGroupVersionV1.WithKind(K8sTCPRouteType)
-	K8sTLSRoutes       = K8sNetworkingGroupVersionV1.WithKind(K8sTLSRouteType)
-	K8sUDPRoutes       = K8sNetworkingGroupVersionV1.WithKind(K8sUDPRouteType)
+	K8sBackendTLSPolicies = K8sNetworkingGroupVersionV1.WithKind(K8sBackendTLSPolicyType)
+	K8sGateways           = K8sNetworkingGroupVersionV1.WithKind(K8sGatewayType)
+	K8sGatewayClasses     = K8sNetworkingGroupVersionV1.WithKind(K8sGatewayClassType)
+	K8sGRPCRoutes         = K8sNetworkingGroupVersionV1.WithKind(K8sGRPCRouteType)
+	K8sHTTPRoutes         = K8sNetworkingGroupVersionV1.WithKind(K8sHTTPRouteType)
+	K8sInferencePools     = K8sInferenceGroupVersionV1.WithKind(K8sInferencePoolsType)
+	K8sListenerSets       = K8sNetworkingGroupVersionV1.WithKind(K8sListenerSetType)
+	K8sReferenceGrants    = K8sNetworkingGroupVersionV1Beta1.WithKind(K8sReferenceGrantType)
+	K8sTCPRoutes          = K8sNetworkingGroupVersionV1.WithKind(K8sTCPRouteType)
+	K8sTLSRoutes          = K8sNetworkingGroupVersionV1.WithKind(K8sTLSRouteType)
+	K8sUDPRoutes          = K8sNetworkingGroupVersionV1.WithKind(K8sUDPRouteType)
 
 	// Authorization PeerAuthentications
 	AuthorizationPolicies = SecurityGroupVersionV1.WithKind(AuthorizationPoliciesType)
@@ -96,6 +102,7 @@ var (
 	Deployments            = AppsGroupVersionV1.WithKind(DeploymentType)
 	DeploymentConfigs      = AppsOpenShiftGroupVersionV1.WithKind(DeploymentConfigType)
 	Jobs                   = BatchGroupVersionV1.WithKind(JobType)
+	Namespaces             = CoreGroupVersionV1.WithKind(NamespaceType)
 	Pods                   = CoreGroupVersionV1.WithKind(PodType)
 	ReplicationControllers = CoreGroupVersionV1.WithKind(ReplicationControllerType)
 	ReplicaSets            = AppsGroupVersionV1.WithKind(ReplicaSetType)
@@ -166,15 +173,17 @@ var (
 	// PluralNames maps Kind to the lowercase plural resource name used in RBAC.
 	// Kubernetes SSAR ResourceAttributes.Resource requires the plural form.
 	PluralNames = map[string]string{
-		K8sGatewayType:        "gateways",
-		K8sGatewayClassType:   "gatewayclasses",
-		K8sGRPCRouteType:      "grpcroutes",
-		K8sHTTPRouteType:      "httproutes",
-		K8sInferencePoolsType: "inferencepools",
-		K8sReferenceGrantType: "referencegrants",
-		K8sTCPRouteType:       "tcproutes",
-		K8sTLSRouteType:       "tlsroutes",
-		K8sUDPRouteType:       "udproutes",
+		K8sBackendTLSPolicyType: "backendtlspolicies",
+		K8sGatewayType:          "gateways",
+		K8sGatewayClassType:     "gatewayclasses",
+		K8sGRPCRouteType:        "grpcroutes",
+		K8sHTTPRouteType:        "httproutes",
+		K8sInferencePoolsType:   "inferencepools",
+		K8sListenerSetType:      "listenersets",
+		K8sReferenceGrantType:   "referencegrants",
+		K8sTCPRouteType:         "tcproutes",
+		K8sTLSRouteType:         "tlsroutes",
+		K8sUDPRouteType:         "udproutes",
 	}
 
 	// Resources
@@ -191,14 +200,16 @@ var (
 		WorkloadEntries.String():   WorkloadEntries,
 		WorkloadGroups.String():    WorkloadGroups,
 
-		K8sGateways.String():        K8sGateways,
-		K8sGRPCRoutes.String():      K8sGRPCRoutes,
-		K8sHTTPRoutes.String():      K8sHTTPRoutes,
-		K8sInferencePools.String():  K8sInferencePools,
-		K8sReferenceGrants.String(): K8sReferenceGrants,
-		K8sTCPRoutes.String():       K8sTCPRoutes,
-		K8sTLSRoutes.String():       K8sTLSRoutes,
-		K8sUDPRoutes.String():       K8sUDPRoutes,
+		K8sBackendTLSPolicies.String(): K8sBackendTLSPolicies,
+		K8sGateways.String():           K8sGateways,
+		K8sGRPCRoutes.String():         K8sGRPCRoutes,
+		K8sHTTPRoutes.String():         K8sHTTPRoutes,
+		K8sInferencePools.String():     K8sInferencePools,
+		K8sListenerSets.String():       K8sListenerSets,
+		K8sReferenceGrants.String():    K8sReferenceGrants,
+		K8sTCPRoutes.String():          K8sTCPRoutes,
+		K8sTLSRoutes.String():          K8sTLSRoutes,
+		K8sUDPRoutes.String():          K8sUDPRoutes,
 
 		AuthorizationPolicies.String():  AuthorizationPolicies,
 		PeerAuthentications.String():    PeerAuthentications,
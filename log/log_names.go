@@ -3,6 +3,7 @@ package log
 // these are variables used to define logger group names
 // see log.WithGroup for when these are typically used
 var (
+	AuditLogName        = "audit"
 	AuthenticateLogName = "authenticate"
 	ChatAILogName       = "chatai"
 	ClustersLogName     = "clusters"
@@ -29,6 +29,7 @@ type NodeData struct {
 	HasInfra       bool        `json:"-"`                        // for local when generating boxes
 	HealthData     interface{} `json:"healthData"`               // data to calculate health status from configurations
 	InfraData      interface{} `json:"infraData,omitempty"`      // infraType-dependent data
+	InfraHealth    interface{} `json:"infraHealth,omitempty"`    // health of istiod, gateways, waypoints and ztunnel
 	IsAmbient      bool        `json:"isAmbient,omitempty"`      // true if configured for ambient
 	IsBox          string      `json:"isBox,omitempty"`          // set for NodeTypeBox, current values: [ 'cluster', 'dataplanes', 'namespace' ]
 	IsExternal     bool        `json:"isExternal,omitempty"`     // true if the infra is external to the mesh | false
@@ -145,6 +146,10 @@ func buildConfig(meshMap mesh.MeshMap, nodes *[]*NodeWrapper, edges *[]*EdgeWrap
 			nd.InfraData = val
 		}
 
+		if val, ok := n.Metadata[mesh.InfraHealth]; ok {
+			nd.InfraHealth = val
+		}
+
 		// node is external (or url could not be parsed)
 		if val, ok := n.Metadata[mesh.IsExternal]; ok && val.(bool) {
 			nd.IsExternal = true
@@ -8,8 +8,10 @@ import (
 	"runtime/debug"
 	"slices"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/config"
@@ -62,6 +64,31 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 		healthData[key] = data.Status
 	}
 
+	// get the health of the mesh infrastructure components (istiod, gateways, waypoints, ztunnel)
+	infraHealth := map[string]*models.MeshComponentHealth{}
+	var gatewayWorkloads models.Workloads
+	if gi.Conf.HealthConfig.Mesh.Enabled {
+		criteria := business.MeshHealthCriteria{
+			QueryTime:    time.Unix(o.QueryTime, 0),
+			RateInterval: string(gi.Conf.HealthConfig.Compute.Duration),
+		}
+		componentsHealth, err := gi.Business.Health.GetMeshHealth(ctx, criteria)
+		if err != nil {
+			// Don't error on the infrastructure health since the components have their status.
+			log.Debugf("Unable to get the health of the mesh infrastructure components: %s", err)
+		}
+		for i, health := range componentsHealth {
+			key := componentHealthKey{Name: health.Name, Namespace: health.Namespace, Cluster: health.Cluster}.String()
+			infraHealth[key] = &componentsHealth[i]
+		}
+		if o.IncludeGateways {
+			// used to find the workloads of the gateway nodes
+			if gatewayWorkloads, err = gi.Business.Workload.GetGateways(ctx); err != nil {
+				log.Debugf("Unable to get the gateway workloads of the mesh: %s", err)
+			}
+		}
+	}
+
 	grafanaHealthKey := componentHealthKey{Name: "grafana", Namespace: "", Cluster: gi.Conf.KubernetesConfig.ClusterName}.String()
 	persesHealthKey := componentHealthKey{Name: "perses", Namespace: "", Cluster: gi.Conf.KubernetesConfig.ClusterName}.String()
 
@@ -118,6 +145,7 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 		healthDataKey := componentHealthKey{Name: cp.IstiodName, Namespace: cp.IstiodNamespace, Cluster: cp.Cluster.Name}.String()
 		istiod, _, err := addInfra(meshMap, mesh.InfraTypeIstiod, cp.Cluster.Name, cp.IstiodNamespace, name, cp, version, false, healthData[healthDataKey])
 		mesh.CheckError(err)
+		addInfraHealth(istiod, infraHealth[healthDataKey])
 
 		if gi.KialiCache.IsControlPlaneNamespaceAmbient(ctx, cp.Cluster.Name, cp.IstiodNamespace, cp.IstiodName) {
 			istiod.Metadata[mesh.IsAmbient] = true
@@ -215,6 +243,7 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 
 				ztunnelNode, _, err := addInfra(meshMap, mesh.InfraTypeZtunnel, ztunnel.Cluster, ztunnel.Namespace, ztunnel.Name, infraData, version, false, "")
 				mesh.CheckError(err)
+				addInfraHealth(ztunnelNode, infraHealth[componentHealthKey{Name: ztunnel.Name, Namespace: ztunnel.Namespace, Cluster: ztunnel.Cluster}.String()])
 
 				// add edge to the managing control plane
 				// Use revision/tag as primary match, version label as tie-breaker for multi-mesh
@@ -269,6 +298,7 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 
 				wpNode, _, err := addInfra(meshMap, mesh.InfraTypeWaypoint, wp.Cluster, wp.Namespace, wp.Name, infraData, version, false, "")
 				mesh.CheckError(err)
+				addInfraHealth(wpNode, infraHealth[componentHealthKey{Name: wp.Name, Namespace: wp.Namespace, Cluster: wp.Cluster}.String()])
 
 				// add edge to the managing control plane
 				for _, infraNode := range meshMap {
@@ -305,6 +335,7 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 					}
 					gwNode, _, err := addInfra(meshMap, mesh.InfraTypeGateway, cluster, gw.Namespace, gw.Name, gw, version, false, "")
 					mesh.CheckError(err)
+					addInfraHealth(gwNode, gatewayHealth(gatewayWorkloads, infraHealth, cluster, "", gw.Spec.Selector))
 					gwNodes = append(gwNodes, gwNode)
 				}
 				for _, gw := range conf.K8sGateways {
@@ -318,6 +349,7 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 					}
 					gwNode, _, err := addInfra(meshMap, mesh.InfraTypeGateway, cluster, gw.Namespace, gw.Name, gw, version, false, "")
 					mesh.CheckError(err)
+					addInfraHealth(gwNode, gatewayHealth(gatewayWorkloads, infraHealth, cluster, gw.Namespace, map[string]string{config.GatewayLabel: gw.Name}))
 					gwNodes = append(gwNodes, gwNode)
 				}
 
@@ -445,6 +477,38 @@ func BuildMeshMap(ctx context.Context, o mesh.Options, gi *mesh.GlobalInfo) (mes
 	return meshMap, nil
 }
 
+// addInfraHealth sets the health of a mesh infrastructure component on its node. An unhealthy component makes the
+// node Unhealthy, when its status is Healthy.
+func addInfraHealth(node *mesh.Node, health *models.MeshComponentHealth) {
+	if health == nil {
+		return
+	}
+	node.Metadata[mesh.InfraHealth] = health
+	status := health.Status.Status
+	if (status == models.HealthStatusDegraded || status == models.HealthStatusFailure) && node.Metadata[mesh.HealthData] == kubernetes.ComponentHealthy {
+		node.Metadata[mesh.HealthData] = kubernetes.ComponentUnhealthy
+	}
+}
+
+// gatewayHealth returns the worst health of the gateway workloads selected by a gateway. An empty namespace selects
+// the workloads of every namespace.
+func gatewayHealth(workloads models.Workloads, infraHealth map[string]*models.MeshComponentHealth, cluster, namespace string, selector map[string]string) *models.MeshComponentHealth {
+	if len(selector) == 0 {
+		return nil
+	}
+	var worst *models.MeshComponentHealth
+	for _, w := range workloads {
+		if w.Cluster != cluster || (namespace != "" && w.Namespace != namespace) || !labels.SelectorFromSet(selector).Matches(labels.Set(w.Labels)) {
+			continue
+		}
+		health := infraHealth[componentHealthKey{Name: w.Name, Namespace: w.Namespace, Cluster: w.Cluster}.String()]
+		if health != nil && (worst == nil || models.HealthStatusPriority(health.Status.Status) > models.HealthStatusPriority(worst.Status.Status)) {
+			worst = health
+		}
+	}
+	return worst
+}
+
 func addInfra(meshMap mesh.MeshMap, infraType, cluster, namespace, name string, infraData interface{}, version string, isExternal bool, healthData string) (*mesh.Node, bool, error) {
 	id, err := mesh.Id(cluster, namespace, name, infraType, version, isExternal)
 	if err != nil {
@@ -15,6 +15,7 @@ func NewMetadata() Metadata {
 const (
 	HealthData     MetadataKey = "healthData"
 	InfraData      MetadataKey = "infraData"
+	InfraHealth    MetadataKey = "infraHealth"
 	IsAmbient      MetadataKey = "isAmbient"
 	IsExternal     MetadataKey = "isExternal"
 	IsInaccessible MetadataKey = "isInaccessible"
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// AuditResultSuccess is used when the audited operation was applied.
+	AuditResultSuccess = "success"
+	// AuditResultFailure is used when the audited operation was rejected or failed.
+	AuditResultFailure = "failure"
+)
+
+const (
+	// AuditSourceAPI is used for operations requested through the Kiali REST API.
+	AuditSourceAPI = "api"
+	// AuditSourceMCP is used for operations requested through the Kiali MCP tools.
+	AuditSourceMCP = "mcp"
+)
+
+// AuditRecord is a structured record of a write operation performed through Kiali.
+type AuditRecord struct {
+	// Unique identifier of the record
+	ID string `json:"id"`
+
+	// Time when the operation was performed
+	Timestamp time.Time `json:"timestamp"`
+
+	// User that requested the operation
+	// example: admin
+	User string `json:"user"`
+
+	// Authentication strategy used by the user
+	// example: openid
+	AuthStrategy string `json:"authStrategy"`
+
+	// ID of the Kiali API token used by the request, when the user authenticated with one
+	APITokenID string `json:"apiTokenId,omitempty"`
+
+	// Request identifier (X-Request-Id), when available
+	RequestID string `json:"requestId,omitempty"`
+
+	// Entry point of the operation: api or mcp
+	Source string `json:"source"`
+
+	// Operation performed: CREATE, UPDATE or DELETE
+	// example: UPDATE
+	Operation string `json:"operation"`
+
+	// Cluster of the modified object
+	Cluster string `json:"cluster"`
+
+	// Namespace of the modified object
+	Namespace string `json:"namespace"`
+
+	// Name of the modified object
+	Name string `json:"name"`
+
+	// Group, Version and Kind of the modified object
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+
+	// Object before the operation, when known
+	Before json.RawMessage `json:"before,omitempty"`
+
+	// Object after the operation, when known
+	After json.RawMessage `json:"after,omitempty"`
+
+	// Patch applied by an UPDATE operation
+	Patch string `json:"patch,omitempty"`
+
+	// Result of the operation: success or failure
+	Result string `json:"result"`
+
+	// Error returned when the operation failed
+	Error string `json:"error,omitempty"`
+
+	// Free form description of the operation
+	Message string `json:"message,omitempty"`
+}
+
+// AuditQuery filters the audit records returned by a search.
+// Empty fields match every record.
+type AuditQuery struct {
+	User      string
+	Cluster   string
+	Namespace string
+	Name      string
+	Kind      string
+	Operation string
+	Result    string
+	StartTime time.Time
+	EndTime   time.Time
+	// Maximum number of records returned, newest first. 0 means no limit.
+	Limit int
+}
+
+// Matches returns true when the record satisfies all the filters of the query.
+func (q AuditQuery) Matches(r AuditRecord) bool {
+	if q.User != "" && q.User != r.User {
+		return false
+	}
+	if q.Cluster != "" && q.Cluster != r.Cluster {
+		return false
+	}
+	if q.Namespace != "" && q.Namespace != r.Namespace {
+		return false
+	}
+	if q.Name != "" && q.Name != r.Name {
+		return false
+	}
+	if q.Kind != "" && q.Kind != r.ObjectGVK.Kind {
+		return false
+	}
+	if q.Operation != "" && q.Operation != r.Operation {
+		return false
+	}
+	if q.Result != "" && q.Result != r.Result {
+		return false
+	}
+	if !q.StartTime.IsZero() && r.Timestamp.Before(q.StartTime) {
+		return false
+	}
+	if !q.EndTime.IsZero() && r.Timestamp.After(q.EndTime) {
+		return false
+	}
+	return true
+}
+
+// AuditRecords is the result of an audit search, newest first.
+type AuditRecords struct {
+	Records []AuditRecord `json:"records"`
+}
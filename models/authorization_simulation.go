@@ -0,0 +1,125 @@
+package models
+
+const (
+	// AuthorizationDecisionAllow means the request would be allowed by the evaluated policies.
+	AuthorizationDecisionAllow = "ALLOW"
+	// AuthorizationDecisionDeny means the request would be denied by the evaluated policies.
+	AuthorizationDecisionDeny = "DENY"
+	// AuthorizationDecisionCustom means the request is delegated to an external authorization
+	// provider (CUSTOM action) and the final outcome depends on that provider.
+	AuthorizationDecisionCustom = "CUSTOM"
+)
+
+const (
+	// AuthorizationEnforcementSidecar is used for policies enforced by the destination sidecar proxy.
+	AuthorizationEnforcementSidecar = "sidecar"
+	// AuthorizationEnforcementWaypoint is used for policies attached to a waypoint proxy (ambient).
+	AuthorizationEnforcementWaypoint = "waypoint"
+	// AuthorizationEnforcementZtunnel is used for selector based policies enforced by ztunnel (ambient, L4 only).
+	AuthorizationEnforcementZtunnel = "ztunnel"
+)
+
+const (
+	// AuthorizationScopeMesh is used for policies in the root namespace without selector.
+	AuthorizationScopeMesh = "mesh"
+	// AuthorizationScopeNamespace is used for policies in the workload namespace without selector.
+	AuthorizationScopeNamespace = "namespace"
+	// AuthorizationScopeWorkload is used for policies selecting the workload by labels.
+	AuthorizationScopeWorkload = "workload"
+	// AuthorizationScopeTargetRef is used for policies attached through targetRef(s).
+	AuthorizationScopeTargetRef = "targetRef"
+)
+
+// AuthorizationSimulationRequest describes a concrete request to evaluate against the
+// AuthorizationPolicies applied to a destination workload.
+type AuthorizationSimulationRequest struct {
+	// Namespace of the source workload
+	// example: bookinfo
+	SourceNamespace string `json:"sourceNamespace"`
+
+	// Service account of the source workload. Used with sourceNamespace to build the source principal.
+	// example: bookinfo-productpage
+	SourceServiceAccount string `json:"sourceServiceAccount"`
+
+	// Source principal. When set it takes precedence over sourceNamespace/sourceServiceAccount.
+	// example: cluster.local/ns/bookinfo/sa/bookinfo-productpage
+	SourcePrincipal string `json:"sourcePrincipal"`
+
+	// Source IP address
+	// example: 10.0.0.12
+	SourceIP string `json:"sourceIP"`
+
+	// Authenticated request principal (JWT iss/sub)
+	// example: https://issuer.example.com/user1
+	RequestPrincipal string `json:"requestPrincipal"`
+
+	// Requested host (authority)
+	// example: reviews.bookinfo.svc.cluster.local
+	Host string `json:"host"`
+
+	// Destination port
+	// example: 9080
+	Port int `json:"port"`
+
+	// Request path
+	// example: /reviews/1
+	Path string `json:"path"`
+
+	// HTTP method
+	// example: GET
+	Method string `json:"method"`
+
+	// Request headers
+	Headers map[string]string `json:"headers"`
+}
+
+// AuthorizationPolicyEvaluation describes how a single AuthorizationPolicy was evaluated for a request.
+type AuthorizationPolicyEvaluation struct {
+	// Name of the AuthorizationPolicy
+	Name string `json:"name"`
+
+	// Namespace of the AuthorizationPolicy
+	Namespace string `json:"namespace"`
+
+	// Action of the policy: ALLOW, DENY, AUDIT or CUSTOM
+	Action string `json:"action"`
+
+	// Provider name for CUSTOM policies
+	Provider string `json:"provider,omitempty"`
+
+	// Scope the policy was applied with: mesh, namespace, workload or targetRef
+	Scope string `json:"scope"`
+
+	// Proxy enforcing the policy: sidecar, waypoint or ztunnel
+	EnforcedBy string `json:"enforcedBy"`
+
+	// True when at least one rule of the policy matched the request
+	Matched bool `json:"matched"`
+
+	// Indexes of the rules (spec.rules[i]) that matched the request
+	MatchedRules []int `json:"matchedRules"`
+
+	// Notes explaining parts of the policy that could not be evaluated exactly
+	Notes []string `json:"notes,omitempty"`
+}
+
+// AuthorizationSimulationResult is the outcome of evaluating a request against the applicable
+// AuthorizationPolicies of a destination workload.
+type AuthorizationSimulationResult struct {
+	// Final decision: ALLOW, DENY or CUSTOM
+	// required: true
+	// example: DENY
+	Decision string `json:"decision"`
+
+	// Human readable explanation of the decision
+	Reason string `json:"reason"`
+
+	// Source principal used in the evaluation
+	SourcePrincipal string `json:"sourcePrincipal"`
+
+	// Policies that determined the decision
+	DeterminingPolicies []AuthorizationPolicyEvaluation `json:"determiningPolicies"`
+
+	// All the policies applicable to the destination, in evaluation order
+	EvaluatedPolicies []AuthorizationPolicyEvaluation `json:"evaluatedPolicies"`
+}
@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// Status of a canary run
+const (
+	CanaryStatusCancelled  = "cancelled"
+	CanaryStatusFailed     = "failed"
+	CanaryStatusRolledBack = "rolled_back"
+	CanaryStatusRunning    = "running"
+	CanaryStatusSucceeded  = "succeeded"
+)
+
+// Actions recorded in the history of a canary run
+const (
+	CanaryActionCancel   = "cancel"
+	CanaryActionFail     = "fail"
+	CanaryActionPromote  = "promote"
+	CanaryActionRollback = "rollback"
+	CanaryActionStart    = "start"
+	CanaryActionSucceed  = "succeed"
+)
+
+// CanaryRequest holds the parameters of a canary run, shifting the traffic of a service from a baseline
+// to a canary version in weighted steps.
+// swagger:model CanaryRequest
+type CanaryRequest struct {
+	// Version receiving the traffic not sent to the canary
+	Baseline CanaryTarget `json:"baseline"`
+	// Version receiving the shifted traffic
+	Canary CanaryTarget `json:"canary"`
+	// Shift the traffic with a Gateway API HTTPRoute instead of an Istio VirtualService and DestinationRule
+	GatewayAPI bool `json:"gatewayAPI,omitempty"`
+	// Percentage of the traffic added to the canary at each step, 10 when not set
+	StepWeight int32 `json:"stepWeight,omitempty"`
+	// Percentage of the traffic sent to the canary when the run succeeds, 100 when not set
+	MaxWeight int32 `json:"maxWeight,omitempty"`
+	// Time between two steps, as a duration (e.g. 5m). It is also the rate interval of the metrics
+	// checked before each step. 1m when not set.
+	Interval string `json:"interval,omitempty"`
+	// Maximum 95th percentile of the canary request duration in milliseconds. Not checked when not set.
+	MaxLatency float64 `json:"maxLatency,omitempty"`
+}
+
+// CanaryTarget is a version of a service in a canary run.
+type CanaryTarget struct {
+	// Version of the service workloads, a DestinationRule subset. Istio config only.
+	Version string `json:"version,omitempty"`
+	// Service receiving the traffic. Gateway API only, defaults to the service of the run.
+	Backend string `json:"backend,omitempty"`
+}
+
+// CanaryRun is the status and history of a canary run.
+// swagger:model CanaryRun
+type CanaryRun struct {
+	ID        string        `json:"id"`
+	Cluster   string        `json:"cluster"`
+	Namespace string        `json:"namespace"`
+	Service   string        `json:"service"`
+	Request   CanaryRequest `json:"request"`
+	// One of running, succeeded, rolled_back, cancelled or failed
+	Status string `json:"status"`
+	// Percentage of the traffic currently sent to the canary
+	Weight int32 `json:"weight"`
+	// User who started the run
+	User      string        `json:"user,omitempty"`
+	StartTime time.Time     `json:"startTime"`
+	EndTime   *time.Time    `json:"endTime,omitempty"`
+	Message   string        `json:"message,omitempty"`
+	History   []CanaryEvent `json:"history"`
+}
+
+// CanaryEvent is a step of a canary run, with the canary metrics that led to it.
+type CanaryEvent struct {
+	Time time.Time `json:"time"`
+	// One of start, promote, succeed, rollback, cancel or fail
+	Action string `json:"action"`
+	// Percentage of the traffic sent to the canary after the step
+	Weight int32 `json:"weight"`
+	// Health status of the canary, from the error rate tolerances of the health config
+	Health HealthStatus `json:"health,omitempty"`
+	// Error ratio of the canary requests, in percent. -1 when there was no traffic.
+	ErrorRatio float64 `json:"errorRatio"`
+	// 95th percentile of the canary request duration in milliseconds, when it is checked
+	Latency *float64 `json:"latency,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// Finished returns true when the run is no longer shifting traffic.
+func (r CanaryRun) Finished() bool {
+	return r.Status != CanaryStatusRunning
+}
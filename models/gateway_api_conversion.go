@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GatewayAPIConversionRequest selects the objects of a namespace converted to the Gateway API.
+// All the Ingresses, Gateways and VirtualServices of the namespace are converted when no object is selected.
+// swagger:model GatewayAPIConversionRequest
+type GatewayAPIConversionRequest struct {
+	// Class of the Gateway API gateways replacing the Ingresses and Istio Gateways.
+	// Defaults to the first Gateway API class configured in Kiali.
+	GatewayClassName string `json:"gatewayClassName,omitempty"`
+	// Names of the Ingresses to convert
+	Ingresses []string `json:"ingresses,omitempty"`
+	// Names of the Istio Gateways to convert
+	Gateways []string `json:"gateways,omitempty"`
+	// Names of the VirtualServices to convert
+	VirtualServices []string `json:"virtualServices,omitempty"`
+}
+
+// GatewayAPIConversionObject is a Gateway API object resulting from a conversion.
+type GatewayAPIConversionObject struct {
+	// GroupVersionKind of the object
+	// required: true
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	// Namespace of the object. ReferenceGrants are in the namespace of the referenced services.
+	// required: true
+	Namespace string `json:"namespace"`
+	// required: true
+	Name string `json:"name"`
+	// CREATE when the object does not exist yet, UPDATE otherwise
+	// required: true
+	Operation string `json:"operation"`
+	// Manifest of the object
+	// required: true
+	Manifest json.RawMessage `json:"manifest"`
+	// JSON merge patch updating the existing object, for an UPDATE
+	Patch string `json:"patch,omitempty"`
+	// Objects converted into this one, as Kind/name
+	Sources []string `json:"sources,omitempty"`
+}
+
+// GatewayAPIConversionIssue is a construct of a converted object that has no Gateway API equivalent,
+// or whose translation changes the behavior.
+type GatewayAPIConversionIssue struct {
+	// Converted object, as Kind/name
+	// required: true
+	Source string `json:"source"`
+	// Path of the construct in the converted object
+	// example: spec/http[0]/fault
+	Path string `json:"path,omitempty"`
+	// required: true
+	Message string `json:"message"`
+}
+
+// GatewayAPIConversion is the Gateway API config equivalent to the Ingresses and Istio config of a namespace.
+// swagger:model GatewayAPIConversion
+type GatewayAPIConversion struct {
+	// required: true
+	Cluster string `json:"cluster"`
+	// required: true
+	Namespace string `json:"namespace"`
+	// Whether the objects were written to the cluster, or only converted for review
+	// required: true
+	Applied bool `json:"applied"`
+	// Gateway API objects resulting from the conversion
+	// required: true
+	Objects []GatewayAPIConversionObject `json:"objects"`
+	// Constructs that were not translated, or translated with a different behavior
+	// required: true
+	Issues []GatewayAPIConversionIssue `json:"issues"`
+	// Validations of the resulting objects, as if they were applied
+	Validations IstioValidations `json:"validations"`
+	// Whether the validations found no error
+	// required: true
+	Valid bool `json:"valid"`
+	// Changes proposed to the GitOps repository instead of being applied, in GitOps mode
+	GitOpsChanges []GitOpsChange `json:"gitOpsChanges,omitempty"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	GitOpsOperationCreate = "CREATE"
+	GitOpsOperationUpdate = "UPDATE"
+	GitOpsOperationDelete = "DELETE"
+)
+
+// GitOpsChange is a change of an Istio object proposed to the GitOps repository instead of being applied to the cluster.
+// swagger:model GitOpsChange
+type GitOpsChange struct {
+	// Operation proposed: CREATE, UPDATE or DELETE
+	// example: UPDATE
+	Operation string `json:"operation"`
+	// Cluster of the object
+	Cluster string `json:"cluster"`
+	// Namespace of the object
+	Namespace string `json:"namespace"`
+	// Name of the object
+	Name string `json:"name"`
+	// GroupVersionKind of the object
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	// Branch created for the change
+	// example: kiali/update-virtualservice-bookinfo-reviews-20240101120000
+	Branch string `json:"branch"`
+	// Branch the change is proposed against
+	// example: main
+	BaseBranch string `json:"baseBranch"`
+	// Commit created for the change, when known
+	Commit string `json:"commit,omitempty"`
+	// Path of the manifest in the repository
+	// example: east/bookinfo/VirtualService/reviews.yaml
+	Path string `json:"path"`
+	// Link to the change in the Git hosting service, when known
+	URL string `json:"url,omitempty"`
+	// Title of the change
+	Title string `json:"title"`
+	// Generated description of the change
+	Description string `json:"description"`
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"math"
+
 	"github.com/prometheus/common/model"
 
 	"github.com/kiali/kiali/log"
@@ -44,13 +46,39 @@ type NamespaceWorkloadHealth map[string]*WorkloadHealth
 // This is populated by the backend's health calculation logic.
 type CalculatedHealthStatus struct {
 	ErrorRatio       float64      `json:"errorRatio,omitempty"`       // Actual error ratio as percentage (0-100)
+	Reason           string       `json:"reason,omitempty"`           // Explanation of the status, when it is not healthy
+	Signal           HealthSignal `json:"signal,omitempty"`           // Signal that caused the status, when it is not healthy
 	Status           HealthStatus `json:"status"`                     // Calculated health status (Healthy, Degraded, Failure, NotReady, NA)
 	TotalRequestRate float64      `json:"totalRequestRate,omitempty"` // Total request rate (req/s) from inbound and outbound traffic
 }
 
+// SLOStatus is the state of a service level objective, over its compliance window
+type SLOStatus struct {
+	// BurnRates are the rates at which the error budget is consumed, by window (e.g. "1h"). A burn rate of 1
+	// consumes exactly the budget over the compliance window.
+	BurnRates map[string]float64 `json:"burnRates"`
+	// ErrorBudgetRemaining is the percentage of the error budget left over the compliance window. It is negative
+	// when the budget is exhausted.
+	ErrorBudgetRemaining float64 `json:"errorBudgetRemaining"`
+	// LatencyThreshold is the duration (ms) under which a request is good, for latency SLOs
+	LatencyThreshold float32 `json:"latencyThreshold,omitempty"`
+	Name             string  `json:"name"`
+	// Objective is the percentage of good requests targeted over the compliance window
+	Objective float64 `json:"objective"`
+	// Reason explains the status, when it is not healthy
+	Reason string `json:"reason,omitempty"`
+	// SLI is the percentage of good requests over the compliance window
+	SLI    float64      `json:"sli"`
+	Status HealthStatus `json:"status"`
+	Type   string       `json:"type"`
+	Window string       `json:"window"`
+}
+
 // ServiceHealth contains aggregated health from various sources, for a given service
 type ServiceHealth struct {
 	Requests RequestHealth `json:"requests"`
+	// SLOs are the states of the SLOs of the service. Populated by the health refresh.
+	SLOs []SLOStatus `json:"slos,omitempty"`
 	// Status is the calculated health status. Populated by the backend when available.
 	Status *CalculatedHealthStatus `json:"status,omitempty"`
 }
@@ -58,6 +86,8 @@ type ServiceHealth struct {
 // AppHealth contains aggregated health from various sources, for a given app
 type AppHealth struct {
 	Requests RequestHealth `json:"requests"`
+	// SLOs are the states of the SLOs of the app. Populated by the health refresh.
+	SLOs []SLOStatus `json:"slos,omitempty"`
 	// Status is the calculated health status. Populated by the backend when available.
 	Status           *CalculatedHealthStatus `json:"status,omitempty"`
 	WorkloadStatuses []*WorkloadStatus       `json:"workloadStatuses"`
@@ -99,6 +129,8 @@ func EmptyWorkloadHealth() *WorkloadHealth {
 // WorkloadHealth contains aggregated health from various sources, for a given workload
 type WorkloadHealth struct {
 	Requests RequestHealth `json:"requests"`
+	// SLOs are the states of the SLOs of the workload. Populated by the health refresh.
+	SLOs []SLOStatus `json:"slos,omitempty"`
 	// Status is the calculated health status. Populated by the backend when available.
 	Status         *CalculatedHealthStatus `json:"status,omitempty"`
 	WorkloadStatus *WorkloadStatus         `json:"workloadStatus"`
@@ -114,11 +146,30 @@ type WorkloadHealth struct {
 // - desired = 1, current = 10, available = 0 would means that a user scaled down a workload from 10 to 1
 // - but in the operaton 10 pods showed problems, so no pod is available/ready but user will see 10 pods under a workload
 type WorkloadStatus struct {
-	AvailableReplicas int32  `json:"availableReplicas"`
-	CurrentReplicas   int32  `json:"currentReplicas"`
-	DesiredReplicas   int32  `json:"desiredReplicas"`
-	Name              string `json:"name"`
-	SyncedProxies     int32  `json:"syncedProxies"`
+	AvailableReplicas int32 `json:"availableReplicas"`
+	CurrentReplicas   int32 `json:"currentReplicas"`
+	DesiredReplicas   int32 `json:"desiredReplicas"`
+	// Kubernetes are the Kubernetes signals of the workload. Populated when health_config.kubernetes is enabled.
+	Kubernetes    *KubernetesSignals `json:"kubernetes,omitempty"`
+	Name          string             `json:"name"`
+	SyncedProxies int32              `json:"syncedProxies"`
+}
+
+// KubernetesSignals are the signals of the health of a workload given by Kubernetes: the state of the containers
+// of its pods and the recent Warning Events involving the pods or the workload.
+type KubernetesSignals struct {
+	// CrashLoopContainers are the containers in CrashLoopBackOff, as pod/container
+	CrashLoopContainers []string `json:"crashLoopContainers,omitempty"`
+	// FailedProbes is the number of readiness probe failures reported by the recent Events
+	FailedProbes int32 `json:"failedProbes"`
+	// LastWarningEvent is the reason and the message of the most recent Warning Event, probe failures excluded
+	LastWarningEvent string `json:"lastWarningEvent,omitempty"`
+	// OOMKilledContainers are the containers recently OOMKilled, as pod/container
+	OOMKilledContainers []string `json:"oomKilledContainers,omitempty"`
+	// RecentRestarts is the number of restarts of the containers that restarted recently
+	RecentRestarts int32 `json:"recentRestarts"`
+	// WarningEvents is the number of recent Warning Events, probe failures excluded
+	WarningEvents int32 `json:"warningEvents"`
 }
 
 // ProxyStatus gives the sync status of the sidecar proxy.
@@ -134,14 +185,39 @@ type ProxyStatus struct {
 // RequestHealth holds several stats about recent request errors
 // - Inbound//Outbound are the rates of requests by protocol and status_code.
 // Example:   Inbound: { "http": {"200": 1.5, "400": 2.3}, "grpc": {"1": 1.2} }
+// - InboundLatency//OutboundLatency are the quantiles of the request duration in milliseconds, by protocol and
+// quantile, when latency thresholds are configured.
+// Example:   InboundLatency: { "http": {"0.95": 120.5} }
 type RequestHealth struct {
 	HealthAnnotations  map[string]string             `json:"healthAnnotations"`
 	Inbound            map[string]map[string]float64 `json:"inbound"`
+	InboundLatency     map[string]map[string]float64 `json:"inboundLatency,omitempty"`
 	Outbound           map[string]map[string]float64 `json:"outbound"`
+	OutboundLatency    map[string]map[string]float64 `json:"outboundLatency,omitempty"`
 	inboundDestination map[string]map[string]float64
 	inboundSource      map[string]map[string]float64
 }
 
+// SetLatency stores the quantile of the request duration of the provided histogram sample, for the given direction
+// ("inbound" or "outbound"). Samples without traffic (NaN) are ignored.
+func (in *RequestHealth) SetLatency(direction, quantile string, sample *model.Sample) {
+	if math.IsNaN(float64(sample.Value)) || math.IsInf(float64(sample.Value), 0) {
+		return
+	}
+	latencies := &in.InboundLatency
+	if direction == "outbound" {
+		latencies = &in.OutboundLatency
+	}
+	if *latencies == nil {
+		*latencies = make(map[string]map[string]float64)
+	}
+	protocol := string(sample.Metric["request_protocol"])
+	if _, ok := (*latencies)[protocol]; !ok {
+		(*latencies)[protocol] = make(map[string]float64)
+	}
+	(*latencies)[protocol][quantile] = float64(sample.Value)
+}
+
 // AggregateInbound adds the provided metric sample to internal inbound counters and updates error ratios
 func (in *RequestHealth) AggregateInbound(sample *model.Sample) {
 	// Samples need to be aggregated by source or destination reporter, but not accumulated both
@@ -400,3 +476,24 @@ func AggregateNamespaceStatus(
 		WorstStatus:    string(worst),
 	}
 }
+
+// PrometheusRuleGroups is the content of a Prometheus rules file.
+type PrometheusRuleGroups struct {
+	Groups []PrometheusRuleGroup `json:"groups"`
+}
+
+// PrometheusRuleGroup is a group of Prometheus recording and alerting rules.
+type PrometheusRuleGroup struct {
+	Name  string           `json:"name"`
+	Rules []PrometheusRule `json:"rules"`
+}
+
+// PrometheusRule is a Prometheus recording rule, when Record is set, or alerting rule.
+type PrometheusRule struct {
+	Alert       string            `json:"alert,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Expr        string            `json:"expr"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Record      string            `json:"record,omitempty"`
+}
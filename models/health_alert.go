@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// HealthAlertState is whether a health alert fires or is resolved
+type HealthAlertState string
+
+const (
+	HealthAlertStateFiring   HealthAlertState = "firing"
+	HealthAlertStateResolved HealthAlertState = "resolved"
+)
+
+// HealthAlert is the notification of an app, service or workload becoming unhealthy, or recovering, as selected by
+// a health alert rule.
+type HealthAlert struct {
+	Cluster string `json:"cluster"`
+	// EndsAt is when the entity recovered, for resolved alerts
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+	Name      string     `json:"name"`
+	Namespace string     `json:"namespace"`
+	// Reason explains the status, for firing alerts
+	Reason string `json:"reason,omitempty"`
+	// Rule is the name of the health alert rule
+	Rule string `json:"rule"`
+	// Severity is critical for the Failure status, warning otherwise
+	Severity string       `json:"severity"`
+	Signal   HealthSignal `json:"signal,omitempty"`
+	// StartsAt is when the entity became unhealthy
+	StartsAt time.Time        `json:"startsAt"`
+	State    HealthAlertState `json:"state"`
+	// Status is the health status of the entity when the alert was notified
+	Status HealthStatus `json:"status"`
+	// Type is app, service or workload
+	Type string `json:"type"`
+}
+
+// HealthAlertNotification is the body posted to the webhook health alert receivers.
+type HealthAlertNotification struct {
+	Alerts []HealthAlert `json:"alerts"`
+}
@@ -6,10 +6,11 @@ type AnnotationKey string
 const (
 	AllHealthAnnotation  AnnotationKey = ".*"
 	RateHealthAnnotation AnnotationKey = "health.kiali.io/rate"
+	SLOHealthAnnotation  AnnotationKey = "health.kiali.io/slo"
 )
 
 func GetHealthConfigAnnotation() []AnnotationKey {
-	return []AnnotationKey{RateHealthAnnotation}
+	return []AnnotationKey{RateHealthAnnotation, SLOHealthAnnotation}
 }
 
 func GetHealthAnnotation(annotations map[string]string, filters []AnnotationKey) map[string]string {
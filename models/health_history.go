@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// HealthTransition is a change of the health status of an entity, or of the signal that caused it.
+type HealthTransition struct {
+	// DurationSeconds is how long the status lasted: until the next transition or, for the current status,
+	// until the entity was last seen.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// Reason explains the status, when it is not healthy
+	Reason    string       `json:"reason,omitempty"`
+	Signal    HealthSignal `json:"signal,omitempty"`
+	Status    HealthStatus `json:"status"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// HealthEntityTimeline is the history of the health transitions of an app, service or workload.
+type HealthEntityTimeline struct {
+	// LastSeen is when the health of the entity was last computed by a health refresh
+	LastSeen time.Time `json:"lastSeen"`
+	Name     string    `json:"name"`
+	// Transitions are ordered oldest first. The last one is the current status.
+	Transitions []HealthTransition `json:"transitions"`
+	// Type is app, service or workload
+	Type string `json:"type"`
+}
+
+// NamespaceHealthHistory holds the health timelines of the entities of a namespace, by HealthHistoryEntityKey.
+type NamespaceHealthHistory map[string]*HealthEntityTimeline
+
+// HealthHistoryEntityKey returns the key of the timeline of an entity of a type in a NamespaceHealthHistory.
+func HealthHistoryEntityKey(healthType, name string) string {
+	return healthType + "/" + name
+}
+
+// HealthTimeline is the health history of the entities of a namespace.
+type HealthTimeline struct {
+	Cluster   string                 `json:"cluster"`
+	Entities  []HealthEntityTimeline `json:"entities"`
+	Namespace string                 `json:"namespace"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "fmt"
+
 // HealthStatus represents the calculated health status of an entity
 type HealthStatus string
 
@@ -11,6 +13,18 @@ const (
 	HealthStatusNA       HealthStatus = "NA"
 )
 
+// HealthSignal identifies the signal a health status was calculated from
+type HealthSignal string
+
+const (
+	HealthSignalControlPlane   HealthSignal = "controlPlane"
+	HealthSignalErrorRate      HealthSignal = "errorRate"
+	HealthSignalKubernetes     HealthSignal = "kubernetes"
+	HealthSignalLatency        HealthSignal = "latency"
+	HealthSignalSLO            HealthSignal = "slo"
+	HealthSignalWorkloadStatus HealthSignal = "workloadStatus"
+)
+
 // HealthStatusPriority returns the priority of a health status (higher = worse)
 func HealthStatusPriority(status HealthStatus) int {
 	switch status {
@@ -82,6 +96,19 @@ func WorkloadStatusHealth(ws *WorkloadStatus) HealthStatus {
 	return HealthStatusDegraded
 }
 
+// WorkloadStatusReason explains the health status calculated from workload replica information
+func WorkloadStatusReason(ws *WorkloadStatus) string {
+	if ws == nil {
+		return ""
+	}
+	reason := fmt.Sprintf("workload [%s] has %d desired, %d current and %d available replicas",
+		ws.Name, ws.DesiredReplicas, ws.CurrentReplicas, ws.AvailableReplicas)
+	if ws.SyncedProxies >= 0 {
+		reason += fmt.Sprintf(", %d synced proxies", ws.SyncedProxies)
+	}
+	return reason
+}
+
 // GetErrorRatio returns the overall error ratio from request health
 // Returns -1 if no data is available
 func (r RequestHealth) GetErrorRatio() float64 {
@@ -29,10 +29,12 @@ func (i *IstioConfigList) ObjectIgnoreValidations(cluster string) ObjectIgnoreVa
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.DestinationRules), kubernetes.DestinationRules, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.EnvoyFilters), kubernetes.EnvoyFilters, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.Gateways), kubernetes.Gateways, cluster)
+	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sBackendTLSPolicies), kubernetes.K8sBackendTLSPolicies, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sGateways), kubernetes.K8sGateways, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sGRPCRoutes), kubernetes.K8sGRPCRoutes, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sHTTPRoutes), kubernetes.K8sHTTPRoutes, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sInferencePools), kubernetes.K8sInferencePools, cluster)
+	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sListenerSets), kubernetes.K8sListenerSets, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sReferenceGrants), kubernetes.K8sReferenceGrants, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sTCPRoutes), kubernetes.K8sTCPRoutes, cluster)
 	addObjectIgnoreValidationsFromObjects(rules, asClientObjects(i.K8sTLSRoutes), kubernetes.K8sTLSRoutes, cluster)
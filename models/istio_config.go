@@ -33,14 +33,16 @@ type IstioConfigList struct {
 	WorkloadEntries   []*networking_v1.WorkloadEntry          `json:"-"`
 	WorkloadGroups    []*networking_v1.WorkloadGroup          `json:"-"`
 
-	K8sGateways        []*k8s_networking_v1.Gateway             `json:"-"`
-	K8sGRPCRoutes      []*k8s_networking_v1.GRPCRoute           `json:"-"`
-	K8sHTTPRoutes      []*k8s_networking_v1.HTTPRoute           `json:"-"`
-	K8sInferencePools  []*k8s_inference_v1.InferencePool        `json:"-"`
-	K8sReferenceGrants []*k8s_networking_v1beta1.ReferenceGrant `json:"-"`
-	K8sTCPRoutes       []*k8s_networking_v1.TCPRoute            `json:"-"`
-	K8sTLSRoutes       []*k8s_networking_v1.TLSRoute            `json:"-"`
-	K8sUDPRoutes       []*k8s_networking_v1.UDPRoute            `json:"-"`
+	K8sBackendTLSPolicies []*k8s_networking_v1.BackendTLSPolicy    `json:"-"`
+	K8sGateways           []*k8s_networking_v1.Gateway             `json:"-"`
+	K8sGRPCRoutes         []*k8s_networking_v1.GRPCRoute           `json:"-"`
+	K8sHTTPRoutes         []*k8s_networking_v1.HTTPRoute           `json:"-"`
+	K8sInferencePools     []*k8s_inference_v1.InferencePool        `json:"-"`
+	K8sListenerSets       []*k8s_networking_v1.ListenerSet         `json:"-"`
+	K8sReferenceGrants    []*k8s_networking_v1beta1.ReferenceGrant `json:"-"`
+	K8sTCPRoutes          []*k8s_networking_v1.TCPRoute            `json:"-"`
+	K8sTLSRoutes          []*k8s_networking_v1.TLSRoute            `json:"-"`
+	K8sUDPRoutes          []*k8s_networking_v1.UDPRoute            `json:"-"`
 
 	AuthorizationPolicies  []*security_v1.AuthorizationPolicy   `json:"-"`
 	PeerAuthentications    []*security_v1.PeerAuthentication    `json:"-"`
@@ -51,33 +53,11 @@ type IstioConfigList struct {
 // Namespaces returns the unique set of namespace names across all config objects.
 func (i *IstioConfigList) Namespaces() []string {
 	seen := make(map[string]struct{})
-	add := func(objs []client.Object) {
+	for _, objs := range i.Objects() {
 		for _, o := range objs {
 			seen[o.GetNamespace()] = struct{}{}
 		}
 	}
-	add(asClientObjects(i.AuthorizationPolicies))
-	add(asClientObjects(i.DestinationRules))
-	add(asClientObjects(i.EnvoyFilters))
-	add(asClientObjects(i.Gateways))
-	add(asClientObjects(i.K8sGateways))
-	add(asClientObjects(i.K8sGRPCRoutes))
-	add(asClientObjects(i.K8sHTTPRoutes))
-	add(asClientObjects(i.K8sInferencePools))
-	add(asClientObjects(i.K8sReferenceGrants))
-	add(asClientObjects(i.K8sTCPRoutes))
-	add(asClientObjects(i.K8sTLSRoutes))
-	add(asClientObjects(i.K8sUDPRoutes))
-	add(asClientObjects(i.PeerAuthentications))
-	add(asClientObjects(i.RequestAuthentications))
-	add(asClientObjects(i.ServiceEntries))
-	add(asClientObjects(i.Sidecars))
-	add(asClientObjects(i.Telemetries))
-	add(asClientObjects(i.TrafficExtensions))
-	add(asClientObjects(i.VirtualServices))
-	add(asClientObjects(i.WasmPlugins))
-	add(asClientObjects(i.WorkloadEntries))
-	add(asClientObjects(i.WorkloadGroups))
 
 	result := make([]string, 0, len(seen))
 	for ns := range seen {
@@ -86,6 +66,157 @@ func (i *IstioConfigList) Namespaces() []string {
 	return result
 }
 
+// Objects returns the config objects of the list by GroupVersionKind.
+func (i *IstioConfigList) Objects() map[schema.GroupVersionKind][]client.Object {
+	return map[schema.GroupVersionKind][]client.Object{
+		kubernetes.AuthorizationPolicies:  asClientObjects(i.AuthorizationPolicies),
+		kubernetes.DestinationRules:       asClientObjects(i.DestinationRules),
+		kubernetes.EnvoyFilters:           asClientObjects(i.EnvoyFilters),
+		kubernetes.Gateways:               asClientObjects(i.Gateways),
+		kubernetes.K8sBackendTLSPolicies:  asClientObjects(i.K8sBackendTLSPolicies),
+		kubernetes.K8sGateways:            asClientObjects(i.K8sGateways),
+		kubernetes.K8sGRPCRoutes:          asClientObjects(i.K8sGRPCRoutes),
+		kubernetes.K8sHTTPRoutes:          asClientObjects(i.K8sHTTPRoutes),
+		kubernetes.K8sInferencePools:      asClientObjects(i.K8sInferencePools),
+		kubernetes.K8sListenerSets:        asClientObjects(i.K8sListenerSets),
+		kubernetes.K8sReferenceGrants:     asClientObjects(i.K8sReferenceGrants),
+		kubernetes.K8sTCPRoutes:           asClientObjects(i.K8sTCPRoutes),
+		kubernetes.K8sTLSRoutes:           asClientObjects(i.K8sTLSRoutes),
+		kubernetes.K8sUDPRoutes:           asClientObjects(i.K8sUDPRoutes),
+		kubernetes.PeerAuthentications:    asClientObjects(i.PeerAuthentications),
+		kubernetes.RequestAuthentications: asClientObjects(i.RequestAuthentications),
+		kubernetes.ServiceEntries:         asClientObjects(i.ServiceEntries),
+		kubernetes.Sidecars:               asClientObjects(i.Sidecars),
+		kubernetes.Telemetries:            asClientObjects(i.Telemetries),
+		kubernetes.TrafficExtensions:      asClientObjects(i.TrafficExtensions),
+		kubernetes.VirtualServices:        asClientObjects(i.VirtualServices),
+		kubernetes.WasmPlugins:            asClientObjects(i.WasmPlugins),
+		kubernetes.WorkloadEntries:        asClientObjects(i.WorkloadEntries),
+		kubernetes.WorkloadGroups:         asClientObjects(i.WorkloadGroups),
+	}
+}
+
+// Add appends an object to the list of its type. It returns false when the type is not part of the list.
+func (i *IstioConfigList) Add(obj client.Object) bool {
+	switch o := obj.(type) {
+	case *security_v1.AuthorizationPolicy:
+		i.AuthorizationPolicies = append(i.AuthorizationPolicies, o)
+	case *networking_v1.DestinationRule:
+		i.DestinationRules = append(i.DestinationRules, o)
+	case *networking_v1alpha3.EnvoyFilter:
+		i.EnvoyFilters = append(i.EnvoyFilters, o)
+	case *networking_v1.Gateway:
+		i.Gateways = append(i.Gateways, o)
+	case *k8s_networking_v1.BackendTLSPolicy:
+		i.K8sBackendTLSPolicies = append(i.K8sBackendTLSPolicies, o)
+	case *k8s_networking_v1.Gateway:
+		i.K8sGateways = append(i.K8sGateways, o)
+	case *k8s_networking_v1.GRPCRoute:
+		i.K8sGRPCRoutes = append(i.K8sGRPCRoutes, o)
+	case *k8s_networking_v1.HTTPRoute:
+		i.K8sHTTPRoutes = append(i.K8sHTTPRoutes, o)
+	case *k8s_inference_v1.InferencePool:
+		i.K8sInferencePools = append(i.K8sInferencePools, o)
+	case *k8s_networking_v1.ListenerSet:
+		i.K8sListenerSets = append(i.K8sListenerSets, o)
+	case *k8s_networking_v1beta1.ReferenceGrant:
+		i.K8sReferenceGrants = append(i.K8sReferenceGrants, o)
+	case *k8s_networking_v1.TCPRoute:
+		i.K8sTCPRoutes = append(i.K8sTCPRoutes, o)
+	case *k8s_networking_v1.TLSRoute:
+		i.K8sTLSRoutes = append(i.K8sTLSRoutes, o)
+	case *k8s_networking_v1.UDPRoute:
+		i.K8sUDPRoutes = append(i.K8sUDPRoutes, o)
+	case *security_v1.PeerAuthentication:
+		i.PeerAuthentications = append(i.PeerAuthentications, o)
+	case *security_v1.RequestAuthentication:
+		i.RequestAuthentications = append(i.RequestAuthentications, o)
+	case *networking_v1.ServiceEntry:
+		i.ServiceEntries = append(i.ServiceEntries, o)
+	case *networking_v1.Sidecar:
+		i.Sidecars = append(i.Sidecars, o)
+	case *telemetry_v1.Telemetry:
+		i.Telemetries = append(i.Telemetries, o)
+	case *extentions_v1alpha1.TrafficExtension:
+		i.TrafficExtensions = append(i.TrafficExtensions, o)
+	case *networking_v1.VirtualService:
+		i.VirtualServices = append(i.VirtualServices, o)
+	case *extentions_v1alpha1.WasmPlugin:
+		i.WasmPlugins = append(i.WasmPlugins, o)
+	case *networking_v1.WorkloadEntry:
+		i.WorkloadEntries = append(i.WorkloadEntries, o)
+	case *networking_v1.WorkloadGroup:
+		i.WorkloadGroups = append(i.WorkloadGroups, o)
+	default:
+		return false
+	}
+	return true
+}
+
+// Remove removes an object from the list of its type.
+func (i *IstioConfigList) Remove(gvk schema.GroupVersionKind, namespace, name string) {
+	switch gvk {
+	case kubernetes.AuthorizationPolicies:
+		i.AuthorizationPolicies = removeObject(i.AuthorizationPolicies, namespace, name)
+	case kubernetes.DestinationRules:
+		i.DestinationRules = removeObject(i.DestinationRules, namespace, name)
+	case kubernetes.EnvoyFilters:
+		i.EnvoyFilters = removeObject(i.EnvoyFilters, namespace, name)
+	case kubernetes.Gateways:
+		i.Gateways = removeObject(i.Gateways, namespace, name)
+	case kubernetes.K8sBackendTLSPolicies:
+		i.K8sBackendTLSPolicies = removeObject(i.K8sBackendTLSPolicies, namespace, name)
+	case kubernetes.K8sGateways:
+		i.K8sGateways = removeObject(i.K8sGateways, namespace, name)
+	case kubernetes.K8sGRPCRoutes:
+		i.K8sGRPCRoutes = removeObject(i.K8sGRPCRoutes, namespace, name)
+	case kubernetes.K8sHTTPRoutes:
+		i.K8sHTTPRoutes = removeObject(i.K8sHTTPRoutes, namespace, name)
+	case kubernetes.K8sInferencePools:
+		i.K8sInferencePools = removeObject(i.K8sInferencePools, namespace, name)
+	case kubernetes.K8sListenerSets:
+		i.K8sListenerSets = removeObject(i.K8sListenerSets, namespace, name)
+	case kubernetes.K8sReferenceGrants:
+		i.K8sReferenceGrants = removeObject(i.K8sReferenceGrants, namespace, name)
+	case kubernetes.K8sTCPRoutes:
+		i.K8sTCPRoutes = removeObject(i.K8sTCPRoutes, namespace, name)
+	case kubernetes.K8sTLSRoutes:
+		i.K8sTLSRoutes = removeObject(i.K8sTLSRoutes, namespace, name)
+	case kubernetes.K8sUDPRoutes:
+		i.K8sUDPRoutes = removeObject(i.K8sUDPRoutes, namespace, name)
+	case kubernetes.PeerAuthentications:
+		i.PeerAuthentications = removeObject(i.PeerAuthentications, namespace, name)
+	case kubernetes.RequestAuthentications:
+		i.RequestAuthentications = removeObject(i.RequestAuthentications, namespace, name)
+	case kubernetes.ServiceEntries:
+		i.ServiceEntries = removeObject(i.ServiceEntries, namespace, name)
+	case kubernetes.Sidecars:
+		i.Sidecars = removeObject(i.Sidecars, namespace, name)
+	case kubernetes.Telemetries:
+		i.Telemetries = removeObject(i.Telemetries, namespace, name)
+	case kubernetes.TrafficExtensions:
+		i.TrafficExtensions = removeObject(i.TrafficExtensions, namespace, name)
+	case kubernetes.VirtualServices:
+		i.VirtualServices = removeObject(i.VirtualServices, namespace, name)
+	case kubernetes.WasmPlugins:
+		i.WasmPlugins = removeObject(i.WasmPlugins, namespace, name)
+	case kubernetes.WorkloadEntries:
+		i.WorkloadEntries = removeObject(i.WorkloadEntries, namespace, name)
+	case kubernetes.WorkloadGroups:
+		i.WorkloadGroups = removeObject(i.WorkloadGroups, namespace, name)
+	}
+}
+
+func removeObject[T client.Object](objects []T, namespace, name string) []T {
+	result := make([]T, 0, len(objects))
+	for _, o := range objects {
+		if o.GetNamespace() != namespace || o.GetName() != name {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
 func asClientObjects[T client.Object](slice []T) []client.Object {
 	out := make([]client.Object, len(slice))
 	for i, o := range slice {
@@ -111,10 +242,12 @@ func (i IstioConfigList) MarshalJSON() ([]byte, error) {
 	resources[kubernetes.TrafficExtensions.String()] = i.TrafficExtensions
 	resources[kubernetes.WasmPlugins.String()] = i.WasmPlugins
 	resources[kubernetes.Telemetries.String()] = i.Telemetries
+	resources[kubernetes.K8sBackendTLSPolicies.String()] = i.K8sBackendTLSPolicies
 	resources[kubernetes.K8sGateways.String()] = i.K8sGateways
 	resources[kubernetes.K8sGRPCRoutes.String()] = i.K8sGRPCRoutes
 	resources[kubernetes.K8sHTTPRoutes.String()] = i.K8sHTTPRoutes
 	resources[kubernetes.K8sInferencePools.String()] = i.K8sInferencePools
+	resources[kubernetes.K8sListenerSets.String()] = i.K8sListenerSets
 	resources[kubernetes.K8sReferenceGrants.String()] = i.K8sReferenceGrants
 	resources[kubernetes.K8sTCPRoutes.String()] = i.K8sTCPRoutes
 	resources[kubernetes.K8sTLSRoutes.String()] = i.K8sTLSRoutes
@@ -193,6 +326,10 @@ func (i *IstioConfigList) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(rawMessage, &i.Telemetries); err != nil {
 				return err
 			}
+		case kubernetes.K8sBackendTLSPolicies.String():
+			if err := json.Unmarshal(rawMessage, &i.K8sBackendTLSPolicies); err != nil {
+				return err
+			}
 		case kubernetes.K8sGateways.String():
 			if err := json.Unmarshal(rawMessage, &i.K8sGateways); err != nil {
 				return err
@@ -209,6 +346,10 @@ func (i *IstioConfigList) UnmarshalJSON(data []byte) error {
 			if err := json.Unmarshal(rawMessage, &i.K8sInferencePools); err != nil {
 				return err
 			}
+		case kubernetes.K8sListenerSets.String():
+			if err := json.Unmarshal(rawMessage, &i.K8sListenerSets); err != nil {
+				return err
+			}
 		case kubernetes.K8sReferenceGrants.String():
 			if err := json.Unmarshal(rawMessage, &i.K8sReferenceGrants); err != nil {
 				return err
@@ -286,6 +427,9 @@ func (i *IstioConfigList) ConvertToResponse() {
 		i.Telemetries = []*telemetry_v1.Telemetry{}
 	}
 
+	if i.K8sBackendTLSPolicies == nil {
+		i.K8sBackendTLSPolicies = []*k8s_networking_v1.BackendTLSPolicy{}
+	}
 	if i.K8sGateways == nil {
 		i.K8sGateways = []*k8s_networking_v1.Gateway{}
 	}
@@ -298,6 +442,9 @@ func (i *IstioConfigList) ConvertToResponse() {
 	if i.K8sInferencePools == nil {
 		i.K8sInferencePools = []*k8s_inference_v1.InferencePool{}
 	}
+	if i.K8sListenerSets == nil {
+		i.K8sListenerSets = []*k8s_networking_v1.ListenerSet{}
+	}
 	if i.K8sReferenceGrants == nil {
 		i.K8sReferenceGrants = []*k8s_networking_v1beta1.ReferenceGrant{}
 	}
@@ -346,14 +493,16 @@ type IstioConfigDetails struct {
 	WorkloadEntry         *networking_v1.WorkloadEntry          `json:"-"`
 	WorkloadGroup         *networking_v1.WorkloadGroup          `json:"-"`
 
-	K8sGateway        *k8s_networking_v1.Gateway             `json:"-"`
-	K8sGRPCRoute      *k8s_networking_v1.GRPCRoute           `json:"-"`
-	K8sHTTPRoute      *k8s_networking_v1.HTTPRoute           `json:"-"`
-	K8sInferencePool  *k8s_inference_v1.InferencePool        `json:"-"`
-	K8sReferenceGrant *k8s_networking_v1beta1.ReferenceGrant `json:"-"`
-	K8sTCPRoute       *k8s_networking_v1.TCPRoute            `json:"-"`
-	K8sTLSRoute       *k8s_networking_v1.TLSRoute            `json:"-"`
-	K8sUDPRoute       *k8s_networking_v1.UDPRoute            `json:"-"`
+	K8sBackendTLSPolicy *k8s_networking_v1.BackendTLSPolicy    `json:"-"`
+	K8sGateway          *k8s_networking_v1.Gateway             `json:"-"`
+	K8sGRPCRoute        *k8s_networking_v1.GRPCRoute           `json:"-"`
+	K8sHTTPRoute        *k8s_networking_v1.HTTPRoute           `json:"-"`
+	K8sInferencePool    *k8s_inference_v1.InferencePool        `json:"-"`
+	K8sListenerSet      *k8s_networking_v1.ListenerSet         `json:"-"`
+	K8sReferenceGrant   *k8s_networking_v1beta1.ReferenceGrant `json:"-"`
+	K8sTCPRoute         *k8s_networking_v1.TCPRoute            `json:"-"`
+	K8sTLSRoute         *k8s_networking_v1.TLSRoute            `json:"-"`
+	K8sUDPRoute         *k8s_networking_v1.UDPRoute            `json:"-"`
 
 	Permissions           ResourcePermissions `json:"-"`
 	IstioValidation       *IstioValidation    `json:"-"`
@@ -396,6 +545,8 @@ func (i IstioConfigDetails) MarshalJSON() ([]byte, error) {
 		resource = i.WasmPlugin
 	} else if i.Telemetry != nil {
 		resource = i.Telemetry
+	} else if i.K8sBackendTLSPolicy != nil {
+		resource = i.K8sBackendTLSPolicy
 	} else if i.K8sGateway != nil {
 		resource = i.K8sGateway
 	} else if i.K8sGRPCRoute != nil {
@@ -404,6 +555,8 @@ func (i IstioConfigDetails) MarshalJSON() ([]byte, error) {
 		resource = i.K8sHTTPRoute
 	} else if i.K8sInferencePool != nil {
 		resource = i.K8sInferencePool
+	} else if i.K8sListenerSet != nil {
+		resource = i.K8sListenerSet
 	} else if i.K8sReferenceGrant != nil {
 		resource = i.K8sReferenceGrant
 	} else if i.K8sTCPRoute != nil {
@@ -552,6 +705,13 @@ func (icd *IstioConfigDetails) UnmarshalJSON(data []byte) error {
 		}
 		icd.Telemetry = &tm
 
+	case kubernetes.K8sBackendTLSPolicies:
+		var policy k8s_networking_v1.BackendTLSPolicy
+		if err := json.Unmarshal(temp.Resource, &policy); err != nil {
+			return err
+		}
+		icd.K8sBackendTLSPolicy = &policy
+
 	case kubernetes.K8sGateways:
 		var kg k8s_networking_v1.Gateway
 		if err := json.Unmarshal(temp.Resource, &kg); err != nil {
@@ -580,6 +740,13 @@ func (icd *IstioConfigDetails) UnmarshalJSON(data []byte) error {
 		}
 		icd.K8sInferencePool = &inferencePool
 
+	case kubernetes.K8sListenerSets:
+		var listenerSet k8s_networking_v1.ListenerSet
+		if err := json.Unmarshal(temp.Resource, &listenerSet); err != nil {
+			return err
+		}
+		icd.K8sListenerSet = &listenerSet
+
 	case kubernetes.K8sReferenceGrants:
 		var refGrant k8s_networking_v1beta1.ReferenceGrant
 		if err := json.Unmarshal(temp.Resource, &refGrant); err != nil {
@@ -729,6 +896,11 @@ var IstioConfigHelpMessages = map[string][]IstioConfigHelp{
 	kubernetes.Telemetries.String(): { // TODO
 		{},
 	},
+	kubernetes.K8sBackendTLSPolicies.String(): {
+		{ObjectField: "spec", Message: "Kubernetes Gateway API Configuration Object. BackendTLSPolicy configures how a Gateway connects to a backend via TLS."},
+		{ObjectField: "spec.targetRefs", Message: "Identify the Services this policy applies to."},
+		{ObjectField: "spec.validation", Message: "Define the CA certificates and hostname used to validate the backend certificate."},
+	},
 	kubernetes.K8sGateways.String(): {
 		{ObjectField: "spec", Message: "Kubernetes Gateway API Configuration Object. A Gateway describes how traffic can be translated to Services within the cluster."},
 		{ObjectField: "spec.gatewayClassName", Message: "Defines the name of a GatewayClass object used by this Gateway."},
@@ -744,6 +916,11 @@ var IstioConfigHelpMessages = map[string][]IstioConfigHelp{
 	kubernetes.K8sInferencePools.String(): { // TODO
 		{ObjectField: "", Message: "Kubernetes Gateway API Inference Extension Configuration Object. InferencePool defines a group of pods dedicated to serving AI models."},
 	},
+	kubernetes.K8sListenerSets.String(): {
+		{ObjectField: "spec", Message: "Kubernetes Gateway API Configuration Object. ListenerSet defines a set of additional listeners to attach to an existing Gateway."},
+		{ObjectField: "spec.parentRef", Message: "Define the Gateway this ListenerSet is attached to."},
+		{ObjectField: "spec.listeners", Message: "Define the hostnames, ports, protocol, termination, TLS settings and which routes can be attached to a listener."},
+	},
 	kubernetes.K8sReferenceGrants.String(): {
 		{ObjectField: "spec", Message: "Kubernetes Gateway API Configuration Object. ReferenceGrant is for enabling cross namespace references within Gateway API."},
 		{ObjectField: "spec.from", Message: "Define the group, kind, and namespace of resources that may reference items described in the to list."},
@@ -791,10 +968,12 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			filtered[ns].DestinationRules = []*networking_v1.DestinationRule{}
 			filtered[ns].EnvoyFilters = []*networking_v1alpha3.EnvoyFilter{}
 			filtered[ns].Gateways = []*networking_v1.Gateway{}
+			filtered[ns].K8sBackendTLSPolicies = []*k8s_networking_v1.BackendTLSPolicy{}
 			filtered[ns].K8sGateways = []*k8s_networking_v1.Gateway{}
 			filtered[ns].K8sGRPCRoutes = []*k8s_networking_v1.GRPCRoute{}
 			filtered[ns].K8sHTTPRoutes = []*k8s_networking_v1.HTTPRoute{}
 			filtered[ns].K8sInferencePools = []*k8s_inference_v1.InferencePool{}
+			filtered[ns].K8sListenerSets = []*k8s_networking_v1.ListenerSet{}
 			filtered[ns].K8sReferenceGrants = []*k8s_networking_v1beta1.ReferenceGrant{}
 			filtered[ns].K8sTCPRoutes = []*k8s_networking_v1.TCPRoute{}
 			filtered[ns].K8sTLSRoutes = []*k8s_networking_v1.TLSRoute{}
@@ -829,6 +1008,12 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			}
 		}
 
+		for _, policy := range configList.K8sBackendTLSPolicies {
+			if policy.Namespace == ns {
+				filtered[ns].K8sBackendTLSPolicies = append(filtered[ns].K8sBackendTLSPolicies, policy)
+			}
+		}
+
 		for _, gw := range configList.K8sGateways {
 			if gw.Namespace == ns {
 				filtered[ns].K8sGateways = append(filtered[ns].K8sGateways, gw)
@@ -853,6 +1038,12 @@ func (configList IstioConfigList) FilterIstioConfigs(nss []string) *IstioConfigs
 			}
 		}
 
+		for _, ls := range configList.K8sListenerSets {
+			if ls.Namespace == ns {
+				filtered[ns].K8sListenerSets = append(filtered[ns].K8sListenerSets, ls)
+			}
+		}
+
 		for _, rg := range configList.K8sReferenceGrants {
 			if rg.Namespace == ns {
 				filtered[ns].K8sReferenceGrants = append(filtered[ns].K8sReferenceGrants, rg)
@@ -957,10 +1148,12 @@ func (configList IstioConfigList) MergeConfigs(ns IstioConfigList) IstioConfigLi
 	configList.EnvoyFilters = append(configList.EnvoyFilters, ns.EnvoyFilters...)
 	configList.Gateways = append(configList.Gateways, ns.Gateways...)
 	configList.AuthorizationPolicies = append(configList.AuthorizationPolicies, ns.AuthorizationPolicies...)
+	configList.K8sBackendTLSPolicies = append(configList.K8sBackendTLSPolicies, ns.K8sBackendTLSPolicies...)
 	configList.K8sGateways = append(configList.K8sGateways, ns.K8sGateways...)
 	configList.K8sGRPCRoutes = append(configList.K8sGRPCRoutes, ns.K8sGRPCRoutes...)
 	configList.K8sHTTPRoutes = append(configList.K8sHTTPRoutes, ns.K8sHTTPRoutes...)
 	configList.K8sInferencePools = append(configList.K8sInferencePools, ns.K8sInferencePools...)
+	configList.K8sListenerSets = append(configList.K8sListenerSets, ns.K8sListenerSets...)
 	configList.K8sReferenceGrants = append(configList.K8sReferenceGrants, ns.K8sReferenceGrants...)
 	configList.K8sTCPRoutes = append(configList.K8sTCPRoutes, ns.K8sTCPRoutes...)
 	configList.K8sTLSRoutes = append(configList.K8sTLSRoutes, ns.K8sTLSRoutes...)
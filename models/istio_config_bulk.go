@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Bulk operations applied to all the Istio config objects matching the criteria of a request.
+const (
+	IstioConfigBulkDelete   = "delete"
+	IstioConfigBulkMetadata = "metadata"
+	IstioConfigBulkPatch    = "patch"
+)
+
+// IstioConfigBulkRequest selects Istio config objects and the operation applied to all of them.
+// swagger:model IstioConfigBulkRequest
+type IstioConfigBulkRequest struct {
+	// GroupVersionKinds of the objects (e.g. networking.istio.io/v1, Kind=VirtualService). All the types when empty.
+	Objects []string `json:"objects,omitempty"`
+	// Label selector of the objects
+	// example: app=reviews,migrated=true
+	LabelSelector string `json:"labelSelector,omitempty"`
+	// Workload selector of the objects, matched against the selector of the objects that have one
+	WorkloadSelector string `json:"workloadSelector,omitempty"`
+	// Namespaces of the objects. All the accessible namespaces when empty, which requires a label selector.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Clusters of the objects. The home cluster when empty.
+	Clusters []string `json:"clusters,omitempty"`
+	// Operation applied to the matching objects: patch, metadata or delete
+	// required: true
+	Operation string `json:"operation"`
+	// JSON merge patch applied by the patch operation
+	Patch json.RawMessage `json:"patch,omitempty"`
+	// Labels added or replaced by the metadata operation
+	Labels map[string]string `json:"labels,omitempty"`
+	// Labels removed by the metadata operation
+	RemoveLabels []string `json:"removeLabels,omitempty"`
+	// Annotations added or replaced by the metadata operation
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Annotations removed by the metadata operation
+	RemoveAnnotations []string `json:"removeAnnotations,omitempty"`
+}
+
+// IstioConfigBulkObject is the outcome of a bulk operation for one of the matching objects.
+type IstioConfigBulkObject struct {
+	// required: true
+	Cluster string `json:"cluster"`
+	// required: true
+	Namespace string `json:"namespace"`
+	// required: true
+	Name string `json:"name"`
+	// required: true
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	// Error of the operation for this object, empty when it succeeded
+	Error string `json:"error,omitempty"`
+
+	// Before and After are the object before and after the operation, to audit it
+	Before client.Object `json:"-"`
+	After  client.Object `json:"-"`
+}
+
+// IstioConfigValidationChange is a validation that differs before and after a bulk operation.
+type IstioConfigValidationChange struct {
+	// Validation of the object before the operation, nil when the object was not validated
+	Before *IstioValidation `json:"before,omitempty"`
+	// Validation of the object after the operation, nil when the object is deleted
+	After *IstioValidation `json:"after,omitempty"`
+}
+
+// IstioConfigBulkResult reports a bulk operation. The operation is independent per object:
+// it can succeed for some of them and fail for others.
+// swagger:model IstioConfigBulkResult
+type IstioConfigBulkResult struct {
+	// Operation performed: patch, metadata or delete
+	// required: true
+	Operation string `json:"operation"`
+	// The operation was only previewed, no object was changed
+	// required: true
+	DryRun bool `json:"dryRun"`
+	// Objects matching the criteria, with the outcome of the operation
+	// required: true
+	Objects []IstioConfigBulkObject `json:"objects"`
+	// Validations changed by the operation, only computed for a dry run when validations are enabled
+	ValidationChanges []IstioConfigValidationChange `json:"validationChanges,omitempty"`
+}
+
+// Failed returns the number of objects the operation failed for.
+func (r IstioConfigBulkResult) Failed() int {
+	failed := 0
+	for _, obj := range r.Objects {
+		if obj.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
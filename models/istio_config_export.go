@@ -0,0 +1,50 @@
+package models
+
+// Formats of an Istio config export.
+const (
+	IstioConfigExportHelm      = "helm"
+	IstioConfigExportKustomize = "kustomize"
+)
+
+// IstioConfigExportOptions are the options of an Istio config export.
+type IstioConfigExportOptions struct {
+	// Format of the export: helm or kustomize
+	Format string
+	// Name of the Helm chart. Defaults to the namespace.
+	ChartName string
+	// Clusters the configuration is exported to: each one gets its own values file or overlay.
+	// Defaults to the cluster the configuration is exported from.
+	Clusters []string
+}
+
+// IstioConfigExportFile is a file of an Istio config export.
+type IstioConfigExportFile struct {
+	// Path of the file, relative to the root of the export
+	// required: true
+	// example: templates/virtualservice-reviews.yaml
+	Path string `json:"path"`
+	// required: true
+	Content string `json:"content"`
+}
+
+// IstioConfigExport is the Istio config of a namespace exported as a Helm chart or a Kustomize base with
+// per-cluster overlays, ready to be applied to another environment. The metadata managed by the API server and the
+// status are stripped from the objects, and the external host names are parameters set per cluster.
+// swagger:model IstioConfigExport
+type IstioConfigExport struct {
+	// Format of the export: helm or kustomize
+	// required: true
+	Format string `json:"format"`
+	// Cluster the configuration is exported from
+	// required: true
+	Cluster string `json:"cluster"`
+	// Namespace the configuration is exported from
+	// required: true
+	Namespace string `json:"namespace"`
+	// Parameterised host names, with the value they have in the exported configuration
+	// required: true
+	Hosts map[string]string `json:"hosts"`
+	// Files of the chart or of the Kustomize base and overlays, sorted by path
+	// required: true
+	Files []IstioConfigExportFile `json:"files"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IstioConfigClusterResult is the outcome of an Istio config write operation in one of the target clusters.
+type IstioConfigClusterResult struct {
+	// Cluster the operation was performed in
+	// required: true
+	Cluster string `json:"cluster"`
+	// HTTP status of the operation in this cluster
+	// required: true
+	// example: 200
+	Status int `json:"status"`
+	// Error of the operation in this cluster, empty when it succeeded
+	Error string `json:"error,omitempty"`
+	// Object resulting from a create or update
+	Details *IstioConfigDetails `json:"details,omitempty"`
+	// Validation of the resulting object in this cluster, when validations are enabled
+	Validation *IstioValidation `json:"validation,omitempty"`
+	// Change proposed to the GitOps repository instead of being applied, in GitOps mode
+	GitOpsChange *GitOpsChange `json:"gitOpsChange,omitempty"`
+}
+
+// IstioConfigMultiClusterResult reports an Istio config write operation performed in several clusters.
+// The operation is independent per cluster: it can succeed in some of them and fail in others.
+// swagger:model IstioConfigMultiClusterResult
+type IstioConfigMultiClusterResult struct {
+	// Operation performed: CREATE, UPDATE or DELETE
+	// required: true
+	Operation string `json:"operation"`
+	// Namespace of the object
+	// required: true
+	Namespace string `json:"namespace"`
+	// Name of the object. Empty for a create that failed in every cluster.
+	Name string `json:"name"`
+	// GroupVersionKind of the object
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	// Clusters where the operation succeeded
+	// required: true
+	Succeeded []string `json:"succeeded"`
+	// Clusters where the operation failed
+	// required: true
+	Failed []string `json:"failed"`
+	// Per cluster results, in the order of the requested clusters
+	// required: true
+	Results []IstioConfigClusterResult `json:"results"`
+}
+
+// AddResult appends the result of a cluster and reports the cluster as succeeded or failed.
+func (r *IstioConfigMultiClusterResult) AddResult(result IstioConfigClusterResult) {
+	r.Results = append(r.Results, result)
+	if result.Error == "" {
+		r.Succeeded = append(r.Succeeded, result.Cluster)
+	} else {
+		r.Failed = append(r.Failed, result.Cluster)
+	}
+}
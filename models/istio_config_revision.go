@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ConfigRevisionOperationUpdate = "UPDATE"
+	ConfigRevisionOperationDelete = "DELETE"
+
+	// ConfigRevisionSourceKiali marks the revisions of objects modified or deleted through Kiali
+	ConfigRevisionSourceKiali = "kiali"
+	// ConfigRevisionSourceExternal marks the revisions of changes made by other tools, observed by the cache informers
+	ConfigRevisionSourceExternal = "external"
+)
+
+// IstioConfigRevisionKey identifies the Istio object a revision history belongs to.
+type IstioConfigRevisionKey struct {
+	Cluster   string                  `json:"cluster"`
+	Namespace string                  `json:"namespace"`
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	Name      string                  `json:"name"`
+}
+
+// IstioConfigRevision is a snapshot of an Istio object taken right before it was modified or deleted.
+type IstioConfigRevision struct {
+	// Revision number, sequential per object
+	// example: 3
+	Revision int `json:"revision"`
+	// Time the snapshot was taken
+	Timestamp time.Time `json:"timestamp"`
+	// Operation that replaced this version of the object: UPDATE or DELETE
+	Operation string `json:"operation"`
+	// Source of the change: kiali or external
+	Source string `json:"source"`
+	// ResourceVersion of the snapshotted object
+	ResourceVersion string `json:"resourceVersion"`
+	// Object as it was before the change
+	Object json.RawMessage `json:"object"`
+	// Diff is the JSON merge patch that turns this revision into the next one, or into the current object
+	// for the latest revision. A "null" diff means the object was deleted afterwards.
+	Diff json.RawMessage `json:"diff,omitempty"`
+}
+
+// IstioConfigRevisions lists the revisions of an Istio object, oldest first.
+type IstioConfigRevisions struct {
+	IstioConfigRevisionKey
+	// Current object, nil when the object does not exist anymore
+	Current   json.RawMessage       `json:"current,omitempty"`
+	Revisions []IstioConfigRevision `json:"revisions"`
+}
+
+// NewIstioConfigRevision snapshots obj. Only the fields that describe the desired state of the object
+// are kept: apiVersion, kind, name, namespace, labels, annotations and spec.
+func NewIstioConfigRevision(gvk schema.GroupVersionKind, obj client.Object, operation, source string) (IstioConfigRevision, error) {
+	raw, err := RevisionObject(gvk, obj)
+	if err != nil {
+		return IstioConfigRevision{}, err
+	}
+	return IstioConfigRevision{
+		Timestamp:       time.Now(),
+		Operation:       operation,
+		Source:          source,
+		ResourceVersion: obj.GetResourceVersion(),
+		Object:          raw,
+	}, nil
+}
+
+// RevisionObject serializes the desired state of obj, without the metadata managed by the API server nor the status.
+// The type meta is taken from gvk since typed objects read from the cache usually have an empty one.
+func RevisionObject(gvk schema.GroupVersionKind, obj client.Object) (json.RawMessage, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	full := map[string]any{}
+	if err := json.Unmarshal(raw, &full); err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]any{
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	revision := map[string]any{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata":   metadata,
+	}
+	if spec, ok := full["spec"]; ok {
+		revision["spec"] = spec
+	}
+	return json.Marshal(revision)
+}
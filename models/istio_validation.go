@@ -292,6 +292,21 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "No matching workload found for the selector in this namespace",
 		Severity: WarningSeverity,
 	},
+	"k8sbackendtlspolicies.targetref.conflict": {
+		Code:     "KIA1902",
+		Message:  "More than one BackendTLSPolicy targets the same Service port",
+		Severity: WarningSeverity,
+	},
+	"k8sbackendtlspolicies.targetref.notfound": {
+		Code:     "KIA1901",
+		Message:  "BackendTLSPolicy target Service not found",
+		Severity: ErrorSeverity,
+	},
+	"k8sgateways.certificateref.nogrant": {
+		Code:     "KIA1505",
+		Message:  "Certificate reference to another namespace is not allowed by any ReferenceGrant",
+		Severity: ErrorSeverity,
+	},
 	"k8sgateways.gatewayclassnotfound": {
 		Code:     "KIA1504",
 		Message:  "Gateway API Class not found in Kiali configuration",
@@ -312,11 +327,31 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Each listener must have a unique combination of Hostname, Port, and Protocol",
 		Severity: ErrorSeverity,
 	},
+	"k8slistenersets.listener.conflict": {
+		Code:     "KIA1802",
+		Message:  "Listener conflicts with a listener of the parent K8s gateway or another ListenerSet",
+		Severity: ErrorSeverity,
+	},
+	"k8slistenersets.nok8sgateway": {
+		Code:     "KIA1801",
+		Message:  "ListenerSet is pointing to a non-existent K8s gateway",
+		Severity: ErrorSeverity,
+	},
+	"k8slistenersets.notallowed": {
+		Code:     "KIA1803",
+		Message:  "K8s gateway doesn't allow ListenerSets from this namespace",
+		Severity: ErrorSeverity,
+	},
 	"k8sreferencegrants.from.namespacenotfound": {
 		Code:     "KIA1601",
 		Message:  "Namespace is not found or is not accessible",
 		Severity: ErrorSeverity,
 	},
+	"k8sroutes.hostname.nointersection": {
+		Code:     "KIA1404",
+		Message:  "Route hostnames don't intersect with any hostname of the parent listeners",
+		Severity: ErrorSeverity,
+	},
 	"k8sroutes.nohost.namenotfound": {
 		Code:     "KIA1402",
 		Message:  "Reference doesn't have a valid service (Service name not found)",
@@ -327,6 +362,11 @@ var checkDescriptors = map[string]IstioCheck{
 		Message:  "Route is pointing to a non-existent or inaccessible K8s gateway",
 		Severity: ErrorSeverity,
 	},
+	"k8sroutes.nok8slistener": {
+		Code:     "KIA1403",
+		Message:  "Route is pointing to a non-existent listener of the K8s gateway",
+		Severity: ErrorSeverity,
+	},
 	"peerauthentication.mtls.destinationrulemissing": {
 		Code:     "KIA0401",
 		Message:  "Mesh-wide Destination Rule enabling mTLS is missing",
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Mesh infrastructure component types
+const (
+	MeshComponentGateway  = "gateway"
+	MeshComponentIstiod   = "istiod"
+	MeshComponentWaypoint = "waypoint"
+	MeshComponentZtunnel  = "ztunnel"
+)
+
+// MeshComponentHealth is the health of a mesh infrastructure component: istiod, an ingress or egress gateway, a
+// waypoint or ztunnel.
+type MeshComponentHealth struct {
+	Cluster   string `json:"cluster"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Signals are the control plane signals, for istiod
+	Signals *ControlPlaneSignals   `json:"signals,omitempty"`
+	Status  CalculatedHealthStatus `json:"status"`
+	// Type is gateway, istiod, waypoint or ztunnel
+	Type string `json:"type"`
+	// WorkloadStatus is the status of the pods of the component, nil when its workload is not found
+	WorkloadStatus *WorkloadStatus `json:"workloadStatus,omitempty"`
+}
+
+// ControlPlaneSignals are the signals of the health of istiod. The metrics are computed over the health rate
+// interval, they are nil when Prometheus does not report them.
+type ControlPlaneSignals struct {
+	// CertExpiry is when the first of the control plane certificates expires
+	CertExpiry *time.Time `json:"certExpiry,omitempty"`
+	// ConfigRejections is the number of xDS configs rejected by the proxies
+	ConfigRejections *float64 `json:"configRejections,omitempty"`
+	// ProxyConvergence is the 99th percentile, in seconds, of the time the proxies take to converge after a config change
+	ProxyConvergence *float64 `json:"proxyConvergence,omitempty"`
+	// XdsPushErrors is the number of push context errors, internal errors and write timeouts of the xDS pushes
+	XdsPushErrors *float64 `json:"xdsPushErrors,omitempty"`
+	// XdsPushLatency is the 99th percentile, in seconds, of the xDS push time
+	XdsPushLatency *float64 `json:"xdsPushLatency,omitempty"`
+}
@@ -50,13 +50,20 @@ type Reference struct {
 	Kind string `json:"kind"`
 }
 
-// ContainerInfo holds container name and image
+// ContainerInfo holds container name, image and status
 type ContainerInfo struct {
 	Name      string `json:"name"`
 	Image     string `json:"image"`
 	IsProxy   bool   `json:"isProxy"`
 	IsReady   bool   `json:"isReady"`
 	IsAmbient bool   `json:"isAmbient"`
+	// LastTerminatedAt is when the previous instance of the container terminated, when it was restarted
+	LastTerminatedAt string `json:"lastTerminatedAt,omitempty"`
+	// LastTerminationReason is why the previous instance of the container terminated (e.g. OOMKilled)
+	LastTerminationReason string `json:"lastTerminationReason,omitempty"`
+	RestartCount          int32  `json:"restartCount"`
+	// WaitingReason is why the container is waiting to run (e.g. CrashLoopBackOff)
+	WaitingReason string `json:"waitingReason,omitempty"`
 }
 
 // Parse extracts desired information from k8s []Pod info
@@ -102,8 +109,8 @@ func (pod *Pod) Parse(p *core_v1.Pod, isControlPlane func(ctx context.Context, c
 					Name:    name,
 					Image:   lookupImage(name, p.Spec.InitContainers),
 					IsProxy: true,
-					IsReady: lookupReady(name, p.Status.InitContainerStatuses),
 				}
+				container.parseStatus(p.Status.InitContainerStatuses)
 				pod.IstioInitContainers = append(pod.IstioInitContainers, &container)
 				istioContainerNames[name] = true
 			}
@@ -112,8 +119,8 @@ func (pod *Pod) Parse(p *core_v1.Pod, isControlPlane func(ctx context.Context, c
 					Name:    name,
 					Image:   lookupImage(name, p.Spec.Containers),
 					IsProxy: true,
-					IsReady: lookupReady(name, p.Status.ContainerStatuses),
 				}
+				container.parseStatus(p.Status.ContainerStatuses)
 				pod.IstioContainers = append(pod.IstioContainers, &container)
 				istioContainerNames[name] = true
 			}
@@ -127,9 +134,9 @@ func (pod *Pod) Parse(p *core_v1.Pod, isControlPlane func(ctx context.Context, c
 			Name:      c.Name,
 			Image:     c.Image,
 			IsProxy:   isIstioProxy(p, &c, *conf, isControlPlane),
-			IsReady:   lookupReady(c.Name, p.Status.ContainerStatuses),
 			IsAmbient: isIstioAmbient(p),
 		}
+		container.parseStatus(p.Status.ContainerStatuses)
 		pod.Containers = append(pod.Containers, &container)
 	}
 	pod.Status = string(p.Status.Phase)
@@ -172,13 +179,25 @@ func lookupImage(containerName string, containers []core_v1.Container) string {
 	return ""
 }
 
-func lookupReady(containerName string, statuses []core_v1.ContainerStatus) bool {
+// parseStatus sets the readiness, the restarts and the state of the container from the status of the pod.
+func (container *ContainerInfo) parseStatus(statuses []core_v1.ContainerStatus) {
 	for _, s := range statuses {
-		if s.Name == containerName {
-			return s.Ready
+		if s.Name != container.Name {
+			continue
+		}
+		container.IsReady = s.Ready
+		container.RestartCount = s.RestartCount
+		if s.State.Waiting != nil {
+			container.WaitingReason = s.State.Waiting.Reason
+		}
+		if terminated := s.LastTerminationState.Terminated; terminated != nil {
+			container.LastTerminationReason = terminated.Reason
+			if !terminated.FinishedAt.IsZero() {
+				container.LastTerminatedAt = formatTime(terminated.FinishedAt.Time)
+			}
 		}
+		return
 	}
-	return false
 }
 
 // HasIstioSidecar returns true if there are no pods or all pods have a sidecar
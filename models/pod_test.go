@@ -55,6 +55,40 @@ func TestPodFullyParsing(t *testing.T) {
 	assert.Equal("alpine", pod.IstioInitContainers[1].Image)
 }
 
+func TestPodParsingContainerStatuses(t *testing.T) {
+	assert := assert.New(t)
+	config.Set(config.NewConfig())
+	finishedAt := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	k8sPod := core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1-7d9f8c-abcde"},
+		Spec: core_v1.PodSpec{
+			Containers: []core_v1.Container{{Name: "reviews", Image: "reviews:1.0"}},
+		},
+		Status: core_v1.PodStatus{
+			ContainerStatuses: []core_v1.ContainerStatus{{
+				Name:         "reviews",
+				RestartCount: 7,
+				State: core_v1.ContainerState{
+					Waiting: &core_v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+				LastTerminationState: core_v1.ContainerState{
+					Terminated: &core_v1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: meta_v1.NewTime(finishedAt)},
+				},
+			}},
+		},
+	}
+
+	pod := Pod{}
+	pod.Parse(&k8sPod, fakeIsControlPlane)
+	assert.Len(pod.Containers, 1)
+	container := pod.Containers[0]
+	assert.False(container.IsReady)
+	assert.Equal(int32(7), container.RestartCount)
+	assert.Equal("CrashLoopBackOff", container.WaitingReason)
+	assert.Equal("OOMKilled", container.LastTerminationReason)
+	assert.Equal("2026-01-01T10:00:00Z", container.LastTerminatedAt)
+}
+
 func TestPodParsingMissingImage(t *testing.T) {
 	assert := assert.New(t)
 	config.Set(config.NewConfig())
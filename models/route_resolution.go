@@ -0,0 +1,152 @@
+package models
+
+const (
+	// RouteSourceVirtualService is used when the route is defined by an Istio VirtualService.
+	RouteSourceVirtualService = "VirtualService"
+	// RouteSourceHTTPRoute is used when the route is defined by a Gateway API HTTPRoute attached to the service.
+	RouteSourceHTTPRoute = "HTTPRoute"
+	// RouteSourceGRPCRoute is used when the route is defined by a Gateway API GRPCRoute attached to the service.
+	RouteSourceGRPCRoute = "GRPCRoute"
+	// RouteSourceDefault is used when no routing object applies and traffic goes to the host unchanged.
+	RouteSourceDefault = "Default"
+)
+
+const (
+	// RouteContributionSelected marks the object that determined the route.
+	RouteContributionSelected = "selected"
+	// RouteContributionApplied marks objects applied on top of the route (DestinationRules, Sidecars).
+	RouteContributionApplied = "applied"
+	// RouteContributionShadowed marks objects for the same host that were ignored because of precedence.
+	RouteContributionShadowed = "shadowed"
+	// RouteContributionNoMatch marks objects for the same host whose rules did not match the request.
+	RouteContributionNoMatch = "noMatch"
+)
+
+// RouteResolutionRequest describes the request whose effective route must be resolved
+// from the point of view of a source workload.
+type RouteResolutionRequest struct {
+	// Destination host. Short names are resolved relative to the source namespace.
+	// example: reviews.bookinfo.svc.cluster.local
+	Host string `json:"host"`
+
+	// Destination port
+	// example: 9080
+	Port int `json:"port"`
+
+	// Request path
+	// example: /reviews/1
+	Path string `json:"path"`
+
+	// HTTP method
+	// example: GET
+	Method string `json:"method"`
+
+	// Request headers
+	Headers map[string]string `json:"headers"`
+}
+
+// RouteContributor is an Istio or Gateway API object considered while resolving a route.
+type RouteContributor struct {
+	IstioReference
+
+	// Role of the object in the resolution: selected, applied, shadowed or noMatch
+	Role string `json:"role"`
+
+	// Human readable explanation of the role
+	Reason string `json:"reason"`
+}
+
+// RouteRetries summarizes the retry policy of a resolved route.
+type RouteRetries struct {
+	Attempts      int32  `json:"attempts"`
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	RetryOn       string `json:"retryOn,omitempty"`
+}
+
+// RouteDestination is a weighted destination of a resolved route.
+type RouteDestination struct {
+	// Destination host (FQDN)
+	Host string `json:"host"`
+
+	// Destination port, 0 when it is inherited from the request
+	Port int `json:"port"`
+
+	// DestinationRule subset, if any
+	Subset string `json:"subset,omitempty"`
+
+	// Labels selecting the subset endpoints, when the subset is defined
+	SubsetLabels map[string]string `json:"subsetLabels,omitempty"`
+
+	// Weight of the destination in percent
+	Weight int32 `json:"weight"`
+
+	// DestinationRule applied to the destination, if any
+	DestinationRule *IstioReference `json:"destinationRule,omitempty"`
+
+	// Envoy cluster expected in the source proxy
+	// example: outbound|9080|v1|reviews.bookinfo.svc.cluster.local
+	Cluster string `json:"cluster"`
+}
+
+// RouteProxyCheck is the result of comparing the resolved route with the source proxy configuration.
+type RouteProxyCheck struct {
+	// Pod whose config_dump was inspected
+	Pod string `json:"pod"`
+
+	// Whether the proxy configuration agrees with the resolved route
+	Consistent bool `json:"consistent"`
+
+	// Envoy virtual host found for the destination
+	VirtualHost string `json:"virtualHost"`
+
+	// VirtualService referenced by the Envoy route metadata (name.namespace)
+	VirtualService string `json:"virtualService,omitempty"`
+
+	// Envoy clusters targeted by the virtual host routes
+	Clusters []string `json:"clusters"`
+
+	// Differences found between the proxy configuration and the resolved route
+	Notes []string `json:"notes"`
+}
+
+// RouteResolutionResult is the effective routing decision for a request sent by a source workload.
+type RouteResolutionResult struct {
+	// Destination host resolved to its FQDN
+	Host string `json:"host"`
+
+	// Destination port
+	Port int `json:"port"`
+
+	// False when the host is not in the egress scope of the source Sidecar configuration
+	Visible bool `json:"visible"`
+
+	// Kind of object that defines the route: VirtualService, HTTPRoute, GRPCRoute or Default
+	RouteSource string `json:"routeSource"`
+
+	// Name of the matched route rule, or its index when unnamed
+	RouteRule string `json:"routeRule,omitempty"`
+
+	// Weighted destinations of the route
+	Destinations []RouteDestination `json:"destinations"`
+
+	// Request timeout applied by the route
+	Timeout string `json:"timeout,omitempty"`
+
+	// Retry policy applied by the route
+	Retries *RouteRetries `json:"retries,omitempty"`
+
+	// Whether the route rewrites the request, redirects it, mirrors it or injects faults
+	Redirect bool `json:"redirect"`
+	Rewrite  bool `json:"rewrite"`
+	Mirror   bool `json:"mirror"`
+	Fault    bool `json:"fault"`
+
+	// Objects considered during the resolution and how they contributed
+	Contributors []RouteContributor `json:"contributors"`
+
+	// Additional explanations and unsupported conditions found
+	Notes []string `json:"notes"`
+
+	// Comparison with the proxy config_dump, nil when no source proxy is available
+	ProxyCheck *RouteProxyCheck `json:"proxyCheck,omitempty"`
+}
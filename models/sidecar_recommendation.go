@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// SidecarRecommendationScopeNamespace recommends one Sidecar applying to all the workloads of the namespace
+	SidecarRecommendationScopeNamespace = "namespace"
+	// SidecarRecommendationScopeWorkload recommends one Sidecar per workload, selecting the workload by its labels
+	SidecarRecommendationScopeWorkload = "workload"
+)
+
+// SidecarDestination is a destination called by the workloads of a namespace in the observed traffic.
+type SidecarDestination struct {
+	// Namespace of the destination service, or of the ServiceEntry defining the host
+	Namespace string `json:"namespace,omitempty"`
+	// Host called, as it appears in the egress hosts of a Sidecar. Empty for traffic to hosts outside of the registry.
+	// example: reviews.bookinfo.svc.cluster.local
+	Host string `json:"host,omitempty"`
+	// Name of the destination service
+	Service string `json:"service,omitempty"`
+	// Name of the ServiceEntry defining the host, when the destination is a ServiceEntry
+	ServiceEntry string `json:"serviceEntry,omitempty"`
+	// Source workloads calling the destination
+	// required: true
+	Workloads []string `json:"workloads"`
+	// Whether the destination is still reachable with the recommended Sidecar
+	// required: true
+	Kept bool `json:"kept"`
+	// Why the destination is kept or not, when it is not a plain egress host
+	Reason string `json:"reason,omitempty"`
+}
+
+// SidecarConfigSize is an estimate of the size of the proxy configuration, computed from the config_dump of a pod.
+type SidecarConfigSize struct {
+	// Pod whose config_dump was used for the estimate
+	// required: true
+	Pod string `json:"pod"`
+	// Size of the current configuration, in bytes
+	// required: true
+	CurrentBytes int `json:"currentBytes"`
+	// Estimated size of the configuration with the recommended Sidecar, in bytes
+	// required: true
+	EstimatedBytes int `json:"estimatedBytes"`
+	// Number of outbound clusters currently in the configuration
+	// required: true
+	CurrentClusters int `json:"currentClusters"`
+	// Estimated number of outbound clusters with the recommended Sidecar
+	// required: true
+	EstimatedClusters int `json:"estimatedClusters"`
+	// Estimated reduction of the configuration size, in percent
+	// required: true
+	ReductionPercent float64 `json:"reductionPercent"`
+}
+
+// SidecarProposal is a Sidecar recommended for a namespace or a workload.
+type SidecarProposal struct {
+	// Workload selected by the Sidecar, empty for a namespace-wide Sidecar
+	Workload string `json:"workload,omitempty"`
+	// GroupVersionKind of the Sidecar
+	// required: true
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	// required: true
+	Namespace string `json:"namespace"`
+	// required: true
+	Name string `json:"name"`
+	// CREATE when the Sidecar does not exist yet, UPDATE otherwise
+	// required: true
+	Operation string `json:"operation"`
+	// Manifest of the Sidecar
+	// required: true
+	Manifest json.RawMessage `json:"manifest"`
+	// JSON merge patch updating the existing Sidecar, for an UPDATE
+	Patch string `json:"patch,omitempty"`
+	// Egress hosts of the Sidecar
+	// required: true
+	Hosts []string `json:"hosts"`
+	// Destinations called in the observed traffic, and whether the Sidecar keeps them reachable
+	// required: true
+	Destinations []SidecarDestination `json:"destinations"`
+	// Estimate of the configuration size reduction, when the config_dump of a pod is available
+	ConfigSize *SidecarConfigSize `json:"configSize,omitempty"`
+	// Why the configuration size could not be estimated
+	ConfigSizeError string `json:"configSizeError,omitempty"`
+}
+
+// SidecarRecommendation is the set of Sidecars that restrict the egress of the workloads of a namespace to the
+// destinations they called in the observed traffic.
+// swagger:model SidecarRecommendation
+type SidecarRecommendation struct {
+	// required: true
+	Cluster string `json:"cluster"`
+	// required: true
+	Namespace string `json:"namespace"`
+	// namespace or workload
+	// required: true
+	Scope string `json:"scope"`
+	// Window of the observed traffic
+	// example: 1h
+	// required: true
+	Duration string `json:"duration"`
+	// Recommended Sidecars
+	// required: true
+	Proposals []SidecarProposal `json:"proposals"`
+	// Validations of the recommended Sidecars, as if they were applied
+	Validations IstioValidations `json:"validations"`
+	// Whether the validations found no error
+	// required: true
+	Valid bool `json:"valid"`
+	// Caveats of the recommendation
+	Notes []string `json:"notes,omitempty"`
+}
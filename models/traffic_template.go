@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Traffic templates generate the same Istio config as the traffic management wizards of the UI.
+// The template name is stored in the kiali_wizard label of the generated objects, so the wizard can edit them later.
+const (
+	TrafficTemplateFaultInjection  = "fault_injection"
+	TrafficTemplateRequestTimeouts = "request_timeouts"
+	TrafficTemplateTrafficShifting = "traffic_shifting"
+)
+
+// TrafficTemplateRequest holds the parameters of a traffic template for a service.
+// swagger:model TrafficTemplateRequest
+type TrafficTemplateRequest struct {
+	// Generate a Gateway API HTTPRoute instead of an Istio VirtualService and DestinationRule.
+	// Fault injection, retries and DestinationRule traffic policies are not supported with the Gateway API.
+	GatewayAPI bool `json:"gatewayAPI,omitempty"`
+	// Weighted routes of the traffic. Defaults to all the traffic to the service.
+	Routes []TrafficTemplateRoute `json:"routes,omitempty"`
+	// Traffic mirrored to a version or backend, in addition to the routes
+	Mirror *TrafficTemplateMirror `json:"mirror,omitempty"`
+	// Faults injected in the requests, required by the fault_injection template
+	Fault *TrafficTemplateFault `json:"fault,omitempty"`
+	// Timeout of the requests, as a duration (e.g. 2s)
+	Timeout string `json:"timeout,omitempty"`
+	// Retries of the failed requests
+	Retries *TrafficTemplateRetries `json:"retries,omitempty"`
+	// Connection pool limits of the DestinationRule
+	ConnectionPool *TrafficTemplateConnectionPool `json:"connectionPool,omitempty"`
+	// Outlier detection of the DestinationRule
+	OutlierDetection *TrafficTemplateOutlierDetection `json:"outlierDetection,omitempty"`
+}
+
+// TrafficTemplateRoute is a weighted destination of the traffic of a service.
+type TrafficTemplateRoute struct {
+	// Version of the service workloads, a DestinationRule subset. Istio config only.
+	Version string `json:"version,omitempty"`
+	// Service receiving the traffic. Gateway API only, defaults to the templated service.
+	Backend string `json:"backend,omitempty"`
+	// Percentage of the traffic, the weights of all the routes must add up to 100
+	Weight int32 `json:"weight"`
+}
+
+// TrafficTemplateMirror is the destination of the mirrored traffic.
+type TrafficTemplateMirror struct {
+	// Version of the service workloads receiving the mirrored traffic. Istio config only.
+	Version string `json:"version,omitempty"`
+	// Service receiving the mirrored traffic. Gateway API only, defaults to the templated service.
+	Backend string `json:"backend,omitempty"`
+	// Percentage of the traffic mirrored, 100 when not set
+	Percentage float64 `json:"percentage,omitempty"`
+}
+
+// TrafficTemplateFault are the faults injected in the requests.
+type TrafficTemplateFault struct {
+	// Percentage of the requests delayed
+	DelayPercentage float64 `json:"delayPercentage,omitempty"`
+	// Delay of the requests, as a duration (e.g. 5s)
+	FixedDelay string `json:"fixedDelay,omitempty"`
+	// Percentage of the requests aborted
+	AbortPercentage float64 `json:"abortPercentage,omitempty"`
+	// HTTP status of the aborted requests
+	HTTPStatus int32 `json:"httpStatus,omitempty"`
+}
+
+// TrafficTemplateRetries are the retries of the failed requests.
+type TrafficTemplateRetries struct {
+	// Number of retries
+	Attempts int32 `json:"attempts"`
+	// Timeout of each attempt, as a duration (e.g. 1s)
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	// Conditions of the retries (e.g. 5xx,connect-failure)
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
+// TrafficTemplateConnectionPool are the connection pool limits of the destinations.
+type TrafficTemplateConnectionPool struct {
+	MaxConnections           int32 `json:"maxConnections,omitempty"`
+	HTTP1MaxPendingRequests  int32 `json:"http1MaxPendingRequests,omitempty"`
+	MaxRequestsPerConnection int32 `json:"maxRequestsPerConnection,omitempty"`
+}
+
+// TrafficTemplateOutlierDetection ejects the failing hosts of the destinations.
+type TrafficTemplateOutlierDetection struct {
+	Consecutive5xxErrors uint32 `json:"consecutive5xxErrors,omitempty"`
+	// Interval between the ejection sweeps, as a duration (e.g. 10s)
+	Interval string `json:"interval,omitempty"`
+	// Minimum ejection duration, as a duration (e.g. 30s)
+	BaseEjectionTime   string `json:"baseEjectionTime,omitempty"`
+	MaxEjectionPercent int32  `json:"maxEjectionPercent,omitempty"`
+}
+
+// TrafficTemplateObject is an object generated by a traffic template.
+type TrafficTemplateObject struct {
+	// GroupVersionKind of the object
+	ObjectGVK schema.GroupVersionKind `json:"objectGVK"`
+	// Name of the object
+	Name string `json:"name"`
+	// CREATE when the object does not exist yet, UPDATE otherwise
+	Operation string `json:"operation"`
+	// Manifest of the object
+	Manifest json.RawMessage `json:"manifest"`
+	// JSON merge patch updating the existing object, for an UPDATE
+	Patch string `json:"patch,omitempty"`
+}
+
+// TrafficTemplateResult is the Istio config generated by a traffic template for a service.
+// swagger:model TrafficTemplateResult
+type TrafficTemplateResult struct {
+	// Template applied
+	// required: true
+	Template string `json:"template"`
+	// Cluster of the service
+	Cluster string `json:"cluster"`
+	// Namespace of the service
+	Namespace string `json:"namespace"`
+	// Service the config is generated for
+	Service string `json:"service"`
+	// Whether the objects were written to the cluster, or only generated for review
+	// required: true
+	Applied bool `json:"applied"`
+	// Objects generated
+	// required: true
+	Objects []TrafficTemplateObject `json:"objects"`
+	// Validations of the generated objects, as if they were applied
+	Validations IstioValidations `json:"validations"`
+	// Whether the validations found no error
+	// required: true
+	Valid bool `json:"valid"`
+	// Changes proposed to the GitOps repository instead of being applied, in GitOps mode
+	GitOpsChanges []GitOpsChange `json:"gitOpsChanges,omitempty"`
+}
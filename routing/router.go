@@ -17,9 +17,12 @@ import (
 	zerolog "github.com/rs/zerolog/log"
 
 	"github.com/kiali/kiali/ai"
+	"github.com/kiali/kiali/ai/types"
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/gitops"
 	"github.com/kiali/kiali/grafana"
 	"github.com/kiali/kiali/graph"
 	"github.com/kiali/kiali/handlers"
@@ -30,6 +33,7 @@ import (
 	"github.com/kiali/kiali/perses"
 	kialiprometheus "github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/prometheus/internalmetrics"
+	"github.com/kiali/kiali/sessionstore"
 	"github.com/kiali/kiali/tracing"
 	utilcontext "github.com/kiali/kiali/util/context"
 )
@@ -169,6 +173,13 @@ func NewRouter(
 			return nil, err
 		}
 		authController = headerAuth
+	case config.AuthStrategyX509:
+		x509Auth, err := authentication.NewX509AuthController(conf, clientFactory)
+		if err != nil {
+			zl.Error().Msgf("Error creating X509AuthController: %v", err)
+			return nil, err
+		}
+		authController = x509Auth
 	}
 
 	// Initialize graph cache and refresh job manager for per-session graph caching
@@ -183,9 +194,27 @@ func NewRouter(
 		zl.Info().Msg("graph cache disabled")
 	}
 
+	// The session store keeps the API tokens and, when shared by the replicas, the AI conversations
+	sessionStore, err := sessionstore.New(conf)
+	if err != nil {
+		zl.Error().Msgf("Error creating the session store: %v", err)
+		return nil, err
+	}
+	apiTokens := authentication.NewAPITokens(conf, sessionStore)
+	if apiTokens.Enabled() {
+		zl.Info().Msgf("API tokens enabled: max_expiration_seconds=%d", conf.Auth.APITokens.MaxExpirationSeconds)
+	}
+
 	// Initialize AI store
 	aiStoreConfig := ai.LoadAIStoreConfig(conf)
-	aiStore := ai.NewAIStore(ctx, aiStoreConfig)
+	var aiStore types.AIStore
+	if conf.SessionStore.IsShared() {
+		// AI conversations are kept in the shared session store so that they can continue in any replica.
+		aiStore = ai.NewSharedAIStore(ctx, aiStoreConfig, sessionStore)
+		zl.Info().Msgf("session store [%s]: login sessions and AI conversations are shared by the replicas", conf.SessionStore.Type)
+	} else {
+		aiStore = ai.NewAIStore(ctx, aiStoreConfig)
+	}
 	if !conf.ChatAI.Enabled {
 		zl.Info().Msg("[ChatAI] DISABLED")
 	} else {
@@ -211,12 +240,44 @@ func NewRouter(
 		}
 	}
 
+	// The session registry tracks the login sessions for the session management API
+	sessionRegistry := authentication.NewSessionRegistry(conf, sessionStore)
+	if sessionRegistry.Enabled() {
+		sessionRegistry.OnEnd(func(sessionID string) {
+			refreshJobManager.StopJob(sessionID)
+			graphCache.Evict(sessionID)
+			if err := aiStore.DeleteSessionConversations(sessionID); err != nil {
+				zl.Warn().Msgf("Unable to delete the AI conversations of session [%s]: %v", sessionID, err)
+			}
+		})
+		authController, err = authentication.NewSessionRegistryController(conf, authController, sessionRegistry)
+		if err != nil {
+			zl.Error().Msgf("Error creating the session registry: %v", err)
+			return nil, err
+		}
+		zl.Info().Msg("session management enabled")
+	}
+
+	auditTrail, err := audit.NewTrail(ctx, conf, clientFactory)
+	if err != nil {
+		zl.Error().Msgf("Error creating the audit trail: %v", err)
+		return nil, err
+	}
+
+	gitOpsProposer, err := gitops.NewProposer(conf)
+	if err != nil {
+		zl.Error().Msgf("Error creating the GitOps proposer: %v", err)
+		return nil, err
+	}
+
+	canaries := business.NewCanaryController(ctx, kialiCache, clientFactory, conf, discovery, prom)
+
 	// Build our API server routes and install them.
-	apiRoutes := NewRoutes(conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, authController, grafana, perses, discovery, graphCache, refreshJobManager, aiStore)
+	apiRoutes := NewRoutes(conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, authController, grafana, perses, discovery, graphCache, refreshJobManager, aiStore, auditTrail, canaries, apiTokens, sessionRegistry)
 	// Add any auth routes to the app router.
 	apiRoutes.Routes = append(apiRoutes.Routes, authRoutes...)
 
-	authenticationHandler := handlers.NewAuthenticationHandler(conf, authController, clientFactory.GetSAHomeClusterClient(), authRedirectHandler, clientFactory.GetSAClients())
+	authenticationHandler := handlers.NewAuthenticationHandler(conf, authController, clientFactory.GetSAHomeClusterClient(), authRedirectHandler, clientFactory.GetSAClients(), apiTokens)
 
 	allRoutes := apiRoutes.Routes
 
@@ -274,7 +335,10 @@ func NewRouter(
 	}
 
 	for _, route := range allRoutes {
-		handlerFunction := metricHandler(route.HandlerFunc, route)
+		handlerFunction := auditHandler(metricHandler(route.HandlerFunc, route), auditTrail)
+		if gitOpsProposer != nil {
+			handlerFunction = gitOpsHandler(handlerFunction, gitOpsProposer)
+		}
 		if route.Authenticated {
 			handlerFunction = authenticationHandler.Handle(handlerFunction)
 		} else {
@@ -374,6 +438,20 @@ func metricHandler(next http.Handler, route Route) http.Handler {
 	})
 }
 
+// auditHandler makes the audit trail available to the handlers recording write operations
+func auditHandler(next http.Handler, auditTrail *audit.Trail) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(audit.NewContext(r.Context(), auditTrail)))
+	})
+}
+
+// gitOpsHandler makes the GitOps proposer available to the handlers of the Istio config write operations
+func gitOpsHandler(next http.Handler, proposer *gitops.Proposer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(gitops.NewContext(r.Context(), proposer)))
+	})
+}
+
 // serveEnvJsFile generates the env.js file needed by the UI from Kiali configs. The
 // generated file is sent to the HTTP response.
 func serveEnvJsFile(conf *config.Config, w http.ResponseWriter) {
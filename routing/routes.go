@@ -6,6 +6,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	ai "github.com/kiali/kiali/ai/types"
+	"github.com/kiali/kiali/audit"
 	"github.com/kiali/kiali/business"
 	"github.com/kiali/kiali/cache"
 	"github.com/kiali/kiali/config"
@@ -52,6 +53,10 @@ func NewRoutes(
 	graphCache graph.GraphCache,
 	refreshJobManager *graph.RefreshJobManager,
 	aiStore ai.AIStore,
+	auditTrail *audit.Trail,
+	canaries *business.CanaryController,
+	apiTokens *authentication.APITokens,
+	sessionRegistry *authentication.SessionRegistry,
 ) (r *Routes) {
 	r = new(Routes)
 
@@ -177,6 +182,121 @@ func NewRoutes(
 			handlers.AuthenticationInfo(conf, authController, maps.Keys(clientFactory.GetSAClients())),
 			false,
 		},
+		// swagger:route POST /auth/tokens auth apiTokenCreate
+		// ---
+		// Endpoint to create an API token acting on behalf of the user, optionally read-only and restricted
+		// to some namespaces and clusters. The token is only returned by this call.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      403: forbiddenError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: apiTokenResponse
+		//
+		{
+			"APITokenCreate",
+			log.AuthenticateLogName,
+			"POST",
+			"/api/auth/tokens",
+			handlers.CreateAPIToken(conf, clientFactory, apiTokens),
+			true,
+		},
+		// swagger:route GET /auth/tokens auth apiTokenList
+		// ---
+		// Endpoint to list the API tokens of the user that are not expired nor revoked
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      403: forbiddenError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: apiTokensResponse
+		//
+		{
+			"APITokenList",
+			log.AuthenticateLogName,
+			"GET",
+			"/api/auth/tokens",
+			handlers.ListAPITokens(conf, clientFactory, apiTokens),
+			true,
+		},
+		// swagger:route DELETE /auth/tokens/{id} auth apiTokenRevoke
+		// ---
+		// Endpoint to revoke an API token of the user
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      403: forbiddenError
+		//      404: notFoundError
+		//      500: internalError
+		//      204: noContent
+		//
+		{
+			"APITokenRevoke",
+			log.AuthenticateLogName,
+			"DELETE",
+			"/api/auth/tokens/{id}",
+			handlers.RevokeAPIToken(conf, clientFactory, apiTokens),
+			true,
+		},
+		// swagger:route GET /auth/sessions auth sessionList
+		// ---
+		// Endpoint to list the active login sessions, with the graph cache and AI store usage of each.
+		// Requires the manage-sessions capability of the Kiali roles.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      403: forbiddenError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: activeSessionsResponse
+		//
+		{
+			"SessionList",
+			log.AuthenticateLogName,
+			"GET",
+			"/api/auth/sessions",
+			handlers.ListSessions(conf, sessionRegistry, graphCache, refreshJobManager, aiStore),
+			true,
+		},
+		// swagger:route DELETE /auth/sessions/{id} auth sessionRevoke
+		// ---
+		// Endpoint to revoke an active login session. Requires the manage-sessions capability of the Kiali roles.
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      403: forbiddenError
+		//      404: notFoundError
+		//      500: internalError
+		//      204: noContent
+		//
+		{
+			"SessionRevoke",
+			log.AuthenticateLogName,
+			"DELETE",
+			"/api/auth/sessions/{id}",
+			handlers.RevokeSession(conf, sessionRegistry),
+			true,
+		},
 		// swagger:route GET /status status getStatus
 		// ---
 		// Endpoint to get the status of Kiali
@@ -314,6 +434,30 @@ func NewRoutes(
 			handlers.Config(conf, kialiCache, discovery, clientFactory, prom),
 			true,
 		},
+		// swagger:route GET /audit kiali auditSearch
+		// ---
+		// Endpoint to search the audit records of the write operations performed through Kiali.
+		// Only the records of namespaces accessible to the user are returned, newest first.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: auditRecordsResponse
+		//
+		{
+			"AuditSearch",
+			log.AuditLogName,
+			"GET",
+			"/api/audit",
+			handlers.AuditSearch(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, auditTrail),
+			true,
+		},
 		// swagger:route GET /config/disabled kiali getDisabledFeatures
 		// ---
 		// Endpoint to get the disabled features of Kiali
@@ -396,6 +540,57 @@ func NewRoutes(
 			handlers.IstioConfigList(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
 			true,
 		},
+		// swagger:route POST /istio/config/bulk config istioConfigBulk
+		// ---
+		// Endpoint to patch, add or remove labels and annotations of, or delete all the Istio objects matching some criteria.
+		// The operation is independent per object. A dry run lists the matching objects and the validations that would change.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      207: istioConfigBulkResponse
+		//      200: istioConfigBulkResponse
+		//
+		{
+			"IstioConfigBulk",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/istio/config/bulk",
+			handlers.IstioConfigBulk(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/istio/export config istioConfigExport
+		// ---
+		// Endpoint to export the Istio config of a namespace as a Helm chart or a Kustomize base with per-cluster overlays.
+		// The metadata managed by the API server and the status are stripped, and the external host names are parameterised.
+		//
+		//     Produces:
+		//     - application/json
+		//     - application/gzip
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: istioConfigExportResponse
+		//
+		{
+			"IstioConfigExport",
+			log.IstioConfigLogName,
+			"GET",
+			"/api/namespaces/{namespace}/istio/export",
+			handlers.IstioConfigExport(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/istio/{group}/{version}/{kind}/{object} config istioConfigDetails
 		// ---
 		// Endpoint to get the Istio Config of an Istio object
@@ -421,7 +616,9 @@ func NewRoutes(
 		},
 		// swagger:route DELETE /namespaces/{namespace}/istio/{group}/{version}/{kind}/{object} config istioConfigDelete
 		// ---
-		// Endpoint to delete the Istio Config of an (arbitrary) Istio object
+		// Endpoint to delete the Istio Config of an (arbitrary) Istio object.
+		// In GitOps mode the deletion is proposed to the GitOps repository instead.
+		// The deletion is performed in every cluster of the clusters parameter when it is set.
 		//
 		//     Produces:
 		//     - application/json
@@ -430,7 +627,10 @@ func NewRoutes(
 		//
 		// responses:
 		//      404: notFoundError
+		//      409: conflictError
 		//      500: internalError
+		//      207: istioConfigMultiClusterResponse
+		//      202: gitOpsChangeResponse
 		//      200
 		//
 		{
@@ -444,6 +644,8 @@ func NewRoutes(
 		// swagger:route PATCH /namespaces/{namespace}/istio/{group}/{version}/{kind}/{object} config istioConfigUpdate
 		// ---
 		// Endpoint to update the Istio Config of an Istio object used for templates and adapters using Json Merge Patch strategy.
+		// In GitOps mode the resulting object is proposed to the GitOps repository instead.
+		// The update is performed in every cluster of the clusters parameter when it is set.
 		//
 		//     Consumes:
 		//	   - application/json
@@ -456,7 +658,10 @@ func NewRoutes(
 		// responses:
 		//      400: badRequestError
 		//      404: notFoundError
+		//      409: conflictError
 		//      500: internalError
+		//      207: istioConfigMultiClusterResponse
+		//      202: gitOpsChangeResponse
 		//      200: istioConfigDetailsResponse
 		//
 		{
@@ -467,9 +672,59 @@ func NewRoutes(
 			handlers.IstioConfigUpdate(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions config istioConfigRevisions
+		// ---
+		// Endpoint to get the revision history of an Istio object, with the diff of every revision against the next one
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: istioConfigRevisionsResponse
+		//
+		{
+			"IstioConfigRevisions",
+			log.IstioConfigLogName,
+			"GET",
+			"/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions",
+			handlers.IstioConfigRevisions(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions/{revision}/rollback config istioConfigRollback
+		// ---
+		// Endpoint to restore a revision of an Istio object. The rollback is validated with a server side dry run before it is applied.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: istioConfigDetailsResponse
+		//
+		{
+			"IstioConfigRollback",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/namespaces/{namespace}/istio/{group}/{version}/{kind}/{object}/revisions/{revision}/rollback",
+			handlers.IstioConfigRollback(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
 		// swagger:route POST /namespaces/{namespace}/istio/{group}/{version}/{kind} config istioConfigCreate
 		// ---
-		// Endpoint to create an Istio object by using an Istio Config item
+		// Endpoint to create an Istio object by using an Istio Config item.
+		// In GitOps mode the object is proposed to the GitOps repository instead.
+		// The object is created in every cluster of the clusters parameter when it is set.
 		//
 		//     Produces:
 		//     - application/json
@@ -478,8 +733,10 @@ func NewRoutes(
 		//
 		// responses:
 		//      404: notFoundError
+		//      409: conflictError
 		//      500: internalError
-		//		202
+		//      207: istioConfigMultiClusterResponse
+		//		202: gitOpsChangeResponse
 		//		201: istioConfigDetailsResponse
 		//      200: istioConfigDetailsResponse
 		//
@@ -799,6 +1056,241 @@ func NewRoutes(
 			handlers.WorkloadUpdate(conf, kialiCache, clientFactory, cpm, prom, traceClientLoader, grafana, discovery),
 			true,
 		},
+		// swagger:route POST /namespaces/{namespace}/workloads/{workload}/authorization/simulate config authorizationSimulate
+		// ---
+		// Endpoint to evaluate whether a request to a workload would be allowed by the AuthorizationPolicies applied to it
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: authorizationSimulationResponse
+		//
+		{
+			"AuthorizationSimulate",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/namespaces/{namespace}/workloads/{workload}/authorization/simulate",
+			handlers.AuthorizationSimulate(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/workloads/{workload}/routing/resolve config routeResolve
+		// ---
+		// Endpoint to resolve the effective route that a workload proxy applies to a request, and the objects contributing to it
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: routeResolutionResponse
+		//
+		{
+			"RouteResolve",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/namespaces/{namespace}/workloads/{workload}/routing/resolve",
+			handlers.RouteResolve(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/services/{service}/traffic_templates/{template} config trafficTemplate
+		// ---
+		// Endpoint to generate the Istio config of a traffic template for a service: traffic_shifting, fault_injection or request_timeouts.
+		// The generated objects are validated and returned for review, or applied when the apply parameter is set.
+		// In GitOps mode the objects are proposed to the GitOps repository instead.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      422: trafficTemplateResponse
+		//      500: internalError
+		//      202: trafficTemplateResponse
+		//      200: trafficTemplateResponse
+		//
+		{
+			"TrafficTemplate",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/namespaces/{namespace}/services/{service}/traffic_templates/{template}",
+			handlers.TrafficTemplate(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/istio/gateway_api_conversion config gatewayAPIConversion
+		// ---
+		// Endpoint to convert the Ingresses, Istio Gateways and VirtualServices of a namespace to Gateway API Gateways,
+		// HTTPRoutes, GRPCRoutes and ReferenceGrants, reporting the constructs that cannot be translated.
+		// The resulting objects are validated and returned for review, or applied when the apply parameter is set.
+		// In GitOps mode the objects are proposed to the GitOps repository instead.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      422: gatewayAPIConversionResponse
+		//      500: internalError
+		//      202: gatewayAPIConversionResponse
+		//      200: gatewayAPIConversionResponse
+		//
+		{
+			"GatewayAPIConversion",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/namespaces/{namespace}/istio/gateway_api_conversion",
+			handlers.GatewayAPIConversion(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/sidecar_recommendation config sidecarRecommendation
+		// ---
+		// Endpoint to recommend the Sidecars that restrict the egress hosts of the workloads of a namespace to the
+		// destinations they called in the traffic graph over the requested window, one for the namespace or one per workload.
+		// Each proposal lists the destinations it keeps and estimates the configuration size it saves from the proxy config_dump.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      503: serviceUnavailableError
+		//      200: sidecarRecommendationResponse
+		//
+		{
+			"SidecarRecommendation",
+			log.IstioConfigLogName,
+			"GET",
+			"/api/namespaces/{namespace}/sidecar_recommendation",
+			handlers.SidecarRecommendation(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route POST /namespaces/{namespace}/services/{service}/canaries config canaryStart
+		// ---
+		// Endpoint to start a canary run, shifting the traffic of a service from a baseline to a canary version in weighted steps.
+		// Before each step the canary error rate is checked against the health tolerances, and optionally its latency,
+		// and the traffic is shifted back to the baseline when they are breached.
+		//
+		//     Consumes:
+		//     - application/json
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      200: canaryRunResponse
+		//
+		{
+			"CanaryStart",
+			log.IstioConfigLogName,
+			"POST",
+			"/api/namespaces/{namespace}/services/{service}/canaries",
+			handlers.CanaryStart(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, canaries),
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/canaries config canaryList
+		// ---
+		// Endpoint to list the canary runs of a service, the most recent first
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200: canaryRunListResponse
+		//
+		{
+			"CanaryList",
+			log.IstioConfigLogName,
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/canaries",
+			handlers.CanaryList(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, canaries),
+			true,
+		},
+		// swagger:route GET /namespaces/{namespace}/services/{service}/canaries/{run} config canaryGet
+		// ---
+		// Endpoint to get the status and history of a canary run
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      500: internalError
+		//      200: canaryRunResponse
+		//
+		{
+			"CanaryGet",
+			log.IstioConfigLogName,
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/canaries/{run}",
+			handlers.CanaryGet(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, canaries),
+			true,
+		},
+		// swagger:route DELETE /namespaces/{namespace}/services/{service}/canaries/{run} config canaryCancel
+		// ---
+		// Endpoint to cancel a canary run, shifting all the traffic back to the baseline
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      404: notFoundError
+		//      409: conflictError
+		//      500: internalError
+		//      200: canaryRunResponse
+		//
+		{
+			"CanaryCancel",
+			log.IstioConfigLogName,
+			"DELETE",
+			"/api/namespaces/{namespace}/services/{service}/canaries/{run}",
+			handlers.CanaryCancel(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana, canaries),
+			true,
+		},
 		// swagger:route GET /clusters/apps apps appList
 		// ---
 		// Endpoint to get the list of apps for a cluster
@@ -1196,6 +1688,53 @@ func NewRoutes(
 			handlers.ClusterHealth(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
 			true,
 		},
+		// swagger:route GET /namespaces/{namespace}/health/history health namespaceHealthHistory
+		// ---
+		// Endpoint to get the timeline of the health transitions of the apps, services and workloads of a namespace,
+		// as recorded by the health cache refreshes. The type and name query parameters select a single entity.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      403: forbiddenError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: healthTimelineResponse
+		//
+		{
+			"NamespaceHealthHistory",
+			log.ClustersLogName,
+			"GET",
+			"/api/namespaces/{namespace}/health/history",
+			handlers.HealthHistory(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
+		// swagger:route GET /health/slo/rules health sloRules
+		// ---
+		// Endpoint to get the Prometheus recording and alerting rules of the SLOs of the health config, as a rules file.
+		// The rules record the error ratios of the SLOs and alert on the same multi-window burn rates as the health.
+		//
+		//     Produces:
+		//     - application/yaml
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      500: internalError
+		//      200: sloRulesResponse
+		//
+		{
+			"SLORules",
+			log.ConfigLogName,
+			"GET",
+			"/api/health/slo/rules",
+			handlers.SLORules(conf),
+			true,
+		},
 		// swagger:route GET /namespaces/{namespace}/validations namespaces namespaceValidations
 		// ---
 		// Get validation summary for all objects in the given namespace
@@ -1504,6 +2043,30 @@ func NewRoutes(
 			handlers.MeshGraph(conf, clientFactory, kialiCache, grafana, perses, prom, traceClientLoader, discovery, cpm),
 			true,
 		},
+		// swagger:route GET /mesh/health health meshHealth
+		// ---
+		// Endpoint to get the health of the mesh infrastructure components: istiod, the ingress and egress gateways,
+		// the waypoints and ztunnel.
+		//
+		//     Produces:
+		//     - application/json
+		//
+		//     Schemes: http, https
+		//
+		// responses:
+		//      400: badRequestError
+		//      404: notFoundError
+		//      500: internalError
+		//      200: meshHealthResponse
+		//
+		{
+			"MeshHealth",
+			log.MeshLogName,
+			"GET",
+			"/api/mesh/health",
+			handlers.MeshHealth(conf, kialiCache, clientFactory, prom, traceClientLoader, discovery, cpm, grafana),
+			true,
+		},
 		// swagger:route GET /mesh/controlplanes controlplanes
 		// ---
 		// The backing JSON for mesh controlplanes
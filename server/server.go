@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/fs"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/NYTimes/gziphandler"
@@ -90,6 +92,17 @@ func NewServer(ctx context.Context,
 		NextProtos: []string{"h2", "http/1.1"},
 	}
 	conf.ResolvedTLSPolicy.ApplyTo(tlsConfig)
+	if conf.Auth.Strategy == config.AuthStrategyX509 {
+		// Client certificates are the credentials of the x509 auth strategy. They are verified when given, but not
+		// required by the handshake: the probes and the metrics scrapes have none, and the x509 auth controller
+		// rejects the requests without a verified client certificate.
+		clientCAs, err := loadClientCAs(conf.Auth.X509.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		tlsConfig.ClientCAs = clientCAs
+	}
 
 	// The /debug/pprof/profiler by default needs a write timeout larger than 30s. But also, you can pass in &seconds=XY on the pprof URL
 	// and ask for any profile to extend to those number of seconds you specify, which could be larger than 30s.
@@ -124,6 +137,19 @@ func NewServer(ctx context.Context,
 	return s, nil
 }
 
+// loadClientCAs reads the CA bundle verifying the client certificates.
+func loadClientCAs(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the client CA file [%s]: %w", caFile, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("the client CA file [%s] contains no PEM certificate", caFile)
+	}
+	return pool, nil
+}
+
 // Start HTTP server asynchronously. TLS may be active depending on the global configuration.
 // TODO: Need to bubble up error somehow. Address is in use etc.
 func (s *Server) Start() {
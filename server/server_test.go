@@ -316,6 +316,58 @@ func TestSecureComm(t *testing.T) {
 	}
 }
 
+func TestX509ClientCertificateNotRequired(t *testing.T) {
+	require := require.New(t)
+	testPort, err := getFreePort(testHostname)
+	require.NoError(err)
+
+	tmpDir := t.TempDir()
+	testServerCertFile := tmpDir + "/server-test-server.cert"
+	testServerKeyFile := tmpDir + "/server-test-server.key"
+	testServerHostPort := fmt.Sprintf("%v:%v", testHostname, testPort)
+	require.NoError(generateCertificate(t, testServerCertFile, testServerKeyFile, testServerHostPort))
+	testClientCAFile := tmpDir + "/server-test-client-ca.cert"
+	require.NoError(generateCertificate(t, testClientCAFile, tmpDir+"/server-test-client-ca.key", testHostname))
+
+	conf := config.NewConfig()
+	conf.Identity.CertFile = testServerCertFile
+	conf.Identity.PrivateKeyFile = testServerKeyFile
+	conf.LoginToken.SigningKey = config.Credential(util.RandomString(16))
+	conf.Server.Address = testHostname
+	conf.Server.Port = testPort
+	conf.Server.Observability.Metrics.Enabled = false
+	conf.Auth.Strategy = config.AuthStrategyX509
+	conf.Auth.X509.ClientCAFile = testClientCAFile
+	conf.KubernetesConfig.ClusterName = config.DefaultClusterID
+	util.Clock = util.RealClock{}
+
+	cf := kubetest.NewFakeClientFactoryWithClient(conf, kubetest.NewFakeK8sClient())
+	cpm := &business.FakeControlPlaneMonitor{}
+	cache := cache.NewTestingCacheWithFactory(t, cf, *conf)
+	prom := prometheustest.FakeClient{}
+	server, err := NewServer(t.Context(), cpm, cf, cache, conf, newTestGrafanaService(t, conf), &prom, nil, nil, filetest.StaticAssetDir(t))
+	require.NoError(err)
+	server.Start()
+	defer server.Stop()
+
+	// The probes have no client certificate: the handshake succeeds, the API requests are not authenticated
+	httpConfig := httpClientConfig{TLSConfig: &tls.Config{InsecureSkipVerify: true}}
+	httpClient, err := httpConfig.buildHTTPClient()
+	require.NoError(err)
+	serverURL := fmt.Sprintf("https://%v", testServerHostPort)
+	checkHTTPReady(httpClient, serverURL+"/healthz")
+
+	resp, err := httpClient.Get(serverURL + "/healthz")
+	require.NoError(err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = httpClient.Get(serverURL + "/api/namespaces")
+	require.NoError(err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
 func TestTracingConfigured(t *testing.T) {
 	testPort, err := getFreePort(testHostname)
 	if err != nil {
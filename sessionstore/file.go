@@ -0,0 +1,109 @@
+package sessionstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/util"
+)
+
+const fileStoreExtension = ".session"
+
+// fileStore keeps each value in its own file of a directory, which can be a volume shared by the replicas.
+// A file starts with the expiration time of the value (unix nanoseconds, zero if it never expires) followed
+// by the value. Files are written to a temporary file first and renamed, so readers never see partial values.
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore returns a store keeping the values in files of the directory, creating it if needed.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create the session store directory [%s]: %w", dir, err)
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+func (s *fileStore) path(key string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(key))+fileStoreExtension)
+}
+
+func (s *fileStore) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to delete session store key [%s]: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (s *fileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	content, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("unable to read session store key [%s]: %w", key, err)
+	}
+	if len(content) < 8 {
+		return nil, fmt.Errorf("session store key [%s] is corrupted", key)
+	}
+	if nanos := int64(binary.BigEndian.Uint64(content[:8])); nanos != 0 && expired(time.Unix(0, nanos), util.Clock.Now()) {
+		_ = s.Delete(ctx, key)
+		return nil, ErrNotFound
+	}
+	return content[8:], nil
+}
+
+func (s *fileStore) Keys(_ context.Context, prefix string) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the session store directory [%s]: %w", s.dir, err)
+	}
+	keys := []string{}
+	for _, entry := range entries {
+		name, found := strings.CutSuffix(entry.Name(), fileStoreExtension)
+		if !found || entry.IsDir() {
+			continue
+		}
+		key, err := base64.RawURLEncoding.DecodeString(name)
+		if err != nil || !strings.HasPrefix(string(key), prefix) {
+			continue
+		}
+		keys = append(keys, string(key))
+	}
+	return keys, nil
+}
+
+func (s *fileStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	content := make([]byte, 8, 8+len(value))
+	if expiresOn := expiresOn(util.Clock.Now(), ttl); !expiresOn.IsZero() {
+		binary.BigEndian.PutUint64(content, uint64(expiresOn.UnixNano()))
+	}
+	content = append(content, value...)
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("unable to write session store key [%s]: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write session store key [%s]: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write session store key [%s]: %w", key, err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("unable to write session store key [%s]: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package sessionstore
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/util"
+)
+
+type memoryEntry struct {
+	expiresOn time.Time
+	value     []byte
+}
+
+// memoryStore keeps the values in the memory of the replica. Expired values are dropped when they are
+// read and, to bound the memory, every time the store has doubled in size since the last sweep.
+type memoryStore struct {
+	entries   map[string]memoryEntry
+	lock      sync.Mutex
+	sweepSize int
+}
+
+// NewMemoryStore returns a store keeping the values in memory. It is the default store.
+func NewMemoryStore() Store {
+	return &memoryStore{entries: map[string]memoryEntry{}, sweepSize: 64}
+}
+
+func (s *memoryStore) Delete(_ context.Context, keys ...string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	entry, found := s.entries[key]
+	if !found {
+		return nil, ErrNotFound
+	}
+	if expired(entry.expiresOn, util.Clock.Now()) {
+		delete(s.entries, key)
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (s *memoryStore) Keys(_ context.Context, prefix string) ([]string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := util.Clock.Now()
+	keys := []string{}
+	for key, entry := range s.entries {
+		if strings.HasPrefix(key, prefix) && !expired(entry.expiresOn, now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := util.Clock.Now()
+	s.entries[key] = memoryEntry{expiresOn: expiresOn(now, ttl), value: append([]byte(nil), value...)}
+	if len(s.entries) >= s.sweepSize {
+		for k, entry := range s.entries {
+			if expired(entry.expiresOn, now) {
+				delete(s.entries, k)
+			}
+		}
+		s.sweepSize = max(64, 2*len(s.entries))
+	}
+	return nil
+}
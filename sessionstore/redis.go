@@ -0,0 +1,281 @@
+package sessionstore
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kiali/kiali/config"
+)
+
+// redisMaxIdleConns is the number of connections kept open between commands.
+const redisMaxIdleConns = 8
+
+// redisError is an error reply of the server. The connection is still usable after it.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisStore keeps the values in a server speaking the Redis protocol (RESP2). Only the handful of commands
+// needed by the store are implemented: AUTH, SELECT, GET, SET, DEL and SCAN.
+type redisStore struct {
+	conf *config.Config
+	idle []*redisConn
+	lock sync.Mutex
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisStore returns a store keeping the values in the Redis server configured in session_store.redis.
+// Connections are opened on use, so the server does not need to be reachable when the store is created.
+func NewRedisStore(conf *config.Config) Store {
+	return &redisStore{conf: conf}
+}
+
+func (s *redisStore) key(key string) string {
+	return s.conf.SessionStore.Redis.KeyPrefix + key
+}
+
+func (s *redisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := []string{"DEL"}
+	for _, key := range keys {
+		args = append(args, s.key(key))
+	}
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := s.do(ctx, "GET", s.key(key))
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply to GET: %v", reply)
+	}
+	return value, nil
+}
+
+func (s *redisStore) Keys(ctx context.Context, prefix string) ([]string, error) {
+	keyPrefix := s.conf.SessionStore.Redis.KeyPrefix
+	pattern := escapeRedisPattern(keyPrefix+prefix) + "*"
+	keys := []string{}
+	cursor := "0"
+	for {
+		reply, err := s.do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			return nil, err
+		}
+		page, ok := reply.([]any)
+		if !ok || len(page) != 2 {
+			return nil, fmt.Errorf("redis: unexpected reply to SCAN: %v", reply)
+		}
+		next, _ := page[0].([]byte)
+		found, _ := page[1].([]any)
+		for _, k := range found {
+			if key, ok := k.([]byte); ok {
+				keys = append(keys, strings.TrimPrefix(string(key), keyPrefix))
+			}
+		}
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return keys, nil
+		}
+	}
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", s.key(key), string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+// do sends a command and returns its reply: nil, string, int64, []byte or []any.
+// Error replies are returned as errors.
+func (s *redisStore) do(ctx context.Context, args ...string) (any, error) {
+	c, err := s.getConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.do(s.deadline(ctx), args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection is in an unknown state after an I/O error.
+		c.conn.Close()
+		return nil, err
+	}
+	s.putConn(c)
+	return reply, err
+}
+
+func (s *redisStore) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(s.conf.SessionStore.Redis.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		return d
+	}
+	return deadline
+}
+
+func (s *redisStore) getConn(ctx context.Context) (*redisConn, error) {
+	s.lock.Lock()
+	if n := len(s.idle); n > 0 {
+		c := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.lock.Unlock()
+		return c, nil
+	}
+	s.lock.Unlock()
+	return s.dial(ctx)
+}
+
+func (s *redisStore) putConn(c *redisConn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.idle) >= redisMaxIdleConns {
+		c.conn.Close()
+		return
+	}
+	s.idle = append(s.idle, c)
+}
+
+func (s *redisStore) dial(ctx context.Context) (*redisConn, error) {
+	redisConf := s.conf.SessionStore.Redis
+	dialer := &net.Dialer{Timeout: redisConf.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", redisConf.Address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the redis session store [%s]: %w", redisConf.Address, err)
+	}
+	if redisConf.UseTLS {
+		host, _, _ := net.SplitHostPort(redisConf.Address)
+		tlsConfig := &tls.Config{RootCAs: s.conf.CertPool(), ServerName: host}
+		s.conf.ResolvedTLSPolicy.ApplyTo(tlsConfig)
+		conn = tls.Client(conn, tlsConfig)
+	}
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	// The password is read on every connection so that it can be rotated.
+	password, err := s.conf.GetCredential(redisConf.Password)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to read the redis session store password: %w", err)
+	}
+	var setup [][]string
+	if password != "" {
+		if redisConf.Username != "" {
+			setup = append(setup, []string{"AUTH", redisConf.Username, password})
+		} else {
+			setup = append(setup, []string{"AUTH", password})
+		}
+	}
+	if redisConf.Database != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(redisConf.Database)})
+	}
+	for _, args := range setup {
+		if _, err := c.do(s.deadline(ctx), args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("unable to set up the redis session store connection: %w", err)
+		}
+	}
+	return c, nil
+}
+
+func (c *redisConn) do(deadline time.Time, args ...string) (any, error) {
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readRedisReply(c.reader)
+}
+
+func readRedisReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk reply [%s]", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array reply [%s]", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		items := make([]any, 0, size)
+		for range size {
+			item, err := readRedisReply(r)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply [%s]", line)
+	}
+}
+
+// escapeRedisPattern escapes the glob characters of a SCAN pattern.
+func escapeRedisPattern(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`*?[]\`, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
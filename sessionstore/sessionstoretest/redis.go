@@ -0,0 +1,195 @@
+// Package sessionstoretest provides a local stand-in of a Redis server for the tests of the redis session store.
+package sessionstoretest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type redisEntry struct {
+	expiresOn time.Time
+	value     string
+}
+
+// RedisServer is an in-process server implementing the subset of the Redis protocol used by the
+// redis session store: PING, AUTH, SELECT, GET, SET (with PX), DEL and SCAN. It is closed with the test.
+type RedisServer struct {
+	data     map[string]redisEntry
+	listener net.Listener
+	lock     sync.Mutex
+	password string
+}
+
+// NewRedisServer starts a stand-in Redis server listening on a random local port. A non empty
+// password is required with AUTH before any other command.
+func NewRedisServer(t testing.TB, password string) *RedisServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to start the stand-in redis server: %v", err)
+	}
+	s := &RedisServer{data: map[string]redisEntry{}, listener: listener, password: password}
+	t.Cleanup(func() { listener.Close() })
+	go s.serve()
+	return s
+}
+
+// Addr returns the address the server is listening on.
+func (s *RedisServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Keys returns the keys stored in the server, including any key prefix.
+func (s *RedisServer) Keys() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *RedisServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *RedisServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := s.password == ""
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(args[0])
+		var reply string
+		switch {
+		case command == "AUTH":
+			if args[len(args)-1] == s.password {
+				authenticated = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid username-password pair\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		default:
+			reply = s.execute(command, args[1:])
+		}
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func (s *RedisServer) execute(command string, args []string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now()
+	for key, entry := range s.data {
+		if !entry.expiresOn.IsZero() && !now.Before(entry.expiresOn) {
+			delete(s.data, key)
+		}
+	}
+
+	switch command {
+	case "PING", "SELECT":
+		return "+OK\r\n"
+	case "GET":
+		entry, found := s.data[args[0]]
+		if !found {
+			return "$-1\r\n"
+		}
+		return bulk(entry.value)
+	case "SET":
+		entry := redisEntry{value: args[1]}
+		if len(args) == 4 && strings.ToUpper(args[2]) == "PX" {
+			ms, err := strconv.ParseInt(args[3], 10, 64)
+			if err != nil || ms <= 0 {
+				return "-ERR invalid expire time in 'set' command\r\n"
+			}
+			entry.expiresOn = now.Add(time.Duration(ms) * time.Millisecond)
+		}
+		s.data[args[0]] = entry
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args {
+			if _, found := s.data[key]; found {
+				delete(s.data, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "SCAN":
+		// All the keys are returned in a single page.
+		pattern := "*"
+		for i := 1; i+1 < len(args); i += 2 {
+			if strings.ToUpper(args[i]) == "MATCH" {
+				pattern = args[i+1]
+			}
+		}
+		var b strings.Builder
+		var keys []string
+		for key := range s.data {
+			if matched, _ := path.Match(pattern, key); matched {
+				keys = append(keys, key)
+			}
+		}
+		fmt.Fprintf(&b, "*2\r\n%s*%d\r\n", bulk("0"), len(keys))
+		for _, key := range keys {
+			b.WriteString(bulk(key))
+		}
+		return b.String()
+	default:
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", command)
+	}
+}
+
+func bulk(s string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(s), s)
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf("invalid command [%s]", line)
+	}
+	args := make([]string, 0, count)
+	for range count {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument [%s]", line)
+		}
+		arg := make([]byte, size+2)
+		if _, err := io.ReadFull(r, arg); err != nil {
+			return nil, err
+		}
+		args = append(args, string(arg[:size]))
+	}
+	return args, nil
+}
@@ -0,0 +1,58 @@
+// Package sessionstore provides the key value stores where Kiali keeps session-scoped state.
+// The memory store keeps the state in the replica. The file and redis stores keep it out of the
+// replicas so that it can be shared by all of them when Kiali runs behind a load balancer.
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kiali/kiali/config"
+)
+
+// ErrNotFound is returned when a key does not exist in the store or has expired.
+var ErrNotFound = errors.New("session store key not found")
+
+// Store is a key value store for session-scoped state. Implementations must be safe for concurrent use.
+type Store interface {
+	// Delete removes the keys from the store. Missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+
+	// Get returns the value of the key, or ErrNotFound if the key does not exist or has expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Keys returns the keys of the store starting with the prefix, in no particular order.
+	Keys(ctx context.Context, prefix string) ([]string, error)
+
+	// Set stores the value of the key. The key expires after the ttl; a zero ttl never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// New returns the store configured in session_store.
+func New(conf *config.Config) (Store, error) {
+	switch conf.SessionStore.Type {
+	case config.SessionStoreTypeMemory, "":
+		return NewMemoryStore(), nil
+	case config.SessionStoreTypeFile:
+		return NewFileStore(conf.SessionStore.File.Path)
+	case config.SessionStoreTypeRedis:
+		return NewRedisStore(conf), nil
+	default:
+		return nil, fmt.Errorf("unknown session store type [%s]", conf.SessionStore.Type)
+	}
+}
+
+// expired returns true if a value expiring on expiresOn is gone at now. A zero expiresOn never expires.
+func expired(expiresOn, now time.Time) bool {
+	return !expiresOn.IsZero() && !now.Before(expiresOn)
+}
+
+// expiresOn returns when a value stored at now with the ttl expires. A zero ttl never expires.
+func expiresOn(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
@@ -0,0 +1,140 @@
+package sessionstore_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/sessionstore"
+	"github.com/kiali/kiali/sessionstore/sessionstoretest"
+	"github.com/kiali/kiali/util"
+)
+
+func TestMain(m *testing.M) {
+	util.Clock = util.RealClock{}
+	os.Exit(m.Run())
+}
+
+func newRedisTestConfig(t *testing.T, password string) *config.Config {
+	server := sessionstoretest.NewRedisServer(t, password)
+	conf := config.NewConfig()
+	conf.SessionStore.Type = config.SessionStoreTypeRedis
+	conf.SessionStore.Redis.Address = server.Addr()
+	conf.SessionStore.Redis.Password = config.Credential(password)
+	return conf
+}
+
+func testStores(t *testing.T) map[string]sessionstore.Store {
+	fileStore, err := sessionstore.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	return map[string]sessionstore.Store{
+		"memory": sessionstore.NewMemoryStore(),
+		"file":   fileStore,
+		"redis":  sessionstore.NewRedisStore(newRedisTestConfig(t, "secret")),
+	}
+}
+
+func TestStoreSetGetDelete(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			_, err := store.Get(ctx, "session:missing")
+			require.ErrorIs(err, sessionstore.ErrNotFound)
+
+			require.NoError(store.Set(ctx, "session:a", []byte("first"), 0))
+			require.NoError(store.Set(ctx, "session:b", []byte("second\r\nline"), time.Hour))
+			require.NoError(store.Set(ctx, "ai:a", []byte("other"), 0))
+
+			value, err := store.Get(ctx, "session:b")
+			require.NoError(err)
+			require.Equal("second\r\nline", string(value))
+
+			keys, err := store.Keys(ctx, "session:")
+			require.NoError(err)
+			assert.ElementsMatch(t, []string{"session:a", "session:b"}, keys)
+
+			require.NoError(store.Set(ctx, "session:a", []byte("replaced"), 0))
+			value, err = store.Get(ctx, "session:a")
+			require.NoError(err)
+			require.Equal("replaced", string(value))
+
+			require.NoError(store.Delete(ctx, "session:a", "session:missing"))
+			_, err = store.Get(ctx, "session:a")
+			require.ErrorIs(err, sessionstore.ErrNotFound)
+		})
+	}
+}
+
+func TestStoreExpiresKeys(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			require.NoError(t, store.Set(ctx, "session:short", []byte("value"), 20*time.Millisecond))
+			require.NoError(t, store.Set(ctx, "session:long", []byte("value"), time.Hour))
+
+			require.Eventually(t, func() bool {
+				_, err := store.Get(ctx, "session:short")
+				return err == sessionstore.ErrNotFound
+			}, 5*time.Second, 10*time.Millisecond)
+
+			keys, err := store.Keys(ctx, "session:")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"session:long"}, keys)
+		})
+	}
+}
+
+func TestFileStoreIsSharedByInstances(t *testing.T) {
+	dir := t.TempDir()
+	first, err := sessionstore.NewFileStore(dir)
+	require.NoError(t, err)
+	second, err := sessionstore.NewFileStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, first.Set(context.Background(), "session:a", []byte("value"), 0))
+	value, err := second.Get(context.Background(), "session:a")
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(value))
+}
+
+func TestRedisStoreUsesKeyPrefix(t *testing.T) {
+	server := sessionstoretest.NewRedisServer(t, "")
+	conf := config.NewConfig()
+	conf.SessionStore.Redis.Address = server.Addr()
+	conf.SessionStore.Redis.KeyPrefix = "kiali-east:"
+	store := sessionstore.NewRedisStore(conf)
+
+	require.NoError(t, store.Set(context.Background(), "session:*", []byte("value"), 0))
+	assert.Equal(t, []string{"kiali-east:session:*"}, server.Keys())
+
+	keys, err := store.Keys(context.Background(), "session:*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"session:*"}, keys)
+}
+
+func TestRedisStoreRejectsWrongPassword(t *testing.T) {
+	conf := newRedisTestConfig(t, "secret")
+	conf.SessionStore.Redis.Password = "wrong"
+
+	_, err := sessionstore.NewRedisStore(conf).Get(context.Background(), "session:a")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, sessionstore.ErrNotFound)
+}
+
+func TestNewReturnsConfiguredStore(t *testing.T) {
+	conf := config.NewConfig()
+	store, err := sessionstore.New(conf)
+	require.NoError(t, err)
+	assert.Equal(t, sessionstore.NewMemoryStore(), store)
+
+	conf.SessionStore.Type = "etcd"
+	_, err = sessionstore.New(conf)
+	require.Error(t, err)
+}
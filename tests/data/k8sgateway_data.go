@@ -257,3 +257,101 @@ func CreateSharedNamespace(name string) models.Namespace {
 func CreateNamespaceWithLabels(name string, labelsMap map[string]string) models.Namespace {
 	return models.Namespace{Name: name, Labels: labelsMap}
 }
+
+func CreateTCPRoute(name string, namespace string, gateway string) *k8s_networking_v1.TCPRoute {
+	rt := k8s_networking_v1.TCPRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, createGatewayParentRef(gateway, namespace))
+	return &rt
+}
+
+func AddBackendRefToTCPRoute(name, namespace string, rt *k8s_networking_v1.TCPRoute) *k8s_networking_v1.TCPRoute {
+	rt.Spec.Rules = append(rt.Spec.Rules, k8s_networking_v1.TCPRouteRule{BackendRefs: []k8s_networking_v1.BackendRef{createServiceBackendRef(name, namespace)}})
+	return rt
+}
+
+func CreateTLSRoute(name string, namespace string, gateway string, hosts []string) *k8s_networking_v1.TLSRoute {
+	rt := k8s_networking_v1.TLSRoute{}
+	rt.Name = name
+	rt.Namespace = namespace
+	rt.Spec.ParentRefs = append(rt.Spec.ParentRefs, createGatewayParentRef(gateway, namespace))
+	for _, host := range hosts {
+		rt.Spec.Hostnames = append(rt.Spec.Hostnames, k8s_networking_v1.Hostname(host))
+	}
+	return &rt
+}
+
+func AddBackendRefToTLSRoute(name, namespace string, rt *k8s_networking_v1.TLSRoute) *k8s_networking_v1.TLSRoute {
+	rt.Spec.Rules = append(rt.Spec.Rules, k8s_networking_v1.TLSRouteRule{BackendRefs: []k8s_networking_v1.BackendRef{createServiceBackendRef(name, namespace)}})
+	return rt
+}
+
+func createGatewayParentRef(name, namespace string) k8s_networking_v1.ParentReference {
+	ns := k8s_networking_v1.Namespace(namespace)
+	group := k8s_networking_v1.Group(kubernetes.K8sGateways.Group)
+	kind := k8s_networking_v1.Kind(kubernetes.K8sGateways.Kind)
+	return k8s_networking_v1.ParentReference{
+		Name:      k8s_networking_v1.ObjectName(name),
+		Namespace: &ns,
+		Group:     &group,
+		Kind:      &kind}
+}
+
+func createServiceBackendRef(name, namespace string) k8s_networking_v1.BackendRef {
+	kind := k8s_networking_v1.Kind(kubernetes.ServiceType)
+	var ns k8s_networking_v1.Namespace
+	if namespace != "" {
+		ns = k8s_networking_v1.Namespace(namespace)
+	}
+	return k8s_networking_v1.BackendRef{
+		BackendObjectReference: k8s_networking_v1.BackendObjectReference{
+			Kind:      &kind,
+			Name:      k8s_networking_v1.ObjectName(name),
+			Namespace: &ns,
+		},
+	}
+}
+
+func CreateListenerSet(name string, namespace string, gateway string, gatewayNamespace string) *k8s_networking_v1.ListenerSet {
+	ls := k8s_networking_v1.ListenerSet{}
+	ls.Name = name
+	ls.Namespace = namespace
+	ns := k8s_networking_v1.Namespace(gatewayNamespace)
+	ls.Spec.ParentRef = k8s_networking_v1.ParentGatewayReference{
+		Name:      k8s_networking_v1.ObjectName(gateway),
+		Namespace: &ns,
+	}
+	return &ls
+}
+
+func AddListenerToListenerSet(listener k8s_networking_v1.Listener, ls *k8s_networking_v1.ListenerSet) *k8s_networking_v1.ListenerSet {
+	ls.Spec.Listeners = append(ls.Spec.Listeners, k8s_networking_v1.ListenerEntry(listener))
+	return ls
+}
+
+func AllowListenersToK8sGateway(from k8s_networking_v1.FromNamespaces, gw *k8s_networking_v1.Gateway) *k8s_networking_v1.Gateway {
+	gw.Spec.AllowedListeners = &k8s_networking_v1.AllowedListeners{
+		Namespaces: &k8s_networking_v1.ListenerNamespaces{From: &from},
+	}
+	return gw
+}
+
+func CreateBackendTLSPolicy(name string, namespace string, service string, sectionName string) *k8s_networking_v1.BackendTLSPolicy {
+	policy := k8s_networking_v1.BackendTLSPolicy{}
+	policy.Name = name
+	policy.Namespace = namespace
+	targetRef := k8s_networking_v1.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: k8s_networking_v1.LocalPolicyTargetReference{
+			Kind: k8s_networking_v1.Kind(kubernetes.ServiceType),
+			Name: k8s_networking_v1.ObjectName(service),
+		},
+	}
+	if sectionName != "" {
+		section := k8s_networking_v1.SectionName(sectionName)
+		targetRef.SectionName = &section
+	}
+	policy.Spec.TargetRefs = append(policy.Spec.TargetRefs, targetRef)
+	policy.Spec.Validation.Hostname = k8s_networking_v1.PreciseHostname(service)
+	return &policy
+}
@@ -0,0 +1,20 @@
+package context
+
+import (
+	"context"
+)
+
+var ContextKeyAPITokenID contextKey = "apiTokenID"
+
+// SetAPITokenIDContext stores the ID of the API token authenticating the request, for audit purposes
+func SetAPITokenIDContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ContextKeyAPITokenID, id)
+}
+
+// GetAPITokenIDContext retrieves the ID of the API token authenticating the request, empty if none
+func GetAPITokenIDContext(ctx context.Context) string {
+	if id, ok := ctx.Value(ContextKeyAPITokenID).(string); ok {
+		return id
+	}
+	return ""
+}